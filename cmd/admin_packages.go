@@ -0,0 +1,129 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	sbom_service "code.gitea.io/gitea/services/packages/sbom"
+	signing_service "code.gitea.io/gitea/services/packages/signing"
+
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	subcmdPackages = &cli.Command{
+		Name:  "packages",
+		Usage: "Manage package registries",
+		Commands: []*cli.Command{
+			microcmdPackagesResign(),
+			microcmdPackagesImportVulnerabilities(),
+		},
+	}
+)
+
+func microcmdPackagesResign() *cli.Command {
+	return &cli.Command{
+		Name:  "resign",
+		Usage: "Sign the repository files of the Alpine, Debian and RPM registries with the current keys",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "owner",
+				Usage: "Only sign the repository files of this user or organization",
+			},
+			&cli.StringSliceFlag{
+				Name:  "type",
+				Usage: "Only sign the repository files of this package type (alpine, debian, rpm), can be repeated",
+			},
+		},
+		Action: runPackagesResign,
+	}
+}
+
+func runPackagesResign(ctx context.Context, c *cli.Command) error {
+	if !setting.IsInTesting {
+		if err := initDB(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := storage.Init(); err != nil {
+		return err
+	}
+
+	opts := signing_service.ResignOptions{
+		LogDetail: func(format string, v ...any) {
+			fmt.Printf(format+"\n", v...)
+		},
+	}
+
+	if c.IsSet("owner") {
+		owner, err := user_model.GetUserByName(ctx, c.String("owner"))
+		if err != nil {
+			return err
+		}
+		opts.OwnerID = owner.ID
+	}
+
+	for _, t := range c.StringSlice("type") {
+		opts.Types = append(opts.Types, packages_model.Type(t))
+	}
+
+	return signing_service.ResignRepositoryFiles(ctx, opts)
+}
+
+func microcmdPackagesImportVulnerabilities() *cli.Command {
+	return &cli.Command{
+		Name:  "import-vulnerabilities",
+		Usage: "Import an OSV vulnerability database file used to scan the dependencies of packages",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "file",
+				Usage:    "Path of the OSV database file, a zip archive or JSON",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "scan",
+				Usage: "Scan all packages after the import",
+			},
+		},
+		Action: runPackagesImportVulnerabilities,
+	}
+}
+
+func runPackagesImportVulnerabilities(ctx context.Context, c *cli.Command) error {
+	if !setting.IsInTesting {
+		if err := initDB(ctx); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(c.String("file"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	count, err := sbom_service.ImportDatabase(ctx, f, fi.Size())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d advisories\n", count)
+
+	if c.Bool("scan") {
+		return sbom_service.ScanPackages(ctx)
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	actions_module "code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/log"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	"code.gitea.io/gitea/modules/setting"
@@ -179,7 +180,7 @@ func migratePackages(ctx context.Context, dstStorage storage.ObjectStorage) erro
 }
 
 func migrateActionsLog(ctx context.Context, dstStorage storage.ObjectStorage) error {
-	return db.Iterate(ctx, nil, func(ctx context.Context, task *actions_model.ActionTask) error {
+	if err := db.Iterate(ctx, nil, func(ctx context.Context, task *actions_model.ActionTask) error {
 		if task.LogExpired {
 			// the log has been cleared
 			return nil
@@ -191,6 +192,18 @@ func migrateActionsLog(ctx context.Context, dstStorage storage.ObjectStorage) er
 		p := task.LogFilename
 		_, err := storage.Copy(dstStorage, p, storage.Actions, p)
 		return err
+	}); err != nil {
+		return err
+	}
+
+	// the summaries of the steps and their rendered HTML are stored alongside the logs
+	return db.Iterate(ctx, nil, func(ctx context.Context, summary *actions_model.ActionTaskSummary) error {
+		for _, p := range []string{summary.Filename, actions_module.SummaryHTMLFilename(summary.Filename)} {
+			if _, err := storage.Copy(dstStorage, p, storage.Actions, p); err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 }
 
@@ -0,0 +1,157 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ApprovalState is the state of an approval gate
+type ApprovalState int
+
+const (
+	ApprovalStateWaiting  ApprovalState = iota // 0 waiting for a user to approve or reject
+	ApprovalStateApproved                      // 1 approved by a user
+	ApprovalStateRejected                      // 2 rejected by a user
+	ApprovalStateTimedOut                      // 3 nobody reviewed it before the deadline
+)
+
+var approvalStateNames = map[ApprovalState]string{
+	ApprovalStateWaiting:  "waiting",
+	ApprovalStateApproved: "approved",
+	ApprovalStateRejected: "rejected",
+	ApprovalStateTimedOut: "timed_out",
+}
+
+// String returns the string name of the approval state
+func (s ApprovalState) String() string {
+	return approvalStateNames[s]
+}
+
+// JobStatus returns the status the approval gate job should be changed to
+func (s ApprovalState) JobStatus() Status {
+	switch s {
+	case ApprovalStateApproved:
+		return StatusSuccess
+	case ApprovalStateRejected:
+		return StatusFailure
+	case ApprovalStateTimedOut:
+		return StatusCancelled
+	}
+	return StatusBlocked
+}
+
+// ActionApproval represents an approval gate of a job.
+// A new approval is created for every attempt of the job, so the approvals are the audit log of who approved or rejected the gates.
+type ActionApproval struct {
+	ID       int64         `xorm:"pk autoincr"`
+	RepoID   int64         `xorm:"index NOT NULL"`
+	RunID    int64         `xorm:"index NOT NULL"`
+	RunJobID int64         `xorm:"UNIQUE(job_attempt) NOT NULL"`
+	RunJob   *ActionRunJob `xorm:"-"`
+	Attempt  int64         `xorm:"UNIQUE(job_attempt) NOT NULL"`
+	Message  string        `xorm:"TEXT"`
+
+	State         ApprovalState    `xorm:"index NOT NULL DEFAULT 0"`
+	ReviewerID    int64            `xorm:"NOT NULL DEFAULT 0"`
+	Reviewer      *user_model.User `xorm:"-"`
+	ReviewComment string           `xorm:"TEXT"`
+	Reviewed      timeutil.TimeStamp
+	// Deadline is the time when the gate times out if nobody reviews it
+	Deadline timeutil.TimeStamp `xorm:"index"`
+
+	Created timeutil.TimeStamp `xorm:"created"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionApproval))
+}
+
+// Status returns the status of the approval gate, which is the status of its job
+func (a *ActionApproval) Status() Status {
+	if a.RunJob == nil {
+		return StatusUnknown
+	}
+	return a.RunJob.Status
+}
+
+// LoadAttributes loads the job and the reviewer of the approval
+func (a *ActionApproval) LoadAttributes(ctx context.Context) error {
+	if a.RunJob == nil {
+		job, err := GetRunJobByRunAndID(ctx, a.RunID, a.RunJobID)
+		if err != nil {
+			return err
+		}
+		a.RunJob = job
+	}
+	if a.Reviewer == nil && a.ReviewerID > 0 {
+		reviewer, err := user_model.GetPossibleUserByID(ctx, a.ReviewerID)
+		if err != nil {
+			return err
+		}
+		a.Reviewer = reviewer
+	}
+	return nil
+}
+
+type FindApprovalsOptions struct {
+	db.ListOptions
+	RepoID         int64
+	RunID          int64
+	RunJobID       int64
+	State          optional.Option[ApprovalState]
+	DeadlineBefore timeutil.TimeStamp
+}
+
+func (opts FindApprovalsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.RunID > 0 {
+		cond = cond.And(builder.Eq{"run_id": opts.RunID})
+	}
+	if opts.RunJobID > 0 {
+		cond = cond.And(builder.Eq{"run_job_id": opts.RunJobID})
+	}
+	if opts.State.Has() {
+		cond = cond.And(builder.Eq{"state": opts.State.Value()})
+	}
+	if opts.DeadlineBefore > 0 {
+		cond = cond.And(builder.Lt{"deadline": opts.DeadlineBefore})
+	}
+	return cond
+}
+
+func (opts FindApprovalsOptions) ToOrders() string {
+	return "`id` DESC"
+}
+
+// GetApprovalByJobAttempt returns the approval of an attempt of a job
+func GetApprovalByJobAttempt(ctx context.Context, runJobID, attempt int64) (*ActionApproval, error) {
+	var approval ActionApproval
+	has, err := db.GetEngine(ctx).Where("run_job_id=? AND attempt=?", runJobID, attempt).Get(&approval)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("approval of job %d attempt %d: %w", runJobID, attempt, util.ErrNotExist)
+	}
+	return &approval, nil
+}
+
+// UpdateApprovalIfWaiting updates the approval if it's still waiting, it returns false if it has been reviewed or timed out concurrently
+func UpdateApprovalIfWaiting(ctx context.Context, approval *ActionApproval, cols ...string) (bool, error) {
+	n, err := db.GetEngine(ctx).ID(approval.ID).Where(builder.Eq{"state": ApprovalStateWaiting}).Cols(cols...).Update(approval)
+	return n == 1, err
+}
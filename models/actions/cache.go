@@ -0,0 +1,149 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionCache is a cache entry of actions/cache, it is scoped by the repository and the ref which created it.
+// A run could restore the caches of its own ref, and the caches of the refs it falls back to,
+// such as the base branch of a pull request and the default branch.
+type ActionCache struct {
+	ID       int64  `xorm:"pk autoincr"`
+	RepoID   int64  `xorm:"index NOT NULL"`
+	Ref      string `xorm:"VARCHAR(255) index NOT NULL"`
+	CacheKey string `xorm:"VARCHAR(512) NOT NULL"`
+	Version  string `xorm:"VARCHAR(255) NOT NULL"`
+	RunID    int64  `xorm:"NOT NULL DEFAULT 0"` // the run which created the cache
+	Size     int64  `xorm:"NOT NULL DEFAULT 0"`
+	// ReservedSize is the size declared when the cache was reserved, 0 if it is unknown
+	ReservedSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	StoragePath  string
+	// Complete is false before the upload of the cache is finalized, incomplete caches couldn't be restored
+	Complete bool               `xorm:"index NOT NULL DEFAULT FALSE"`
+	LastUsed timeutil.TimeStamp `xorm:"index"` // the last time the cache was created or restored, the least recently used caches are evicted first
+	Created  timeutil.TimeStamp `xorm:"created"`
+	Updated  timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionCache))
+}
+
+type FindCachesOptions struct {
+	db.ListOptions
+	RepoID         int64
+	Refs           []string
+	CacheKey       string
+	Version        string
+	Complete       optional.Option[bool]
+	LastUsedBefore timeutil.TimeStamp
+	CreatedBefore  timeutil.TimeStamp
+	OrderBy        string // defaults to "`id` DESC"
+}
+
+func (opts FindCachesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if len(opts.Refs) > 0 {
+		cond = cond.And(builder.In("ref", opts.Refs))
+	}
+	if opts.CacheKey != "" {
+		cond = cond.And(builder.Eq{"cache_key": opts.CacheKey})
+	}
+	if opts.Version != "" {
+		cond = cond.And(builder.Eq{"version": opts.Version})
+	}
+	if opts.Complete.Has() {
+		cond = cond.And(builder.Eq{"complete": opts.Complete.Value()})
+	}
+	if opts.LastUsedBefore > 0 {
+		cond = cond.And(builder.Lt{"last_used": opts.LastUsedBefore})
+	}
+	if opts.CreatedBefore > 0 {
+		cond = cond.And(builder.Lt{"created": opts.CreatedBefore})
+	}
+	return cond
+}
+
+func (opts FindCachesOptions) ToOrders() string {
+	if opts.OrderBy != "" {
+		return opts.OrderBy
+	}
+	return "`id` DESC"
+}
+
+// GetCacheByID returns the cache by its id
+func GetCacheByID(ctx context.Context, id int64) (*ActionCache, error) {
+	var cache ActionCache
+	has, err := db.GetEngine(ctx).ID(id).Get(&cache)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("cache with id %d: %w", id, util.ErrNotExist)
+	}
+	return &cache, nil
+}
+
+// GetCacheByRepoAndID returns the cache of a repository by its id
+func GetCacheByRepoAndID(ctx context.Context, repoID, id int64) (*ActionCache, error) {
+	var cache ActionCache
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND id=?", repoID, id).Get(&cache)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("cache with id %d: %w", id, util.ErrNotExist)
+	}
+	return &cache, nil
+}
+
+// UpdateCache updates the cache
+func UpdateCache(ctx context.Context, cache *ActionCache, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(cache.ID).Cols(cols...).Update(cache)
+	return err
+}
+
+// UpdateCacheLastUsed marks the cache as used now
+func UpdateCacheLastUsed(ctx context.Context, cache *ActionCache) error {
+	cache.LastUsed = timeutil.TimeStampNow()
+	return UpdateCache(ctx, cache, "last_used")
+}
+
+// GetCacheSizeOfRepo returns the total size of the caches of a repository
+func GetCacheSizeOfRepo(ctx context.Context, repoID int64) (int64, error) {
+	return db.GetEngine(ctx).Where("repo_id=?", repoID).SumInt(new(ActionCache), "size")
+}
+
+// CacheUsage is the total size of the caches of a repository
+type CacheUsage struct {
+	RepoID int64
+	Size   int64
+}
+
+// GetCacheUsagesExceedingSize returns the cache usages of the repositories whose caches exceed the size
+func GetCacheUsagesExceedingSize(ctx context.Context, size int64) ([]*CacheUsage, error) {
+	usages := make([]*CacheUsage, 0, 10)
+	return usages, db.GetEngine(ctx).Table("action_cache").
+		Select("repo_id, SUM(size) AS size").
+		GroupBy("repo_id").
+		Having(fmt.Sprintf("SUM(size) > %d", size)).
+		Find(&usages)
+}
+
+// DeleteCacheByID deletes the record of the cache, the file in storage should be deleted by the caller
+func DeleteCacheByID(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[ActionCache](ctx, id)
+	return err
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// DeploymentReviewState is the review state of a deployment
+type DeploymentReviewState int
+
+const (
+	DeploymentReviewStateNone     DeploymentReviewState = iota // 0 no review is required
+	DeploymentReviewStateWaiting                               // 1 waiting for a required reviewer
+	DeploymentReviewStateApproved                              // 2 approved by a required reviewer
+	DeploymentReviewStateRejected                              // 3 rejected by a required reviewer
+)
+
+var deploymentReviewStateNames = map[DeploymentReviewState]string{
+	DeploymentReviewStateNone:     "none",
+	DeploymentReviewStateWaiting:  "waiting",
+	DeploymentReviewStateApproved: "approved",
+	DeploymentReviewStateRejected: "rejected",
+}
+
+// String returns the string name of the review state
+func (s DeploymentReviewState) String() string {
+	return deploymentReviewStateNames[s]
+}
+
+// ActionDeployment represents a deployment of a job to an environment.
+// A new deployment is created for every attempt of the job, so the deployments of an environment are its deployment history.
+type ActionDeployment struct {
+	ID            int64         `xorm:"pk autoincr"`
+	RepoID        int64         `xorm:"index NOT NULL"`
+	EnvironmentID int64         `xorm:"index NOT NULL"`
+	RunID         int64         `xorm:"index NOT NULL"`
+	RunJobID      int64         `xorm:"UNIQUE(job_attempt) NOT NULL"`
+	RunJob        *ActionRunJob `xorm:"-"`
+	Attempt       int64         `xorm:"UNIQUE(job_attempt) NOT NULL"`
+	Ref           string        `xorm:"VARCHAR(255)"`
+	CommitSHA     string        `xorm:"VARCHAR(64)"`
+	URL           string        `xorm:"TEXT"` // the "url" of the job's environment
+	CreatorID     int64         `xorm:"NOT NULL DEFAULT 0"`
+
+	ReviewState   DeploymentReviewState `xorm:"NOT NULL DEFAULT 0"`
+	ReviewerID    int64                 `xorm:"NOT NULL DEFAULT 0"`
+	Reviewer      *user_model.User      `xorm:"-"`
+	ReviewComment string                `xorm:"TEXT"`
+	Reviewed      timeutil.TimeStamp
+
+	Created timeutil.TimeStamp `xorm:"created"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionDeployment))
+}
+
+// Status returns the status of the deployment, which is the status of its job
+func (d *ActionDeployment) Status() Status {
+	if d.RunJob == nil {
+		return StatusUnknown
+	}
+	return d.RunJob.Status
+}
+
+// LoadAttributes loads the job and the reviewer of the deployment
+func (d *ActionDeployment) LoadAttributes(ctx context.Context) error {
+	if d.RunJob == nil {
+		job, err := GetRunJobByRunAndID(ctx, d.RunID, d.RunJobID)
+		if err != nil {
+			return err
+		}
+		d.RunJob = job
+	}
+	if d.Reviewer == nil && d.ReviewerID > 0 {
+		reviewer, err := user_model.GetPossibleUserByID(ctx, d.ReviewerID)
+		if err != nil {
+			return err
+		}
+		d.Reviewer = reviewer
+	}
+	return nil
+}
+
+type FindDeploymentsOptions struct {
+	db.ListOptions
+	RepoID         int64
+	EnvironmentIDs []int64
+	RunID          int64
+	ReviewState    DeploymentReviewState
+}
+
+func (opts FindDeploymentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if len(opts.EnvironmentIDs) > 0 {
+		cond = cond.And(builder.In("environment_id", opts.EnvironmentIDs))
+	}
+	if opts.RunID > 0 {
+		cond = cond.And(builder.Eq{"run_id": opts.RunID})
+	}
+	if opts.ReviewState != DeploymentReviewStateNone {
+		cond = cond.And(builder.Eq{"review_state": opts.ReviewState})
+	}
+	return cond
+}
+
+func (opts FindDeploymentsOptions) ToOrders() string {
+	return "`id` DESC"
+}
+
+// GetDeploymentByJobAttempt returns the deployment of an attempt of a job
+func GetDeploymentByJobAttempt(ctx context.Context, runJobID, attempt int64) (*ActionDeployment, error) {
+	var deployment ActionDeployment
+	has, err := db.GetEngine(ctx).Where("run_job_id=? AND attempt=?", runJobID, attempt).Get(&deployment)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("deployment of job %d attempt %d: %w", runJobID, attempt, util.ErrNotExist)
+	}
+	return &deployment, nil
+}
+
+// UpdateDeployment updates the deployment, the condition is used to avoid updating a deployment which has been reviewed concurrently
+func UpdateDeployment(ctx context.Context, deployment *ActionDeployment, cond builder.Cond, cols ...string) (int64, error) {
+	sess := db.GetEngine(ctx).ID(deployment.ID)
+	if cond != nil {
+		sess.Where(cond)
+	}
+	return sess.Cols(cols...).Update(deployment)
+}
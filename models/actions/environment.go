@@ -0,0 +1,166 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionEnvironment represents a deployment environment of a repository.
+// A job referencing an environment by "environment" can only access the secrets and variables of the environment
+// after the protection rules of the environment are satisfied.
+// An environment referenced by a job but not configured yet is created without protection rules when the job is going to start.
+type ActionEnvironment struct {
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"UNIQUE(repo_name) NOT NULL"`
+	Name      string `xorm:"NOT NULL"`
+	LowerName string `xorm:"UNIQUE(repo_name) NOT NULL"`
+
+	// BranchFilters and TagFilters are glob patterns of the branches and tags which could be deployed to the environment.
+	// All refs could be deployed if both of them are empty.
+	BranchFilters []string `xorm:"JSON TEXT"`
+	TagFilters    []string `xorm:"JSON TEXT"`
+
+	// RequiredReviewerIDs are the users who could approve the deployments, one approval is required if it is not empty.
+	RequiredReviewerIDs []int64 `xorm:"JSON TEXT"`
+	// PreventSelfReview prevents the user who triggered the run from approving its deployments.
+	PreventSelfReview bool `xorm:"NOT NULL DEFAULT FALSE"`
+
+	Created timeutil.TimeStamp `xorm:"created"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+const EnvironmentNameMaxLength = 255
+
+func init() {
+	db.RegisterModel(new(ActionEnvironment))
+}
+
+// IsRefAllowed returns whether the ref could be deployed to the environment
+func (env *ActionEnvironment) IsRefAllowed(ref string) bool {
+	if len(env.BranchFilters) == 0 && len(env.TagFilters) == 0 {
+		return true
+	}
+	refName := git.RefName(ref)
+	switch {
+	case refName.IsBranch():
+		return matchGlobPatterns(env.BranchFilters, refName.BranchName())
+	case refName.IsTag():
+		return matchGlobPatterns(env.TagFilters, refName.TagName())
+	}
+	return false
+}
+
+func matchGlobPatterns(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			log.Warn("Invalid glob pattern %q of environment: %v", pattern, err)
+			continue
+		}
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedReview returns whether the deployments to the environment need to be reviewed
+func (env *ActionEnvironment) NeedReview() bool {
+	return len(env.RequiredReviewerIDs) > 0
+}
+
+// IsRequiredReviewer returns whether the user could review the deployments to the environment
+func (env *ActionEnvironment) IsRequiredReviewer(userID int64) bool {
+	return slices.Contains(env.RequiredReviewerIDs, userID)
+}
+
+type FindEnvironmentsOptions struct {
+	db.ListOptions
+	IDs    []int64
+	RepoID int64
+	Name   string
+}
+
+func (opts FindEnvironmentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if len(opts.IDs) > 0 {
+		cond = cond.And(builder.In("id", opts.IDs))
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Name != "" {
+		cond = cond.And(builder.Eq{"lower_name": strings.ToLower(opts.Name)})
+	}
+	return cond
+}
+
+func (opts FindEnvironmentsOptions) ToOrders() string {
+	return "lower_name ASC"
+}
+
+// GetEnvironmentByRepoAndName returns the environment of a repository by its name, the name is case-insensitive
+func GetEnvironmentByRepoAndName(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	var env ActionEnvironment
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND lower_name=?", repoID, strings.ToLower(name)).Get(&env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("environment %q: %w", name, util.ErrNotExist)
+	}
+	return &env, nil
+}
+
+// GetEnvironmentByRepoAndID returns the environment of a repository by its id
+func GetEnvironmentByRepoAndID(ctx context.Context, repoID, id int64) (*ActionEnvironment, error) {
+	var env ActionEnvironment
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND id=?", repoID, id).Get(&env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("environment with id %d: %w", id, util.ErrNotExist)
+	}
+	return &env, nil
+}
+
+// InsertEnvironment inserts a new environment
+func InsertEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	env.LowerName = strings.ToLower(env.Name)
+	return db.Insert(ctx, env)
+}
+
+// UpdateEnvironment updates the environment
+func UpdateEnvironment(ctx context.Context, env *ActionEnvironment, cols ...string) error {
+	env.LowerName = strings.ToLower(env.Name)
+	_, err := db.GetEngine(ctx).ID(env.ID).Cols(cols...).Update(env)
+	return err
+}
+
+// DeleteEnvironment deletes the environment with its variables and deployment history,
+// the secrets of the environment should be deleted by the caller.
+func DeleteEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &ActionVariable{RepoID: env.RepoID, EnvironmentID: env.ID}); err != nil {
+			return err
+		}
+		if _, err := db.DeleteByBean(ctx, &ActionDeployment{EnvironmentID: env.ID}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[ActionEnvironment](ctx, env.ID)
+		return err
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionEnvironment_IsRefAllowed(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      *ActionEnvironment
+		ref      string
+		expected bool
+	}{
+		{
+			name:     "no filters",
+			env:      &ActionEnvironment{},
+			ref:      "refs/pull/1/head",
+			expected: true,
+		},
+		{
+			name:     "branch matched",
+			env:      &ActionEnvironment{BranchFilters: []string{"main", "release/*"}},
+			ref:      "refs/heads/release/v1.0",
+			expected: true,
+		},
+		{
+			name:     "branch not matched",
+			env:      &ActionEnvironment{BranchFilters: []string{"release/*"}},
+			ref:      "refs/heads/release/v1.0/fix",
+			expected: false,
+		},
+		{
+			name:     "tag without tag filters",
+			env:      &ActionEnvironment{BranchFilters: []string{"main"}},
+			ref:      "refs/tags/v1.0.0",
+			expected: false,
+		},
+		{
+			name:     "tag matched",
+			env:      &ActionEnvironment{TagFilters: []string{"v*"}},
+			ref:      "refs/tags/v1.0.0",
+			expected: true,
+		},
+		{
+			name:     "pull request with filters",
+			env:      &ActionEnvironment{BranchFilters: []string{"**"}},
+			ref:      "refs/pull/1/head",
+			expected: false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.env.IsRefAllowed(c.ref))
+		})
+	}
+}
@@ -41,6 +41,28 @@ type ActionRunJob struct {
 	TaskID int64    // the latest task of the job
 	Status Status   `xorm:"index"`
 
+	// RunsOnGroup is the name of the runner group required by `runs-on: {group: <name>}`, only the runners of the group could run the job.
+	RunsOnGroup string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+
+	// ParentJobID is the id of the job calling the reusable workflow which this job belongs to, it is 0 for the jobs of the run's workflow.
+	// The JobID and Needs of a job are only meaningful among the jobs with the same ParentJobID.
+	ParentJobID int64 `xorm:"index NOT NULL DEFAULT 0"`
+	// IsWorkflowCall is true if the job calls a reusable workflow by "uses", such a job is never picked up by runners,
+	// it is running when the jobs of the called workflow are started, and its result is aggregated from them.
+	IsWorkflowCall bool `xorm:"NOT NULL DEFAULT FALSE"`
+
+	// IsApprovalGate is true if the job is an approval gate declared by "approval", such a job is never picked up by runners,
+	// it pauses until a user approves or rejects it, see ActionApproval.
+	IsApprovalGate bool `xorm:"NOT NULL DEFAULT FALSE"`
+
+	// Queued is the time when the job started to wait for a runner, it is used to enforce the max queue time
+	Queued timeutil.TimeStamp `xorm:"index"`
+	// StopReason is the reason why the job has been stopped by the server, such as exceeding its timeout
+	StopReason JobStopReason `xorm:"NOT NULL DEFAULT 0"`
+
+	// Environment is the evaluated name of the deployment environment referenced by the job, it is empty if the job doesn't deploy to an environment.
+	Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+
 	RawConcurrency string // raw concurrency from job YAML's "concurrency" section
 
 	// IsConcurrencyEvaluated is only valid/needed when this job's RawConcurrency is not empty.
@@ -61,6 +83,12 @@ func init() {
 	db.RegisterModel(new(ActionRunJob))
 }
 
+// IsStartedByJobEmitter returns whether the job is always inserted as blocked and started by the job emitter after the checks on the server side,
+// such as the jobs calling reusable workflows, the jobs of the called workflows, the jobs deploying to environments and the approval gates.
+func (job *ActionRunJob) IsStartedByJobEmitter() bool {
+	return job.IsWorkflowCall || job.ParentJobID > 0 || job.Environment != "" || job.IsApprovalGate
+}
+
 func (job *ActionRunJob) Duration() time.Duration {
 	return calculateDuration(job.Started, job.Stopped, job.Status)
 }
@@ -153,6 +181,12 @@ func GetRunJobsByRunID(ctx context.Context, runID int64) (ActionJobList, error)
 func UpdateRunJob(ctx context.Context, job *ActionRunJob, cond builder.Cond, cols ...string) (int64, error) {
 	e := db.GetEngine(ctx)
 
+	if slices.Contains(cols, "status") && job.Status.IsWaiting() && !slices.Contains(cols, "queued") {
+		// the job starts to wait for a runner (again)
+		job.Queued = timeutil.TimeStampNow()
+		cols = append(cols, "queued")
+	}
+
 	sess := e.ID(job.ID)
 	if len(cols) > 0 {
 		sess.Cols(cols...)
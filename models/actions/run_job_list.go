@@ -75,6 +75,7 @@ type FindRunJobOptions struct {
 	CommitSHA        string
 	Statuses         []Status
 	UpdatedBefore    timeutil.TimeStamp
+	QueuedBefore     timeutil.TimeStamp
 	ConcurrencyGroup string
 }
 
@@ -95,6 +96,9 @@ func (opts FindRunJobOptions) ToConds() builder.Cond {
 	if opts.UpdatedBefore > 0 {
 		cond = cond.And(builder.Lt{"`action_run_job`.updated": opts.UpdatedBefore})
 	}
+	if opts.QueuedBefore > 0 {
+		cond = cond.And(builder.Gt{"`action_run_job`.queued": 0}, builder.Lt{"`action_run_job`.queued": opts.QueuedBefore})
+	}
 	if opts.ConcurrencyGroup != "" {
 		if opts.RepoID == 0 {
 			panic("Invalid FindRunJobOptions: repo_id is required")
@@ -0,0 +1,92 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// QueuedJobGroup is a group of the jobs of an owner which are waiting for runners with the same `runs-on` labels
+type QueuedJobGroup struct {
+	OwnerID int64
+	Owner   *user_model.User
+	// Labels are the sorted `runs-on` labels of the jobs
+	Labels []string
+	Count  int64
+	// OldestQueued is the time when the job which has been waiting longest was queued
+	OldestQueued timeutil.TimeStamp
+}
+
+// WaitSeconds returns how long the oldest job of the group has been waiting for a runner
+func (g *QueuedJobGroup) WaitSeconds() int64 {
+	return max(int64(timeutil.TimeStampNow()-g.OldestQueued), 0)
+}
+
+// GetQueuedJobGroups returns the jobs waiting for runners, grouped by their owners and `runs-on` labels.
+// The groups are sorted by owners, and the groups which have been waiting longest go first.
+func GetQueuedJobGroups(ctx context.Context, ownerID, repoID int64) ([]*QueuedJobGroup, error) {
+	jobs, err := db.Find[ActionRunJob](ctx, FindRunJobOptions{
+		OwnerID:  ownerID,
+		RepoID:   repoID,
+		Statuses: []Status{StatusWaiting},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type groupKey struct {
+		ownerID int64
+		labels  string
+	}
+	groups := make(map[groupKey]*QueuedJobGroup)
+	for _, job := range jobs {
+		labels := slices.Clone(job.RunsOn)
+		slices.Sort(labels)
+		labels = slices.Compact(labels)
+		// the jobs queued before the queued time was recorded have to fall back to the created time
+		queued := job.Queued
+		if queued == 0 {
+			queued = job.Created
+		}
+
+		key := groupKey{ownerID: job.OwnerID, labels: strings.Join(labels, "\n")}
+		group, ok := groups[key]
+		if !ok {
+			group = &QueuedJobGroup{OwnerID: job.OwnerID, Labels: labels, OldestQueued: queued}
+			groups[key] = group
+		}
+		group.Count++
+		group.OldestQueued = min(group.OldestQueued, queued)
+	}
+
+	ret := make([]*QueuedJobGroup, 0, len(groups))
+	ownerIDs := make([]int64, 0, len(groups))
+	for _, group := range groups {
+		ret = append(ret, group)
+		ownerIDs = append(ownerIDs, group.OwnerID)
+	}
+	owners, err := user_model.GetUsersMapByIDs(ctx, ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range ret {
+		group.Owner = owners[group.OwnerID]
+	}
+
+	slices.SortFunc(ret, func(a, b *QueuedJobGroup) int {
+		return cmp.Or(
+			cmp.Compare(a.OwnerID, b.OwnerID),
+			cmp.Compare(a.OldestQueued, b.OldestQueued),
+			slices.Compare(a.Labels, b.Labels),
+		)
+	})
+	return ret, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetQueuedJobGroups(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	newJob := func(runsOn []string, status Status, queued timeutil.TimeStamp) {
+		require.NoError(t, db.Insert(t.Context(), &ActionRunJob{
+			RunID:   1,
+			RepoID:  4,
+			OwnerID: 5,
+			Name:    "test",
+			JobID:   "test",
+			RunsOn:  runsOn,
+			Status:  status,
+			Queued:  queued,
+		}))
+	}
+	newJob([]string{"ubuntu-latest"}, StatusWaiting, now.AddDuration(-time.Minute))
+	newJob([]string{"linux", "gpu"}, StatusWaiting, now.AddDuration(-10*time.Minute))
+	newJob([]string{"gpu", "linux"}, StatusWaiting, now.AddDuration(-5*time.Minute))
+	newJob([]string{"gpu", "linux"}, StatusRunning, now.AddDuration(-30*time.Minute))
+
+	groups, err := GetQueuedJobGroups(t.Context(), 0, 4)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	// the jobs with the same labels in different orders are in the same group, and the group waiting longest goes first
+	assert.EqualValues(t, 5, groups[0].OwnerID)
+	assert.Equal(t, "user5", groups[0].Owner.Name)
+	assert.Equal(t, []string{"gpu", "linux"}, groups[0].Labels)
+	assert.EqualValues(t, 2, groups[0].Count)
+	assert.Equal(t, now.AddDuration(-10*time.Minute), groups[0].OldestQueued)
+	assert.GreaterOrEqual(t, groups[0].WaitSeconds(), int64(600))
+
+	assert.Equal(t, []string{"ubuntu-latest"}, groups[1].Labels)
+	assert.EqualValues(t, 1, groups[1].Count)
+
+	ownerGroups, err := GetQueuedJobGroups(t.Context(), 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, groups, ownerGroups)
+}
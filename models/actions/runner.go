@@ -64,6 +64,9 @@ type ActionRunner struct {
 	Ephemeral bool `xorm:"ephemeral NOT NULL DEFAULT false"`
 	// Store if this runner is disabled and should not pick up new jobs
 	IsDisabled bool `xorm:"is_disabled NOT NULL DEFAULT false"`
+	// GroupID is the runner group of a user/org level runner, 0 means the default group
+	GroupID int64              `xorm:"index NOT NULL DEFAULT 0"`
+	Group   *ActionRunnerGroup `xorm:"-"`
 
 	Created timeutil.TimeStamp `xorm:"created"`
 	Updated timeutil.TimeStamp `xorm:"updated"`
@@ -168,9 +171,33 @@ func (r *ActionRunner) LoadAttributes(ctx context.Context) error {
 			r.Repo = &repo
 		}
 	}
+	return r.LoadGroup(ctx)
+}
+
+// LoadGroup loads the runner group of the runner, it is nil if the runner is in the default group
+func (r *ActionRunner) LoadGroup(ctx context.Context) error {
+	if r.GroupID == 0 || r.Group != nil {
+		return nil
+	}
+	var group ActionRunnerGroup
+	has, err := db.GetEngine(ctx).ID(r.GroupID).Get(&group)
+	if err != nil {
+		return err
+	}
+	if has {
+		r.Group = &group
+	}
 	return nil
 }
 
+// GroupName returns the name of the runner group, it is empty for the default group
+func (r *ActionRunner) GroupName() string {
+	if r.Group == nil {
+		return ""
+	}
+	return r.Group.Name
+}
+
 func (r *ActionRunner) GenerateToken() (err error) {
 	r.Token, r.TokenSalt, r.TokenHash, _, err = generateSaltedToken()
 	return err
@@ -183,6 +210,17 @@ func (r *ActionRunner) CanMatchLabels(jobRunsOn []string) bool {
 	return runnerLabelSet.Contains(jobRunsOn...) // match all labels
 }
 
+// CanMatchGroup checks whether the runner belongs to the runner group required by a job's `runs-on: {group: <name>}`,
+// any runner matches if the job doesn't require a group. The group should be loaded.
+func (r *ActionRunner) CanMatchGroup(jobRunsOnGroup string) bool {
+	return jobRunsOnGroup == "" || (r.Group != nil && r.Group.Name == jobRunsOnGroup)
+}
+
+// CanRun checks whether the runner group allows the runner to run the jobs of the run, the group should be loaded
+func (r *ActionRunner) CanRun(run *ActionRun) bool {
+	return r.Group == nil || r.Group.CanRun(run)
+}
+
 func init() {
 	db.RegisterModel(&ActionRunner{})
 }
@@ -202,6 +240,7 @@ type FindRunnerOptions struct {
 	Filter        string
 	IsOnline      optional.Option[bool]
 	IsDisabled    optional.Option[bool]
+	GroupID       int64
 	WithAvailable bool // not only runners belong to, but also runners can be used
 }
 
@@ -246,6 +285,10 @@ func (opts FindRunnerOptions) ToConds() builder.Cond {
 	if opts.IsDisabled.Has() {
 		cond = cond.And(builder.Eq{"is_disabled": opts.IsDisabled.Value()})
 	}
+
+	if opts.GroupID > 0 {
+		cond = cond.And(builder.Eq{"group_id": opts.GroupID})
+	}
 	return cond
 }
 
@@ -0,0 +1,177 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionRunnerGroup is a named group of the runners of a user or an organization.
+// The runners in a group only run the jobs of the repositories and workflows allowed by the group,
+// and a job could target a group by its name with `runs-on: {group: <name>}`.
+// The runners which don't belong to any group are in the default group, which has no restriction.
+type ActionRunnerGroup struct {
+	ID          int64
+	OwnerID     int64  `xorm:"UNIQUE(owner_name) NOT NULL"`
+	Name        string `xorm:"UNIQUE(owner_name) VARCHAR(255) NOT NULL"`
+	Description string `xorm:"TEXT"`
+	// RepoIDs are the repositories whose jobs could be run by the runners of the group, empty means all repositories of the owner
+	RepoIDs []int64 `xorm:"JSON TEXT"`
+	// Workflows are the file names of the workflows whose jobs could be run by the runners of the group, empty means all workflows
+	Workflows []string `xorm:"JSON TEXT"`
+
+	Created timeutil.TimeStamp `xorm:"created"`
+	Updated timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionRunnerGroup))
+}
+
+// IsRestricted returns whether the runners of the group could only run the jobs of some repositories or workflows
+func (g *ActionRunnerGroup) IsRestricted() bool {
+	return len(g.RepoIDs) > 0 || len(g.Workflows) > 0
+}
+
+// CanRun returns whether the runners of the group could run the jobs of the run
+func (g *ActionRunnerGroup) CanRun(run *ActionRun) bool {
+	if len(g.RepoIDs) > 0 && !slices.Contains(g.RepoIDs, run.RepoID) {
+		return false
+	}
+	return len(g.Workflows) == 0 || slices.Contains(g.Workflows, run.WorkflowID)
+}
+
+// runCond returns the condition of the runs whose jobs could be run by the runners of the group
+func (g *ActionRunnerGroup) runCond() builder.Cond {
+	cond := builder.NewCond()
+	if len(g.RepoIDs) > 0 {
+		cond = cond.And(builder.In("repo_id", g.RepoIDs))
+	}
+	if len(g.Workflows) > 0 {
+		cond = cond.And(builder.In("workflow_id", g.Workflows))
+	}
+	return cond
+}
+
+type FindRunnerGroupOptions struct {
+	db.ListOptions
+	OwnerID int64
+	Name    string
+}
+
+func (opts FindRunnerGroupOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.Name != "" {
+		cond = cond.And(builder.Eq{"name": opts.Name})
+	}
+	return cond
+}
+
+func (opts FindRunnerGroupOptions) ToOrders() string {
+	return "name ASC"
+}
+
+// GetRunnerGroupByID returns a runner group of the owner by its id
+func GetRunnerGroupByID(ctx context.Context, ownerID, id int64) (*ActionRunnerGroup, error) {
+	var group ActionRunnerGroup
+	has, err := db.GetEngine(ctx).Where("id=? AND owner_id=?", id, ownerID).Get(&group)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("runner group with id %d: %w", id, util.ErrNotExist)
+	}
+	return &group, nil
+}
+
+func validateRunnerGroup(ctx context.Context, group *ActionRunnerGroup) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" || len(group.Name) > 255 {
+		return util.NewInvalidArgumentErrorf("invalid runner group name %q", group.Name)
+	}
+	exist, err := db.Exist[ActionRunnerGroup](ctx, builder.Eq{"owner_id": group.OwnerID, "name": group.Name}.And(builder.Neq{"id": group.ID}))
+	if err != nil {
+		return err
+	} else if exist {
+		return util.NewAlreadyExistErrorf("runner group %q already exists", group.Name)
+	}
+	return nil
+}
+
+// CreateRunnerGroup creates a runner group for a user or an organization
+func CreateRunnerGroup(ctx context.Context, group *ActionRunnerGroup) error {
+	if group.OwnerID == 0 {
+		return util.NewInvalidArgumentErrorf("runner group must belong to a user or an organization")
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := validateRunnerGroup(ctx, group); err != nil {
+			return err
+		}
+		return db.Insert(ctx, group)
+	})
+}
+
+// UpdateRunnerGroup updates a runner group, the runners of the group will pick tasks with the new restrictions
+func UpdateRunnerGroup(ctx context.Context, group *ActionRunnerGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := validateRunnerGroup(ctx, group); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).ID(group.ID).Cols("name", "description", "repo_ids", "workflows").Update(group); err != nil {
+			return err
+		}
+		return IncreaseTaskVersion(ctx, group.OwnerID, 0)
+	})
+}
+
+// DeleteRunnerGroup deletes a runner group, and moves its runners to the default group
+func DeleteRunnerGroup(ctx context.Context, group *ActionRunnerGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("group_id=?", group.ID).Cols("group_id").Update(&ActionRunner{GroupID: 0}); err != nil {
+			return err
+		}
+		if _, err := db.DeleteByID[ActionRunnerGroup](ctx, group.ID); err != nil {
+			return err
+		}
+		return IncreaseTaskVersion(ctx, group.OwnerID, 0)
+	})
+}
+
+// SetRunnerGroup moves a runner to a group of its owner, groupID 0 means the default group
+func SetRunnerGroup(ctx context.Context, runner *ActionRunner, groupID int64) error {
+	if runner.GroupID == groupID {
+		return nil
+	}
+	if groupID != 0 {
+		if runner.OwnerID == 0 || runner.RepoID != 0 {
+			return util.NewInvalidArgumentErrorf("only the runners of users or organizations could be moved to runner groups")
+		}
+		group, err := GetRunnerGroupByID(ctx, runner.OwnerID, groupID)
+		if err != nil {
+			return err
+		}
+		runner.Group = group
+	} else {
+		runner.Group = nil
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		runner.GroupID = groupID
+		if err := UpdateRunner(ctx, runner, "group_id"); err != nil {
+			return err
+		}
+		return IncreaseTaskVersion(ctx, runner.OwnerID, runner.RepoID)
+	})
+}
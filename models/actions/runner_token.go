@@ -6,12 +6,15 @@ package actions
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
 )
 
 // ActionRunnerToken represents runner tokens
@@ -26,14 +29,19 @@ import (
 // For example, conditions like `OwnerID = 1` will also return token {OwnerID: 1, RepoID: 1},
 // but it's a repo level token, not an org/user level token.
 // To avoid this, make it clear with {OwnerID: 0, RepoID: 1} for repo level tokens.
+//
+// An ephemeral token could only be used once before it expires, and the runner registered with it will be deleted after it has run one task.
+// Ephemeral tokens are created on demand, e.g. by autoscalers, they don't invalidate and aren't invalidated by the other tokens.
 type ActionRunnerToken struct {
-	ID       int64
-	Token    string                 `xorm:"UNIQUE"`
-	OwnerID  int64                  `xorm:"index"`
-	Owner    *user_model.User       `xorm:"-"`
-	RepoID   int64                  `xorm:"index"`
-	Repo     *repo_model.Repository `xorm:"-"`
-	IsActive bool                   // true means it can be used
+	ID        int64
+	Token     string                 `xorm:"UNIQUE"`
+	OwnerID   int64                  `xorm:"index"`
+	Owner     *user_model.User       `xorm:"-"`
+	RepoID    int64                  `xorm:"index"`
+	Repo      *repo_model.Repository `xorm:"-"`
+	IsActive  bool                   // true means it can be used
+	Ephemeral bool                   `xorm:"NOT NULL DEFAULT false"`
+	Expires   timeutil.TimeStamp     `xorm:"index NOT NULL DEFAULT 0"` // only ephemeral tokens expire, 0 means never
 
 	Created timeutil.TimeStamp `xorm:"created"`
 	Updated timeutil.TimeStamp `xorm:"updated"`
@@ -44,6 +52,11 @@ func init() {
 	db.RegisterModel(new(ActionRunnerToken))
 }
 
+// IsExpired returns whether the token has expired
+func (t *ActionRunnerToken) IsExpired() bool {
+	return t.Expires > 0 && t.Expires <= timeutil.TimeStampNow()
+}
+
 // GetRunnerToken returns a action runner via token
 func GetRunnerToken(ctx context.Context, token string) (*ActionRunnerToken, error) {
 	var runnerToken ActionRunnerToken
@@ -85,7 +98,7 @@ func NewRunnerTokenWithValue(ctx context.Context, ownerID, repoID int64, token s
 	}
 
 	return runnerToken, db.WithTx(ctx, func(ctx context.Context) error {
-		if _, err := db.GetEngine(ctx).Where("owner_id =? AND repo_id = ?", ownerID, repoID).Cols("is_active").Update(&ActionRunnerToken{
+		if _, err := db.GetEngine(ctx).Where("owner_id =? AND repo_id = ? AND ephemeral = ?", ownerID, repoID, false).Cols("is_active").Update(&ActionRunnerToken{
 			IsActive: false,
 		}); err != nil {
 			return err
@@ -104,6 +117,42 @@ func NewRunnerToken(ctx context.Context, ownerID, repoID int64) (*ActionRunnerTo
 	return NewRunnerTokenWithValue(ctx, ownerID, repoID, token)
 }
 
+// NewEphemeralRunnerToken creates a new single-use runner token which expires after the given duration,
+// the runner registered with it will be ephemeral.
+// ownerID will be ignored and treated as 0 if repoID is non-zero.
+func NewEphemeralRunnerToken(ctx context.Context, ownerID, repoID int64, expiration time.Duration) (*ActionRunnerToken, error) {
+	if ownerID != 0 && repoID != 0 {
+		ownerID = 0
+	}
+	token, err := util.CryptoRandomString(40)
+	if err != nil {
+		return nil, err
+	}
+
+	runnerToken := &ActionRunnerToken{
+		OwnerID:   ownerID,
+		RepoID:    repoID,
+		IsActive:  true,
+		Ephemeral: true,
+		Token:     token,
+		Expires:   timeutil.TimeStampNow().AddDuration(expiration),
+	}
+	return runnerToken, db.Insert(ctx, runnerToken)
+}
+
+// UseEphemeralRunnerToken invalidates an ephemeral runner token when a runner is registered with it,
+// it returns false if the token has been used by another runner or has expired.
+func UseEphemeralRunnerToken(ctx context.Context, r *ActionRunnerToken) (bool, error) {
+	n, err := db.GetEngine(ctx).Where("id=? AND ephemeral=? AND is_active=? AND expires>?", r.ID, true, true, timeutil.TimeStampNow()).Cols("is_active").Update(&ActionRunnerToken{
+		IsActive: false,
+	})
+	if err != nil {
+		return false, err
+	}
+	r.IsActive = false
+	return n == 1, nil
+}
+
 // GetLatestRunnerToken returns the latest runner token
 func GetLatestRunnerToken(ctx context.Context, ownerID, repoID int64) (*ActionRunnerToken, error) {
 	if ownerID != 0 && repoID != 0 {
@@ -113,7 +162,7 @@ func GetLatestRunnerToken(ctx context.Context, ownerID, repoID int64) (*ActionRu
 	}
 
 	var runnerToken ActionRunnerToken
-	has, err := db.GetEngine(ctx).Where("owner_id=? AND repo_id=?", ownerID, repoID).
+	has, err := db.GetEngine(ctx).Where("owner_id=? AND repo_id=? AND ephemeral=?", ownerID, repoID, false).
 		OrderBy("id DESC").Get(&runnerToken)
 	if err != nil {
 		return nil, err
@@ -122,3 +171,13 @@ func GetLatestRunnerToken(ctx context.Context, ownerID, repoID int64) (*ActionRu
 	}
 	return &runnerToken, nil
 }
+
+// DeleteStaleEphemeralRunnerTokens deletes the ephemeral runner tokens which have been used or have expired
+func DeleteStaleEphemeralRunnerTokens(ctx context.Context) (int64, error) {
+	cond := builder.Eq{"ephemeral": true}.And(builder.Eq{"is_active": false}.Or(builder.Lte{"expires": timeutil.TimeStampNow()}))
+	res, err := db.GetEngine(ctx).Exec(builder.Delete(cond).From("`action_runner_token`"))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -5,6 +5,7 @@ package actions
 
 import (
 	"testing"
+	"time"
 
 	"code.gitea.io/gitea/models/unittest"
 
@@ -37,3 +38,51 @@ func TestUpdateRunnerToken(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedToken, token)
 }
+
+func TestNewEphemeralRunnerToken(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	token, err := NewEphemeralRunnerToken(t.Context(), 1, 0, time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, token.Ephemeral)
+	assert.False(t, token.IsExpired())
+
+	// the ephemeral tokens don't replace the latest token
+	latestToken := unittest.AssertExistsAndLoadBean(t, &ActionRunnerToken{ID: 3})
+	expectedToken, err := GetLatestRunnerToken(t.Context(), 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, latestToken, expectedToken)
+
+	// and they aren't invalidated by new tokens
+	_, err = NewRunnerToken(t.Context(), 1, 0)
+	assert.NoError(t, err)
+	token = unittest.AssertExistsAndLoadBean(t, &ActionRunnerToken{ID: token.ID})
+	assert.True(t, token.IsActive)
+
+	// an ephemeral token could only be used once
+	used, err := UseEphemeralRunnerToken(t.Context(), token)
+	assert.NoError(t, err)
+	assert.True(t, used)
+	used, err = UseEphemeralRunnerToken(t.Context(), token)
+	assert.NoError(t, err)
+	assert.False(t, used)
+	unittest.AssertExistsAndLoadBean(t, &ActionRunnerToken{ID: token.ID, IsActive: false})
+
+	// an expired token can't be used
+	expiredToken, err := NewEphemeralRunnerToken(t.Context(), 1, 0, -time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, expiredToken.IsExpired())
+	used, err = UseEphemeralRunnerToken(t.Context(), expiredToken)
+	assert.NoError(t, err)
+	assert.False(t, used)
+
+	// the used and the expired tokens are deleted, the unused ones and the other tokens are kept
+	unusedToken, err := NewEphemeralRunnerToken(t.Context(), 1, 0, time.Hour)
+	assert.NoError(t, err)
+	deleted, err := DeleteStaleEphemeralRunnerTokens(t.Context())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, deleted)
+	unittest.AssertNotExistsBean(t, &ActionRunnerToken{ID: token.ID})
+	unittest.AssertNotExistsBean(t, &ActionRunnerToken{ID: expiredToken.ID})
+	unittest.AssertExistsAndLoadBean(t, &ActionRunnerToken{ID: unusedToken.ID})
+	unittest.AssertExistsAndLoadBean(t, &ActionRunnerToken{ID: 3})
+}
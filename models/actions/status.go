@@ -46,6 +46,34 @@ func (s Status) LocaleString(lang translation.Locale) string {
 	return lang.TrString("actions.status." + s.String())
 }
 
+// JobStopReason is the reason why a job has been stopped by the server instead of finishing on a runner
+type JobStopReason int
+
+const (
+	JobStopReasonNone         JobStopReason = iota // 0, the job hasn't been stopped by the server
+	JobStopReasonTimeout                           // 1, the job ran longer than its timeout-minutes
+	JobStopReasonQueueTimeout                      // 2, the job waited for a runner longer than the max queue time
+)
+
+var jobStopReasonNames = map[JobStopReason]string{
+	JobStopReasonNone:         "",
+	JobStopReasonTimeout:      "timeout",
+	JobStopReasonQueueTimeout: "queue_timeout",
+}
+
+// String returns the string name of the JobStopReason
+func (r JobStopReason) String() string {
+	return jobStopReasonNames[r]
+}
+
+// LocaleString returns the locale string of the JobStopReason, it is empty if the job hasn't been stopped by the server
+func (r JobStopReason) LocaleString(lang translation.Locale) string {
+	if r == JobStopReasonNone {
+		return ""
+	}
+	return lang.TrString("actions.stop_reason." + r.String())
+}
+
 // IsDone returns whether the Status is final
 func (s Status) IsDone() bool {
 	return s.In(StatusSuccess, StatusFailure, StatusCancelled, StatusSkipped)
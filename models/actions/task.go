@@ -37,6 +37,8 @@ type ActionTask struct {
 	Status   Status             `xorm:"index"`
 	Started  timeutil.TimeStamp `xorm:"index"`
 	Stopped  timeutil.TimeStamp `xorm:"index(stopped_log_expired)"`
+	// Deadline is the time when the task times out according to the timeout-minutes of its job, 0 means it never times out
+	Deadline timeutil.TimeStamp `xorm:"index"`
 
 	RepoID            int64  `xorm:"index"`
 	OwnerID           int64  `xorm:"index"`
@@ -247,12 +249,24 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 			Join("INNER", "repo_unit", "`repository`.id = `repo_unit`.repo_id").
 			Where(builder.Eq{"`repository`.owner_id": runner.OwnerID, "`repo_unit`.type": unit.TypeActions}))
 	}
+	if err := runner.LoadGroup(ctx); err != nil {
+		return nil, false, err
+	}
+	if runner.Group != nil {
+		// the runner only runs the jobs of the repositories and workflows allowed by its group
+		jobCond = jobCond.And(runner.Group.runCond())
+	}
 	if jobCond.IsValid() {
 		jobCond = builder.In("run_id", builder.Select("id").From("action_run").Where(jobCond))
 	}
+	// the jobs requiring a runner group could only be run by the runners of the group
+	groupCond := builder.Eq{"runs_on_group": ""}
+	if runner.Group != nil {
+		groupCond = builder.Eq{"runs_on_group": []string{"", runner.Group.Name}}
+	}
 
 	var jobs []*ActionRunJob
-	if err := e.Where("task_id=? AND status=?", 0, StatusWaiting).And(jobCond).Asc("updated", "id").Find(&jobs); err != nil {
+	if err := e.Where("task_id=? AND status=?", 0, StatusWaiting).And(jobCond).And(groupCond).Asc("updated", "id").Find(&jobs); err != nil {
 		return nil, false, err
 	}
 
@@ -296,6 +310,9 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 	if err != nil {
 		return nil, false, fmt.Errorf("load job %d: %w", job.ID, err)
 	}
+	if timeout := workflowJob.Timeout(); timeout > 0 {
+		task.Deadline = now.AddDuration(timeout)
+	}
 
 	if _, err := e.Insert(task); err != nil {
 		return nil, false, err
@@ -0,0 +1,109 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// MaxAnnotationsPerTask is the max number of annotations of a task, the rest ones are ignored
+const MaxAnnotationsPerTask = 50
+
+// AnnotationLevel represents the level of an annotation
+type AnnotationLevel int
+
+const (
+	AnnotationLevelNotice  AnnotationLevel = iota // 0
+	AnnotationLevelWarning                        // 1
+	AnnotationLevelError                          // 2
+)
+
+var annotationLevelNames = map[AnnotationLevel]string{
+	AnnotationLevelNotice:  "notice",
+	AnnotationLevelWarning: "warning",
+	AnnotationLevelError:   "error",
+}
+
+// String returns the name of the level, it's the same as the workflow command which creates the annotation
+func (l AnnotationLevel) String() string {
+	return annotationLevelNames[l]
+}
+
+// ParseAnnotationLevel returns the level of the name of a workflow command
+func ParseAnnotationLevel(name string) (AnnotationLevel, bool) {
+	for level, levelName := range annotationLevelNames {
+		if levelName == name {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// ActionTaskAnnotation represents an annotation written by a step of ActionTask with a workflow command like
+// `::error file=app.js,line=1,title=Syntax error::Missing semicolon`.
+type ActionTaskAnnotation struct {
+	ID        int64
+	TaskID    int64  `xorm:"index"`
+	JobID     int64  `xorm:"index"`
+	RunID     int64  `xorm:"index"`
+	RepoID    int64  `xorm:"index"`
+	CommitSHA string `xorm:"index VARCHAR(64)"`
+	LogIndex  int64  // the index of the log line of the workflow command in the logs of the task
+	Level     AnnotationLevel
+	Path      string `xorm:"VARCHAR(1024)"` // the path of the file relative to the root of the repository, could be empty
+	Line      int64
+	EndLine   int64
+	Column    int64
+	EndColumn int64
+	Title     string             `xorm:"VARCHAR(255)"`
+	Message   string             `xorm:"TEXT"`
+	Created   timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionTaskAnnotation))
+}
+
+// InsertTaskAnnotations inserts the annotations of a task, the ones exceeding MaxAnnotationsPerTask are ignored
+func InsertTaskAnnotations(ctx context.Context, taskID int64, annotations []*ActionTaskAnnotation) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		count, err := db.GetEngine(ctx).Where("task_id=?", taskID).Count(new(ActionTaskAnnotation))
+		if err != nil {
+			return err
+		}
+		remaining := max(MaxAnnotationsPerTask-int(count), 0)
+		if len(annotations) > remaining {
+			annotations = annotations[:remaining]
+		}
+		if len(annotations) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, annotations)
+	})
+}
+
+// GetTaskAnnotations returns the annotations of a task, ordered by the log lines which create them
+func GetTaskAnnotations(ctx context.Context, taskID int64) ([]*ActionTaskAnnotation, error) {
+	var annotations []*ActionTaskAnnotation
+	return annotations, db.GetEngine(ctx).Where("task_id=?", taskID).OrderBy("log_index ASC").Find(&annotations)
+}
+
+// GetCommitFileAnnotations returns the annotations with files of the latest attempts of the jobs running for a commit
+func GetCommitFileAnnotations(ctx context.Context, repoID int64, commitSHA string) ([]*ActionTaskAnnotation, error) {
+	var annotations []*ActionTaskAnnotation
+	return annotations, db.GetEngine(ctx).
+		Where(builder.Eq{"repo_id": repoID, "commit_sha": commitSHA}).
+		And(builder.Neq{"path": ""}).
+		And(builder.In("task_id", builder.Select("task_id").From("action_run_job").Where(builder.Eq{"repo_id": repoID, "commit_sha": commitSHA}))).
+		OrderBy("level DESC, id ASC").
+		Find(&annotations)
+}
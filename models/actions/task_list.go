@@ -47,14 +47,15 @@ func (tasks TaskList) LoadAttributes(ctx context.Context) error {
 
 type FindTaskOptions struct {
 	db.ListOptions
-	RepoID        int64
-	JobID         int64
-	OwnerID       int64
-	CommitSHA     string
-	Status        Status
-	UpdatedBefore timeutil.TimeStamp
-	StartedBefore timeutil.TimeStamp
-	RunnerID      int64
+	RepoID         int64
+	JobID          int64
+	OwnerID        int64
+	CommitSHA      string
+	Status         Status
+	UpdatedBefore  timeutil.TimeStamp
+	StartedBefore  timeutil.TimeStamp
+	DeadlineBefore timeutil.TimeStamp
+	RunnerID       int64
 }
 
 func (opts FindTaskOptions) ToConds() builder.Cond {
@@ -80,6 +81,9 @@ func (opts FindTaskOptions) ToConds() builder.Cond {
 	if opts.StartedBefore > 0 {
 		cond = cond.And(builder.Lt{"started": opts.StartedBefore})
 	}
+	if opts.DeadlineBefore > 0 {
+		cond = cond.And(builder.Gt{"deadline": 0}, builder.Lt{"deadline": opts.DeadlineBefore})
+	}
 	if opts.RunnerID > 0 {
 		cond = cond.And(builder.Eq{"runner_id": opts.RunnerID})
 	}
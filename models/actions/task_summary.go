@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ActionTaskSummary represents the Markdown summary written by a step of ActionTask to `GITHUB_STEP_SUMMARY`.
+// The content is stored in the storage of the actions logs, like the logs, the summaries of a rerun job are reset since the task is new.
+type ActionTaskSummary struct {
+	ID        int64
+	TaskID    int64  `xorm:"index unique(task_step)"`
+	StepIndex int64  `xorm:"unique(task_step)"`
+	RepoID    int64  `xorm:"index"`
+	Filename  string `xorm:"VARCHAR(255)"` // the file name in the storage of the actions logs
+	Size      int64
+	Created   timeutil.TimeStamp `xorm:"created"`
+	Updated   timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionTaskSummary))
+}
+
+// summaryFileName returns the file name of the summary of a step, it's stored next to the logs of the task
+func summaryFileName(repoID, taskID, stepIndex int64) string {
+	return fmt.Sprintf("summaries/%d/%02x/%d-%d.md", repoID, taskID%256, taskID, stepIndex)
+}
+
+// GetTaskSummaries returns the summaries of the steps of a task, ordered by the steps
+func GetTaskSummaries(ctx context.Context, taskID int64) ([]*ActionTaskSummary, error) {
+	var summaries []*ActionTaskSummary
+	return summaries, db.GetEngine(ctx).Where("task_id=?", taskID).OrderBy("step_index ASC").Find(&summaries)
+}
+
+// GetTaskSummariesByRepoID returns the summaries of all tasks of a repository
+func GetTaskSummariesByRepoID(ctx context.Context, repoID int64) ([]*ActionTaskSummary, error) {
+	var summaries []*ActionTaskSummary
+	return summaries, db.GetEngine(ctx).Where("repo_id=?", repoID).Find(&summaries)
+}
+
+// GetTaskSummary returns the summary of a step of a task, a new one with the file name to store the content is returned if it doesn't exist
+func GetTaskSummary(ctx context.Context, task *ActionTask, stepIndex int64) (*ActionTaskSummary, error) {
+	summary := &ActionTaskSummary{}
+	has, err := db.GetEngine(ctx).Where("task_id=? AND step_index=?", task.ID, stepIndex).Get(summary)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		summary.TaskID = task.ID
+		summary.StepIndex = stepIndex
+		summary.RepoID = task.RepoID
+		summary.Filename = summaryFileName(task.RepoID, task.ID, stepIndex)
+	}
+	return summary, nil
+}
+
+// SaveTaskSummary inserts or updates the summary of a step after its content has been stored
+func SaveTaskSummary(ctx context.Context, summary *ActionTaskSummary) error {
+	if summary.ID == 0 {
+		return db.Insert(ctx, summary)
+	}
+	_, err := db.GetEngine(ctx).ID(summary.ID).Cols("size").Update(summary)
+	return err
+}
+
+// DeleteTaskSummaries deletes the summary records of the tasks, the content in the storage should be removed by the caller
+func DeleteTaskSummaries(ctx context.Context, taskIDs ...int64) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).In("task_id", taskIDs).Delete(&ActionTaskSummary{})
+	return err
+}
@@ -5,6 +5,7 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"unicode/utf8"
 
@@ -28,15 +29,19 @@ import (
 // For example, conditions like `OwnerID = 1` will also return variable {OwnerID: 1, RepoID: 1},
 // but it's a repo level variable, not an org/user level variable.
 // To avoid this, make it clear with {OwnerID: 0, RepoID: 1} for repo level variables.
+//
+// A repo level variable could belong to a deployment environment of the repository by EnvironmentID,
+// it is only available to the jobs deploying to the environment.
 type ActionVariable struct {
-	ID          int64              `xorm:"pk autoincr"`
-	OwnerID     int64              `xorm:"UNIQUE(owner_repo_name)"`
-	RepoID      int64              `xorm:"INDEX UNIQUE(owner_repo_name)"`
-	Name        string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
-	Data        string             `xorm:"LONGTEXT NOT NULL"`
-	Description string             `xorm:"TEXT"`
-	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
-	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	ID            int64              `xorm:"pk autoincr"`
+	OwnerID       int64              `xorm:"UNIQUE(owner_repo_name)"`
+	RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name)"`
+	EnvironmentID int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	Data          string             `xorm:"LONGTEXT NOT NULL"`
+	Description   string             `xorm:"TEXT"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
 }
 
 const (
@@ -49,6 +54,11 @@ func init() {
 }
 
 func InsertVariable(ctx context.Context, ownerID, repoID int64, name, data, description string) (*ActionVariable, error) {
+	return InsertEnvironmentVariable(ctx, ownerID, repoID, 0, name, data, description)
+}
+
+// InsertEnvironmentVariable inserts a variable, environmentID is only meaningful for a repo level variable
+func InsertEnvironmentVariable(ctx context.Context, ownerID, repoID, environmentID int64, name, data, description string) (*ActionVariable, error) {
 	if ownerID != 0 && repoID != 0 {
 		// It's trying to create a variable that belongs to a repository, but OwnerID has been set accidentally.
 		// Remove OwnerID to avoid confusion; it's not worth returning an error here.
@@ -61,12 +71,17 @@ func InsertVariable(ctx context.Context, ownerID, repoID int64, name, data, desc
 
 	description = util.TruncateRunes(description, VariableDescriptionMaxLength)
 
+	if repoID == 0 && environmentID != 0 {
+		return nil, util.NewInvalidArgumentErrorf("only repo level variables could belong to an environment")
+	}
+
 	variable := &ActionVariable{
-		OwnerID:     ownerID,
-		RepoID:      repoID,
-		Name:        strings.ToUpper(name),
-		Data:        data,
-		Description: description,
+		OwnerID:       ownerID,
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          strings.ToUpper(name),
+		Data:          data,
+		Description:   description,
 	}
 	return variable, db.Insert(ctx, variable)
 }
@@ -76,7 +91,9 @@ type FindVariablesOpts struct {
 	IDs     []int64
 	RepoID  int64
 	OwnerID int64 // it will be ignored if RepoID is set
-	Name    string
+	// EnvironmentID is the environment which the repo level variables belong to, 0 means the variables of the repository itself
+	EnvironmentID int64
+	Name          string
 }
 
 func (opts FindVariablesOpts) ToConds() builder.Cond {
@@ -99,6 +116,7 @@ func (opts FindVariablesOpts) ToConds() builder.Cond {
 	} else {
 		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
 	}
+	cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
 
 	if opts.Name != "" {
 		cond = cond.And(builder.Eq{"name": strings.ToUpper(opts.Name)})
@@ -169,6 +187,36 @@ func GetVariablesOfRun(ctx context.Context, run *ActionRun) (map[string]string,
 	return variables, nil
 }
 
+// GetVariablesOfJob returns the variables of the run of the job, with the variables of the job's environment taking precedence
+func GetVariablesOfJob(ctx context.Context, job *ActionRunJob) (map[string]string, error) {
+	if err := job.LoadRun(ctx); err != nil {
+		return nil, err
+	}
+	variables, err := GetVariablesOfRun(ctx, job.Run)
+	if err != nil {
+		return nil, err
+	}
+	if job.Environment == "" {
+		return variables, nil
+	}
+
+	env, err := GetEnvironmentByRepoAndName(ctx, job.RepoID, job.Environment)
+	if errors.Is(err, util.ErrNotExist) {
+		return variables, nil
+	} else if err != nil {
+		return nil, err
+	}
+	envVariables, err := db.Find[ActionVariable](ctx, FindVariablesOpts{RepoID: job.RepoID, EnvironmentID: env.ID})
+	if err != nil {
+		log.Error("find variables of environment: %d, error: %v", env.ID, err)
+		return nil, err
+	}
+	for _, v := range envVariables {
+		variables[v.Name] = v.Data
+	}
+	return variables, nil
+}
+
 func CountWrongRepoLevelVariables(ctx context.Context) (int64, error) {
 	var result int64
 	_, err := db.GetEngine(ctx).SQL("SELECT count(`id`) FROM `action_variable` WHERE `repo_id` > 0 AND `owner_id` > 0").Get(&result)
@@ -37,10 +37,12 @@ type IssuesOptions struct { //nolint:revive // export stutter
 	SubscriberID       int64
 	MilestoneIDs       []int64
 	ProjectID          int64
+	ProjectIDs         []int64 // the issues belong to one of the projects, ignored if ProjectID is set
 	ProjectColumnID    int64
 	IsClosed           optional.Option[bool]
 	IsPull             optional.Option[bool]
 	LabelIDs           []int64
+	LabelIDSets        [][]int64 // the issues have at least one label of each set, ignored if LabelIDs asks for issues without labels
 	IncludedLabelNames []string
 	ExcludedLabelNames []string
 	IncludeMilestones  []string
@@ -173,6 +175,12 @@ func applyLabelsCondition(sess *xorm.Session, opts *IssuesOptions) {
 		}
 	}
 
+	if len(opts.LabelIDs) == 0 || opts.LabelIDs[0] != 0 {
+		for _, labelIDs := range opts.LabelIDSets {
+			sess.In("issue.id", builder.Select("issue_id").From("issue_label").Where(builder.In("label_id", labelIDs)))
+		}
+	}
+
 	if len(opts.IncludedLabelNames) > 0 {
 		sess.In("issue.id", BuildLabelNamesIssueIDsCondition(opts.IncludedLabelNames))
 	}
@@ -203,6 +211,8 @@ func applyProjectCondition(sess *xorm.Session, opts *IssuesOptions) {
 			And("project_issue.project_id=?", opts.ProjectID)
 	} else if opts.ProjectID == db.NoConditionID { // show those that are in no project
 		sess.And(builder.NotIn("issue.id", builder.Select("issue_id").From("project_issue").And(builder.Neq{"project_id": 0})))
+	} else if len(opts.ProjectIDs) > 0 { // one of the projects
+		sess.In("issue.id", builder.Select("issue_id").From("project_issue").Where(builder.In("project_id", opts.ProjectIDs)))
 	}
 	// opts.ProjectID == 0 means all projects,
 	// do not need to apply any condition
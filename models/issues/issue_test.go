@@ -191,6 +191,26 @@ func TestIssues(t *testing.T) {
 			},
 			[]int64{}, // issues with **both** label 1 and 2, none of these issues matches, TODO: add more tests
 		},
+		{
+			issues_model.IssuesOptions{
+				LabelIDSets: [][]int64{{1, 2}},
+				SortType:    "oldest",
+			},
+			[]int64{1, 2, 5},
+		},
+		{
+			issues_model.IssuesOptions{
+				LabelIDSets: [][]int64{{1, 2}, {3, 4}},
+			},
+			[]int64{2},
+		},
+		{
+			issues_model.IssuesOptions{
+				ProjectIDs: []int64{1, 2},
+				SortType:   "oldest",
+			},
+			[]int64{1, 2, 3, 5},
+		},
 		{
 			issues_model.IssuesOptions{
 				MilestoneIDs: []int64{1},
@@ -489,6 +489,20 @@ func GetLabelIDsByNames(ctx context.Context, labelNames []string) ([]int64, erro
 		Find(&labelIDs)
 }
 
+// GetLabelsByNamesInRepos returns the labels with the names (case-insensitive) which can be used by the repositories selected by repoCond,
+// that are the labels of the repositories and of their owner organizations. All labels are searched if repoCond is empty.
+func GetLabelsByNamesInRepos(ctx context.Context, repoCond builder.Cond, names []string) ([]*Label, error) {
+	cond := db.BuildCaseInsensitiveIn("name", names)
+	if repoCond.IsValid() {
+		cond = cond.And(builder.Or(
+			builder.In("repo_id", builder.Select("id").From("repository").Where(repoCond)),
+			builder.In("org_id", builder.Select("owner_id").From("repository").Where(repoCond)),
+		))
+	}
+	labels := make([]*Label, 0, len(names))
+	return labels, db.GetEngine(ctx).Where(cond).Cols("id", "name").Find(&labels)
+}
+
 // CountLabelsByOrgID count all labels that belong to given organization by ID.
 func CountLabelsByOrgID(ctx context.Context, orgID int64) (int64, error) {
 	return db.GetEngine(ctx).Where("org_id = ?", orgID).Count(&Label{})
@@ -101,6 +101,17 @@ func GetMilestoneIDsByNames(ctx context.Context, names []string) ([]int64, error
 		Find(&ids)
 }
 
+// GetMilestonesByNamesInRepos returns the milestones with the names (case-insensitive) of the repositories selected by repoCond.
+// All milestones are searched if repoCond is empty.
+func GetMilestonesByNamesInRepos(ctx context.Context, repoCond builder.Cond, names []string) (MilestoneList, error) {
+	cond := db.BuildCaseInsensitiveIn("name", names)
+	if repoCond.IsValid() {
+		cond = cond.And(builder.In("repo_id", builder.Select("id").From("repository").Where(repoCond)))
+	}
+	milestones := make(MilestoneList, 0, len(names))
+	return milestones, db.GetEngine(ctx).Where(cond).Cols("id", "name").Find(&milestones)
+}
+
 // LoadTotalTrackedTimes loads for every milestone in the list the TotalTrackedTime by a batch request
 func (milestones MilestoneList) LoadTotalTrackedTimes(ctx context.Context) error {
 	type totalTimesByMilestone struct {
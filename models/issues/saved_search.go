@@ -0,0 +1,286 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrSavedSearchNotExist represents a "SavedSearchNotExist" kind of error.
+type ErrSavedSearchNotExist struct {
+	ID int64
+}
+
+// IsErrSavedSearchNotExist checks if an error is a ErrSavedSearchNotExist.
+func IsErrSavedSearchNotExist(err error) bool {
+	_, ok := err.(ErrSavedSearchNotExist)
+	return ok
+}
+
+func (err ErrSavedSearchNotExist) Error() string {
+	return fmt.Sprintf("saved search does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSavedSearchNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// SavedSearch is a named issue or pull request search query.
+// A search owned by an organization is visible to its owners and all its members, or only to the members of the team if TeamID is set.
+type SavedSearch struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"INDEX NOT NULL"`
+	Owner       *user_model.User   `xorm:"-"`
+	TeamID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatorID   int64              `xorm:"NOT NULL"`
+	Name        string             `xorm:"NOT NULL"`
+	IsPull      bool               `xorm:"NOT NULL DEFAULT false"`
+	Query       string             `xorm:"TEXT NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL"`
+}
+
+// SavedSearchUser stores the per user settings of a saved search
+type SavedSearchUser struct {
+	ID               int64              `xorm:"pk autoincr"`
+	SavedSearchID    int64              `xorm:"UNIQUE(s) NOT NULL"`
+	UserID           int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	IsPinned         bool               `xorm:"NOT NULL DEFAULT false"`
+	IsSubscribed     bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	LastNotifiedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(SavedSearch))
+	db.RegisterModel(new(SavedSearchUser))
+}
+
+// LoadOwner loads the owner of the saved search
+func (s *SavedSearch) LoadOwner(ctx context.Context) (err error) {
+	if s.Owner != nil {
+		return nil
+	}
+	s.Owner, err = user_model.GetUserByID(ctx, s.OwnerID)
+	return err
+}
+
+func (s *SavedSearch) relativeLink() string {
+	page := util.Iif(s.IsPull, "pulls", "issues")
+	if s.Owner != nil && s.Owner.IsOrganization() {
+		return fmt.Sprintf("org/%s/%s?q=%s", url.PathEscape(s.Owner.Name), page, url.QueryEscape(s.Query))
+	}
+	return fmt.Sprintf("%s?type=your_repositories&q=%s", page, url.QueryEscape(s.Query))
+}
+
+// Link returns the link to the issue overview page showing the results of the saved search
+func (s *SavedSearch) Link() string {
+	return setting.AppSubURL + "/" + s.relativeLink()
+}
+
+// HTMLURL returns the absolute url to the issue overview page showing the results of the saved search
+func (s *SavedSearch) HTMLURL() string {
+	return setting.AppURL + s.relativeLink()
+}
+
+// CreateSavedSearch creates a new saved search
+func CreateSavedSearch(ctx context.Context, s *SavedSearch) error {
+	return db.Insert(ctx, s)
+}
+
+// UpdateSavedSearch updates the name, query, type and team of the saved search
+func UpdateSavedSearch(ctx context.Context, s *SavedSearch) error {
+	_, err := db.GetEngine(ctx).ID(s.ID).Cols("name", "query", "is_pull", "team_id").Update(s)
+	return err
+}
+
+// GetSavedSearchByID returns the saved search with the id
+func GetSavedSearchByID(ctx context.Context, id int64) (*SavedSearch, error) {
+	s := &SavedSearch{}
+	has, err := db.GetEngine(ctx).ID(id).Get(s)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSavedSearchNotExist{ID: id}
+	}
+	return s, nil
+}
+
+// DeleteSavedSearchByID deletes the saved search and the settings of its users
+func DeleteSavedSearchByID(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&SavedSearchUser{SavedSearchID: id}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[SavedSearch](ctx, id)
+		return err
+	})
+}
+
+// DeleteSavedSearchesByOwnerID deletes all saved searches of the owner and the settings of their users
+func DeleteSavedSearchesByOwnerID(ctx context.Context, ownerID int64) error {
+	return deleteSavedSearches(ctx, builder.Eq{"owner_id": ownerID})
+}
+
+// DeleteSavedSearchesByTeamID deletes all saved searches shared with the team and the settings of their users
+func DeleteSavedSearchesByTeamID(ctx context.Context, teamID int64) error {
+	return deleteSavedSearches(ctx, builder.Eq{"team_id": teamID})
+}
+
+func deleteSavedSearches(ctx context.Context, cond builder.Cond) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).
+			Where(builder.In("saved_search_id", builder.Select("id").From("saved_search").Where(cond))).
+			Delete(&SavedSearchUser{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Where(cond).Delete(&SavedSearch{})
+		return err
+	})
+}
+
+// FindSavedSearchOptions represents the options to find saved searches
+type FindSavedSearchOptions struct {
+	db.ListOptions
+	OwnerID         int64
+	VisibleToUserID int64
+	PinnedByUserID  int64
+	IsPull          optional.Option[bool]
+}
+
+// ToConds implements db.FindOptions
+func (opts FindSavedSearchOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.VisibleToUserID > 0 {
+		// the own searches, all searches of the organizations owned by the user
+		// and the ones of the other organizations of the user which are shared with all members or a team of the user
+		cond = cond.And(builder.Or(
+			builder.Eq{"owner_id": opts.VisibleToUserID},
+			builder.In("owner_id", builder.Select("`team_user`.org_id").From("`team_user`").
+				Join("INNER", "`team`", "`team`.id = `team_user`.team_id").
+				Where(builder.Eq{"`team_user`.uid": opts.VisibleToUserID, "`team`.authorize": perm.AccessModeOwner})),
+			builder.And(
+				builder.In("owner_id", builder.Select("org_id").From("org_user").Where(builder.Eq{"uid": opts.VisibleToUserID})),
+				builder.Or(
+					builder.Eq{"team_id": 0},
+					builder.In("team_id", builder.Select("team_id").From("team_user").Where(builder.Eq{"uid": opts.VisibleToUserID})),
+				),
+			),
+		))
+	}
+	if opts.PinnedByUserID > 0 {
+		cond = cond.And(builder.In("id", builder.Select("saved_search_id").From("saved_search_user").
+			Where(builder.Eq{"user_id": opts.PinnedByUserID, "is_pinned": true})))
+	}
+	if opts.IsPull.Has() {
+		cond = cond.And(builder.Eq{"is_pull": opts.IsPull.Value()})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindSavedSearchOptions) ToOrders() string {
+	return "name ASC, id ASC"
+}
+
+// SavedSearchList is a list of saved searches
+type SavedSearchList []*SavedSearch
+
+// LoadOwners loads the owners of the saved searches
+func (l SavedSearchList) LoadOwners(ctx context.Context) error {
+	ownerIDs := make([]int64, 0, len(l))
+	for _, s := range l {
+		ownerIDs = append(ownerIDs, s.OwnerID)
+	}
+	owners, err := user_model.GetUsersMapByIDs(ctx, ownerIDs)
+	if err != nil {
+		return err
+	}
+	for _, s := range l {
+		s.Owner = owners[s.OwnerID]
+	}
+	return nil
+}
+
+// GetSavedSearchUsers returns the settings of the user for the saved searches, indexed by the saved search id
+func GetSavedSearchUsers(ctx context.Context, userID int64, savedSearchIDs []int64) (map[int64]*SavedSearchUser, error) {
+	result := make(map[int64]*SavedSearchUser, len(savedSearchIDs))
+	if len(savedSearchIDs) == 0 {
+		return result, nil
+	}
+	settings := make([]*SavedSearchUser, 0, len(savedSearchIDs))
+	if err := db.GetEngine(ctx).
+		Where(builder.Eq{"user_id": userID}.And(builder.In("saved_search_id", savedSearchIDs))).
+		Find(&settings); err != nil {
+		return nil, err
+	}
+	for _, su := range settings {
+		result[su.SavedSearchID] = su
+	}
+	return result, nil
+}
+
+func updateSavedSearchUser(ctx context.Context, savedSearchID, userID int64, cols []string, update func(*SavedSearchUser)) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		su := &SavedSearchUser{SavedSearchID: savedSearchID, UserID: userID}
+		has, err := db.GetEngine(ctx).Get(su)
+		if err != nil {
+			return err
+		}
+		update(su)
+		if !has {
+			return db.Insert(ctx, su)
+		}
+		_, err = db.GetEngine(ctx).ID(su.ID).Cols(cols...).Update(su)
+		return err
+	})
+}
+
+// SetSavedSearchPinned pins or unpins the saved search on the dashboard of the user
+func SetSavedSearchPinned(ctx context.Context, savedSearchID, userID int64, pinned bool) error {
+	return updateSavedSearchUser(ctx, savedSearchID, userID, []string{"is_pinned"}, func(su *SavedSearchUser) {
+		su.IsPinned = pinned
+	})
+}
+
+// SetSavedSearchSubscribed subscribes or unsubscribes the user to the digests of the saved search.
+// Only items created after subscribing are included in the digests.
+func SetSavedSearchSubscribed(ctx context.Context, savedSearchID, userID int64, subscribed bool) error {
+	return updateSavedSearchUser(ctx, savedSearchID, userID, []string{"is_subscribed", "last_notified_unix"}, func(su *SavedSearchUser) {
+		if subscribed && !su.IsSubscribed {
+			su.LastNotifiedUnix = timeutil.TimeStampNow()
+		}
+		su.IsSubscribed = subscribed
+	})
+}
+
+// FindSubscribedSavedSearchUsers returns a page of the subscriptions to saved searches
+func FindSubscribedSavedSearchUsers(ctx context.Context, opts db.ListOptions) ([]*SavedSearchUser, error) {
+	sess := db.GetEngine(ctx).Where(builder.Eq{"is_subscribed": true}).OrderBy("id")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	subscriptions := make([]*SavedSearchUser, 0, opts.PageSize)
+	return subscriptions, sess.Find(&subscriptions)
+}
+
+// UpdateSavedSearchUserNotified stores the time of the last digest of the subscription
+func UpdateSavedSearchUserNotified(ctx context.Context, su *SavedSearchUser) error {
+	_, err := db.GetEngine(ctx).ID(su.ID).Cols("last_notified_unix").Update(su)
+	return err
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/optional"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearch(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	create := func(t *testing.T, ownerID, teamID int64, name string, isPull bool) *issues_model.SavedSearch {
+		s := &issues_model.SavedSearch{OwnerID: ownerID, TeamID: teamID, CreatorID: 2, Name: name, IsPull: isPull, Query: "is:open"}
+		require.NoError(t, issues_model.CreateSavedSearch(t.Context(), s))
+		return s
+	}
+
+	own := create(t, 2, 0, "own", false)
+	org := create(t, 3, 0, "org", false)
+	otherTeam := create(t, 3, 7, "other team", true)
+	team := create(t, 3, 2, "team", false)
+
+	find := func(t *testing.T, opts issues_model.FindSavedSearchOptions) []int64 {
+		searches, err := db.Find[issues_model.SavedSearch](t.Context(), opts)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(searches))
+		for _, s := range searches {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+
+	t.Run("Visibility", func(t *testing.T) {
+		// user2 owns org3
+		assert.Equal(t, []int64{org.ID, otherTeam.ID, own.ID, team.ID}, find(t, issues_model.FindSavedSearchOptions{VisibleToUserID: 2}))
+		// user4 is a member of team 2
+		assert.Equal(t, []int64{org.ID, team.ID}, find(t, issues_model.FindSavedSearchOptions{VisibleToUserID: 4}))
+		// user28 is a member of team 12
+		assert.Equal(t, []int64{org.ID}, find(t, issues_model.FindSavedSearchOptions{VisibleToUserID: 28}))
+		assert.Empty(t, find(t, issues_model.FindSavedSearchOptions{VisibleToUserID: 5}))
+
+		assert.Equal(t, []int64{otherTeam.ID}, find(t, issues_model.FindSavedSearchOptions{OwnerID: 3, IsPull: optional.Some(true)}))
+	})
+
+	t.Run("PinAndSubscribe", func(t *testing.T) {
+		require.NoError(t, issues_model.SetSavedSearchPinned(t.Context(), org.ID, 4, true))
+		require.NoError(t, issues_model.SetSavedSearchPinned(t.Context(), team.ID, 4, true))
+		require.NoError(t, issues_model.SetSavedSearchPinned(t.Context(), team.ID, 4, false))
+		assert.Equal(t, []int64{org.ID}, find(t, issues_model.FindSavedSearchOptions{VisibleToUserID: 4, PinnedByUserID: 4}))
+
+		require.NoError(t, issues_model.SetSavedSearchSubscribed(t.Context(), team.ID, 4, true))
+		settings, err := issues_model.GetSavedSearchUsers(t.Context(), 4, []int64{org.ID, team.ID, own.ID})
+		require.NoError(t, err)
+		require.Len(t, settings, 2)
+		assert.True(t, settings[org.ID].IsPinned)
+		assert.False(t, settings[org.ID].IsSubscribed)
+		assert.False(t, settings[team.ID].IsPinned)
+		assert.True(t, settings[team.ID].IsSubscribed)
+		assert.NotZero(t, settings[team.ID].LastNotifiedUnix)
+
+		subscriptions, err := issues_model.FindSubscribedSavedSearchUsers(t.Context(), db.ListOptions{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		require.Len(t, subscriptions, 1)
+		assert.Equal(t, team.ID, subscriptions[0].SavedSearchID)
+	})
+
+	t.Run("Link", func(t *testing.T) {
+		assert.NoError(t, own.LoadOwner(t.Context()))
+		assert.Equal(t, "/issues?type=your_repositories&q=is%3Aopen", own.Link())
+		assert.NoError(t, otherTeam.LoadOwner(t.Context()))
+		assert.Equal(t, "/org/org3/pulls?q=is%3Aopen", otherTeam.Link())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, issues_model.DeleteSavedSearchesByTeamID(t.Context(), 2))
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearch{ID: team.ID})
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearchUser{SavedSearchID: team.ID})
+
+		require.NoError(t, issues_model.DeleteSavedSearchesByOwnerID(t.Context(), 3))
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearch{OwnerID: 3})
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearchUser{SavedSearchID: org.ID})
+
+		require.NoError(t, issues_model.DeleteSavedSearchByID(t.Context(), own.ID))
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearch{ID: own.ID})
+
+		_, err := issues_model.GetSavedSearchByID(t.Context(), own.ID)
+		assert.True(t, issues_model.IsErrSavedSearchNotExist(err))
+	})
+}
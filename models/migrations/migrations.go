@@ -402,6 +402,23 @@ func prepareMigrationTasks() []*migration {
 		newMigration(325, "Fix missed repo_id when migrate attachments", v1_26.FixMissedRepoIDWhenMigrateAttachments),
 		newMigration(326, "Migrate commit status target URL to use run ID and job ID", v1_26.FixCommitStatusTargetURLToUseRunAndJobID),
 		newMigration(327, "Add disabled state to action runners", v1_26.AddDisabledToActionRunner),
+		newMigration(328, "Add reusable workflow call support to action run jobs", v1_26.AddReusableWorkflowCallToActionRunJob),
+		newMigration(329, "Add deployment environments for actions", v1_26.AddActionsDeploymentEnvironments),
+		newMigration(330, "Add action cache table", v1_26.AddActionCacheTable),
+		newMigration(331, "Add actions approval gates", v1_26.AddActionsApprovalGates),
+		newMigration(332, "Add actions job timeouts", v1_26.AddActionsJobTimeouts),
+		newMigration(333, "Add actions runner groups", v1_26.AddActionsRunnerGroups),
+		newMigration(334, "Add ephemeral to action runner token", v1_26.AddEphemeralToActionRunnerToken),
+		newMigration(335, "Add action task summary", v1_26.AddActionTaskSummary),
+		newMigration(336, "Add action task annotation", v1_26.AddActionTaskAnnotation),
+		newMigration(337, "Add package remote", v1_26.AddPackageRemote),
+		newMigration(338, "Add package virtual registry", v1_26.AddPackageVirtual),
+		newMigration(339, "Add terraform state tables", v1_26.AddTerraformState),
+		newMigration(340, "Add remove untagged days to package cleanup rule", v1_26.AddRemoveUntaggedDaysToPackageCleanupRule),
+		newMigration(341, "Add private packages and package team permissions", v1_26.AddPackageAccessControl),
+		newMigration(342, "Add package vulnerability tables", v1_26.AddPackageVulnerabilityTables),
+		newMigration(343, "Add saved search tables", v1_26.AddSavedSearchTables),
+		newMigration(344, "Add code indexer ref patterns and ref name of indexer status", v1_26.AddCodeIndexerRefColumns),
 	}
 	return preparedMigrations
 }
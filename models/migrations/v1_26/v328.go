@@ -0,0 +1,18 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import "xorm.io/xorm"
+
+func AddReusableWorkflowCallToActionRunJob(x *xorm.Engine) error {
+	type ActionRunJob struct {
+		ParentJobID    int64 `xorm:"index NOT NULL DEFAULT 0"`
+		IsWorkflowCall bool  `xorm:"NOT NULL DEFAULT FALSE"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionRunJob))
+	return err
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsDeploymentEnvironments(x *xorm.Engine) error {
+	type ActionEnvironment struct {
+		ID                  int64              `xorm:"pk autoincr"`
+		RepoID              int64              `xorm:"UNIQUE(repo_name) NOT NULL"`
+		Name                string             `xorm:"NOT NULL"`
+		LowerName           string             `xorm:"UNIQUE(repo_name) NOT NULL"`
+		BranchFilters       []string           `xorm:"JSON TEXT"`
+		TagFilters          []string           `xorm:"JSON TEXT"`
+		RequiredReviewerIDs []int64            `xorm:"JSON TEXT"`
+		PreventSelfReview   bool               `xorm:"NOT NULL DEFAULT FALSE"`
+		Created             timeutil.TimeStamp `xorm:"created"`
+		Updated             timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionDeployment struct {
+		ID            int64  `xorm:"pk autoincr"`
+		RepoID        int64  `xorm:"index NOT NULL"`
+		EnvironmentID int64  `xorm:"index NOT NULL"`
+		RunID         int64  `xorm:"index NOT NULL"`
+		RunJobID      int64  `xorm:"UNIQUE(job_attempt) NOT NULL"`
+		Attempt       int64  `xorm:"UNIQUE(job_attempt) NOT NULL"`
+		Ref           string `xorm:"VARCHAR(255)"`
+		CommitSHA     string `xorm:"VARCHAR(64)"`
+		URL           string `xorm:"TEXT"`
+		CreatorID     int64  `xorm:"NOT NULL DEFAULT 0"`
+		ReviewState   int    `xorm:"NOT NULL DEFAULT 0"`
+		ReviewerID    int64  `xorm:"NOT NULL DEFAULT 0"`
+		ReviewComment string `xorm:"TEXT"`
+		Reviewed      timeutil.TimeStamp
+		Created       timeutil.TimeStamp `xorm:"created"`
+		Updated       timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionRunJob struct {
+		Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	}
+
+	// the unique indexes of secrets and variables are changed to include the environment
+	type Secret struct {
+		OwnerID       int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+
+	type ActionVariable struct {
+		OwnerID       int64  `xorm:"UNIQUE(owner_repo_name)"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name)"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionEnvironment), new(ActionDeployment), new(ActionRunJob), new(Secret), new(ActionVariable))
+	return err
+}
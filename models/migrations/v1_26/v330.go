@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionCacheTable(x *xorm.Engine) error {
+	type ActionCache struct {
+		ID           int64  `xorm:"pk autoincr"`
+		RepoID       int64  `xorm:"index NOT NULL"`
+		Ref          string `xorm:"VARCHAR(255) index NOT NULL"`
+		CacheKey     string `xorm:"VARCHAR(512) NOT NULL"`
+		Version      string `xorm:"VARCHAR(255) NOT NULL"`
+		RunID        int64  `xorm:"NOT NULL DEFAULT 0"`
+		Size         int64  `xorm:"NOT NULL DEFAULT 0"`
+		ReservedSize int64  `xorm:"NOT NULL DEFAULT 0"`
+		StoragePath  string
+		Complete     bool               `xorm:"index NOT NULL DEFAULT FALSE"`
+		LastUsed     timeutil.TimeStamp `xorm:"index"`
+		Created      timeutil.TimeStamp `xorm:"created"`
+		Updated      timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionCache))
+	return err
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsApprovalGates(x *xorm.Engine) error {
+	type ActionApproval struct {
+		ID            int64  `xorm:"pk autoincr"`
+		RepoID        int64  `xorm:"index NOT NULL"`
+		RunID         int64  `xorm:"index NOT NULL"`
+		RunJobID      int64  `xorm:"UNIQUE(job_attempt) NOT NULL"`
+		Attempt       int64  `xorm:"UNIQUE(job_attempt) NOT NULL"`
+		Message       string `xorm:"TEXT"`
+		State         int    `xorm:"index NOT NULL DEFAULT 0"`
+		ReviewerID    int64  `xorm:"NOT NULL DEFAULT 0"`
+		ReviewComment string `xorm:"TEXT"`
+		Reviewed      timeutil.TimeStamp
+		Deadline      timeutil.TimeStamp `xorm:"index"`
+		Created       timeutil.TimeStamp `xorm:"created"`
+		Updated       timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionRunJob struct {
+		IsApprovalGate bool `xorm:"NOT NULL DEFAULT FALSE"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionApproval), new(ActionRunJob))
+	return err
+}
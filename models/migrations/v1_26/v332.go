@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsJobTimeouts(x *xorm.Engine) error {
+	type ActionRunJob struct {
+		Queued     timeutil.TimeStamp `xorm:"index"`
+		StopReason int                `xorm:"NOT NULL DEFAULT 0"`
+	}
+	type ActionTask struct {
+		Deadline timeutil.TimeStamp `xorm:"index"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(ActionRunJob), new(ActionTask))
+	return err
+}
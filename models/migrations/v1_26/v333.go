@@ -0,0 +1,40 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsRunnerGroups(x *xorm.Engine) error {
+	type ActionRunnerGroup struct {
+		ID          int64
+		OwnerID     int64    `xorm:"UNIQUE(owner_name) NOT NULL"`
+		Name        string   `xorm:"UNIQUE(owner_name) VARCHAR(255) NOT NULL"`
+		Description string   `xorm:"TEXT"`
+		RepoIDs     []int64  `xorm:"JSON TEXT"`
+		Workflows   []string `xorm:"JSON TEXT"`
+
+		Created timeutil.TimeStamp `xorm:"created"`
+		Updated timeutil.TimeStamp `xorm:"updated"`
+	}
+	type ActionRunner struct {
+		GroupID int64 `xorm:"index NOT NULL DEFAULT 0"`
+	}
+	type ActionRunJob struct {
+		RunsOnGroup string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	}
+
+	if err := x.Sync(new(ActionRunnerGroup)); err != nil {
+		return err
+	}
+	// the unique constraints of the existing tables aren't in the partial structs, so they are ignored to keep them
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(ActionRunner), new(ActionRunJob))
+	return err
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddEphemeralToActionRunnerToken(x *xorm.Engine) error {
+	type ActionRunnerToken struct {
+		Ephemeral bool               `xorm:"NOT NULL DEFAULT false"`
+		Expires   timeutil.TimeStamp `xorm:"index NOT NULL DEFAULT 0"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(ActionRunnerToken))
+	return err
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionTaskSummary(x *xorm.Engine) error {
+	type ActionTaskSummary struct {
+		ID        int64
+		TaskID    int64  `xorm:"index unique(task_step)"`
+		StepIndex int64  `xorm:"unique(task_step)"`
+		RepoID    int64  `xorm:"index"`
+		Filename  string `xorm:"VARCHAR(255)"`
+		Size      int64
+		Created   timeutil.TimeStamp `xorm:"created"`
+		Updated   timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionTaskSummary))
+	return err
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionTaskAnnotation(x *xorm.Engine) error {
+	type ActionTaskAnnotation struct {
+		ID        int64
+		TaskID    int64  `xorm:"index"`
+		JobID     int64  `xorm:"index"`
+		RunID     int64  `xorm:"index"`
+		RepoID    int64  `xorm:"index"`
+		CommitSHA string `xorm:"index VARCHAR(64)"`
+		LogIndex  int64
+		Level     int
+		Path      string `xorm:"VARCHAR(1024)"`
+		Line      int64
+		EndLine   int64
+		Column    int64
+		EndColumn int64
+		Title     string             `xorm:"VARCHAR(255)"`
+		Message   string             `xorm:"TEXT"`
+		Created   timeutil.TimeStamp `xorm:"created"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionTaskAnnotation))
+	return err
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageRemote(x *xorm.Engine) error {
+	type PackageRemote struct {
+		ID                int64              `xorm:"pk autoincr"`
+		Enabled           bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		OwnerID           int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		Type              string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		URL               string             `xorm:"VARCHAR(2048) NOT NULL DEFAULT ''"`
+		Username          string             `xorm:"NOT NULL DEFAULT ''"`
+		MetadataTTL       int64              `xorm:"NOT NULL DEFAULT 0"`
+		PasswordEncrypted string             `xorm:"TEXT"`
+		CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix       timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(PackageRemote))
+	return err
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageVirtual(x *xorm.Engine) error {
+	type VirtualSource struct {
+		OwnerID int64 `json:"owner_id"`
+		Remote  bool  `json:"remote"`
+	}
+
+	type PackageVirtual struct {
+		ID              int64              `xorm:"pk autoincr"`
+		Enabled         bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		OwnerID         int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		Type            string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Sources         []*VirtualSource   `xorm:"JSON TEXT"`
+		InternalPattern string             `xorm:"NOT NULL DEFAULT ''"`
+		CreatedUnix     timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix     timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(PackageVirtual))
+	return err
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddTerraformState(x *xorm.Engine) error {
+	type TerraformState struct {
+		ID            int64  `xorm:"pk autoincr"`
+		OwnerID       int64  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		RepoID        int64  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		Name          string `xorm:"VARCHAR(255) UNIQUE(s) NOT NULL"`
+		LatestVersion int64  `xorm:"NOT NULL DEFAULT 0"`
+		LockID        string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+		LockInfo      string `xorm:"TEXT"`
+		LockerID      int64  `xorm:"NOT NULL DEFAULT 0"`
+		LockedUnix    timeutil.TimeStamp
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated NOT NULL"`
+	}
+
+	type TerraformStateVersion struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		StateID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Version     int64              `xorm:"UNIQUE(s) NOT NULL"`
+		Serial      int64              `xorm:"NOT NULL DEFAULT 0"`
+		Lineage     string             `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+		Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(TerraformState), new(TerraformStateVersion))
+	return err
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import "xorm.io/xorm"
+
+func AddRemoveUntaggedDaysToPackageCleanupRule(x *xorm.Engine) error {
+	type PackageCleanupRule struct {
+		RemoveUntaggedDays int `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(PackageCleanupRule))
+	return err
+}
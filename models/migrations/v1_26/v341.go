@@ -0,0 +1,30 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import "xorm.io/xorm"
+
+func AddPackageAccessControl(x *xorm.Engine) error {
+	type Package struct {
+		IsPrivate              bool `xorm:"NOT NULL DEFAULT false"`
+		InheritRepoPermissions bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	type PackageTeam struct {
+		ID         int64 `xorm:"pk autoincr"`
+		PackageID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		TeamID     int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		AccessMode int   `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	if err := x.Sync(new(PackageTeam)); err != nil {
+		return err
+	}
+	// the unique constraint of the packages isn't in the partial struct, so it is ignored to keep it
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(Package))
+	return err
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageVulnerabilityTables(x *xorm.Engine) error {
+	type PackageAdvisory struct {
+		ID           int64  `xorm:"pk autoincr"`
+		AdvisoryID   string `xorm:"INDEX NOT NULL"`
+		Ecosystem    string `xorm:"INDEX(s) NOT NULL"`
+		PackageName  string `xorm:"INDEX(s) NOT NULL"`
+		Summary      string `xorm:"TEXT"`
+		Severity     string
+		Affected     string             `xorm:"LONGTEXT NOT NULL"`
+		ModifiedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type PackageVulnerability struct {
+		ID                int64  `xorm:"pk autoincr"`
+		VersionID         int64  `xorm:"INDEX NOT NULL"`
+		AdvisoryID        string `xorm:"NOT NULL"`
+		DependencyName    string `xorm:"NOT NULL"`
+		DependencyVersion string `xorm:"NOT NULL"`
+		FixedVersion      string
+		Summary           string `xorm:"TEXT"`
+		Severity          string
+		CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+
+	return x.Sync(new(PackageAdvisory), new(PackageVulnerability))
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddSavedSearchTables(x *xorm.Engine) error {
+	type SavedSearch struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL"`
+		TeamID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		CreatorID   int64              `xorm:"NOT NULL"`
+		Name        string             `xorm:"NOT NULL"`
+		IsPull      bool               `xorm:"NOT NULL DEFAULT false"`
+		Query       string             `xorm:"TEXT NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL"`
+	}
+
+	type SavedSearchUser struct {
+		ID               int64              `xorm:"pk autoincr"`
+		SavedSearchID    int64              `xorm:"UNIQUE(s) NOT NULL"`
+		UserID           int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		IsPinned         bool               `xorm:"NOT NULL DEFAULT false"`
+		IsSubscribed     bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		LastNotifiedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(SavedSearch), new(SavedSearchUser))
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import "xorm.io/xorm"
+
+func AddCodeIndexerRefColumns(x *xorm.Engine) error {
+	type Repository struct {
+		CodeIndexerRefPatterns []string `xorm:"TEXT JSON"`
+	}
+
+	type RepoIndexerStatus struct {
+		RefName string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+		IgnoreConstrains:  true,
+	}, new(Repository), new(RepoIndexerStatus))
+	return err
+}
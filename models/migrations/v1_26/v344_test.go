@@ -0,0 +1,51 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/migrations/base"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddCodeIndexerRefColumns(t *testing.T) {
+	type Repository struct {
+		ID        int64  `xorm:"pk autoincr"`
+		OwnerID   int64  `xorm:"UNIQUE(s) index"`
+		LowerName string `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	}
+
+	type RepoIndexerStatus struct {
+		ID          int64  `xorm:"pk autoincr"`
+		RepoID      int64  `xorm:"INDEX(s)"`
+		CommitSha   string `xorm:"VARCHAR(64)"`
+		IndexerType int    `xorm:"INDEX(s) NOT NULL DEFAULT 0"`
+	}
+
+	x, deferable := base.PrepareTestEnv(t, 0, new(Repository), new(RepoIndexerStatus))
+	defer deferable()
+
+	_, err := x.Insert(&RepoIndexerStatus{RepoID: 1, CommitSha: "65f1bf27bc3bf70f64657658635e66094edbcb4d"})
+	require.NoError(t, err)
+
+	tablesBefore := base.LoadTableSchemasMap(t, x)
+	require.NoError(t, AddCodeIndexerRefColumns(x))
+
+	var refName string
+	has, err := x.SQL("SELECT ref_name FROM repo_indexer_status WHERE id = ?", 1).Get(&refName)
+	require.NoError(t, err)
+	require.True(t, has)
+	assert.Empty(t, refName)
+
+	// the indexes of the tables are kept
+	tables := base.LoadTableSchemasMap(t, x)
+	assert.NotNil(t, tables["repository"].GetColumn("code_indexer_ref_patterns"))
+	for _, name := range []string{"repository", "repo_indexer_status"} {
+		assert.NotEmpty(t, tables[name].Indexes)
+		assert.Len(t, tables[name].Indexes, len(tablesBefore[name].Indexes))
+	}
+}
@@ -31,6 +31,9 @@ type RecipeSearchOptions struct {
 	Version string
 	User    string
 	Channel string
+	// private packages are not found, except the ones listed in PrivateIDs
+	HidePrivate bool
+	PrivateIDs  []int64
 }
 
 // SearchRecipes gets all recipes matching the search options
@@ -42,6 +45,14 @@ func SearchRecipes(ctx context.Context, opts *RecipeSearchOptions) ([]string, er
 		"package_version.is_internal": false,
 	}
 
+	if opts.HidePrivate {
+		var privateCond builder.Cond = builder.Eq{"package.is_private": false}
+		if len(opts.PrivateIDs) > 0 {
+			privateCond = privateCond.Or(builder.In("package.id", opts.PrivateIDs))
+		}
+		cond = cond.And(privateCond)
+	}
+
 	if opts.Name != "" {
 		cond = cond.And(buildCondition("package.lower_name", strings.ToLower(opts.Name)))
 	}
@@ -19,6 +19,9 @@ type FileSearchOptions struct {
 	Channel  string
 	Subdir   string
 	Filename string
+	// private packages are not found, except the ones listed in PrivateIDs
+	HidePrivate bool
+	PrivateIDs  []int64
 }
 
 // SearchFiles gets all files matching the search options
@@ -35,6 +38,14 @@ func SearchFiles(ctx context.Context, opts *FileSearchOptions) ([]*packages.Pack
 		})
 	}
 
+	if opts.HidePrivate {
+		var privateCond builder.Cond = builder.Eq{"package.is_private": false}
+		if len(opts.PrivateIDs) > 0 {
+			privateCond = privateCond.Or(builder.In("package.id", opts.PrivateIDs))
+		}
+		cond = cond.And(privateCond)
+	}
+
 	var versionPropsCond builder.Cond = builder.Eq{
 		"package_property.ref_type": packages.PropertyTypePackage,
 		"package_property.name":     conda_module.PropertyChannel,
@@ -27,6 +27,7 @@ type BlobSearchOptions struct {
 	IsManifest bool
 	OnlyLead   bool
 	Repository string
+	Subject    string // the digest of the manifest the matching manifests refer to
 }
 
 func (opts *BlobSearchOptions) toConds() builder.Cond {
@@ -67,6 +68,15 @@ func (opts *BlobSearchOptions) toConds() builder.Cond {
 
 		cond = cond.And(builder.In("package.id", builder.Select("package_property.ref_id").Where(propsCond).From("package_property")))
 	}
+	if opts.Subject != "" {
+		var propsCond builder.Cond = builder.Eq{
+			"package_property.ref_type": packages.PropertyTypeVersion,
+			"package_property.name":     container_module.PropertyManifestSubject,
+			"package_property.value":    opts.Subject,
+		}
+
+		cond = cond.And(builder.In("package_version.id", builder.Select("package_property.ref_id").Where(propsCond).From("package_property")))
+	}
 
 	return cond
 }
@@ -271,7 +281,8 @@ func GetRepositories(ctx context.Context, actor *user_model.User, n int, last st
 		actor = nil
 	}
 
-	cond = cond.And(user_model.BuildCanSeeUserCondition(actor))
+	cond = cond.And(user_model.BuildCanSeeUserCondition(actor)).
+		And(packages.BuildCanSeePrivatePackageCondition(actor))
 
 	sess := db.GetEngine(ctx).
 		Table("package").
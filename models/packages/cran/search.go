@@ -21,6 +21,9 @@ type SearchOptions struct {
 	Platform string
 	RVersion string
 	Filename string
+	// private packages are not found, except the ones listed in PrivateIDs
+	HidePrivate bool
+	PrivateIDs  []int64
 }
 
 func (opts *SearchOptions) toConds() builder.Cond {
@@ -34,6 +37,14 @@ func (opts *SearchOptions) toConds() builder.Cond {
 		cond = cond.And(builder.Eq{"package_file.lower_name": strings.ToLower(opts.Filename)})
 	}
 
+	if opts.HidePrivate {
+		var privateCond builder.Cond = builder.Eq{"package.is_private": false}
+		if len(opts.PrivateIDs) > 0 {
+			privateCond = privateCond.Or(builder.In("package.id", opts.PrivateIDs))
+		}
+		cond = cond.And(privateCond)
+	}
+
 	var propsCond builder.Cond = builder.Eq{
 		"package_property.ref_type": packages.PropertyTypeFile,
 	}
@@ -19,6 +19,7 @@ type PackageSearchOptions struct {
 	Distribution string
 	Component    string
 	Architecture string
+	HidePrivate  bool // the files of private packages are not found
 }
 
 func (opts *PackageSearchOptions) toCond() builder.Cond {
@@ -29,6 +30,9 @@ func (opts *PackageSearchOptions) toCond() builder.Cond {
 		"package.is_internal":         false,
 		"package_version.is_internal": false,
 	}
+	if opts.HidePrivate {
+		cond = cond.And(builder.Eq{"package.is_private": false})
+	}
 
 	props := make(map[string]string)
 	if opts.Distribution != "" {
@@ -66,5 +66,12 @@ func toConds(opts *packages_model.PackageSearchOptions) builder.Cond {
 			cond = cond.And(builder.Like{"package.lower_name", strings.ToLower(opts.Name.Value)})
 		}
 	}
+	if opts.HidePrivate {
+		var privateCond builder.Cond = builder.Eq{"package.is_private": false}
+		if len(opts.PrivateIDs) > 0 {
+			privateCond = privateCond.Or(builder.In("package.id", opts.PrivateIDs))
+		}
+		cond = cond.And(privateCond)
+	}
 	return cond
 }
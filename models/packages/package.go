@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/util"
 
 	"xorm.io/builder"
@@ -43,6 +45,7 @@ const (
 	TypeGeneric   Type = "generic"
 	TypeGo        Type = "go"
 	TypeHelm      Type = "helm"
+	TypeHex       Type = "hex"
 	TypeMaven     Type = "maven"
 	TypeNpm       Type = "npm"
 	TypeNuGet     Type = "nuget"
@@ -51,6 +54,7 @@ const (
 	TypeRpm       Type = "rpm"
 	TypeRubyGems  Type = "rubygems"
 	TypeSwift     Type = "swift"
+	TypeTerraform Type = "terraform"
 	TypeVagrant   Type = "vagrant"
 )
 
@@ -68,6 +72,7 @@ var TypeList = []Type{
 	TypeGeneric,
 	TypeGo,
 	TypeHelm,
+	TypeHex,
 	TypeMaven,
 	TypeNpm,
 	TypeNuGet,
@@ -76,6 +81,7 @@ var TypeList = []Type{
 	TypeRpm,
 	TypeRubyGems,
 	TypeSwift,
+	TypeTerraform,
 	TypeVagrant,
 }
 
@@ -108,6 +114,8 @@ func (pt Type) Name() string {
 		return "Go"
 	case TypeHelm:
 		return "Helm"
+	case TypeHex:
+		return "Hex"
 	case TypeMaven:
 		return "Maven"
 	case TypeNpm:
@@ -124,6 +132,8 @@ func (pt Type) Name() string {
 		return "RubyGems"
 	case TypeSwift:
 		return "Swift"
+	case TypeTerraform:
+		return "Terraform"
 	case TypeVagrant:
 		return "Vagrant"
 	}
@@ -159,6 +169,8 @@ func (pt Type) SVGName() string {
 		return "gitea-go"
 	case TypeHelm:
 		return "gitea-helm"
+	case TypeHex:
+		return "gitea-hex"
 	case TypeMaven:
 		return "gitea-maven"
 	case TypeNpm:
@@ -175,6 +187,8 @@ func (pt Type) SVGName() string {
 		return "gitea-rubygems"
 	case TypeSwift:
 		return "gitea-swift"
+	case TypeTerraform:
+		return "gitea-terraform"
 	case TypeVagrant:
 		return "gitea-vagrant"
 	}
@@ -191,6 +205,10 @@ type Package struct {
 	LowerName        string `xorm:"UNIQUE(s) INDEX NOT NULL"`
 	SemverCompatible bool   `xorm:"NOT NULL DEFAULT false"`
 	IsInternal       bool   `xorm:"NOT NULL DEFAULT false"`
+	// IsPrivate hides the package from users which are no members of the owner, even if the owner is visible
+	IsPrivate bool `xorm:"NOT NULL DEFAULT false"`
+	// InheritRepoPermissions grants the users with access to the linked repository the same access to the package
+	InheritRepoPermissions bool `xorm:"NOT NULL DEFAULT false"`
 }
 
 // TryInsertPackage inserts a package. If a package exists already, ErrDuplicatePackage is returned
@@ -218,10 +236,47 @@ func TryInsertPackage(ctx context.Context, p *Package) (*Package, error) {
 
 // DeletePackageByID deletes a package by id
 func DeletePackageByID(ctx context.Context, packageID int64) error {
+	if _, err := db.GetEngine(ctx).Where("package_id = ?", packageID).Delete(&PackageTeam{}); err != nil {
+		return err
+	}
 	_, err := db.GetEngine(ctx).ID(packageID).Delete(&Package{})
 	return err
 }
 
+// UpdatePackageAccessSettings updates the visibility and the repository permission inheritance of the package
+func UpdatePackageAccessSettings(ctx context.Context, p *Package) error {
+	_, err := db.GetEngine(ctx).ID(p.ID).Cols("is_private", "inherit_repo_permissions").Update(p)
+	return err
+}
+
+// GetPrivatePackagesByOwner gets all private packages of the owner
+func GetPrivatePackagesByOwner(ctx context.Context, ownerID int64) ([]*Package, error) {
+	ps := make([]*Package, 0, 10)
+	return ps, db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID, "is_private": true}).Find(&ps)
+}
+
+// BuildCanSeePrivatePackageCondition returns the condition for the packages of any owner the actor can see despite the private flag.
+// These are the packages of the actor and its organizations and the ones its teams have access to.
+// The permissions inherited from the linked repositories are not considered.
+func BuildCanSeePrivatePackageCondition(actor *user_model.User) builder.Cond {
+	var cond builder.Cond = builder.Eq{"package.is_private": false}
+	if actor == nil || actor.IsGhost() || actor.ID <= 0 {
+		return cond
+	}
+	if actor.IsAdmin {
+		return nil
+	}
+	return cond.
+		Or(builder.Eq{"package.owner_id": actor.ID}).
+		Or(builder.In("package.owner_id", builder.Select("org_id").From("org_user").Where(builder.Eq{"uid": actor.ID}))).
+		Or(builder.In("package.id", builder.
+			Select("package_team.package_id").
+			From("package_team").
+			InnerJoin("team_user", "team_user.team_id = package_team.team_id").
+			Where(builder.Eq{"team_user.uid": actor.ID}.And(builder.Gte{"package_team.access_mode": perm.AccessModeRead})),
+		))
+}
+
 // SetRepositoryLink sets the linked repository
 func SetRepositoryLink(ctx context.Context, packageID, repoID int64) error {
 	_, err := db.GetEngine(ctx).ID(packageID).Cols("repo_id").Update(&Package{RepoID: repoID})
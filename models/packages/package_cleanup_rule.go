@@ -34,6 +34,7 @@ type PackageCleanupRule struct {
 	RemovePattern        string             `xorm:"NOT NULL DEFAULT ''"`
 	RemovePatternMatcher *regexp.Regexp     `xorm:"-"`
 	MatchFullName        bool               `xorm:"NOT NULL DEFAULT false"`
+	RemoveUntaggedDays   int                `xorm:"NOT NULL DEFAULT 0"` // container only: remove untagged manifests older than this, 0 to disable
 	CreatedUnix          timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
 	UpdatedUnix          timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
 }
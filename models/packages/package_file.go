@@ -131,6 +131,7 @@ type PackageFileSearchOptions struct {
 	OlderThan     time.Duration
 	HashAlgorithm string
 	Hash          string
+	HidePrivate   bool // the files of private packages are not found
 	db.Paginator
 }
 
@@ -149,6 +150,9 @@ func (opts *PackageFileSearchOptions) toConds() builder.Cond {
 		if opts.PackageType != "" && opts.PackageType != "all" {
 			versionCond = versionCond.And(builder.Eq{"package.type": opts.PackageType})
 		}
+		if opts.HidePrivate {
+			versionCond = versionCond.And(builder.Eq{"package.is_private": false})
+		}
 
 		in := builder.
 			Select("package_version.id").
@@ -0,0 +1,136 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/secret"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var ErrPackageRemoteNotExist = util.NewNotExistErrorf("package remote does not exist")
+
+// PropertyCachedFromRemote is the package property which marks a package as fetched from the upstream of a remote.
+// The value is the url of the upstream.
+const PropertyCachedFromRemote = "remote.cached_from"
+
+// RemoteTypes are the package types which can be proxied from an upstream registry
+var RemoteTypes = []Type{
+	TypeContainer,
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// IsRemoteSupported returns true if the package type can be proxied from an upstream registry
+func IsRemoteSupported(t Type) bool {
+	for _, rt := range RemoteTypes {
+		if rt == t {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	db.RegisterModel(new(PackageRemote))
+}
+
+// PackageRemote represents an upstream registry whose packages are fetched and cached on demand
+// if they don't exist for the owner and package type.
+type PackageRemote struct {
+	ID          int64  `xorm:"pk autoincr"`
+	Enabled     bool   `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID     int64  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type        Type   `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	URL         string `xorm:"VARCHAR(2048) NOT NULL DEFAULT ''"`
+	Username    string `xorm:"NOT NULL DEFAULT ''"`
+	MetadataTTL int64  `xorm:"NOT NULL DEFAULT 0"` // seconds to cache the metadata of the upstream before asking it again
+
+	// PasswordEncrypted should be accessed using Password() and SetPassword()
+	PasswordEncrypted string `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// Password returns the decrypted password or token to access the upstream
+func (pr *PackageRemote) Password() (string, error) {
+	if pr.PasswordEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, pr.PasswordEncrypted)
+}
+
+// SetPassword encrypts and sets the password or token to access the upstream
+func (pr *PackageRemote) SetPassword(cleartext string) error {
+	if cleartext == "" {
+		pr.PasswordEncrypted = ""
+		return nil
+	}
+	ciphertext, err := secret.EncryptSecret(setting.SecretKey, cleartext)
+	if err != nil {
+		return err
+	}
+	pr.PasswordEncrypted = ciphertext
+	return nil
+}
+
+func InsertRemote(ctx context.Context, pr *PackageRemote) (*PackageRemote, error) {
+	return pr, db.Insert(ctx, pr)
+}
+
+func GetRemoteByID(ctx context.Context, id int64) (*PackageRemote, error) {
+	pr := &PackageRemote{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(pr)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return pr, nil
+}
+
+// GetEnabledRemote returns the enabled remote of the owner for the package type
+func GetEnabledRemote(ctx context.Context, ownerID int64, packageType Type) (*PackageRemote, error) {
+	pr := &PackageRemote{}
+
+	has, err := db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ? AND enabled = ?", ownerID, packageType, true).
+		Get(pr)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return pr, nil
+}
+
+func UpdateRemote(ctx context.Context, pr *PackageRemote) error {
+	_, err := db.GetEngine(ctx).ID(pr.ID).AllCols().Update(pr)
+	return err
+}
+
+func GetRemotesByOwner(ctx context.Context, ownerID int64) ([]*PackageRemote, error) {
+	prs := make([]*PackageRemote, 0, len(RemoteTypes))
+	return prs, db.GetEngine(ctx).Where("owner_id = ?", ownerID).Find(&prs)
+}
+
+func DeleteRemoteByID(ctx context.Context, remoteID int64) error {
+	_, err := db.GetEngine(ctx).ID(remoteID).Delete(&PackageRemote{})
+	return err
+}
+
+func HasOwnerRemoteForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Exist(&PackageRemote{})
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/perm"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageTeam))
+}
+
+// PackageTeam grants the members of a team of the owner organization access to a single package
+type PackageTeam struct {
+	ID         int64           `xorm:"pk autoincr"`
+	PackageID  int64           `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	TeamID     int64           `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	AccessMode perm.AccessMode `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// GetPackageTeams gets all team permissions of the package
+func GetPackageTeams(ctx context.Context, packageID int64) ([]*PackageTeam, error) {
+	pts := make([]*PackageTeam, 0, 5)
+	return pts, db.GetEngine(ctx).Where("package_id = ?", packageID).OrderBy("team_id").Find(&pts)
+}
+
+// SetPackageTeam grants the team the access mode to the package, an existing permission of the team gets replaced
+func SetPackageTeam(ctx context.Context, packageID, teamID int64, mode perm.AccessMode) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		e := db.GetEngine(ctx)
+
+		pt := &PackageTeam{}
+		has, err := e.Where(builder.Eq{"package_id": packageID, "team_id": teamID}).Get(pt)
+		if err != nil {
+			return err
+		}
+		if has {
+			pt.AccessMode = mode
+			_, err = e.ID(pt.ID).Cols("access_mode").Update(pt)
+			return err
+		}
+
+		_, err = e.Insert(&PackageTeam{PackageID: packageID, TeamID: teamID, AccessMode: mode})
+		return err
+	})
+}
+
+// RemovePackageTeam removes the permission of the team from the package
+func RemovePackageTeam(ctx context.Context, packageID, teamID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"package_id": packageID, "team_id": teamID}).Delete(&PackageTeam{})
+	return err
+}
+
+// GetUserPackageTeamAccessMode returns the highest access mode the teams of the user grant to the package
+func GetUserPackageTeamAccessMode(ctx context.Context, packageID, userID int64) (perm.AccessMode, error) {
+	pts := make([]*PackageTeam, 0, 5)
+	err := db.GetEngine(ctx).
+		Join("INNER", "team_user", "team_user.team_id = package_team.team_id").
+		Where(builder.Eq{"package_team.package_id": packageID, "team_user.uid": userID}).
+		Find(&pts)
+	if err != nil {
+		return perm.AccessModeNone, err
+	}
+
+	mode := perm.AccessModeNone
+	for _, pt := range pts {
+		mode = max(mode, pt.AccessMode)
+	}
+	return mode, nil
+}
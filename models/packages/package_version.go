@@ -195,6 +195,8 @@ type PackageSearchOptions struct {
 	IsInternal      optional.Option[bool]
 	HasFileWithName string                // only results are found which are associated with a file with the specific name
 	HasFiles        optional.Option[bool] // only results are found which have associated files
+	HidePrivate     bool                  // private packages are not found, except the ones listed in PrivateIDs
+	PrivateIDs      []int64
 	Sort            VersionSort
 	Paginator       db.Paginator
 }
@@ -260,6 +262,14 @@ func (opts *PackageSearchOptions) ToConds() builder.Cond {
 		cond = cond.And(builder.Exists(builder.Select("package_file.id").From("package_file").Where(fileCond)))
 	}
 
+	if opts.HidePrivate {
+		var privateCond builder.Cond = builder.Eq{"package.is_private": false}
+		if len(opts.PrivateIDs) > 0 {
+			privateCond = privateCond.Or(builder.In("package.id", opts.PrivateIDs))
+		}
+		cond = cond.And(privateCond)
+	}
+
 	if opts.HasFiles.Has() {
 		filesCond := builder.Exists(builder.Select("package_file.id").From("package_file").Where(builder.Expr("package_file.version_id = package_version.id")))
 
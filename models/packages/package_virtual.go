@@ -0,0 +1,116 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var ErrPackageVirtualNotExist = util.NewNotExistErrorf("package virtual registry does not exist")
+
+// VirtualTypes are the package types which can be resolved by a virtual registry
+var VirtualTypes = RemoteTypes
+
+// IsVirtualSupported returns true if the package type can be resolved by a virtual registry
+func IsVirtualSupported(t Type) bool {
+	return IsRemoteSupported(t)
+}
+
+func init() {
+	db.RegisterModel(new(PackageVirtual))
+}
+
+// VirtualSource is an entry of the ordered list of sources of a virtual registry
+type VirtualSource struct {
+	OwnerID int64 `json:"owner_id"`
+	// Remote is true if the packages are fetched through the remote of the owner instead of the local packages of the owner
+	Remote bool `json:"remote"`
+}
+
+// PackageVirtual represents a virtual registry of an owner which resolves the requests for a package type
+// against the owner itself and an ordered list of sources, the first source having the package wins.
+type PackageVirtual struct {
+	ID      int64            `xorm:"pk autoincr"`
+	Enabled bool             `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID int64            `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type    Type             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Sources []*VirtualSource `xorm:"JSON TEXT"`
+	// InternalPattern matches the names of packages which are never resolved by remote sources, even if no local source has them
+	InternalPattern string `xorm:"NOT NULL DEFAULT ''"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+
+	InternalPatternMatcher *regexp.Regexp `xorm:"-"`
+}
+
+func (pv *PackageVirtual) CompiledPattern() error {
+	if pv.InternalPatternMatcher != nil || pv.InternalPattern == "" {
+		return nil
+	}
+
+	var err error
+	pv.InternalPatternMatcher, err = regexp.Compile(fmt.Sprintf(`(?i)\A%s\z`, pv.InternalPattern))
+	return err
+}
+
+func InsertVirtual(ctx context.Context, pv *PackageVirtual) (*PackageVirtual, error) {
+	return pv, db.Insert(ctx, pv)
+}
+
+func GetVirtualByID(ctx context.Context, id int64) (*PackageVirtual, error) {
+	pv := &PackageVirtual{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(pv)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageVirtualNotExist
+	}
+	return pv, nil
+}
+
+// GetEnabledVirtual returns the enabled virtual registry of the owner for the package type
+func GetEnabledVirtual(ctx context.Context, ownerID int64, packageType Type) (*PackageVirtual, error) {
+	pv := &PackageVirtual{}
+
+	has, err := db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ? AND enabled = ?", ownerID, packageType, true).
+		Get(pv)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageVirtualNotExist
+	}
+	return pv, nil
+}
+
+func UpdateVirtual(ctx context.Context, pv *PackageVirtual) error {
+	_, err := db.GetEngine(ctx).ID(pv.ID).AllCols().Update(pv)
+	return err
+}
+
+func GetVirtualsByOwner(ctx context.Context, ownerID int64) ([]*PackageVirtual, error) {
+	pvs := make([]*PackageVirtual, 0, len(VirtualTypes))
+	return pvs, db.GetEngine(ctx).Where("owner_id = ?", ownerID).Find(&pvs)
+}
+
+func DeleteVirtualByID(ctx context.Context, virtualID int64) error {
+	_, err := db.GetEngine(ctx).ID(virtualID).Delete(&PackageVirtual{})
+	return err
+}
+
+func HasOwnerVirtualForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Exist(&PackageVirtual{})
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageAdvisory))
+	db.RegisterModel(new(PackageVulnerability))
+}
+
+// PackageAdvisory is an imported advisory of the vulnerability database affecting a package of an ecosystem
+type PackageAdvisory struct {
+	ID           int64  `xorm:"pk autoincr"`
+	AdvisoryID   string `xorm:"INDEX NOT NULL"`
+	Ecosystem    string `xorm:"INDEX(s) NOT NULL"`
+	PackageName  string `xorm:"INDEX(s) NOT NULL"`
+	Summary      string `xorm:"TEXT"`
+	Severity     string
+	Affected     string             `xorm:"LONGTEXT NOT NULL"` // JSON of the affected versions
+	ModifiedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// ReplaceAdvisory replaces all entries of an advisory, no entries remove the advisory
+func ReplaceAdvisory(ctx context.Context, advisoryID string, pas []*PackageAdvisory) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("advisory_id = ?", advisoryID).Delete(&PackageAdvisory{}); err != nil {
+			return err
+		}
+		if len(pas) == 0 {
+			return nil
+		}
+		_, err := db.GetEngine(ctx).Insert(&pas)
+		return err
+	})
+}
+
+// GetAdvisoriesByPackage gets all advisories affecting the package of the ecosystem
+func GetAdvisoriesByPackage(ctx context.Context, ecosystem, packageName string) ([]*PackageAdvisory, error) {
+	pas := make([]*PackageAdvisory, 0, 5)
+	return pas, db.GetEngine(ctx).Where(builder.Eq{"ecosystem": ecosystem, "package_name": packageName}).Find(&pas)
+}
+
+// AdvisoryStats are statistics about the imported advisories
+type AdvisoryStats struct {
+	Count        int64
+	LastModified timeutil.TimeStamp
+}
+
+// GetAdvisoryStats gets statistics about the imported advisories
+func GetAdvisoryStats(ctx context.Context) (*AdvisoryStats, error) {
+	count, err := db.GetEngine(ctx).Distinct("advisory_id").Count(&PackageAdvisory{})
+	if err != nil {
+		return nil, err
+	}
+
+	pa := &PackageAdvisory{}
+	if _, err := db.GetEngine(ctx).Desc("modified_unix").Get(pa); err != nil {
+		return nil, err
+	}
+
+	return &AdvisoryStats{Count: count, LastModified: pa.ModifiedUnix}, nil
+}
+
+// PackageVulnerability is a finding of the vulnerability scan of a package version
+type PackageVulnerability struct {
+	ID                int64  `xorm:"pk autoincr"`
+	VersionID         int64  `xorm:"INDEX NOT NULL"`
+	AdvisoryID        string `xorm:"NOT NULL"`
+	DependencyName    string `xorm:"NOT NULL"`
+	DependencyVersion string `xorm:"NOT NULL"`
+	FixedVersion      string
+	Summary           string `xorm:"TEXT"`
+	Severity          string
+	CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+// ReplaceVulnerabilities replaces the findings of the package version
+func ReplaceVulnerabilities(ctx context.Context, versionID int64, pvs []*PackageVulnerability) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := DeleteVulnerabilitiesByVersionID(ctx, versionID); err != nil {
+			return err
+		}
+		if len(pvs) == 0 {
+			return nil
+		}
+		for _, pv := range pvs {
+			pv.VersionID = versionID
+		}
+		_, err := db.GetEngine(ctx).Insert(&pvs)
+		return err
+	})
+}
+
+// GetVulnerabilitiesByVersionID gets the findings of the package version
+func GetVulnerabilitiesByVersionID(ctx context.Context, versionID int64) ([]*PackageVulnerability, error) {
+	pvs := make([]*PackageVulnerability, 0, 5)
+	return pvs, db.GetEngine(ctx).Where("version_id = ?", versionID).OrderBy("dependency_name, advisory_id").Find(&pvs)
+}
+
+// DeleteVulnerabilitiesByVersionID deletes the findings of the package version
+func DeleteVulnerabilitiesByVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageVulnerability{})
+	return err
+}
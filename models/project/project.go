@@ -332,6 +332,20 @@ func GetAllProjectsIDsByOwnerIDAndType(ctx context.Context, ownerID int64, proje
 	return projects, db.GetEngine(ctx).Table(&Project{}).Where("owner_id=? AND type=?", ownerID, projectType).Cols("id").Find(&projects)
 }
 
+// GetProjectIDsByTitleInRepos returns the ids of the projects with the title (case-insensitive) which can be used by the repositories
+// selected by repoCond, that are the projects of the repositories and of their owners. All projects are searched if repoCond is empty.
+func GetProjectIDsByTitleInRepos(ctx context.Context, repoCond builder.Cond, title string) ([]int64, error) {
+	cond := db.BuildCaseInsensitiveIn("title", []string{title})
+	if repoCond.IsValid() {
+		cond = cond.And(builder.Or(
+			builder.In("repo_id", builder.Select("id").From("repository").Where(repoCond)),
+			builder.Eq{"repo_id": 0}.And(builder.In("owner_id", builder.Select("owner_id").From("repository").Where(repoCond))),
+		))
+	}
+	ids := make([]int64, 0, 1)
+	return ids, db.GetEngine(ctx).Table(&Project{}).Where(cond).Cols("id").Find(&ids)
+}
+
 // UpdateProject updates project properties
 func UpdateProject(ctx context.Context, p *Project) error {
 	if !IsCardTypeValid(p.CardType) {
@@ -23,12 +23,13 @@ const (
 )
 
 // RepoIndexerStatus status of a repo's entry in the repo indexer
-// For now, implicitly refers to default branch
+// An empty RefName refers to the default branch, others to the extra refs indexed by the code indexer
 type RepoIndexerStatus struct { //revive:disable-line:exported
 	ID          int64           `xorm:"pk autoincr"`
 	RepoID      int64           `xorm:"INDEX(s)"`
 	CommitSha   string          `xorm:"VARCHAR(64)"`
 	IndexerType RepoIndexerType `xorm:"INDEX(s) NOT NULL DEFAULT 0"`
+	RefName     string          `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
 }
 
 func init() {
@@ -43,7 +44,7 @@ func GetUnindexedRepos(ctx context.Context, indexerType RepoIndexerType, maxRepo
 	}).And(builder.Eq{
 		"repository.is_empty": false,
 	})
-	sess := db.GetEngine(ctx).Table("repository").Join("LEFT OUTER", "repo_indexer_status", "repository.id = repo_indexer_status.repo_id AND repo_indexer_status.indexer_type = ?", indexerType)
+	sess := db.GetEngine(ctx).Table("repository").Join("LEFT OUTER", "repo_indexer_status", "repository.id = repo_indexer_status.repo_id AND repo_indexer_status.indexer_type = ? AND repo_indexer_status.ref_name = ''", indexerType)
 	if maxRepoID > 0 {
 		cond = builder.And(cond, builder.Lte{
 			"repository.id": maxRepoID,
@@ -75,7 +76,7 @@ func GetIndexerStatus(ctx context.Context, repo *Repository, indexerType RepoInd
 		}
 	}
 	status := &RepoIndexerStatus{RepoID: repo.ID}
-	if has, err := db.GetEngine(ctx).Where("`indexer_type` = ?", indexerType).Get(status); err != nil {
+	if has, err := db.GetEngine(ctx).Where("`indexer_type` = ? AND `ref_name` = ''", indexerType).Get(status); err != nil {
 		return nil, err
 	} else if !has {
 		status.IndexerType = indexerType
@@ -112,3 +113,33 @@ func UpdateIndexerStatus(ctx context.Context, repo *Repository, indexerType Repo
 	}
 	return nil
 }
+
+// GetIndexerRefStatuses returns the statuses of the extra refs of the repository, ordered by the ref name
+func GetIndexerRefStatuses(ctx context.Context, repoID int64, indexerType RepoIndexerType) ([]*RepoIndexerStatus, error) {
+	statuses := make([]*RepoIndexerStatus, 0, 5)
+	return statuses, db.GetEngine(ctx).
+		Where("`repo_id` = ? AND `indexer_type` = ? AND `ref_name` <> ''", repoID, indexerType).
+		Asc("ref_name").
+		Find(&statuses)
+}
+
+// UpdateIndexerRefStatus inserts or updates the status of an extra ref
+func UpdateIndexerRefStatus(ctx context.Context, status *RepoIndexerStatus, sha string) error {
+	status.CommitSha = sha
+	if status.ID == 0 {
+		if err := db.Insert(ctx, status); err != nil {
+			return fmt.Errorf("UpdateIndexerRefStatus: Unable to insert repoIndexerStatus for repo: %d Ref: %s Error: %w", status.RepoID, status.RefName, err)
+		}
+		return nil
+	}
+	if _, err := db.GetEngine(ctx).ID(status.ID).Cols("commit_sha").Update(status); err != nil {
+		return fmt.Errorf("UpdateIndexerRefStatus: Unable to update repoIndexerStatus for repo: %d Ref: %s Error: %w", status.RepoID, status.RefName, err)
+	}
+	return nil
+}
+
+// DeleteIndexerRefStatus deletes the status of an extra ref which isn't indexed anymore
+func DeleteIndexerRefStatus(ctx context.Context, id int64) error {
+	_, err := db.DeleteByID[RepoIndexerStatus](ctx, id)
+	return err
+}
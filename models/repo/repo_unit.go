@@ -180,6 +180,8 @@ type ActionsConfig struct {
 	// CollaborativeOwnerIDs is a list of owner IDs used to share actions from private repos.
 	// Only workflows from the private repos whose owners are in CollaborativeOwnerIDs can access the current repo's actions.
 	CollaborativeOwnerIDs []int64
+	// MaxQueueMinutes is the max time in minutes a job could wait for a runner, 0 means to inherit the limit of the owner
+	MaxQueueMinutes int64 `json:",omitempty"`
 }
 
 func (cfg *ActionsConfig) EnableWorkflow(file string) {
@@ -5,6 +5,7 @@ package secret
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -34,14 +35,18 @@ import (
 //
 // Please note that it's not acceptable to have both OwnerID and RepoID to zero, global secrets are not supported.
 // It's for security reasons, admin may be not aware of that the secrets could be stolen by any user when setting them as global.
+//
+// A repo level secret could belong to a deployment environment of the repository by EnvironmentID,
+// it is only available to the jobs deploying to the environment.
 type Secret struct {
-	ID          int64
-	OwnerID     int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
-	RepoID      int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
-	Name        string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
-	Data        string             `xorm:"LONGTEXT"` // encrypted data
-	Description string             `xorm:"TEXT"`
-	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	ID            int64
+	OwnerID       int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+	RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	EnvironmentID int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	Data          string             `xorm:"LONGTEXT"` // encrypted data
+	Description   string             `xorm:"TEXT"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
 }
 
 const (
@@ -64,6 +69,11 @@ func (err ErrSecretNotFound) Unwrap() error {
 
 // InsertEncryptedSecret Creates, encrypts, and validates a new secret with yet unencrypted data and insert into database
 func InsertEncryptedSecret(ctx context.Context, ownerID, repoID int64, name, data, description string) (*Secret, error) {
+	return InsertEncryptedEnvironmentSecret(ctx, ownerID, repoID, 0, name, data, description)
+}
+
+// InsertEncryptedEnvironmentSecret is like InsertEncryptedSecret, environmentID is only meaningful for a repo level secret
+func InsertEncryptedEnvironmentSecret(ctx context.Context, ownerID, repoID, environmentID int64, name, data, description string) (*Secret, error) {
 	if ownerID != 0 && repoID != 0 {
 		// It's trying to create a secret that belongs to a repository, but OwnerID has been set accidentally.
 		// Remove OwnerID to avoid confusion; it's not worth returning an error here.
@@ -72,6 +82,9 @@ func InsertEncryptedSecret(ctx context.Context, ownerID, repoID int64, name, dat
 	if ownerID == 0 && repoID == 0 {
 		return nil, fmt.Errorf("%w: ownerID and repoID cannot be both zero, global secrets are not supported", util.ErrInvalidArgument)
 	}
+	if repoID == 0 && environmentID != 0 {
+		return nil, util.NewInvalidArgumentErrorf("only repo level secrets could belong to an environment")
+	}
 
 	if len(data) > SecretDataMaxLength {
 		return nil, util.NewInvalidArgumentErrorf("data too long")
@@ -85,11 +98,12 @@ func InsertEncryptedSecret(ctx context.Context, ownerID, repoID int64, name, dat
 	}
 
 	secret := &Secret{
-		OwnerID:     ownerID,
-		RepoID:      repoID,
-		Name:        strings.ToUpper(name),
-		Data:        encrypted,
-		Description: description,
+		OwnerID:       ownerID,
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          strings.ToUpper(name),
+		Data:          encrypted,
+		Description:   description,
 	}
 	return secret, db.Insert(ctx, secret)
 }
@@ -100,10 +114,12 @@ func init() {
 
 type FindSecretsOptions struct {
 	db.ListOptions
-	RepoID   int64
-	OwnerID  int64 // it will be ignored if RepoID is set
-	SecretID int64
-	Name     string
+	RepoID  int64
+	OwnerID int64 // it will be ignored if RepoID is set
+	// EnvironmentID is the environment which the repo level secrets belong to, 0 means the secrets of the repository itself
+	EnvironmentID int64
+	SecretID      int64
+	Name          string
 }
 
 func (opts FindSecretsOptions) ToConds() builder.Cond {
@@ -116,6 +132,7 @@ func (opts FindSecretsOptions) ToConds() builder.Cond {
 	} else {
 		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
 	}
+	cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
 
 	if opts.SecretID != 0 {
 		cond = cond.And(builder.Eq{"id": opts.SecretID})
@@ -175,7 +192,23 @@ func GetSecretsOfTask(ctx context.Context, task *actions_model.ActionTask) (map[
 		return nil, err
 	}
 
-	for _, secret := range append(ownerSecrets, repoSecrets...) {
+	// the secrets of the job's environment take precedence over the repo level ones
+	var envSecrets []*Secret
+	if task.Job.Environment != "" {
+		env, err := actions_model.GetEnvironmentByRepoAndName(ctx, task.Job.RepoID, task.Job.Environment)
+		if err != nil && !errors.Is(err, util.ErrNotExist) {
+			return nil, err
+		}
+		if env != nil {
+			envSecrets, err = db.Find[Secret](ctx, FindSecretsOptions{RepoID: task.Job.RepoID, EnvironmentID: env.ID})
+			if err != nil {
+				log.Error("find secrets of environment %v: %v", env.ID, err)
+				return nil, err
+			}
+		}
+	}
+
+	for _, secret := range append(append(ownerSecrets, repoSecrets...), envSecrets...) {
 		v, err := secret_module.DecryptSecret(setting.SecretKey, secret.Data)
 		if err != nil {
 			log.Error("Unable to decrypt Actions secret %v %q, maybe SECRET_KEY is wrong: %v", secret.ID, secret.Name, err)
@@ -0,0 +1,189 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrStateNotExist represents a "StateNotExist" kind of error.
+var ErrStateNotExist = util.NewNotExistErrorf("terraform state does not exist")
+
+// TerraformState is a named state of the Terraform http backend of a repository or an owner.
+// It belongs to the owner if OwnerID is set, or to the repository if RepoID is set.
+type TerraformState struct {
+	ID      int64  `xorm:"pk autoincr"`
+	OwnerID int64  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	RepoID  int64  `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Name    string `xorm:"VARCHAR(255) UNIQUE(s) NOT NULL"`
+	// LatestVersion is the number of the latest version of the state, 0 if the state has been locked but never written
+	LatestVersion int64 `xorm:"NOT NULL DEFAULT 0"`
+	// LockID is the id of the lock held by a Terraform run, the state is unlocked if it's empty
+	LockID      string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	LockInfo    string `xorm:"TEXT"` // the lock info sent by Terraform, it's returned to other runs which try to lock the state
+	LockerID    int64  `xorm:"NOT NULL DEFAULT 0"`
+	LockedUnix  timeutil.TimeStamp
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL"`
+}
+
+// TerraformStateVersion is a version in the history of a state
+type TerraformStateVersion struct {
+	ID      int64  `xorm:"pk autoincr"`
+	OwnerID int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID  int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	StateID int64  `xorm:"UNIQUE(s) NOT NULL"`
+	Version int64  `xorm:"UNIQUE(s) NOT NULL"`
+	Serial  int64  `xorm:"NOT NULL DEFAULT 0"` // the serial of the state document written by Terraform
+	Lineage string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	Size    int64  `xorm:"NOT NULL DEFAULT 0"`
+	// CreatorID is the id of the user who wrote the version, it's the Actions user for the Actions task tokens
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(TerraformState))
+	db.RegisterModel(new(TerraformStateVersion))
+}
+
+// IsLocked checks if the state is locked by a Terraform run
+func (s *TerraformState) IsLocked() bool {
+	return s.LockID != ""
+}
+
+// StoragePath returns the path of the content of the version in the storage
+func (v *TerraformStateVersion) StoragePath() string {
+	return fmt.Sprintf("%d/%d/%d", v.RepoID, v.StateID, v.Version)
+}
+
+// GetStateByName gets the state of the owner or the repository by its name
+func GetStateByName(ctx context.Context, ownerID, repoID int64, name string) (*TerraformState, error) {
+	s := &TerraformState{}
+	has, err := db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ? AND name = ?", ownerID, repoID, name).Get(s)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateNotExist
+	}
+	return s, nil
+}
+
+// GetOrInsertState gets the state of the owner or the repository by its name or inserts a new one
+func GetOrInsertState(ctx context.Context, ownerID, repoID int64, name string) (*TerraformState, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (*TerraformState, error) {
+		s, err := GetStateByName(ctx, ownerID, repoID, name)
+		if err == nil {
+			return s, nil
+		} else if err != ErrStateNotExist {
+			return nil, err
+		}
+
+		s = &TerraformState{
+			OwnerID: ownerID,
+			RepoID:  repoID,
+			Name:    name,
+		}
+		if err := db.Insert(ctx, s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	})
+}
+
+// UpdateStateCols updates the columns of the state
+func UpdateStateCols(ctx context.Context, s *TerraformState, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(s.ID).Cols(cols...).Update(s)
+	return err
+}
+
+// DeleteState deletes the state and all its versions
+func DeleteState(ctx context.Context, s *TerraformState) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("state_id = ?", s.ID).Delete(&TerraformStateVersion{}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[TerraformState](ctx, s.ID)
+		return err
+	})
+}
+
+type FindStatesOptions struct {
+	db.ListOptions
+	OwnerID int64
+	RepoID  int64
+}
+
+func (opts FindStatesOptions) ToConds() builder.Cond {
+	// the states of an owner and the states of its repositories are separated
+	return builder.Eq{"owner_id": opts.OwnerID, "repo_id": opts.RepoID}
+}
+
+func (opts FindStatesOptions) ToOrders() string {
+	return "`name` ASC"
+}
+
+// InsertStateVersion inserts a version of the state
+func InsertStateVersion(ctx context.Context, v *TerraformStateVersion) error {
+	return db.Insert(ctx, v)
+}
+
+// GetStateVersion gets a version of the state by its number
+func GetStateVersion(ctx context.Context, stateID, version int64) (*TerraformStateVersion, error) {
+	v := &TerraformStateVersion{}
+	has, err := db.GetEngine(ctx).Where("state_id = ? AND version = ?", stateID, version).Get(v)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, util.NewNotExistErrorf("version %d of terraform state does not exist", version)
+	}
+	return v, nil
+}
+
+type FindStateVersionsOptions struct {
+	db.ListOptions
+	OwnerID       int64
+	RepoID        int64
+	StateID       int64
+	VersionBefore int64 // the versions older than this one
+}
+
+func (opts FindStateVersionsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.StateID > 0 {
+		cond = cond.And(builder.Eq{"state_id": opts.StateID})
+	}
+	if opts.VersionBefore > 0 {
+		cond = cond.And(builder.Lt{"version": opts.VersionBefore})
+	}
+	return cond
+}
+
+func (opts FindStateVersionsOptions) ToOrders() string {
+	return "`version` DESC"
+}
+
+// DeleteStateVersions deletes the versions by their ids
+func DeleteStateVersions(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).In("id", ids).Delete(&TerraformStateVersion{})
+	return err
+}
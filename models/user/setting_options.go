@@ -22,4 +22,7 @@ const (
 	SettingEmailNotificationGiteaActionsAll         = "all"
 	SettingEmailNotificationGiteaActionsFailureOnly = "failure-only" // Default for actions email preference
 	SettingEmailNotificationGiteaActionsDisabled    = "disabled"
+
+	// SettingsKeyActionsMaxQueueMinutes is the setting key for the max time in minutes a job of the user/org could wait for a runner
+	SettingsKeyActionsMaxQueueMinutes = "actions.max_queue_minutes"
 )
@@ -168,6 +168,10 @@ func (w *Webhook) UpdateEvent() error {
 }
 
 func (w *Webhook) HasEvent(evt webhook_module.HookEventType) bool {
+	// the queue event is sent periodically, it must be chosen explicitly to avoid flooding the webhooks sending everything
+	if evt == webhook_module.HookEventWorkflowJobQueue {
+		return !w.PushOnly && w.HookEvents[evt]
+	}
 	if w.SendEverything {
 		return true
 	}
@@ -187,7 +191,9 @@ func (w *Webhook) EventsArray() []string {
 	if w.SendEverything {
 		events := make([]string, 0, len(webhook_module.AllEvents()))
 		for _, evt := range webhook_module.AllEvents() {
-			events = append(events, string(evt))
+			if w.HasEvent(evt) {
+				events = append(events, string(evt))
+			}
 		}
 		return events
 	}
@@ -87,6 +87,21 @@ func TestWebhook_EventsArray(t *testing.T) {
 	)
 }
 
+func TestWebhook_HasEvent(t *testing.T) {
+	w := &Webhook{HookEvent: &webhook_module.HookEvent{SendEverything: true}}
+	assert.True(t, w.HasEvent(webhook_module.HookEventWorkflowJob))
+	assert.False(t, w.HasEvent(webhook_module.HookEventWorkflowJobQueue))
+
+	w = &Webhook{HookEvent: &webhook_module.HookEvent{
+		ChooseEvents: true,
+		HookEvents: webhook_module.HookEvents{
+			webhook_module.HookEventWorkflowJobQueue: true,
+		},
+	}}
+	assert.False(t, w.HasEvent(webhook_module.HookEventWorkflowJob))
+	assert.True(t, w.HasEvent(webhook_module.HookEventWorkflowJobQueue))
+}
+
 func TestCreateWebhook(t *testing.T) {
 	hook := &Webhook{
 		RepoID:      3,
@@ -61,11 +61,25 @@ func Parse(content []byte, options ...ParseOption) ([]*SingleWorkflow, error) {
 			job.Strategy.RawMatrix = encodeMatrix(matrix)
 			evaluator := NewExpressionEvaluator(NewInterpeter(id, origin.GetJob(id), matrix, pc.gitContext, results, pc.vars, pc.inputs))
 			job.Name = nameWithMatrix(job.Name, matrix, evaluator)
-			runsOn := origin.GetJob(id).RunsOn()
+			runsOn := job.RunsOn()
 			for i, v := range runsOn {
 				runsOn[i] = evaluator.Interpolate(v)
 			}
-			job.RawRunsOn = encodeRunsOn(runsOn)
+			job.RawRunsOn = encodeRunsOn(evaluator.Interpolate(job.RunsOnGroup()), runsOn)
+			if job.TimeoutMinutes == "" {
+				// the timeout-minutes of the workflow is the default of its jobs
+				job.TimeoutMinutes = workflow.TimeoutMinutes
+			}
+			job.TimeoutMinutes = evaluator.Interpolate(job.TimeoutMinutes)
+			if env := job.DeploymentEnvironment(); env != nil {
+				env.Name = evaluator.Interpolate(env.Name)
+				env.URL = evaluator.Interpolate(env.URL)
+				job.RawEnvironment = encodeEnvironment(env)
+			}
+			if approval := job.Approval(); approval != nil && approval.Message != "" {
+				approval.Message = evaluator.Interpolate(approval.Message)
+				_ = job.RawApproval.Encode(approval)
+			}
 			swf := &SingleWorkflow{
 				Name:           workflow.Name,
 				RawOn:          workflow.RawOn,
@@ -140,9 +154,14 @@ func encodeMatrix(matrix map[string]any) yaml.Node {
 	return node
 }
 
-func encodeRunsOn(runsOn []string) yaml.Node {
+func encodeRunsOn(group string, runsOn []string) yaml.Node {
 	node := yaml.Node{}
-	if len(runsOn) == 1 {
+	if group != "" {
+		_ = node.Encode(struct {
+			Group  string   `yaml:"group"`
+			Labels []string `yaml:"labels,omitempty"`
+		}{Group: group, Labels: runsOn})
+	} else if len(runsOn) == 1 {
 		_ = node.Encode(runsOn[0])
 	} else {
 		_ = node.Encode(runsOn)
@@ -150,6 +169,16 @@ func encodeRunsOn(runsOn []string) yaml.Node {
 	return node
 }
 
+func encodeEnvironment(env *JobEnvironment) yaml.Node {
+	node := yaml.Node{}
+	if env.URL == "" {
+		_ = node.Encode(env.Name)
+	} else {
+		_ = node.Encode(env)
+	}
+	return node
+}
+
 func nameWithMatrix(name string, m map[string]any, evaluator *ExpressionEvaluator) string {
 	if len(m) == 0 {
 		return name
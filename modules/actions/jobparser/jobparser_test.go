@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/nektos/act/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.yaml.in/yaml/v4"
@@ -58,6 +59,26 @@ func TestParse(t *testing.T) {
 			options: nil,
 			wantErr: false,
 		},
+		{
+			name:    "has_environment",
+			options: nil,
+			wantErr: false,
+		},
+		{
+			name:    "has_approval",
+			options: []ParseOption{WithGitContext(&model.GithubContext{Repository: "owner/repo"})},
+			wantErr: false,
+		},
+		{
+			name:    "has_timeout",
+			options: []ParseOption{WithVars(map[string]string{"TIMEOUT": "5"})},
+			wantErr: false,
+		},
+		{
+			name:    "has_runner_group",
+			options: []ParseOption{WithVars(map[string]string{"GROUP": "gpu-runners", "ARCH": "x64"})},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
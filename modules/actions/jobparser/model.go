@@ -7,6 +7,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/nektos/act/pkg/model"
 	"go.yaml.in/yaml/v4"
@@ -21,6 +24,8 @@ type SingleWorkflow struct {
 	Defaults       Defaults          `yaml:"defaults,omitempty"`
 	RawPermissions yaml.Node         `yaml:"permissions,omitempty"`
 	RunName        string            `yaml:"run-name,omitempty"`
+	// TimeoutMinutes is the default timeout-minutes of the jobs, it is only read from the workflow since Parse sets it to the jobs
+	TimeoutMinutes string `yaml:"timeout-minutes,omitempty"`
 }
 
 func (w *SingleWorkflow) Job() (string, *Job) {
@@ -95,6 +100,8 @@ type Job struct {
 	RawSecrets     yaml.Node                 `yaml:"secrets,omitempty"`
 	RawConcurrency *model.RawConcurrency     `yaml:"concurrency,omitempty"`
 	RawPermissions yaml.Node                 `yaml:"permissions,omitempty"`
+	RawEnvironment yaml.Node                 `yaml:"environment,omitempty"`
+	RawApproval    yaml.Node                 `yaml:"approval,omitempty"`
 }
 
 func (j *Job) Clone() *Job {
@@ -119,6 +126,8 @@ func (j *Job) Clone() *Job {
 		RawSecrets:     j.RawSecrets,
 		RawConcurrency: j.RawConcurrency,
 		RawPermissions: j.RawPermissions,
+		RawEnvironment: j.RawEnvironment,
+		RawApproval:    j.RawApproval,
 	}
 }
 
@@ -131,10 +140,110 @@ func (j *Job) EraseNeeds() *Job {
 	return j
 }
 
+// RunsOn returns the labels of the runners which could run the job,
+// the runner group of `runs-on: {group: <name>, labels: [...]}` isn't one of the labels, see RunsOnGroup.
 func (j *Job) RunsOn() []string {
+	if j.RawRunsOn.Kind == yaml.MappingNode {
+		var val struct {
+			Labels yaml.Node `yaml:"labels"`
+		}
+		if err := j.RawRunsOn.Decode(&val); err != nil {
+			return nil
+		}
+		return (&model.Job{RawRunsOn: val.Labels}).RunsOn()
+	}
 	return (&model.Job{RawRunsOn: j.RawRunsOn}).RunsOn()
 }
 
+// RunsOnGroup returns the name of the runner group declared by `runs-on: {group: <name>}`, it is empty if there is none.
+func (j *Job) RunsOnGroup() string {
+	if j.RawRunsOn.Kind != yaml.MappingNode {
+		return ""
+	}
+	var val struct {
+		Group string `yaml:"group"`
+	}
+	if err := j.RawRunsOn.Decode(&val); err != nil {
+		return ""
+	}
+	return val.Group
+}
+
+// InheritSecrets returns whether the job calling a reusable workflow passes all secrets of the caller by "secrets: inherit"
+func (j *Job) InheritSecrets() bool {
+	return (&model.Job{RawSecrets: j.RawSecrets}).InheritSecrets()
+}
+
+// Secrets returns the secrets passed to a reusable workflow explicitly, the values may contain expressions
+func (j *Job) Secrets() map[string]string {
+	return (&model.Job{RawSecrets: j.RawSecrets}).Secrets()
+}
+
+// JobEnvironment is the deployment environment of a job
+type JobEnvironment struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url,omitempty"`
+}
+
+// DeploymentEnvironment returns the deployment environment referenced by the job, or nil if there is none.
+// The environment could be a name, or a mapping with "name" and "url".
+func (j *Job) DeploymentEnvironment() *JobEnvironment {
+	switch j.RawEnvironment.Kind {
+	case yaml.ScalarNode:
+		var name string
+		if err := j.RawEnvironment.Decode(&name); err != nil || name == "" {
+			return nil
+		}
+		return &JobEnvironment{Name: name}
+	case yaml.MappingNode:
+		env := &JobEnvironment{}
+		if err := j.RawEnvironment.Decode(env); err != nil || env.Name == "" {
+			return nil
+		}
+		return env
+	}
+	return nil
+}
+
+// JobApproval is the approval gate of a job, it's an extension of Gitea
+type JobApproval struct {
+	// Message is shown to the users who could approve the gate
+	Message string `yaml:"message,omitempty"`
+	// UntrustedOnly makes the gate only wait for approval if the run is from an untrusted user,
+	// such as a fork pull request of a first-time contributor, otherwise the gate passes automatically
+	UntrustedOnly bool `yaml:"untrusted-only,omitempty"`
+}
+
+// Timeout returns the evaluated timeout-minutes of the job as a duration, or 0 if it isn't set or invalid
+func (j *Job) Timeout() time.Duration {
+	minutes, err := strconv.ParseFloat(strings.TrimSpace(j.TimeoutMinutes), 64)
+	if err != nil || minutes <= 0 {
+		return 0
+	}
+	return time.Duration(minutes * float64(time.Minute))
+}
+
+// Approval returns the approval gate of the job, or nil if the job isn't an approval gate.
+// The approval could be "true", or a mapping with "message" and "untrusted-only".
+// An approval gate isn't executed by runners, it pauses until a user approves or rejects it.
+func (j *Job) Approval() *JobApproval {
+	switch j.RawApproval.Kind {
+	case yaml.ScalarNode:
+		var enabled bool
+		if err := j.RawApproval.Decode(&enabled); err != nil || !enabled {
+			return nil
+		}
+		return &JobApproval{}
+	case yaml.MappingNode:
+		approval := &JobApproval{}
+		if err := j.RawApproval.Decode(approval); err != nil {
+			return nil
+		}
+		return approval
+	}
+	return nil
+}
+
 type Step struct {
 	ID               string            `yaml:"id,omitempty"`
 	If               yaml.Node         `yaml:"if,omitempty"`
@@ -196,11 +305,43 @@ type WorkflowDispatchInput struct {
 	Options     []string `yaml:"options"`
 }
 
+// WorkflowCallInput is an input of the "workflow_call" trigger of a reusable workflow
+type WorkflowCallInput struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Default     string `yaml:"default"`
+	Type        string `yaml:"type"`
+}
+
+// WorkflowCallSecret is a secret of the "workflow_call" trigger of a reusable workflow
+type WorkflowCallSecret struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+// WorkflowCallOutput is an output of the "workflow_call" trigger of a reusable workflow,
+// its value is an expression which usually refers to the outputs of the jobs in the reusable workflow
+type WorkflowCallOutput struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Value       string `yaml:"value"`
+}
+
+// WorkflowCall is the config of the "workflow_call" trigger of a reusable workflow
+type WorkflowCall struct {
+	Inputs  []WorkflowCallInput
+	Secrets []WorkflowCallSecret
+	Outputs []WorkflowCallOutput
+}
+
 type Event struct {
-	Name      string
-	acts      map[string][]string
-	schedules []map[string]string
-	inputs    []WorkflowDispatchInput
+	Name         string
+	acts         map[string][]string
+	schedules    []map[string]string
+	inputs       []WorkflowDispatchInput
+	workflowCall *WorkflowCall
 }
 
 func (evt *Event) IsSchedule() bool {
@@ -219,6 +360,11 @@ func (evt *Event) Inputs() []WorkflowDispatchInput {
 	return evt.inputs
 }
 
+// WorkflowCall returns the config of the "workflow_call" trigger, it is nil if the event has no inputs, secrets or outputs
+func (evt *Event) WorkflowCall() *WorkflowCall {
+	return evt.workflowCall
+}
+
 func ReadWorkflowRawConcurrency(content []byte) (*model.RawConcurrency, error) {
 	w := new(model.Workflow)
 	err := yaml.NewDecoder(bytes.NewReader(content)).Decode(w)
@@ -371,6 +517,7 @@ func ParseRawOn(rawOn *yaml.Node) ([]*Event, error) {
 			case yaml.MappingNode:
 				acts := make(map[string][]string, len(v.Content)/2)
 				var inputs []WorkflowDispatchInput
+				var workflowCall *WorkflowCall
 				expectedKey := true
 				var act string
 				for _, content := range v.Content {
@@ -400,8 +547,17 @@ func ParseRawOn(rawOn *yaml.Node) ([]*Event, error) {
 							}
 							acts[act] = []string{t}
 						case yaml.MappingNode:
+							if k == "workflow_call" {
+								if workflowCall == nil {
+									workflowCall = &WorkflowCall{}
+								}
+								if err := parseWorkflowCallMappingNode(workflowCall, act, content); err != nil {
+									return nil, err
+								}
+								break
+							}
 							if k != "workflow_dispatch" || act != "inputs" {
-								return nil, fmt.Errorf("map should only for workflow_dispatch but %s: %#v", act, content)
+								return nil, fmt.Errorf("map should only for workflow_dispatch or workflow_call but %s: %#v", act, content)
 							}
 
 							var key string
@@ -440,9 +596,10 @@ func ParseRawOn(rawOn *yaml.Node) ([]*Event, error) {
 					acts = nil
 				}
 				res = append(res, &Event{
-					Name:   k,
-					acts:   acts,
-					inputs: inputs,
+					Name:         k,
+					acts:         acts,
+					inputs:       inputs,
+					workflowCall: workflowCall,
 				})
 			default:
 				return nil, fmt.Errorf("unknown on type: %v", v.Kind)
@@ -454,6 +611,42 @@ func ParseRawOn(rawOn *yaml.Node) ([]*Event, error) {
 	}
 }
 
+// parseWorkflowCallMappingNode parses the "inputs", "secrets" or "outputs" of a "workflow_call" trigger into the config
+func parseWorkflowCallMappingNode(config *WorkflowCall, act string, node *yaml.Node) error {
+	switch act {
+	case "inputs":
+		names, inputs, err := parseMappingNode[WorkflowCallInput](node)
+		if err != nil {
+			return err
+		}
+		for i := range inputs {
+			inputs[i].Name = names[i]
+		}
+		config.Inputs = inputs
+	case "secrets":
+		names, secrets, err := parseMappingNode[WorkflowCallSecret](node)
+		if err != nil {
+			return err
+		}
+		for i := range secrets {
+			secrets[i].Name = names[i]
+		}
+		config.Secrets = secrets
+	case "outputs":
+		names, outputs, err := parseMappingNode[WorkflowCallOutput](node)
+		if err != nil {
+			return err
+		}
+		for i := range outputs {
+			outputs[i].Name = names[i]
+		}
+		config.Outputs = outputs
+	default:
+		return fmt.Errorf("unknown workflow_call config %s: %#v", act, node)
+	}
+	return nil
+}
+
 // parseMappingNode parse a mapping node and preserve order.
 func parseMappingNode[T any](node *yaml.Node) ([]string, []T, error) {
 	if node.Kind != yaml.MappingNode {
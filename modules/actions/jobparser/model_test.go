@@ -6,6 +6,7 @@ package jobparser
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/nektos/act/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -254,6 +255,51 @@ func TestParseRawOn(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: `on:
+  workflow_call:
+    inputs:
+      version:
+        description: 'Version to build'
+        required: true
+        type: string
+    secrets:
+      token:
+        required: true
+    outputs:
+      artifact:
+        description: 'Name of the artifact'
+        value: ${{ jobs.build.outputs.artifact }}
+`,
+			result: []*Event{
+				{
+					Name: "workflow_call",
+					workflowCall: &WorkflowCall{
+						Inputs: []WorkflowCallInput{
+							{
+								Name:        "version",
+								Description: "Version to build",
+								Required:    true,
+								Type:        "string",
+							},
+						},
+						Secrets: []WorkflowCallSecret{
+							{
+								Name:     "token",
+								Required: true,
+							},
+						},
+						Outputs: []WorkflowCallOutput{
+							{
+								Name:        "artifact",
+								Description: "Name of the artifact",
+								Value:       "${{ jobs.build.outputs.artifact }}",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, kase := range kases {
 		t.Run(kase.input, func(t *testing.T) {
@@ -371,3 +417,21 @@ func TestParseMappingNode(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_Timeout(t *testing.T) {
+	tests := []struct {
+		timeoutMinutes string
+		want           time.Duration
+	}{
+		{timeoutMinutes: "", want: 0},
+		{timeoutMinutes: "10", want: 10 * time.Minute},
+		{timeoutMinutes: "1.5", want: 90 * time.Second},
+		{timeoutMinutes: "0", want: 0},
+		{timeoutMinutes: "-1", want: 0},
+		{timeoutMinutes: "${{ vars.TIMEOUT }}", want: 0},
+	}
+	for _, test := range tests {
+		job := &Job{TimeoutMinutes: test.timeoutMinutes}
+		assert.Equal(t, test.want, job.Timeout(), test.timeoutMinutes)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package jobparser
+
+import (
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	PermissionScopeIDToken = "id-token"
+
+	PermissionLevelNone  = "none"
+	PermissionLevelRead  = "read"
+	PermissionLevelWrite = "write"
+)
+
+// Permission returns the access level of the scope granted to the job,
+// the permissions of the job take precedence over the permissions of the workflow.
+// It returns PermissionLevelNone if neither the job nor the workflow declares the permissions.
+func (w *SingleWorkflow) Permission(scope string) string {
+	_, job := w.Job()
+	if job != nil {
+		if level, ok := parsePermission(&job.RawPermissions, scope); ok {
+			return level
+		}
+	}
+	if level, ok := parsePermission(&w.RawPermissions, scope); ok {
+		return level
+	}
+	return PermissionLevelNone
+}
+
+// parsePermission returns the access level of the scope in the permissions node,
+// the returned bool is false if the permissions are not declared.
+func parsePermission(node *yaml.Node, scope string) (string, bool) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		switch node.Value {
+		case "write-all":
+			return PermissionLevelWrite, true
+		case "read-all":
+			// "id-token" only supports "write" and "none"
+			if scope == PermissionScopeIDToken {
+				return PermissionLevelNone, true
+			}
+			return PermissionLevelRead, true
+		}
+		return PermissionLevelNone, true
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == scope {
+				return node.Content[i+1].Value, true
+			}
+		}
+		// the scopes not specified are set to "none"
+		return PermissionLevelNone, true
+	}
+	return "", false
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package jobparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSingleWorkflow_Permission(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name: "not declared",
+			content: `
+on: push
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+`,
+			expected: PermissionLevelNone,
+		},
+		{
+			name: "workflow level",
+			content: `
+on: push
+permissions:
+  id-token: write
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+`,
+			expected: PermissionLevelWrite,
+		},
+		{
+			name: "job level overrides workflow level",
+			content: `
+on: push
+permissions: write-all
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
+`,
+			expected: PermissionLevelNone,
+		},
+		{
+			name: "read-all",
+			content: `
+on: push
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+    permissions: read-all
+`,
+			expected: PermissionLevelNone,
+		},
+		{
+			name: "write-all",
+			content: `
+on: push
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+    permissions: write-all
+`,
+			expected: PermissionLevelWrite,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			workflows, err := Parse([]byte(c.content))
+			require.NoError(t, err)
+			require.Len(t, workflows, 1)
+			assert.Equal(t, c.expected, workflows[0].Permission(PermissionScopeIDToken))
+		})
+	}
+}
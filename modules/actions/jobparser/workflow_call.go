@@ -0,0 +1,266 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package jobparser
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nektos/act/pkg/exprparser"
+	"github.com/nektos/act/pkg/model"
+)
+
+// ReusableWorkflowRef is the reference to a reusable workflow in the "uses" of a job
+type ReusableWorkflowRef struct {
+	Owner string // empty for a workflow in the same repository
+	Repo  string // empty for a workflow in the same repository
+	Path  string // path of the workflow file in the repository
+	Ref   string // empty for a workflow in the same repository, the commit of the caller is used
+}
+
+// IsLocal returns whether the reusable workflow is in the same repository as the caller
+func (r *ReusableWorkflowRef) IsLocal() bool {
+	return r.Owner == ""
+}
+
+// ParseReusableWorkflowRef parses the "uses" of a job, which could be
+// "./.gitea/workflows/build.yml" for a workflow in the same repository, or
+// "owner/repo/.gitea/workflows/build.yml@ref" for a workflow in another repository
+func ParseReusableWorkflowRef(uses string) (*ReusableWorkflowRef, error) {
+	if uses == "" {
+		return nil, errors.New("empty reusable workflow reference")
+	}
+
+	path, ref, hasRef := strings.Cut(uses, "@")
+	if !hasRef {
+		path = strings.TrimPrefix(path, "./")
+		if path == "" || strings.HasPrefix(path, "/") || strings.Contains(path, "..") {
+			return nil, fmt.Errorf("invalid local reusable workflow reference %q", uses)
+		}
+		return &ReusableWorkflowRef{Path: path}, nil
+	}
+
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" || ref == "" || strings.Contains(parts[2], "..") {
+		return nil, fmt.Errorf("invalid reusable workflow reference %q, it should be like owner/repo/path/to/workflow.yml@ref", uses)
+	}
+	return &ReusableWorkflowRef{
+		Owner: parts[0],
+		Repo:  parts[1],
+		Path:  parts[2],
+		Ref:   ref,
+	}, nil
+}
+
+// EvaluateWorkflowCallInputs evaluates the "with" of a job calling a reusable workflow,
+// and returns the inputs of the called workflow with defaults applied and values converted to the declared types.
+func EvaluateWorkflowCallInputs(call *WorkflowCall, jobID string, job *Job, gitCtx map[string]any, results map[string]*JobResult, vars map[string]string, inputs map[string]any) (map[string]any, error) {
+	declared := make(map[string]*WorkflowCallInput, len(call.Inputs))
+	for i := range call.Inputs {
+		declared[call.Inputs[i].Name] = &call.Inputs[i]
+	}
+	for name := range job.With {
+		if _, ok := declared[name]; !ok {
+			return nil, fmt.Errorf("input %q is not defined in the called workflow", name)
+		}
+	}
+
+	evaluator := NewExpressionEvaluator(NewInterpeter(jobID, toActJob(job), matrixOfJob(job), toGitContext(gitCtx), withJobResult(jobID, job, results), vars, inputs))
+
+	ret := make(map[string]any, len(call.Inputs))
+	for _, input := range call.Inputs {
+		value, ok := job.With[input.Name]
+		if !ok {
+			if input.Required && input.Default == "" {
+				return nil, fmt.Errorf("input %q is required but not provided", input.Name)
+			}
+			value = input.Default
+		}
+		if s, isString := value.(string); isString {
+			value = evaluator.Interpolate(s)
+		}
+		converted, err := convertWorkflowCallInput(&input, value)
+		if err != nil {
+			return nil, err
+		}
+		ret[input.Name] = converted
+	}
+	return ret, nil
+}
+
+func convertWorkflowCallInput(input *WorkflowCallInput, value any) (any, error) {
+	s, isString := value.(string)
+	switch input.Type {
+	case "boolean":
+		if !isString {
+			if b, ok := value.(bool); ok {
+				return b, nil
+			}
+			return nil, fmt.Errorf("input %q should be a boolean", input.Name)
+		}
+		if s == "" {
+			return false, nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("input %q should be a boolean: %w", input.Name, err)
+		}
+		return b, nil
+	case "number":
+		if !isString {
+			switch v := value.(type) {
+			case int:
+				return float64(v), nil
+			case float64:
+				return v, nil
+			}
+			return nil, fmt.Errorf("input %q should be a number", input.Name)
+		}
+		if s == "" {
+			return float64(0), nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("input %q should be a number: %w", input.Name, err)
+		}
+		return f, nil
+	default:
+		if isString {
+			return s, nil
+		}
+		return fmt.Sprint(value), nil
+	}
+}
+
+// EvaluateWorkflowCallSecrets returns the secrets passed to a reusable workflow by a job.
+// The secrets could be inherited from the caller by "secrets: inherit", or be passed explicitly by expressions of the caller's secrets.
+// The automatically generated tokens are always passed.
+func EvaluateWorkflowCallSecrets(job *Job, secrets map[string]string) map[string]string {
+	if job.InheritSecrets() {
+		return secrets
+	}
+
+	ret := map[string]string{}
+	for _, name := range []string{"GITHUB_TOKEN", "GITEA_TOKEN"} {
+		if v, ok := secrets[name]; ok {
+			ret[name] = v
+		}
+	}
+
+	evaluator := NewExpressionEvaluator(exprparser.NewInterpeter(&exprparser.EvaluationEnvironment{Secrets: secrets}, exprparser.Config{}))
+	for name, value := range job.Secrets() {
+		ret[name] = evaluator.Interpolate(value)
+	}
+	return ret
+}
+
+// CheckWorkflowCallSecrets checks whether a job calling a reusable workflow passes all the required secrets
+func CheckWorkflowCallSecrets(call *WorkflowCall, job *Job) error {
+	if job.InheritSecrets() {
+		return nil
+	}
+	passed := job.Secrets()
+	for _, secret := range call.Secrets {
+		if _, ok := passed[secret.Name]; secret.Required && !ok {
+			return fmt.Errorf("secret %q is required but not provided", secret.Name)
+		}
+	}
+	return nil
+}
+
+var (
+	expressionPattern    = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+	stringLiteralPattern = regexp.MustCompile(`'(?:[^']|'')*'`)
+	needsContextPattern  = regexp.MustCompile(`\bneeds\s*[.\[]`)
+)
+
+// CheckWorkflowCallInputs checks whether the "with" of a job calling a reusable workflow can be evaluated when the run is created.
+// The called workflow is parsed with the inputs before the needed jobs run, so the inputs can't depend on their outputs.
+func CheckWorkflowCallInputs(job *Job) error {
+	for name, value := range job.With {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		for _, m := range expressionPattern.FindAllStringSubmatch(s, -1) {
+			if needsContextPattern.MatchString(stringLiteralPattern.ReplaceAllString(m[1], "")) {
+				return fmt.Errorf("input %q can't use the needs context, the outputs of the needed jobs are unknown when the called workflow is loaded", name)
+			}
+		}
+	}
+	return nil
+}
+
+// EvaluateWorkflowCallOutputs evaluates the outputs of a reusable workflow by the outputs of its jobs
+func EvaluateWorkflowCallOutputs(call *WorkflowCall, jobOutputs map[string]map[string]string, gitCtx map[string]any, vars map[string]string, inputs map[string]any) map[string]string {
+	jobs := make(map[string]*model.WorkflowCallResult, len(jobOutputs))
+	for id, outputs := range jobOutputs {
+		jobs[id] = &model.WorkflowCallResult{Outputs: outputs}
+	}
+	evaluator := NewExpressionEvaluator(exprparser.NewInterpeter(&exprparser.EvaluationEnvironment{
+		Github: toGitContext(gitCtx),
+		Jobs:   &jobs,
+		Vars:   vars,
+		Inputs: inputs,
+	}, exprparser.Config{}))
+
+	ret := make(map[string]string, len(call.Outputs))
+	for _, output := range call.Outputs {
+		ret[output.Name] = evaluator.Interpolate(output.Value)
+	}
+	return ret
+}
+
+// EvaluateJobIf evaluates the "if" of a job on the server side, a job without "if" runs only if all its needs succeeded.
+// It is used for the jobs which are not executed by runners, such as a job calling a reusable workflow.
+func EvaluateJobIf(jobID string, job *Job, gitCtx map[string]any, results map[string]*JobResult, vars map[string]string, inputs map[string]any) (bool, error) {
+	interpreter := NewInterpeter(jobID, toActJob(job), matrixOfJob(job), toGitContext(gitCtx), withJobResult(jobID, job, results), vars, inputs)
+	expr, err := rewriteSubExpression(job.If.Value, false)
+	if err != nil {
+		return false, err
+	}
+	result, err := interpreter.Evaluate(expr, exprparser.DefaultStatusCheckSuccess)
+	if err != nil {
+		return false, fmt.Errorf("evaluate if %q: %w", job.If.Value, err)
+	}
+	return exprparser.IsTruthy(result), nil
+}
+
+// withJobResult makes sure the results contain the job itself, which is required by the interpreter to resolve the needs of the job
+func withJobResult(jobID string, job *Job, results map[string]*JobResult) map[string]*JobResult {
+	if _, ok := results[jobID]; ok {
+		return results
+	}
+	ret := make(map[string]*JobResult, len(results)+1)
+	maps.Copy(ret, results)
+	ret[jobID] = &JobResult{Needs: job.Needs()}
+	return ret
+}
+
+func toActJob(job *Job) *model.Job {
+	actJob := &model.Job{
+		RawNeeds: job.RawNeeds,
+		Strategy: &model.Strategy{
+			FailFastString:    job.Strategy.FailFastString,
+			MaxParallelString: job.Strategy.MaxParallelString,
+			RawMatrix:         job.Strategy.RawMatrix,
+		},
+	}
+	actJob.Strategy.FailFast = actJob.Strategy.GetFailFast()
+	actJob.Strategy.MaxParallel = actJob.Strategy.GetMaxParallel()
+	return actJob
+}
+
+// matrixOfJob returns the matrix of a job in a SingleWorkflow, which has at most one combination
+func matrixOfJob(job *Job) map[string]any {
+	matrixes, err := toActJob(job).GetMatrixes()
+	if err != nil || len(matrixes) == 0 {
+		return map[string]any{}
+	}
+	return matrixes[0]
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package jobparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReusableWorkflowRef(t *testing.T) {
+	kases := []struct {
+		uses   string
+		result *ReusableWorkflowRef
+	}{
+		{
+			uses:   "./.gitea/workflows/build.yml",
+			result: &ReusableWorkflowRef{Path: ".gitea/workflows/build.yml"},
+		},
+		{
+			uses:   "owner/repo/.gitea/workflows/build.yml@v1",
+			result: &ReusableWorkflowRef{Owner: "owner", Repo: "repo", Path: ".gitea/workflows/build.yml", Ref: "v1"},
+		},
+		{uses: ""},
+		{uses: "./"},
+		{uses: "./../other/build.yml"},
+		{uses: "owner/repo@v1"},
+		{uses: "owner/repo/.gitea/workflows/build.yml@"},
+	}
+	for _, kase := range kases {
+		t.Run(kase.uses, func(t *testing.T) {
+			ref, err := ParseReusableWorkflowRef(kase.uses)
+			if kase.result == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, kase.result, ref)
+		})
+	}
+}
+
+func TestEvaluateWorkflowCallInputs(t *testing.T) {
+	call := &WorkflowCall{
+		Inputs: []WorkflowCallInput{
+			{Name: "name", Type: "string", Required: true},
+			{Name: "debug", Type: "boolean", Default: "true"},
+			{Name: "count", Type: "number"},
+		},
+	}
+
+	job := &Job{With: map[string]any{"name": "${{ vars.NAME }}", "count": 3}}
+	inputs, err := EvaluateWorkflowCallInputs(call, "call", job, map[string]any{}, nil, map[string]string{"NAME": "gitea"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "gitea", "debug": true, "count": float64(3)}, inputs)
+
+	_, err = EvaluateWorkflowCallInputs(call, "call", &Job{With: map[string]any{}}, map[string]any{}, nil, nil, nil)
+	assert.ErrorContains(t, err, `input "name" is required`)
+
+	_, err = EvaluateWorkflowCallInputs(call, "call", &Job{With: map[string]any{"name": "a", "unknown": "b"}}, map[string]any{}, nil, nil, nil)
+	assert.ErrorContains(t, err, `input "unknown" is not defined`)
+
+	_, err = EvaluateWorkflowCallInputs(call, "call", &Job{With: map[string]any{"name": "a", "debug": "maybe"}}, map[string]any{}, nil, nil, nil)
+	assert.ErrorContains(t, err, `input "debug" should be a boolean`)
+}
+
+func TestCheckWorkflowCallInputs(t *testing.T) {
+	assert.NoError(t, CheckWorkflowCallInputs(&Job{With: map[string]any{
+		"name":    "${{ vars.NAME }}-${{ github.ref_name }}",
+		"literal": "${{ format('needs.{0}', inputs.name) }}",
+		"count":   3,
+	}}))
+
+	err := CheckWorkflowCallInputs(&Job{With: map[string]any{"version": "v${{ needs.build.outputs.version }}"}})
+	assert.ErrorContains(t, err, `input "version" can't use the needs context`)
+
+	err = CheckWorkflowCallInputs(&Job{With: map[string]any{"version": "${{ needs['build'].result }}"}})
+	assert.ErrorContains(t, err, `input "version" can't use the needs context`)
+}
+
+func TestEvaluateWorkflowCallOutputs(t *testing.T) {
+	call := &WorkflowCall{
+		Outputs: []WorkflowCallOutput{
+			{Name: "artifact", Value: "${{ jobs.build.outputs.artifact }}-${{ inputs.version }}"},
+		},
+	}
+	outputs := EvaluateWorkflowCallOutputs(call, map[string]map[string]string{
+		"build": {"artifact": "dist"},
+	}, map[string]any{}, nil, map[string]any{"version": "1.0"})
+	assert.Equal(t, map[string]string{"artifact": "dist-1.0"}, outputs)
+}
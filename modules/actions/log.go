@@ -9,12 +9,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/dbfs"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/zstd"
 
 	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
@@ -25,6 +28,9 @@ const (
 	MaxLineSize = 64 * 1024
 	DBFSPrefix  = "actions_log/"
 
+	// MaxSummarySize is the max size of the summary of a step, it's the same as GitHub
+	MaxSummarySize = 1024 * 1024
+
 	timeFormat     = "2006-01-02T15:04:05.0000000Z07:00"
 	defaultBufSize = MaxLineSize
 )
@@ -224,3 +230,102 @@ func ParseLog(in string) (time.Time, string, error) {
 	}
 	return timestamp, in[index+1:], nil
 }
+
+// SummaryHTMLFilename returns the file name of the rendered HTML of the summary of a step, it's stored next to the Markdown
+func SummaryHTMLFilename(filename string) string {
+	return strings.TrimSuffix(filename, ".md") + ".html"
+}
+
+// WriteSummary stores the Markdown summary of a step and its rendered HTML in object storage, it replaces the old content.
+// Unlike logs, a summary is sent as a whole, so it's stored in object storage directly.
+func WriteSummary(filename, content, rendered string) error {
+	if _, err := storage.Actions.Save(filename, strings.NewReader(content), int64(len(content))); err != nil {
+		return fmt.Errorf("storage save %q: %w", filename, err)
+	}
+	htmlFilename := SummaryHTMLFilename(filename)
+	if _, err := storage.Actions.Save(htmlFilename, strings.NewReader(rendered), int64(len(rendered))); err != nil {
+		return fmt.Errorf("storage save %q: %w", htmlFilename, err)
+	}
+	return nil
+}
+
+// ReadSummary reads the summary of a step from object storage, it reads the rendered HTML instead of the Markdown if rendered is true
+func ReadSummary(filename string, rendered bool) (string, error) {
+	if rendered {
+		filename = SummaryHTMLFilename(filename)
+	}
+	f, err := storage.Actions.Open(filename)
+	if err != nil {
+		return "", fmt.Errorf("storage open %q: %w", filename, err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return "", fmt.Errorf("storage read %q: %w", filename, err)
+	}
+	return string(content), nil
+}
+
+// RemoveSummary removes the summary of a step and its rendered HTML from object storage
+func RemoveSummary(filename string) error {
+	for _, name := range []string{filename, SummaryHTMLFilename(filename)} {
+		if err := storage.Actions.Delete(name); err != nil {
+			return fmt.Errorf("storage delete %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+var (
+	commandDataUnescaper     = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%25", "%")
+	commandPropertyUnescaper = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%3A", ":", "%2C", ",", "%25", "%")
+)
+
+// ParseAnnotation parses an annotation from a log line of a workflow command like
+// `::error file=app.js,line=1,col=5,endColumn=7,title=Syntax error::Missing semicolon`,
+// the returned annotation only contains the fields from the command.
+func ParseAnnotation(content string) (*actions_model.ActionTaskAnnotation, bool) {
+	command, ok := strings.CutPrefix(strings.TrimLeft(content, " \t"), "::")
+	if !ok {
+		return nil, false
+	}
+	command, message, ok := strings.Cut(command, "::")
+	if !ok {
+		return nil, false
+	}
+	name, properties, _ := strings.Cut(command, " ")
+	level, ok := actions_model.ParseAnnotationLevel(name)
+	if !ok {
+		return nil, false
+	}
+
+	annotation := &actions_model.ActionTaskAnnotation{
+		Level:   level,
+		Message: commandDataUnescaper.Replace(message),
+	}
+	for property := range strings.SplitSeq(properties, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(property), "=")
+		if !ok {
+			continue
+		}
+		value = commandPropertyUnescaper.Replace(value)
+		switch key {
+		case "file":
+			if len(value) <= 1024 {
+				annotation.Path = strings.TrimPrefix(value, "./")
+			}
+		case "title":
+			annotation.Title = util.TruncateRunes(value, 255)
+		case "line":
+			annotation.Line, _ = strconv.ParseInt(value, 10, 64)
+		case "endLine":
+			annotation.EndLine, _ = strconv.ParseInt(value, 10, 64)
+		case "col":
+			annotation.Column, _ = strconv.ParseInt(value, 10, 64)
+		case "endColumn":
+			annotation.EndColumn, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+	return annotation, true
+}
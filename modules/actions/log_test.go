@@ -0,0 +1,69 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnnotation(t *testing.T) {
+	tests := []struct {
+		content string
+		want    *actions_model.ActionTaskAnnotation
+	}{
+		{
+			content: "::error file=app.js,line=10,endLine=12,col=5,endColumn=7,title=Syntax error::Missing semicolon",
+			want: &actions_model.ActionTaskAnnotation{
+				Level:     actions_model.AnnotationLevelError,
+				Path:      "app.js",
+				Line:      10,
+				EndLine:   12,
+				Column:    5,
+				EndColumn: 7,
+				Title:     "Syntax error",
+				Message:   "Missing semicolon",
+			},
+		},
+		{
+			content: "  ::warning file=./cmd/main.go,line=3::unused variable%0Aremove it",
+			want: &actions_model.ActionTaskAnnotation{
+				Level:   actions_model.AnnotationLevelWarning,
+				Path:    "cmd/main.go",
+				Line:    3,
+				Message: "unused variable\nremove it",
+			},
+		},
+		{
+			content: "::notice title=Coverage%3A 80%25%2C ok::coverage is 80%25",
+			want: &actions_model.ActionTaskAnnotation{
+				Level:   actions_model.AnnotationLevelNotice,
+				Title:   "Coverage: 80%, ok",
+				Message: "coverage is 80%",
+			},
+		},
+		{
+			content: "::error line=abc::bad line",
+			want: &actions_model.ActionTaskAnnotation{
+				Level:   actions_model.AnnotationLevelError,
+				Message: "bad line",
+			},
+		},
+		{content: "::debug::not an annotation"},
+		{content: "::group::Run tests"},
+		{content: "##[error]Process completed with exit code 1."},
+		{content: "error: ::error::not at the beginning"},
+		{content: "::error file=app.js"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			got, ok := ParseAnnotation(tt.content)
+			assert.Equal(t, tt.want != nil, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
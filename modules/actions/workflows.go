@@ -107,6 +107,25 @@ func GetEventsFromContent(content []byte) ([]*jobparser.Event, error) {
 	return events, nil
 }
 
+// GetWorkflowCallFromContent returns the config of the "workflow_call" trigger of a reusable workflow,
+// it returns nil if the workflow can't be called by other workflows.
+func GetWorkflowCallFromContent(content []byte) (*jobparser.WorkflowCall, error) {
+	events, err := GetEventsFromContent(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, evt := range events {
+		if evt.Name != GithubEventWorkflowCall {
+			continue
+		}
+		if call := evt.WorkflowCall(); call != nil {
+			return call, nil
+		}
+		return &jobparser.WorkflowCall{}, nil
+	}
+	return nil, nil //nolint:nilnil // return nil to indicate that the workflow is not a reusable workflow
+}
+
 func DetectWorkflows(
 	gitRepo *git.Repository,
 	commit *git.Commit,
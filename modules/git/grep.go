@@ -18,6 +18,7 @@ import (
 )
 
 type GrepResult struct {
+	RefName     string
 	Filename    string
 	LineNumbers []int
 	LineCodes   []string
@@ -33,6 +34,7 @@ const (
 
 type GrepOptions struct {
 	RefName           string
+	RefNames          []string // search in several refs instead of RefName
 	MaxResultLimit    int
 	ContextLineNumber int
 	GrepMode          GrepModeType
@@ -74,7 +76,11 @@ func GrepSearch(ctx context.Context, repo *Repository, search string, opts GrepO
 			}
 		}
 	}
-	cmd.AddDynamicArguments(util.IfZero(opts.RefName, "HEAD"))
+	if len(opts.RefNames) > 0 {
+		cmd.AddDynamicArguments(opts.RefNames...)
+	} else {
+		cmd.AddDynamicArguments(util.IfZero(opts.RefName, "HEAD"))
+	}
 	cmd.AddDashesAndList(opts.PathspecList...)
 	opts.MaxResultLimit = util.IfZero(opts.MaxResultLimit, 50)
 
@@ -99,9 +105,9 @@ func GrepSearch(ctx context.Context, repo *Repository, search string, opts GrepO
 				}
 				line := string(lineBytes) // the memory of lineBytes is mutable
 				if !isInBlock {
-					if _ /* ref */, filename, ok := strings.Cut(line, ":"); ok {
+					if ref, filename, ok := strings.Cut(line, ":"); ok {
 						isInBlock = true
-						res = &GrepResult{Filename: filename}
+						res = &GrepResult{RefName: ref, Filename: filename}
 						results = append(results, res)
 					}
 					continue
@@ -19,11 +19,13 @@ func TestGrepSearch(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []*GrepResult{
 		{
+			RefName:     "HEAD",
 			Filename:    "java-hello/main.java",
 			LineNumbers: []int{3},
 			LineCodes:   []string{" public static void main(String[] args)"},
 		},
 		{
+			RefName:     "HEAD",
 			Filename:    "main.vendor.java",
 			LineNumbers: []int{3},
 			LineCodes:   []string{" public static void main(String[] args)"},
@@ -34,6 +36,7 @@ func TestGrepSearch(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []*GrepResult{
 		{
+			RefName:     "HEAD",
 			Filename:    "java-hello/main.java",
 			LineNumbers: []int{3},
 			LineCodes:   []string{" public static void main(String[] args)"},
@@ -44,6 +47,7 @@ func TestGrepSearch(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []*GrepResult{
 		{
+			RefName:     "HEAD",
 			Filename:    "main.vendor.java",
 			LineNumbers: []int{3},
 			LineCodes:   []string{" public static void main(String[] args)"},
@@ -54,6 +58,7 @@ func TestGrepSearch(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []*GrepResult{
 		{
+			RefName:     "HEAD",
 			Filename:    "java-hello/main.java",
 			LineNumbers: []int{3},
 			LineCodes:   []string{" public static void main(String[] args)"},
@@ -64,12 +69,30 @@ func TestGrepSearch(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []*GrepResult{
 		{
+			RefName:     "HEAD",
 			Filename:    "java-hello/main.java",
 			LineNumbers: []int{3},
 			LineCodes:   []string{" public static void main(String[] arg"},
 		},
 	}, res)
 
+	res, err = GrepSearch(t.Context(), repo, "void", GrepOptions{RefNames: []string{"HEAD", "refs/heads/master"}, PathspecList: []string{":(glob)java-hello/*"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*GrepResult{
+		{
+			RefName:     "HEAD",
+			Filename:    "java-hello/main.java",
+			LineNumbers: []int{3},
+			LineCodes:   []string{" public static void main(String[] args)"},
+		},
+		{
+			RefName:     "refs/heads/master",
+			Filename:    "java-hello/main.java",
+			LineNumbers: []int{3},
+			LineCodes:   []string{" public static void main(String[] args)"},
+		},
+	}, res)
+
 	res, err = GrepSearch(t.Context(), repo, "no-such-content", GrepOptions{})
 	assert.NoError(t, err)
 	assert.Empty(t, res)
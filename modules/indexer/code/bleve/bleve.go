@@ -5,9 +5,7 @@ package bleve
 
 import (
 	"context"
-	"fmt"
-	"io"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,7 +13,6 @@ import (
 	"code.gitea.io/gitea/modules/analyze"
 	"code.gitea.io/gitea/modules/charset"
 	"code.gitea.io/gitea/modules/git"
-	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/indexer"
 	path_filter "code.gitea.io/gitea/modules/indexer/code/bleve/token/path"
@@ -24,7 +21,6 @@ import (
 	inner_bleve "code.gitea.io/gitea/modules/indexer/internal/bleve"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
-	"code.gitea.io/gitea/modules/typesniffer"
 	"code.gitea.io/gitea/modules/util"
 
 	"github.com/blevesearch/bleve/v2"
@@ -33,6 +29,7 @@ import (
 	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
 	"github.com/blevesearch/bleve/v2/analysis/token/unicodenorm"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/letter"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/mapping"
 	"github.com/blevesearch/bleve/v2/search/query"
@@ -54,10 +51,12 @@ func addUnicodeNormalizeTokenFilter(m *mapping.IndexMappingImpl) error {
 // RepoIndexerData data stored in the repo indexer
 type RepoIndexerData struct {
 	RepoID    int64
+	Ref       string
 	CommitID  string
 	Content   string
 	Filename  string
 	Language  string
+	Symbols   []string
 	UpdatedAt time.Time
 }
 
@@ -70,8 +69,9 @@ const (
 	repoIndexerAnalyzer      = "repoIndexerAnalyzer"
 	filenameIndexerAnalyzer  = "filenameIndexerAnalyzer"
 	filenameIndexerTokenizer = "filenameIndexerTokenizer"
+	lowercaseKeywordAnalyzer = "lowercaseKeywordAnalyzer"
 	repoIndexerDocType       = "repoIndexerDocType"
-	repoIndexerLatestVersion = 9
+	repoIndexerLatestVersion = 11
 )
 
 // generateBleveIndexMapping generates a bleve index mapping for the repo indexer
@@ -88,13 +88,26 @@ func generateBleveIndexMapping() (mapping.IndexMapping, error) {
 	fileNamedMapping := bleve.NewTextFieldMapping()
 	fileNamedMapping.IncludeInAll = false
 	fileNamedMapping.Analyzer = filenameIndexerAnalyzer
-	docMapping.AddFieldMappingsAt("Filename", fileNamedMapping)
+	// the whole path is also indexed as a single term to match parts of it with "path:"
+	pathMapping := bleve.NewTextFieldMapping()
+	pathMapping.Name = "Path"
+	pathMapping.IncludeInAll = false
+	pathMapping.Store = false
+	pathMapping.Analyzer = lowercaseKeywordAnalyzer
+	docMapping.AddFieldMappingsAt("Filename", fileNamedMapping, pathMapping)
+
+	symbolsMapping := bleve.NewTextFieldMapping()
+	symbolsMapping.IncludeInAll = false
+	symbolsMapping.Store = false
+	symbolsMapping.Analyzer = lowercaseKeywordAnalyzer
+	docMapping.AddFieldMappingsAt("Symbols", symbolsMapping)
 
 	termFieldMapping := bleve.NewTextFieldMapping()
 	termFieldMapping.IncludeInAll = false
 	termFieldMapping.Analyzer = analyzer_keyword.Name
 	docMapping.AddFieldMappingsAt("Language", termFieldMapping)
 	docMapping.AddFieldMappingsAt("CommitID", termFieldMapping)
+	docMapping.AddFieldMappingsAt("Ref", termFieldMapping)
 
 	timeFieldMapping := bleve.NewDateTimeFieldMapping()
 	timeFieldMapping.IncludeInAll = false
@@ -122,6 +135,15 @@ func generateBleveIndexMapping() (mapping.IndexMapping, error) {
 		return nil, err
 	}
 
+	if err := mapping.AddCustomAnalyzer(lowercaseKeywordAnalyzer, map[string]any{
+		"type":          analyzer_custom.Name,
+		"char_filters":  []string{},
+		"tokenizer":     single.Name,
+		"token_filters": []string{lowercase.Name},
+	}); err != nil {
+		return nil, err
+	}
+
 	mapping.DefaultAnalyzer = repoIndexerAnalyzer
 	mapping.AddDocumentMapping(repoIndexerDocType, docMapping)
 	mapping.AddDocumentMapping("_all", bleve.NewDocumentDisabledMapping())
@@ -138,7 +160,7 @@ type Indexer struct {
 }
 
 func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
-	return indexer.SearchModesExactWords()
+	return indexer.SearchModesExactWordsRegexp()
 }
 
 // NewIndexer creates a new bleve local indexer
@@ -150,7 +172,7 @@ func NewIndexer(indexDir string) *Indexer {
 	}
 }
 
-func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, commitSha string,
+func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, ref, commitSha string,
 	update internal.FileUpdate, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch,
 ) error {
 	// Ignore vendored files in code search
@@ -158,58 +180,36 @@ func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch,
 		return nil
 	}
 
-	size := update.Size
-
-	var err error
-	if !update.Sized {
-		var stdout string
-		stdout, _, err = gitrepo.RunCmdString(ctx, repo, gitcmd.NewCommand("cat-file", "-s").AddDynamicArguments(update.BlobSha))
-		if err != nil {
-			return err
-		}
-		if size, err = strconv.ParseInt(strings.TrimSpace(stdout), 10, 64); err != nil {
-			return fmt.Errorf("misformatted git cat-file output: %w", err)
-		}
-	}
-
-	if size > setting.Indexer.MaxIndexerFileSize {
-		return b.addDelete(update.Filename, repo, batch)
-	}
-
-	info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
-	if err != nil {
-		return err
-	}
-	fileContents, err := io.ReadAll(io.LimitReader(batchReader, info.Size))
+	fileContents, tooLarge, err := internal.ReadFileContent(ctx, catFileBatch, repo, update)
 	if err != nil {
 		return err
-	} else if !typesniffer.DetectContentType(fileContents).IsText() {
-		// FIXME: UTF-16 files will probably fail here
-		// Even if the file is not recognized as a "text file", we could still put its name into the indexers to make the filename become searchable, while leave the content to empty.
-		fileContents = nil
+	} else if tooLarge {
+		return b.addDelete(ref, update.Filename, repo, batch)
 	}
 
-	if _, err = batchReader.Discard(1); err != nil {
-		return err
-	}
-	id := internal.FilenameIndexerID(repo.ID, update.Filename)
+	// Even if the file is not recognized as a "text file", we could still put its name into the indexers to make the filename become searchable, while leave the content to empty.
+	id := internal.FilenameIndexerID(repo.ID, ref, update.Filename)
+	content := string(charset.ToUTF8DropErrors(fileContents))
+	language := analyze.GetCodeLanguage(update.Filename, fileContents)
 	return batch.Index(id, &RepoIndexerData{
 		RepoID:    repo.ID,
+		Ref:       util.IfZero(ref, internal.DefaultBranchRef),
 		CommitID:  commitSha,
 		Filename:  update.Filename,
-		Content:   string(charset.ToUTF8DropErrors(fileContents)),
-		Language:  analyze.GetCodeLanguage(update.Filename, fileContents),
+		Content:   content,
+		Language:  language,
+		Symbols:   internal.SymbolNames(internal.ExtractSymbols(update.Filename, language, content)),
 		UpdatedAt: time.Now().UTC(),
 	})
 }
 
-func (b *Indexer) addDelete(filename string, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch) error {
-	id := internal.FilenameIndexerID(repo.ID, filename)
+func (b *Indexer) addDelete(ref, filename string, repo *repo_model.Repository, batch *inner_bleve.FlushingBatch) error {
+	id := internal.FilenameIndexerID(repo.ID, ref, filename)
 	return batch.Delete(id)
 }
 
 // Index indexes the data
-func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *internal.RepoChanges) error {
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *internal.RepoChanges) error {
 	batch := inner_bleve.NewFlushingBatch(b.inner.Indexer, maxBatchSize)
 	if len(changes.Updates) > 0 {
 		catfileBatch, err := gitrepo.NewBatch(ctx, repo)
@@ -219,13 +219,13 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 		defer catfileBatch.Close()
 
 		for _, update := range changes.Updates {
-			if err := b.addUpdate(ctx, catfileBatch, sha, update, repo, batch); err != nil {
+			if err := b.addUpdate(ctx, catfileBatch, ref, sha, update, repo, batch); err != nil {
 				return err
 			}
 		}
 	}
 	for _, filename := range changes.RemovedFilenames {
-		if err := b.addDelete(filename, repo, batch); err != nil {
+		if err := b.addDelete(ref, filename, repo, batch); err != nil {
 			return err
 		}
 	}
@@ -234,7 +234,17 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 
 // Delete deletes indexes by ids
 func (b *Indexer) Delete(_ context.Context, repoID int64) error {
-	query := inner_bleve.NumericEqualityQuery(repoID, "RepoID")
+	return b.deleteByQuery(inner_bleve.NumericEqualityQuery(repoID, "RepoID"))
+}
+
+// DeleteRef deletes the indexes of a ref of the repository
+func (b *Indexer) DeleteRef(_ context.Context, repoID int64, ref string) error {
+	refQuery := bleve.NewTermQuery(util.IfZero(ref, internal.DefaultBranchRef))
+	refQuery.FieldVal = "Ref"
+	return b.deleteByQuery(bleve.NewConjunctionQuery(inner_bleve.NumericEqualityQuery(repoID, "RepoID"), refQuery))
+}
+
+func (b *Indexer) deleteByQuery(query query.Query) error {
 	searchRequest := bleve.NewSearchRequestOptions(query, 2147483647, 0, false)
 	result, err := b.inner.Indexer.Search(searchRequest)
 	if err != nil {
@@ -254,8 +264,8 @@ func (b *Indexer) Delete(_ context.Context, repoID int64) error {
 func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
 	var (
 		indexerQuery query.Query
-		keywordQuery query.Query
 		contentQuery query.Query
+		queries      []query.Query
 	)
 
 	pathQuery := bleve.NewPrefixQuery(strings.ToLower(opts.Keyword))
@@ -263,13 +273,24 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 	pathQuery.SetBoost(10)
 
 	searchMode := util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)
-	if searchMode == indexer.SearchModeExact {
+	switch {
+	case opts.Keyword == "":
+		// only search by the symbol or the path
+	case searchMode == indexer.SearchModeRegexp:
+		// the regexp is matched against the words of the content, which are lowercase
+		if err := internal.CheckTermRegexp(opts.Keyword); err != nil {
+			return 0, nil, nil, err
+		}
+		q := bleve.NewRegexpQuery("(?i).*(?:" + opts.Keyword + ").*")
+		q.FieldVal = "Content"
+		contentQuery = q
+	case searchMode == indexer.SearchModeExact:
 		// 1.21 used NewPrefixQuery, but it seems not working well, and later releases changed to NewMatchPhraseQuery
 		q := bleve.NewMatchPhraseQuery(opts.Keyword)
 		q.Analyzer = repoIndexerAnalyzer
 		q.FieldVal = "Content"
 		contentQuery = q
-	} else /* words */ {
+	default: /* words */
 		q := bleve.NewMatchQuery(opts.Keyword)
 		q.FieldVal = "Content"
 		q.Analyzer = repoIndexerAnalyzer
@@ -281,21 +302,50 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 		}
 		contentQuery = q
 	}
+	if contentQuery != nil {
+		queries = append(queries, bleve.NewDisjunctionQuery(contentQuery, pathQuery))
+	}
 
-	keywordQuery = bleve.NewDisjunctionQuery(contentQuery, pathQuery)
+	if opts.Symbol != "" {
+		symbolQuery := bleve.NewTermQuery(strings.ToLower(opts.Symbol))
+		symbolQuery.FieldVal = "Symbols"
+		queries = append(queries, symbolQuery)
+	}
+
+	if opts.Path != "" {
+		pathPartQuery := bleve.NewWildcardQuery("*" + strings.ToLower(opts.Path) + "*")
+		pathPartQuery.FieldVal = "Path"
+		queries = append(queries, pathPartQuery)
+	}
+
+	if refPatterns := internal.RefSearchPatterns(opts.Refs); !slices.Contains(refPatterns, "*") {
+		refQueries := make([]query.Query, 0, len(refPatterns))
+		for _, pattern := range refPatterns {
+			if internal.IsWildcardPattern(pattern) {
+				q := bleve.NewWildcardQuery(pattern)
+				q.FieldVal = "Ref"
+				refQueries = append(refQueries, q)
+			} else {
+				q := bleve.NewTermQuery(pattern)
+				q.FieldVal = "Ref"
+				refQueries = append(refQueries, q)
+			}
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(refQueries...))
+	}
 
 	if len(opts.RepoIDs) > 0 {
 		repoQueries := make([]query.Query, 0, len(opts.RepoIDs))
 		for _, repoID := range opts.RepoIDs {
 			repoQueries = append(repoQueries, inner_bleve.NumericEqualityQuery(repoID, "RepoID"))
 		}
+		queries = append(queries, bleve.NewDisjunctionQuery(repoQueries...))
+	}
 
-		indexerQuery = bleve.NewConjunctionQuery(
-			bleve.NewDisjunctionQuery(repoQueries...),
-			keywordQuery,
-		)
+	if len(queries) == 1 {
+		indexerQuery = queries[0]
 	} else {
-		indexerQuery = keywordQuery
+		indexerQuery = bleve.NewConjunctionQuery(queries...)
 	}
 
 	// Save for reuse without language filter
@@ -313,7 +363,7 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 
 	from, pageSize := opts.GetSkipTake()
 	searchRequest := bleve.NewSearchRequestOptions(indexerQuery, pageSize, from, false)
-	searchRequest.Fields = []string{"Content", "Filename", "RepoID", "Language", "CommitID", "UpdatedAt"}
+	searchRequest.Fields = []string{"Content", "Filename", "RepoID", "Ref", "Language", "CommitID", "UpdatedAt"}
 	searchRequest.IncludeLocations = true
 
 	if len(opts.Language) == 0 {
@@ -343,21 +393,30 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 				endIndex = locationEnd
 			}
 		}
-		if len(hit.Locations["Filename"]) > 0 {
-			startIndex, endIndex = internal.FilenameMatchIndexPos(hit.Fields["Content"].(string))
+		language := hit.Fields["Language"].(string)
+		filename := internal.FilenameOfIndexerID(hit.ID)
+		content := hit.Fields["Content"].(string)
+		if opts.Symbol != "" {
+			startIndex, endIndex = internal.SymbolMatchIndexPos(filename, language, content, opts.Symbol)
+		} else if len(hit.Locations["Filename"]) > 0 || startIndex < 0 {
+			startIndex, endIndex = internal.FilenameMatchIndexPos(content)
 		}
 
-		language := hit.Fields["Language"].(string)
 		var updatedUnix timeutil.TimeStamp
 		if t, err := time.Parse(time.RFC3339, hit.Fields["UpdatedAt"].(string)); err == nil {
 			updatedUnix = timeutil.TimeStamp(t.Unix())
 		}
+		ref, _ := hit.Fields["Ref"].(string)
+		if ref == internal.DefaultBranchRef {
+			ref = ""
+		}
 		searchResults[i] = &internal.SearchResult{
 			RepoID:      int64(hit.Fields["RepoID"].(float64)),
+			Ref:         ref,
 			StartIndex:  startIndex,
 			EndIndex:    endIndex,
-			Filename:    internal.FilenameOfIndexerID(hit.ID),
-			Content:     hit.Fields["Content"].(string),
+			Filename:    filename,
+			Content:     content,
 			CommitID:    hit.Fields["CommitID"].(string),
 			UpdatedUnix: updatedUnix,
 			Language:    language,
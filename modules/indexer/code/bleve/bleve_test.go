@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package bleve
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymbolSearch(t *testing.T) {
+	idx := NewIndexer(t.TempDir())
+	defer idx.Close()
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	files := map[string]string{
+		"server/server.go": "package server\n\n// NewServer is called by main\nfunc NewServer() *Server {\n\treturn &Server{}\n}\n",
+		"main.go":          "package main\n\nfunc main() {\n\tserver.NewServer()\n}\n",
+		"lib/server.py":    "class Server:\n    def serve(self):\n        pass\n",
+	}
+	for filename, content := range files {
+		language := map[string]string{".go": "Go", ".py": "Python"}[filename[len(filename)-3:]]
+		require.NoError(t, idx.inner.Indexer.Index(internal.FilenameIndexerID(1, "", filename), &RepoIndexerData{
+			RepoID:    1,
+			Ref:       internal.DefaultBranchRef,
+			Filename:  filename,
+			Content:   content,
+			Language:  language,
+			Symbols:   internal.SymbolNames(internal.ExtractSymbols(filename, language, content)),
+			UpdatedAt: time.Now().UTC(),
+		}))
+	}
+
+	search := func(opts *internal.SearchOptions) []*internal.SearchResult {
+		opts.Paginator = &db.ListOptions{Page: 1, PageSize: 10}
+		_, results, _, err := idx.Search(t.Context(), opts)
+		require.NoError(t, err)
+		return results
+	}
+
+	results := search(&internal.SearchOptions{Symbol: "newserver"})
+	require.Len(t, results, 1)
+	assert.Equal(t, "server/server.go", results[0].Filename)
+	assert.Equal(t, "NewServer", results[0].Content[results[0].StartIndex:results[0].EndIndex])
+	assert.Equal(t, 52, results[0].StartIndex)
+
+	results = search(&internal.SearchOptions{Symbol: "Server", Language: "Python"})
+	require.Len(t, results, 1)
+	assert.Equal(t, "lib/server.py", results[0].Filename)
+
+	results = search(&internal.SearchOptions{Keyword: "pass", Symbol: "serve", Path: "lib/"})
+	require.Len(t, results, 1)
+	assert.Equal(t, "lib/server.py", results[0].Filename)
+
+	assert.Empty(t, search(&internal.SearchOptions{Symbol: "serve", RepoIDs: []int64{2}}))
+	assert.Empty(t, search(&internal.SearchOptions{Symbol: "main", Path: "server"}))
+}
+
+func TestRegexpAndRefSearch(t *testing.T) {
+	idx := NewIndexer(t.TempDir())
+	defer idx.Close()
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	index := func(ref, filename, content string) {
+		require.NoError(t, idx.inner.Indexer.Index(internal.FilenameIndexerID(1, ref, filename), &RepoIndexerData{
+			RepoID:    1,
+			Ref:       util.IfZero(ref, internal.DefaultBranchRef),
+			Filename:  filename,
+			Content:   content,
+			UpdatedAt: time.Now().UTC(),
+		}))
+	}
+	index("", "main.go", "func handleRequest() {}\n")
+	index("refs/heads/release/1.0", "main.go", "func handleResponse() {}\n")
+	index("refs/tags/v1.0", "main.go", "func handleRequest() {}\n")
+
+	search := func(keyword string, refs ...string) (refNames []string) {
+		_, results, _, err := idx.Search(t.Context(), &internal.SearchOptions{
+			Keyword:    keyword,
+			Refs:       refs,
+			SearchMode: indexer.SearchModeRegexp,
+			Paginator:  &db.ListOptions{Page: 1, PageSize: 10},
+		})
+		require.NoError(t, err)
+		for _, result := range results {
+			assert.Equal(t, "main.go", result.Filename)
+			assert.Equal(t, "handle", strings.ToLower(result.Content[result.StartIndex:result.StartIndex+6]))
+			refNames = append(refNames, result.Ref)
+		}
+		slices.Sort(refNames)
+		return refNames
+	}
+
+	assert.Equal(t, []string{""}, search("Handle(Request|Response)"))
+	assert.Equal(t, []string{"refs/heads/release/1.0"}, search("handle(request|response)", "release/*"))
+	assert.Equal(t, []string{"", "refs/tags/v1.0"}, search("handlereq.*", "*"))
+	assert.Equal(t, []string{"", "refs/heads/release/1.0"}, search("handle", "HEAD", "refs/heads/*"))
+	assert.Empty(t, search("response", "v1.0"))
+
+	_, _, _, err = idx.Search(t.Context(), &internal.SearchOptions{Keyword: "^handle", SearchMode: indexer.SearchModeRegexp})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	require.NoError(t, idx.DeleteRef(t.Context(), 1, "refs/tags/v1.0"))
+	assert.Equal(t, []string{"", "refs/heads/release/1.0"}, search("handle", "*"))
+	require.NoError(t, idx.DeleteRef(t.Context(), 1, ""))
+	assert.Equal(t, []string{"refs/heads/release/1.0"}, search("handle", "*"))
+}
@@ -6,15 +6,14 @@ package elasticsearch
 import (
 	"context"
 	"fmt"
-	"io"
-	"strconv"
+	"regexp"
+	"slices"
 	"strings"
 
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/analyze"
 	"code.gitea.io/gitea/modules/charset"
 	"code.gitea.io/gitea/modules/git"
-	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/gitrepo"
 	"code.gitea.io/gitea/modules/indexer"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
@@ -24,7 +23,6 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
-	"code.gitea.io/gitea/modules/typesniffer"
 	"code.gitea.io/gitea/modules/util"
 
 	"github.com/go-enry/go-enry/v2"
@@ -32,7 +30,7 @@ import (
 )
 
 const (
-	esRepoIndexerLatestVersion = 3
+	esRepoIndexerLatestVersion = 5
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
@@ -48,7 +46,7 @@ type Indexer struct {
 }
 
 func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
-	return indexer.SearchModesExactWords()
+	return indexer.SearchModesExactWordsRegexp()
 }
 
 // NewIndexer creates a new elasticsearch indexer
@@ -91,6 +89,12 @@ const (
 						"delimiter": "/",
 						"reverse": true
 					}
+				},
+				"normalizer": {
+					"lowercase_normalizer": {
+						"type": "custom",
+						"filter": ["lowercase"]
+					}
 				}
 			}
   		},
@@ -112,7 +116,11 @@ const (
           				"path_reversed": {
             				"type": "text",
             				"analyzer": "filename_path_analyzer"
-          				}
+          				},
+						"keyword": {
+							"type": "keyword",
+							"normalizer": "lowercase_normalizer"
+						}
         			}
 				},
 				"content": {
@@ -125,10 +133,19 @@ const (
 					"type": "keyword",
 					"index": true
 				},
+				"ref": {
+					"type": "keyword",
+					"index": true
+				},
 				"language": {
 					"type": "keyword",
 					"index": true
 				},
+				"symbols": {
+					"type": "keyword",
+					"index": true,
+					"normalizer": "lowercase_normalizer"
+				},
 				"updated_at": {
 					"type": "long",
 					"index": true
@@ -138,46 +155,24 @@ const (
 	}`
 )
 
-func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, sha string, update internal.FileUpdate, repo *repo_model.Repository) ([]elastic.BulkableRequest, error) {
+func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, ref, sha string, update internal.FileUpdate, repo *repo_model.Repository) ([]elastic.BulkableRequest, error) {
 	// Ignore vendored files in code search
 	if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
 		return nil, nil
 	}
 
-	size := update.Size
-	var err error
-	if !update.Sized {
-		var stdout string
-		stdout, _, err = gitrepo.RunCmdString(ctx, repo, gitcmd.NewCommand("cat-file", "-s").AddDynamicArguments(update.BlobSha))
-		if err != nil {
-			return nil, err
-		}
-		if size, err = strconv.ParseInt(strings.TrimSpace(stdout), 10, 64); err != nil {
-			return nil, fmt.Errorf("misformatted git cat-file output: %w", err)
-		}
-	}
-
-	if size > setting.Indexer.MaxIndexerFileSize {
-		return []elastic.BulkableRequest{b.addDelete(update.Filename, repo)}, nil
-	}
-
-	info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
+	fileContents, tooLarge, err := internal.ReadFileContent(ctx, catFileBatch, repo, update)
 	if err != nil {
 		return nil, err
-	}
-
-	fileContents, err := io.ReadAll(io.LimitReader(batchReader, info.Size))
-	if err != nil {
-		return nil, err
-	} else if !typesniffer.DetectContentType(fileContents).IsText() {
-		// FIXME: UTF-16 files will probably fail here
+	} else if tooLarge {
+		return []elastic.BulkableRequest{b.addDelete(ref, update.Filename, repo)}, nil
+	} else if fileContents == nil {
 		return nil, nil
 	}
 
-	if _, err = batchReader.Discard(1); err != nil {
-		return nil, err
-	}
-	id := internal.FilenameIndexerID(repo.ID, update.Filename)
+	id := internal.FilenameIndexerID(repo.ID, ref, update.Filename)
+	content := string(charset.ToUTF8DropErrors(fileContents))
+	language := analyze.GetCodeLanguage(update.Filename, fileContents)
 
 	return []elastic.BulkableRequest{
 		elastic.NewBulkIndexRequest().
@@ -185,24 +180,26 @@ func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch,
 			Id(id).
 			Doc(map[string]any{
 				"repo_id":    repo.ID,
+				"ref":        util.IfZero(ref, internal.DefaultBranchRef),
 				"filename":   update.Filename,
-				"content":    string(charset.ToUTF8DropErrors(fileContents)),
+				"content":    content,
 				"commit_id":  sha,
-				"language":   analyze.GetCodeLanguage(update.Filename, fileContents),
+				"language":   language,
+				"symbols":    internal.SymbolNames(internal.ExtractSymbols(update.Filename, language, content)),
 				"updated_at": timeutil.TimeStampNow(),
 			}),
 	}, nil
 }
 
-func (b *Indexer) addDelete(filename string, repo *repo_model.Repository) elastic.BulkableRequest {
-	id := internal.FilenameIndexerID(repo.ID, filename)
+func (b *Indexer) addDelete(ref, filename string, repo *repo_model.Repository) elastic.BulkableRequest {
+	id := internal.FilenameIndexerID(repo.ID, ref, filename)
 	return elastic.NewBulkDeleteRequest().
 		Index(b.inner.VersionedIndexName()).
 		Id(id)
 }
 
 // Index will save the index data
-func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *internal.RepoChanges) error {
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *internal.RepoChanges) error {
 	reqs := make([]elastic.BulkableRequest, 0)
 	if len(changes.Updates) > 0 {
 		batch, err := gitrepo.NewBatch(ctx, repo)
@@ -212,7 +209,7 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 		defer batch.Close()
 
 		for _, update := range changes.Updates {
-			updateReqs, err := b.addUpdate(ctx, batch, sha, update, repo)
+			updateReqs, err := b.addUpdate(ctx, batch, ref, sha, update, repo)
 			if err != nil {
 				return err
 			}
@@ -223,7 +220,7 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 	}
 
 	for _, filename := range changes.RemovedFilenames {
-		reqs = append(reqs, b.addDelete(filename, repo))
+		reqs = append(reqs, b.addDelete(ref, filename, repo))
 	}
 
 	if len(reqs) > 0 {
@@ -244,13 +241,25 @@ func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, sha st
 
 // Delete entries by repoId
 func (b *Indexer) Delete(ctx context.Context, repoID int64) error {
-	if err := b.doDelete(ctx, repoID); err != nil {
+	return b.deleteByQuery(ctx, repoID, elastic.NewTermsQuery("repo_id", repoID))
+}
+
+// DeleteRef deletes the entries of a ref of the repository
+func (b *Indexer) DeleteRef(ctx context.Context, repoID int64, ref string) error {
+	return b.deleteByQuery(ctx, repoID, elastic.NewBoolQuery().Must(
+		elastic.NewTermsQuery("repo_id", repoID),
+		elastic.NewTermQuery("ref", util.IfZero(ref, internal.DefaultBranchRef)),
+	))
+}
+
+func (b *Indexer) deleteByQuery(ctx context.Context, repoID int64, query elastic.Query) error {
+	if err := b.doDelete(ctx, query); err != nil {
 		// Maybe there is a conflict during the delete operation, so we should retry after a refresh
 		log.Warn("Deletion of entries of repo %v within index %v was erroneous. Trying to refresh index before trying again", repoID, b.inner.VersionedIndexName(), err)
 		if err := b.refreshIndex(ctx); err != nil {
 			return err
 		}
-		if err := b.doDelete(ctx, repoID); err != nil {
+		if err := b.doDelete(ctx, query); err != nil {
 			log.Error("Could not delete entries of repo %v within index %v", repoID, b.inner.VersionedIndexName())
 			return err
 		}
@@ -267,10 +276,10 @@ func (b *Indexer) refreshIndex(ctx context.Context) error {
 	return nil
 }
 
-// Delete entries by repoId
-func (b *Indexer) doDelete(ctx context.Context, repoID int64) error {
+// Delete entries by query
+func (b *Indexer) doDelete(ctx context.Context, query elastic.Query) error {
 	_, err := b.inner.Client.DeleteByQuery(b.inner.VersionedIndexName()).
-		Query(elastic.NewTermsQuery("repo_id", repoID)).
+		Query(query).
 		Do(ctx)
 	return err
 }
@@ -291,10 +300,10 @@ func contentMatchIndexPos(content, start, end string) (int, int) {
 	return startIdx, (startIdx + len(start) + endIdx + len(end)) - 9 // remove the length <em></em> since we give Content the original data
 }
 
-func convertResult(searchResult *elastic.SearchResult, kw string, pageSize int) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
+func convertResult(searchResult *elastic.SearchResult, opts *internal.SearchOptions, keywordRegexp *regexp.Regexp, pageSize int) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
 	hits := make([]*internal.SearchResult, 0, pageSize)
 	for _, hit := range searchResult.Hits.Hits {
-		repoID, fileName := internal.ParseIndexerID(hit.Id)
+		repoID, ref, fileName := internal.ParseIndexerID(hit.Id)
 		res := make(map[string]any)
 		if err := json.Unmarshal(hit.Source, &res); err != nil {
 			return 0, nil, nil, err
@@ -304,7 +313,12 @@ func convertResult(searchResult *elastic.SearchResult, kw string, pageSize int)
 		// So we get it from content, this may made the query slower. See
 		// https://discuss.elastic.co/t/fetching-position-of-keyword-in-matched-document/94291
 		var startIndex, endIndex int
-		if c, ok := hit.Highlight["filename"]; ok && len(c) > 0 {
+		if opts.Symbol != "" {
+			startIndex, endIndex = internal.SymbolMatchIndexPos(fileName, res["language"].(string), res["content"].(string), opts.Symbol)
+		} else if loc := keywordRegexpIndex(keywordRegexp, res["content"].(string)); loc != nil {
+			// the highlighter doesn't always support the regexp queries, so the position is searched in the content
+			startIndex, endIndex = loc[0], loc[1]
+		} else if c, ok := hit.Highlight["filename"]; ok && len(c) > 0 {
 			startIndex, endIndex = internal.FilenameMatchIndexPos(res["content"].(string))
 		} else if c, ok := hit.Highlight["content"]; ok && len(c) > 0 {
 			// FIXME: Since the highlighting content will include <em> and </em> for the keywords,
@@ -312,8 +326,11 @@ func convertResult(searchResult *elastic.SearchResult, kw string, pageSize int)
 			// <em> and </em> tags? If elastic search has handled that?
 			startIndex, endIndex = contentMatchIndexPos(c[0], "<em>", "</em>")
 			if startIndex == -1 {
-				panic(fmt.Sprintf("1===%s,,,%#v,,,%s", kw, hit.Highlight, c[0]))
+				panic(fmt.Sprintf("1===%s,,,%#v,,,%s", opts.Keyword, hit.Highlight, c[0]))
 			}
+		} else if opts.Keyword == "" {
+			// only the path has been searched
+			startIndex, endIndex = internal.FilenameMatchIndexPos(res["content"].(string))
 		} else {
 			panic(fmt.Sprintf("2===%#v", hit.Highlight))
 		}
@@ -322,6 +339,7 @@ func convertResult(searchResult *elastic.SearchResult, kw string, pageSize int)
 
 		hits = append(hits, &internal.SearchResult{
 			RepoID:      repoID,
+			Ref:         ref,
 			Filename:    fileName,
 			CommitID:    res["commit_id"].(string),
 			Content:     res["content"].(string),
@@ -336,6 +354,13 @@ func convertResult(searchResult *elastic.SearchResult, kw string, pageSize int)
 	return searchResult.TotalHits(), hits, extractAggs(searchResult), nil
 }
 
+func keywordRegexpIndex(re *regexp.Regexp, content string) []int {
+	if re == nil {
+		return nil
+	}
+	return re.FindStringIndex(content)
+}
+
 func extractAggs(searchResult *elastic.SearchResult) []*internal.SearchResultLanguages {
 	var searchResultLanguages []*internal.SearchResultLanguages
 	agg, found := searchResult.Aggregations.Terms("language")
@@ -356,19 +381,46 @@ func extractAggs(searchResult *elastic.SearchResult) []*internal.SearchResultLan
 // Search searches for codes and language stats by given conditions.
 func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
 	var contentQuery elastic.Query
+	var keywordRegexp *regexp.Regexp
 	searchMode := util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)
-	if searchMode == indexer.SearchModeExact {
+	if searchMode == indexer.SearchModeRegexp {
+		// the regexp is matched against the words of the content
+		if err := internal.CheckTermRegexp(opts.Keyword); err != nil {
+			return 0, nil, nil, err
+		}
+		keywordRegexp = regexp.MustCompile("(?i)" + opts.Keyword)
+		contentQuery = elastic.NewRegexpQuery("content", ".*("+opts.Keyword+").*").CaseInsensitive(true)
+	} else if searchMode == indexer.SearchModeExact {
 		// 1.21 used NewMultiMatchQuery().Type(esMultiMatchTypePhrasePrefix), but later releases changed to NewMatchPhraseQuery
 		contentQuery = elastic.NewMatchPhraseQuery("content", opts.Keyword)
 	} else /* words */ {
 		contentQuery = elastic.NewMultiMatchQuery("content", opts.Keyword).Type(esMultiMatchTypeBestFields).Operator("and")
 	}
-	kwQuery := elastic.NewBoolQuery().Should(
-		contentQuery,
-		elastic.NewMultiMatchQuery(opts.Keyword, "filename^10").Type(esMultiMatchTypePhrasePrefix),
-	)
 	query := elastic.NewBoolQuery()
-	query = query.Must(kwQuery)
+	if opts.Keyword != "" {
+		kwQuery := elastic.NewBoolQuery().Should(
+			contentQuery,
+			elastic.NewMultiMatchQuery(opts.Keyword, "filename^10").Type(esMultiMatchTypePhrasePrefix),
+		)
+		query = query.Must(kwQuery)
+	}
+	if opts.Symbol != "" {
+		query = query.Must(elastic.NewTermQuery("symbols", strings.ToLower(opts.Symbol)))
+	}
+	if opts.Path != "" {
+		query = query.Must(elastic.NewWildcardQuery("filename.keyword", "*"+strings.ToLower(opts.Path)+"*"))
+	}
+	if refPatterns := internal.RefSearchPatterns(opts.Refs); !slices.Contains(refPatterns, "*") {
+		refQueries := make([]elastic.Query, 0, len(refPatterns))
+		for _, pattern := range refPatterns {
+			if internal.IsWildcardPattern(pattern) {
+				refQueries = append(refQueries, elastic.NewWildcardQuery("ref", pattern))
+			} else {
+				refQueries = append(refQueries, elastic.NewTermQuery("ref", pattern))
+			}
+		}
+		query = query.Must(elastic.NewBoolQuery().Should(refQueries...))
+	}
 	if len(opts.RepoIDs) > 0 {
 		repoStrs := make([]any, 0, len(opts.RepoIDs))
 		for _, repoID := range opts.RepoIDs {
@@ -380,7 +432,6 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 
 	var (
 		start, pageSize = opts.GetSkipTake()
-		kw              = "<em>" + opts.Keyword + "</em>"
 		aggregation     = elastic.NewTermsAggregation().Field("language").Size(10).OrderByCountDesc()
 	)
 
@@ -404,7 +455,7 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 			return 0, nil, nil, err
 		}
 
-		return convertResult(searchResult, kw, pageSize)
+		return convertResult(searchResult, opts, keywordRegexp, pageSize)
 	}
 
 	langQuery := elastic.NewMatchQuery("language", opts.Language)
@@ -437,7 +488,7 @@ func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int
 		return 0, nil, nil, err
 	}
 
-	total, hits, _, err := convertResult(searchResult, kw, pageSize)
+	total, hits, _, err := convertResult(searchResult, opts, keywordRegexp, pageSize)
 
 	return total, hits, extractAggs(countResult), err
 }
@@ -25,13 +25,47 @@ func getDefaultBranchSha(ctx context.Context, repo *repo_model.Repository) (stri
 	return strings.TrimSpace(stdout), nil
 }
 
-// getRepoChanges returns changes to repo since last indexer update
-func getRepoChanges(ctx context.Context, repo *repo_model.Repository, revision string) (*internal.RepoChanges, error) {
-	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode)
+type indexerRef struct {
+	Name git.RefName
+	Sha  string
+}
+
+// getIndexerRefs returns the branches and tags matching the code indexer ref patterns of the repository besides the default branch,
+// the most recent ones first. The sha of an annotated tag is the one of its commit.
+func getIndexerRefs(ctx context.Context, repo *repo_model.Repository) ([]*indexerRef, error) {
+	if len(repo.CodeIndexerRefPatterns) == 0 || setting.Indexer.MaxIndexerRefs <= 0 {
+		return nil, nil
+	}
+	patterns, err := internal.CompileRefPatterns(repo.CodeIndexerRefPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := gitcmd.NewCommand("for-each-ref", "--sort=-creatordate", "--format=%(refname) %(objectname) %(*objectname)", git.BranchPrefix, git.TagPrefix)
+	stdout, _, err := gitrepo.RunCmdString(ctx, repo, cmd)
 	if err != nil {
 		return nil, err
 	}
+	var refs []*indexerRef
+	for line := range strings.SplitSeq(stdout, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		ref := &indexerRef{Name: git.RefName(fields[0]), Sha: fields[len(fields)-1]}
+		if ref.Name == git.RefNameFromBranch(repo.DefaultBranch) || !patterns.Match(ref.Name) {
+			continue
+		}
+		refs = append(refs, ref)
+		if len(refs) == setting.Indexer.MaxIndexerRefs {
+			break
+		}
+	}
+	return refs, nil
+}
 
+// getRepoChanges returns changes to the ref of the status since last indexer update
+func getRepoChanges(ctx context.Context, repo *repo_model.Repository, status *repo_model.RepoIndexerStatus, revision string) (*internal.RepoChanges, error) {
 	needGenesis := len(status.CommitSha) == 0
 	if !needGenesis {
 		hasAncestorCmd := gitcmd.NewCommand("merge-base").AddDynamicArguments(status.CommitSha, revision)
@@ -42,7 +76,7 @@ func getRepoChanges(ctx context.Context, repo *repo_model.Repository, revision s
 	if needGenesis {
 		return genesisChanges(ctx, repo, revision)
 	}
-	return nonGenesisChanges(ctx, repo, revision)
+	return nonGenesisChanges(ctx, repo, status, revision)
 }
 
 func isIndexable(entry *git.TreeEntry) bool {
@@ -99,14 +133,14 @@ func genesisChanges(ctx context.Context, repo *repo_model.Repository, revision s
 }
 
 // nonGenesisChanges get changes since the previous indexer update
-func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, revision string) (*internal.RepoChanges, error) {
-	diffCmd := gitcmd.NewCommand("diff", "--name-status").AddDynamicArguments(repo.CodeIndexerStatus.CommitSha, revision)
+func nonGenesisChanges(ctx context.Context, repo *repo_model.Repository, status *repo_model.RepoIndexerStatus, revision string) (*internal.RepoChanges, error) {
+	diffCmd := gitcmd.NewCommand("diff", "--name-status").AddDynamicArguments(status.CommitSha, revision)
 	stdout, _, runErr := gitrepo.RunCmdString(ctx, repo, diffCmd)
 	if runErr != nil {
 		// previous commit sha may have been removed by a force push, so
 		// try rebuilding from scratch
 		log.Warn("git diff: %v", runErr)
-		if err := (*globalIndexer.Load()).Delete(ctx, repo.ID); err != nil {
+		if err := (*globalIndexer.Load()).DeleteRef(ctx, repo.ID, status.RefName); err != nil {
 			return nil, err
 		}
 		return genesisChanges(ctx, repo, revision)
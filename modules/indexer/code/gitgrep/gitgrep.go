@@ -6,12 +6,15 @@ package gitgrep
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/indexer"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 )
 
 func indexSettingToGitGrepPathspecList() (list []string) {
@@ -24,7 +27,76 @@ func indexSettingToGitGrepPathspecList() (list []string) {
 	return list
 }
 
-func PerformSearch(ctx context.Context, page int, repoID int64, gitRepo *git.Repository, ref git.RefName, keyword string, searchMode indexer.SearchModeType) (searchResults []*code_indexer.Result, total int64, err error) {
+// symbolDefinitionPattern returns a perl regexp matching the lines which may define the symbol after a keyword like "func" or "class"
+func symbolDefinitionPattern(symbol string) string {
+	return `(?i)\b(?:def|defn|defp|fn|fun|func|function|proc|sub|class|module|object|trait|enum|interface|protocol|record|struct|type|typealias|union)\b.*\b` +
+		regexp.QuoteMeta(symbol) + `\b`
+}
+
+// filterGrepResults keeps the results in the path and, if a symbol is searched, the ones whose lines define it
+func filterGrepResults(res []*git.GrepResult, q *code_indexer.Query) []*git.GrepResult {
+	if q.Path == "" && q.Symbol == "" {
+		return res
+	}
+	filtered := make([]*git.GrepResult, 0, len(res))
+	for _, r := range res {
+		if q.Path != "" && !strings.Contains(strings.ToLower(r.Filename), strings.ToLower(q.Path)) {
+			continue
+		}
+		if q.Symbol != "" {
+			found := false
+			for _, s := range internal.ExtractSymbols(r.Filename, "", strings.Join(r.LineCodes, "\n")) {
+				if strings.EqualFold(s.Name, q.Symbol) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+// searchRefs returns the default branch if the query has no "ref:" pattern, otherwise the branches and tags matching the patterns.
+// The number of refs besides the default branch is limited like for the code indexer.
+func searchRefs(gitRepo *git.Repository, defaultBranch git.RefName, patterns []string) ([]git.RefName, error) {
+	if len(patterns) == 0 {
+		return []git.RefName{defaultBranch}, nil
+	}
+	matcher, err := internal.CompileRefPatterns(patterns)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid ref pattern: %v", err)
+	}
+	var refs []git.RefName
+	if matcher.Match(internal.DefaultBranchRef) || matcher.Match(defaultBranch) {
+		refs = append(refs, defaultBranch)
+	}
+	gitRefs, err := gitRepo.GetRefs()
+	if err != nil {
+		return nil, err
+	}
+	extraRefs := 0
+	for _, gitRef := range gitRefs {
+		if extraRefs >= setting.Indexer.MaxIndexerRefs {
+			break
+		}
+		refName := git.RefName(gitRef.Name)
+		if (refName.IsBranch() || refName.IsTag()) && refName != defaultBranch && matcher.Match(refName) {
+			refs = append(refs, refName)
+			extraRefs++
+		}
+	}
+	return refs, nil
+}
+
+// PerformSearch searches the files of the default branch, or the branches and tags given by the "ref:" patterns, by "git grep".
+// The "sym:" qualifier of the query searches for definitions introduced by a keyword instead of the keyword of the query,
+// "path:" limits the results to the files whose path contains it.
+// The results of other refs than the default branch have their ref name, the matches are marked in their lines.
+func PerformSearch(ctx context.Context, page int, repoID int64, gitRepo *git.Repository, defaultBranch git.RefName, q *code_indexer.Query, searchMode indexer.SearchModeType) (searchResults []*code_indexer.Result, total int64, err error) {
 	grepMode := git.GrepModeWords
 	switch searchMode {
 	case indexer.SearchModeExact:
@@ -32,19 +104,53 @@ func PerformSearch(ctx context.Context, page int, repoID int64, gitRepo *git.Rep
 	case indexer.SearchModeRegexp:
 		grepMode = git.GrepModeRegexp
 	}
+	keyword := q.Keyword
+	if q.Symbol != "" {
+		keyword, grepMode = symbolDefinitionPattern(q.Symbol), git.GrepModeRegexp
+	} else if keyword == "" {
+		// only the path is given, search for any content
+		keyword, grepMode = "^", git.GrepModeRegexp
+	}
+	pathspecList := indexSettingToGitGrepPathspecList()
+	if q.Path != "" && len(setting.Indexer.IncludePatterns) == 0 {
+		// git grep searches the files matching any of the pathspecs, so the path can't be combined with the include patterns,
+		// the results are filtered by the path anyway
+		pathspecList = append(pathspecList, ":(icase)*"+q.Path+"*")
+	}
+	refs, err := searchRefs(gitRepo, defaultBranch, q.Refs)
+	if err != nil {
+		return nil, 0, err
+	} else if len(refs) == 0 {
+		return nil, 0, nil
+	}
+	refNames := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		refNames = append(refNames, ref.String())
+	}
 	res, err := git.GrepSearch(ctx, gitRepo, keyword, git.GrepOptions{
 		ContextLineNumber: 1,
 		GrepMode:          grepMode,
-		RefName:           ref.String(),
-		PathspecList:      indexSettingToGitGrepPathspecList(),
+		RefNames:          refNames,
+		PathspecList:      pathspecList,
 	})
 	if err != nil {
 		// TODO: if no branch exists, it reports: exit status 128, fatal: this operation must be run in a work tree.
 		return nil, 0, fmt.Errorf("git.GrepSearch: %w", err)
 	}
-	commitID, err := gitRepo.GetRefCommitID(ref.String())
-	if err != nil {
-		return nil, 0, fmt.Errorf("gitRepo.GetRefCommitID: %w", err)
+	res = filterGrepResults(res, q)
+	commitIDs := make(map[string]string, len(refs))
+	for _, ref := range refNames {
+		if commitIDs[ref], err = gitRepo.GetRefCommitID(ref); err != nil {
+			return nil, 0, fmt.Errorf("gitRepo.GetRefCommitID: %w", err)
+		}
+	}
+
+	// the symbol is searched instead of the keyword, the words are searched case-insensitively
+	var matchRegexp *regexp.Regexp
+	if q.Symbol != "" {
+		matchRegexp = code_indexer.SearchMatchRegexp("", q.Symbol, searchMode, false)
+	} else {
+		matchRegexp = code_indexer.SearchMatchRegexp(q.Keyword, "", searchMode, grepMode != git.GrepModeWords)
 	}
 
 	total = int64(len(res))
@@ -52,14 +158,20 @@ func PerformSearch(ctx context.Context, page int, repoID int64, gitRepo *git.Rep
 	pageEnd := min(page*setting.UI.RepoSearchPagingNum, len(res))
 	res = res[pageStart:pageEnd]
 	for _, r := range res {
+		ref := git.RefName(r.RefName)
+		if ref == defaultBranch {
+			ref = ""
+		}
+		code := strings.Join(r.LineCodes, "\n")
 		searchResults = append(searchResults, &code_indexer.Result{
 			RepoID:   repoID,
+			Ref:      ref,
 			Filename: r.Filename,
-			CommitID: commitID,
+			CommitID: commitIDs[r.RefName],
 			// UpdatedUnix: not supported yet
 			// Language:    not supported yet
 			// Color:       not supported yet
-			Lines: code_indexer.HighlightSearchResultCode(r.Filename, "", r.LineNumbers, strings.Join(r.LineCodes, "\n")),
+			Lines: code_indexer.HighlightSearchResultCode(r.Filename, "", r.LineNumbers, code, code_indexer.SearchMatchRanges(matchRegexp, code)),
 		})
 	}
 	return searchResults, total, nil
@@ -6,6 +6,8 @@ package gitgrep
 import (
 	"testing"
 
+	"code.gitea.io/gitea/modules/git"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/test"
 
@@ -17,3 +19,18 @@ func TestIndexSettingToGitGrepPathspecList(t *testing.T) {
 	defer test.MockVariableValue(&setting.Indexer.ExcludePatterns, setting.IndexerGlobFromString("b"))()
 	assert.Equal(t, []string{":(glob)a", ":(glob,exclude)b"}, indexSettingToGitGrepPathspecList())
 }
+
+func TestFilterGrepResults(t *testing.T) {
+	res := []*git.GrepResult{
+		{Filename: "cmd/main.go", LineCodes: []string{"func main() {", "\tserve()"}},
+		{Filename: "server/serve.go", LineCodes: []string{"// serve starts the server", "func serve() {"}},
+		{Filename: "server/other.go", LineCodes: []string{"type handler struct {", "\tserve func()"}},
+	}
+	assert.Equal(t, res, filterGrepResults(res, &code_indexer.Query{Keyword: "serve"}))
+	assert.Equal(t, res[1:], filterGrepResults(res, &code_indexer.Query{Path: "SERVER/"}))
+	assert.Equal(t, res[1:2], filterGrepResults(res, &code_indexer.Query{Symbol: "Serve"}))
+	assert.Empty(t, filterGrepResults(res, &code_indexer.Query{Symbol: "serve", Path: "cmd"}))
+
+	assert.Regexp(t, symbolDefinitionPattern("serve"), "func (s *Server) serve() {")
+	assert.NotRegexp(t, symbolDefinitionPattern("serve"), "\tserve()")
+}
@@ -13,11 +13,14 @@ import (
 
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/graceful"
 	"code.gitea.io/gitea/modules/indexer"
 	"code.gitea.io/gitea/modules/indexer/code/bleve"
 	"code.gitea.io/gitea/modules/indexer/code/elasticsearch"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/indexer/code/trigram"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/queue"
@@ -77,18 +80,78 @@ func index(ctx context.Context, indexer internal.Indexer, repoID int64) error {
 	if err != nil {
 		return err
 	}
-	changes, err := getRepoChanges(ctx, repo, sha)
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		return err
+	}
+	if err := indexRef(ctx, indexer, repo, status, sha); err != nil {
+		return err
+	}
+
+	return indexExtraRefs(ctx, indexer, repo)
+}
+
+// indexRef indexes the changes of the ref of the status, the default branch if its ref name is empty
+func indexRef(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository, status *repo_model.RepoIndexerStatus, sha string) error {
+	if status.CommitSha == sha {
+		return nil
+	}
+	changes, err := getRepoChanges(ctx, repo, status, sha)
 	if err != nil {
 		return err
 	} else if changes == nil {
 		return nil
 	}
 
-	if err := indexer.Index(ctx, repo, sha, changes); err != nil {
+	if err := indexer.Index(ctx, repo, status.RefName, sha, changes); err != nil {
 		return err
 	}
 
-	return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode, sha)
+	if status.RefName == "" {
+		return repo_model.UpdateIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeCode, sha)
+	}
+	return repo_model.UpdateIndexerRefStatus(ctx, status, sha)
+}
+
+// indexExtraRefs indexes the branches and tags matching the ref patterns of the repository,
+// and removes the refs which don't match anymore from the index
+func indexExtraRefs(ctx context.Context, indexer internal.Indexer, repo *repo_model.Repository) error {
+	statuses, err := repo_model.GetIndexerRefStatuses(ctx, repo.ID, repo_model.RepoIndexerTypeCode)
+	if err != nil {
+		return err
+	}
+	refs, err := getIndexerRefs(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	indexed := make(container.Set[string], len(refs))
+	for _, ref := range refs {
+		idx := slices.IndexFunc(statuses, func(status *repo_model.RepoIndexerStatus) bool {
+			return status.RefName == ref.Name.String()
+		})
+		status := &repo_model.RepoIndexerStatus{RepoID: repo.ID, IndexerType: repo_model.RepoIndexerTypeCode, RefName: ref.Name.String()}
+		if idx >= 0 {
+			status = statuses[idx]
+		}
+		if err := indexRef(ctx, indexer, repo, status, ref.Sha); err != nil {
+			return err
+		}
+		indexed.Add(ref.Name.String())
+	}
+
+	for _, status := range statuses {
+		if indexed.Contains(status.RefName) {
+			continue
+		}
+		if err := indexer.DeleteRef(ctx, repo.ID, status.RefName); err != nil {
+			return err
+		}
+		if err := repo_model.DeleteIndexerRefStatus(ctx, status.ID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Init initialize the repo indexer
@@ -117,7 +180,7 @@ func Init() {
 
 	// Create the Queue
 	switch setting.Indexer.RepoType {
-	case "bleve", "elasticsearch":
+	case "bleve", "elasticsearch", "trigram":
 		handler := func(items ...*internal.IndexerData) (unhandled []*internal.IndexerData) {
 			indexer := *globalIndexer.Load()
 			for _, indexerData := range items {
@@ -166,6 +229,24 @@ func Init() {
 				close(waitChannel)
 				log.Fatal("PID: %d Unable to initialize the bleve Repository Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.RepoPath, err)
 			}
+		case "trigram":
+			log.Info("PID: %d Initializing Repository Indexer at: %s", os.Getpid(), setting.Indexer.RepoPath)
+			defer func() {
+				if err := recover(); err != nil {
+					log.Error("PANIC whilst initializing repository indexer: %v\nStacktrace: %s", err, log.Stack(2))
+					log.Error("The indexer files are likely corrupted and may need to be deleted")
+					log.Error("You can completely remove the \"%s\" directory to make Gitea recreate the indexes", setting.Indexer.RepoPath)
+				}
+			}()
+
+			rIndexer = trigram.NewIndexer(setting.Indexer.RepoPath)
+			existed, err = rIndexer.Init(ctx)
+			if err != nil {
+				cancel()
+				(*globalIndexer.Load()).Close()
+				close(waitChannel)
+				log.Fatal("PID: %d Unable to initialize the trigram Repository Indexer at path: %s Error: %v", os.Getpid(), setting.Indexer.RepoPath, err)
+			}
 		case "elasticsearch":
 			log.Info("PID: %d Initializing Repository Indexer at: %s", os.Getpid(), util.SanitizeCredentialURLs(setting.Indexer.RepoConnStr))
 			defer func() {
@@ -242,6 +323,25 @@ func UpdateRepoIndexer(repo *repo_model.Repository) {
 	}
 }
 
+// IsIndexedRef checks if the ref is indexed for the repository, it is either the default branch
+// or a branch or tag matching the code search ref patterns of the repository
+func IsIndexedRef(repo *repo_model.Repository, refName git.RefName) bool {
+	if refName == git.RefNameFromBranch(repo.DefaultBranch) {
+		return true
+	}
+	if len(repo.CodeIndexerRefPatterns) == 0 || setting.Indexer.MaxIndexerRefs <= 0 {
+		return false
+	}
+	patterns, err := internal.CompileRefPatterns(repo.CodeIndexerRefPatterns)
+	return err == nil && patterns.Match(refName)
+}
+
+// ValidateRefPatterns checks the glob patterns of the branches and tags to index
+func ValidateRefPatterns(patterns []string) error {
+	_, err := internal.CompileRefPatterns(patterns)
+	return err
+}
+
 // IsAvailable checks if issue indexer is available
 func IsAvailable(ctx context.Context) bool {
 	return (*globalIndexer.Load()).Ping(ctx) == nil
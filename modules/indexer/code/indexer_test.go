@@ -10,11 +10,13 @@ import (
 	"testing"
 
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	indexer_module "code.gitea.io/gitea/modules/indexer"
 	"code.gitea.io/gitea/modules/indexer/code/bleve"
 	"code.gitea.io/gitea/modules/indexer/code/elasticsearch"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/indexer/code/trigram"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/test"
 	"code.gitea.io/gitea/modules/util"
@@ -43,6 +45,7 @@ func testIndexer(name string, t *testing.T, indexer internal.Indexer) {
 		keywords := []struct {
 			RepoIDs    []int64
 			Keyword    string
+			Path       string
 			Langs      int
 			SearchMode indexer_module.SearchModeType
 			Results    []codeSearchResult
@@ -234,13 +237,42 @@ func testIndexer(name string, t *testing.T, indexer internal.Indexer) {
 					},
 				},
 			},
+			// Search for files by a part of their path within the repo '62'.
+			// This scenario yields a single result (the file potato/ham.md on the repo '62')
+			{
+				RepoIDs: []int64{62},
+				Path:    "POTATO/",
+				Langs:   1,
+				Results: []codeSearchResult{
+					{
+						Filename: "potato/ham.md",
+						Content:  "This is not cheese",
+					},
+				},
+			},
+			// Search for matches on the contents of files within a path of the repo '62'.
+			// This scenario yields a single result (the file potato/ham.md), the file ham.md in the root
+			// directory also contains the keyword but its path doesn't contain "to/h"
+			{
+				RepoIDs: []int64{62},
+				Keyword: "cheese",
+				Path:    "to/h",
+				Langs:   1,
+				Results: []codeSearchResult{
+					{
+						Filename: "potato/ham.md",
+						Content:  "This is not cheese",
+					},
+				},
+			},
 		}
 
 		for _, kw := range keywords {
-			t.Run(kw.Keyword, func(t *testing.T) {
+			t.Run(kw.Keyword+kw.Path, func(t *testing.T) {
 				total, res, langs, err := indexer.Search(t.Context(), &internal.SearchOptions{
 					RepoIDs:    kw.RepoIDs,
 					Keyword:    kw.Keyword,
+					Path:       kw.Path,
 					SearchMode: util.IfZero(kw.SearchMode, indexer_module.SearchModeWords),
 					Paginator: &db.ListOptions{
 						Page:     1,
@@ -263,6 +295,11 @@ func testIndexer(name string, t *testing.T, indexer internal.Indexer) {
 					})
 				}
 
+				if kw.Path != "" {
+					// the files outside of the path must not be found
+					assert.Len(t, hits, len(kw.Results))
+				}
+
 				lastIndex := -1
 
 				for _, expected := range kw.Results {
@@ -296,6 +333,18 @@ func TestBleveIndexAndSearch(t *testing.T) {
 	testIndexer("bleve", t, idx)
 }
 
+func TestTrigramIndexAndSearch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	idx := trigram.NewIndexer(t.TempDir())
+	defer idx.Close()
+
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	testIndexer("trigram", t, idx)
+}
+
 func TestESIndexAndSearch(t *testing.T) {
 	unittest.PrepareTestEnv(t)
 
@@ -318,6 +367,60 @@ func TestESIndexAndSearch(t *testing.T) {
 	testIndexer("elastic_search", t, indexer)
 }
 
+func TestBleveIndexExtraRefs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	idx := bleve.NewIndexer(t.TempDir())
+	defer idx.Close()
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	updatePatterns := func(patterns ...string) {
+		repo.CodeIndexerRefPatterns = patterns
+		require.NoError(t, repo_model.UpdateRepositoryColsNoAutoTime(t.Context(), repo, "code_indexer_ref_patterns"))
+		require.NoError(t, index(t.Context(), idx, repo.ID))
+	}
+	search := func(keyword string, refs ...string) (refNames []string) {
+		_, results, _, err := idx.Search(t.Context(), &internal.SearchOptions{
+			RepoIDs:   []int64{repo.ID},
+			Keyword:   keyword,
+			Refs:      refs,
+			Paginator: &db.ListOptions{Page: 1, PageSize: 10},
+		})
+		require.NoError(t, err)
+		for _, result := range results {
+			refNames = append(refNames, result.Ref)
+		}
+		slices.Sort(refNames)
+		return refNames
+	}
+	countStatuses := func() int {
+		statuses, err := repo_model.GetIndexerRefStatuses(t.Context(), repo.ID, repo_model.RepoIndexerTypeCode)
+		require.NoError(t, err)
+		return len(statuses)
+	}
+
+	updatePatterns("branch*", "DefaultBranch")
+	assert.True(t, IsIndexedRef(repo, "refs/heads/branch2"))
+	assert.True(t, IsIndexedRef(repo, "refs/heads/master"))
+	assert.False(t, IsIndexedRef(repo, "refs/heads/develop"))
+	assert.Equal(t, []string{"refs/heads/branch2"}, search("second", "*"))
+	assert.Empty(t, search("second"))
+	assert.Equal(t, []string{"", "refs/heads/DefaultBranch", "refs/heads/branch2"}, search("Description", "*"))
+	assert.Equal(t, []string{""}, search("Description"))
+	assert.Equal(t, 2, countStatuses())
+
+	updatePatterns("DefaultBranch")
+	assert.Equal(t, []string{"", "refs/heads/DefaultBranch"}, search("Description", "*"))
+	assert.Equal(t, 1, countStatuses())
+
+	defer test.MockVariableValue(&setting.Indexer.MaxIndexerRefs, 0)()
+	updatePatterns("DefaultBranch")
+	assert.False(t, IsIndexedRef(repo, "refs/heads/DefaultBranch"))
+	assert.Equal(t, []string{""}, search("Description", "*"))
+	assert.Equal(t, 0, countStatuses())
+}
+
 func setupRepositoryIndexes(ctx context.Context, indexer internal.Indexer) error {
 	for _, repoID := range repositoriesToSearch() {
 		if err := index(ctx, indexer, repoID); err != nil {
@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/typesniffer"
+)
+
+// ReadFileContent reads the content of an updated file for the indexers.
+// tooLarge is true if the file exceeds the size limit of the indexer, then it has to be removed from the index.
+// The content is nil if the file isn't a text file.
+func ReadFileContent(ctx context.Context, catFileBatch git.CatFileBatch, repo *repo_model.Repository, update FileUpdate) (content []byte, tooLarge bool, err error) {
+	size := update.Size
+	if !update.Sized {
+		var stdout string
+		stdout, _, err = gitrepo.RunCmdString(ctx, repo, gitcmd.NewCommand("cat-file", "-s").AddDynamicArguments(update.BlobSha))
+		if err != nil {
+			return nil, false, err
+		}
+		if size, err = strconv.ParseInt(strings.TrimSpace(stdout), 10, 64); err != nil {
+			return nil, false, fmt.Errorf("misformatted git cat-file output: %w", err)
+		}
+	}
+
+	if size > setting.Indexer.MaxIndexerFileSize {
+		return nil, true, nil
+	}
+
+	info, batchReader, err := catFileBatch.QueryContent(update.BlobSha)
+	if err != nil {
+		return nil, false, err
+	}
+	content, err = io.ReadAll(io.LimitReader(batchReader, info.Size))
+	if err != nil {
+		return nil, false, err
+	} else if !typesniffer.DetectContentType(content).IsText() {
+		// FIXME: UTF-16 files will probably fail here
+		content = nil
+	}
+
+	if _, err = batchReader.Discard(1); err != nil {
+		return nil, false, err
+	}
+	return content, false, nil
+}
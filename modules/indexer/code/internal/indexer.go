@@ -16,8 +16,11 @@ import (
 // Indexer defines an interface to index and search code contents
 type Indexer interface {
 	internal.Indexer
-	Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *RepoChanges) error
+	// Index indexes the changes of the ref, an empty ref refers to the default branch
+	Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *RepoChanges) error
 	Delete(ctx context.Context, repoID int64) error
+	// DeleteRef deletes the files of a ref, an empty ref refers to the default branch
+	DeleteRef(ctx context.Context, repoID int64, ref string) error
 	Search(ctx context.Context, opts *SearchOptions) (int64, []*SearchResult, []*SearchResultLanguages, error)
 	SupportedSearchModes() []indexer.SearchMode
 }
@@ -26,6 +29,9 @@ type SearchOptions struct {
 	RepoIDs  []int64
 	Keyword  string
 	Language string
+	Symbol   string   // only files defining a function, class or type with the name (case-insensitive)
+	Path     string   // only files whose path contains it (case-insensitive)
+	Refs     []string // glob patterns of the refs to search in, see RefSearchPatterns
 
 	SearchMode indexer.SearchModeType
 
@@ -47,7 +53,11 @@ func (d *dummyIndexer) SupportedSearchModes() []indexer.SearchMode {
 	return nil
 }
 
-func (d *dummyIndexer) Index(ctx context.Context, repo *repo_model.Repository, sha string, changes *RepoChanges) error {
+func (d *dummyIndexer) Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *RepoChanges) error {
+	return errors.New("indexer is not ready")
+}
+
+func (d *dummyIndexer) DeleteRef(ctx context.Context, repoID int64, ref string) error {
 	return errors.New("indexer is not ready")
 }
 
@@ -26,6 +26,7 @@ type IndexerData struct {
 // SearchResult result of performing a search in a repo
 type SearchResult struct {
 	RepoID      int64
+	Ref         string // empty for the default branch
 	StartIndex  int
 	EndIndex    int
 	Filename    string
@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"strings"
+
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/glob"
+)
+
+// DefaultBranchRef is the ref stored in the indexers for the files of the default branch,
+// the API uses an empty ref for it
+const DefaultBranchRef = "HEAD"
+
+// RefPatterns matches the full names of the branches and tags against glob patterns like "release/*" or "refs/tags/v1.*".
+// A pattern without "refs/" prefix matches both the branches and the tags with the name, "*" matches any characters.
+type RefPatterns []glob.Glob
+
+// CompileRefPatterns compiles the patterns, empty ones are skipped
+func CompileRefPatterns(patterns []string) (RefPatterns, error) {
+	globs := make(RefPatterns, 0, len(patterns))
+	for _, pattern := range patterns {
+		for _, p := range expandRefPattern(pattern) {
+			g, err := glob.Compile(p)
+			if err != nil {
+				return nil, err
+			}
+			globs = append(globs, g)
+		}
+	}
+	return globs, nil
+}
+
+// Match checks if any of the patterns matches the full ref name
+func (patterns RefPatterns) Match(refName git.RefName) bool {
+	for _, g := range patterns {
+		if g.Match(refName.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+func expandRefPattern(pattern string) []string {
+	pattern = strings.TrimSpace(pattern)
+	switch {
+	case pattern == "":
+		return nil
+	case pattern == DefaultBranchRef || strings.HasPrefix(pattern, "refs/"):
+		return []string{pattern}
+	}
+	return []string{git.BranchPrefix + pattern, git.TagPrefix + pattern}
+}
+
+// RefSearchPatterns converts the "ref:" patterns of a search to wildcard patterns of the refs stored in the indexers.
+// No pattern searches the default branch, the DefaultBranchRef pattern too, and "*" searches all the indexed refs.
+func RefSearchPatterns(refs []string) []string {
+	if len(refs) == 0 {
+		return []string{DefaultBranchRef}
+	}
+	patterns := make([]string, 0, len(refs)*2)
+	for _, ref := range refs {
+		if strings.TrimSpace(ref) == "*" {
+			return []string{"*"}
+		}
+		patterns = append(patterns, expandRefPattern(ref)...)
+	}
+	return patterns
+}
+
+// IsWildcardPattern checks if the search pattern has wildcards, otherwise it is a single ref
+func IsWildcardPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?")
+}
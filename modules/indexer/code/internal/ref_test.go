@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileRefPatterns(t *testing.T) {
+	patterns, err := CompileRefPatterns([]string{"release/*", " ", "refs/tags/v1.*"})
+	require.NoError(t, err)
+	assert.True(t, patterns.Match("refs/heads/release/1.0"))
+	assert.True(t, patterns.Match("refs/tags/release/1.0"))
+	assert.True(t, patterns.Match("refs/tags/v1.2"))
+	assert.False(t, patterns.Match("refs/heads/v1.2"))
+	assert.False(t, patterns.Match("refs/heads/main"))
+	assert.False(t, patterns.Match(git.RefName(DefaultBranchRef)))
+
+	patterns, err = CompileRefPatterns(nil)
+	require.NoError(t, err)
+	assert.False(t, patterns.Match("refs/heads/main"))
+
+	_, err = CompileRefPatterns([]string{"release/["})
+	assert.Error(t, err)
+}
+
+func TestRefSearchPatterns(t *testing.T) {
+	assert.Equal(t, []string{DefaultBranchRef}, RefSearchPatterns(nil))
+	assert.Equal(t, []string{"*"}, RefSearchPatterns([]string{"main", "*"}))
+	assert.Equal(t, []string{"refs/heads/release/*", "refs/tags/release/*", "refs/tags/v1", DefaultBranchRef},
+		RefSearchPatterns([]string{"release/*", "refs/tags/v1", DefaultBranchRef}))
+}
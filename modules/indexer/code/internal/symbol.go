@@ -0,0 +1,178 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"code.gitea.io/gitea/modules/highlight"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/go-enry/go-enry/v2"
+)
+
+// SymbolKind is the kind of a symbol definition
+type SymbolKind string
+
+const (
+	SymbolKindFunction SymbolKind = "function"
+	SymbolKindClass    SymbolKind = "class"
+	SymbolKindType     SymbolKind = "type"
+)
+
+// definitionKeywords maps the keywords which introduce a definition to the kind of the defined symbol
+var definitionKeywords = map[string]SymbolKind{
+	"def":       SymbolKindFunction,
+	"defmacro":  SymbolKindFunction,
+	"defn":      SymbolKindFunction,
+	"defp":      SymbolKindFunction,
+	"fn":        SymbolKindFunction,
+	"fun":       SymbolKindFunction,
+	"func":      SymbolKindFunction,
+	"function":  SymbolKindFunction,
+	"proc":      SymbolKindFunction,
+	"sub":       SymbolKindFunction,
+	"class":     SymbolKindClass,
+	"module":    SymbolKindClass,
+	"object":    SymbolKindClass,
+	"trait":     SymbolKindClass,
+	"enum":      SymbolKindType,
+	"interface": SymbolKindType,
+	"protocol":  SymbolKindType,
+	"record":    SymbolKindType,
+	"struct":    SymbolKindType,
+	"type":      SymbolKindType,
+	"typealias": SymbolKindType,
+	"union":     SymbolKindType,
+}
+
+// Symbol is a definition of a function, class or type in a file
+type Symbol struct {
+	Name  string
+	Kind  SymbolKind
+	Start int // the byte offsets of the name in the content
+	End   int
+}
+
+func isSymbolName(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '$' || unicode.IsLetter(r):
+		case i > 0 && (unicode.IsDigit(r) || r == '?' || r == '!'):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
+// ExtractSymbols returns the definitions of functions, classes and types in the content of a source code file.
+// It uses the lexer of the syntax highlighter: a name following a definition keyword like "func", "def" or "class"
+// is a definition, as well as a function name following a type like in C or Java.
+func ExtractSymbols(filename, language, content string) []*Symbol {
+	if language != "" && enry.GetLanguageType(language) != enry.Programming {
+		return nil
+	}
+	lexer := highlight.DetectChromaLexerByFileName(filename, language)
+	if lexer == nil || lexer == lexers.Fallback {
+		return nil
+	}
+	iterator, err := lexer.Tokenise(nil, content)
+	if err != nil {
+		return nil
+	}
+
+	var (
+		symbols []*Symbol
+		pending SymbolKind // the kind of the definition introduced by the last keyword
+		depth   int        // the depth of the parentheses of a method receiver like in "func (r *T) Name()"
+		last    *Symbol    // the last definition, it is a qualifier if it is followed by a "." like in "def self.name"
+		prev    chroma.Token
+		offset  int
+	)
+	add := func(name string, kind SymbolKind, offset int) *Symbol {
+		// the lexers may normalize the line endings, so the offset can be behind the real position
+		if offset > len(content) || !strings.HasPrefix(content[offset:], name) {
+			idx := strings.Index(content[min(offset, len(content)):], name)
+			if idx < 0 {
+				return nil
+			}
+			offset += idx
+		}
+		s := &Symbol{Name: name, Kind: kind, Start: offset, End: offset + len(name)}
+		symbols = append(symbols, s)
+		return s
+	}
+
+	for _, token := range iterator.Tokens() {
+		value := token.Value
+		tokenOffset := offset
+		offset += len(value)
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		if last != nil && (value == "." || value == "::") {
+			symbols = symbols[:len(symbols)-1]
+			pending, last = last.Kind, nil
+			continue
+		}
+		last = nil
+
+		if pending != "" {
+			switch {
+			case depth > 0:
+				depth += strings.Count(value, "(") - strings.Count(value, ")")
+				continue
+			case pending == SymbolKindFunction && token.Type.Category() == chroma.Punctuation && strings.HasPrefix(value, "("):
+				depth = strings.Count(value, "(") - strings.Count(value, ")")
+				if depth > 0 {
+					continue
+				}
+			case token.Type.Category() == chroma.Name && isSymbolName(value):
+				last = add(value, pending, tokenOffset)
+				pending = ""
+				prev = token
+				continue
+			}
+			pending, depth = "", 0
+		}
+
+		switch {
+		case token.Type.Category() == chroma.Keyword:
+			pending = definitionKeywords[strings.ToLower(value)]
+		case token.Type == chroma.NameFunction && isSymbolName(value) &&
+			(prev.Type == chroma.KeywordType || prev.Type.Category() == chroma.Name):
+			// a function definition with a return type, calls are preceded by operators, punctuations or other keywords
+			add(value, SymbolKindFunction, tokenOffset)
+		}
+		prev = token
+	}
+	return symbols
+}
+
+// SymbolNames returns the distinct names of the symbols
+func SymbolNames(symbols []*Symbol) []string {
+	names := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if !slices.Contains(names, s.Name) {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// SymbolMatchIndexPos returns the boundaries of the first definition of the symbol in the content,
+// or the boundaries of its first seven lines if the definition can't be found.
+func SymbolMatchIndexPos(filename, language, content, name string) (int, int) {
+	for _, s := range ExtractSymbols(filename, language, content) {
+		if strings.EqualFold(s.Name, name) {
+			return s.Start, s.End
+		}
+	}
+	return FilenameMatchIndexPos(content)
+}
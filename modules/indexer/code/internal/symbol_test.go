@@ -0,0 +1,117 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractSymbols(t *testing.T) {
+	cases := []struct {
+		Filename string
+		Language string
+		Content  string
+		Symbols  map[string]SymbolKind
+	}{
+		{
+			Filename: "main.go",
+			Language: "Go",
+			Content: `package main
+
+type Server struct{}
+
+type (
+	grouped int
+)
+
+func (s *Server) Serve() error {
+	return start()
+}
+
+func Map[T any](v T) T {
+	x := start()
+	return v
+}
+`,
+			Symbols: map[string]SymbolKind{"Server": SymbolKindType, "Serve": SymbolKindFunction, "Map": SymbolKindFunction},
+		},
+		{
+			Filename: "app.py",
+			Content: `class Handler(Base):
+    def handle(self):
+        return process(self)
+`,
+			Symbols: map[string]SymbolKind{"Handler": SymbolKindClass, "handle": SymbolKindFunction},
+		},
+		{
+			Filename: "index.js",
+			Content: `function render(el) {
+  return build(el)
+}
+class Widget {}
+const cb = function (a) { return a }
+`,
+			Symbols: map[string]SymbolKind{"render": SymbolKindFunction, "Widget": SymbolKindClass},
+		},
+		{
+			Filename: "lib.rs",
+			Content: `struct Point { x: i32 }
+trait Shape {}
+fn area(p: &Point) -> i32 { compute(p) }
+`,
+			Symbols: map[string]SymbolKind{"Point": SymbolKindType, "Shape": SymbolKindClass, "area": SymbolKindFunction},
+		},
+		{
+			Filename: "Main.java",
+			Content: `public class Main {
+    public static void main(String[] args) {
+        run(args);
+    }
+}
+`,
+			Symbols: map[string]SymbolKind{"Main": SymbolKindClass, "main": SymbolKindFunction},
+		},
+		{
+			Filename: "lib.rb",
+			Content: `module Tools
+  def self.helper
+  end
+end
+`,
+			Symbols: map[string]SymbolKind{"Tools": SymbolKindClass, "helper": SymbolKindFunction},
+		},
+		{
+			Filename: "README.md",
+			Language: "Markdown",
+			Content:  "```go\nfunc Example() {}\n```\n",
+		},
+	}
+
+	for _, c := range cases {
+		symbols := ExtractSymbols(c.Filename, c.Language, c.Content)
+		found := make(map[string]SymbolKind, len(symbols))
+		for _, s := range symbols {
+			found[s.Name] = s.Kind
+			assert.Equal(t, s.Name, c.Content[s.Start:s.End], c.Filename)
+		}
+		if len(c.Symbols) == 0 {
+			assert.Empty(t, found, c.Filename)
+		} else {
+			assert.Equal(t, c.Symbols, found, c.Filename)
+		}
+	}
+}
+
+func TestSymbolMatchIndexPos(t *testing.T) {
+	content := "package main\n\n// Serve is called by main\nfunc Serve() {}\n"
+	start, end := SymbolMatchIndexPos("main.go", "Go", content, "serve")
+	assert.Equal(t, "Serve", content[start:end])
+	assert.Equal(t, 46, start)
+
+	start, end = SymbolMatchIndexPos("main.go", "Go", content, "unknown")
+	assert.Equal(t, 0, start)
+	assert.Equal(t, len(content), end)
+}
@@ -4,33 +4,45 @@
 package internal
 
 import (
+	"regexp/syntax"
 	"strings"
 
 	"code.gitea.io/gitea/modules/indexer/internal"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
 )
 
 const filenameMatchNumberOfLines = 7 // Copied from GitHub search
 
-func FilenameIndexerID(repoID int64, filename string) string {
-	return internal.Base36(repoID) + "_" + filename
+// FilenameIndexerID returns the ID of the document of a file, the default branch is referred by an empty ref
+func FilenameIndexerID(repoID int64, ref, filename string) string {
+	if ref == "" || ref == DefaultBranchRef {
+		return internal.Base36(repoID) + "_" + filename
+	}
+	return internal.Base36(repoID) + "@" + ref + ":" + filename
 }
 
-func ParseIndexerID(indexerID string) (int64, string) {
-	before, after, ok := strings.Cut(indexerID, "_")
-	if !ok {
+// ParseIndexerID returns the repository ID, the ref and the filename of the document ID
+func ParseIndexerID(indexerID string) (repoID int64, ref, filename string) {
+	pos := strings.IndexAny(indexerID, "_@")
+	if pos < 0 {
 		log.Error("Unexpected ID in repo indexer: %s", indexerID)
+		return 0, "", ""
+	}
+	repoID, _ = internal.ParseBase36(indexerID[:pos])
+	filename = indexerID[pos+1:]
+	if indexerID[pos] == '@' {
+		var ok bool
+		if ref, filename, ok = strings.Cut(filename, ":"); !ok {
+			log.Error("Unexpected ID in repo indexer: %s", indexerID)
+		}
 	}
-	repoID, _ := internal.ParseBase36(before)
-	return repoID, after
+	return repoID, ref, filename
 }
 
 func FilenameOfIndexerID(indexerID string) string {
-	_, after, ok := strings.Cut(indexerID, "_")
-	if !ok {
-		log.Error("Unexpected ID in repo indexer: %s", indexerID)
-	}
-	return after
+	_, _, filename := ParseIndexerID(indexerID)
+	return filename
 }
 
 // FilenameMatchIndexPos returns the boundaries of its first seven lines.
@@ -46,3 +58,26 @@ func FilenameMatchIndexPos(content string) (int, int) {
 	}
 	return 0, len(content)
 }
+
+// CheckTermRegexp checks if the regexp can be matched against the indexed terms,
+// the indexers don't support anchors and word boundaries since the terms are matched as a whole
+func CheckTermRegexp(pattern string) error {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return util.NewInvalidArgumentErrorf("invalid regular expression: %v", err)
+	}
+	var check func(re *syntax.Regexp) error
+	check = func(re *syntax.Regexp) error {
+		switch re.Op {
+		case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+			return util.NewInvalidArgumentErrorf("unsupported regular expression: %s", re)
+		}
+		for _, sub := range re.Sub {
+			if err := check(sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check(re)
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexerID(t *testing.T) {
+	cases := []struct {
+		RepoID   int64
+		Ref      string
+		Filename string
+		ID       string
+	}{
+		{RepoID: 36, Filename: "dir/a_b.go", ID: "10_dir/a_b.go"},
+		{RepoID: 36, Ref: DefaultBranchRef, Filename: "a.go", ID: "10_a.go"},
+		{RepoID: 1, Ref: "refs/heads/release/1.0", Filename: "a@b:c.go", ID: "1@refs/heads/release/1.0:a@b:c.go"},
+	}
+	for _, c := range cases {
+		id := FilenameIndexerID(c.RepoID, c.Ref, c.Filename)
+		assert.Equal(t, c.ID, id)
+		repoID, ref, filename := ParseIndexerID(id)
+		assert.Equal(t, c.RepoID, repoID)
+		if c.Ref != DefaultBranchRef {
+			assert.Equal(t, c.Ref, ref)
+		}
+		assert.Equal(t, c.Filename, filename)
+		assert.Equal(t, c.Filename, FilenameOfIndexerID(id))
+	}
+}
+
+func TestCheckTermRegexp(t *testing.T) {
+	assert.NoError(t, CheckTermRegexp(`handle(Request|Response)\d*`))
+	assert.ErrorIs(t, CheckTermRegexp(`handle(`), util.ErrInvalidArgument)
+	assert.ErrorIs(t, CheckTermRegexp(`^handle`), util.ErrInvalidArgument)
+	assert.ErrorIs(t, CheckTermRegexp(`\bhandle\b`), util.ErrInvalidArgument)
+}
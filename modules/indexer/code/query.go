@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package code
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+// Query is a parsed code search query like `sym:NewIndexer lang:go path:modules/ repo:owner/name ref:release/* keyword`
+type Query struct {
+	Keyword  string // the remaining free text
+	Symbol   string
+	Language string
+	Path     string
+	Repos    []string // the "owner/name" or "name" of the repositories to search in
+	Refs     []string // the glob patterns of the branches and tags to search in, the default branch if empty
+}
+
+// IsEmpty checks if the query has neither a keyword nor a symbol or path to search for
+func (q *Query) IsEmpty() bool {
+	return q.Keyword == "" && q.Symbol == "" && q.Path == ""
+}
+
+// ParseQuery extracts the "sym:", "lang:", "path:", "repo:" and "ref:" qualifiers from the input.
+// Values may be quoted with double quotes, other terms are kept in the keyword.
+func ParseQuery(input string) *Query {
+	q := &Query{}
+	var words []string
+	for _, token := range tokenizeQuery(input) {
+		key, value, found := strings.Cut(token, ":")
+		value = strings.Trim(value, `"`)
+		if !found || value == "" {
+			words = append(words, token)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "sym", "symbol":
+			q.Symbol = value
+		case "lang", "language":
+			q.Language = value
+			if lang, ok := enry.GetLanguageByAlias(value); ok {
+				q.Language = lang
+			}
+		case "path":
+			q.Path = value
+		case "repo":
+			q.Repos = append(q.Repos, value)
+		case "ref":
+			q.Refs = append(q.Refs, value)
+		default:
+			words = append(words, token)
+		}
+	}
+	q.Keyword = strings.Join(words, " ")
+	return q
+}
+
+// tokenizeQuery splits the input at spaces which are not inside double quotes
+func tokenizeQuery(input string) []string {
+	var tokens []string
+	var sb strings.Builder
+	inQuotes := false
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			sb.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			if sb.Len() > 0 {
+				tokens = append(tokens, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() > 0 {
+		tokens = append(tokens, sb.String())
+	}
+	return tokens
+}
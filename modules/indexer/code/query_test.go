@@ -0,0 +1,46 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package code
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected *Query
+	}{
+		{
+			Input:    "",
+			Expected: &Query{},
+		},
+		{
+			Input:    "console.log",
+			Expected: &Query{Keyword: "console.log"},
+		},
+		{
+			Input: `sym:NewIndexer lang:golang path:"modules/indexer" repo:user2/repo1 repo:repo2 ref:release/* REF:v1.0 init ctx`,
+			Expected: &Query{
+				Keyword:  "init ctx",
+				Symbol:   "NewIndexer",
+				Language: "Go",
+				Path:     "modules/indexer",
+				Repos:    []string{"user2/repo1", "repo2"},
+				Refs:     []string{"release/*", "v1.0"},
+			},
+		},
+		{
+			Input:    `Lang:UnknownLanguage http://example.com sym: "a b"`,
+			Expected: &Query{Keyword: `http://example.com sym: "a b"`, Language: "UnknownLanguage"},
+		},
+	}
+	for _, c := range cases {
+		q := ParseQuery(c.Input)
+		assert.Equal(t, c.Expected, q, "input %q", c.Input)
+		assert.Equal(t, c.Input == "", q.IsEmpty(), "input %q", c.Input)
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"bytes"
 	"context"
 	"html/template"
+	"regexp"
 	"strings"
 
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/highlight"
+	"code.gitea.io/gitea/modules/indexer"
 	"code.gitea.io/gitea/modules/indexer/code/internal"
 	"code.gitea.io/gitea/modules/timeutil"
 )
@@ -17,6 +20,7 @@ import (
 // Result a search result to display
 type Result struct {
 	RepoID      int64
+	Ref         git.RefName // empty for the default branch
 	Filename    string
 	CommitID    string
 	UpdatedUnix timeutil.TimeStamp
@@ -70,11 +74,110 @@ func writeStrings(buf *bytes.Buffer, strs ...string) error {
 	return nil
 }
 
-func HighlightSearchResultCode(filename, language string, lineNums []int, code string) []*ResultLine {
+// SearchMatchRegexp returns a regexp finding the keyword and the symbol of a search in the results to mark them,
+// it is nil if there is nothing to mark or the regexp isn't supported by Go
+func SearchMatchRegexp(keyword, symbol string, searchMode indexer.SearchModeType, caseSensitive bool) *regexp.Regexp {
+	var patterns []string
+	switch {
+	case keyword == "":
+		// only the symbol or the path is searched
+	case searchMode == indexer.SearchModeRegexp:
+		// "^" and "$" match at the line boundaries like in the indexers supporting them
+		patterns = append(patterns, "(?m:"+keyword+")")
+	case searchMode == indexer.SearchModeExact:
+		patterns = append(patterns, regexp.QuoteMeta(keyword))
+	default: /* words */
+		for _, word := range strings.Fields(keyword) {
+			patterns = append(patterns, regexp.QuoteMeta(word))
+		}
+	}
+	if symbol != "" {
+		patterns = append(patterns, `\b`+regexp.QuoteMeta(symbol)+`\b`)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	flags := "(?i)"
+	if caseSensitive {
+		flags = ""
+	}
+	re, err := regexp.Compile(flags + strings.Join(patterns, "|"))
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// SearchMatchRanges returns the non-empty ranges of the code matched by the regexp
+func SearchMatchRanges(re *regexp.Regexp, code string) (ranges [][2]int) {
+	if re == nil {
+		return nil
+	}
+	for _, loc := range re.FindAllStringIndex(code, -1) {
+		if loc[0] < loc[1] {
+			ranges = append(ranges, [2]int{loc[0], loc[1]})
+		}
+	}
+	return ranges
+}
+
+// markMatchRanges wraps the text of the highlighted code which is in the sorted ranges of the original code in <mark> tags.
+// A mark is closed before any other tag or line break to keep the lines well-formed.
+func markMatchRanges(hl string, ranges [][2]int) string {
+	if len(ranges) == 0 {
+		return hl
+	}
+	var sb strings.Builder
+	pos, idx, marked := 0, 0, false // the position in the original code, the index of the current range
+	closeMark := func() {
+		if marked {
+			sb.WriteString("</mark>")
+			marked = false
+		}
+	}
+	for i := 0; i < len(hl); {
+		if hl[i] == '<' {
+			end := strings.IndexByte(hl[i:], '>') + 1
+			if end == 0 {
+				end = len(hl) - i
+			}
+			closeMark()
+			sb.WriteString(hl[i : i+end])
+			i += end
+			continue
+		}
+		n := 1
+		if hl[i] == '&' {
+			// an escaped character of the original code
+			if end := strings.IndexByte(hl[i:], ';'); end > 0 {
+				n = end + 1
+			}
+		}
+		for idx < len(ranges) && ranges[idx][1] <= pos {
+			idx++
+		}
+		if hl[i] != '\n' && idx < len(ranges) && ranges[idx][0] <= pos {
+			if !marked {
+				sb.WriteString("<mark>")
+				marked = true
+			}
+		} else {
+			closeMark()
+		}
+		sb.WriteString(hl[i : i+n])
+		i += n
+		pos++
+	}
+	closeMark()
+	return sb.String()
+}
+
+// HighlightSearchResultCode highlights the code of the lines, the matchRanges of the code are marked
+func HighlightSearchResultCode(filename, language string, lineNums []int, code string, matchRanges [][2]int) []*ResultLine {
 	// we should highlight the whole code block first, otherwise it doesn't work well with multiple line highlighting
 	lexer := highlight.DetectChromaLexerByFileName(filename, language)
-	hl := highlight.RenderCodeByLexer(lexer, code)
-	highlightedLines := strings.Split(string(hl), "\n")
+	hl := markMatchRanges(string(highlight.RenderCodeByLexer(lexer, code)), matchRanges)
+	highlightedLines := strings.Split(hl, "\n")
 
 	// The lineNums outputted by render might not match the original lineNums, because "highlight" removes the last `\n`
 	lines := make([]*ResultLine, min(len(highlightedLines), len(lineNums)))
@@ -87,7 +190,7 @@ func HighlightSearchResultCode(filename, language string, lineNums []int, code s
 	return lines
 }
 
-func searchResult(result *internal.SearchResult, startIndex, endIndex int) (*Result, error) {
+func searchResult(result *internal.SearchResult, startIndex, endIndex int, matchRegexp *regexp.Regexp) (*Result, error) {
 	startLineNum := 1 + strings.Count(result.Content[:startIndex], "\n")
 
 	var formattedLinesBuffer bytes.Buffer
@@ -118,20 +221,22 @@ func searchResult(result *internal.SearchResult, startIndex, endIndex int) (*Res
 		index += len(line)
 	}
 
+	code := formattedLinesBuffer.String()
 	return &Result{
 		RepoID:      result.RepoID,
+		Ref:         git.RefName(result.Ref),
 		Filename:    result.Filename,
 		CommitID:    result.CommitID,
 		UpdatedUnix: result.UpdatedUnix,
 		Language:    result.Language,
 		Color:       result.Color,
-		Lines:       HighlightSearchResultCode(result.Filename, result.Language, lineNums, formattedLinesBuffer.String()),
+		Lines:       HighlightSearchResultCode(result.Filename, result.Language, lineNums, code, SearchMatchRanges(matchRegexp, code)),
 	}, nil
 }
 
 // PerformSearch perform a search on a repository
 func PerformSearch(ctx context.Context, opts *SearchOptions) (int64, []*Result, []*SearchResultLanguages, error) {
-	if opts == nil || len(opts.Keyword) == 0 {
+	if opts == nil || (opts.Keyword == "" && opts.Symbol == "" && opts.Path == "") {
 		return 0, nil, nil, nil
 	}
 
@@ -141,10 +246,11 @@ func PerformSearch(ctx context.Context, opts *SearchOptions) (int64, []*Result,
 	}
 
 	displayResults := make([]*Result, len(results))
+	matchRegexp := SearchMatchRegexp(opts.Keyword, opts.Symbol, opts.SearchMode, false)
 
 	for i, result := range results {
 		startIndex, endIndex := indices(result.Content, result.StartIndex, result.EndIndex)
-		displayResults[i], err = searchResult(result, startIndex, endIndex)
+		displayResults[i], err = searchResult(result, startIndex, endIndex, matchRegexp)
 		if err != nil {
 			return 0, nil, nil, err
 		}
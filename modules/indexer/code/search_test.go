@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package code
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/indexer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchMatchRanges(t *testing.T) {
+	code := "func Serve() {\n\tserve(a < b)\n}"
+	cases := []struct {
+		Keyword       string
+		Symbol        string
+		Mode          indexer.SearchModeType
+		CaseSensitive bool
+		Ranges        [][2]int
+	}{
+		{Keyword: "SERVE a", Mode: indexer.SearchModeWords, Ranges: [][2]int{{5, 10}, {16, 21}, {22, 23}}},
+		{Keyword: "serve(a", Mode: indexer.SearchModeExact, Ranges: [][2]int{{16, 23}}},
+		{Keyword: `Serve\(\)`, Mode: indexer.SearchModeRegexp, CaseSensitive: true, Ranges: [][2]int{{5, 12}}},
+		{Keyword: "a*", Mode: indexer.SearchModeRegexp, Ranges: [][2]int{{22, 23}}},
+		{Keyword: "(?<=x)", Mode: indexer.SearchModeRegexp},
+		{Symbol: "serve", Ranges: [][2]int{{5, 10}, {16, 21}}},
+		{},
+	}
+	for _, c := range cases {
+		re := SearchMatchRegexp(c.Keyword, c.Symbol, c.Mode, c.CaseSensitive)
+		assert.Equal(t, c.Ranges, SearchMatchRanges(re, code), "keyword %q, symbol %q", c.Keyword, c.Symbol)
+	}
+}
+
+func TestMarkMatchRanges(t *testing.T) {
+	hl := `<span class="kd">func</span> <span class="nx">Serve</span><span class="p">()</span> <span class="p">{</span>` + "\n" +
+		`	<span class="nx">a</span> <span class="o">&lt;</span> <span class="nx">b</span>`
+	assert.Equal(t, hl, markMatchRanges(hl, nil))
+	assert.Equal(t,
+		`<span class="kd">func</span> <span class="nx"><mark>Serve</mark></span><span class="p"><mark>()</mark></span> <span class="p">{</span>`+"\n"+
+			`	<span class="nx">a</span> <span class="o"><mark>&lt;</mark></span><mark> </mark><span class="nx"><mark>b</mark></span>`,
+		markMatchRanges(hl, [][2]int{{5, 12}, {18, 23}}))
+	assert.Equal(t,
+		`<span class="kd">func</span> <span class="nx">Serve</span><span class="p">()</span><mark> </mark><span class="p"><mark>{</mark></span>`+"\n"+
+			`<mark>	</mark><span class="nx"><mark>a</mark></span> <span class="o">&lt;</span> <span class="nx">b</span>`,
+		markMatchRanges(hl, [][2]int{{12, 17}}))
+}
+
+func TestHighlightSearchResultCode(t *testing.T) {
+	lines := HighlightSearchResultCode("a.txt", "", []int{3, 4}, "a <b>\nc", [][2]int{{2, 5}})
+	assert.Len(t, lines, 2)
+	assert.Equal(t, 3, lines[0].Num)
+	assert.EqualValues(t, "a <mark>&lt;b&gt;</mark>", lines[0].FormattedContent)
+	assert.EqualValues(t, "c", lines[1].FormattedContent)
+}
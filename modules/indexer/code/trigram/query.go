@@ -0,0 +1,405 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	maxFuzziness = 2
+	// maxMatches limits the number of the matches found in a file, they are only used to rank the files
+	maxMatches = 100
+)
+
+// trigram consists of three lowercase runes, each of them takes 21 bits
+type trigram uint64
+
+func newTrigram(r1, r2, r3 rune) trigram {
+	return trigram(r1)<<42 | trigram(r2)<<21 | trigram(r3)
+}
+
+// forEachTrigram calls the function with the trigrams of the lowercase string in their order
+func forEachTrigram(s string, fn func(tri trigram)) {
+	var r1, r2 rune
+	n := 0
+	for _, r := range s {
+		r = unicode.ToLower(r)
+		if n >= 2 {
+			fn(newTrigram(r1, r2, r))
+		}
+		r1, r2 = r2, r
+		n++
+	}
+}
+
+// contentTrigrams returns the distinct trigrams of the content
+func contentTrigrams(content string) container.Set[trigram] {
+	trigrams := make(container.Set[trigram])
+	forEachTrigram(content, func(tri trigram) {
+		trigrams.Add(tri)
+	})
+	return trigrams
+}
+
+// literalTrigrams returns the distinct trigrams of the literal in their order
+func literalTrigrams(literal string) []trigram {
+	var trigrams []trigram
+	forEachTrigram(literal, func(tri trigram) {
+		if !slices.Contains(trigrams, tri) {
+			trigrams = append(trigrams, tri)
+		}
+	})
+	return trigrams
+}
+
+type queryOp int
+
+const (
+	queryAll      queryOp = iota // all the documents
+	queryTrigrams                // the documents containing at least minCount of the trigrams
+	queryAnd                     // the documents matching all the sub queries
+	queryOr                      // the documents matching any of the sub queries
+)
+
+// query finds the candidate documents of a search by their trigrams, the candidates have to be verified by a matcher
+type query struct {
+	op       queryOp
+	trigrams []trigram
+	minCount int
+	subs     []*query
+}
+
+var matchAllQuery = &query{op: queryAll}
+
+// trigramQuery matches the documents which may contain the literal
+func trigramQuery(literal string) *query {
+	trigrams := literalTrigrams(literal)
+	if len(trigrams) == 0 {
+		return matchAllQuery
+	}
+	return &query{op: queryTrigrams, trigrams: trigrams, minCount: len(trigrams)}
+}
+
+// fuzzyTrigramQuery matches the documents which may contain the word with up to fuzziness edits,
+// every edit changes at most three of its trigrams
+func fuzzyTrigramQuery(word string, fuzziness int) *query {
+	q := trigramQuery(word)
+	if q.op == queryTrigrams {
+		q.minCount -= 3 * fuzziness
+		if q.minCount <= 0 {
+			return matchAllQuery
+		}
+	}
+	return q
+}
+
+func andQuery(subs ...*query) *query {
+	filtered := make([]*query, 0, len(subs))
+	for _, sub := range subs {
+		if sub.op != queryAll {
+			filtered = append(filtered, sub)
+		}
+	}
+	switch len(filtered) {
+	case 0:
+		return matchAllQuery
+	case 1:
+		return filtered[0]
+	}
+	return &query{op: queryAnd, subs: filtered}
+}
+
+func orQuery(subs ...*query) *query {
+	if len(subs) == 0 || slices.ContainsFunc(subs, func(sub *query) bool { return sub.op == queryAll }) {
+		return matchAllQuery
+	} else if len(subs) == 1 {
+		return subs[0]
+	}
+	return &query{op: queryOr, subs: subs}
+}
+
+// regexpQuery returns the query of the literals which every match of the regexp contains,
+// the parts of the regexp which may match anything or nothing don't restrict the candidates
+func regexpQuery(re *syntax.Regexp) *query {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return trigramQuery(string(re.Rune))
+	case syntax.OpCapture, syntax.OpPlus:
+		return regexpQuery(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return regexpQuery(re.Sub[0])
+		}
+	case syntax.OpConcat, syntax.OpAlternate:
+		subs := make([]*query, 0, len(re.Sub))
+		for _, sub := range re.Sub {
+			subs = append(subs, regexpQuery(sub))
+		}
+		if re.Op == syntax.OpConcat {
+			return andQuery(subs...)
+		}
+		return orQuery(subs...)
+	}
+	return matchAllQuery
+}
+
+// candidates returns the sorted IDs of the documents matching the query, all is true if every document matches
+func (s *shard) candidates(q *query) (ids []uint32, all bool) {
+	switch q.op {
+	case queryTrigrams:
+		return s.trigramCandidates(q.trigrams, q.minCount), false
+	case queryAnd:
+		all = true
+		for _, sub := range q.subs {
+			subIDs, subAll := s.candidates(sub)
+			if subAll {
+				continue
+			}
+			if all {
+				ids, all = subIDs, false
+			} else {
+				ids = intersectIDs(ids, subIDs)
+			}
+			if len(ids) == 0 {
+				return nil, false
+			}
+		}
+		return ids, all
+	case queryOr:
+		for _, sub := range q.subs {
+			subIDs, subAll := s.candidates(sub)
+			if subAll {
+				return nil, true
+			}
+			ids = unionIDs(ids, subIDs)
+		}
+		return ids, false
+	}
+	return nil, true
+}
+
+func (s *shard) trigramCandidates(trigrams []trigram, minCount int) []uint32 {
+	if minCount >= len(trigrams) {
+		lists := make([][]uint32, 0, len(trigrams))
+		for _, tri := range trigrams {
+			ids := s.postings[tri]
+			if len(ids) == 0 {
+				return nil
+			}
+			lists = append(lists, ids)
+		}
+		// start with the shortest posting list to keep the intersections small
+		slices.SortFunc(lists, func(a, b []uint32) int { return len(a) - len(b) })
+		ids := lists[0]
+		for _, list := range lists[1:] {
+			if ids = intersectIDs(ids, list); len(ids) == 0 {
+				break
+			}
+		}
+		return ids
+	}
+
+	counts := make(map[uint32]int)
+	for _, tri := range trigrams {
+		for _, id := range s.postings[tri] {
+			counts[id]++
+		}
+	}
+	ids := make([]uint32, 0, len(counts))
+	for id, count := range counts {
+		if count >= minCount {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func intersectIDs(a, b []uint32) []uint32 {
+	ids := make([]uint32, 0, min(len(a), len(b)))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			ids = append(ids, a[i])
+			i++
+			j++
+		}
+	}
+	return ids
+}
+
+func unionIDs(a, b []uint32) []uint32 {
+	ids := make([]uint32, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			ids = append(ids, a[i])
+			i++
+		case a[i] > b[j]:
+			ids = append(ids, b[j])
+			j++
+		default:
+			ids = append(ids, a[i])
+			i++
+			j++
+		}
+	}
+	ids = append(ids, a[i:]...)
+	return append(ids, b[j:]...)
+}
+
+// matcher verifies the candidate documents of a keyword and finds the ranges of the matches in their contents
+type matcher struct {
+	query    *query
+	terms    []*term        // all the terms have to match the content
+	phrase   *regexp.Regexp // the whole keyword of a search for several words, the documents containing it rank higher
+	filename func(filename string) bool
+}
+
+// term is a regexp to find in the content, a fuzzy term also matches the words which are similar to the word
+type term struct {
+	re        *regexp.Regexp
+	word      string
+	fuzziness int
+}
+
+func literalRegexp(literal string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(literal))
+}
+
+// newMatcher returns the matcher of the keyword for the search mode, the searches are case-insensitive.
+// The exact mode finds the keyword, the words mode finds all the words of the keyword in any order,
+// the fuzzy mode finds all of them with some typos and the regexp mode finds matches of the regexp, where "^" and "$" match at line boundaries.
+func newMatcher(keyword string, searchMode indexer.SearchModeType) (*matcher, error) {
+	lowerKeyword := strings.ToLower(keyword)
+	containsKeyword := func(filename string) bool {
+		return strings.Contains(strings.ToLower(filename), lowerKeyword)
+	}
+
+	switch searchMode {
+	case indexer.SearchModeRegexp:
+		parsed, err := syntax.Parse(keyword, syntax.Perl)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid regular expression: %v", err)
+		}
+		re, err := regexp.Compile("(?im)" + keyword)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid regular expression: %v", err)
+		}
+		return &matcher{query: regexpQuery(parsed), terms: []*term{{re: re}}, filename: re.MatchString}, nil
+	case indexer.SearchModeExact:
+		return &matcher{query: trigramQuery(keyword), terms: []*term{{re: literalRegexp(keyword)}}, filename: containsKeyword}, nil
+	}
+
+	words := strings.Fields(keyword)
+	m := &matcher{terms: make([]*term, 0, len(words)), filename: containsKeyword}
+	queries := make([]*query, 0, len(words))
+	for _, word := range words {
+		t := &term{re: literalRegexp(word)}
+		if searchMode == indexer.SearchModeFuzzy {
+			t.word, t.fuzziness = strings.ToLower(word), guessFuzziness(word)
+			queries = append(queries, fuzzyTrigramQuery(word, t.fuzziness))
+		} else {
+			queries = append(queries, trigramQuery(word))
+		}
+		m.terms = append(m.terms, t)
+	}
+	if len(words) > 1 {
+		m.phrase = literalRegexp(keyword)
+	}
+	m.query = andQuery(queries...)
+	return m, nil
+}
+
+// match returns the sorted ranges of the matches of all the terms, it is false if any term doesn't match
+func (m *matcher) match(content string) ([][2]int, bool) {
+	var ranges [][2]int
+	for _, t := range m.terms {
+		termRanges := t.match(content)
+		if len(termRanges) == 0 {
+			return nil, false
+		}
+		ranges = append(ranges, termRanges...)
+	}
+	slices.SortFunc(ranges, func(a, b [2]int) int { return a[0] - b[0] })
+	return ranges, true
+}
+
+func (t *term) match(content string) (ranges [][2]int) {
+	for _, loc := range t.re.FindAllStringIndex(content, maxMatches) {
+		if loc[0] < loc[1] {
+			ranges = append(ranges, [2]int{loc[0], loc[1]})
+		}
+	}
+	if len(ranges) == 0 && t.fuzziness > 0 {
+		ranges = fuzzyMatchRanges(content, t.word, t.fuzziness)
+	}
+	return ranges
+}
+
+var wordRegexp = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+
+// fuzzyMatchRanges returns the ranges of the words of the content which differ from the lowercase word by up to fuzziness edits
+func fuzzyMatchRanges(content, word string, fuzziness int) (ranges [][2]int) {
+	wordLen := utf8.RuneCountInString(word)
+	for _, loc := range wordRegexp.FindAllStringIndex(content, -1) {
+		token := content[loc[0]:loc[1]]
+		if diff := utf8.RuneCountInString(token) - wordLen; diff > fuzziness || diff < -fuzziness {
+			continue
+		}
+		if levenshteinDistance(strings.ToLower(token), word) <= fuzziness {
+			ranges = append(ranges, [2]int{loc[0], loc[1]})
+			if len(ranges) == maxMatches {
+				break
+			}
+		}
+	}
+	return ranges
+}
+
+// guessFuzziness allows an edit for every four letters of the word, words which contain other characters have to match exactly
+func guessFuzziness(word string) int {
+	for _, r := range word {
+		if r >= utf8.RuneSelf || !unicode.IsLetter(r) {
+			return 0
+		}
+	}
+	return min(maxFuzziness, len(word)/4)
+}
+
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range ra {
+		curr[0] = i + 1
+		for j := range rb {
+			cost := 1
+			if ra[i] == rb[j] {
+				cost = 0
+			}
+			curr[j+1] = min(prev[j+1]+1, curr[j]+1, prev[j]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"regexp/syntax"
+	"slices"
+	"testing"
+
+	"code.gitea.io/gitea/modules/indexer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexpQuery(t *testing.T) {
+	literals := func(q *query) any {
+		var convert func(q *query) any
+		convert = func(q *query) any {
+			switch q.op {
+			case queryTrigrams:
+				return len(q.trigrams)
+			case queryAnd, queryOr:
+				subs := make([]any, 0, len(q.subs))
+				for _, sub := range q.subs {
+					subs = append(subs, convert(sub))
+				}
+				return map[queryOp][]any{q.op: subs}
+			}
+			return nil
+		}
+		return convert(q)
+	}
+
+	// the parser factors out the common prefix "Re" of the alternatives, which is too short for a trigram
+	cases := map[string]any{
+		"handle":                   4,
+		"Handle(Request|Response)": map[queryOp][]any{queryAnd: {4, map[queryOp][]any{queryOr: {3, 4}}}},
+		`func\s+\w+`:               2,
+		"(ab)+c":                   nil,
+		"a.*b":                     nil,
+		"handle(Request)?":         4,
+		"(handle|.)":               nil,
+		"(?i)kelvin{2,}":           3,
+	}
+	for pattern, expected := range cases {
+		re, err := syntax.Parse(pattern, syntax.Perl)
+		require.NoError(t, err)
+		assert.Equal(t, expected, literals(regexpQuery(re)), pattern)
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	s := &shard{postings: make(map[trigram][]uint32)}
+	contents := []string{
+		"func handleRequest(w http.ResponseWriter) {}",
+		"func handleResponse() {}",
+		"// the HANDLER of the requests",
+	}
+	for id, content := range contents {
+		for tri := range contentTrigrams(content) {
+			s.postings[tri] = append(s.postings[tri], uint32(id))
+		}
+	}
+
+	cases := []struct {
+		Keyword string
+		Mode    indexer.SearchModeType
+		Matches []string // the first match in each content
+	}{
+		{"handlerequest", indexer.SearchModeExact, []string{"handleRequest", "", ""}},
+		{"handle request", indexer.SearchModeWords, []string{"handle", "", "HANDLE"}},
+		{"handl requst", indexer.SearchModeWords, []string{"", "", ""}},
+		{"handlerequst", indexer.SearchModeFuzzy, []string{"handleRequest", "", ""}},
+		{"handlr", indexer.SearchModeFuzzy, []string{"", "", "HANDLER"}},
+		{"handle(request|response)", indexer.SearchModeRegexp, []string{"handleRequest", "handleResponse", ""}},
+		{"^func", indexer.SearchModeRegexp, []string{"func", "func", ""}},
+	}
+	for _, c := range cases {
+		m, err := newMatcher(c.Keyword, c.Mode)
+		require.NoError(t, err)
+		ids, all := s.candidates(m.query)
+		for id, content := range contents {
+			ranges, ok := m.match(content)
+			if c.Matches[id] == "" {
+				assert.False(t, ok, "%s %q in %q", c.Mode, c.Keyword, content)
+			} else if assert.True(t, ok, "%s %q in %q", c.Mode, c.Keyword, content) {
+				assert.True(t, all || slices.Contains(ids, uint32(id)), "%s %q is not a candidate in %q", c.Mode, c.Keyword, content)
+				assert.Equal(t, c.Matches[id], content[ranges[0][0]:ranges[0][1]], "%s %q in %q", c.Mode, c.Keyword, content)
+			}
+		}
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 0, levenshteinDistance("kitten", "kitten"))
+	assert.Equal(t, 3, levenshteinDistance("kitten", "sitting"))
+	assert.Equal(t, 2, levenshteinDistance("größe", "grösse"))
+	assert.Equal(t, 4, levenshteinDistance("", "abcd"))
+}
@@ -0,0 +1,521 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// shardMagic is written at the beginning of the shard files to detect foreign or truncated files
+const shardMagic = "GTRIGRAM"
+
+// document is a file of a ref of the repository, its content is stored in the data file of the shard
+type document struct {
+	Ref         string
+	Filename    string
+	CommitID    string
+	Language    string
+	Symbols     []string // the lowercase names of the defined symbols
+	UpdatedUnix int64
+	Offset      int64
+	Size        int64
+	Deleted     bool
+}
+
+// shard is the index of all the indexed refs of a repository. It is stored in two files:
+// "<repoID>.shard" keeps the documents and the trigram posting lists and is rewritten on every update,
+// "<repoID>-<generation>.data" keeps the contents, new contents are appended and it is only rewritten
+// into the next generation when more than half of the documents have been deleted.
+type shard struct {
+	mu sync.RWMutex
+
+	dir        string
+	repoID     int64
+	generation uint64
+	dataSize   int64
+	data       *os.File
+
+	docs     []*document          // the documents by their IDs, including the deleted ones until the next compaction
+	postings map[trigram][]uint32 // the sorted IDs of the documents containing the trigram
+	byKey    map[string]uint32    // the IDs of the documents which are not deleted by their ref and filename
+	deleted  int
+
+	// unloaded is set when the indexer has released the shard, its documents are kept
+	// for the matches of running searches, which read their contents from the data file
+	unloaded bool
+}
+
+func shardFilename(dir string, repoID int64) string {
+	return filepath.Join(dir, strconv.FormatInt(repoID, 10)+".shard")
+}
+
+func dataFilename(dir string, repoID int64, generation uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%d-%d.data", repoID, generation))
+}
+
+// parseShardFilename returns the repository ID of a shard file name
+func parseShardFilename(name string) (int64, bool) {
+	idStr, ok := strings.CutSuffix(name, ".shard")
+	if !ok {
+		return 0, false
+	}
+	repoID, err := strconv.ParseInt(idStr, 10, 64)
+	return repoID, err == nil
+}
+
+func docKey(ref, filename string) string {
+	return ref + "\x00" + filename
+}
+
+// openShard reads the shard of the repository, the shard is empty if it doesn't exist yet
+func openShard(dir string, repoID int64) (*shard, error) {
+	s := &shard{
+		dir:        dir,
+		repoID:     repoID,
+		generation: 1,
+		postings:   make(map[trigram][]uint32),
+		byKey:      make(map[string]uint32),
+	}
+	f, err := os.Open(shardFilename(dir, repoID))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := s.readMeta(bufio.NewReader(f)); err != nil {
+		return nil, fmt.Errorf("unable to read the shard of repository %d: %w", repoID, err)
+	}
+	if s.data, err = os.OpenFile(dataFilename(dir, repoID, s.generation), os.O_RDWR|os.O_CREATE, 0o644); err != nil {
+		return nil, err
+	}
+	s.removeObsoleteData()
+	return s, nil
+}
+
+// removeObsoleteData removes the data files of the other generations, which are left if a compaction was interrupted
+func (s *shard) removeObsoleteData() {
+	matches, err := filepath.Glob(filepath.Join(s.dir, fmt.Sprintf("%d-*.data", s.repoID)))
+	if err != nil {
+		return
+	}
+	current := dataFilename(s.dir, s.repoID, s.generation)
+	for _, match := range matches {
+		if match != current {
+			if err := util.Remove(match); err != nil {
+				log.Warn("Unable to remove obsolete code indexer data file %s: %v", match, err)
+			}
+		}
+	}
+}
+
+func (s *shard) close() {
+	if s.data != nil {
+		if err := s.data.Close(); err != nil {
+			log.Error("Unable to close the code indexer data file of repository %d: %v", s.repoID, err)
+		}
+		s.data = nil
+	}
+}
+
+// unload closes the shard once it has been removed from the indexer and releases the posting lists
+func (s *shard) unload() {
+	s.close()
+	s.postings = nil
+	s.unloaded = true
+}
+
+// destroy closes the shard and removes its files
+func (s *shard) destroy() error {
+	s.close()
+	if err := util.Remove(shardFilename(s.dir, s.repoID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := util.Remove(dataFilename(s.dir, s.repoID, s.generation)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	s.docs, s.deleted, s.dataSize = nil, 0, 0
+	s.postings = make(map[trigram][]uint32)
+	s.byKey = make(map[string]uint32)
+	return nil
+}
+
+// isEmpty checks if there are no documents which are not deleted
+func (s *shard) isEmpty() bool {
+	return len(s.docs) == s.deleted
+}
+
+func (s *shard) readContent(doc *document) (string, error) {
+	if doc.Size == 0 {
+		return "", nil
+	}
+	data := s.data
+	if data == nil {
+		// the shard has been unloaded, the data file is only opened to read the content
+		f, err := os.Open(dataFilename(s.dir, s.repoID, s.generation))
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		data = f
+	}
+	buf := make([]byte, doc.Size)
+	if _, err := data.ReadAt(buf, doc.Offset); err != nil {
+		return "", err
+	}
+	return util.UnsafeBytesToString(buf), nil
+}
+
+// add adds the document or replaces the document of the same ref and filename
+func (s *shard) add(doc *document, content string) error {
+	s.remove(doc.Ref, doc.Filename)
+	if s.data == nil {
+		var err error
+		if s.data, err = os.OpenFile(dataFilename(s.dir, s.repoID, s.generation), os.O_RDWR|os.O_CREATE, 0o644); err != nil {
+			return err
+		}
+	}
+	if _, err := s.data.WriteAt([]byte(content), s.dataSize); err != nil {
+		return err
+	}
+	doc.Offset, doc.Size = s.dataSize, int64(len(content))
+	s.dataSize += doc.Size
+
+	id := uint32(len(s.docs))
+	s.docs = append(s.docs, doc)
+	s.byKey[docKey(doc.Ref, doc.Filename)] = id
+	for tri := range contentTrigrams(content) {
+		s.postings[tri] = append(s.postings[tri], id)
+	}
+	return nil
+}
+
+// remove marks the document of the ref and filename as deleted
+func (s *shard) remove(ref, filename string) {
+	key := docKey(ref, filename)
+	if id, ok := s.byKey[key]; ok {
+		s.docs[id].Deleted = true
+		s.deleted++
+		delete(s.byKey, key)
+	}
+}
+
+// removeRef marks the documents of the ref as deleted
+func (s *shard) removeRef(ref string) {
+	for _, doc := range s.docs {
+		if !doc.Deleted && doc.Ref == ref {
+			s.remove(doc.Ref, doc.Filename)
+		}
+	}
+}
+
+// save writes the shard to the disk, it is compacted first if more than half of its documents are deleted
+func (s *shard) save() error {
+	if s.isEmpty() {
+		return s.destroy()
+	}
+
+	obsolete := ""
+	if s.deleted*2 > len(s.docs) {
+		var err error
+		if obsolete, err = s.compact(); err != nil {
+			return err
+		}
+	}
+	if err := s.data.Sync(); err != nil {
+		return err
+	}
+
+	tmpFilename := shardFilename(s.dir, s.repoID) + ".tmp"
+	f, err := os.Create(tmpFilename)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	err = s.writeMeta(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = util.Rename(tmpFilename, shardFilename(s.dir, s.repoID))
+	}
+	if err != nil {
+		_ = util.Remove(tmpFilename)
+		return err
+	}
+
+	if obsolete != "" {
+		if err := util.Remove(obsolete); err != nil {
+			log.Warn("Unable to remove obsolete code indexer data file %s: %v", obsolete, err)
+		}
+	}
+	return nil
+}
+
+// compact copies the contents of the documents which are not deleted to the data file of the next generation
+// and renumbers the documents, it returns the name of the previous data file to remove once the shard is saved
+func (s *shard) compact() (string, error) {
+	generation := s.generation + 1
+	data, err := os.OpenFile(dataFilename(s.dir, s.repoID, generation), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return "", err
+	}
+
+	newIDs := make([]uint32, len(s.docs)) // the new IDs plus one, zero for the deleted documents
+	docs := make([]*document, 0, len(s.docs)-s.deleted)
+	var dataSize int64
+	for id, doc := range s.docs {
+		if doc.Deleted {
+			continue
+		}
+		content, err := s.readContent(doc)
+		if err == nil {
+			_, err = data.WriteAt([]byte(content), dataSize)
+		}
+		if err != nil {
+			_ = data.Close()
+			_ = util.Remove(data.Name())
+			return "", err
+		}
+		doc.Offset = dataSize
+		dataSize += doc.Size
+		docs = append(docs, doc)
+		newIDs[id] = uint32(len(docs))
+	}
+
+	postings := make(map[trigram][]uint32, len(s.postings))
+	for tri, ids := range s.postings {
+		var remaining []uint32
+		for _, id := range ids {
+			if newIDs[id] > 0 {
+				remaining = append(remaining, newIDs[id]-1)
+			}
+		}
+		if len(remaining) > 0 {
+			postings[tri] = remaining
+		}
+	}
+
+	obsolete := dataFilename(s.dir, s.repoID, s.generation)
+	s.close()
+	s.data, s.generation, s.dataSize = data, generation, dataSize
+	s.docs, s.postings, s.deleted = docs, postings, 0
+	s.byKey = make(map[string]uint32, len(docs))
+	for id, doc := range docs {
+		s.byKey[docKey(doc.Ref, doc.Filename)] = uint32(id)
+	}
+	return obsolete, nil
+}
+
+// The shard file starts with the magic string and has the following fields encoded as varints,
+// strings are prefixed by their length and the IDs of the posting lists are delta-encoded:
+// generation, data size, number of documents, documents, number of trigrams, trigrams with their posting lists
+func (s *shard) writeMeta(bw *bufio.Writer) error {
+	w := &metaWriter{w: bw}
+	w.bytes([]byte(shardMagic))
+	w.uvarint(s.generation)
+	w.varint(s.dataSize)
+
+	w.uvarint(uint64(len(s.docs)))
+	for _, doc := range s.docs {
+		w.string(doc.Ref)
+		w.string(doc.Filename)
+		w.string(doc.CommitID)
+		w.string(doc.Language)
+		w.uvarint(uint64(len(doc.Symbols)))
+		for _, symbol := range doc.Symbols {
+			w.string(symbol)
+		}
+		w.varint(doc.UpdatedUnix)
+		w.varint(doc.Offset)
+		w.varint(doc.Size)
+		w.bool(doc.Deleted)
+	}
+
+	trigrams := make([]trigram, 0, len(s.postings))
+	for tri := range s.postings {
+		trigrams = append(trigrams, tri)
+	}
+	slices.Sort(trigrams)
+	w.uvarint(uint64(len(trigrams)))
+	for _, tri := range trigrams {
+		ids := s.postings[tri]
+		w.uvarint(uint64(tri))
+		w.uvarint(uint64(len(ids)))
+		prev := uint32(0)
+		for _, id := range ids {
+			w.uvarint(uint64(id - prev))
+			prev = id
+		}
+	}
+	return w.err
+}
+
+func (s *shard) readMeta(br *bufio.Reader) error {
+	r := &metaReader{r: br}
+	if magic := r.bytes(len(shardMagic)); r.err == nil && string(magic) != shardMagic {
+		return errors.New("invalid shard file")
+	}
+	s.generation = r.uvarint()
+	s.dataSize = r.varint()
+
+	numDocs := r.count()
+	s.docs = make([]*document, 0, numDocs)
+	for i := 0; i < numDocs && r.err == nil; i++ {
+		doc := &document{
+			Ref:      r.string(),
+			Filename: r.string(),
+			CommitID: r.string(),
+			Language: r.string(),
+		}
+		numSymbols := r.count()
+		for j := 0; j < numSymbols && r.err == nil; j++ {
+			doc.Symbols = append(doc.Symbols, r.string())
+		}
+		doc.UpdatedUnix = r.varint()
+		doc.Offset = r.varint()
+		doc.Size = r.varint()
+		doc.Deleted = r.bool()
+
+		if doc.Deleted {
+			s.deleted++
+		} else {
+			s.byKey[docKey(doc.Ref, doc.Filename)] = uint32(len(s.docs))
+		}
+		s.docs = append(s.docs, doc)
+	}
+
+	numTrigrams := r.count()
+	for i := 0; i < numTrigrams && r.err == nil; i++ {
+		tri := trigram(r.uvarint())
+		ids := make([]uint32, r.count())
+		prev := uint32(0)
+		for j := range ids {
+			prev += uint32(r.uvarint())
+			ids[j] = prev
+		}
+		s.postings[tri] = ids
+	}
+	if r.err == nil && len(s.docs) > 0 && int(maxPostingID(s.postings)) >= len(s.docs) {
+		return errors.New("invalid posting list")
+	}
+	return r.err
+}
+
+func maxPostingID(postings map[trigram][]uint32) (maxID uint32) {
+	for _, ids := range postings {
+		if len(ids) > 0 {
+			maxID = max(maxID, ids[len(ids)-1])
+		}
+	}
+	return maxID
+}
+
+// metaWriter writes the fields of a shard file, the first error is kept and the later writes are skipped
+type metaWriter struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func (w *metaWriter) bytes(b []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(b)
+	}
+}
+
+func (w *metaWriter) uvarint(v uint64) {
+	w.bytes(w.buf[:binary.PutUvarint(w.buf[:], v)])
+}
+
+func (w *metaWriter) varint(v int64) {
+	w.bytes(w.buf[:binary.PutVarint(w.buf[:], v)])
+}
+
+func (w *metaWriter) string(s string) {
+	w.uvarint(uint64(len(s)))
+	w.bytes([]byte(s))
+}
+
+func (w *metaWriter) bool(b bool) {
+	if b {
+		w.uvarint(1)
+	} else {
+		w.uvarint(0)
+	}
+}
+
+// metaReader reads the fields of a shard file, the first error is kept and the later reads return zero values
+type metaReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+// maxMetaLength limits the lengths read from a corrupted shard file
+const maxMetaLength = 1 << 30
+
+func (r *metaReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	b := make([]byte, n)
+	if _, r.err = io.ReadFull(r.r, b); r.err != nil {
+		return nil
+	}
+	return b
+}
+
+func (r *metaReader) uvarint() (v uint64) {
+	if r.err == nil {
+		v, r.err = binary.ReadUvarint(r.r)
+	}
+	return v
+}
+
+func (r *metaReader) varint() (v int64) {
+	if r.err == nil {
+		v, r.err = binary.ReadVarint(r.r)
+	}
+	return v
+}
+
+// count reads the number of the following items or the length of a string
+func (r *metaReader) count() int {
+	v := r.uvarint()
+	if r.err == nil && v > maxMetaLength {
+		r.err = errors.New("invalid length")
+	}
+	if r.err != nil {
+		return 0
+	}
+	return int(v)
+}
+
+func (r *metaReader) string() string {
+	return string(r.bytes(r.count()))
+}
+
+func (r *metaReader) bool() bool {
+	return r.uvarint() != 0
+}
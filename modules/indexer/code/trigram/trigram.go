@@ -0,0 +1,545 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/analyze"
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/ethantkoenig/rupture"
+	"github.com/go-enry/go-enry/v2"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const repoIndexerLatestVersion = 1
+
+var _ internal.Indexer = &Indexer{}
+
+// Indexer represents a trigram indexer implementation, which keeps a shard per repository in the index directory.
+// The documents and the trigram posting lists of the opened shards are kept in memory, the contents are read from the disk.
+// At most maxOpenShards shards are kept open, the least recently used one is unloaded to open another one.
+type Indexer struct {
+	indexDir      string
+	maxOpenShards int
+
+	mu     sync.Mutex
+	repos  container.Set[int64]      // the IDs of the indexed repositories, nil if the indexer isn't initialized
+	shards *lru.Cache[int64, *shard] // the opened shards by their repository IDs
+}
+
+// NewIndexer creates a new trigram indexer
+func NewIndexer(indexDir string) *Indexer {
+	return &Indexer{indexDir: indexDir, maxOpenShards: max(setting.Indexer.TypeTrigramMaxOpenShards, 1)}
+}
+
+// Init initializes the indexer, an index of a previous version is removed
+func (b *Indexer) Init(_ context.Context) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.repos != nil {
+		return false, errors.New("indexer is already initialized")
+	}
+
+	existed := false
+	if _, err := os.Stat(b.indexDir); err == nil {
+		metadata, err := rupture.ReadIndexMetadata(b.indexDir)
+		if err != nil {
+			return false, err
+		}
+		if metadata.Version == repoIndexerLatestVersion {
+			existed = true
+		} else {
+			log.Warn("Found trigram index with version %d, Gitea will remove it and rebuild", metadata.Version)
+			if err := util.RemoveAll(b.indexDir); err != nil {
+				return false, err
+			}
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	if !existed {
+		if err := os.MkdirAll(b.indexDir, os.ModePerm); err != nil {
+			return false, err
+		}
+		if err := rupture.WriteIndexMetadata(b.indexDir, &rupture.IndexMetadata{Version: repoIndexerLatestVersion}); err != nil {
+			return false, err
+		}
+	}
+
+	entries, err := os.ReadDir(b.indexDir)
+	if err != nil {
+		return false, err
+	}
+	// the cache never evicts a shard by itself, the shards are unloaded by getShard
+	if b.shards, err = lru.New[int64, *shard](b.maxOpenShards + 1); err != nil {
+		return false, err
+	}
+	b.repos = make(container.Set[int64], len(entries))
+	for _, entry := range entries {
+		if repoID, ok := parseShardFilename(entry.Name()); ok {
+			b.repos.Add(repoID)
+		}
+	}
+	return existed, nil
+}
+
+// Ping checks if the indexer is available
+func (b *Indexer) Ping(_ context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.repos == nil {
+		return errors.New("indexer is not initialized")
+	}
+	return nil
+}
+
+// Close closes the indexer
+func (b *Indexer) Close() {
+	b.mu.Lock()
+	if b.repos == nil {
+		b.mu.Unlock()
+		return
+	}
+	shards := b.shards.Values()
+	b.repos = nil
+	b.shards.Purge()
+	b.mu.Unlock()
+	for _, s := range shards {
+		s.mu.Lock()
+		s.unload()
+		s.mu.Unlock()
+	}
+}
+
+func (b *Indexer) SupportedSearchModes() []indexer.SearchMode {
+	return indexer.SearchModesExactWordsFuzzyRegexp()
+}
+
+// getShard returns the shard of the repository, it is nil if the repository isn't indexed unless create is true.
+// The shard may be unloaded before the caller locks it, then the caller has to get it again.
+func (b *Indexer) getShard(repoID int64, create bool) (*shard, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.repos == nil {
+		return nil, errors.New("indexer is not initialized")
+	}
+	if s, ok := b.shards.Get(repoID); ok {
+		return s, nil
+	}
+	if !create && !b.repos.Contains(repoID) {
+		return nil, nil
+	}
+	s, err := openShard(b.indexDir, repoID)
+	if err != nil {
+		return nil, err
+	}
+	b.unloadLeastRecentlyUsedShard()
+	b.repos.Add(repoID)
+	b.shards.Add(repoID, s)
+	return s, nil
+}
+
+// unloadLeastRecentlyUsedShard unloads the least recently used shard which isn't in use if the limit of open shards is reached.
+// The shard is unloaded while b.mu is held, so it can't be opened again before its files are closed.
+func (b *Indexer) unloadLeastRecentlyUsedShard() {
+	if b.shards.Len() < b.maxOpenShards {
+		return
+	}
+	for _, repoID := range b.shards.Keys() {
+		s, _ := b.shards.Peek(repoID)
+		// a shard in use can't be locked here, because its user may wait for b.mu
+		if s.mu.TryLock() {
+			b.shards.Remove(repoID)
+			s.unload()
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+// lockShard returns the shard of the repository like getShard, but it's locked and hasn't been unloaded
+func (b *Indexer) lockShard(repoID int64, create bool) (*shard, error) {
+	for {
+		s, err := b.getShard(repoID, create)
+		if err != nil || s == nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		if !s.unloaded {
+			return s, nil
+		}
+		s.mu.Unlock()
+	}
+}
+
+// repoIDs returns the sorted IDs of the indexed repositories
+func (b *Indexer) repoIDs() []int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	repoIDs := b.repos.Values()
+	slices.Sort(repoIDs)
+	return repoIDs
+}
+
+// unloadShard unloads the locked shard, it will be read again from the disk when it is used the next time
+func (b *Indexer) unloadShard(s *shard) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.repos != nil {
+		if cached, ok := b.shards.Peek(s.repoID); ok && cached == s {
+			b.shards.Remove(s.repoID)
+		}
+	}
+	s.unload()
+}
+
+func (b *Indexer) addUpdate(ctx context.Context, catFileBatch git.CatFileBatch, s *shard, ref, commitSha string,
+	update internal.FileUpdate, repo *repo_model.Repository,
+) error {
+	// Ignore vendored files in code search
+	if setting.Indexer.ExcludeVendored && analyze.IsVendor(update.Filename) {
+		return nil
+	}
+
+	fileContents, tooLarge, err := internal.ReadFileContent(ctx, catFileBatch, repo, update)
+	if err != nil {
+		return err
+	} else if tooLarge {
+		s.remove(ref, update.Filename)
+		return nil
+	}
+
+	content := string(charset.ToUTF8DropErrors(fileContents))
+	language := analyze.GetCodeLanguage(update.Filename, fileContents)
+	symbols := internal.SymbolNames(internal.ExtractSymbols(update.Filename, language, content))
+	for i := range symbols {
+		symbols[i] = strings.ToLower(symbols[i])
+	}
+	return s.add(&document{
+		Ref:         ref,
+		Filename:    update.Filename,
+		CommitID:    commitSha,
+		Language:    language,
+		Symbols:     symbols,
+		UpdatedUnix: time.Now().Unix(),
+	}, content)
+}
+
+// Index indexes the data
+func (b *Indexer) Index(ctx context.Context, repo *repo_model.Repository, ref, sha string, changes *internal.RepoChanges) error {
+	s, err := b.lockShard(repo.ID, true)
+	if err != nil {
+		return err
+	}
+	defer s.mu.Unlock()
+
+	ref = util.IfZero(ref, internal.DefaultBranchRef)
+	if err := b.index(ctx, s, repo, ref, sha, changes); err != nil {
+		// the changes may be applied partially, so the shard has to be read again
+		b.unloadShard(s)
+		return err
+	}
+	return nil
+}
+
+func (b *Indexer) index(ctx context.Context, s *shard, repo *repo_model.Repository, ref, sha string, changes *internal.RepoChanges) error {
+	if len(changes.Updates) > 0 {
+		catfileBatch, err := gitrepo.NewBatch(ctx, repo)
+		if err != nil {
+			return err
+		}
+		defer catfileBatch.Close()
+
+		for _, update := range changes.Updates {
+			if err := b.addUpdate(ctx, catfileBatch, s, ref, sha, update, repo); err != nil {
+				return err
+			}
+		}
+	}
+	for _, filename := range changes.RemovedFilenames {
+		s.remove(ref, filename)
+	}
+	return s.save()
+}
+
+// Delete deletes the shard of the repository
+func (b *Indexer) Delete(_ context.Context, repoID int64) error {
+	s, err := b.lockShard(repoID, false)
+	if err != nil || s == nil {
+		return err
+	}
+	defer s.mu.Unlock()
+	if err := s.destroy(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	if b.repos != nil {
+		b.shards.Remove(repoID)
+		b.repos.Remove(repoID)
+	}
+	b.mu.Unlock()
+	s.unload()
+	return nil
+}
+
+// DeleteRef deletes the documents of a ref of the repository
+func (b *Indexer) DeleteRef(_ context.Context, repoID int64, ref string) error {
+	s, err := b.lockShard(repoID, false)
+	if err != nil || s == nil {
+		return err
+	}
+	defer s.mu.Unlock()
+	s.removeRef(util.IfZero(ref, internal.DefaultBranchRef))
+	if err := s.save(); err != nil {
+		b.unloadShard(s)
+		return err
+	}
+	return nil
+}
+
+// searchMatch is a document matching a search, the content is only read for the documents of the requested page
+// unless it has been read to verify the keyword
+type searchMatch struct {
+	shard   *shard
+	doc     document
+	content string
+	loaded  bool
+	ranges  [][2]int
+	score   float64
+}
+
+// errShardUnloaded is returned by the search of a shard which has been unloaded before it could be searched
+var errShardUnloaded = errors.New("shard has been unloaded")
+
+// search returns the documents of the shard matching the search
+func (s *shard) search(opts *internal.SearchOptions, m *matcher, refPatterns internal.RefPatterns) ([]*searchMatch, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.unloaded {
+		return nil, errShardUnloaded
+	}
+
+	var candidateIDs []uint32
+	allCandidates := true
+	if m != nil {
+		candidateIDs, allCandidates = s.candidates(m.query)
+	}
+
+	symbol := strings.ToLower(opts.Symbol)
+	path := strings.ToLower(opts.Path)
+
+	var matches []*searchMatch
+	for id, doc := range s.docs {
+		isCandidate := allCandidates
+		for len(candidateIDs) > 0 && int(candidateIDs[0]) <= id {
+			isCandidate = isCandidate || int(candidateIDs[0]) == id
+			candidateIDs = candidateIDs[1:]
+		}
+
+		if doc.Deleted ||
+			(refPatterns != nil && !refPatterns.Match(git.RefName(doc.Ref))) ||
+			(symbol != "" && !slices.Contains(doc.Symbols, symbol)) ||
+			(path != "" && !strings.Contains(strings.ToLower(doc.Filename), path)) {
+			continue
+		}
+
+		match := &searchMatch{shard: s, doc: *doc}
+		if m != nil {
+			filenameMatched := m.filename(doc.Filename)
+			if !filenameMatched && !isCandidate {
+				continue
+			}
+			if isCandidate {
+				content, err := s.readContent(doc)
+				if err != nil {
+					return nil, err
+				}
+				match.content, match.loaded = content, true
+				var contentMatched bool
+				if match.ranges, contentMatched = m.match(content); contentMatched {
+					match.score += 1 + float64(min(len(match.ranges), 10))/10
+					if m.phrase != nil && m.phrase.MatchString(content) {
+						match.score += 2
+					}
+				} else if !filenameMatched {
+					continue
+				}
+			}
+			if filenameMatched {
+				match.score += 10
+			}
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// loadContent reads the content of the document of the match if it hasn't been read yet
+func (m *searchMatch) loadContent() error {
+	if m.loaded {
+		return nil
+	}
+	m.shard.mu.RLock()
+	defer m.shard.mu.RUnlock()
+	// the documents may have been changed or compacted since the search, so the current document has to be read
+	id, ok := m.shard.byKey[docKey(m.doc.Ref, m.doc.Filename)]
+	if !ok {
+		m.loaded = true
+		return nil
+	}
+	content, err := m.shard.readContent(m.shard.docs[id])
+	if errors.Is(err, os.ErrNotExist) {
+		// the shard has been unloaded and compacted by another instance since the search
+		m.loaded = true
+		return nil
+	} else if err != nil {
+		return err
+	}
+	m.content, m.loaded = content, true
+	return nil
+}
+
+// searchShard searches the shard of the repository, it is opened again if it has been unloaded before it could be searched
+func (b *Indexer) searchShard(repoID int64, opts *internal.SearchOptions, m *matcher, refPatterns internal.RefPatterns) ([]*searchMatch, error) {
+	for {
+		s, err := b.getShard(repoID, false)
+		if err != nil || s == nil {
+			return nil, err
+		}
+		matches, err := s.search(opts, m, refPatterns)
+		if !errors.Is(err, errShardUnloaded) {
+			return matches, err
+		}
+	}
+}
+
+// Search searches for files in the specified repo.
+// Returns the matching file-paths
+func (b *Indexer) Search(ctx context.Context, opts *internal.SearchOptions) (int64, []*internal.SearchResult, []*internal.SearchResultLanguages, error) {
+	var m *matcher
+	if opts.Keyword != "" {
+		var err error
+		if m, err = newMatcher(opts.Keyword, util.IfZero(opts.SearchMode, b.SupportedSearchModes()[0].ModeValue)); err != nil {
+			return 0, nil, nil, err
+		}
+	}
+
+	var refPatterns internal.RefPatterns
+	if patterns := internal.RefSearchPatterns(opts.Refs); !slices.Contains(patterns, "*") {
+		var err error
+		if refPatterns, err = internal.CompileRefPatterns(patterns); err != nil {
+			return 0, nil, nil, util.NewInvalidArgumentErrorf("invalid ref pattern: %v", err)
+		}
+	}
+
+	repoIDs := opts.RepoIDs
+	if len(repoIDs) == 0 {
+		repoIDs = b.repoIDs()
+	}
+
+	var matches []*searchMatch
+	for _, repoID := range repoIDs {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, nil, err
+		}
+		shardMatches, err := b.searchShard(repoID, opts, m, refPatterns)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		matches = append(matches, shardMatches...)
+	}
+
+	languageCounts := make(map[string]int)
+	for _, match := range matches {
+		if match.doc.Language != "" {
+			languageCounts[match.doc.Language]++
+		}
+	}
+	searchResultLanguages := make([]*internal.SearchResultLanguages, 0, len(languageCounts))
+	for language, count := range languageCounts {
+		searchResultLanguages = append(searchResultLanguages, &internal.SearchResultLanguages{
+			Language: language,
+			Color:    enry.GetColor(language),
+			Count:    count,
+		})
+	}
+	slices.SortFunc(searchResultLanguages, func(a, b *internal.SearchResultLanguages) int {
+		return cmp.Or(b.Count-a.Count, strings.Compare(a.Language, b.Language))
+	})
+	searchResultLanguages = searchResultLanguages[:min(len(searchResultLanguages), 10)]
+
+	if opts.Language != "" {
+		matches = slices.DeleteFunc(matches, func(match *searchMatch) bool {
+			return match.doc.Language != opts.Language
+		})
+	}
+
+	slices.SortFunc(matches, func(a, b *searchMatch) int {
+		return cmp.Or(
+			cmp.Compare(b.score, a.score),
+			cmp.Compare(len(a.doc.Filename), len(b.doc.Filename)),
+			strings.Compare(a.doc.Filename, b.doc.Filename),
+			cmp.Compare(a.shard.repoID, b.shard.repoID),
+			strings.Compare(a.doc.Ref, b.doc.Ref),
+		)
+	})
+
+	total := int64(len(matches))
+	skip, take := opts.GetSkipTake()
+	matches = matches[min(skip, len(matches)):min(skip+take, len(matches))]
+
+	searchResults := make([]*internal.SearchResult, 0, len(matches))
+	for _, match := range matches {
+		if err := match.loadContent(); err != nil {
+			return 0, nil, nil, err
+		}
+		var startIndex, endIndex int
+		switch {
+		case opts.Symbol != "":
+			startIndex, endIndex = internal.SymbolMatchIndexPos(match.doc.Filename, match.doc.Language, match.content, opts.Symbol)
+		case len(match.ranges) > 0:
+			startIndex, endIndex = match.ranges[0][0], match.ranges[0][1]
+		default:
+			startIndex, endIndex = internal.FilenameMatchIndexPos(match.content)
+		}
+		ref := match.doc.Ref
+		if ref == internal.DefaultBranchRef {
+			ref = ""
+		}
+		searchResults = append(searchResults, &internal.SearchResult{
+			RepoID:      match.shard.repoID,
+			Ref:         ref,
+			StartIndex:  startIndex,
+			EndIndex:    endIndex,
+			Filename:    match.doc.Filename,
+			Content:     match.content,
+			CommitID:    match.doc.CommitID,
+			UpdatedUnix: timeutil.TimeStamp(match.doc.UpdatedUnix),
+			Language:    match.doc.Language,
+			Color:       enry.GetColor(match.doc.Language),
+		})
+	}
+	return total, searchResults, searchResultLanguages, nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package trigram
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/indexer"
+	"code.gitea.io/gitea/modules/indexer/code/internal"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addDocuments(t *testing.T, idx *Indexer, repoID int64, ref string, files map[string]string) {
+	s, err := idx.lockShard(repoID, true)
+	require.NoError(t, err)
+	defer s.mu.Unlock()
+	for filename, content := range files {
+		language := map[string]string{".go": "Go", ".py": "Python", ".md": "Markdown"}[filename[strings.LastIndexByte(filename, '.'):]]
+		symbols := internal.SymbolNames(internal.ExtractSymbols(filename, language, content))
+		for i := range symbols {
+			symbols[i] = strings.ToLower(symbols[i])
+		}
+		require.NoError(t, s.add(&document{Ref: ref, Filename: filename, Language: language, Symbols: symbols}, content))
+	}
+	require.NoError(t, s.save())
+}
+
+type searchHit struct {
+	RepoID   int64
+	Ref      string
+	Filename string
+	Match    string
+}
+
+func search(t *testing.T, idx *Indexer, opts *internal.SearchOptions) []searchHit {
+	if opts.Paginator == nil {
+		opts.Paginator = &db.ListOptions{Page: 1, PageSize: 10}
+	}
+	_, results, _, err := idx.Search(t.Context(), opts)
+	require.NoError(t, err)
+	var hits []searchHit
+	for _, result := range results {
+		hits = append(hits, searchHit{result.RepoID, result.Ref, result.Filename, result.Content[result.StartIndex:result.EndIndex]})
+	}
+	return hits
+}
+
+func TestIndexAndSearch(t *testing.T) {
+	dir := t.TempDir()
+	idx := NewIndexer(dir)
+	existed, err := idx.Init(t.Context())
+	require.NoError(t, err)
+	assert.False(t, existed)
+
+	addDocuments(t, idx, 1, internal.DefaultBranchRef, map[string]string{
+		"server/server.go": "package server\n\n// NewServer is called by main\nfunc NewServer() *Server {\n\treturn &Server{}\n}\n",
+		"main.go":          "package main\n\nfunc main() {\n\tserver.NewServer()\n}\n",
+		"README.md":        "# Server\n\nRun the server with `go run main.go`\n",
+	})
+	addDocuments(t, idx, 1, "refs/heads/release/1.0", map[string]string{
+		"main.go": "package main\n\nfunc main() {\n\tserver.NewLegacyServer()\n}\n",
+	})
+	addDocuments(t, idx, 2, internal.DefaultBranchRef, map[string]string{
+		"lib/server.py": "class Server:\n    def serve(self):\n        pass\n",
+	})
+
+	cases := []struct {
+		Name     string
+		Opts     *internal.SearchOptions
+		Expected []searchHit
+	}{
+		{
+			Name: "exact",
+			Opts: &internal.SearchOptions{Keyword: "server.newserver", SearchMode: indexer.SearchModeExact},
+			Expected: []searchHit{
+				{1, "", "main.go", "server.NewServer"},
+			},
+		},
+		{
+			Name: "exact filename first",
+			Opts: &internal.SearchOptions{Keyword: "server", SearchMode: indexer.SearchModeExact, RepoIDs: []int64{1}},
+			Expected: []searchHit{
+				{1, "", "server/server.go", "server"},
+				{1, "", "main.go", "server"},
+				{1, "", "README.md", "Server"},
+			},
+		},
+		{
+			Name: "words",
+			Opts: &internal.SearchOptions{Keyword: "main run", SearchMode: indexer.SearchModeWords},
+			Expected: []searchHit{
+				{1, "", "README.md", "Run"},
+			},
+		},
+		{
+			Name: "fuzzy",
+			Opts: &internal.SearchOptions{Keyword: "pazs", SearchMode: indexer.SearchModeFuzzy},
+			Expected: []searchHit{
+				{2, "", "lib/server.py", "pass"},
+			},
+		},
+		{
+			Name: "regexp",
+			Opts: &internal.SearchOptions{Keyword: `^\s+server\.New(Legacy)?Server`, SearchMode: indexer.SearchModeRegexp, Refs: []string{"*"}},
+			Expected: []searchHit{
+				{1, "", "main.go", "\tserver.NewServer"},
+				{1, "refs/heads/release/1.0", "main.go", "\tserver.NewLegacyServer"},
+			},
+		},
+		{
+			Name: "regexp without literals",
+			Opts: &internal.SearchOptions{Keyword: `de[f]\s`, SearchMode: indexer.SearchModeRegexp},
+			Expected: []searchHit{
+				{2, "", "lib/server.py", "def "},
+			},
+		},
+		{
+			Name: "ref",
+			Opts: &internal.SearchOptions{Keyword: "NewLegacyServer", Refs: []string{"release/*"}},
+			Expected: []searchHit{
+				{1, "refs/heads/release/1.0", "main.go", "NewLegacyServer"},
+			},
+		},
+		{
+			Name: "symbol",
+			Opts: &internal.SearchOptions{Symbol: "newserver"},
+			Expected: []searchHit{
+				{1, "", "server/server.go", "NewServer"},
+			},
+		},
+		{
+			Name: "path and language",
+			Opts: &internal.SearchOptions{Keyword: "server", Path: "LIB/", Language: "Python"},
+			Expected: []searchHit{
+				{2, "", "lib/server.py", "Server"},
+			},
+		},
+		{
+			Name: "repository",
+			Opts: &internal.SearchOptions{Keyword: "package", RepoIDs: []int64{2, 3}},
+		},
+		{
+			Name: "no match",
+			Opts: &internal.SearchOptions{Keyword: "NewServer main", SearchMode: indexer.SearchModeExact},
+		},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.Expected, search(t, idx, c.Opts), c.Name)
+	}
+
+	total, results, languages, err := idx.Search(t.Context(), &internal.SearchOptions{
+		Keyword:   "server",
+		Language:  "Go",
+		Paginator: &db.ListOptions{Page: 2, PageSize: 1},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+	require.Len(t, results, 1)
+	assert.Equal(t, "main.go", results[0].Filename)
+	assert.Equal(t, []*internal.SearchResultLanguages{
+		{Language: "Go", Color: "#00ADD8", Count: 2},
+		{Language: "Markdown", Color: "#083fa1", Count: 1},
+		{Language: "Python", Color: "#3572A5", Count: 1},
+	}, languages)
+
+	_, _, _, err = idx.Search(t.Context(), &internal.SearchOptions{Keyword: "server(", SearchMode: indexer.SearchModeRegexp})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	// the shards are read again from the disk
+	idx.Close()
+	idx = NewIndexer(dir)
+	defer idx.Close()
+	existed, err = idx.Init(t.Context())
+	require.NoError(t, err)
+	assert.True(t, existed)
+	for _, c := range cases {
+		assert.Equal(t, c.Expected, search(t, idx, c.Opts), c.Name)
+	}
+
+	require.NoError(t, idx.DeleteRef(t.Context(), 1, "refs/heads/release/1.0"))
+	assert.Empty(t, search(t, idx, &internal.SearchOptions{Keyword: "NewLegacyServer", Refs: []string{"*"}}))
+	require.NoError(t, idx.Delete(t.Context(), 2))
+	assert.Empty(t, search(t, idx, &internal.SearchOptions{Keyword: "serve", RepoIDs: []int64{2}}))
+	assert.Equal(t, []int64{1}, idx.repoIDs())
+	assert.NoFileExists(t, shardFilename(dir, 2))
+}
+
+func TestShardCompaction(t *testing.T) {
+	dir := t.TempDir()
+	idx := NewIndexer(dir)
+	defer idx.Close()
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	addDocuments(t, idx, 1, internal.DefaultBranchRef, map[string]string{
+		"a.go": "package a // alpha",
+		"b.go": "package b // beta",
+		"c.go": "package c // gamma",
+	})
+	filenames := func(keyword string) (names []string) {
+		for _, hit := range search(t, idx, &internal.SearchOptions{Keyword: keyword}) {
+			names = append(names, hit.Filename)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	s, err := idx.getShard(1, false)
+	require.NoError(t, err)
+	s.mu.Lock()
+	s.remove(internal.DefaultBranchRef, "a.go")
+	require.NoError(t, s.add(&document{Ref: internal.DefaultBranchRef, Filename: "b.go"}, "package b // beta delta"))
+	require.NoError(t, s.save())
+	s.mu.Unlock()
+	assert.EqualValues(t, 1, s.generation)
+	assert.Equal(t, []string{"b.go", "c.go"}, filenames("package"))
+	assert.Equal(t, []string{"b.go"}, filenames("delta"))
+
+	s.mu.Lock()
+	s.remove(internal.DefaultBranchRef, "c.go")
+	require.NoError(t, s.save())
+	s.mu.Unlock()
+	assert.EqualValues(t, 2, s.generation)
+	assert.Len(t, s.docs, 1)
+	assert.Equal(t, []string{"b.go"}, filenames("package"))
+	assert.Empty(t, filenames("gamma"))
+	assert.NoFileExists(t, dataFilename(dir, 1, 1))
+
+	reopened, err := openShard(dir, 1)
+	require.NoError(t, err)
+	defer reopened.close()
+	assert.Equal(t, s.docs, reopened.docs)
+	assert.Equal(t, s.postings, reopened.postings)
+}
+
+func TestMaxOpenShards(t *testing.T) {
+	defer test.MockVariableValue(&setting.Indexer.TypeTrigramMaxOpenShards, 2)()
+
+	idx := NewIndexer(t.TempDir())
+	defer idx.Close()
+	_, err := idx.Init(t.Context())
+	require.NoError(t, err)
+
+	for repoID := int64(1); repoID <= 3; repoID++ {
+		addDocuments(t, idx, repoID, internal.DefaultBranchRef, map[string]string{"main.go": "package main // shared"})
+	}
+	assert.Equal(t, []int64{2, 3}, idx.shards.Keys())
+
+	// a search without repositories opens every shard, the least recently used ones are unloaded
+	// but the contents of their matches are still read
+	hits := search(t, idx, &internal.SearchOptions{Keyword: "shared"})
+	assert.Equal(t, []searchHit{
+		{1, "", "main.go", "shared"},
+		{2, "", "main.go", "shared"},
+		{3, "", "main.go", "shared"},
+	}, hits)
+	assert.Equal(t, 2, idx.shards.Len())
+	hits = search(t, idx, &internal.SearchOptions{Path: "main.go"})
+	require.Len(t, hits, 3)
+	for _, hit := range hits {
+		assert.Equal(t, "package main // shared", hit.Match)
+	}
+
+	// a shard in use isn't unloaded
+	s, err := idx.lockShard(2, false)
+	require.NoError(t, err)
+	_, err = idx.getShard(1, false)
+	require.NoError(t, err)
+	assert.False(t, s.unloaded)
+	assert.ElementsMatch(t, []int64{1, 2}, idx.shards.Keys())
+	s.mu.Unlock()
+
+	// the shard of the repository 2 is the least recently used one
+	_, err = idx.getShard(3, false)
+	require.NoError(t, err)
+	assert.True(t, s.unloaded)
+	assert.ElementsMatch(t, []int64{1, 3}, idx.shards.Keys())
+	assert.Equal(t, []searchHit{{2, "", "main.go", "shared"}}, search(t, idx, &internal.SearchOptions{Keyword: "shared", RepoIDs: []int64{2}}))
+}
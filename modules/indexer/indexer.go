@@ -43,7 +43,7 @@ func SearchModesExactWordsFuzzy() []SearchMode {
 	}...)
 }
 
-func GitGrepSupportedSearchModes() []SearchMode {
+func SearchModesExactWordsRegexp() []SearchMode {
 	return append(SearchModesExactWords(), []SearchMode{
 		{
 			ModeValue:    SearchModeRegexp,
@@ -52,3 +52,17 @@ func GitGrepSupportedSearchModes() []SearchMode {
 		},
 	}...)
 }
+
+func SearchModesExactWordsFuzzyRegexp() []SearchMode {
+	return append(SearchModesExactWordsFuzzy(), []SearchMode{
+		{
+			ModeValue:    SearchModeRegexp,
+			TooltipTrKey: "search.regexp_tooltip",
+			TitleTrKey:   "search.regexp",
+		},
+	}...)
+}
+
+func GitGrepSupportedSearchModes() []SearchMode {
+	return SearchModesExactWordsRegexp()
+}
@@ -222,6 +222,13 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 			}
 			queries = append(queries, bleve.NewDisjunctionQuery(includeQueries...))
 		}
+		for _, labelIDs := range options.IncludedLabelIDSets {
+			var includeQueries []query.Query
+			for _, labelID := range labelIDs {
+				includeQueries = append(includeQueries, inner_bleve.NumericEqualityQuery(labelID, "label_ids"))
+			}
+			queries = append(queries, bleve.NewDisjunctionQuery(includeQueries...))
+		}
 		if len(options.ExcludedLabelIDs) > 0 {
 			var excludeQueries []query.Query
 			for _, labelID := range options.ExcludedLabelIDs {
@@ -243,6 +250,12 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 
 	if options.ProjectID.Has() {
 		queries = append(queries, inner_bleve.NumericEqualityQuery(options.ProjectID.Value(), "project_id"))
+	} else if len(options.ProjectIDs) > 0 {
+		var projectQueries []query.Query
+		for _, projectID := range options.ProjectIDs {
+			projectQueries = append(projectQueries, inner_bleve.NumericEqualityQuery(projectID, "project_id"))
+		}
+		queries = append(queries, bleve.NewDisjunctionQuery(projectQueries...))
 	}
 	if options.ProjectColumnID.Has() {
 		queries = append(queries, inner_bleve.NumericEqualityQuery(options.ProjectColumnID.Value(), "project_board_id"))
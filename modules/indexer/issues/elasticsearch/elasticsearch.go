@@ -191,6 +191,9 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		} else if len(options.IncludedAnyLabelIDs) > 0 {
 			query.Must(elastic.NewTermsQuery("label_ids", toAnySlice(options.IncludedAnyLabelIDs)...))
 		}
+		for _, labelIDs := range options.IncludedLabelIDSets {
+			query.Must(elastic.NewTermsQuery("label_ids", toAnySlice(labelIDs)...))
+		}
 		if len(options.ExcludedLabelIDs) > 0 {
 			q := elastic.NewBoolQuery()
 			for _, labelID := range options.ExcludedLabelIDs {
@@ -206,6 +209,8 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 
 	if options.ProjectID.Has() {
 		query.Must(elastic.NewTermQuery("project_id", options.ProjectID.Value()))
+	} else if len(options.ProjectIDs) > 0 {
+		query.Must(elastic.NewTermsQuery("project_id", toAnySlice(options.ProjectIDs)...))
 	}
 	if options.ProjectColumnID.Has() {
 		query.Must(elastic.NewTermQuery("project_board_id", options.ProjectColumnID.Value()))
@@ -87,14 +87,16 @@ type SearchOptions struct {
 	IsClosed   optional.Option[bool] // if the issues is closed
 	IsArchived optional.Option[bool] // if the repo is archived
 
-	IncludedLabelIDs    []int64 // labels the issues have
-	ExcludedLabelIDs    []int64 // labels the issues don't have
-	IncludedAnyLabelIDs []int64 // labels the issues have at least one. It will be ignored if IncludedLabelIDs is not empty. It's an uncommon filter, but it has been supported accidentally by issues.IssuesOptions.IncludedLabelNames.
-	NoLabelOnly         bool    // if the issues have no label, if true, IncludedLabelIDs and ExcludedLabelIDs, IncludedAnyLabelIDs will be ignored
+	IncludedLabelIDs    []int64   // labels the issues have
+	ExcludedLabelIDs    []int64   // labels the issues don't have
+	IncludedAnyLabelIDs []int64   // labels the issues have at least one. It will be ignored if IncludedLabelIDs is not empty. It's an uncommon filter, but it has been supported accidentally by issues.IssuesOptions.IncludedLabelNames.
+	IncludedLabelIDSets [][]int64 // the issues have at least one label of every set, used for label names which match labels of several repositories
+	NoLabelOnly         bool      // if the issues have no label, if true, IncludedLabelIDs and ExcludedLabelIDs, IncludedAnyLabelIDs, IncludedLabelIDSets will be ignored
 
 	MilestoneIDs []int64 // milestones the issues have
 
 	ProjectID       optional.Option[int64] // project the issues belong to
+	ProjectIDs      []int64                // projects the issues belong to one of, it will be ignored if ProjectID is set
 	ProjectColumnID optional.Option[int64] // project column the issues belong to
 
 	PosterID   string // poster of the issues, "(none)" or "(any)" or a user ID
@@ -264,6 +264,22 @@ var cases = []*testIndexerCase{
 		ExpectedIDs:   []int64{1003, 1001, 1000},
 		ExpectedTotal: 3,
 	},
+	{
+		Name: "include label sets",
+		ExtraData: []*internal.IndexerData{
+			{ID: 1000, Title: "hello a", LabelIDs: []int64{2000, 2002}},
+			{ID: 1001, Title: "hello b", LabelIDs: []int64{2001, 2003}},
+			{ID: 1002, Title: "hello c", LabelIDs: []int64{2000, 2001}},
+			{ID: 1003, Title: "hello d", LabelIDs: []int64{2002}},
+			{ID: 1004, Title: "hello e", LabelIDs: []int64{}},
+		},
+		SearchOptions: &internal.SearchOptions{
+			Keyword:             "hello",
+			IncludedLabelIDSets: [][]int64{{2000, 2001}, {2002, 2003}},
+		},
+		ExpectedIDs:   []int64{1001, 1000},
+		ExpectedTotal: 2,
+	},
 	{
 		Name: "MilestoneIDs",
 		SearchOptions: &internal.SearchOptions{
@@ -318,6 +334,24 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "ProjectIDs",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			ProjectIDs: []int64{1, 2},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Contains(t, []int64{1, 2}, data[v.ID].ProjectID)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return v.ProjectID == 1 || v.ProjectID == 2
+			}), result.Total)
+		},
+	},
 	{
 		Name: "no ProjectID",
 		SearchOptions: &internal.SearchOptions{
@@ -169,6 +169,9 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		} else if len(options.IncludedAnyLabelIDs) > 0 {
 			query.And(inner_meilisearch.NewFilterIn("label_ids", options.IncludedAnyLabelIDs...))
 		}
+		for _, labelIDs := range options.IncludedLabelIDSets {
+			query.And(inner_meilisearch.NewFilterIn("label_ids", labelIDs...))
+		}
 		if len(options.ExcludedLabelIDs) > 0 {
 			q := &inner_meilisearch.FilterAnd{}
 			for _, labelID := range options.ExcludedLabelIDs {
@@ -184,6 +187,8 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 
 	if options.ProjectID.Has() {
 		query.And(inner_meilisearch.NewFilterEq("project_id", options.ProjectID.Value()))
+	} else if len(options.ProjectIDs) > 0 {
+		query.And(inner_meilisearch.NewFilterIn("project_id", options.ProjectIDs...))
 	}
 	if options.ProjectColumnID.Has() {
 		query.And(inner_meilisearch.NewFilterEq("project_board_id", options.ProjectColumnID.Value()))
@@ -0,0 +1,298 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/indexer/issues/internal"
+	"code.gitea.io/gitea/modules/indexer/issues/query"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// querySortTypes maps the "sort:" values of the query syntax to the sort types used by issues_model.IssuesOptions
+var querySortTypes = map[string]string{
+	"created":       "latest",
+	"created-desc":  "latest",
+	"created-asc":   "oldest",
+	"updated":       "recentupdate",
+	"updated-desc":  "recentupdate",
+	"updated-asc":   "leastupdate",
+	"comments":      "mostcomment",
+	"comments-desc": "mostcomment",
+	"comments-asc":  "leastcomment",
+	"deadline":      "nearduedate",
+	"deadline-asc":  "nearduedate",
+	"deadline-desc": "farduedate",
+}
+
+var querySortBy = map[string]internal.SortBy{
+	"latest":       SortByCreatedDesc,
+	"oldest":       SortByCreatedAsc,
+	"recentupdate": SortByUpdatedDesc,
+	"leastupdate":  SortByUpdatedAsc,
+	"mostcomment":  SortByCommentsDesc,
+	"leastcomment": SortByCommentsAsc,
+	"nearduedate":  SortByDeadlineAsc,
+	"farduedate":   SortByDeadlineDesc,
+}
+
+// QuerySortType returns the sort type of issues_model.IssuesOptions requested by the last "sort:" filter of the query,
+// or an empty string if the query doesn't contain a valid one.
+func QuerySortType(q *query.Query) string {
+	values := q.Get(query.KeySort, false)
+	if len(values) == 0 {
+		return ""
+	}
+	return querySortTypes[strings.ToLower(values[len(values)-1])]
+}
+
+// ApplyQuery translates the filters of the parsed query into the search options and replaces the keyword by the free text of the query.
+// Label, milestone and project names are resolved in the scope of the repositories of the options including all public ones if AllPublic is set,
+// a name matching in several repositories matches any of them. "@me" refers to the doer which may be nil for anonymous users.
+// An invalid query results in an error which satisfies util.ErrInvalidArgument.
+func ApplyQuery(ctx context.Context, doer *user_model.User, q *query.Query, opts *SearchOptions) error {
+	opts.Keyword = q.Keyword
+
+	repoCond := builder.NewCond()
+	if len(opts.RepoIDs) > 0 {
+		repoCond = builder.In("id", opts.RepoIDs)
+	}
+	if opts.AllPublic {
+		repoCond = repoCond.Or(builder.Eq{"is_private": false})
+	}
+
+	var includedLabels, excludedLabels, milestones []string
+
+	for _, f := range q.Filters {
+		if f.Negated && f.Key != query.KeyIs && f.Key != query.KeyLabel {
+			return util.NewInvalidArgumentErrorf("%q can't be negated", f.Key+":")
+		}
+		if f.Value == "" {
+			return util.NewInvalidArgumentErrorf("%q requires a value", f.Key+":")
+		}
+
+		switch f.Key {
+		case query.KeyIs:
+			switch strings.ToLower(f.Value) {
+			case "open":
+				opts.IsClosed = optional.Some(f.Negated)
+			case "closed":
+				opts.IsClosed = optional.Some(!f.Negated)
+			case "issue":
+				opts.IsPull = optional.Some(f.Negated)
+			case "pr", "pull":
+				opts.IsPull = optional.Some(!f.Negated)
+			case "archived":
+				opts.IsArchived = optional.Some(!f.Negated)
+			default:
+				return util.NewInvalidArgumentErrorf("unknown value %q for %q", f.Value, "is:")
+			}
+		case query.KeyNo:
+			switch strings.ToLower(f.Value) {
+			case "label":
+				opts.NoLabelOnly = true
+			case "milestone":
+				opts.MilestoneIDs = []int64{0}
+			case "project":
+				opts.ProjectID = optional.Some[int64](0)
+			case "assignee":
+				opts.AssigneeID = "(none)"
+			default:
+				return util.NewInvalidArgumentErrorf("unknown value %q for %q", f.Value, "no:")
+			}
+		case query.KeyLabel:
+			if f.Negated {
+				excludedLabels = append(excludedLabels, f.Value)
+			} else {
+				includedLabels = append(includedLabels, f.Value)
+			}
+		case query.KeyMilestone:
+			milestones = append(milestones, f.Value)
+		case query.KeyProject:
+			ids, err := project_model.GetProjectIDsByTitleInRepos(ctx, repoCond, f.Value)
+			if err != nil {
+				return err
+			}
+			switch len(ids) {
+			case 0:
+				return util.NewInvalidArgumentErrorf("project %q does not exist", f.Value)
+			case 1:
+				opts.ProjectID, opts.ProjectIDs = optional.Some(ids[0]), nil
+			default:
+				opts.ProjectID, opts.ProjectIDs = optional.None[int64](), ids
+			}
+		case query.KeyAuthor, query.KeyAssignee, query.KeyMentions, query.KeyReviewRequested, query.KeyReviewedBy:
+			userID, err := resolveQueryUser(ctx, doer, f.Value)
+			if err != nil {
+				return err
+			}
+			switch f.Key {
+			case query.KeyAuthor:
+				opts.PosterID = strconv.FormatInt(userID, 10)
+			case query.KeyAssignee:
+				opts.AssigneeID = strconv.FormatInt(userID, 10)
+			case query.KeyMentions:
+				opts.MentionID = optional.Some(userID)
+			case query.KeyReviewRequested:
+				opts.ReviewRequestedID = optional.Some(userID)
+			case query.KeyReviewedBy:
+				opts.ReviewedID = optional.Some(userID)
+			}
+		case query.KeyUpdated:
+			after, before, err := parseQueryTimeRange(f.Value)
+			if err != nil {
+				return err
+			}
+			if after.Has() {
+				opts.UpdatedAfterUnix = after
+			}
+			if before.Has() {
+				opts.UpdatedBeforeUnix = before
+			}
+		case query.KeySort:
+			sortType, ok := querySortTypes[strings.ToLower(f.Value)]
+			if !ok {
+				return util.NewInvalidArgumentErrorf("unknown value %q for %q", f.Value, "sort:")
+			}
+			opts.SortBy = querySortBy[sortType]
+		}
+	}
+
+	if err := applyQueryLabels(ctx, repoCond, includedLabels, excludedLabels, opts); err != nil {
+		return err
+	}
+
+	if len(milestones) > 0 {
+		ms, err := issues_model.GetMilestonesByNamesInRepos(ctx, repoCond, milestones)
+		if err != nil {
+			return err
+		}
+		found := make(map[string]bool, len(ms))
+		opts.MilestoneIDs = make([]int64, 0, len(ms))
+		for _, m := range ms {
+			found[strings.ToLower(m.Name)] = true
+			opts.MilestoneIDs = append(opts.MilestoneIDs, m.ID)
+		}
+		for _, name := range milestones {
+			if !found[strings.ToLower(name)] {
+				return util.NewInvalidArgumentErrorf("milestone %q does not exist", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func applyQueryLabels(ctx context.Context, repoCond builder.Cond, included, excluded []string, opts *SearchOptions) error {
+	if len(included) == 0 && len(excluded) == 0 {
+		return nil
+	}
+
+	labels, err := issues_model.GetLabelsByNamesInRepos(ctx, repoCond, append(append([]string{}, included...), excluded...))
+	if err != nil {
+		return err
+	}
+	// the same label name can exist in several repositories or in a repository and its organization
+	idsByName := make(map[string][]int64, len(labels))
+	for _, l := range labels {
+		name := strings.ToLower(l.Name)
+		idsByName[name] = append(idsByName[name], l.ID)
+	}
+
+	for _, name := range excluded {
+		opts.ExcludedLabelIDs = append(opts.ExcludedLabelIDs, idsByName[strings.ToLower(name)]...)
+	}
+
+	for _, name := range included {
+		ids := idsByName[strings.ToLower(name)]
+		switch len(ids) {
+		case 0:
+			return util.NewInvalidArgumentErrorf("label %q does not exist", name)
+		case 1:
+			opts.IncludedLabelIDs = append(opts.IncludedLabelIDs, ids[0])
+		default:
+			// the issues need to have one of the labels with the name
+			opts.IncludedLabelIDSets = append(opts.IncludedLabelIDSets, ids)
+		}
+	}
+	return nil
+}
+
+func resolveQueryUser(ctx context.Context, doer *user_model.User, name string) (int64, error) {
+	if name == "@me" {
+		if doer == nil {
+			return 0, util.NewInvalidArgumentErrorf("%q requires a signed-in user", name)
+		}
+		return doer.ID, nil
+	}
+	u, err := user_model.GetUserByName(ctx, strings.TrimPrefix(name, "@"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return 0, util.NewInvalidArgumentErrorf("user %q does not exist", name)
+		}
+		return 0, err
+	}
+	return u.ID, nil
+}
+
+// parseQueryTimeRange parses ">DATE", ">=DATE", "<DATE", "<=DATE", "DATE" and "FROM..TO" into an inclusive range of unix timestamps.
+// A DATE is either a day like "2026-01-02" or a RFC 3339 time, "*" is an open end of a range.
+func parseQueryTimeRange(value string) (after, before optional.Option[int64], err error) {
+	parse := func(s string) (start, end int64, err error) {
+		if t, err := time.ParseInLocation(time.DateOnly, s, setting.DefaultUILocation); err == nil {
+			return t.Unix(), t.AddDate(0, 0, 1).Unix() - 1, nil
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return 0, 0, util.NewInvalidArgumentErrorf("invalid date %q", s)
+		}
+		return t.Unix(), t.Unix(), nil
+	}
+
+	var start, end int64
+	switch {
+	case strings.HasPrefix(value, ">="):
+		start, _, err = parse(value[2:])
+		return optional.Some(start), nil, err
+	case strings.HasPrefix(value, "<="):
+		_, end, err = parse(value[2:])
+		return nil, optional.Some(end), err
+	case strings.HasPrefix(value, ">"):
+		_, end, err = parse(value[1:])
+		return optional.Some(end + 1), nil, err
+	case strings.HasPrefix(value, "<"):
+		start, _, err = parse(value[1:])
+		return nil, optional.Some(start - 1), err
+	}
+
+	if from, to, found := strings.Cut(value, ".."); found {
+		if from != "*" {
+			if start, _, err = parse(from); err != nil {
+				return nil, nil, err
+			}
+			after = optional.Some(start)
+		}
+		if to != "*" {
+			if _, end, err = parse(to); err != nil {
+				return nil, nil, err
+			}
+			before = optional.Some(end)
+		}
+		return after, before, nil
+	}
+
+	start, end, err = parse(value)
+	return optional.Some(start), optional.Some(end), err
+}
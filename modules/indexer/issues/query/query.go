@@ -0,0 +1,166 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package query parses the structured issue search syntax like
+// `is:open label:bug -label:wontfix assignee:@me milestone:"v2" updated:>2026-01-01 sort:updated-desc`.
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+// The supported filter keys
+const (
+	KeyIs              = "is"
+	KeyNo              = "no"
+	KeyLabel           = "label"
+	KeyMilestone       = "milestone"
+	KeyProject         = "project"
+	KeyAuthor          = "author"
+	KeyAssignee        = "assignee"
+	KeyMentions        = "mentions"
+	KeyReviewRequested = "review-requested"
+	KeyReviewedBy      = "reviewed-by"
+	KeyUpdated         = "updated"
+	KeySort            = "sort"
+)
+
+var knownKeys = map[string]bool{
+	KeyIs:              true,
+	KeyNo:              true,
+	KeyLabel:           true,
+	KeyMilestone:       true,
+	KeyProject:         true,
+	KeyAuthor:          true,
+	KeyAssignee:        true,
+	KeyMentions:        true,
+	KeyReviewRequested: true,
+	KeyReviewedBy:      true,
+	KeyUpdated:         true,
+	KeySort:            true,
+}
+
+// Filter is a single `key:value` or `-key:value` term
+type Filter struct {
+	Key     string
+	Value   string
+	Negated bool
+}
+
+// String returns the filter in the query syntax
+func (f *Filter) String() string {
+	var sb strings.Builder
+	if f.Negated {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(f.Key)
+	sb.WriteByte(':')
+	if f.Value == "" || strings.ContainsFunc(f.Value, unicode.IsSpace) {
+		sb.WriteByte('"')
+		sb.WriteString(f.Value)
+		sb.WriteByte('"')
+	} else {
+		sb.WriteString(f.Value)
+	}
+	return sb.String()
+}
+
+// Query is a parsed search query
+type Query struct {
+	Keyword string // the remaining free text
+	Filters []*Filter
+}
+
+// HasFilters checks if the query contains any filter
+func (q *Query) HasFilters() bool {
+	return len(q.Filters) > 0
+}
+
+// Get returns the values of the filters with the key
+func (q *Query) Get(key string, negated bool) []string {
+	var values []string
+	for _, f := range q.Filters {
+		if f.Key == key && f.Negated == negated {
+			values = append(values, f.Value)
+		}
+	}
+	return values
+}
+
+// String returns the query in a normalized form
+func (q *Query) String() string {
+	parts := make([]string, 0, len(q.Filters)+1)
+	for _, f := range q.Filters {
+		parts = append(parts, f.String())
+	}
+	if q.Keyword != "" {
+		parts = append(parts, q.Keyword)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Parse splits the input into filters with a known key and the free text keyword.
+// Terms with an unknown key like "http://example.com" are kept in the keyword.
+// Values may be quoted with double quotes to contain spaces.
+func Parse(input string) *Query {
+	q := &Query{}
+	var words []string
+
+	for _, token := range tokenize(input) {
+		term := token
+		negated := false
+		if len(term) > 1 && term[0] == '-' {
+			negated = true
+			term = term[1:]
+		}
+
+		key, value, found := strings.Cut(term, ":")
+		key = strings.ToLower(key)
+		if !found || !knownKeys[key] {
+			words = append(words, token)
+			continue
+		}
+
+		q.Filters = append(q.Filters, &Filter{
+			Key:     key,
+			Value:   unquote(value),
+			Negated: negated,
+		})
+	}
+
+	q.Keyword = strings.Join(words, " ")
+	return q
+}
+
+// tokenize splits the input at spaces which are not inside double quotes
+func tokenize(input string) []string {
+	var tokens []string
+	var sb strings.Builder
+	inQuotes := false
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			sb.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			if sb.Len() > 0 {
+				tokens = append(tokens, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() > 0 {
+		tokens = append(tokens, sb.String())
+	}
+	return tokens
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		return value[1 : len(value)-1]
+	}
+	return strings.Trim(value, `"`)
+}
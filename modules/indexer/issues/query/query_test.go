@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		Input   string
+		Keyword string
+		Filters []*Filter
+	}{
+		{
+			Input: "",
+		},
+		{
+			Input:   "  crash on   startup ",
+			Keyword: "crash on startup",
+		},
+		{
+			Input:   `is:open label:bug -label:wontfix assignee:@me milestone:"v2 beta" updated:>2026-01-01 sort:updated-desc crash`,
+			Keyword: "crash",
+			Filters: []*Filter{
+				{Key: KeyIs, Value: "open"},
+				{Key: KeyLabel, Value: "bug"},
+				{Key: KeyLabel, Value: "wontfix", Negated: true},
+				{Key: KeyAssignee, Value: "@me"},
+				{Key: KeyMilestone, Value: "v2 beta"},
+				{Key: KeyUpdated, Value: ">2026-01-01"},
+				{Key: KeySort, Value: "updated-desc"},
+			},
+		},
+		{
+			Input:   `Label:"kind/bug" http://example.com -flaky "exact phrase" review-requested:user2`,
+			Keyword: `http://example.com -flaky "exact phrase"`,
+			Filters: []*Filter{
+				{Key: KeyLabel, Value: "kind/bug"},
+				{Key: KeyReviewRequested, Value: "user2"},
+			},
+		},
+		{
+			Input:   `no:label - label:`,
+			Keyword: "-",
+			Filters: []*Filter{
+				{Key: KeyNo, Value: "label"},
+				{Key: KeyLabel, Value: ""},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		q := Parse(c.Input)
+		assert.Equal(t, c.Keyword, q.Keyword, "input %q", c.Input)
+		assert.Equal(t, c.Filters, q.Filters, "input %q", c.Input)
+		assert.Equal(t, len(c.Filters) > 0, q.HasFilters(), "input %q", c.Input)
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	q := Parse(`crash -label:wontfix milestone:"v2 beta" is:open`)
+	assert.Equal(t, `-label:wontfix milestone:"v2 beta" is:open crash`, q.String())
+	assert.Equal(t, q, Parse(q.String()))
+	assert.Equal(t, []string{"wontfix"}, q.Get(KeyLabel, true))
+	assert.Empty(t, q.Get(KeyLabel, false))
+}
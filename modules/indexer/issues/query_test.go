@@ -0,0 +1,156 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package issues
+
+import (
+	"testing"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	project_model "code.gitea.io/gitea/models/project"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/indexer/issues/query"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyQuery(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.DefaultUILocation, time.UTC)()
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	apply := func(t *testing.T, input string, repoIDs ...int64) (*SearchOptions, error) {
+		opts := &SearchOptions{RepoIDs: repoIDs}
+		return opts, ApplyQuery(t.Context(), doer, query.Parse(input), opts)
+	}
+
+	t.Run("Filters", func(t *testing.T) {
+		opts, err := apply(t, `crash is:open is:pr -label:label2 label:LABEL1 assignee:@me author:user1 milestone:"milestone1" project:"project on user2" sort:updated-asc`, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "crash", opts.Keyword)
+		assert.Equal(t, optional.Some(false), opts.IsClosed)
+		assert.Equal(t, optional.Some(true), opts.IsPull)
+		assert.Equal(t, []int64{1}, opts.IncludedLabelIDs)
+		assert.Equal(t, []int64{2}, opts.ExcludedLabelIDs)
+		assert.Equal(t, "2", opts.AssigneeID)
+		assert.Equal(t, "1", opts.PosterID)
+		assert.Equal(t, []int64{1}, opts.MilestoneIDs)
+		assert.Equal(t, optional.Some[int64](4), opts.ProjectID)
+		assert.Equal(t, SortByUpdatedAsc, opts.SortBy)
+	})
+
+	t.Run("OrganizationLabel", func(t *testing.T) {
+		opts, err := apply(t, "label:orglabel3", 3)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3}, opts.IncludedLabelIDs)
+
+		_, err = apply(t, "label:orglabel3", 1)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("No", func(t *testing.T) {
+		opts, err := apply(t, "no:label no:milestone no:project no:assignee", 1)
+		require.NoError(t, err)
+		assert.True(t, opts.NoLabelOnly)
+		assert.Equal(t, []int64{0}, opts.MilestoneIDs)
+		assert.Equal(t, optional.Some[int64](0), opts.ProjectID)
+		assert.Equal(t, "(none)", opts.AssigneeID)
+	})
+
+	t.Run("Updated", func(t *testing.T) {
+		day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC).Unix()
+
+		cases := []struct {
+			Value  string
+			After  optional.Option[int64]
+			Before optional.Option[int64]
+		}{
+			{">2026-01-02", optional.Some(day + 86400), nil},
+			{">=2026-01-02", optional.Some(day), nil},
+			{"<2026-01-02", nil, optional.Some(day - 1)},
+			{"<=2026-01-02", nil, optional.Some(day + 86399)},
+			{"2026-01-02", optional.Some(day), optional.Some(day + 86399)},
+			{"2026-01-02..2026-01-03", optional.Some(day), optional.Some(day + 2*86400 - 1)},
+			{"*..2026-01-02", nil, optional.Some(day + 86399)},
+			{">2026-01-02T00:00:00Z", optional.Some(day + 1), nil},
+		}
+		for _, c := range cases {
+			opts, err := apply(t, "updated:"+c.Value, 1)
+			require.NoError(t, err, c.Value)
+			assert.Equal(t, c.After, opts.UpdatedAfterUnix, c.Value)
+			assert.Equal(t, c.Before, opts.UpdatedBeforeUnix, c.Value)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, input := range []string{
+			"is:unknown",
+			"no:reviewer",
+			"-assignee:user1",
+			"label:",
+			"label:unknown",
+			"milestone:unknown",
+			"project:unknown",
+			"author:unknown",
+			"updated:yesterday",
+			"sort:random",
+		} {
+			_, err := apply(t, input, 1)
+			assert.ErrorIs(t, err, util.ErrInvalidArgument, input)
+		}
+
+		err := ApplyQuery(t.Context(), nil, query.Parse("assignee:@me"), &SearchOptions{})
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("AllPublic", func(t *testing.T) {
+		applyAllPublic := func(t *testing.T, input string, repoIDs ...int64) (*SearchOptions, error) {
+			opts := &SearchOptions{RepoIDs: repoIDs, AllPublic: true}
+			return opts, ApplyQuery(t.Context(), doer, query.Parse(input), opts)
+		}
+
+		// the names of private repositories are only resolved if the repositories are searched
+		for _, input := range []string{"label:repo3label1", `project:"second project"`} {
+			_, err := applyAllPublic(t, input)
+			assert.ErrorIs(t, err, util.ErrInvalidArgument, input)
+		}
+
+		opts, err := applyAllPublic(t, `label:repo3label1 project:"second project"`, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{10}, opts.IncludedLabelIDs)
+		assert.Equal(t, optional.Some[int64](2), opts.ProjectID)
+
+		// names which match in several repositories match any of them
+		publicLabel := &issues_model.Label{RepoID: 10, Name: "label1", Color: "#000000"}
+		privateLabel := &issues_model.Label{RepoID: 2, Name: "label1", Color: "#000000"}
+		require.NoError(t, issues_model.NewLabels(t.Context(), publicLabel, privateLabel))
+		publicProject := &project_model.Project{RepoID: 10, Title: "first project", Type: project_model.TypeRepository}
+		require.NoError(t, project_model.NewProject(t.Context(), publicProject))
+
+		opts, err = applyAllPublic(t, `label:label1 label:label2 project:"first project"`)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, opts.IncludedLabelIDs)
+		if assert.Len(t, opts.IncludedLabelIDSets, 1) {
+			assert.ElementsMatch(t, []int64{1, publicLabel.ID}, opts.IncludedLabelIDSets[0])
+		}
+		assert.False(t, opts.ProjectID.Has())
+		assert.ElementsMatch(t, []int64{1, publicProject.ID}, opts.ProjectIDs)
+
+		opts, err = applyAllPublic(t, "label:label1", 2)
+		require.NoError(t, err)
+		if assert.Len(t, opts.IncludedLabelIDSets, 1) {
+			assert.ElementsMatch(t, []int64{1, publicLabel.ID, privateLabel.ID}, opts.IncludedLabelIDSets[0])
+		}
+	})
+
+	assert.Equal(t, "leastcomment", QuerySortType(query.Parse("sort:comments-desc sort:comments-asc")))
+	assert.Empty(t, QuerySortType(query.Parse("sort:random")))
+}
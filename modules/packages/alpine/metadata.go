@@ -29,8 +29,11 @@ const (
 	PropertyRepository   = "alpine.repository"
 	PropertyArchitecture = "alpine.architecture"
 
-	SettingKeyPrivate = "alpine.key.private"
-	SettingKeyPublic  = "alpine.key.public"
+	SettingKeyPrivate         = "alpine.key.private"
+	SettingKeyPublic          = "alpine.key.public"
+	SettingPreviousKeyPrivate = "alpine.key.previous.private"
+	SettingPreviousKeyPublic  = "alpine.key.previous.public"
+	SettingPreviousKeyExpires = "alpine.key.previous.expires"
 
 	RepositoryPackage = "_alpine"
 	RepositoryVersion = "_repository"
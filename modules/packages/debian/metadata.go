@@ -27,8 +27,11 @@ const (
 	PropertyControl                    = "debian.control"
 	PropertyRepositoryIncludeInRelease = "debian.repository.include_in_release"
 
-	SettingKeyPrivate = "debian.key.private"
-	SettingKeyPublic  = "debian.key.public"
+	SettingKeyPrivate         = "debian.key.private"
+	SettingKeyPublic          = "debian.key.public"
+	SettingPreviousKeyPrivate = "debian.key.previous.private"
+	SettingPreviousKeyPublic  = "debian.key.previous.public"
+	SettingPreviousKeyExpires = "debian.key.previous.expires"
 
 	RepositoryPackage = "_debian"
 	RepositoryVersion = "_repository"
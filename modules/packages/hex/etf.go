@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// https://www.erlang.org/doc/apps/erts/erl_ext_dist.html
+const (
+	etfVersion         = 131
+	etfSmallIntegerExt = 97
+	etfIntegerExt      = 98
+	etfNilExt          = 106
+	etfListExt         = 108
+	etfBinaryExt       = 109
+	etfSmallBigExt     = 110
+	etfMapExt          = 116
+	etfSmallAtomUTF8   = 119
+)
+
+// EncodeTerm encodes a value in the Erlang external term format which is used by the Hex HTTP API.
+// Strings are encoded as binaries, nil and booleans as atoms, slices as lists and maps as maps.
+func EncodeTerm(v any) ([]byte, error) {
+	return appendTerm([]byte{etfVersion}, v)
+}
+
+func appendTerm(b []byte, v any) ([]byte, error) {
+	switch t := v.(type) {
+	case nil:
+		return appendAtom(b, "nil"), nil
+	case bool:
+		if t {
+			return appendAtom(b, "true"), nil
+		}
+		return appendAtom(b, "false"), nil
+	case Atom:
+		return appendAtom(b, string(t)), nil
+	case string:
+		b = append(b, etfBinaryExt)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		return append(b, t...), nil
+	case int:
+		return appendInteger(b, int64(t)), nil
+	case int64:
+		return appendInteger(b, t), nil
+	case []string:
+		list := make([]any, 0, len(t))
+		for _, s := range t {
+			list = append(list, s)
+		}
+		return appendTerm(b, list)
+	case []any:
+		if len(t) == 0 {
+			return append(b, etfNilExt), nil
+		}
+		b = append(b, etfListExt)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		for _, item := range t {
+			var err error
+			if b, err = appendTerm(b, item); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, etfNilExt), nil
+	case map[string]any:
+		b = append(b, etfMapExt)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		// sort the keys to get a stable output
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			var err error
+			if b, err = appendTerm(b, k); err != nil {
+				return nil, err
+			}
+			if b, err = appendTerm(b, t[k]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported term type %T", v)
+}
+
+func appendAtom(b []byte, name string) []byte {
+	b = append(b, etfSmallAtomUTF8, byte(len(name)))
+	return append(b, name...)
+}
+
+func appendInteger(b []byte, n int64) []byte {
+	if n >= 0 && n <= math.MaxUint8 {
+		return append(b, etfSmallIntegerExt, byte(n))
+	}
+	if n >= math.MinInt32 && n <= math.MaxInt32 {
+		b = append(b, etfIntegerExt)
+		return binary.BigEndian.AppendUint32(b, uint32(int32(n)))
+	}
+
+	sign := byte(0)
+	u := uint64(n)
+	if n < 0 {
+		sign = 1
+		u = uint64(-n)
+	}
+	digits := binary.LittleEndian.AppendUint64(nil, u)
+	for len(digits) > 1 && digits[len(digits)-1] == 0 {
+		digits = digits[:len(digits)-1]
+	}
+	b = append(b, etfSmallBigExt, byte(len(digits)), sign)
+	return append(b, digits...)
+}
@@ -0,0 +1,313 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+var (
+	ErrInvalidTarball        = util.NewInvalidArgumentErrorf("package tarball is invalid")
+	ErrUnsupportedVersion    = util.NewInvalidArgumentErrorf("package tarball version is not supported")
+	ErrMissingMetadataFile   = util.NewInvalidArgumentErrorf("metadata.config file is missing")
+	ErrInvalidChecksum       = util.NewInvalidArgumentErrorf("package tarball checksum is invalid")
+	ErrInvalidName           = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion        = util.NewInvalidArgumentErrorf("package version is invalid")
+	ErrMetadataFileTooLarge  = util.NewInvalidArgumentErrorf("metadata.config file is too large")
+	errInvalidMetadataFormat = util.NewInvalidArgumentErrorf("metadata.config file is invalid")
+)
+
+const (
+	// TarballVersion is the only supported version of the package tarball format
+	TarballVersion = "3"
+
+	SettingKeyPrivate = "hex.key.private"
+	SettingKeyPublic  = "hex.key.public"
+
+	// DocsFilename is the name of the file containing the documentation of a package version
+	DocsFilename = "docs.tar.gz"
+
+	maxMetadataFileSize = 128 * 1024
+	maxReadmeFileSize   = 1024 * 1024
+)
+
+// https://github.com/hexpm/hex_core/blob/main/src/hex_tarball.erl
+var namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+
+// Package represents a Hex package
+type Package struct {
+	Name     string
+	Version  string
+	Metadata *Metadata
+}
+
+// Metadata represents the metadata of a Hex package
+type Metadata struct {
+	App          string            `json:"app,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Licenses     []string          `json:"licenses,omitempty"`
+	Links        map[string]string `json:"links,omitempty"`
+	BuildTools   []string          `json:"build_tools,omitempty"`
+	Elixir       string            `json:"elixir,omitempty"`
+	Readme       string            `json:"readme,omitempty"`
+	Dependencies []*Dependency     `json:"dependencies,omitempty"`
+	// InnerChecksum is the SHA-256 checksum of the tarball content listed in the registry
+	InnerChecksum string `json:"inner_checksum"`
+}
+
+// Dependency represents a requirement of a Hex package
+type Dependency struct {
+	Name        string `json:"name"`
+	Requirement string `json:"requirement"`
+	Optional    bool   `json:"optional,omitempty"`
+	App         string `json:"app,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+}
+
+// TarballFilename returns the name of the package tarball
+func TarballFilename(name, version string) string {
+	return name + "-" + version + ".tar"
+}
+
+// ParseFilename splits a "<name>-<version><suffix>" filename as used by the tarball and docs urls
+func ParseFilename(filename, suffix string) (string, string, error) {
+	base, ok := strings.CutSuffix(filename, suffix)
+	if !ok {
+		return "", "", util.NewInvalidArgumentErrorf("filename must end with %s", suffix)
+	}
+	// package names can't contain a hyphen
+	name, version, ok := strings.Cut(base, "-")
+	if !ok || name == "" || version == "" {
+		return "", "", util.NewInvalidArgumentErrorf("invalid filename")
+	}
+	return name, version, nil
+}
+
+// ParsePackage parses the outer tarball of a Hex package
+// https://github.com/hexpm/specifications/blob/main/package_tarball.md
+func ParsePackage(r io.Reader) (*Package, error) {
+	var versionContent, checksumContent, metadataContent, contents []byte
+
+	tr := tar.NewReader(r)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidTarball
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch hd.Name {
+		case "VERSION":
+			versionContent, err = util.ReadWithLimit(tr, 16)
+		case "CHECKSUM":
+			checksumContent, err = util.ReadWithLimit(tr, 128)
+		case "metadata.config":
+			if hd.Size > maxMetadataFileSize {
+				return nil, ErrMetadataFileTooLarge
+			}
+			metadataContent, err = util.ReadWithLimit(tr, maxMetadataFileSize)
+		case "contents.tar.gz":
+			contents, err = io.ReadAll(tr)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if versionContent == nil || contents == nil {
+		return nil, ErrInvalidTarball
+	}
+	if strings.TrimSpace(string(versionContent)) != TarballVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	if metadataContent == nil {
+		return nil, ErrMissingMetadataFile
+	}
+
+	h := sha256.New()
+	h.Write(versionContent)
+	h.Write(metadataContent)
+	h.Write(contents)
+	innerChecksum := h.Sum(nil)
+
+	if checksumContent != nil && !strings.EqualFold(strings.TrimSpace(string(checksumContent)), hex.EncodeToString(innerChecksum)) {
+		return nil, ErrInvalidChecksum
+	}
+
+	p, err := ParseMetadataConfig(bytes.NewReader(metadataContent))
+	if err != nil {
+		return nil, err
+	}
+
+	p.Metadata.InnerChecksum = hex.EncodeToString(innerChecksum)
+	p.Metadata.Readme, err = extractReadme(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// extractReadme returns the content of the readme file from the contents archive if present
+func extractReadme(contents []byte) (string, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(contents))
+	if err != nil {
+		return "", ErrInvalidTarball
+	}
+	defer zr.Close()
+
+	tr := tar.NewReader(zr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			return "", nil
+		}
+		if err != nil {
+			return "", ErrInvalidTarball
+		}
+
+		if hd.Typeflag == tar.TypeReg && strings.EqualFold(hd.Name, "README.md") {
+			data, err := util.ReadWithLimit(tr, maxReadmeFileSize)
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		}
+	}
+}
+
+// ParseMetadataConfig parses the metadata.config file of a Hex package
+func ParseMetadataConfig(r io.Reader) (*Package, error) {
+	data, err := util.ReadWithLimit(r, maxMetadataFileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	terms, err := ParseTerms(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	props := make(map[string]any, len(terms))
+	for _, term := range terms {
+		tuple, ok := term.(Tuple)
+		if !ok || len(tuple) != 2 {
+			return nil, errInvalidMetadataFormat
+		}
+		key, ok := termToString(tuple[0])
+		if !ok {
+			return nil, errInvalidMetadataFormat
+		}
+		props[key] = tuple[1]
+	}
+
+	name, _ := termToString(props["name"])
+	if !namePattern.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+
+	v, _ := termToString(props["version"])
+	if _, err := version.NewSemver(v); err != nil {
+		return nil, ErrInvalidVersion
+	}
+
+	m := &Metadata{
+		Licenses:   termToStringList(props["licenses"]),
+		BuildTools: termToStringList(props["build_tools"]),
+	}
+	m.App, _ = termToString(props["app"])
+	m.Description, _ = termToString(props["description"])
+	m.Elixir, _ = termToString(props["elixir"])
+
+	if links := termToProperties(props["links"]); len(links) > 0 {
+		m.Links = make(map[string]string, len(links))
+		for name, link := range links {
+			if s, ok := termToString(link); ok && validation.IsValidURL(s) {
+				m.Links[name] = s
+			}
+		}
+	}
+
+	m.Dependencies, err = parseRequirements(props["requirements"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Package{
+		Name:     name,
+		Version:  v,
+		Metadata: m,
+	}, nil
+}
+
+// parseRequirements supports the current format which maps the package name to the requirement properties
+// and the old format which is a list of requirement properties including the package name
+func parseRequirements(term any) ([]*Dependency, error) {
+	if term == nil {
+		return nil, nil
+	}
+
+	var items []any
+	switch t := term.(type) {
+	case []any:
+		items = t
+	case map[string]any:
+		for name, props := range t {
+			items = append(items, Tuple{name, props})
+		}
+	default:
+		return nil, errInvalidMetadataFormat
+	}
+
+	deps := make([]*Dependency, 0, len(items))
+	for _, item := range items {
+		var name string
+		var props map[string]any
+		if tuple, ok := item.(Tuple); ok && len(tuple) == 2 {
+			name, _ = termToString(tuple[0])
+			props = termToProperties(tuple[1])
+		} else {
+			props = termToProperties(item)
+			name, _ = termToString(props["name"])
+		}
+		if name == "" || props == nil {
+			return nil, errInvalidMetadataFormat
+		}
+
+		d := &Dependency{Name: name}
+		d.Requirement, _ = termToString(props["requirement"])
+		d.App, _ = termToString(props["app"])
+		d.Repository, _ = termToString(props["repository"])
+		d.Optional = props["optional"] == Atom("true")
+		if d.Requirement == "" {
+			return nil, util.NewInvalidArgumentErrorf("requirement of dependency %s is missing", name)
+		}
+		deps = append(deps, d)
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].Name < deps[j].Name
+	})
+
+	return deps, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packageName    = "gitea"
+	packageVersion = "1.0.1"
+	description    = "Package \"Description\""
+	readme         = "# Readme"
+)
+
+const metadataContent = `{<<"app">>,<<"gitea">>}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"description">>,<<"Package \"Description\""/utf8>>}.
+{<<"elixir">>,<<"~> 1.15">>}.
+{<<"files">>,[<<"lib">>,<<"lib/gitea.ex">>,<<"mix.exs">>,<<"README.md">>]}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"links">>,[{<<"GitHub">>,<<"https://github.com/go-gitea/gitea">>}]}.
+{<<"name">>,<<"gitea">>}.
+{<<"requirements">>,
+ [{<<"jason">>,
+   [{<<"app">>,<<"jason">>},
+    {<<"optional">>,false},
+    {<<"requirement">>,<<"~> 1.4">>},
+    {<<"repository">>,<<"hexpm">>}]},
+  {<<"decimal">>,
+   [{<<"app">>,<<"decimal">>},
+    {<<"optional">>,true},
+    {<<"requirement">>,<<"~> 2.0">>},
+    {<<"repository">>,<<"hexpm">>}]}]}.
+{<<"version">>,<<"1.0.1">>}.
+`
+
+func createContents(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0o600, Size: int64(len(readme))}))
+	_, err := tw.Write([]byte(readme))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func createTarball(t *testing.T, files map[string][]byte) io.Reader {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range []string{"VERSION", "CHECKSUM", "metadata.config", "contents.tar.gz"} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return &buf
+}
+
+func TestParsePackage(t *testing.T) {
+	contents := createContents(t)
+
+	h := sha256.New()
+	h.Write([]byte(TarballVersion))
+	h.Write([]byte(metadataContent))
+	h.Write(contents)
+	checksum := h.Sum(nil)
+
+	t.Run("MissingVersionFile", func(t *testing.T) {
+		p, err := ParsePackage(createTarball(t, map[string][]byte{
+			"metadata.config": []byte(metadataContent),
+			"contents.tar.gz": contents,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidTarball)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		p, err := ParsePackage(createTarball(t, map[string][]byte{
+			"VERSION":         []byte("2"),
+			"metadata.config": []byte(metadataContent),
+			"contents.tar.gz": contents,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("MissingMetadataFile", func(t *testing.T) {
+		p, err := ParsePackage(createTarball(t, map[string][]byte{
+			"VERSION":         []byte(TarballVersion),
+			"contents.tar.gz": contents,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingMetadataFile)
+	})
+
+	t.Run("InvalidChecksum", func(t *testing.T) {
+		p, err := ParsePackage(createTarball(t, map[string][]byte{
+			"VERSION":         []byte(TarballVersion),
+			"CHECKSUM":        []byte(strings.Repeat("0", 64)),
+			"metadata.config": []byte(metadataContent),
+			"contents.tar.gz": contents,
+		}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidChecksum)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParsePackage(createTarball(t, map[string][]byte{
+			"VERSION":         []byte(TarballVersion),
+			"CHECKSUM":        []byte(strings.ToUpper(hex.EncodeToString(checksum))),
+			"metadata.config": []byte(metadataContent),
+			"contents.tar.gz": contents,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, hex.EncodeToString(checksum), p.Metadata.InnerChecksum)
+		assert.Equal(t, readme, p.Metadata.Readme)
+	})
+}
+
+func TestParseMetadataConfig(t *testing.T) {
+	t.Run("InvalidName", func(t *testing.T) {
+		for _, name := range []string{"", "Gitea", "gitea-package", "1gitea"} {
+			p, err := ParseMetadataConfig(strings.NewReader(`{<<"name">>,<<"` + name + `">>}. {<<"version">>,<<"1.0.0">>}.`))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidName)
+		}
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p, err := ParseMetadataConfig(strings.NewReader(`{<<"name">>,<<"gitea">>}. {<<"version">>,<<"latest">>}.`))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		p, err := ParseMetadataConfig(strings.NewReader(`{<<"name">>,<<"gitea">>`))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParseMetadataConfig(strings.NewReader(metadataContent))
+		require.NoError(t, err)
+
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, "gitea", p.Metadata.App)
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, "~> 1.15", p.Metadata.Elixir)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+		assert.Equal(t, []string{"mix"}, p.Metadata.BuildTools)
+		assert.Equal(t, map[string]string{"GitHub": "https://github.com/go-gitea/gitea"}, p.Metadata.Links)
+		assert.Equal(t, []*Dependency{
+			{Name: "decimal", Requirement: "~> 2.0", Optional: true, App: "decimal", Repository: "hexpm"},
+			{Name: "jason", Requirement: "~> 1.4", App: "jason", Repository: "hexpm"},
+		}, p.Metadata.Dependencies)
+	})
+
+	t.Run("LegacyRequirements", func(t *testing.T) {
+		p, err := ParseMetadataConfig(strings.NewReader(`{<<"name">>,<<"gitea">>}.
+{<<"version">>,<<"1.0.0">>}.
+{<<"requirements">>,[[{<<"name">>,<<"jason">>},{<<"app">>,<<"jason">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.4">>}]]}.`))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{{Name: "jason", Requirement: "~> 1.4", App: "jason"}}, p.Metadata.Dependencies)
+	})
+}
+
+func TestParseFilename(t *testing.T) {
+	name, version, err := ParseFilename("gitea-1.0.0-rc.1.tar", ".tar")
+	assert.NoError(t, err)
+	assert.Equal(t, "gitea", name)
+	assert.Equal(t, "1.0.0-rc.1", version)
+
+	_, _, err = ParseFilename("gitea-1.0.0.tar.gz", ".tar")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	_, _, err = ParseFilename("gitea.tar", ".tar")
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
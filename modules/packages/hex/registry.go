@@ -0,0 +1,161 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The registry resources are protobuf messages wrapped in a signed message and compressed with gzip.
+// The messages are encoded by hand because they are small and stable.
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+
+// NamesPackage is an entry of the /names resource
+type NamesPackage struct {
+	Name      string
+	UpdatedAt time.Time
+}
+
+// VersionsPackage is an entry of the /versions resource
+type VersionsPackage struct {
+	Name     string
+	Versions []string
+}
+
+// Release is a version of the /packages/<name> resource
+type Release struct {
+	Version       string
+	InnerChecksum []byte
+	OuterChecksum []byte
+	Dependencies  []*Dependency
+}
+
+// EncodeNames encodes the Names message
+func EncodeNames(repository string, packages []*NamesPackage) []byte {
+	var b []byte
+	for _, p := range packages {
+		var pb []byte
+		pb = protowire.AppendTag(pb, 1, protowire.BytesType)
+		pb = protowire.AppendString(pb, p.Name)
+		if !p.UpdatedAt.IsZero() {
+			var tb []byte
+			tb = protowire.AppendTag(tb, 1, protowire.VarintType)
+			tb = protowire.AppendVarint(tb, uint64(p.UpdatedAt.Unix()))
+			tb = protowire.AppendTag(tb, 2, protowire.VarintType)
+			tb = protowire.AppendVarint(tb, uint64(p.UpdatedAt.Nanosecond()))
+			pb = protowire.AppendTag(pb, 2, protowire.BytesType)
+			pb = protowire.AppendBytes(pb, tb)
+		}
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, pb)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendString(b, repository)
+}
+
+// EncodeVersions encodes the Versions message
+func EncodeVersions(repository string, packages []*VersionsPackage) []byte {
+	var b []byte
+	for _, p := range packages {
+		var pb []byte
+		pb = protowire.AppendTag(pb, 1, protowire.BytesType)
+		pb = protowire.AppendString(pb, p.Name)
+		for _, v := range p.Versions {
+			pb = protowire.AppendTag(pb, 2, protowire.BytesType)
+			pb = protowire.AppendString(pb, v)
+		}
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, pb)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendString(b, repository)
+}
+
+// EncodePackage encodes the Package message
+func EncodePackage(repository, name string, releases []*Release) []byte {
+	var b []byte
+	for _, r := range releases {
+		var rb []byte
+		rb = protowire.AppendTag(rb, 1, protowire.BytesType)
+		rb = protowire.AppendString(rb, r.Version)
+		rb = protowire.AppendTag(rb, 2, protowire.BytesType)
+		rb = protowire.AppendBytes(rb, r.InnerChecksum)
+		for _, d := range r.Dependencies {
+			var db []byte
+			db = protowire.AppendTag(db, 1, protowire.BytesType)
+			db = protowire.AppendString(db, d.Name)
+			db = protowire.AppendTag(db, 2, protowire.BytesType)
+			db = protowire.AppendString(db, d.Requirement)
+			if d.Optional {
+				db = protowire.AppendTag(db, 3, protowire.VarintType)
+				db = protowire.AppendVarint(db, 1)
+			}
+			if d.App != "" {
+				db = protowire.AppendTag(db, 4, protowire.BytesType)
+				db = protowire.AppendString(db, d.App)
+			}
+			if d.Repository != "" {
+				db = protowire.AppendTag(db, 5, protowire.BytesType)
+				db = protowire.AppendString(db, d.Repository)
+			}
+			rb = protowire.AppendTag(rb, 3, protowire.BytesType)
+			rb = protowire.AppendBytes(rb, db)
+		}
+		if len(r.OuterChecksum) > 0 {
+			rb = protowire.AppendTag(rb, 5, protowire.BytesType)
+			rb = protowire.AppendBytes(rb, r.OuterChecksum)
+		}
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, rb)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendString(b, name)
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	return protowire.AppendString(b, repository)
+}
+
+// SignResource wraps the payload in the Signed message using a SHA-512 RSA signature of the PEM encoded private key and compresses it
+func SignResource(payload []byte, privateKey string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, errors.New("failed to decode private key pem")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha512.Sum512(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, payload)
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, signature)
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,382 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Atom is an Erlang atom
+type Atom string
+
+// Tuple is an Erlang tuple
+type Tuple []any
+
+// ParseTerms parses Erlang terms in the format of file:consult/1 which is used by the metadata.config file.
+// Binaries and strings are returned as string, lists as []any, maps as map[string]any and integers as int64.
+// https://github.com/hexpm/specifications/blob/main/package_tarball.md
+func ParseTerms(s string) ([]any, error) {
+	p := &termParser{s: s}
+
+	var terms []any
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return terms, nil
+		}
+
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipWhitespace()
+		if !p.consume(".") {
+			return nil, p.errorf("expected '.'")
+		}
+		terms = append(terms, term)
+	}
+}
+
+type termParser struct {
+	s   string
+	pos int
+}
+
+func (p *termParser) errorf(format string, args ...any) error {
+	return util.NewInvalidArgumentErrorf("invalid term at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *termParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *termParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *termParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.s[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *termParser) skipWhitespace() {
+	for !p.eof() {
+		c := p.peek()
+		if c == '%' {
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *termParser) parseTerm() (any, error) {
+	p.skipWhitespace()
+
+	switch c := p.peek(); {
+	case p.consume("<<"):
+		return p.parseBinary()
+	case c == '[':
+		p.pos++
+		items, err := p.parseSequence(']')
+		if err != nil {
+			return nil, err
+		}
+		return items, nil
+	case c == '{':
+		p.pos++
+		items, err := p.parseSequence('}')
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(items), nil
+	case p.consume("#{"):
+		return p.parseMap()
+	case c == '"':
+		return p.parseString('"')
+	case c == '\'':
+		s, err := p.parseString('\'')
+		if err != nil {
+			return nil, err
+		}
+		return Atom(s), nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseInteger()
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for !p.eof() {
+			c := p.peek()
+			if !(c == '_' || c == '@' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+				break
+			}
+			p.pos++
+		}
+		return Atom(p.s[start:p.pos]), nil
+	default:
+		return nil, p.errorf("unexpected character %q", c)
+	}
+}
+
+// parseSequence parses the comma separated items of a list or tuple
+func (p *termParser) parseSequence(end byte) ([]any, error) {
+	items := make([]any, 0, 5)
+
+	p.skipWhitespace()
+	if p.peek() == end {
+		p.pos++
+		return items, nil
+	}
+
+	for {
+		item, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		p.skipWhitespace()
+		if p.peek() == end {
+			p.pos++
+			return items, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' or %q", end)
+		}
+	}
+}
+
+func (p *termParser) parseMap() (any, error) {
+	m := make(map[string]any)
+
+	p.skipWhitespace()
+	if p.consume("}") {
+		return m, nil
+	}
+
+	for {
+		key, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		k, ok := termToString(key)
+		if !ok {
+			return nil, p.errorf("unsupported map key")
+		}
+
+		p.skipWhitespace()
+		if !p.consume("=>") {
+			return nil, p.errorf("expected '=>'")
+		}
+
+		value, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		m[k] = value
+
+		p.skipWhitespace()
+		if p.consume("}") {
+			return m, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+// parseBinary parses the content of a binary after the opening "<<"
+func (p *termParser) parseBinary() (any, error) {
+	p.skipWhitespace()
+	if p.consume(">>") {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	for {
+		p.skipWhitespace()
+		switch c := p.peek(); {
+		case c == '"':
+			s, err := p.parseString('"')
+			if err != nil {
+				return nil, err
+			}
+			sb.WriteString(s)
+			p.skipWhitespace()
+			p.consume("/utf8")
+		case c >= '0' && c <= '9':
+			n, err := p.parseInteger()
+			if err != nil {
+				return nil, err
+			}
+			if n > 255 {
+				return nil, p.errorf("byte value out of range")
+			}
+			sb.WriteByte(byte(n))
+		default:
+			return nil, p.errorf("unexpected character %q in binary", c)
+		}
+
+		p.skipWhitespace()
+		if p.consume(">>") {
+			return sb.String(), nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' or '>>'")
+		}
+	}
+}
+
+func (p *termParser) parseString(quote byte) (string, error) {
+	p.pos++ // opening quote
+
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+
+		c := p.s[p.pos]
+		switch c {
+		case quote:
+			p.pos++
+			return sb.String(), nil
+		case '\\':
+			p.pos++
+			if p.eof() {
+				return "", p.errorf("unterminated string")
+			}
+			e := p.s[p.pos]
+			p.pos++
+			switch e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 's':
+				sb.WriteByte(' ')
+			case 'e':
+				sb.WriteByte(0x1b)
+			case 'x':
+				// \xHH or \x{H...}
+				var digits string
+				if p.consume("{") {
+					end := strings.IndexByte(p.s[p.pos:], '}')
+					if end == -1 {
+						return "", p.errorf("invalid escape sequence")
+					}
+					digits = p.s[p.pos : p.pos+end]
+					p.pos += end + 1
+				} else if p.pos+2 <= len(p.s) {
+					digits = p.s[p.pos : p.pos+2]
+					p.pos += 2
+				}
+				r, err := strconv.ParseUint(digits, 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid escape sequence")
+				}
+				sb.WriteRune(rune(r))
+			default:
+				if e >= '0' && e <= '7' {
+					// octal escape with up to three digits
+					start := p.pos - 1
+					for p.pos < len(p.s) && p.pos-start < 3 && p.s[p.pos] >= '0' && p.s[p.pos] <= '7' {
+						p.pos++
+					}
+					r, _ := strconv.ParseUint(p.s[start:p.pos], 8, 32)
+					sb.WriteRune(rune(r))
+				} else {
+					sb.WriteByte(e)
+				}
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(p.s[p.pos:])
+			if r == utf8.RuneError && size == 1 {
+				return "", p.errorf("invalid UTF-8 encoding")
+			}
+			sb.WriteRune(r)
+			p.pos += size
+		}
+	}
+}
+
+func (p *termParser) parseInteger() (int64, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for !p.eof() && unicode.IsDigit(rune(p.peek())) {
+		p.pos++
+	}
+	n, err := strconv.ParseInt(p.s[start:p.pos], 10, 64)
+	if err != nil {
+		return 0, p.errorf("invalid integer")
+	}
+	return n, nil
+}
+
+func termToString(term any) (string, bool) {
+	switch t := term.(type) {
+	case string:
+		return t, true
+	case Atom:
+		return string(t), true
+	}
+	return "", false
+}
+
+// termToStringList converts a list of binaries
+func termToStringList(term any) []string {
+	list, ok := term.([]any)
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := termToString(item); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// termToProperties converts a proplist or a map into a map
+func termToProperties(term any) map[string]any {
+	switch t := term.(type) {
+	case map[string]any:
+		return t
+	case []any:
+		m := make(map[string]any, len(t))
+		for _, item := range t {
+			tuple, ok := item.(Tuple)
+			if !ok || len(tuple) != 2 {
+				continue
+			}
+			if key, ok := termToString(tuple[0]); ok {
+				m[key] = tuple[1]
+			}
+		}
+		return m
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTerms(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected []any
+	}{
+		{`<<"gitea">>.`, []any{"gitea"}},
+		{`<<>>.`, []any{""}},
+		{`<<"gïtea"/utf8>>.`, []any{"gïtea"}},
+		{`<<103,105>>.`, []any{"gi"}},
+		{`"a\"b\\c\n".`, []any{"a\"b\\c\n"}},
+		{`true. 'quoted atom'. -12.`, []any{Atom("true"), Atom("quoted atom"), int64(-12)}},
+		{`[]. [1, [2]].`, []any{[]any{}, []any{int64(1), []any{int64(2)}}}},
+		{`{<<"key">>, value}.`, []any{Tuple{"key", Atom("value")}}},
+		{"% comment\n#{<<\"a\">> => 1, b => []}.", []any{map[string]any{"a": int64(1), "b": []any{}}}},
+	}
+
+	for _, c := range cases {
+		terms, err := ParseTerms(c.Input)
+		assert.NoError(t, err, c.Input)
+		assert.Equal(t, c.Expected, terms, c.Input)
+	}
+
+	for _, input := range []string{`<<"gitea">>`, `[1,`, `{a b}.`, `"unterminated.`, `#{1 => 2}.`, `<<256>>.`} {
+		_, err := ParseTerms(input)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, input)
+	}
+}
+
+func TestEncodeTerm(t *testing.T) {
+	cases := []struct {
+		Input    any
+		Expected []byte
+	}{
+		{nil, []byte{131, 119, 3, 'n', 'i', 'l'}},
+		{true, []byte{131, 119, 4, 't', 'r', 'u', 'e'}},
+		{"ab", []byte{131, 109, 0, 0, 0, 2, 'a', 'b'}},
+		{1, []byte{131, 97, 1}},
+		{1000, []byte{131, 98, 0, 0, 3, 232}},
+		{-1, []byte{131, 98, 255, 255, 255, 255}},
+		{int64(1) << 40, []byte{131, 110, 6, 0, 0, 0, 0, 0, 0, 1}},
+		{[]any{}, []byte{131, 106}},
+		{[]string{"a"}, []byte{131, 108, 0, 0, 0, 1, 109, 0, 0, 0, 1, 'a', 106}},
+		{map[string]any{"b": 2, "a": 1}, []byte{131, 116, 0, 0, 0, 2, 109, 0, 0, 0, 1, 'a', 97, 1, 109, 0, 0, 0, 1, 'b', 97, 2}},
+	}
+
+	for _, c := range cases {
+		b, err := EncodeTerm(c.Input)
+		assert.NoError(t, err)
+		assert.Equal(t, c.Expected, b, "%v", c.Input)
+	}
+
+	_, err := EncodeTerm(struct{}{})
+	assert.Error(t, err)
+}
@@ -216,47 +216,18 @@ func ParsePackage(r io.Reader) (*Package, error) {
 			return nil, ErrInvalidPackageVersion
 		}
 
-		scope := ""
-		name := meta.Name
-		nameParts := strings.SplitN(meta.Name, "/", 2)
-		if len(nameParts) == 2 {
-			scope = nameParts[0]
-			name = nameParts[1]
-		}
-
-		if !validation.IsValidURL(meta.Homepage) {
-			meta.Homepage = ""
-		}
-
 		p := &Package{
 			Name:     meta.Name,
 			Version:  v.String(),
 			DistTags: make([]string, 0, 1),
-			Metadata: Metadata{
-				Scope:                   scope,
-				Name:                    name,
-				Description:             meta.Description,
-				Author:                  meta.Author.Name,
-				License:                 meta.License,
-				ProjectURL:              meta.Homepage,
-				Keywords:                meta.Keywords,
-				Dependencies:            meta.Dependencies,
-				BundleDependencies:      meta.BundleDependencies,
-				DevelopmentDependencies: meta.DevDependencies,
-				PeerDependencies:        meta.PeerDependencies,
-				PeerDependenciesMeta:    meta.PeerDependenciesMeta,
-				OptionalDependencies:    meta.OptionalDependencies,
-				Bin:                     meta.Bin,
-				Readme:                  meta.Readme,
-				Repository:              meta.Repository,
-			},
+			Metadata: NewMetadata(meta),
 		}
 
 		for tag := range upload.DistTags {
 			p.DistTags = append(p.DistTags, tag)
 		}
 
-		p.Filename = strings.ToLower(fmt.Sprintf("%s-%s.tgz", name, p.Version))
+		p.Filename = strings.ToLower(fmt.Sprintf("%s-%s.tgz", p.Metadata.Name, p.Version))
 
 		attachment := func() *PackageAttachment {
 			for _, a := range upload.Attachments {
@@ -310,3 +281,38 @@ func validateName(name string) bool {
 	}
 	return nameMatch.MatchString(name)
 }
+
+// NewMetadata creates the metadata stored for the version of a package
+func NewMetadata(meta *PackageMetadataVersion) Metadata {
+	scope := ""
+	name := meta.Name
+	nameParts := strings.SplitN(meta.Name, "/", 2)
+	if len(nameParts) == 2 {
+		scope = nameParts[0]
+		name = nameParts[1]
+	}
+
+	homepage := meta.Homepage
+	if !validation.IsValidURL(homepage) {
+		homepage = ""
+	}
+
+	return Metadata{
+		Scope:                   scope,
+		Name:                    name,
+		Description:             meta.Description,
+		Author:                  meta.Author.Name,
+		License:                 meta.License,
+		ProjectURL:              homepage,
+		Keywords:                meta.Keywords,
+		Dependencies:            meta.Dependencies,
+		BundleDependencies:      meta.BundleDependencies,
+		DevelopmentDependencies: meta.DevDependencies,
+		PeerDependencies:        meta.PeerDependencies,
+		PeerDependenciesMeta:    meta.PeerDependenciesMeta,
+		OptionalDependencies:    meta.OptionalDependencies,
+		Bin:                     meta.Bin,
+		Readme:                  meta.Readme,
+		Repository:              meta.Repository,
+	}
+}
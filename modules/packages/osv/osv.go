@@ -0,0 +1,282 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package osv
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/hashicorp/go-version"
+)
+
+// https://ossf.github.io/osv-schema/
+
+// Ecosystems of the package types which can be scanned
+const (
+	EcosystemCargo     = "crates.io"
+	EcosystemComposer  = "Packagist"
+	EcosystemMaven     = "Maven"
+	EcosystemNpm       = "npm"
+	EcosystemPyPI      = "PyPI"
+	rangeTypeSemver    = "SEMVER"
+	rangeTypeEcosystem = "ECOSYSTEM"
+)
+
+var ErrInvalidDatabase = util.NewInvalidArgumentErrorf("invalid vulnerability database")
+
+// Vulnerability is an entry of the OSV database
+type Vulnerability struct {
+	ID               string           `json:"id"`
+	Modified         time.Time        `json:"modified"`
+	Withdrawn        *time.Time       `json:"withdrawn,omitempty"`
+	Aliases          []string         `json:"aliases,omitempty"`
+	Summary          string           `json:"summary,omitempty"`
+	Details          string           `json:"details,omitempty"`
+	Affected         []*Affected      `json:"affected,omitempty"`
+	DatabaseSpecific DatabaseSpecific `json:"database_specific"`
+}
+
+// DatabaseSpecific contains the fields of the database specific section which are used
+type DatabaseSpecific struct {
+	Severity string `json:"severity,omitempty"`
+}
+
+// Affected describes the affected versions of a package
+type Affected struct {
+	Package  AffectedPackage `json:"package"`
+	Ranges   []*Range        `json:"ranges,omitempty"`
+	Versions []string        `json:"versions,omitempty"`
+}
+
+// AffectedPackage identifies the affected package
+type AffectedPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+// Range is a range of affected versions described by events
+type Range struct {
+	Type   string   `json:"type"`
+	Events []*Event `json:"events"`
+}
+
+// Event is a version at which the affected state changes
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Severity returns the normalized severity of the vulnerability or "" if the database does not provide one
+func (v *Vulnerability) Severity() string {
+	switch s := strings.ToUpper(v.DatabaseSpecific.Severity); s {
+	case "MODERATE":
+		return "MEDIUM"
+	case "LOW", "MEDIUM", "HIGH", "CRITICAL":
+		return s
+	}
+	return ""
+}
+
+// ReadDatabase reads the vulnerabilities of an OSV database file and calls fn for every entry.
+// The file can be a zip archive containing a JSON file per vulnerability like the official exports,
+// a JSON file with a single vulnerability or a JSON array of vulnerabilities.
+func ReadDatabase(r io.ReaderAt, size int64, fn func(*Vulnerability) error) error {
+	magic := make([]byte, 4)
+	if _, err := r.ReadAt(magic, 0); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	if bytes.Equal(magic, []byte("PK\x03\x04")) {
+		zr, err := zip.NewReader(r, size)
+		if err != nil {
+			return ErrInvalidDatabase
+		}
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".json") {
+				continue
+			}
+			if err := readZipEntry(f, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return readJSON(io.NewSectionReader(r, 0, size), fn)
+}
+
+func readZipEntry(f *zip.File, fn func(*Vulnerability) error) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return readJSON(rc, fn)
+}
+
+func readJSON(r io.Reader, fn func(*Vulnerability) error) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var vs []*Vulnerability
+		if err := json.Unmarshal(data, &vs); err != nil {
+			return ErrInvalidDatabase
+		}
+		for _, v := range vs {
+			if err := handleVulnerability(v, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var v *Vulnerability
+	if err := json.Unmarshal(data, &v); err != nil {
+		return ErrInvalidDatabase
+	}
+	return handleVulnerability(v, fn)
+}
+
+func handleVulnerability(v *Vulnerability, fn func(*Vulnerability) error) error {
+	if v == nil || v.ID == "" {
+		return ErrInvalidDatabase
+	}
+	return fn(v)
+}
+
+var pypiNameReplacer = regexp.MustCompile(`[-_.]+`)
+
+// NormalizeName normalizes a package name of the ecosystem so that names can be compared
+func NormalizeName(ecosystem, name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if ecosystem == EcosystemPyPI {
+		// https://peps.python.org/pep-0503/#normalized-names
+		name = pypiNameReplacer.ReplaceAllString(name, "-")
+	}
+	return name
+}
+
+// IsAffected checks if the version is affected
+func (a *Affected) IsAffected(v string) bool {
+	for _, av := range a.Versions {
+		if av == v {
+			return true
+		}
+	}
+
+	pv, err := version.NewVersion(v)
+	if err != nil {
+		return false
+	}
+
+	for _, r := range a.Ranges {
+		if r.contains(pv) {
+			return true
+		}
+	}
+	return false
+}
+
+// FixedVersion returns the lowest version which fixes the affected version or "" if there is none
+func (a *Affected) FixedVersion(v string) string {
+	pv, err := version.NewVersion(v)
+	if err != nil {
+		return ""
+	}
+
+	var fixed *version.Version
+	var fixedRaw string
+	for _, r := range a.Ranges {
+		if !r.contains(pv) {
+			continue
+		}
+		for _, e := range r.Events {
+			if e.Fixed == "" {
+				continue
+			}
+			fv, err := version.NewVersion(e.Fixed)
+			if err != nil || !fv.GreaterThan(pv) {
+				continue
+			}
+			if fixed == nil || fv.LessThan(fixed) {
+				fixed = fv
+				fixedRaw = e.Fixed
+			}
+		}
+	}
+	return fixedRaw
+}
+
+type parsedEvent struct {
+	event   *Event
+	version *version.Version
+}
+
+// contains evaluates the events of the range in version order, the state of the last event which is not above the version decides
+func (r *Range) contains(v *version.Version) bool {
+	if r.Type != rangeTypeSemver && r.Type != rangeTypeEcosystem {
+		return false
+	}
+
+	events := make([]*parsedEvent, 0, len(r.Events))
+	for _, e := range r.Events {
+		var raw string
+		switch {
+		case e.Introduced != "":
+			raw = e.Introduced
+		case e.Fixed != "":
+			raw = e.Fixed
+		case e.LastAffected != "":
+			raw = e.LastAffected
+		default:
+			continue
+		}
+
+		ev, err := version.NewVersion(raw)
+		if err != nil {
+			continue
+		}
+		events = append(events, &parsedEvent{e, ev})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].version.LessThan(events[j].version)
+	})
+
+	affected := false
+	for _, e := range events {
+		switch {
+		case e.event.Introduced != "":
+			if !v.LessThan(e.version) {
+				affected = true
+			}
+		case e.event.Fixed != "":
+			if !v.LessThan(e.version) {
+				affected = false
+			}
+		case e.event.LastAffected != "":
+			if v.GreaterThan(e.version) {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
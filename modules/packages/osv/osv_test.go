@@ -0,0 +1,138 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package osv
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vulnerabilityContent = `{
+  "id": "GHSA-test-0001",
+  "modified": "2024-01-02T03:04:05Z",
+  "summary": "Prototype pollution",
+  "affected": [
+    {
+      "package": {"ecosystem": "npm", "name": "lodash"},
+      "ranges": [
+        {"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.12"}]}
+      ]
+    }
+  ],
+  "database_specific": {"severity": "MODERATE"}
+}`
+
+func TestReadDatabase(t *testing.T) {
+	read := func(t *testing.T, content []byte) []*Vulnerability {
+		var vs []*Vulnerability
+		err := ReadDatabase(bytes.NewReader(content), int64(len(content)), func(v *Vulnerability) error {
+			vs = append(vs, v)
+			return nil
+		})
+		require.NoError(t, err)
+		return vs
+	}
+
+	t.Run("Object", func(t *testing.T) {
+		vs := read(t, []byte(vulnerabilityContent))
+		assert.Len(t, vs, 1)
+		assert.Equal(t, "GHSA-test-0001", vs[0].ID)
+		assert.Equal(t, "MEDIUM", vs[0].Severity())
+		assert.Equal(t, "lodash", vs[0].Affected[0].Package.Name)
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		vs := read(t, []byte("  ["+vulnerabilityContent+","+strings.Replace(vulnerabilityContent, "0001", "0002", 1)+"]"))
+		assert.Len(t, vs, 2)
+		assert.Equal(t, "GHSA-test-0002", vs[1].ID)
+	})
+
+	t.Run("Zip", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create("GHSA-test-0001.json")
+		w.Write([]byte(vulnerabilityContent))
+		w, _ = zw.Create("README.md")
+		w.Write([]byte("not a vulnerability"))
+		zw.Close()
+
+		vs := read(t, buf.Bytes())
+		assert.Len(t, vs, 1)
+		assert.Equal(t, "GHSA-test-0001", vs[0].ID)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []string{"{", `{"summary":"missing id"}`, "[1]"} {
+			err := ReadDatabase(strings.NewReader(content), int64(len(content)), func(v *Vulnerability) error {
+				return nil
+			})
+			assert.ErrorIs(t, err, ErrInvalidDatabase)
+		}
+	})
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "zope-interface", NormalizeName(EcosystemPyPI, "Zope.Interface"))
+	assert.Equal(t, "zope-interface", NormalizeName(EcosystemPyPI, "zope__interface"))
+	assert.Equal(t, "@scope/name", NormalizeName(EcosystemNpm, "@Scope/Name"))
+}
+
+func TestAffected(t *testing.T) {
+	a := &Affected{
+		Ranges: []*Range{
+			{
+				Type: rangeTypeEcosystem,
+				Events: []*Event{
+					{Introduced: "2.0.0"},
+					{Fixed: "2.3.1"},
+					{Introduced: "1.0.0"},
+					{Fixed: "1.4.0"},
+				},
+			},
+			{
+				Type: rangeTypeSemver,
+				Events: []*Event{
+					{Introduced: "3.0.0"},
+					{LastAffected: "3.2.0"},
+				},
+			},
+			{
+				Type: "GIT",
+				Events: []*Event{
+					{Introduced: "0"},
+				},
+			},
+		},
+		Versions: []string{"0.9-beta"},
+	}
+
+	cases := []struct {
+		Version  string
+		Affected bool
+		Fixed    string
+	}{
+		{"0.5.0", false, ""},
+		{"0.9-beta", true, ""},
+		{"1.0.0", true, "1.4.0"},
+		{"1.3.9", true, "1.4.0"},
+		{"1.4.0", false, ""},
+		{"2.1.0", true, "2.3.1"},
+		{"2.3.1", false, ""},
+		{"3.0.0", true, ""},
+		{"3.2.0", true, ""},
+		{"3.2.1", false, ""},
+		{"invalid", false, ""},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.Affected, a.IsAffected(c.Version), "version %s", c.Version)
+		if c.Affected {
+			assert.Equal(t, c.Fixed, a.FixedVersion(c.Version), "version %s", c.Version)
+		}
+	}
+}
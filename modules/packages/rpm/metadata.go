@@ -19,8 +19,11 @@ const (
 	PropertyGroup        = "rpm.group"
 	PropertyArchitecture = "rpm.architecture"
 
-	SettingKeyPrivate = "rpm.key.private"
-	SettingKeyPublic  = "rpm.key.public"
+	SettingKeyPrivate         = "rpm.key.private"
+	SettingKeyPublic          = "rpm.key.public"
+	SettingPreviousKeyPrivate = "rpm.key.previous.private"
+	SettingPreviousKeyPublic  = "rpm.key.previous.public"
+	SettingPreviousKeyExpires = "rpm.key.previous.expires"
 
 	RepositoryPackage = "_rpm"
 	RepositoryVersion = "_repository"
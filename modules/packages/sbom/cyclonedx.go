@@ -0,0 +1,115 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/google/uuid"
+)
+
+// https://cyclonedx.org/docs/1.5/json/
+
+const cycloneDXSpecVersion = "1.5"
+
+type cycloneDXDocument struct {
+	BOMFormat    string                 `json:"bomFormat"`
+	SpecVersion  string                 `json:"specVersion"`
+	SerialNumber string                 `json:"serialNumber"`
+	Version      int                    `json:"version"`
+	Metadata     cycloneDXMetadata      `json:"metadata"`
+	Components   []*cycloneDXComponent  `json:"components"`
+	Dependencies []*cycloneDXDependency `json:"dependencies"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string              `json:"timestamp"`
+	Tools     cycloneDXTools      `json:"tools"`
+	Component *cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTools struct {
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type       string               `json:"type"`
+	BOMRef     string               `json:"bom-ref,omitempty"`
+	Name       string               `json:"name"`
+	Version    string               `json:"version,omitempty"`
+	Scope      string               `json:"scope,omitempty"`
+	PURL       string               `json:"purl,omitempty"`
+	Licenses   []*cycloneDXLicense  `json:"licenses,omitempty"`
+	Properties []*cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	License cycloneDXLicenseName `json:"license"`
+}
+
+type cycloneDXLicenseName struct {
+	Name string `json:"name"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+func toCycloneDXComponent(c *Component) *cycloneDXComponent {
+	cc := &cycloneDXComponent{
+		Type:    "library",
+		BOMRef:  c.PURL,
+		Name:    c.Name,
+		Version: c.Version,
+		Scope:   c.Scope,
+		PURL:    c.PURL,
+	}
+	if c.License != "" {
+		cc.Licenses = []*cycloneDXLicense{{License: cycloneDXLicenseName{Name: c.License}}}
+	}
+	if c.Requirement != "" {
+		cc.Properties = []*cycloneDXProperty{{Name: "gitea:requirement", Value: c.Requirement}}
+	}
+	return cc
+}
+
+// CycloneDX creates the CycloneDX JSON representation of the document
+func (d *Document) CycloneDX() ([]byte, error) {
+	root := toCycloneDXComponent(d.Root)
+	root.Scope = ""
+
+	doc := &cycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  cycloneDXSpecVersion,
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: d.Created.UTC().Format(time.RFC3339),
+			Tools: cycloneDXTools{
+				Components: []*cycloneDXComponent{{Type: "application", Name: d.ToolName, Version: d.ToolVersion}},
+			},
+			Component: root,
+		},
+		Components:   make([]*cycloneDXComponent, 0, len(d.Dependencies)),
+		Dependencies: make([]*cycloneDXDependency, 0, len(d.Dependencies)+1),
+	}
+
+	refs := make([]string, 0, len(d.Dependencies))
+	for _, c := range d.Dependencies {
+		doc.Components = append(doc.Components, toCycloneDXComponent(c))
+		doc.Dependencies = append(doc.Dependencies, &cycloneDXDependency{Ref: c.PURL, DependsOn: []string{}})
+		refs = append(refs, c.PURL)
+	}
+	doc.Dependencies = append([]*cycloneDXDependency{{Ref: root.BOMRef, DependsOn: refs}}, doc.Dependencies...)
+
+	return json.MarshalIndent(doc, "", "  ")
+}
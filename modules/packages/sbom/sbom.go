@@ -0,0 +1,179 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Formats of the generated documents
+const (
+	FormatCycloneDX = "cyclonedx"
+	FormatSPDX      = "spdx"
+)
+
+var ErrUnsupportedFormat = util.NewInvalidArgumentErrorf("unsupported software bill of materials format")
+
+// Scopes of a dependency
+const (
+	ScopeRequired = "required"
+	ScopeOptional = "optional"
+)
+
+// Component is a package described by a software bill of materials
+type Component struct {
+	Ecosystem string
+	Name      string
+	// Version is the concrete version of the component, it is empty if it could not be resolved from the requirement
+	Version     string
+	Requirement string
+	PURL        string
+	License     string
+	Scope       string
+}
+
+// Document is a software bill of materials of a package version and its declared dependencies
+type Document struct {
+	Namespace    string
+	ToolName     string
+	ToolVersion  string
+	Created      time.Time
+	Root         *Component
+	Dependencies []*Component
+}
+
+// Encode creates the representation of the document in the format
+func (d *Document) Encode(format string) ([]byte, error) {
+	switch format {
+	case FormatCycloneDX:
+		return d.CycloneDX()
+	case FormatSPDX:
+		return d.SPDX()
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// ContentType returns the media type of the format
+func ContentType(format string) string {
+	switch format {
+	case FormatCycloneDX:
+		return "application/vnd.cyclonedx+json"
+	case FormatSPDX:
+		return "application/spdx+json"
+	}
+	return "application/json"
+}
+
+// FileExtension returns the conventional file extension of the format
+func FileExtension(format string) string {
+	switch format {
+	case FormatCycloneDX:
+		return ".cdx.json"
+	case FormatSPDX:
+		return ".spdx.json"
+	}
+	return ".json"
+}
+
+// PackageURL creates a package url
+// https://github.com/package-url/purl-spec
+func PackageURL(purlType, namespace, name, version string) string {
+	var sb strings.Builder
+	sb.WriteString("pkg:")
+	sb.WriteString(purlType)
+	sb.WriteByte('/')
+	if namespace != "" {
+		for _, segment := range strings.Split(namespace, "/") {
+			sb.WriteString(escapePURLSegment(segment))
+			sb.WriteByte('/')
+		}
+	}
+	sb.WriteString(escapePURLSegment(name))
+	if version != "" {
+		sb.WriteByte('@')
+		sb.WriteString(escapePURLSegment(version))
+	}
+	return sb.String()
+}
+
+func escapePURLSegment(s string) string {
+	// the @ separates the version and must be encoded in the other parts
+	return strings.ReplaceAll(url.PathEscape(s), "@", "%40")
+}
+
+// MinimumVersion returns the lowest version allowed by the version requirement or "" if it can't be determined.
+// Dependencies are declared with requirements, the lowest matching version is the one a package is guaranteed to accept.
+func MinimumVersion(requirement string) string {
+	req := strings.TrimSpace(requirement)
+	if req == "" {
+		return ""
+	}
+
+	// Maven version ranges like [1.0,2.0) or [1.0]
+	if req[0] == '[' || req[0] == '(' {
+		if req[0] == '(' {
+			return ""
+		}
+		lower, _, _ := strings.Cut(req[1:], ",")
+		return cleanVersion(strings.TrimRight(lower, "])"))
+	}
+
+	// the first alternative has the lowest versions
+	req, _, _ = strings.Cut(req, "|")
+
+	fields := strings.FieldsFunc(req, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	for i := 0; i < len(fields); i++ {
+		part := fields[i]
+		if isOperator(part) && i+1 < len(fields) {
+			i++
+			part += fields[i]
+		}
+
+		idx := strings.IndexFunc(part, func(r rune) bool {
+			return !strings.ContainsRune(operatorChars, r)
+		})
+		if idx == -1 {
+			continue
+		}
+
+		op := part[:idx]
+		switch op {
+		case "", "=", "==", "===", "^", "~", "~=", "~>", ">=":
+			if v := cleanVersion(part[len(op):]); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+const operatorChars = "<>=!~^"
+
+func isOperator(s string) bool {
+	return s != "" && strings.Trim(s, operatorChars) == ""
+}
+
+// cleanVersion removes prefixes, stability flags and wildcards from a version
+func cleanVersion(v string) string {
+	v = strings.TrimSpace(v)
+	v, _, _ = strings.Cut(v, "@")
+	v = strings.TrimPrefix(strings.TrimPrefix(v, "v"), "V")
+	if v == "" || v[0] < '0' || v[0] > '9' {
+		return ""
+	}
+
+	parts := strings.Split(v, ".")
+	for i, p := range parts {
+		if p == "*" || p == "x" || p == "X" {
+			parts[i] = "0"
+		}
+	}
+	return strings.Join(parts, ".")
+}
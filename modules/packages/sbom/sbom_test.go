@@ -0,0 +1,113 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinimumVersion(t *testing.T) {
+	cases := map[string]string{
+		"":                 "",
+		"1.2.3":            "1.2.3",
+		"v1.2.3":           "1.2.3",
+		"^1.2.3":           "1.2.3",
+		"~1.2":             "1.2",
+		">=1.0, <2.0":      "1.0",
+		"< 2.0 >= 1.5":     "1.5",
+		">= 1.5":           "1.5",
+		"~=2.2":            "2.2",
+		"==2.31.0":         "2.31.0",
+		">1.0":             "",
+		"!=1.0":            "",
+		"1.2.x":            "1.2.0",
+		"1.*":              "1.0",
+		"*":                "",
+		"latest":           "",
+		"^1.0 || ^2.0":     "1.0",
+		"^7.4|^8.0":        "7.4",
+		"1.0.0@dev":        "1.0.0",
+		"[1.0,2.0)":        "1.0",
+		"[1.5]":            "1.5",
+		"(1.0,2.0]":        "",
+		"git+https://x/y":  "",
+		"npm:other@1.0.0":  "",
+		"file:../local":    "",
+		"1.2.3 - 2.0.0":    "1.2.3",
+		"~> 3.1":           "3.1",
+		"workspace:*":      "",
+		"${project.value}": "",
+	}
+	for requirement, expected := range cases {
+		assert.Equal(t, expected, MinimumVersion(requirement), "requirement %q", requirement)
+	}
+}
+
+func TestPackageURL(t *testing.T) {
+	assert.Equal(t, "pkg:npm/%40scope/name@1.0.0", PackageURL("npm", "@scope", "name", "1.0.0"))
+	assert.Equal(t, "pkg:maven/org.example/artifact@2.0", PackageURL("maven", "org.example", "artifact", "2.0"))
+	assert.Equal(t, "pkg:cargo/serde", PackageURL("cargo", "", "serde", ""))
+}
+
+func TestDocument(t *testing.T) {
+	doc := &Document{
+		Namespace:   "https://gitea.example/sbom/1",
+		ToolName:    "Gitea",
+		ToolVersion: "1.0",
+		Created:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Root:        &Component{Name: "root", Version: "1.0.0", PURL: "pkg:npm/root@1.0.0", License: "MIT", Scope: ScopeRequired},
+		Dependencies: []*Component{
+			{Name: "dep", Version: "2.0.0", Requirement: "^2.0.0", PURL: "pkg:npm/dep@2.0.0", Scope: ScopeRequired},
+			{Name: "opt", Requirement: "latest", PURL: "pkg:npm/opt", Scope: ScopeOptional},
+		},
+	}
+
+	t.Run("CycloneDX", func(t *testing.T) {
+		content, err := doc.Encode(FormatCycloneDX)
+		require.NoError(t, err)
+
+		var result cycloneDXDocument
+		require.NoError(t, json.Unmarshal(content, &result))
+
+		assert.Equal(t, "CycloneDX", result.BOMFormat)
+		assert.Equal(t, "2026-01-02T03:04:05Z", result.Metadata.Timestamp)
+		assert.Equal(t, "root", result.Metadata.Component.Name)
+		assert.Equal(t, "MIT", result.Metadata.Component.Licenses[0].License.Name)
+		assert.Len(t, result.Components, 2)
+		assert.Equal(t, "2.0.0", result.Components[0].Version)
+		assert.Equal(t, ScopeOptional, result.Components[1].Scope)
+		assert.Equal(t, "pkg:npm/root@1.0.0", result.Dependencies[0].Ref)
+		assert.Equal(t, []string{"pkg:npm/dep@2.0.0", "pkg:npm/opt"}, result.Dependencies[0].DependsOn)
+	})
+
+	t.Run("SPDX", func(t *testing.T) {
+		content, err := doc.Encode(FormatSPDX)
+		require.NoError(t, err)
+
+		var result spdxDocument
+		require.NoError(t, json.Unmarshal(content, &result))
+
+		assert.Equal(t, "SPDX-2.3", result.SPDXVersion)
+		assert.Equal(t, "https://gitea.example/sbom/1", result.DocumentNamespace)
+		assert.Len(t, result.Packages, 3)
+		assert.Equal(t, "MIT", result.Packages[0].LicenseDeclared)
+		assert.Equal(t, "NOASSERTION", result.Packages[1].LicenseDeclared)
+		assert.Equal(t, "pkg:npm/dep@2.0.0", result.Packages[1].ExternalRefs[0].ReferenceLocator)
+		assert.Len(t, result.Relationships, 3)
+		assert.Equal(t, "DESCRIBES", result.Relationships[0].RelationshipType)
+		assert.Equal(t, "DEPENDS_ON", result.Relationships[1].RelationshipType)
+		assert.Equal(t, "OPTIONAL_DEPENDENCY_OF", result.Relationships[2].RelationshipType)
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		_, err := doc.Encode("unknown")
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"fmt"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+)
+
+// https://spdx.github.io/spdx-spec/v2.3/
+
+const (
+	spdxVersion     = "SPDX-2.3"
+	spdxNoAssertion = "NOASSERTION"
+	spdxDocumentID  = "SPDXRef-DOCUMENT"
+)
+
+type spdxDocument struct {
+	SPDXVersion       string              `json:"spdxVersion"`
+	DataLicense       string              `json:"dataLicense"`
+	SPDXID            string              `json:"SPDXID"`
+	Name              string              `json:"name"`
+	DocumentNamespace string              `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo    `json:"creationInfo"`
+	Packages          []*spdxPackage      `json:"packages"`
+	Relationships     []*spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string             `json:"name"`
+	SPDXID           string             `json:"SPDXID"`
+	VersionInfo      string             `json:"versionInfo,omitempty"`
+	DownloadLocation string             `json:"downloadLocation"`
+	FilesAnalyzed    bool               `json:"filesAnalyzed"`
+	LicenseDeclared  string             `json:"licenseDeclared"`
+	Comment          string             `json:"comment,omitempty"`
+	ExternalRefs     []*spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+func toSPDXPackage(c *Component, id string) *spdxPackage {
+	p := &spdxPackage{
+		Name:             c.Name,
+		SPDXID:           id,
+		VersionInfo:      c.Version,
+		DownloadLocation: spdxNoAssertion,
+		LicenseDeclared:  spdxNoAssertion,
+	}
+	if c.License != "" {
+		p.LicenseDeclared = c.License
+	}
+	if c.Requirement != "" {
+		p.Comment = "Version requirement: " + c.Requirement
+	}
+	if c.PURL != "" {
+		p.ExternalRefs = []*spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: c.PURL}}
+	}
+	return p
+}
+
+// SPDX creates the SPDX JSON representation of the document
+func (d *Document) SPDX() ([]byte, error) {
+	const rootID = "SPDXRef-Package"
+
+	doc := &spdxDocument{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            spdxDocumentID,
+		Name:              d.Root.Name,
+		DocumentNamespace: d.Namespace,
+		CreationInfo: spdxCreationInfo{
+			Created:  d.Created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + d.ToolName + "-" + d.ToolVersion},
+		},
+		Packages:      make([]*spdxPackage, 0, len(d.Dependencies)+1),
+		Relationships: make([]*spdxRelationship, 0, len(d.Dependencies)+1),
+	}
+
+	doc.Packages = append(doc.Packages, toSPDXPackage(d.Root, rootID))
+	doc.Relationships = append(doc.Relationships, &spdxRelationship{SPDXElementID: spdxDocumentID, RelationshipType: "DESCRIBES", RelatedSPDXElement: rootID})
+
+	for i, c := range d.Dependencies {
+		id := fmt.Sprintf("SPDXRef-Dependency-%d", i+1)
+		doc.Packages = append(doc.Packages, toSPDXPackage(c, id))
+
+		if c.Scope == ScopeOptional {
+			doc.Relationships = append(doc.Relationships, &spdxRelationship{SPDXElementID: id, RelationshipType: "OPTIONAL_DEPENDENCY_OF", RelatedSPDXElement: rootID})
+		} else {
+			doc.Relationships = append(doc.Relationships, &spdxRelationship{SPDXElementID: rootID, RelationshipType: "DEPENDS_ON", RelatedSPDXElement: id})
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
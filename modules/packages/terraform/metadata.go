@@ -0,0 +1,178 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	PropertyOS   = "terraform.os"
+	PropertyArch = "terraform.arch"
+
+	SettingKeyPrivate = "terraform.key.private"
+	SettingKeyPublic  = "terraform.key.public"
+)
+
+// Kind is the kind of a Terraform package
+type Kind string
+
+const (
+	KindModule   Kind = "module"
+	KindProvider Kind = "provider"
+)
+
+// DefaultProtocols are the plugin protocols of a provider which has no manifest file
+var DefaultProtocols = []string{"5.0"}
+
+var (
+	// ErrInvalidName indicates an invalid name
+	ErrInvalidName = util.NewInvalidArgumentErrorf("name is invalid")
+	// ErrInvalidFilename indicates an invalid provider file name
+	ErrInvalidFilename = util.NewInvalidArgumentErrorf("file name is invalid")
+	// ErrMissingConfiguration indicates a module archive without Terraform configuration files
+	ErrMissingConfiguration = util.NewInvalidArgumentErrorf("module archive contains no configuration files")
+	// ErrInvalidManifest indicates an invalid provider manifest file
+	ErrInvalidManifest = util.NewInvalidArgumentErrorf("manifest file is invalid")
+)
+
+var (
+	// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#module-addresses
+	namePattern = regexp.MustCompile(`\A[0-9A-Za-z](?:[0-9A-Za-z-_]{0,62}[0-9A-Za-z])?\z`)
+	// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#provider-addresses
+	providerTypePattern = regexp.MustCompile(`\A[0-9a-z](?:[0-9a-z-]{0,62}[0-9a-z])?\z`)
+
+	providerArchivePattern  = regexp.MustCompile(`\Aterraform-provider-([0-9a-z-]+)_([^_/]+)_([0-9a-z]+)_([0-9a-z]+)\.zip\z`)
+	providerManifestPattern = regexp.MustCompile(`\Aterraform-provider-([0-9a-z-]+)_([^_/]+)_manifest\.json\z`)
+)
+
+// Metadata represents the metadata of a Terraform module or provider
+type Metadata struct {
+	Kind      Kind     `json:"kind"`
+	Readme    string   `json:"readme,omitempty"`
+	Protocols []string `json:"protocols,omitempty"`
+}
+
+// IsValidName checks if the name is a valid namespace, module name or target system
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// IsValidProviderType checks if the name is a valid provider type
+func IsValidProviderType(name string) bool {
+	return providerTypePattern.MatchString(name)
+}
+
+// ModuleFilename returns the name of the archive file of a module version
+func ModuleFilename(name, system, version string) string {
+	return strings.ToLower(name + "-" + system + "-" + version + ".tar.gz")
+}
+
+// ProviderArchive represents the parts of the name of a provider archive
+type ProviderArchive struct {
+	Type    string
+	Version string
+	OS      string
+	Arch    string
+}
+
+// ParseProviderArchiveFilename parses the name of a provider archive
+// which follows the format terraform-provider-{type}_{version}_{os}_{arch}.zip
+func ParseProviderArchiveFilename(filename string) (*ProviderArchive, error) {
+	m := providerArchivePattern.FindStringSubmatch(filename)
+	if m == nil {
+		return nil, ErrInvalidFilename
+	}
+	return &ProviderArchive{
+		Type:    m[1],
+		Version: m[2],
+		OS:      m[3],
+		Arch:    m[4],
+	}, nil
+}
+
+// IsProviderManifestFilename checks if the file name is the manifest of the provider version
+func IsProviderManifestFilename(filename, providerType, version string) bool {
+	m := providerManifestPattern.FindStringSubmatch(filename)
+	return m != nil && m[1] == providerType && m[2] == version
+}
+
+// ProviderShasumsFilename returns the name of the checksums file of a provider version
+func ProviderShasumsFilename(providerType, version string) string {
+	return "terraform-provider-" + providerType + "_" + version + "_SHA256SUMS"
+}
+
+// ParseProviderManifest parses the terraform-registry-manifest.json file of a provider and returns the supported plugin protocols
+// https://developer.hashicorp.com/terraform/registry/providers/publishing#terraform-registry-manifest-file
+func ParseProviderManifest(r io.Reader) ([]string, error) {
+	var manifest struct {
+		Version  int `json:"version"`
+		Metadata struct {
+			ProtocolVersions []string `json:"protocol_versions"`
+		} `json:"metadata"`
+	}
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return nil, ErrInvalidManifest
+	}
+	if manifest.Version != 1 || len(manifest.Metadata.ProtocolVersions) == 0 {
+		return nil, ErrInvalidManifest
+	}
+	return manifest.Metadata.ProtocolVersions, nil
+}
+
+// ParseModuleArchive parses the tar.gz archive of a module and extracts the metadata
+func ParseModuleArchive(r io.Reader) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+
+	metadata := &Metadata{
+		Kind: KindModule,
+	}
+
+	hasConfiguration := false
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := strings.TrimPrefix(path.Clean(hd.Name), "./")
+		lowerName := strings.ToLower(name)
+		if strings.HasSuffix(lowerName, ".tf") || strings.HasSuffix(lowerName, ".tf.json") {
+			hasConfiguration = true
+		} else if lowerName == "readme.md" {
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			metadata.Readme = string(data)
+		}
+	}
+
+	if !hasConfiguration {
+		return nil, ErrMissingConfiguration
+	}
+	return metadata, nil
+}
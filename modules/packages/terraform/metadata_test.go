@@ -0,0 +1,114 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const readme = "# Module\n\nModule description"
+
+func TestIsValidName(t *testing.T) {
+	for _, name := range []string{"a", "name", "Name-1", "name_2", "a1"} {
+		assert.True(t, IsValidName(name), name)
+	}
+	for _, name := range []string{"", "-name", "name-", "_name", "na/me", "na.me", strings.Repeat("a", 65)} {
+		assert.False(t, IsValidName(name), name)
+	}
+
+	assert.True(t, IsValidProviderType("my-provider"))
+	assert.False(t, IsValidProviderType("My-Provider"))
+	assert.False(t, IsValidProviderType("my_provider"))
+}
+
+func TestParseProviderArchiveFilename(t *testing.T) {
+	pa, err := ParseProviderArchiveFilename("terraform-provider-my-provider_1.2.3-beta.1_linux_amd64.zip")
+	assert.NoError(t, err)
+	assert.Equal(t, &ProviderArchive{Type: "my-provider", Version: "1.2.3-beta.1", OS: "linux", Arch: "amd64"}, pa)
+
+	for _, filename := range []string{
+		"terraform-provider-test_1.2.3_linux.zip",
+		"terraform-provider-test_1.2.3_linux_amd64.tar.gz",
+		"provider-test_1.2.3_linux_amd64.zip",
+		"terraform-provider-test_1.2.3_SHA256SUMS",
+	} {
+		_, err := ParseProviderArchiveFilename(filename)
+		assert.ErrorIs(t, err, ErrInvalidFilename, filename)
+	}
+
+	assert.True(t, IsProviderManifestFilename("terraform-provider-test_1.2.3_manifest.json", "test", "1.2.3"))
+	assert.False(t, IsProviderManifestFilename("terraform-provider-test_1.2.3_manifest.json", "test", "1.2.4"))
+	assert.False(t, IsProviderManifestFilename("terraform-provider-other_1.2.3_manifest.json", "test", "1.2.3"))
+
+	assert.Equal(t, "terraform-provider-test_1.2.3_SHA256SUMS", ProviderShasumsFilename("test", "1.2.3"))
+}
+
+func TestParseProviderManifest(t *testing.T) {
+	protocols, err := ParseProviderManifest(strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"6.0"}, protocols)
+
+	for _, content := range []string{
+		"",
+		"{}",
+		`{"version":2,"metadata":{"protocol_versions":["6.0"]}}`,
+		`{"version":1,"metadata":{"protocol_versions":[]}}`,
+	} {
+		_, err := ParseProviderManifest(strings.NewReader(content))
+		assert.ErrorIs(t, err, ErrInvalidManifest, content)
+	}
+}
+
+func TestParseModuleArchive(t *testing.T) {
+	createArchive := func(files map[string]string) io.Reader {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for filename, content := range files {
+			hdr := &tar.Header{
+				Name: filename,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			}
+			tw.WriteHeader(hdr)
+			tw.Write([]byte(content))
+		}
+		tw.Close()
+		zw.Close()
+		return &buf
+	}
+
+	t.Run("InvalidArchive", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(strings.NewReader("dummy"))
+		assert.Nil(t, metadata)
+		assert.Error(t, err)
+	})
+
+	t.Run("MissingConfiguration", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(createArchive(map[string]string{"README.md": readme}))
+		assert.Nil(t, metadata)
+		assert.ErrorIs(t, err, ErrMissingConfiguration)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		metadata, err := ParseModuleArchive(createArchive(map[string]string{
+			"./README.md":            readme,
+			"main.tf":                `variable "name" {}`,
+			"modules/sub/README.md":  "sub module",
+			"modules/sub/main.tf":    "",
+			"examples/basic/main.tf": "",
+		}))
+		assert.NoError(t, err)
+		assert.NotNil(t, metadata)
+		assert.Equal(t, KindModule, metadata.Kind)
+		assert.Equal(t, readme, metadata.Readme)
+	})
+}
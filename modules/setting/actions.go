@@ -25,8 +25,15 @@ var (
 		ZombieTaskTimeout     time.Duration     `ini:"ZOMBIE_TASK_TIMEOUT"`
 		EndlessTaskTimeout    time.Duration     `ini:"ENDLESS_TASK_TIMEOUT"`
 		AbandonedJobTimeout   time.Duration     `ini:"ABANDONED_JOB_TIMEOUT"`
+		ApprovalGateTimeout   time.Duration     `ini:"APPROVAL_GATE_TIMEOUT"`
+		MaxQueueTime          time.Duration     `ini:"MAX_QUEUE_TIME"`
 		SkipWorkflowStrings   []string          `ini:"SKIP_WORKFLOW_STRINGS"`
 		WorkflowDirs          []string          `ini:"WORKFLOW_DIRS"`
+		IDTokenExpiration     time.Duration     `ini:"ID_TOKEN_EXPIRATION"`
+		EphemeralTokenExpiry  time.Duration     `ini:"EPHEMERAL_TOKEN_EXPIRY"`
+		CacheStorage          *Storage          // how the caches of actions/cache should be stored
+		CacheRetentionDays    int64             `ini:"CACHE_RETENTION_DAYS"`
+		CacheRepoSizeLimit    int64             `ini:"-"`
 	}{
 		Enabled:             true,
 		DefaultActionsURL:   defaultActionsURLGitHub,
@@ -114,9 +121,27 @@ func loadActionsFrom(rootCfg ConfigProvider) error {
 		Actions.ArtifactRetentionDays = 90
 	}
 
+	Actions.CacheStorage, err = getStorage(rootCfg, "actions_cache", "", nil)
+	if err != nil {
+		return err
+	}
+	// the caches which haven't been accessed for 7 days are evicted in Github Actions
+	if Actions.CacheRetentionDays <= 0 {
+		Actions.CacheRetentionDays = 7
+	}
+	// default to 10 GiB per repository in Github Actions, -1 means no limit
+	Actions.CacheRepoSizeLimit = 10 * 1024 * 1024 * 1024
+	if sec.HasKey("CACHE_REPO_SIZE_LIMIT") {
+		Actions.CacheRepoSizeLimit = mustBytes(sec, "CACHE_REPO_SIZE_LIMIT")
+	}
+
 	Actions.ZombieTaskTimeout = sec.Key("ZOMBIE_TASK_TIMEOUT").MustDuration(10 * time.Minute)
 	Actions.EndlessTaskTimeout = sec.Key("ENDLESS_TASK_TIMEOUT").MustDuration(3 * time.Hour)
 	Actions.AbandonedJobTimeout = sec.Key("ABANDONED_JOB_TIMEOUT").MustDuration(24 * time.Hour)
+	Actions.ApprovalGateTimeout = sec.Key("APPROVAL_GATE_TIMEOUT").MustDuration(24 * time.Hour)
+	Actions.MaxQueueTime = sec.Key("MAX_QUEUE_TIME").MustDuration(0)
+	Actions.IDTokenExpiration = sec.Key("ID_TOKEN_EXPIRATION").MustDuration(10 * time.Minute)
+	Actions.EphemeralTokenExpiry = sec.Key("EPHEMERAL_TOKEN_EXPIRY").MustDuration(time.Hour)
 
 	if !Actions.LogCompression.IsValid() {
 		return fmt.Errorf("invalid [actions] LOG_COMPRESSION: %q", Actions.LogCompression)
@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+)
+
+// TerraformState settings of the Terraform http state backend
+var TerraformState = struct {
+	Enabled      bool
+	Storage      *Storage
+	MaxVersions  int   `ini:"MAX_VERSIONS"`
+	MaxStateSize int64 `ini:"-"`
+}{
+	Enabled:      true,
+	MaxVersions:  100,
+	MaxStateSize: 50 * 1024 * 1024,
+}
+
+func loadTerraformStateFrom(rootCfg ConfigProvider) (err error) {
+	sec, _ := rootCfg.GetSection("terraform_state")
+	if sec != nil {
+		if err = sec.MapTo(&TerraformState); err != nil {
+			return fmt.Errorf("failed to map TerraformState settings: %v", err)
+		}
+		if sec.HasKey("MAX_STATE_SIZE") {
+			TerraformState.MaxStateSize = mustBytes(sec, "MAX_STATE_SIZE")
+		}
+	}
+
+	TerraformState.Storage, err = getStorage(rootCfg, "terraform_state", "", sec)
+	return err
+}
@@ -138,6 +138,11 @@ var (
 	Actions ObjectStorage = uninitializedStorage
 	// Actions Artifacts represents actions artifacts storage
 	ActionsArtifacts ObjectStorage = uninitializedStorage
+	// ActionsCaches represents the storage of the caches of actions/cache
+	ActionsCaches ObjectStorage = uninitializedStorage
+
+	// TerraformStates represents the storage of the states of the Terraform http backend
+	TerraformStates ObjectStorage = uninitializedStorage
 )
 
 // Init init the storage
@@ -150,6 +155,7 @@ func Init() error {
 		initRepoArchives,
 		initPackages,
 		initActions,
+		initTerraformStates,
 	} {
 		if err := f(); err != nil {
 			return err
@@ -223,6 +229,7 @@ func initActions() (err error) {
 	if !setting.Actions.Enabled {
 		Actions = discardStorage("Actions isn't enabled")
 		ActionsArtifacts = discardStorage("ActionsArtifacts isn't enabled")
+		ActionsCaches = discardStorage("ActionsCaches isn't enabled")
 		return nil
 	}
 	log.Info("Initialising Actions storage with type: %s", setting.Actions.LogStorage.Type)
@@ -230,6 +237,20 @@ func initActions() (err error) {
 		return err
 	}
 	log.Info("Initialising ActionsArtifacts storage with type: %s", setting.Actions.ArtifactStorage.Type)
-	ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage)
+	if ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage); err != nil {
+		return err
+	}
+	log.Info("Initialising ActionsCaches storage with type: %s", setting.Actions.CacheStorage.Type)
+	ActionsCaches, err = NewStorage(setting.Actions.CacheStorage.Type, setting.Actions.CacheStorage)
+	return err
+}
+
+func initTerraformStates() (err error) {
+	if !setting.TerraformState.Enabled {
+		TerraformStates = discardStorage("TerraformState isn't enabled")
+		return nil
+	}
+	log.Info("Initialising TerraformStates storage with type: %s", setting.TerraformState.Storage.Type)
+	TerraformStates, err = NewStorage(setting.TerraformState.Storage.Type, setting.TerraformState.Storage)
 	return err
 }
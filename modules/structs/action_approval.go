@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// ActionApproval represents an approval gate of an attempt of a workflow job
+// swagger:model
+type ActionApproval struct {
+	ID      int64  `json:"id"`
+	RunID   int64  `json:"run_id"`
+	JobID   int64  `json:"job_id"`
+	JobName string `json:"job_name"`
+	Attempt int64  `json:"attempt"`
+	// the message of the approval gate defined by the job
+	Message string `json:"message"`
+	// the status of the job
+	Status string `json:"status"`
+	// the state of the approval gate, one of waiting, approved, rejected and timed_out
+	State         string `json:"state"`
+	Reviewer      *User  `json:"reviewer,omitempty"`
+	ReviewComment string `json:"review_comment,omitempty"`
+	// swagger:strfmt date-time
+	Reviewed *time.Time `json:"reviewed_at,omitempty"`
+	// the time when the approval gate times out if nobody reviews it
+	// swagger:strfmt date-time
+	Deadline time.Time `json:"deadline"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// ReviewActionApprovalOption options when reviewing the approval gate of a workflow job
+// swagger:model
+type ReviewActionApprovalOption struct {
+	// either approved or rejected
+	//
+	// required: true
+	// enum: approved,rejected
+	State string `json:"state" binding:"Required;In(approved,rejected)"`
+	// comment of the review
+	Comment string `json:"comment"`
+}
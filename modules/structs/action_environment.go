@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// ActionEnvironment represents a deployment environment of a repository
+// swagger:model
+type ActionEnvironment struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// glob patterns of the branches which could be deployed to the environment
+	BranchFilters []string `json:"branch_filters"`
+	// glob patterns of the tags which could be deployed to the environment
+	TagFilters []string `json:"tag_filters"`
+	// the users who could approve the deployments to the environment
+	RequiredReviewers []*User `json:"required_reviewers"`
+	// whether the user who triggered the run is prevented from approving its deployments
+	PreventSelfReview bool `json:"prevent_self_review"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateOrUpdateActionEnvironmentOption options when creating or updating a deployment environment
+// swagger:model
+type CreateOrUpdateActionEnvironmentOption struct {
+	// glob patterns of the branches which could be deployed to the environment,
+	// all refs could be deployed if both branch_filters and tag_filters are empty
+	BranchFilters []string `json:"branch_filters"`
+	// glob patterns of the tags which could be deployed to the environment
+	TagFilters []string `json:"tag_filters"`
+	// names of the users who could approve the deployments, one approval is required if it is not empty
+	RequiredReviewers []string `json:"required_reviewers"`
+	// prevent the user who triggered the run from approving its deployments
+	PreventSelfReview bool `json:"prevent_self_review"`
+}
+
+// ActionDeployment represents a deployment of a workflow job to an environment
+// swagger:model
+type ActionDeployment struct {
+	ID          int64  `json:"id"`
+	Environment string `json:"environment"`
+	RunID       int64  `json:"run_id"`
+	JobID       int64  `json:"job_id"`
+	Attempt     int64  `json:"attempt"`
+	Ref         string `json:"ref"`
+	CommitSHA   string `json:"sha"`
+	// the url of the environment defined by the job
+	URL string `json:"url"`
+	// the status of the job
+	Status string `json:"status"`
+	// the review state of the deployment, one of none, waiting, approved and rejected
+	ReviewState   string `json:"review_state"`
+	Reviewer      *User  `json:"reviewer,omitempty"`
+	ReviewComment string `json:"review_comment,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// ReviewActionDeploymentsOption options when reviewing the pending deployments of a workflow run
+// swagger:model
+type ReviewActionDeploymentsOption struct {
+	// ids of the environments to review
+	//
+	// required: true
+	EnvironmentIDs []int64 `json:"environment_ids" binding:"Required"`
+	// either approved or rejected
+	//
+	// required: true
+	// enum: approved,rejected
+	State string `json:"state" binding:"Required;In(approved,rejected)"`
+	// comment of the review
+	Comment string `json:"comment"`
+}
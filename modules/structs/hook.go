@@ -642,3 +642,19 @@ type WorkflowJobPayload struct {
 func (p *WorkflowJobPayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
+
+// WorkflowJobQueuePayload represents a payload information of the jobs of an owner waiting for runners,
+// it's sent periodically while there are queued jobs, so it could be used to scale runners.
+type WorkflowJobQueuePayload struct {
+	// The owner of the queued jobs
+	Owner *User `json:"owner"`
+	// The organization that owns the queued jobs (if applicable)
+	Organization *Organization `json:"organization,omitempty"`
+	// The queued jobs grouped by their `runs-on` labels
+	QueuedJobs []*ActionQueuedJobs `json:"queued_jobs"`
+}
+
+// JSONPayload implements Payload
+func (p *WorkflowJobQueuePayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
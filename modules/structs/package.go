@@ -47,3 +47,22 @@ type PackageFile struct {
 	// The SHA512 hash of the package file
 	HashSHA512 string `json:"sha512"`
 }
+
+// PackageVulnerability represents a known vulnerability in a dependency of a package
+type PackageVulnerability struct {
+	// The identifier of the advisory
+	AdvisoryID string `json:"advisory_id"`
+	// The name of the affected dependency
+	DependencyName string `json:"dependency_name"`
+	// The version of the affected dependency
+	DependencyVersion string `json:"dependency_version"`
+	// The lowest version of the dependency which fixes the vulnerability
+	FixedVersion string `json:"fixed_version"`
+	// The summary of the advisory
+	Summary string `json:"summary"`
+	// The severity of the vulnerability (LOW, MEDIUM, HIGH or CRITICAL)
+	Severity string `json:"severity"`
+	// swagger:strfmt date-time
+	// The date and time when the vulnerability was found
+	FoundAt time.Time `json:"found_at"`
+}
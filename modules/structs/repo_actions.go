@@ -146,6 +146,33 @@ type ActionArtifactsResponse struct {
 	TotalCount int64             `json:"total_count"`
 }
 
+// ActionCache represents a cache of actions/cache
+type ActionCache struct {
+	ID           int64  `json:"id"`
+	RepositoryID int64  `json:"repository_id"`
+	Ref          string `json:"ref"`
+	Key          string `json:"key"`
+	Version      string `json:"version"`
+	SizeInBytes  int64  `json:"size_in_bytes"`
+	// swagger:strfmt date-time
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ActionCachesResponse returns ActionCaches
+type ActionCachesResponse struct {
+	Entries    []*ActionCache `json:"actions_caches"`
+	TotalCount int64          `json:"total_count"`
+}
+
+// ActionCacheUsage represents the cache usage of a repository
+type ActionCacheUsage struct {
+	FullName                string `json:"full_name"`
+	ActiveCachesSizeInBytes int64  `json:"active_caches_size_in_bytes"`
+	ActiveCachesCount       int64  `json:"active_caches_count"`
+}
+
 // ActionWorkflowStep represents a step of a WorkflowJob
 type ActionWorkflowStep struct {
 	Name       string `json:"name"`
@@ -158,20 +185,32 @@ type ActionWorkflowStep struct {
 	CompletedAt time.Time `json:"completed_at"`
 }
 
+// ActionJobSummary represents the Markdown summary written by a step of a WorkflowJob
+type ActionJobSummary struct {
+	StepNumber int64  `json:"step_number"`
+	StepName   string `json:"step_name"`
+	// Content is the Markdown content written by the step to `GITHUB_STEP_SUMMARY`
+	Content string `json:"content"`
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // ActionWorkflowJob represents a WorkflowJob
 type ActionWorkflowJob struct {
-	ID         int64                 `json:"id"`
-	URL        string                `json:"url"`
-	HTMLURL    string                `json:"html_url"`
-	RunID      int64                 `json:"run_id"`
-	RunURL     string                `json:"run_url"`
-	Name       string                `json:"name"`
-	Labels     []string              `json:"labels"`
-	RunAttempt int64                 `json:"run_attempt"`
-	HeadSha    string                `json:"head_sha"`
-	HeadBranch string                `json:"head_branch,omitempty"`
-	Status     string                `json:"status"`
-	Conclusion string                `json:"conclusion,omitempty"`
+	ID         int64    `json:"id"`
+	URL        string   `json:"url"`
+	HTMLURL    string   `json:"html_url"`
+	RunID      int64    `json:"run_id"`
+	RunURL     string   `json:"run_url"`
+	Name       string   `json:"name"`
+	Labels     []string `json:"labels"`
+	RunAttempt int64    `json:"run_attempt"`
+	HeadSha    string   `json:"head_sha"`
+	HeadBranch string   `json:"head_branch,omitempty"`
+	Status     string   `json:"status"`
+	Conclusion string   `json:"conclusion,omitempty"`
+	// StopReason is the reason why the job has been stopped by the server, "timeout" or "queue_timeout"
+	StopReason string                `json:"stop_reason,omitempty"`
 	RunnerID   int64                 `json:"runner_id,omitempty"`
 	RunnerName string                `json:"runner_name,omitempty"`
 	Steps      []*ActionWorkflowStep `json:"steps"`
@@ -199,6 +238,8 @@ type ActionRunner struct {
 	Disabled  bool                 `json:"disabled"`
 	Ephemeral bool                 `json:"ephemeral"`
 	Labels    []*ActionRunnerLabel `json:"labels"`
+	// RunnerGroupID is the runner group of the runner, 0 means the default group
+	RunnerGroupID int64 `json:"runner_group_id"`
 }
 
 // EditActionRunnerOption represents the editable fields for a runner.
@@ -208,6 +249,77 @@ type EditActionRunnerOption struct {
 	Disabled *bool `json:"disabled"`
 }
 
+// ActionRunnerGroup represents a group of the runners of an organization
+type ActionRunnerGroup struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Repositories are the names of the repositories whose jobs could be run by the runners of the group, empty means all repositories
+	Repositories []string `json:"repositories"`
+	// Workflows are the file names of the workflows whose jobs could be run by the runners of the group, empty means all workflows
+	Workflows []string `json:"workflows"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateActionRunnerGroupOption represents the options to create a runner group
+// swagger:model
+type CreateActionRunnerGroupOption struct {
+	// required: true
+	Name        string `json:"name" binding:"Required;MaxSize(255)"`
+	Description string `json:"description"`
+	// Repositories are the names of the repositories whose jobs could be run by the runners of the group, empty means all repositories
+	Repositories []string `json:"repositories"`
+	// Workflows are the file names of the workflows whose jobs could be run by the runners of the group, empty means all workflows
+	Workflows []string `json:"workflows"`
+}
+
+// EditActionRunnerGroupOption represents the options to edit a runner group, the fields which are not set are not changed
+// swagger:model
+type EditActionRunnerGroupOption struct {
+	Name         *string   `json:"name" binding:"MaxSize(255)"`
+	Description  *string   `json:"description"`
+	Repositories *[]string `json:"repositories"`
+	Workflows    *[]string `json:"workflows"`
+}
+
+// ActionQueueLimit represents the max time jobs could wait for a runner before they are cancelled
+type ActionQueueLimit struct {
+	// MaxQueueMinutes is the limit configured at this level, 0 means to inherit the limit of the upper level
+	MaxQueueMinutes int64 `json:"max_queue_minutes"`
+	// EffectiveMaxQueueMinutes is the limit applied to the jobs after inheriting, 0 means no limit
+	EffectiveMaxQueueMinutes int64 `json:"effective_max_queue_minutes"`
+}
+
+// EditActionQueueLimitOption represents the options to update the max queue time
+// swagger:model
+type EditActionQueueLimitOption struct {
+	// MaxQueueMinutes is the max time in minutes jobs could wait for a runner, 0 means to inherit the limit of the upper level
+	// required: true
+	MaxQueueMinutes *int64 `json:"max_queue_minutes"`
+}
+
+// ActionQueuedJobs represents the jobs of an owner which are waiting for runners with the same labels
+type ActionQueuedJobs struct {
+	Owner *User `json:"owner"`
+	// Labels are the `runs-on` labels required by the jobs
+	Labels []string `json:"labels"`
+	// Count is the number of the jobs
+	Count int64 `json:"count"`
+	// swagger:strfmt date-time
+	OldestQueuedAt time.Time `json:"oldest_queued_at"`
+	// WaitSeconds is how long the oldest job has been waiting for a runner
+	WaitSeconds int64 `json:"wait_seconds"`
+}
+
+// ActionQueuedJobsResponse returns the queued jobs grouped by owners and labels
+type ActionQueuedJobsResponse struct {
+	Entries    []*ActionQueuedJobs `json:"queued_jobs"`
+	TotalCount int64               `json:"total_count"`
+}
+
 // ActionRunnersResponse returns Runners
 type ActionRunnersResponse struct {
 	Entries    []*ActionRunner `json:"runners"`
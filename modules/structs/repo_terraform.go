@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// TerraformState represents a state of the Terraform http backend of a repository or an owner
+// swagger:model
+type TerraformState struct {
+	Name string `json:"name"`
+	// the number of the latest version, 0 if the state has been locked but never written
+	LatestVersion int64 `json:"latest_version"`
+	Locked        bool  `json:"locked"`
+	// the lock info sent by the Terraform run holding the lock
+	LockInfo string `json:"lock_info,omitempty"`
+	Locker   *User  `json:"locker,omitempty"`
+	// swagger:strfmt date-time
+	LockedAt *time.Time `json:"locked_at,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// TerraformStateVersion represents a version in the history of a Terraform state
+// swagger:model
+type TerraformStateVersion struct {
+	Version int64 `json:"version"`
+	// the serial of the state document
+	Serial int64 `json:"serial"`
+	// the lineage of the state document
+	Lineage string `json:"lineage"`
+	Size    int64  `json:"size"`
+	Creator *User  `json:"creator,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
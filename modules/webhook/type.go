@@ -40,6 +40,8 @@ const (
 	HookEventSchedule    HookEventType = "schedule"
 	HookEventWorkflowRun HookEventType = "workflow_run"
 	HookEventWorkflowJob HookEventType = "workflow_job"
+	// HookEventWorkflowJobQueue is only sent to the webhooks of users, organizations and the system
+	HookEventWorkflowJobQueue HookEventType = "workflow_job_queue"
 )
 
 func AllEvents() []HookEventType {
@@ -70,6 +72,7 @@ func AllEvents() []HookEventType {
 		HookEventStatus,
 		HookEventWorkflowRun,
 		HookEventWorkflowJob,
+		HookEventWorkflowJobQueue,
 	}
 }
 
@@ -20,5 +20,8 @@ func Routes(prefix string) *web.Router {
 	path, handler = runner.NewRunnerServiceHandler()
 	m.Post(path+"*", http.StripPrefix(prefix, handler).ServeHTTP)
 
+	registerOIDCRoutes(m)
+	registerSummaryRoutes(m)
+
 	return m
 }
@@ -0,0 +1,482 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+// GitHub Actions Cache API Simple Description
+//
+// The caches are scoped by the repository and the ref of the run which created them.
+// A run could restore the caches of its own ref, the base branch of the pull request and the default branch.
+//
+// 1. V1 API, used when ACTIONS_CACHE_URL points to /api/actions_cache/
+// 1.1. Query a cache
+// GET: /api/actions_cache/_apis/artifactcache/cache?keys=key1,prefix2&version=xxx
+// Response: 204 if no cache matches, or
+// {
+//     "cacheKey": "key1",
+//     "scope": "refs/heads/main",
+//     "creationTime": "2026-01-23T00:13:28Z",
+//     "archiveLocation": "http://localhost:3000/api/actions_cache/_apis/artifactcache/artifacts/1?sig=xxx&expires=xxx"
+// }
+// 1.2. Reserve a cache, 409 if the cache exists
+// POST: /api/actions_cache/_apis/artifactcache/caches
+// Request:
+// {
+//     "key": "key1",
+//     "version": "xxx",
+//     "cacheSize": 1024
+// }
+// Response:
+// {
+//     "cacheId": 1
+// }
+// 1.3. Upload the chunks of the cache with the header "Content-Range: bytes 0-1023/*"
+// PATCH: /api/actions_cache/_apis/artifactcache/caches/1
+// 1.4. Commit the cache
+// POST: /api/actions_cache/_apis/artifactcache/caches/1
+// Request:
+// {
+//     "size": 1024
+// }
+// 1.5. Download the cache (unauthenticated request)
+// GET: /api/actions_cache/_apis/artifactcache/artifacts/1?sig=xxx&expires=xxx
+//
+// 2. V2 API, used when ACTIONS_CACHE_SERVICE_V2 is set, it's a twirp service under ACTIONS_RESULTS_URL
+// 2.1. CreateCacheEntry
+// POST: /twirp/github.actions.results.api.v1.CacheService/CreateCacheEntry
+// Request:
+// {
+//     "key": "key1",
+//     "version": "xxx"
+// }
+// Response:
+// {
+//     "ok": true,
+//     "signedUploadUrl": "http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/UploadCache?sig=xxx&expires=xxx&cacheID=1"
+// }
+// 2.2. Upload the cache to the signed url like Azure Blob Storage (unauthenticated request),
+// in a single request or by blocks with "comp=block&blockid=xxx" and a block list with "comp=blocklist"
+// 2.3. FinalizeCacheEntryUpload
+// POST: /twirp/github.actions.results.api.v1.CacheService/FinalizeCacheEntryUpload
+// Request:
+// {
+//     "key": "key1",
+//     "version": "xxx",
+//     "sizeBytes": "1024"
+// }
+// Response:
+// {
+//     "ok": true,
+//     "entryId": "1"
+// }
+// 2.4. GetCacheEntryDownloadURL
+// POST: /twirp/github.actions.results.api.v1.CacheService/GetCacheEntryDownloadURL
+// Request:
+// {
+//     "key": "key1",
+//     "restoreKeys": ["prefix2"],
+//     "version": "xxx"
+// }
+// Response:
+// {
+//     "ok": true,
+//     "signedDownloadUrl": "http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/DownloadCache?sig=xxx&expires=xxx&cacheID=1",
+//     "matchedKey": "key1"
+// }
+// 2.5. Download the cache (unauthenticated request)
+// GET: /twirp/github.actions.results.api.v1.CacheService/DownloadCache?sig=xxx&expires=xxx&cacheID=1
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+)
+
+const (
+	CacheRouteBase   = "/_apis/artifactcache"
+	CacheV2RouteBase = "/twirp/github.actions.results.api.v1.CacheService"
+)
+
+type cacheRoutes struct {
+	prefix string
+}
+
+// CacheRoutes serves the v1 cache API of actions/cache
+func CacheRoutes(prefix string) *web.Router {
+	m := web.NewRouter()
+
+	r := cacheRoutes{prefix: prefix}
+
+	m.Group(CacheRouteBase, func() {
+		m.Group("", func() {
+			m.Get("/cache", r.getCacheEntry)
+			m.Post("/caches", r.reserveCache)
+			m.Combo("/caches/{cache_id}").Patch(r.uploadCacheChunk).Post(r.commitCache)
+		}, ArtifactContexter())
+		m.Get("/artifacts/{cache_id}", ArtifactV4Contexter(), r.downloadCache)
+	})
+
+	return m
+}
+
+// CacheV2Routes serves the v2 cache API of actions/cache
+func CacheV2Routes(prefix string) *web.Router {
+	m := web.NewRouter()
+
+	r := cacheRoutes{prefix: prefix}
+
+	m.Group("", func() {
+		m.Post("CreateCacheEntry", r.createCacheEntry)
+		m.Post("FinalizeCacheEntryUpload", r.finalizeCacheEntryUpload)
+		m.Post("GetCacheEntryDownloadURL", r.getCacheEntryDownloadURL)
+	}, ArtifactContexter())
+	m.Group("", func() {
+		m.Put("UploadCache", r.uploadCacheV2)
+		m.Get("DownloadCache", r.downloadCacheV2)
+	}, ArtifactV4Contexter())
+
+	return m
+}
+
+func (r *cacheRoutes) buildSignature(endpoint, expires string, cacheID int64) []byte {
+	mac := hmac.New(sha256.New, setting.GetGeneralTokenSigningSecret())
+	mac.Write([]byte(endpoint))
+	mac.Write([]byte(expires))
+	_, _ = fmt.Fprint(mac, cacheID)
+	return mac.Sum(nil)
+}
+
+func (r *cacheRoutes) buildSignedQuery(endpoint string, cacheID int64) string {
+	expires := time.Now().Add(60 * time.Minute).Format("2006-01-02 15:04:05.999999999 -0700 MST")
+	return "sig=" + base64.RawURLEncoding.EncodeToString(r.buildSignature(endpoint, expires, cacheID)) +
+		"&expires=" + url.QueryEscape(expires)
+}
+
+func (r *cacheRoutes) buildURL(ctx *ArtifactContext, path string) string {
+	return strings.TrimSuffix(httplib.GuessCurrentAppURL(ctx), "/") + strings.TrimSuffix(r.prefix, "/") + path
+}
+
+// verifySignature checks the signed url and returns the cache it signs
+func (r *cacheRoutes) verifySignature(ctx *ArtifactContext, endpoint string, cacheID int64) (*actions_model.ActionCache, bool) {
+	sig, err := base64.RawURLEncoding.DecodeString(ctx.Req.URL.Query().Get("sig"))
+	if err != nil {
+		ctx.HTTPError(http.StatusBadRequest, "Error decoding signature")
+		return nil, false
+	}
+	expires := ctx.Req.URL.Query().Get("expires")
+	if !hmac.Equal(sig, r.buildSignature(endpoint, expires, cacheID)) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error unauthorized")
+		return nil, false
+	}
+	t, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", expires)
+	if err != nil || t.Before(time.Now()) {
+		ctx.HTTPError(http.StatusUnauthorized, "Error link expired")
+		return nil, false
+	}
+	cache, err := actions_model.GetCacheByID(ctx, cacheID)
+	if err != nil {
+		r.handleError(ctx, err)
+		return nil, false
+	}
+	return cache, true
+}
+
+func (r *cacheRoutes) handleError(ctx *ArtifactContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.HTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, util.ErrNotExist):
+		ctx.HTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.HTTPError(http.StatusConflict, err.Error())
+	default:
+		log.Error("Error handling cache request: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error handling cache request")
+	}
+}
+
+func (r *cacheRoutes) getRun(ctx *ArtifactContext) (*actions_model.ActionRun, bool) {
+	if err := ctx.ActionTask.Job.LoadRun(ctx); err != nil {
+		r.handleError(ctx, err)
+		return nil, false
+	}
+	return ctx.ActionTask.Job.Run, true
+}
+
+// getWritableCache returns the cache in path which could be uploaded by the run
+func (r *cacheRoutes) getWritableCache(ctx *ArtifactContext, run *actions_model.ActionRun) (*actions_model.ActionCache, bool) {
+	cache, err := actions_model.GetCacheByRepoAndID(ctx, run.RepoID, ctx.PathParamInt64("cache_id"))
+	if err != nil {
+		r.handleError(ctx, err)
+		return nil, false
+	}
+	if cache.Ref != run.Ref || cache.Complete {
+		ctx.HTTPError(http.StatusBadRequest, "Cache is not writable")
+		return nil, false
+	}
+	return cache, true
+}
+
+func (r *cacheRoutes) serveCache(ctx *ArtifactContext, cache *actions_model.ActionCache) {
+	obj, err := actions_service.OpenCache(cache)
+	if err != nil {
+		r.handleError(ctx, err)
+		return
+	}
+	defer obj.Close()
+
+	ctx.ServeContent(obj, &context.ServeHeaderOptions{
+		Filename:     fmt.Sprintf("cache-%d.tzst", cache.ID),
+		LastModified: cache.Created.AsLocalTime(),
+	})
+}
+
+type cacheEntryV1 struct {
+	CacheKey        string    `json:"cacheKey"`
+	Scope           string    `json:"scope"`
+	CreationTime    time.Time `json:"creationTime"`
+	ArchiveLocation string    `json:"archiveLocation"`
+}
+
+func (r *cacheRoutes) getCacheEntry(ctx *ArtifactContext) {
+	run, ok := r.getRun(ctx)
+	if !ok {
+		return
+	}
+	var keys []string
+	for key := range strings.SplitSeq(ctx.Req.URL.Query().Get("keys"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	cache, err := actions_service.FindCacheToRestore(ctx, run, keys, ctx.Req.URL.Query().Get("version"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.Status(http.StatusNoContent)
+		return
+	} else if err != nil {
+		r.handleError(ctx, err)
+		return
+	}
+
+	endpoint := "artifacts/" + strconv.FormatInt(cache.ID, 10)
+	ctx.JSON(http.StatusOK, &cacheEntryV1{
+		CacheKey:        cache.CacheKey,
+		Scope:           cache.Ref,
+		CreationTime:    cache.Created.AsTime(),
+		ArchiveLocation: r.buildURL(ctx, CacheRouteBase+"/"+endpoint+"?"+r.buildSignedQuery(endpoint, cache.ID)),
+	})
+}
+
+type reserveCacheRequestV1 struct {
+	Key       string `json:"key"`
+	Version   string `json:"version"`
+	CacheSize int64  `json:"cacheSize"`
+}
+
+type reserveCacheResponseV1 struct {
+	CacheID int64 `json:"cacheId"`
+}
+
+func (r *cacheRoutes) reserveCache(ctx *ArtifactContext) {
+	run, ok := r.getRun(ctx)
+	if !ok {
+		return
+	}
+	var req reserveCacheRequestV1
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&req); err != nil {
+		ctx.HTTPError(http.StatusBadRequest, "Error decode request body")
+		return
+	}
+	cache, err := actions_service.ReserveCache(ctx, run, req.Key, req.Version, req.CacheSize)
+	if err != nil {
+		r.handleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, &reserveCacheResponseV1{CacheID: cache.ID})
+}
+
+func (r *cacheRoutes) uploadCacheChunk(ctx *ArtifactContext) {
+	run, ok := r.getRun(ctx)
+	if !ok {
+		return
+	}
+	cache, ok := r.getWritableCache(ctx, run)
+	if !ok {
+		return
+	}
+
+	var start, end int64
+	if _, err := fmt.Sscanf(ctx.Req.Header.Get("Content-Range"), "bytes %d-%d/", &start, &end); err != nil || start < 0 || end < start {
+		ctx.HTTPError(http.StatusBadRequest, "Invalid Content-Range header")
+		return
+	}
+	if err := actions_service.UploadCacheChunk(cache, start, ctx.Req.Body, end-start+1); err != nil {
+		r.handleError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+type commitCacheRequestV1 struct {
+	Size int64 `json:"size"`
+}
+
+func (r *cacheRoutes) commitCache(ctx *ArtifactContext) {
+	run, ok := r.getRun(ctx)
+	if !ok {
+		return
+	}
+	cache, ok := r.getWritableCache(ctx, run)
+	if !ok {
+		return
+	}
+	var req commitCacheRequestV1
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&req); err != nil {
+		ctx.HTTPError(http.StatusBadRequest, "Error decode request body")
+		return
+	}
+
+	if err := actions_service.CommitCache(ctx, cache, req.Size); err != nil {
+		r.handleError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (r *cacheRoutes) downloadCache(ctx *ArtifactContext) {
+	cacheID := ctx.PathParamInt64("cache_id")
+	cache, ok := r.verifySignature(ctx, "artifacts/"+strconv.FormatInt(cacheID, 10), cacheID)
+	if !ok {
+		return
+	}
+	r.serveCache(ctx, cache)
+}
+
+func (r *cacheRoutes) createCacheEntry(ctx *ArtifactContext) {
+	run, ok := r.getRun(ctx)
+	if !ok {
+		return
+	}
+	var req CreateCacheEntryRequest
+	if ok := parseProtobufBody(ctx, &req); !ok {
+		return
+	}
+
+	cache, err := actions_service.ReserveCache(ctx, run, req.Key, req.Version, 0)
+	if err != nil {
+		if !actions_service.IsCacheErrorClient(err) {
+			r.handleError(ctx, err)
+			return
+		}
+		sendProtobufBody(ctx, &CreateCacheEntryResponse{Ok: false, Message: err.Error()})
+		return
+	}
+	sendProtobufBody(ctx, &CreateCacheEntryResponse{
+		Ok:              true,
+		SignedUploadUrl: r.buildURL(ctx, "/UploadCache?"+r.buildSignedQuery("UploadCache", cache.ID)+"&cacheID="+strconv.FormatInt(cache.ID, 10)),
+	})
+}
+
+func (r *cacheRoutes) uploadCacheV2(ctx *ArtifactContext) {
+	cacheID, _ := strconv.ParseInt(ctx.Req.URL.Query().Get("cacheID"), 10, 64)
+	cache, ok := r.verifySignature(ctx, "UploadCache", cacheID)
+	if !ok {
+		return
+	}
+
+	var err error
+	switch ctx.Req.URL.Query().Get("comp") {
+	case "block":
+		err = actions_service.UploadCacheBlock(cache, ctx.Req.URL.Query().Get("blockid"), ctx.Req.Body, ctx.Req.ContentLength)
+	case "blocklist":
+		err = actions_service.UploadCacheBlockList(cache, ctx.Req.Body)
+	case "":
+		// small caches are uploaded in a single request
+		err = actions_service.UploadCacheChunk(cache, 0, ctx.Req.Body, ctx.Req.ContentLength)
+	default:
+		ctx.HTTPError(http.StatusBadRequest, "Unsupported comp parameter")
+		return
+	}
+	if err != nil {
+		r.handleError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusCreated)
+}
+
+func (r *cacheRoutes) finalizeCacheEntryUpload(ctx *ArtifactContext) {
+	run, ok := r.getRun(ctx)
+	if !ok {
+		return
+	}
+	var req FinalizeCacheEntryUploadRequest
+	if ok := parseProtobufBody(ctx, &req); !ok {
+		return
+	}
+
+	cache, err := actions_service.GetReservedCache(ctx, run, req.Key, req.Version)
+	if err == nil {
+		err = actions_service.CommitCache(ctx, cache, req.SizeBytes)
+	}
+	if err != nil {
+		if !actions_service.IsCacheErrorClient(err) {
+			r.handleError(ctx, err)
+			return
+		}
+		sendProtobufBody(ctx, &FinalizeCacheEntryUploadResponse{Ok: false, Message: err.Error()})
+		return
+	}
+	sendProtobufBody(ctx, &FinalizeCacheEntryUploadResponse{Ok: true, EntryId: cache.ID})
+}
+
+func (r *cacheRoutes) getCacheEntryDownloadURL(ctx *ArtifactContext) {
+	run, ok := r.getRun(ctx)
+	if !ok {
+		return
+	}
+	var req GetCacheEntryDownloadURLRequest
+	if ok := parseProtobufBody(ctx, &req); !ok {
+		return
+	}
+
+	cache, err := actions_service.FindCacheToRestore(ctx, run, append([]string{req.Key}, req.RestoreKeys...), req.Version)
+	if errors.Is(err, util.ErrNotExist) {
+		sendProtobufBody(ctx, &GetCacheEntryDownloadURLResponse{Ok: false})
+		return
+	} else if err != nil {
+		r.handleError(ctx, err)
+		return
+	}
+	sendProtobufBody(ctx, &GetCacheEntryDownloadURLResponse{
+		Ok:                true,
+		SignedDownloadUrl: r.buildURL(ctx, "/DownloadCache?"+r.buildSignedQuery("DownloadCache", cache.ID)+"&cacheID="+strconv.FormatInt(cache.ID, 10)),
+		MatchedKey:        cache.CacheKey,
+	})
+}
+
+func (r *cacheRoutes) downloadCacheV2(ctx *ArtifactContext) {
+	cacheID, _ := strconv.ParseInt(ctx.Req.URL.Query().Get("cacheID"), 10, 64)
+	cache, ok := r.verifySignature(ctx, "DownloadCache", cacheID)
+	if !ok {
+		return
+	}
+	r.serveCache(ctx, cache)
+}
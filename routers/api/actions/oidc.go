@@ -0,0 +1,93 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+// Gitea acts as an OpenID Connect issuer for Actions jobs, so the jobs could authenticate to cloud providers without long-lived secrets.
+// The jobs with the "id-token: write" permission get "gitea_id_token_request_url" in the task context,
+// and request an ID token with Bearer ACTIONS_RUNTIME_TOKEN like GitHub's ACTIONS_ID_TOKEN_REQUEST_URL and ACTIONS_ID_TOKEN_REQUEST_TOKEN:
+//
+// GET: /api/actions/oidc/token?audience=https://vault.example.com
+// Response:
+// {
+// 	"value": "eyJhbGciOiJSUzI1NiIsImtpZCI6..."
+// }
+//
+// The relying parties discover the public keys by the OpenID configuration:
+//
+// GET: /api/actions/oidc/.well-known/openid-configuration
+// GET: /api/actions/oidc/.well-known/jwks
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+)
+
+const oidcRouteBase = "/oidc"
+
+func registerOIDCRoutes(m *web.Router) {
+	m.Group(oidcRouteBase, func() {
+		m.Get("/.well-known/openid-configuration", oidcWellKnown)
+		m.Get("/.well-known/jwks", oidcKeys)
+		m.Get("/token", ArtifactContexter(), oidcToken)
+	})
+}
+
+func oidcWellKnown(resp http.ResponseWriter, req *http.Request) {
+	ctx := context.NewBaseContext(resp, req)
+	signingKey, err := actions_service.IDTokenSigningKey()
+	if err != nil {
+		ctx.HTTPError(http.StatusNotFound, err.Error())
+		return
+	}
+	issuer := actions_service.IDTokenIssuer()
+	ctx.JSON(http.StatusOK, map[string]any{
+		"issuer":                                issuer,
+		"jwks_uri":                              issuer + "/.well-known/jwks",
+		"subject_types_supported":               []string{"public"},
+		"response_types_supported":              []string{"id_token"},
+		"scopes_supported":                      []string{"openid"},
+		"id_token_signing_alg_values_supported": []string{signingKey.SigningMethod().Alg()},
+		"claims_supported": []string{
+			"sub", "aud", "exp", "iat", "iss", "jti", "nbf",
+			"ref", "ref_type", "sha", "repository", "repository_id", "repository_owner", "repository_owner_id", "repository_visibility",
+			"run_id", "run_number", "run_attempt", "actor", "actor_id", "workflow", "workflow_ref", "event_name", "head_ref", "base_ref", "environment",
+		},
+	})
+}
+
+func oidcKeys(resp http.ResponseWriter, req *http.Request) {
+	ctx := context.NewBaseContext(resp, req)
+	signingKey, err := actions_service.IDTokenSigningKey()
+	if err != nil {
+		ctx.HTTPError(http.StatusNotFound, err.Error())
+		return
+	}
+	jwk, err := signingKey.ToJWK()
+	if err != nil {
+		log.Error("Error converting signing key to JWK: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error converting signing key to JWK")
+		return
+	}
+	jwk["use"] = "sig"
+	ctx.JSON(http.StatusOK, map[string][]map[string]string{"keys": {jwk}})
+}
+
+func oidcToken(ctx *ArtifactContext) {
+	token, err := actions_service.CreateIDToken(ctx, ctx.ActionTask, ctx.Req.URL.Query().Get("audience"))
+	if errors.Is(err, util.ErrPermissionDenied) {
+		ctx.HTTPError(http.StatusForbidden, err.Error())
+		return
+	} else if err != nil {
+		log.Error("Error creating ID token for task %d: %v", ctx.ActionTask.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error creating ID token")
+		return
+	}
+	ctx.JSON(http.StatusOK, map[string]string{"value": token})
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/actions"
@@ -55,6 +56,10 @@ func (s *Service) Register(
 		return nil, errors.New("runner registration token has been invalidated, please use the latest one")
 	}
 
+	if runnerToken.IsExpired() {
+		return nil, errors.New("runner registration token has expired, please use a new one")
+	}
+
 	if runnerToken.OwnerID > 0 {
 		if _, err := user_model.GetUserByID(ctx, runnerToken.OwnerID); err != nil {
 			return nil, errors.New("owner of the token not found")
@@ -78,21 +83,36 @@ func (s *Service) Register(
 		RepoID:      runnerToken.RepoID,
 		Version:     req.Msg.Version,
 		AgentLabels: labels,
-		Ephemeral:   req.Msg.Ephemeral,
+		// the runners registered with ephemeral tokens must be ephemeral, whatever they have requested
+		Ephemeral: req.Msg.Ephemeral || runnerToken.Ephemeral,
 	}
 	if err := runner.GenerateToken(); err != nil {
 		return nil, errors.New("can't generate token")
 	}
 
-	// create new runner
-	if err := actions_model.CreateRunner(ctx, runner); err != nil {
+	// create new runner, an ephemeral token is used up in the same transaction so it can't register two runners
+	errTokenUsed := errors.New("runner registration token has been used or has expired, please use a new one")
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if runnerToken.Ephemeral {
+			if ok, err := actions_model.UseEphemeralRunnerToken(ctx, runnerToken); err != nil {
+				return err
+			} else if !ok {
+				return errTokenUsed
+			}
+		}
+		return actions_model.CreateRunner(ctx, runner)
+	}); errors.Is(err, errTokenUsed) {
+		return nil, errTokenUsed
+	} else if err != nil {
 		return nil, errors.New("can't create new runner")
 	}
 
 	// update token status
-	runnerToken.IsActive = true
-	if err := actions_model.UpdateRunnerToken(ctx, runnerToken, "is_active"); err != nil {
-		return nil, errors.New("can't update runner token status")
+	if !runnerToken.Ephemeral {
+		runnerToken.IsActive = true
+		if err := actions_model.UpdateRunnerToken(ctx, runnerToken, "is_active"); err != nil {
+			return nil, errors.New("can't update runner token status")
+		}
 	}
 
 	res := connect.NewResponse(&runnerv1.RegisterResponse{
@@ -278,6 +298,10 @@ func (s *Service) UpdateLog(
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "unable to append logs to dbfs file: %v", err)
 	}
+	if err := actions_service.ExtractAnnotations(ctx, task, ack, rows); err != nil {
+		// the annotations are not essential, so don't fail the uploading of the logs
+		log.Error("Failed to extract annotations of task %d: %v", task.ID, err)
+	}
 	task.LogLength += int64(len(rows))
 	for _, n := range ns {
 		task.LogIndexes = append(task.LogIndexes, task.LogSize)
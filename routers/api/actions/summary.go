@@ -0,0 +1,54 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+// The runners upload the Markdown summaries written by the steps to `GITHUB_STEP_SUMMARY` with Bearer ACTIONS_RUNTIME_TOKEN.
+// The url is "gitea_step_summary_url" in the task context, and the token is "gitea_runtime_token".
+// A runner reads the file of `GITHUB_STEP_SUMMARY` after a step has run, and uploads it before it reports the result of the task,
+// since the summaries of a task can't be uploaded after the task is done.
+// The summary of a step replaces the one uploaded before, and its size must not exceed 1 MiB:
+//
+// PUT: /api/actions/summaries/{step_index}
+// Request: the Markdown content
+// Response: 204
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+)
+
+const summaryRouteBase = "/summaries"
+
+func registerSummaryRoutes(m *web.Router) {
+	m.Put(summaryRouteBase+"/{step_index}", ArtifactContexter(), uploadStepSummary)
+}
+
+func uploadStepSummary(ctx *ArtifactContext) {
+	stepIndex := ctx.PathParamInt64("step_index")
+	// read one more byte to know whether the summary is too large
+	content, err := io.ReadAll(io.LimitReader(ctx.Req.Body, actions_module.MaxSummarySize+1))
+	if err != nil {
+		log.Error("Error reading summary of step %d of task %d: %v", stepIndex, ctx.ActionTask.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error reading summary")
+		return
+	}
+
+	err = actions_service.SaveStepSummary(ctx, ctx.ActionTask, stepIndex, string(content))
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.HTTPError(http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		log.Error("Error saving summary of step %d of task %d: %v", stepIndex, ctx.ActionTask.ID, err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error saving summary")
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
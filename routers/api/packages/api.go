@@ -4,9 +4,11 @@
 package packages
 
 import (
+	"errors"
 	"net/http"
 
 	auth_model "code.gitea.io/gitea/models/auth"
+	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/models/perm"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
@@ -24,6 +26,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/generic"
 	"code.gitea.io/gitea/routers/api/packages/goproxy"
 	"code.gitea.io/gitea/routers/api/packages/helm"
+	"code.gitea.io/gitea/routers/api/packages/hex"
 	"code.gitea.io/gitea/routers/api/packages/maven"
 	"code.gitea.io/gitea/routers/api/packages/npm"
 	"code.gitea.io/gitea/routers/api/packages/nuget"
@@ -32,9 +35,11 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/rpm"
 	"code.gitea.io/gitea/routers/api/packages/rubygems"
 	"code.gitea.io/gitea/routers/api/packages/swift"
+	"code.gitea.io/gitea/routers/api/packages/terraform"
 	"code.gitea.io/gitea/routers/api/packages/vagrant"
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
 )
 
 func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.Context) {
@@ -88,6 +93,31 @@ func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.Context) {
 	}
 }
 
+// packageNameAccess applies the permissions of the package with the name returned by nameFn to the package context.
+// It must run before reqPackageAccess, the permissions of the owner are kept if the package does not exist yet.
+func packageNameAccess(packageType packages_model.Type, nameFn func(ctx *context.Context) string) func(ctx *context.Context) {
+	return func(ctx *context.Context) {
+		p, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packageType, nameFn(ctx))
+		if err != nil {
+			if !errors.Is(err, packages_model.ErrPackageNotExist) {
+				ctx.HTTPError(http.StatusInternalServerError, "GetPackageByName", err.Error())
+			}
+			return
+		}
+
+		ctx.Package.AccessMode, err = packages_service.GetPackageAccessMode(ctx, ctx.Package.AccessMode, ctx.Package.Owner, p, ctx.Doer)
+		if err != nil {
+			ctx.HTTPError(http.StatusInternalServerError, "GetPackageAccessMode", err.Error())
+		}
+	}
+}
+
+func pathParam(name string) func(ctx *context.Context) string {
+	return func(ctx *context.Context) string {
+		return ctx.PathParam(name)
+	}
+}
+
 func verifyAuth(r *web.Router, authMethods []auth.Method) {
 	if setting.Service.EnableReverseProxyAuth {
 		authMethods = append(authMethods, &auth.ReverseProxy{})
@@ -119,6 +149,20 @@ func CommonRoutes() *web.Router {
 		&nuget.Auth{},
 		&Auth{},
 		&chef.Auth{},
+		&terraform.Auth{},
+		&hex.Auth{},
+	})
+
+	// the Terraform registry protocols use a single base url for all owners, see the service discovery in /.well-known/terraform.json
+	r.Group("/-/terraform", func() {
+		r.Group("/modules/v1/{username}/{name}/{system}", func() {
+			r.Get("/versions", terraform.ModuleVersions)
+			r.Get("/{version}/download", terraform.ModuleDownload)
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), packageNameAccess(packages_model.TypeTerraform, terraform.ModuleNameFromParams), reqPackageAccess(perm.AccessModeRead))
+		r.Group("/providers/v1/{username}/{type}", func() {
+			r.Get("/versions", terraform.ProviderVersions)
+			r.Get("/{version}/download/{os}/{arch}", terraform.ProviderDownload)
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), packageNameAccess(packages_model.TypeTerraform, pathParam("type")), reqPackageAccess(perm.AccessModeRead))
 	})
 
 	r.Group("/{username}", func() {
@@ -155,14 +199,16 @@ func CommonRoutes() *web.Router {
 						r.Put("/unyank", reqPackageAccess(perm.AccessModeWrite), cargo.UnyankPackage)
 					})
 					r.Get("/owners", cargo.ListOwners)
-				})
+				}, packageNameAccess(packages_model.TypeCargo, pathParam("package")), reqPackageAccess(perm.AccessModeRead))
 			})
 			r.Get("/config.json", cargo.RepositoryConfig)
-			r.Get("/1/{package}", cargo.EnumeratePackageVersions)
-			r.Get("/2/{package}", cargo.EnumeratePackageVersions)
-			// Use dummy placeholders because these parts are not of interest
-			r.Get("/3/{_}/{package}", cargo.EnumeratePackageVersions)
-			r.Get("/{_}/{__}/{package}", cargo.EnumeratePackageVersions)
+			r.Group("", func() {
+				r.Get("/1/{package}", cargo.EnumeratePackageVersions)
+				r.Get("/2/{package}", cargo.EnumeratePackageVersions)
+				// Use dummy placeholders because these parts are not of interest
+				r.Get("/3/{_}/{package}", cargo.EnumeratePackageVersions)
+				r.Get("/{_}/{__}/{package}", cargo.EnumeratePackageVersions)
+			}, packageNameAccess(packages_model.TypeCargo, pathParam("package")), reqPackageAccess(perm.AccessModeRead))
 		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/chef", func() {
 			r.Group("/api/v1", func() {
@@ -179,7 +225,7 @@ func CommonRoutes() *web.Router {
 							r.Get("/download", chef.DownloadPackage)
 						})
 						r.Delete("", reqPackageAccess(perm.AccessModeWrite), chef.DeletePackage)
-					})
+					}, packageNameAccess(packages_model.TypeChef, pathParam("name")), reqPackageAccess(perm.AccessModeRead))
 				})
 			})
 		}, reqPackageAccess(perm.AccessModeRead))
@@ -187,8 +233,10 @@ func CommonRoutes() *web.Router {
 			r.Get("/packages.json", composer.ServiceIndex)
 			r.Get("/search.json", composer.SearchPackages)
 			r.Get("/list.json", composer.EnumeratePackages)
-			r.Get("/p2/{vendorname}/{projectname}~dev.json", composer.PackageMetadata)
-			r.Get("/p2/{vendorname}/{projectname}.json", composer.PackageMetadata)
+			r.Group("/p2/{vendorname}", func() {
+				r.Get("/{projectname}~dev.json", composer.PackageMetadata)
+				r.Get("/{projectname}.json", composer.PackageMetadata)
+			}, packageNameAccess(packages_model.TypeComposer, composer.PackageNameFromParams), reqPackageAccess(perm.AccessModeRead))
 			r.Get("/files/{package}/{version}/{filename}", composer.DownloadPackageFile)
 			r.Put("", reqPackageAccess(perm.AccessModeWrite), composer.UploadPackage)
 		}, reqPackageAccess(perm.AccessModeRead))
@@ -217,7 +265,7 @@ func CommonRoutes() *web.Router {
 								r.Get("/download_urls", conan.PackageDownloadURLs)
 							})
 						})
-					}, conan.ExtractPathParameters)
+					}, conan.ExtractPathParameters, packageNameAccess(packages_model.TypeConan, pathParam("name")), reqPackageAccess(perm.AccessModeRead))
 				})
 				r.Group("/files/{name}/{version}/{user}/{channel}/{recipe_revision}", func() {
 					r.Group("/recipe/{filename}", func() {
@@ -228,7 +276,7 @@ func CommonRoutes() *web.Router {
 						r.Get("", conan.DownloadPackageFile)
 						r.Put("", reqPackageAccess(perm.AccessModeWrite), conan.UploadPackageFile)
 					})
-				}, conan.ExtractPathParameters)
+				}, conan.ExtractPathParameters, packageNameAccess(packages_model.TypeConan, pathParam("name")), reqPackageAccess(perm.AccessModeRead))
 			})
 			r.Group("/v2", func() {
 				r.Get("/ping", conan.Ping)
@@ -276,7 +324,7 @@ func CommonRoutes() *web.Router {
 								})
 							})
 						})
-					}, conan.ExtractPathParameters)
+					}, conan.ExtractPathParameters, packageNameAccess(packages_model.TypeConan, pathParam("name")), reqPackageAccess(perm.AccessModeRead))
 				})
 			})
 		}, reqPackageAccess(perm.AccessModeRead))
@@ -328,11 +376,13 @@ func CommonRoutes() *web.Router {
 
 			// https://go.dev/ref/mod#goproxy-protocol
 			r.PathGroup("/*", func(g *web.RouterPathGroup) {
-				g.MatchPath("GET", "/<name:*>/@<version:latest>", goproxy.PackageVersionMetadata)
-				g.MatchPath("GET", "/<name:*>/@v/list", goproxy.EnumeratePackageVersions)
-				g.MatchPath("GET", "/<name:*>/@v/<version>.zip", goproxy.DownloadPackageFile)
-				g.MatchPath("GET", "/<name:*>/@v/<version>.info", goproxy.PackageVersionMetadata)
-				g.MatchPath("GET", "/<name:*>/@v/<version>.mod", goproxy.PackageVersionGoModContent)
+				moduleAccess := packageNameAccess(packages_model.TypeGo, pathParam("name"))
+
+				g.MatchPath("GET", "/<name:*>/@<version:latest>", moduleAccess, reqPackageAccess(perm.AccessModeRead), goproxy.PackageVersionMetadata)
+				g.MatchPath("GET", "/<name:*>/@v/list", moduleAccess, reqPackageAccess(perm.AccessModeRead), goproxy.EnumeratePackageVersions)
+				g.MatchPath("GET", "/<name:*>/@v/<version>.zip", moduleAccess, reqPackageAccess(perm.AccessModeRead), goproxy.DownloadPackageFile)
+				g.MatchPath("GET", "/<name:*>/@v/<version>.info", moduleAccess, reqPackageAccess(perm.AccessModeRead), goproxy.PackageVersionMetadata)
+				g.MatchPath("GET", "/<name:*>/@v/<version>.mod", moduleAccess, reqPackageAccess(perm.AccessModeRead), goproxy.PackageVersionGoModContent)
 			})
 		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/generic", func() {
@@ -345,13 +395,28 @@ func CommonRoutes() *web.Router {
 						r.Delete("", generic.DeletePackageFile)
 					}, reqPackageAccess(perm.AccessModeWrite))
 				})
-			})
+			}, packageNameAccess(packages_model.TypeGeneric, pathParam("packagename")), reqPackageAccess(perm.AccessModeRead))
 		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/helm", func() {
 			r.Get("/index.yaml", helm.Index)
 			r.Get("/{filename}", helm.DownloadPackageFile)
 			r.Post("/api/charts", reqPackageAccess(perm.AccessModeWrite), helm.UploadPackage)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/hex", func() {
+			r.Get("/names", hex.EnumeratePackageNames)
+			r.Get("/versions", hex.EnumeratePackageVersions)
+			r.Get("/packages/{name}", packageNameAccess(packages_model.TypeHex, pathParam("name")), reqPackageAccess(perm.AccessModeRead), hex.PackageMetadata)
+			r.Get("/tarballs/{filename}", hex.DownloadPackageFile)
+			r.Get("/docs/{filename}", hex.DownloadDocsFile)
+			r.Get("/public_key", hex.GetPublicKey)
+			r.Group("/api", func() {
+				r.Post("/publish", reqPackageAccess(perm.AccessModeWrite), hex.UploadPackageFile)
+				r.Group("/packages/{name}/releases/{version}", func() {
+					r.Delete("", hex.DeletePackageVersion)
+					r.Post("/docs", hex.UploadDocsFile)
+				}, packageNameAccess(packages_model.TypeHex, pathParam("name")), reqPackageAccess(perm.AccessModeWrite))
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/maven", func() {
 			r.Put("/*", reqPackageAccess(perm.AccessModeWrite), maven.UploadPackageFile)
 			r.Get("/*", maven.DownloadPackageFile)
@@ -368,18 +433,18 @@ func CommonRoutes() *web.Router {
 				r.Group("/registration/{id}", func() {
 					r.Get("/index.json", nuget.RegistrationIndex)
 					r.Get("/{version}", nuget.RegistrationLeafV3)
-				})
+				}, packageNameAccess(packages_model.TypeNuGet, pathParam("id")), reqPackageAccess(perm.AccessModeRead))
 				r.Group("/package/{id}", func() {
 					r.Get("/index.json", nuget.EnumeratePackageVersionsV3)
 					r.Get("/{version}/{filename}", nuget.DownloadPackageFile)
-				})
+				}, packageNameAccess(packages_model.TypeNuGet, pathParam("id")), reqPackageAccess(perm.AccessModeRead))
 				r.Group("", func() {
 					r.Put("/", nuget.UploadPackage)
 					r.Put("/symbolpackage", nuget.UploadSymbolPackage)
-					r.Delete("/{id}/{version}", nuget.DeletePackage)
 				}, reqPackageAccess(perm.AccessModeWrite))
+				r.Delete("/{id}/{version}", packageNameAccess(packages_model.TypeNuGet, pathParam("id")), reqPackageAccess(perm.AccessModeWrite), nuget.DeletePackage)
 				r.Get("/symbols/{filename}/{guid:[0-9a-fA-F]{32}[fF]{8}}/{filename2}", nuget.DownloadSymbolFile)
-				r.Get("/Packages(Id='{id:[^']+}',Version='{version:[^']+}')", nuget.RegistrationLeafV2)
+				r.Get("/Packages(Id='{id:[^']+}',Version='{version:[^']+}')", packageNameAccess(packages_model.TypeNuGet, pathParam("id")), reqPackageAccess(perm.AccessModeRead), nuget.RegistrationLeafV2)
 				r.Group("/Packages()", func() {
 					r.Get("", nuget.SearchServiceV2)
 					r.Get("/$count", nuget.SearchServiceV2Count)
@@ -407,7 +472,7 @@ func CommonRoutes() *web.Router {
 					r.Delete("", npm.DeletePackage)
 					r.Put("", npm.DeletePreview)
 				}, reqPackageAccess(perm.AccessModeWrite))
-			})
+			}, packageNameAccess(packages_model.TypeNpm, npm.PackageNameFromParams), reqPackageAccess(perm.AccessModeRead))
 			r.Group("/{id}", func() {
 				r.Get("", npm.PackageMetadata)
 				r.Put("", reqPackageAccess(perm.AccessModeWrite), npm.UploadPackage)
@@ -420,21 +485,21 @@ func CommonRoutes() *web.Router {
 					r.Delete("", npm.DeletePackage)
 					r.Put("", npm.DeletePreview)
 				}, reqPackageAccess(perm.AccessModeWrite))
-			})
+			}, packageNameAccess(packages_model.TypeNpm, npm.PackageNameFromParams), reqPackageAccess(perm.AccessModeRead))
 			r.Group("/-/package/@{scope}/{id}/dist-tags", func() {
 				r.Get("", npm.ListPackageTags)
 				r.Group("/{tag}", func() {
 					r.Put("", npm.AddPackageTag)
 					r.Delete("", npm.DeletePackageTag)
 				}, reqPackageAccess(perm.AccessModeWrite))
-			})
+			}, packageNameAccess(packages_model.TypeNpm, npm.PackageNameFromParams), reqPackageAccess(perm.AccessModeRead))
 			r.Group("/-/package/{id}/dist-tags", func() {
 				r.Get("", npm.ListPackageTags)
 				r.Group("/{tag}", func() {
 					r.Put("", npm.AddPackageTag)
 					r.Delete("", npm.DeletePackageTag)
 				}, reqPackageAccess(perm.AccessModeWrite))
-			})
+			}, packageNameAccess(packages_model.TypeNpm, npm.PackageNameFromParams), reqPackageAccess(perm.AccessModeRead))
 			r.Group("/-/v1/search", func() {
 				r.Get("", npm.PackageSearch)
 			})
@@ -450,14 +515,16 @@ func CommonRoutes() *web.Router {
 					r.Get("", pub.EnumeratePackageVersions)
 					r.Get("/files/{version}", pub.DownloadPackageFile)
 					r.Get("/{version}", pub.PackageVersionMetadata)
-				})
+				}, packageNameAccess(packages_model.TypePub, pathParam("id")), reqPackageAccess(perm.AccessModeRead))
 			})
 		}, reqPackageAccess(perm.AccessModeRead))
 
 		r.Group("/pypi", func() {
 			r.Post("/", reqPackageAccess(perm.AccessModeWrite), pypi.UploadPackageFile)
-			r.Get("/files/{id}/{version}/{filename}", pypi.DownloadPackageFile)
-			r.Get("/simple/{id}", pypi.PackageMetadata)
+			r.Group("", func() {
+				r.Get("/files/{id}/{version}/{filename}", pypi.DownloadPackageFile)
+				r.Get("/simple/{id}", pypi.PackageMetadata)
+			}, packageNameAccess(packages_model.TypePyPI, pypi.PackageNameFromParams), reqPackageAccess(perm.AccessModeRead))
 		}, reqPackageAccess(perm.AccessModeRead))
 
 		r.Methods("HEAD,GET", "/rpm.repo", reqPackageAccess(perm.AccessModeRead), rpm.GetRepositoryConfig)
@@ -479,7 +546,7 @@ func CommonRoutes() *web.Router {
 			r.Get("/prerelease_specs.4.8.gz", rubygems.EnumeratePackagesPreRelease)
 			r.Get("/quick/Marshal.4.8/{filename}", rubygems.ServePackageSpecification)
 			r.Get("/gems/{filename}", rubygems.DownloadPackageFile)
-			r.Get("/info/{packagename}", rubygems.GetPackageInfo)
+			r.Get("/info/{packagename}", packageNameAccess(packages_model.TypeRubyGems, pathParam("packagename")), reqPackageAccess(perm.AccessModeRead), rubygems.GetPackageInfo)
 			r.Get("/versions", rubygems.GetAllPackagesVersions)
 			r.Group("/api/v1/gems", func() {
 				r.Post("/", rubygems.UploadPackageFile)
@@ -505,10 +572,20 @@ func CommonRoutes() *web.Router {
 						g.MatchPath("GET", "/<version>", swift.CheckAcceptMediaType(swift.AcceptJSON), swift.PackageVersionMetadata)
 						g.MatchPath("PUT", "/<version>", reqPackageAccess(perm.AccessModeWrite), swift.CheckAcceptMediaType(swift.AcceptJSON), swift.UploadPackageFile)
 					})
-				})
+				}, packageNameAccess(packages_model.TypeSwift, swift.PackageNameFromParams), reqPackageAccess(perm.AccessModeRead))
 				r.Get("/identifiers", swift.CheckAcceptMediaType(swift.AcceptJSON), swift.LookupPackageIdentifiers)
 			}, reqPackageAccess(perm.AccessModeRead))
 		})
+		r.Group("/terraform", func() {
+			r.Group("/modules/{name}/{system}/{version}", func() {
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), terraform.UploadModule)
+				r.Get("/{filename}", terraform.DownloadModuleFile)
+			}, packageNameAccess(packages_model.TypeTerraform, terraform.ModuleNameFromParams), reqPackageAccess(perm.AccessModeRead))
+			r.Group("/providers/{type}/{version}/{filename}", func() {
+				r.Get("", terraform.DownloadProviderFile)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), terraform.UploadProviderFile)
+			}, packageNameAccess(packages_model.TypeTerraform, pathParam("type")), reqPackageAccess(perm.AccessModeRead))
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/vagrant", func() {
 			r.Group("/authenticate", func() {
 				r.Get("", vagrant.CheckAuthenticate)
@@ -520,7 +597,7 @@ func CommonRoutes() *web.Router {
 					r.Get("", vagrant.DownloadPackageFile)
 					r.Put("", reqPackageAccess(perm.AccessModeWrite), vagrant.UploadPackageFile)
 				})
-			})
+			}, packageNameAccess(packages_model.TypeVagrant, pathParam("name")), reqPackageAccess(perm.AccessModeRead))
 		}, reqPackageAccess(perm.AccessModeRead))
 	}, context.UserAssignmentWeb(), context.PackageAssignment())
 
@@ -551,23 +628,26 @@ func ContainerRoutes() *web.Router {
 	r.Get("/_catalog", container.ReqContainerAccess, container.GetRepositoryList)
 	r.Group("/{username}", func() {
 		r.PathGroup("/*", func(g *web.RouterPathGroup) {
-			g.MatchPath("POST", "/<image:*>/blobs/uploads", reqPackageAccess(perm.AccessModeWrite), container.VerifyImageName, container.PostBlobsUploads)
-			g.MatchPath("GET", "/<image:*>/tags/list", container.VerifyImageName, container.GetTagsList)
+			imageAccess := packageNameAccess(packages_model.TypeContainer, pathParam("image"))
+
+			g.MatchPath("POST", "/<image:*>/blobs/uploads", container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeWrite), container.PostBlobsUploads)
+			g.MatchPath("GET", "/<image:*>/tags/list", container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeRead), container.GetTagsList)
+			g.MatchPath("GET", "/<image:*>/referrers/<digest>", container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeRead), container.GetReferrers)
 
-			patternBlobsUploadsUUID := g.PatternRegexp(`/<image:*>/blobs/uploads/<uuid:[-.=\w]+>`, reqPackageAccess(perm.AccessModeWrite), container.VerifyImageName)
+			patternBlobsUploadsUUID := g.PatternRegexp(`/<image:*>/blobs/uploads/<uuid:[-.=\w]+>`, container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeWrite))
 			g.MatchPattern("GET", patternBlobsUploadsUUID, container.GetBlobsUpload)
 			g.MatchPattern("PATCH", patternBlobsUploadsUUID, container.PatchBlobsUpload)
 			g.MatchPattern("PUT", patternBlobsUploadsUUID, container.PutBlobsUpload)
 			g.MatchPattern("DELETE", patternBlobsUploadsUUID, container.DeleteBlobsUpload)
 
-			g.MatchPath("HEAD", `/<image:*>/blobs/<digest>`, container.VerifyImageName, container.HeadBlob)
-			g.MatchPath("GET", `/<image:*>/blobs/<digest>`, container.VerifyImageName, container.GetBlob)
-			g.MatchPath("DELETE", `/<image:*>/blobs/<digest>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), container.DeleteBlob)
+			g.MatchPath("HEAD", `/<image:*>/blobs/<digest>`, container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeRead), container.HeadBlob)
+			g.MatchPath("GET", `/<image:*>/blobs/<digest>`, container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeRead), container.GetBlob)
+			g.MatchPath("DELETE", `/<image:*>/blobs/<digest>`, container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeWrite), container.DeleteBlob)
 
-			g.MatchPath("HEAD", `/<image:*>/manifests/<reference>`, container.VerifyImageName, container.HeadManifest)
-			g.MatchPath("GET", `/<image:*>/manifests/<reference>`, container.VerifyImageName, container.GetManifest)
-			g.MatchPath("PUT", `/<image:*>/manifests/<reference>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), container.PutManifest)
-			g.MatchPath("DELETE", `/<image:*>/manifests/<reference>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), container.DeleteManifest)
+			g.MatchPath("HEAD", `/<image:*>/manifests/<reference>`, container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeRead), container.HeadManifest)
+			g.MatchPath("GET", `/<image:*>/manifests/<reference>`, container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeRead), container.GetManifest)
+			g.MatchPath("PUT", `/<image:*>/manifests/<reference>`, container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeWrite), container.PutManifest)
+			g.MatchPath("DELETE", `/<image:*>/manifests/<reference>`, container.VerifyImageName, imageAccess, reqPackageAccess(perm.AccessModeWrite), container.DeleteManifest)
 		})
 	}, container.ReqContainerAccess, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
 
@@ -101,16 +101,19 @@ func SearchPackages(ctx *context.Context) {
 		PageSize: convert.ToCorrectPageSize(perPage),
 	}
 
-	pvs, total, err := packages_model.SearchLatestVersions(
-		ctx,
-		&packages_model.PackageSearchOptions{
-			OwnerID:    ctx.Package.Owner.ID,
-			Type:       packages_model.TypeCargo,
-			Name:       packages_model.SearchValue{Value: ctx.FormTrim("q")},
-			IsInternal: optional.Some(false),
-			Paginator:  &paginator,
-		},
-	)
+	opts := &packages_model.PackageSearchOptions{
+		OwnerID:    ctx.Package.Owner.ID,
+		Type:       packages_model.TypeCargo,
+		Name:       packages_model.SearchValue{Value: ctx.FormTrim("q")},
+		IsInternal: optional.Some(false),
+		Paginator:  &paginator,
+	}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, opts); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pvs, total, err := packages_model.SearchLatestVersions(ctx, opts)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -37,11 +37,17 @@ func apiError(ctx *context.Context, status int, obj any) {
 }
 
 func PackagesUniverse(ctx *context.Context) {
-	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+	opts := &packages_model.PackageSearchOptions{
 		OwnerID:    ctx.Package.Owner.ID,
 		Type:       packages_model.TypeChef,
 		IsInternal: optional.Some(false),
-	})
+	}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, opts); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pvs, _, err := packages_model.SearchVersions(ctx, opts)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -98,6 +104,10 @@ func EnumeratePackages(ctx *context.Context) {
 	default:
 		opts.Sort = packages_model.SortNameAsc
 	}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, opts); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
 
 	pvs, total, err := packages_model.SearchLatestVersions(ctx, opts)
 	if err != nil {
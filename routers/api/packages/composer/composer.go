@@ -67,6 +67,10 @@ func SearchPackages(ctx *context.Context) {
 			composer_module.TypeProperty: ctx.FormTrim("type"),
 		}
 	}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, opts); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
 
 	pvs, total, err := packages_model.SearchLatestVersions(ctx, opts)
 	if err != nil {
@@ -112,6 +116,11 @@ func EnumeratePackages(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	ps, err = packages_service.FilterPrivatePackages(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, ps)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
 
 	names := make([]string, 0, len(ps))
 	for _, p := range ps {
@@ -123,13 +132,15 @@ func EnumeratePackages(ctx *context.Context) {
 	})
 }
 
+// PackageNameFromParams gets the package name from the url parameters
+func PackageNameFromParams(ctx *context.Context) string {
+	return ctx.PathParam("vendorname") + "/" + ctx.PathParam("projectname")
+}
+
 // PackageMetadata returns the metadata for a single package
 // https://packagist.org/apidoc#get-package-data
 func PackageMetadata(ctx *context.Context) {
-	vendorName := ctx.PathParam("vendorname")
-	projectName := ctx.PathParam("projectname")
-
-	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeComposer, vendorName+"/"+projectName)
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeComposer, PackageNameFromParams(ctx))
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -8,11 +8,13 @@ import (
 	"net/http"
 	"strings"
 
+	packages_model "code.gitea.io/gitea/models/packages"
 	conan_model "code.gitea.io/gitea/models/packages/conan"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/json"
 	conan_module "code.gitea.io/gitea/modules/packages/conan"
 	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
 )
 
 // SearchResult contains the found recipe names
@@ -26,6 +28,13 @@ func SearchRecipes(ctx *context.Context) {
 
 	opts := parseQuery(ctx.Package.Owner, q)
 
+	filter := &packages_model.PackageSearchOptions{}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, filter); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	opts.HidePrivate, opts.PrivateIDs = filter.HidePrivate, filter.PrivateIDs
+
 	results, err := conan_model.SearchRecipes(ctx, opts)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
@@ -89,10 +89,18 @@ func EnumeratePackages(ctx *context.Context) {
 		Removed:       make(map[string]*PackageInfo),
 	}
 
+	filter := &packages_model.PackageSearchOptions{}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, filter); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
 	pfs, err := conda_model.SearchFiles(ctx, &conda_model.FileSearchOptions{
-		OwnerID: ctx.Package.Owner.ID,
-		Channel: ctx.PathParam("channel"),
-		Subdir:  repoData.Info.Subdir,
+		OwnerID:     ctx.Package.Owner.ID,
+		Channel:     ctx.PathParam("channel"),
+		Subdir:      repoData.Info.Subdir,
+		HidePrivate: filter.HidePrivate,
+		PrivateIDs:  filter.PrivateIDs,
 	})
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
@@ -34,6 +34,8 @@ import (
 	container_service "code.gitea.io/gitea/services/packages/container"
 
 	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // maximum size of a container manifest
@@ -57,6 +59,7 @@ type containerHeaders struct {
 	Location      string
 	ContentType   string
 	ContentLength optional.Option[int64]
+	Subject       string
 }
 
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#legacy-docker-support-http-headers
@@ -80,6 +83,9 @@ func setResponseHeaders(resp http.ResponseWriter, h *containerHeaders) {
 		resp.Header().Set("Docker-Content-Digest", h.ContentDigest)
 		resp.Header().Set("ETag", fmt.Sprintf(`"%s"`, h.ContentDigest))
 	}
+	if h.Subject != "" {
+		resp.Header().Set("OCI-Subject", h.Subject)
+	}
 	resp.Header().Set("Docker-Distribution-Api-Version", "registry/2.0")
 	resp.WriteHeader(h.Status)
 }
@@ -495,11 +501,20 @@ func getBlobFromContext(ctx *context.Context) (*packages_model.PackageFileDescri
 		return nil, container_model.ErrContainerBlobNotExist
 	}
 
-	return workaroundGetContainerBlob(ctx, &container_model.BlobSearchOptions{
+	if err := resolveVirtualImage(ctx); err != nil {
+		return nil, err
+	}
+
+	opts := &container_model.BlobSearchOptions{
 		OwnerID: ctx.Package.Owner.ID,
 		Image:   ctx.PathParam("image"),
 		Digest:  string(d),
-	})
+	}
+	blob, err := workaroundGetContainerBlob(ctx, opts)
+	if errors.Is(err, container_model.ErrContainerBlobNotExist) && fetchRemoteBlob(ctx, &packages_service.PackageInfo{Owner: ctx.Package.Owner, Name: opts.Image}, d) {
+		blob, err = workaroundGetContainerBlob(ctx, opts)
+	}
+	return blob, err
 }
 
 // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#checking-if-content-exists-in-the-registry
@@ -603,6 +618,7 @@ func PutManifest(ctx *context.Context) {
 	setResponseHeaders(ctx.Resp, &containerHeaders{
 		Location:      fmt.Sprintf("/v2/%s/%s/manifests/%s", ctx.Package.Owner.LowerName, mci.Image, reference),
 		ContentDigest: digest,
+		Subject:       mci.Subject,
 		Status:        http.StatusCreated,
 	})
 }
@@ -628,11 +644,17 @@ func getBlobSearchOptionsFromContext(ctx *context.Context) (*container_model.Blo
 }
 
 func getManifestFromContext(ctx *context.Context) (*packages_model.PackageFileDescriptor, error) {
+	if err := resolveVirtualImage(ctx); err != nil {
+		return nil, err
+	}
+
 	opts, err := getBlobSearchOptionsFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	syncRemoteManifest(ctx, &packages_service.PackageInfo{Owner: ctx.Package.Owner, Name: opts.Image}, opts)
+
 	return workaroundGetContainerBlob(ctx, opts)
 }
 
@@ -779,6 +801,50 @@ func GetTagsList(ctx *context.Context) {
 	})
 }
 
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func GetReferrers(ctx *context.Context) {
+	subject := digest.Digest(ctx.PathParam("digest"))
+	if subject.Validate() != nil {
+		apiErrorDefined(ctx, errDigestInvalid)
+		return
+	}
+
+	index := oci.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: oci.MediaTypeImageIndex,
+		Manifests: []oci.Descriptor{},
+	}
+
+	if err := resolveVirtualImage(ctx); err != nil {
+		// the registry must return an empty index if the subject is unknown
+		if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	} else {
+		artifactType := ctx.FormTrim("artifactType")
+
+		referrers, err := container_service.GetReferrers(ctx, ctx.Package.Owner.ID, ctx.PathParam("image"), string(subject), artifactType)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		for _, referrer := range referrers {
+			index.Manifests = append(index.Manifests, referrer.Descriptor)
+		}
+
+		if artifactType != "" {
+			ctx.Resp.Header().Set("OCI-Filters-Applied", "artifactType")
+		}
+	}
+
+	setResponseHeaders(ctx.Resp, &containerHeaders{
+		Status:      http.StatusOK,
+		ContentType: oci.MediaTypeImageIndex,
+	})
+	_ = json.NewEncoder(ctx.Resp).Encode(index) // ignore network errors
+}
+
 // FIXME: Workaround to be removed in v1.20.
 // Update maybe we should never really remote it, as long as there is legacy data?
 // https://github.com/go-gitea/gitea/issues/19586
@@ -38,6 +38,8 @@ type manifestCreationInfo struct {
 	Reference  string
 	IsTagged   bool
 	Properties map[string]string
+	// Subject is the digest of the manifest the created manifest refers to, it's set while processing the manifest
+	Subject string
 }
 
 func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (string, error) {
@@ -104,6 +106,12 @@ func processOciImageManifest(ctx context.Context, mci *manifestCreationInfo, buf
 	if err != nil {
 		return "", err
 	}
+	if manifest.Subject != nil {
+		// https://github.com/opencontainers/image-spec/blob/main/manifest.md#guidelines-for-artifact-usage
+		if err := setSubjectMetadata(mci, metadata, manifest.Subject, util.IfZero(manifest.ArtifactType, manifest.Config.MediaType), manifest.Annotations); err != nil {
+			return "", err
+		}
+	}
 	if _, err = buf.Seek(0, io.SeekStart); err != nil {
 		return "", err
 	}
@@ -176,6 +184,11 @@ func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *p
 			Type:      container_module.TypeOCI,
 			Manifests: make([]*container_module.Manifest, 0, len(index.Manifests)),
 		}
+		if index.Subject != nil {
+			if err := setSubjectMetadata(mci, metadata, index.Subject, index.ArtifactType, index.Annotations); err != nil {
+				return err
+			}
+		}
 
 		for _, manifest := range index.Manifests {
 			if !container_module.IsMediaTypeImageManifest(manifest.MediaType) {
@@ -230,6 +243,18 @@ func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *p
 	return handleCreateManifestResult(ctx, err, mci, contentStore, &txRet)
 }
 
+// setSubjectMetadata stores the relation of an artifact manifest to its subject which is needed by the referrers api
+func setSubjectMetadata(mci *manifestCreationInfo, metadata *container_module.Metadata, subject *oci.Descriptor, artifactType string, annotations map[string]string) error {
+	if subject.Digest.Validate() != nil {
+		return errManifestInvalid.WithMessage("Subject digest is invalid")
+	}
+	mci.Subject = string(subject.Digest)
+	metadata.Subject = mci.Subject
+	metadata.ArtifactType = artifactType
+	metadata.Annotations = annotations
+	return nil
+}
+
 func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, metadata *container_module.Metadata) (*packages_model.PackageVersion, error) {
 	created := true
 	p := &packages_model.Package{
@@ -318,6 +343,15 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 		}
 	}
 
+	if err = packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject); err != nil {
+		return nil, fmt.Errorf("DeletePropertiesByName(ManifestSubject): %w", err)
+	}
+	if metadata.Subject != "" {
+		if _, err = packages_model.InsertProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject, metadata.Subject); err != nil {
+			return nil, fmt.Errorf("InsertProperty(ManifestSubject): %w", err)
+		}
+	}
+
 	return pv, nil
 }
 
@@ -0,0 +1,223 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"bytes"
+	"errors"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// getRemote returns the container remote of the owner if the image is not a local one
+func getRemote(ctx *context.Context, pi *packages_service.PackageInfo) (*remote_service.Remote, error) {
+	r, err := remote_service.GetRemote(ctx, pi.Owner, packages_model.TypeContainer)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if local, err := remote_service.IsLocalPackage(ctx, pi.Owner.ID, packages_model.TypeContainer, pi.Name); err != nil || local {
+		return nil, err
+	}
+	return r, nil
+}
+
+// syncRemoteManifest caches the manifest from the upstream if it's missing or if the tag points to another manifest in the upstream.
+// Errors are only logged, the cached manifest (if any) is served if the upstream is not available.
+func syncRemoteManifest(ctx *context.Context, pi *packages_service.PackageInfo, opts *container_model.BlobSearchOptions) {
+	r, err := getRemote(ctx, pi)
+	if err != nil {
+		log.Error("Unable to get the container remote of owner %d: %v", pi.Owner.ID, err)
+		return
+	}
+	if r == nil {
+		return
+	}
+
+	reference, isTagged := opts.Tag, opts.Tag != ""
+	if !isTagged {
+		reference = opts.Digest
+	}
+
+	local, err := workaroundGetContainerBlob(ctx, opts)
+	if err != nil && !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		log.Error("Unable to get the manifest %s of image %s: %v", reference, pi.Name, err)
+		return
+	}
+	// manifests referenced by digest never change
+	if local != nil && !isTagged {
+		return
+	}
+
+	content, err := r.ContainerManifest(ctx, pi.Name, reference)
+	if err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Warn("Unable to get the manifest %s of image %s from the upstream: %v", reference, pi.Name, err)
+		}
+		return
+	}
+	if local != nil && local.Properties.GetByName(container_module.PropertyDigest) == digest.FromBytes(content).String() {
+		return
+	}
+
+	if err := markCachedImage(ctx, r, pi); err != nil {
+		log.Error("Unable to mark image %s as cached: %v", pi.Name, err)
+		return
+	}
+	if err := cacheRemoteManifest(ctx, r, pi, reference, isTagged, content); err != nil {
+		log.Warn("Unable to cache the manifest %s of image %s from the upstream: %v", reference, pi.Name, err)
+	}
+}
+
+// markCachedImage creates the package of the image and marks it as cached before any file is added,
+// so the image isn't treated as a local one.
+func markCachedImage(ctx *context.Context, r *remote_service.Remote, pi *packages_service.PackageInfo) error {
+	uploadVersion, err := getOrCreateUploadVersion(ctx, pi)
+	if err != nil {
+		return err
+	}
+	return packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypePackage, uploadVersion.PackageID, packages_model.PropertyCachedFromRemote, r.URL)
+}
+
+func cacheRemoteManifest(ctx *context.Context, r *remote_service.Remote, pi *packages_service.PackageInfo, reference string, isTagged bool, content []byte) error {
+	var manifest struct {
+		MediaType string           `json:"mediaType"`
+		Config    *oci.Descriptor  `json:"config"`
+		Layers    []oci.Descriptor `json:"layers"`
+		Manifests []oci.Descriptor `json:"manifests"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return err
+	}
+
+	mediaType := manifest.MediaType
+	if mediaType == "" {
+		// the media type is optional in OCI manifests
+		mediaType = util.Iif(manifest.Manifests != nil, oci.MediaTypeImageIndex, oci.MediaTypeImageManifest)
+	}
+
+	if container_module.IsMediaTypeImageIndex(mediaType) {
+		for _, m := range manifest.Manifests {
+			_, err := container_model.GetContainerBlob(ctx, &container_model.BlobSearchOptions{
+				OwnerID:    pi.Owner.ID,
+				Image:      pi.Name,
+				Digest:     string(m.Digest),
+				IsManifest: true,
+			})
+			if err == nil {
+				continue
+			} else if !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+				return err
+			}
+
+			child, err := r.ContainerManifest(ctx, pi.Name, string(m.Digest))
+			if err != nil {
+				return err
+			}
+			if err := cacheRemoteManifest(ctx, r, pi, string(m.Digest), false, child); err != nil {
+				return err
+			}
+		}
+	} else if container_module.IsMediaTypeImageManifest(mediaType) {
+		blobs := manifest.Layers
+		if manifest.Config != nil {
+			blobs = append([]oci.Descriptor{*manifest.Config}, blobs...)
+		}
+		for _, blob := range blobs {
+			if err := cacheRemoteBlob(ctx, r, pi, blob.Digest); err != nil {
+				return err
+			}
+		}
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	_, err = processManifest(ctx, &manifestCreationInfo{
+		MediaType: mediaType,
+		Owner:     pi.Owner,
+		Creator:   pi.Owner,
+		Image:     pi.Name,
+		Reference: reference,
+		IsTagged:  isTagged,
+	}, buf)
+	return err
+}
+
+// cacheRemoteBlob fetches the blob from the upstream if it doesn't exist for the image
+func cacheRemoteBlob(ctx *context.Context, r *remote_service.Remote, pi *packages_service.PackageInfo, d digest.Digest) error {
+	_, err := workaroundGetContainerBlob(ctx, &container_model.BlobSearchOptions{
+		OwnerID: pi.Owner.ID,
+		Image:   pi.Name,
+		Digest:  string(d),
+	})
+	if err == nil || !errors.Is(err, container_model.ErrContainerBlobNotExist) {
+		return err
+	}
+
+	buf, err := r.ContainerDownloadBlob(ctx, pi.Name, d)
+	if err != nil {
+		return err
+	}
+	defer buf.Close()
+
+	_, err = saveAsPackageBlob(ctx, buf, &packages_service.PackageCreationInfo{
+		PackageInfo: *pi,
+		Creator:     pi.Owner,
+	})
+	return err
+}
+
+// fetchRemoteBlob caches the blob from the upstream, it returns false if the blob can't be fetched
+func fetchRemoteBlob(ctx *context.Context, pi *packages_service.PackageInfo, d digest.Digest) bool {
+	r, err := getRemote(ctx, pi)
+	if err != nil {
+		log.Error("Unable to get the container remote of owner %d: %v", pi.Owner.ID, err)
+		return false
+	}
+	if r == nil {
+		return false
+	}
+
+	if err := markCachedImage(ctx, r, pi); err != nil {
+		log.Error("Unable to mark image %s as cached: %v", pi.Name, err)
+		return false
+	}
+	if err := cacheRemoteBlob(ctx, r, pi, d); err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Warn("Unable to cache blob %s of image %s from the upstream: %v", d, pi.Name, err)
+		}
+		return false
+	}
+	return true
+}
+
+// resolveVirtualImage switches the package context to the source of the virtual registry which has the image
+func resolveVirtualImage(ctx *context.Context) error {
+	if err := helper.ResolveVirtualPackage(ctx, packages_model.TypeContainer, ctx.PathParam("image")); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return container_model.ErrContainerBlobNotExist
+		}
+		return err
+	}
+	return nil
+}
@@ -48,6 +48,13 @@ func enumeratePackages(ctx *context.Context, format string, opts *cran_model.Sea
 		return
 	}
 
+	filter := &packages_model.PackageSearchOptions{}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, filter); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	opts.HidePrivate, opts.PrivateIDs = filter.HidePrivate, filter.PrivateIDs
+
 	pvs, err := cran_model.SearchLatestVersions(ctx, opts)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
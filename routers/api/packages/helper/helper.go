@@ -9,10 +9,15 @@ import (
 	"net/http"
 	"net/url"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	virtual_service "code.gitea.io/gitea/services/packages/virtual"
 )
 
 // ProcessErrorForUser logs the error and returns a user-error message for the end user.
@@ -39,7 +44,21 @@ func ProcessErrorForUser(ctx *context.Context, status int, errObj any) string {
 
 // ServePackageFile the content of the package file
 // If the url is set it will redirect the request, otherwise the content is copied to the response.
+// Files of private packages the doer has no access to are not served.
 func ServePackageFile(ctx *context.Context, s io.ReadSeekCloser, u *url.URL, pf *packages_model.PackageFile, forceOpts ...*context.ServeHeaderOptions) {
+	canRead, err := canReadPackageFile(ctx, pf)
+	if err != nil || !canRead {
+		if s != nil {
+			s.Close()
+		}
+		if err != nil {
+			ctx.HTTPError(http.StatusInternalServerError, ProcessErrorForUser(ctx, http.StatusInternalServerError, err))
+		} else {
+			ctx.HTTPError(http.StatusNotFound)
+		}
+		return
+	}
+
 	if u != nil {
 		ctx.Redirect(u.String())
 		return
@@ -59,3 +78,65 @@ func ServePackageFile(ctx *context.Context, s io.ReadSeekCloser, u *url.URL, pf
 
 	ctx.ServeContent(s, opts)
 }
+
+func canReadPackageFile(ctx *context.Context, pf *packages_model.PackageFile) (bool, error) {
+	if ctx.Package == nil || ctx.IsUserSiteAdmin() {
+		return true, nil
+	}
+
+	pv, err := packages_model.GetVersionByID(ctx, pf.VersionID)
+	if err != nil {
+		return false, err
+	}
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		return false, err
+	}
+	if !p.IsPrivate {
+		return true, nil
+	}
+
+	accessMode, err := packages_service.GetPackageAccessMode(ctx, ctx.Package.AccessMode, ctx.Package.Owner, p, ctx.Doer)
+	return accessMode >= perm.AccessModeRead, err
+}
+
+// ResolveVirtualPackage resolves the package against the virtual registry of the owner, if there is one.
+// If another owner has the package, the package context is switched to that owner to serve the package from there.
+// packages_model.ErrPackageNotExist is returned if the owner has a virtual registry but no source has the package.
+func ResolveVirtualPackage(ctx *context.Context, packageType packages_model.Type, packageNames ...string) error {
+	// virtual registries are read-only, modifications always apply to the packages of the owner itself
+	if ctx.Req.Method != http.MethodGet && ctx.Req.Method != http.MethodHead {
+		return nil
+	}
+
+	publicOnly := false
+	if scope, ok := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope); ok {
+		var err error
+		if publicOnly, err = scope.PublicOnly(); err != nil {
+			return err
+		}
+	}
+
+	canRead := func(owner *user_model.User) (bool, error) {
+		if publicOnly && owner.Visibility.IsPrivate() {
+			return false, nil
+		}
+		if ctx.IsUserSiteAdmin() {
+			return true, nil
+		}
+		accessMode, err := context.PackageAccessMode(ctx.Base, owner, ctx.Doer)
+		return accessMode >= perm.AccessModeRead, err
+	}
+
+	s, err := virtual_service.Resolve(ctx, ctx.Package.Owner, packageType, canRead, packageNames...)
+	if err != nil || s == nil {
+		return err
+	}
+	if s.Owner.ID != ctx.Package.Owner.ID {
+		ctx.Package = &context.Package{
+			Owner:      s.Owner,
+			AccessMode: perm.AccessModeRead,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"net/http"
+	"strings"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/services/auth"
+)
+
+var _ auth.Method = &Auth{}
+
+// Auth authenticates the Hex client which sends the API key without an authentication scheme
+type Auth struct {
+	basicAuth auth.Basic
+}
+
+func (a *Auth) Name() string {
+	return "hex"
+}
+
+// Verify extracts the user from the token in the Authorization header
+func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) (*user_model.User, error) {
+	if !strings.Contains(req.URL.Path, "/hex/") {
+		return nil, nil //nolint:nilnil // the auth method is not applicable
+	}
+
+	token := req.Header.Get("Authorization")
+	if token == "" || strings.Contains(token, " ") {
+		return nil, nil //nolint:nilnil // the header contains a scheme which is handled by the other auth methods
+	}
+
+	return a.basicAuth.VerifyAuthToken(req, w, store, sess, token)
+}
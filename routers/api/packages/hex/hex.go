@@ -0,0 +1,302 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	hex_service "code.gitea.io/gitea/services/packages/hex"
+)
+
+// https://github.com/hexpm/specifications/blob/main/apiary.apib
+const termContentType = "application/vnd.hex+erlang"
+
+// apiError responds in the Erlang term format because the Hex client shows the message of the error
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	termResponse(ctx, status, map[string]any{
+		"status":  status,
+		"message": message,
+	})
+}
+
+func termResponse(ctx *context.Context, status int, obj any) {
+	b, err := hex_module.EncodeTerm(obj)
+	if err != nil {
+		ctx.ServerError("EncodeTerm", err)
+		return
+	}
+	ctx.Resp.Header().Set("Content-Type", termContentType)
+	ctx.Resp.WriteHeader(status)
+	_, _ = ctx.Resp.Write(b)
+}
+
+func serveResource(ctx *context.Context, resource []byte, err error) {
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/octet-stream")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(resource)
+}
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#names
+func EnumeratePackageNames(ctx *context.Context) {
+	resource, err := hex_service.BuildNames(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer)
+	serveResource(ctx, resource, err)
+}
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#versions
+func EnumeratePackageVersions(ctx *context.Context) {
+	resource, err := hex_service.BuildVersions(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer)
+	serveResource(ctx, resource, err)
+}
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#package
+func PackageMetadata(ctx *context.Context) {
+	resource, err := hex_service.BuildPackage(ctx, ctx.Package.Owner, ctx.PathParam("name"))
+	serveResource(ctx, resource, err)
+}
+
+// GetPublicKey serves the public key which verifies the signature of the registry resources
+func GetPublicKey(ctx *context.Context) {
+	_, pub, err := hex_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(strings.NewReader(pub), &context.ServeHeaderOptions{
+		ContentType: "application/x-pem-file",
+		Filename:    "public_key",
+	})
+}
+
+// DownloadPackageFile serves the package tarball
+func DownloadPackageFile(ctx *context.Context) {
+	packageName, packageVersion, err := hex_module.ParseFilename(ctx.PathParam("filename"), ".tar")
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	serveFile(ctx, packageName, packageVersion, hex_module.TarballFilename(packageName, packageVersion))
+}
+
+// DownloadDocsFile serves the documentation tarball of a package version
+func DownloadDocsFile(ctx *context.Context) {
+	packageName, packageVersion, err := hex_module.ParseFilename(ctx.PathParam("filename"), ".tar.gz")
+	if err != nil {
+		apiError(ctx, http.StatusNotFound, err)
+		return
+	}
+
+	serveFile(ctx, packageName, packageVersion, hex_module.DocsFilename)
+}
+
+func serveFile(ctx *context.Context, packageName, packageVersion, filename string) {
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        packageName,
+			Version:     packageVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadPackageFile publishes a package tarball, an existing version is only replaced if requested
+func UploadPackageFile(ctx *context.Context) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	pck, err := hex_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusUnprocessableEntity, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if ctx.FormBool("replace") {
+		pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, pck.Name, pck.Version)
+		if err == nil {
+			err = packages_service.RemovePackageVersion(ctx, ctx.Doer, pv)
+		}
+		if err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeHex,
+				Name:        pck.Name,
+				Version:     pck.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: hex_module.TarballFilename(pck.Name, pck.Version),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	termResponse(ctx, http.StatusCreated, map[string]any{
+		"version":  pck.Version,
+		"url":      fmt.Sprintf("%sapi/packages/%s/hex/tarballs/%s", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(hex_module.TarballFilename(pck.Name, pck.Version))),
+		"html_url": fmt.Sprintf("%s/-/packages/hex/%s/%s", ctx.Package.Owner.HTMLURL(ctx), url.PathEscape(pck.Name), url.PathEscape(pck.Version)),
+	})
+}
+
+// UploadDocsFile adds the documentation tarball to an existing package version
+func UploadDocsFile(ctx *context.Context) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	_, err = packages_service.AddFileToExistingPackage(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        ctx.PathParam("name"),
+			Version:     ctx.PathParam("version"),
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: hex_module.DocsFilename,
+			},
+			Creator:           ctx.Doer,
+			Data:              buf,
+			OverwriteExisting: true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrPackageNotExist:
+			apiError(ctx, http.StatusNotFound, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	termResponse(ctx, http.StatusCreated, map[string]any{
+		"url": fmt.Sprintf("%sapi/packages/%s/hex/docs/%s-%s.tar.gz", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(ctx.PathParam("name")), url.PathEscape(ctx.PathParam("version"))),
+	})
+}
+
+// DeletePackageVersion reverts the publication of a package version
+func DeletePackageVersion(ctx *context.Context) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        ctx.PathParam("name"),
+			Version:     ctx.PathParam("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	"code.gitea.io/gitea/modules/globallock"
 	"code.gitea.io/gitea/modules/json"
 	packages_module "code.gitea.io/gitea/modules/packages"
@@ -70,6 +71,18 @@ func handlePackageFile(ctx *context.Context, serveContent bool) {
 		return
 	}
 
+	if !resolveVirtualPackage(ctx, params.toInternalPackageName(), params.toInternalPackageNameLegacy()) {
+		return
+	}
+
+	if !checkPackageReadAccess(ctx, params) {
+		return
+	}
+
+	if params.IsMeta && serveRemoteMavenMetadata(ctx, params) {
+		return
+	}
+
 	if params.IsMeta && params.Version == "" {
 		serveMavenMetadata(ctx, params)
 	} else {
@@ -77,6 +90,32 @@ func handlePackageFile(ctx *context.Context, serveContent bool) {
 	}
 }
 
+// checkPackageReadAccess responds with an error if the package is private and the doer is not allowed to read it.
+// The metadata and the checksums are served without helper.ServePackageFile so the access must be checked upfront.
+func checkPackageReadAccess(ctx *context.Context, params parameters) bool {
+	for _, name := range []string{params.toInternalPackageName(), params.toInternalPackageNameLegacy()} {
+		p, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, name)
+		if err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				continue
+			}
+			apiError(ctx, http.StatusInternalServerError, err)
+			return false
+		}
+
+		accessMode, err := packages_service.GetPackageAccessMode(ctx, ctx.Package.AccessMode, ctx.Package.Owner, p, ctx.Doer)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return false
+		}
+		if accessMode < perm.AccessModeRead {
+			apiError(ctx, http.StatusUnauthorized, errors.New("user should have specific permission or be a site admin"))
+			return false
+		}
+	}
+	return true
+}
+
 func serveMavenMetadata(ctx *context.Context, params parameters) {
 	// path pattern: /com/foo/project/maven-metadata.xml[.md5/.sha1/.sha256/.sha512]
 	// in case there are legacy package names ("GroupID-ArtifactID") we need to check both, new packages always use ":" as separator("GroupID:ArtifactID")
@@ -120,6 +159,11 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
 	ctx.Resp.Header().Set("Last-Modified", lastModified)
 
+	writeMavenMetadata(ctx, params, xmlMetadataWithHeader)
+}
+
+// writeMavenMetadata writes the metadata or its checksum if a checksum file is requested
+func writeMavenMetadata(ctx *context.Context, params parameters, xmlMetadataWithHeader []byte) {
 	ext := strings.ToLower(path.Ext(params.Filename))
 	if isChecksumExtension(ext) {
 		var hash []byte
@@ -147,20 +191,19 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	_, _ = ctx.Resp.Write(xmlMetadataWithHeader)
 }
 
-func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
+func getPackageFile(ctx *context.Context, params parameters, filename string) (*packages_model.PackageFile, error) {
 	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
 	if errors.Is(err, util.ErrNotExist) {
 		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
 	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
-		}
-		return
+		return nil, err
 	}
 
+	return packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+}
+
+func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
 	filename := params.Filename
 
 	ext := strings.ToLower(path.Ext(filename))
@@ -168,9 +211,12 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 		filename = filename[:len(filename)-len(ext)]
 	}
 
-	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+	pf, err := getPackageFile(ctx, params, filename)
+	if errors.Is(err, util.ErrNotExist) && cacheRemotePackageFile(ctx, params, filename) {
+		pf, err = getPackageFile(ctx, params, filename)
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -0,0 +1,97 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package maven
+
+import (
+	"errors"
+	"net/http"
+	"path"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+)
+
+// getRemote returns the Maven remote of the owner if the package is not a local one
+func getRemote(ctx *context.Context, params parameters) (*remote_service.Remote, error) {
+	r, err := remote_service.GetRemote(ctx, ctx.Package.Owner, packages_model.TypeMaven)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, packageName := range []string{params.toInternalPackageName(), params.toInternalPackageNameLegacy()} {
+		if local, err := remote_service.IsLocalPackage(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, packageName); err != nil || local {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// remotePath returns the path of the file in the upstream, it's the same as the path in the registry
+func remotePath(ctx *context.Context, filename string) string {
+	return path.Join("/", path.Dir(ctx.PathParam("*")), filename)
+}
+
+// serveRemoteMavenMetadata serves the maven-metadata.xml of the upstream (or its checksum), it returns false if there is none
+func serveRemoteMavenMetadata(ctx *context.Context, params parameters) bool {
+	r, err := getRemote(ctx, params)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return true
+	}
+	if r == nil {
+		return false
+	}
+
+	content, err := r.GetMetadata(ctx, remotePath(ctx, mavenMetadataFile))
+	if err != nil {
+		// the versions which have been cached already are served if the upstream is not available
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Warn("Unable to get the metadata of Maven package %s from the upstream: %v", params.toInternalPackageName(), err)
+		}
+		return false
+	}
+
+	writeMavenMetadata(ctx, params, content)
+	return true
+}
+
+// cacheRemotePackageFile fetches the file from the upstream, it returns false if the file can't be fetched
+func cacheRemotePackageFile(ctx *context.Context, params parameters, filename string) bool {
+	r, err := getRemote(ctx, params)
+	if err != nil {
+		log.Error("Unable to get the Maven remote of owner %d: %v", ctx.Package.Owner.ID, err)
+		return false
+	}
+	if r == nil {
+		return false
+	}
+
+	if _, err := r.MavenCachePackageFile(ctx, remotePath(ctx, filename), params.toInternalPackageName(), params.Version); err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Warn("Unable to cache Maven package file %s/%s/%s from the upstream: %v", params.toInternalPackageName(), params.Version, filename, err)
+		}
+		return false
+	}
+	return true
+}
+
+// resolveVirtualPackage switches the package context to the source of the virtual registry which has the package.
+// It returns false if an error response has been written.
+func resolveVirtualPackage(ctx *context.Context, packageNames ...string) bool {
+	if err := helper.ResolveVirtualPackage(ctx, packages_model.TypeMaven, packageNames...); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return false
+	}
+	return true
+}
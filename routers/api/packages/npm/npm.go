@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"code.gitea.io/gitea/models/db"
@@ -39,9 +40,9 @@ func apiError(ctx *context.Context, status int, obj any) {
 	})
 }
 
-// packageNameFromParams gets the package name from the url parameters
+// PackageNameFromParams gets the package name from the url parameters
 // Variations: /name/, /@scope/name/, /@scope%2Fname/
-func packageNameFromParams(ctx *context.Context) string {
+func PackageNameFromParams(ctx *context.Context) string {
 	scope := ctx.PathParam("scope")
 	id := ctx.PathParam("id")
 	if scope != "" {
@@ -52,7 +53,16 @@ func packageNameFromParams(ctx *context.Context) string {
 
 // PackageMetadata returns the metadata for a single package
 func PackageMetadata(ctx *context.Context) {
-	packageName := packageNameFromParams(ctx)
+	packageName := PackageNameFromParams(ctx)
+	registryURL := setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/npm"
+
+	if !resolveVirtualPackage(ctx, packageName) {
+		return
+	}
+
+	if serveRemotePackageMetadata(ctx, packageName, registryURL) {
+		return
+	}
 
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
 	if err != nil {
@@ -70,33 +80,43 @@ func PackageMetadata(ctx *context.Context) {
 		return
 	}
 
-	resp := createPackageMetadataResponse(
-		setting.AppURL+"api/packages/"+ctx.Package.Owner.Name+"/npm",
-		pds,
-	)
+	resp := createPackageMetadataResponse(registryURL, pds)
 
 	ctx.JSON(http.StatusOK, resp)
 }
 
 // DownloadPackageFile serves the content of a package
 func DownloadPackageFile(ctx *context.Context) {
-	packageName := packageNameFromParams(ctx)
+	packageName := PackageNameFromParams(ctx)
 	packageVersion := ctx.PathParam("version")
 	filename := ctx.PathParam("filename")
 
-	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
-		ctx,
-		&packages_service.PackageInfo{
-			Owner:       ctx.Package.Owner,
-			PackageType: packages_model.TypeNpm,
-			Name:        packageName,
-			Version:     packageVersion,
-		},
-		&packages_service.PackageFileInfo{
-			Filename: filename,
-		},
-		ctx.Req.Method,
-	)
+	if !resolveVirtualPackage(ctx, packageName) {
+		return
+	}
+
+	openFile := func() (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+		return packages_service.OpenFileForDownloadByPackageNameAndVersion(
+			ctx,
+			&packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeNpm,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			&packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			ctx.Req.Method,
+		)
+	}
+
+	s, u, pf, err := openFile()
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		if cacheRemotePackageFile(ctx, packageName, packageVersion, filename) {
+			s, u, pf, err = openFile()
+		}
+	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -111,18 +131,31 @@ func DownloadPackageFile(ctx *context.Context) {
 
 // DownloadPackageFileByName finds the version and serves the contents of a package
 func DownloadPackageFileByName(ctx *context.Context) {
+	packageName := PackageNameFromParams(ctx)
 	filename := ctx.PathParam("filename")
 
-	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
-		OwnerID: ctx.Package.Owner.ID,
-		Type:    packages_model.TypeNpm,
-		Name: packages_model.SearchValue{
-			ExactMatch: true,
-			Value:      packageNameFromParams(ctx),
-		},
-		HasFileWithName: filename,
-		IsInternal:      optional.Some(false),
-	})
+	if !resolveVirtualPackage(ctx, packageName) {
+		return
+	}
+
+	searchVersions := func() ([]*packages_model.PackageVersion, error) {
+		pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+			OwnerID: ctx.Package.Owner.ID,
+			Type:    packages_model.TypeNpm,
+			Name: packages_model.SearchValue{
+				ExactMatch: true,
+				Value:      packageName,
+			},
+			HasFileWithName: filename,
+			IsInternal:      optional.Some(false),
+		})
+		return pvs, err
+	}
+
+	pvs, err := searchVersions()
+	if err == nil && len(pvs) == 0 && cacheRemotePackageFile(ctx, packageName, "", filename) {
+		pvs, err = searchVersions()
+	}
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -254,7 +287,7 @@ func DeletePreview(ctx *context.Context) {
 
 // DeletePackageVersion deletes the package version
 func DeletePackageVersion(ctx *context.Context) {
-	packageName := packageNameFromParams(ctx)
+	packageName := PackageNameFromParams(ctx)
 	packageVersion := ctx.PathParam("version")
 
 	err := packages_service.RemovePackageVersionByNameAndVersion(
@@ -281,7 +314,7 @@ func DeletePackageVersion(ctx *context.Context) {
 
 // DeletePackage deletes the package and all versions
 func DeletePackage(ctx *context.Context) {
-	packageName := packageNameFromParams(ctx)
+	packageName := PackageNameFromParams(ctx)
 
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
 	if err != nil {
@@ -306,7 +339,7 @@ func DeletePackage(ctx *context.Context) {
 
 // ListPackageTags returns all tags for a package
 func ListPackageTags(ctx *context.Context) {
-	packageName := packageNameFromParams(ctx)
+	packageName := PackageNameFromParams(ctx)
 
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
 	if err != nil {
@@ -331,7 +364,7 @@ func ListPackageTags(ctx *context.Context) {
 
 // AddPackageTag adds a tag to the package
 func AddPackageTag(ctx *context.Context) {
-	packageName := packageNameFromParams(ctx)
+	packageName := PackageNameFromParams(ctx)
 
 	body, err := io.ReadAll(ctx.Req.Body)
 	if err != nil {
@@ -362,7 +395,7 @@ func AddPackageTag(ctx *context.Context) {
 
 // DeletePackageTag deletes a package tag
 func DeletePackageTag(ctx *context.Context) {
-	packageName := packageNameFromParams(ctx)
+	packageName := PackageNameFromParams(ctx)
 
 	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
 	if err != nil {
@@ -430,7 +463,7 @@ func setPackageTag(ctx std_ctx.Context, tag string, pv *packages_model.PackageVe
 }
 
 func PackageSearch(ctx *context.Context) {
-	pvs, total, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+	opts := &packages_model.PackageSearchOptions{
 		OwnerID:    ctx.Package.Owner.ID,
 		Type:       packages_model.TypeNpm,
 		IsInternal: optional.Some(false),
@@ -442,7 +475,13 @@ func PackageSearch(ctx *context.Context) {
 			ctx.FormInt("from"),
 			ctx.FormInt("size"),
 		),
-	})
+	}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, opts); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pvs, total, err := packages_model.SearchLatestVersions(ctx, opts)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
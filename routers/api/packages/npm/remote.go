@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"errors"
+	"net/http"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+)
+
+// getRemote returns the npm remote of the owner if the package is not a local one
+func getRemote(ctx *context.Context, packageName string) (*remote_service.Remote, error) {
+	r, err := remote_service.GetRemote(ctx, ctx.Package.Owner, packages_model.TypeNpm)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if local, err := remote_service.IsLocalPackage(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName); err != nil || local {
+		return nil, err
+	}
+	return r, nil
+}
+
+// serveRemotePackageMetadata serves the package document of the upstream, it returns false if there is none
+func serveRemotePackageMetadata(ctx *context.Context, packageName, registryURL string) bool {
+	r, err := getRemote(ctx, packageName)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return true
+	}
+	if r == nil {
+		return false
+	}
+
+	doc, err := r.NpmPackageMetadata(ctx, packageName, registryURL)
+	if err != nil {
+		// the versions which have been cached already are served if the upstream is not available
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Warn("Unable to get the metadata of npm package %s from the upstream: %v", packageName, err)
+		}
+		return false
+	}
+
+	ctx.JSON(http.StatusOK, doc)
+	return true
+}
+
+// cacheRemotePackageFile fetches the file from the upstream, it returns false if the file can't be fetched
+func cacheRemotePackageFile(ctx *context.Context, packageName, packageVersion, filename string) bool {
+	r, err := getRemote(ctx, packageName)
+	if err != nil {
+		log.Error("Unable to get the npm remote of owner %d: %v", ctx.Package.Owner.ID, err)
+		return false
+	}
+	if r == nil {
+		return false
+	}
+
+	if packageVersion == "" {
+		if packageVersion, err = r.NpmFindVersionByFilename(ctx, packageName, filename); err != nil {
+			if !errors.Is(err, util.ErrNotExist) {
+				log.Warn("Unable to find the version of npm package file %s/%s in the upstream: %v", packageName, filename, err)
+			}
+			return false
+		}
+	}
+
+	if _, err := r.NpmCachePackageFile(ctx, packageName, packageVersion, filename); err != nil {
+		if !errors.Is(err, util.ErrNotExist) {
+			log.Warn("Unable to cache npm package file %s@%s/%s from the upstream: %v", packageName, packageVersion, filename, err)
+		}
+		return false
+	}
+	return true
+}
+
+// resolveVirtualPackage switches the package context to the source of the virtual registry which has the package.
+// It returns false if an error response has been written.
+func resolveVirtualPackage(ctx *context.Context, packageNames ...string) bool {
+	if err := helper.ResolveVirtualPackage(ctx, packages_model.TypeNpm, packageNames...); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return false
+	}
+	return true
+}
@@ -31,22 +31,30 @@ func apiError(ctx *context.Context, status int, obj any) {
 
 // EnumeratePackages serves the package list
 func EnumeratePackages(ctx *context.Context) {
-	packages, err := packages_model.GetVersionsByPackageType(ctx, ctx.Package.Owner.ID, packages_model.TypeRubyGems)
+	opts, err := searchOptions(ctx)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	enumeratePackages(ctx, "specs.4.8", packages)
+	pvs, _, err := packages_model.SearchVersions(ctx, opts)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	enumeratePackages(ctx, "specs.4.8", pvs)
 }
 
 // EnumeratePackagesLatest serves the list of the latest version of every package
 func EnumeratePackagesLatest(ctx *context.Context) {
-	pvs, _, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
-		OwnerID:    ctx.Package.Owner.ID,
-		Type:       packages_model.TypeRubyGems,
-		IsInternal: optional.Some(false),
-	})
+	opts, err := searchOptions(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pvs, _, err := packages_model.SearchLatestVersions(ctx, opts)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -55,6 +63,16 @@ func EnumeratePackagesLatest(ctx *context.Context) {
 	enumeratePackages(ctx, "latest_specs.4.8", pvs)
 }
 
+// searchOptions returns the options to search the packages of the owner the doer can see
+func searchOptions(ctx *context.Context) (*packages_model.PackageSearchOptions, error) {
+	opts := &packages_model.PackageSearchOptions{
+		OwnerID:    ctx.Package.Owner.ID,
+		Type:       packages_model.TypeRubyGems,
+		IsInternal: optional.Some(false),
+	}
+	return opts, packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, opts)
+}
+
 // EnumeratePackagesPreRelease is not supported and serves an empty list
 func EnumeratePackagesPreRelease(ctx *context.Context) {
 	enumeratePackages(ctx, "prerelease_specs.4.8", []*packages_model.PackageVersion{})
@@ -326,6 +344,11 @@ func GetAllPackagesVersions(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
+	packages, err = packages_service.FilterPrivatePackages(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, packages)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
 
 	ephemeralCache := cache.NewEphemeralCache()
 	out := &strings.Builder{}
@@ -456,11 +479,12 @@ func makeGemFullFileName(gemName, version, platform string) string {
 }
 
 func getVersionsByFilename(ctx *context.Context, filename string) ([]*packages_model.PackageVersion, error) {
-	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
-		OwnerID:         ctx.Package.Owner.ID,
-		Type:            packages_model.TypeRubyGems,
-		HasFileWithName: filename,
-		IsInternal:      optional.Some(false),
-	})
+	opts, err := searchOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.HasFileWithName = filename
+
+	pvs, _, err := packages_model.SearchVersions(ctx, opts)
 	return pvs, err
 }
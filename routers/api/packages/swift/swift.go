@@ -112,6 +112,11 @@ func buildPackageID(scope, name string) string {
 	return scope + "." + name
 }
 
+// PackageNameFromParams gets the package name from the url parameters
+func PackageNameFromParams(ctx *context.Context) string {
+	return buildPackageID(ctx.PathParam("scope"), ctx.PathParam("name"))
+}
+
 type Release struct {
 	URL string `json:"url"`
 }
@@ -456,14 +461,20 @@ func LookupPackageIdentifiers(ctx *context.Context) {
 		return
 	}
 
-	pvs, _, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+	opts := &packages_model.PackageSearchOptions{
 		OwnerID: ctx.Package.Owner.ID,
 		Type:    packages_model.TypeSwift,
 		Properties: map[string]string{
 			swift_module.PropertyRepositoryURL: url,
 		},
 		IsInternal: optional.Some(false),
-	})
+	}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, opts); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pvs, _, err := packages_model.SearchLatestVersions(ctx, opts)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"net/http"
+	"strings"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/services/auth"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// downloadTokenParameter is the query parameter of the download urls which contains the token of the user
+const downloadTokenParameter = "download_token"
+
+var _ auth.Method = &Auth{}
+
+// Auth authenticates the download urls created for Terraform, which doesn't send credentials when it downloads archives
+type Auth struct{}
+
+func (a *Auth) Name() string {
+	return "terraform"
+}
+
+// Verify extracts the user from the download token
+func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) (*user_model.User, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, nil //nolint:nilnil // the auth method is not applicable
+	}
+	if !strings.Contains(req.URL.Path, "/terraform/") {
+		return nil, nil //nolint:nilnil // the auth method is not applicable
+	}
+
+	token := req.URL.Query().Get(downloadTokenParameter)
+	if token == "" {
+		return nil, nil //nolint:nilnil // the auth method is not applicable
+	}
+
+	packageMeta, err := packages_service.ParseAuthorizationToken(token)
+	if err != nil {
+		log.Trace("ParseAuthorizationToken: %v", err)
+		return nil, err
+	}
+
+	var u *user_model.User
+	if packageMeta.UserID == user_model.ActionsUserID {
+		u = user_model.NewActionsUserWithTaskID(packageMeta.ActionsUserTaskID)
+	} else if u, err = user_model.GetUserByID(req.Context(), packageMeta.UserID); err != nil {
+		return nil, err
+	}
+
+	store.GetData()["IsApiToken"] = true
+	store.GetData()["ApiTokenScope"] = packageMeta.Scope
+
+	return u, nil
+}
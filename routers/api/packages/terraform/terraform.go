@@ -0,0 +1,612 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	auth_service "code.gitea.io/gitea/services/auth"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	terraform_service "code.gitea.io/gitea/services/packages/terraform"
+
+	"github.com/hashicorp/go-version"
+)
+
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.JSON(status, struct {
+		Errors []string `json:"errors"`
+	}{
+		Errors: []string{
+			message,
+		},
+	})
+}
+
+func baseURL(ctx *context.Context) string {
+	return setting.AppURL + "api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/terraform"
+}
+
+// downloadURL adds a download token of the doer to the url because Terraform doesn't send credentials when it downloads archives
+func downloadURL(ctx *context.Context, u string) (string, error) {
+	if ctx.Doer == nil {
+		return u, nil
+	}
+
+	scope := auth_model.AccessTokenScopeReadPackage
+	if publicOnly, _ := auth_service.GetAccessScope(ctx.Data).PublicOnly(); publicOnly {
+		scope = auth_model.AccessTokenScopePublicOnly + "," + scope
+	}
+
+	token, err := packages_service.CreateAuthorizationToken(ctx.Doer, scope)
+	if err != nil {
+		return "", err
+	}
+	return u + "?" + downloadTokenParameter + "=" + url.QueryEscape(token), nil
+}
+
+func moduleName(ctx *context.Context) (string, bool) {
+	name, system := ctx.PathParam("name"), ctx.PathParam("system")
+	if !terraform_module.IsValidName(name) || !terraform_module.IsValidName(system) {
+		return "", false
+	}
+	return name + "/" + system, true
+}
+
+// ModuleNameFromParams gets the package name of the module from the url parameters
+func ModuleNameFromParams(ctx *context.Context) string {
+	name, _ := moduleName(ctx)
+	return name
+}
+
+type moduleVersions struct {
+	Modules []*moduleVersionList `json:"modules"`
+}
+
+type moduleVersionList struct {
+	Versions []*moduleVersion `json:"versions"`
+}
+
+type moduleVersion struct {
+	Version string `json:"version"`
+}
+
+// ModuleVersions lists the available versions of a module
+func ModuleVersions(ctx *context.Context) {
+	packageName, ok := moduleName(ctx)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, packageName)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	versions := make([]*moduleVersion, 0, len(pvs))
+	for _, pv := range pvs {
+		versions = append(versions, &moduleVersion{Version: pv.Version})
+	}
+
+	ctx.JSON(http.StatusOK, &moduleVersions{
+		Modules: []*moduleVersionList{{Versions: versions}},
+	})
+}
+
+// ModuleDownload points Terraform to the archive of the module version
+func ModuleDownload(ctx *context.Context) {
+	packageName, ok := moduleName(ctx)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, packageName, ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pfs) == 0 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	u, err := downloadURL(ctx, baseURL(ctx)+"/modules/"+packageName+"/"+url.PathEscape(pv.Version)+"/"+url.PathEscape(pfs[0].Name))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("X-Terraform-Get", u)
+	ctx.Status(http.StatusNoContent)
+}
+
+// UploadModule creates a new module version from a tar.gz archive
+func UploadModule(ctx *context.Context) {
+	packageName, ok := moduleName(ctx)
+	if !ok {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	packageVersion := ctx.PathParam("version")
+	if _, err := version.NewSemver(packageVersion); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := terraform_module.ParseModuleArchive(buf)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: terraform_module.ModuleFilename(ctx.PathParam("name"), ctx.PathParam("system"), packageVersion),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadModuleFile serves the archive of a module version
+func DownloadModuleFile(ctx *context.Context) {
+	packageName, ok := moduleName(ctx)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        packageName,
+			Version:     ctx.PathParam("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ctx.PathParam("filename"),
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+type providerVersions struct {
+	Versions []*providerVersion `json:"versions"`
+}
+
+type providerVersion struct {
+	Version   string              `json:"version"`
+	Protocols []string            `json:"protocols"`
+	Platforms []*providerPlatform `json:"platforms"`
+}
+
+type providerPlatform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+type providerDownload struct {
+	Protocols           []string            `json:"protocols"`
+	OS                  string              `json:"os"`
+	Arch                string              `json:"arch"`
+	Filename            string              `json:"filename"`
+	DownloadURL         string              `json:"download_url"`
+	ShasumsURL          string              `json:"shasums_url"`
+	ShasumsSignatureURL string              `json:"shasums_signature_url"`
+	Shasum              string              `json:"shasum"`
+	SigningKeys         providerSigningKeys `json:"signing_keys"`
+}
+
+type providerSigningKeys struct {
+	GPGPublicKeys []*providerGPGPublicKey `json:"gpg_public_keys"`
+}
+
+type providerGPGPublicKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+func providerProtocols(pd *packages_model.PackageDescriptor) []string {
+	if protocols := pd.Metadata.(*terraform_module.Metadata).Protocols; len(protocols) > 0 {
+		return protocols
+	}
+	return terraform_module.DefaultProtocols
+}
+
+// ProviderVersions lists the available versions of a provider and their platforms
+func ProviderVersions(ctx *context.Context) {
+	providerType := ctx.PathParam("type")
+	if !terraform_module.IsValidProviderType(providerType) {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, providerType)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versions := make([]*providerVersion, 0, len(pds))
+	for _, pd := range pds {
+		platforms := make([]*providerPlatform, 0, len(pd.Files))
+		for _, pfd := range pd.Files {
+			if os := pfd.Properties.GetByName(terraform_module.PropertyOS); os != "" {
+				platforms = append(platforms, &providerPlatform{
+					OS:   os,
+					Arch: pfd.Properties.GetByName(terraform_module.PropertyArch),
+				})
+			}
+		}
+		if len(platforms) == 0 {
+			continue
+		}
+		sort.Slice(platforms, func(i, j int) bool {
+			if platforms[i].OS != platforms[j].OS {
+				return platforms[i].OS < platforms[j].OS
+			}
+			return platforms[i].Arch < platforms[j].Arch
+		})
+
+		versions = append(versions, &providerVersion{
+			Version:   pd.Version.Version,
+			Protocols: providerProtocols(pd),
+			Platforms: platforms,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, &providerVersions{
+		Versions: versions,
+	})
+}
+
+func getProviderDescriptor(ctx *context.Context) (*packages_model.PackageDescriptor, error) {
+	providerType := ctx.PathParam("type")
+	if !terraform_module.IsValidProviderType(providerType) {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, providerType, ctx.PathParam("version"))
+	if err != nil {
+		return nil, err
+	}
+	return packages_model.GetPackageDescriptor(ctx, pv)
+}
+
+// ProviderDownload returns the information to download and verify the archive of a provider version for a platform
+func ProviderDownload(ctx *context.Context) {
+	pd, err := getProviderDescriptor(ctx)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	var archive *packages_model.PackageFileDescriptor
+	for _, pfd := range pd.Files {
+		if pfd.Properties.GetByName(terraform_module.PropertyOS) == ctx.PathParam("os") && pfd.Properties.GetByName(terraform_module.PropertyArch) == ctx.PathParam("arch") {
+			archive = pfd
+			break
+		}
+	}
+	if archive == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	key, err := terraform_service.GetSigningKey(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versionURL := baseURL(ctx) + "/providers/" + url.PathEscape(pd.Package.Name) + "/" + url.PathEscape(pd.Version.Version) + "/"
+	shasumsFilename := terraform_module.ProviderShasumsFilename(pd.Package.Name, pd.Version.Version)
+
+	var urls [3]string
+	for i, filename := range []string{archive.File.Name, shasumsFilename, shasumsFilename + ".sig"} {
+		if urls[i], err = downloadURL(ctx, versionURL+url.PathEscape(filename)); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, &providerDownload{
+		Protocols:           providerProtocols(pd),
+		OS:                  ctx.PathParam("os"),
+		Arch:                ctx.PathParam("arch"),
+		Filename:            archive.File.Name,
+		DownloadURL:         urls[0],
+		ShasumsURL:          urls[1],
+		ShasumsSignatureURL: urls[2],
+		Shasum:              archive.Blob.HashSHA256,
+		SigningKeys: providerSigningKeys{
+			GPGPublicKeys: []*providerGPGPublicKey{
+				{
+					KeyID:      key.KeyID,
+					ASCIIArmor: key.ASCIIArmor,
+				},
+			},
+		},
+	})
+}
+
+// UploadProviderFile adds a platform archive or the manifest file to a provider version
+func UploadProviderFile(ctx *context.Context) {
+	providerType := ctx.PathParam("type")
+	if !terraform_module.IsValidProviderType(providerType) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	packageVersion := ctx.PathParam("version")
+	if _, err := version.NewSemver(packageVersion); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	filename := ctx.PathParam("filename")
+	isManifest := terraform_module.IsProviderManifestFilename(filename, providerType, packageVersion)
+
+	var properties map[string]string
+	if !isManifest {
+		pa, err := terraform_module.ParseProviderArchiveFilename(filename)
+		if err != nil || pa.Type != providerType || pa.Version != packageVersion {
+			apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidFilename)
+			return
+		}
+		properties = map[string]string{
+			terraform_module.PropertyOS:   pa.OS,
+			terraform_module.PropertyArch: pa.Arch,
+		}
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata := &terraform_module.Metadata{
+		Kind: terraform_module.KindProvider,
+	}
+	if isManifest {
+		if metadata.Protocols, err = terraform_module.ParseProviderManifest(buf); err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        providerType,
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator:    ctx.Doer,
+			Data:       buf,
+			IsLead:     isManifest,
+			Properties: properties,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageFile:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	// the version could have been created by an archive before the manifest
+	if isManifest {
+		raw, err := json.Marshal(metadata)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if pv.MetadataJSON != string(raw) {
+			pv.MetadataJSON = string(raw)
+			if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadProviderFile serves a file of a provider version or the signed checksums of its archives
+func DownloadProviderFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	shasumsFilename := terraform_module.ProviderShasumsFilename(ctx.PathParam("type"), ctx.PathParam("version"))
+	if filename == shasumsFilename || filename == shasumsFilename+".sig" {
+		serveProviderShasums(ctx, filename != shasumsFilename)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        ctx.PathParam("type"),
+			Version:     ctx.PathParam("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+func serveProviderShasums(ctx *context.Context, signature bool) {
+	pd, err := getProviderDescriptor(ctx)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	content := terraform_service.BuildProviderShasums(pd.Files)
+	if signature {
+		if content, err = terraform_service.SignProviderShasums(ctx, ctx.Package.Owner.ID, content); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	// the content changes if archives are added to the version, so it's served without a modification time
+	ctx.ServeContent(bytes.NewReader(content), &context.ServeHeaderOptions{
+		Filename: ctx.PathParam("filename"),
+	})
+}
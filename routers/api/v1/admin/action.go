@@ -40,6 +40,19 @@ func ListWorkflowJobs(ctx *context.APIContext) {
 	shared.ListJobs(ctx, 0, 0, 0)
 }
 
+// ListQueuedJobs lists all jobs waiting for runners
+func ListQueuedJobs(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/queue admin listAdminQueuedJobs
+	// ---
+	// summary: Lists all jobs waiting for runners, grouped by their owners and runs-on labels
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueuedJobsResponse"
+	shared.ListQueuedJobs(ctx, 0, 0)
+}
+
 // ListWorkflowRuns Lists all runs
 func ListWorkflowRuns(ctx *context.APIContext) {
 	// swagger:operation GET /admin/actions/runs admin listAdminWorkflowRuns
@@ -91,3 +104,71 @@ func ListWorkflowRuns(ctx *context.APIContext) {
 
 	shared.ListRuns(ctx, 0, 0)
 }
+
+// ListActionCaches lists the caches of actions/cache of all repositories
+func ListActionCaches(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/caches admin listAdminActionCaches
+	// ---
+	// summary: Lists the caches of actions/cache of all repositories
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: key
+	//   in: query
+	//   description: the key of the caches
+	//   type: string
+	// - name: ref
+	//   in: query
+	//   description: the full git reference of the caches, e.g. refs/heads/main
+	//   type: string
+	// - name: sort
+	//   in: query
+	//   description: the property to sort the caches by
+	//   type: string
+	//   enum: [created_at, last_accessed_at, size_in_bytes]
+	//   default: last_accessed_at
+	// - name: direction
+	//   in: query
+	//   description: the direction to sort the caches
+	//   type: string
+	//   enum: [asc, desc]
+	//   default: desc
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionCachesList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+
+	shared.ListCaches(ctx, 0)
+}
+
+// DeleteActionCache deletes a cache of actions/cache
+func DeleteActionCache(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/actions/caches/{cache_id} admin deleteAdminActionCache
+	// ---
+	// summary: Delete a cache of actions/cache
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cache_id
+	//   in: path
+	//   description: id of the cache
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: cache has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteCache(ctx, 0, ctx.PathParamInt64("cache_id"))
+}
@@ -90,6 +90,7 @@ import (
 	"code.gitea.io/gitea/routers/api/v1/packages"
 	"code.gitea.io/gitea/routers/api/v1/repo"
 	"code.gitea.io/gitea/routers/api/v1/settings"
+	"code.gitea.io/gitea/routers/api/v1/shared"
 	"code.gitea.io/gitea/routers/api/v1/user"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/actions"
@@ -313,7 +314,8 @@ func tokenRequiresScopes(requiredScopeCategories ...auth_model.AccessTokenScopeC
 
 		// use the http method to determine the access level
 		requiredScopeLevel := auth_model.Read
-		if ctx.Req.Method == http.MethodPost || ctx.Req.Method == http.MethodPut || ctx.Req.Method == http.MethodPatch || ctx.Req.Method == http.MethodDelete {
+		switch ctx.Req.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, shared.MethodLock, shared.MethodUnlock:
 			requiredScopeLevel = auth_model.Write
 		}
 
@@ -575,6 +577,26 @@ func reqWebhooksEnabled() func(ctx *context.APIContext) {
 	}
 }
 
+// reqTerraformStateEnabled requires the Terraform state backend to be enabled in the config.
+func reqTerraformStateEnabled() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !setting.TerraformState.Enabled {
+			ctx.APIErrorNotFound()
+			return
+		}
+	}
+}
+
+// reqNotActionsUser requires the doer not to be the user of an Actions task, which can't own anything.
+func reqNotActionsUser() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if ctx.Doer != nil && ctx.Doer.IsGiteaActions() {
+			ctx.APIError(http.StatusForbidden, "the token of an Actions task can't be used")
+			return
+		}
+	}
+}
+
 // reqStarsEnabled requires Starring to be enabled in the config.
 func reqStarsEnabled() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -920,6 +942,10 @@ func Routes() *web.Router {
 			})
 			m.Get("/runs", reqToken(), reqReaderCheck, act.ListWorkflowRuns)
 			m.Get("/jobs", reqToken(), reqReaderCheck, act.ListWorkflowJobs)
+			m.Get("/queue", reqToken(), reqOwnerCheck, act.ListQueuedJobs)
+			m.Combo("/queue-limit").
+				Get(reqToken(), reqOwnerCheck, act.GetQueueLimit).
+				Put(reqToken(), reqOwnerCheck, bind(api.EditActionQueueLimitOption{}), act.UpdateQueueLimit)
 		})
 	}
 
@@ -1049,6 +1075,7 @@ func Routes() *web.Router {
 
 				m.Get("/runs", reqToken(), user.ListWorkflowRuns)
 				m.Get("/jobs", reqToken(), user.ListWorkflowJobs)
+				m.Get("/queue", reqToken(), user.ListQueuedJobs)
 			})
 
 			m.Get("/followers", user.ListMyFollowers)
@@ -1128,6 +1155,22 @@ func Routes() *web.Router {
 					m.Delete("", user.UnblockUser)
 				}, context.UserAssignmentAPI(), checkTokenPublicOnly())
 			})
+
+			m.Group("/terraform/state", func() {
+				m.Get("", user.ListTerraformStates)
+				m.Group("/{name}", func() {
+					m.Combo("").Get(user.GetTerraformState).
+						Post(user.UpdateTerraformState).
+						Delete(user.DeleteTerraformState)
+					m.Methods(shared.MethodLock, "", user.LockTerraformState)
+					m.Methods(shared.MethodUnlock, "", user.UnlockTerraformState)
+					m.Combo("/lock").Get(user.GetTerraformStateLock).
+						Post(user.LockTerraformState).
+						Delete(user.UnlockTerraformState)
+					m.Get("/versions", user.ListTerraformStateVersions)
+					m.Get("/versions/{version}", user.GetTerraformStateVersion)
+				})
+			}, reqTerraformStateEnabled(), reqNotActionsUser())
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryUser), reqToken())
 
 		// Repositories (requires repo scope, org scope)
@@ -1176,6 +1219,7 @@ func Routes() *web.Router {
 				m.Group("/actions/jobs", func() {
 					m.Get("/{job_id}", repo.GetWorkflowJob)
 					m.Get("/{job_id}/logs", repo.DownloadActionsRunJobLogs)
+					m.Get("/{job_id}/summaries", repo.ListActionsRunJobSummaries)
 				}, reqToken(), reqRepoReader(unit.TypeActions))
 
 				m.Group("/hooks/git", func() {
@@ -1261,7 +1305,32 @@ func Routes() *web.Router {
 							m.Post("/rerun", reqToken(), reqRepoWriter(unit.TypeActions), repo.RerunWorkflowRun)
 							m.Get("/jobs", repo.ListWorkflowRunJobs)
 							m.Post("/jobs/{job_id}/rerun", reqToken(), reqRepoWriter(unit.TypeActions), repo.RerunWorkflowJob)
+							m.Post("/jobs/{job_id}/approval", reqToken(), reqNotActionsUser(), reqRepoWriter(unit.TypeActions), bind(api.ReviewActionApprovalOption{}), repo.ReviewActionJobApproval)
+							m.Get("/approvals", repo.ListActionRunApprovals)
 							m.Get("/artifacts", repo.GetArtifactsOfRun)
+							m.Combo("/pending_deployments").Get(repo.GetPendingDeployments).
+								Post(reqToken(), bind(api.ReviewActionDeploymentsOption{}), repo.ReviewPendingDeployments)
+						})
+					})
+					m.Group("/environments", func() {
+						m.Get("", repo.ListActionEnvironments)
+						m.Group("/{environment_name}", func() {
+							m.Combo("").Get(repo.GetActionEnvironment).
+								Put(reqToken(), reqAdmin(), bind(api.CreateOrUpdateActionEnvironmentOption{}), repo.CreateOrUpdateActionEnvironment).
+								Delete(reqToken(), reqAdmin(), repo.DeleteActionEnvironment)
+							m.Get("/deployments", repo.ListActionEnvironmentDeployments)
+							m.Group("/secrets", func() {
+								m.Get("", repo.ListActionEnvironmentSecrets)
+								m.Combo("/{secretname}").
+									Put(bind(api.CreateOrUpdateSecretOption{}), repo.CreateOrUpdateActionEnvironmentSecret).
+									Delete(repo.DeleteActionEnvironmentSecret)
+							}, reqToken(), reqAdmin())
+							m.Group("/variables", func() {
+								m.Get("", repo.ListActionEnvironmentVariables)
+								m.Combo("/{variablename}").
+									Put(bind(api.CreateVariableOption{}), repo.CreateOrUpdateActionEnvironmentVariable).
+									Delete(repo.DeleteActionEnvironmentVariable)
+							}, reqToken(), reqAdmin())
 						})
 					})
 					m.Get("/artifacts", repo.GetArtifacts)
@@ -1270,7 +1339,26 @@ func Routes() *web.Router {
 						m.Delete("", reqRepoWriter(unit.TypeActions), repo.DeleteArtifact)
 					})
 					m.Get("/artifacts/{artifact_id}/zip", repo.DownloadArtifact)
+					m.Combo("/caches").Get(repo.ListActionCaches).
+						Delete(reqToken(), reqRepoWriter(unit.TypeActions), repo.DeleteActionCachesByKey)
+					m.Delete("/caches/{cache_id}", reqToken(), reqRepoWriter(unit.TypeActions), repo.DeleteActionCache)
+					m.Get("/cache/usage", repo.GetActionCacheUsage)
 				}, reqRepoReader(unit.TypeActions), context.ReferencesGitRepo(true))
+				m.Group("/terraform/state", func() {
+					m.Get("", repo.ListTerraformStates)
+					m.Group("/{name}", func() {
+						m.Combo("").Get(repo.GetTerraformState).
+							Post(reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.UpdateTerraformState).
+							Delete(reqAdmin(), repo.DeleteTerraformState)
+						m.Methods(shared.MethodLock, "", reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.LockTerraformState)
+						m.Methods(shared.MethodUnlock, "", reqRepoWriter(unit.TypeCode), repo.UnlockTerraformState)
+						m.Combo("/lock").Get(repo.GetTerraformStateLock).
+							Post(reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.LockTerraformState).
+							Delete(reqRepoWriter(unit.TypeCode), repo.UnlockTerraformState)
+						m.Get("/versions", repo.ListTerraformStateVersions)
+						m.Get("/versions/{version}", repo.GetTerraformStateVersion)
+					})
+				}, reqTerraformStateEnabled(), reqToken(), reqRepoReader(unit.TypeCode))
 				m.Group("/keys", func() {
 					m.Combo("").Get(repo.ListDeployKeys).
 						Post(bind(api.CreateKeyOption{}), repo.CreateDeployKey)
@@ -1584,6 +1672,8 @@ func Routes() *web.Router {
 					m.Get("", packages.GetPackage)
 					m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
 					m.Get("/files", packages.ListPackageFiles)
+					m.Get("/sbom", packages.GetPackageSBOM)
+					m.Get("/vulnerabilities", packages.ListPackageVulnerabilities)
 				})
 
 				m.Group("/-", func() {
@@ -1622,6 +1712,34 @@ func Routes() *web.Router {
 				reqOrgOwnership(),
 				org.NewAction(),
 			)
+			m.Group("/actions/runner-groups", func() {
+				m.Combo("").Get(org.ListRunnerGroups).
+					Post(bind(api.CreateActionRunnerGroupOption{}), org.CreateRunnerGroup)
+				m.Group("/{group_id}", func() {
+					m.Combo("").Get(org.GetRunnerGroup).
+						Patch(bind(api.EditActionRunnerGroupOption{}), org.EditRunnerGroup).
+						Delete(org.DeleteRunnerGroup)
+					m.Get("/runners", org.ListRunnerGroupRunners)
+					m.Combo("/runners/{runner_id}").
+						Put(org.AddRunnerGroupRunner).
+						Delete(org.RemoveRunnerGroupRunner)
+				})
+			}, reqToken(), reqOrgOwnership())
+			m.Group("/terraform/state", func() {
+				m.Get("", org.ListTerraformStates)
+				m.Group("/{name}", func() {
+					m.Combo("").Get(org.GetTerraformState).
+						Post(reqOrgOwnership(), org.UpdateTerraformState).
+						Delete(reqOrgOwnership(), org.DeleteTerraformState)
+					m.Methods(shared.MethodLock, "", reqOrgOwnership(), org.LockTerraformState)
+					m.Methods(shared.MethodUnlock, "", reqOrgOwnership(), org.UnlockTerraformState)
+					m.Combo("/lock").Get(org.GetTerraformStateLock).
+						Post(reqOrgOwnership(), org.LockTerraformState).
+						Delete(reqOrgOwnership(), org.UnlockTerraformState)
+					m.Get("/versions", org.ListTerraformStateVersions)
+					m.Get("/versions/{version}", org.GetTerraformStateVersion)
+				})
+			}, reqTerraformStateEnabled(), reqToken(), reqOrgMembership())
 			m.Group("/public_members", func() {
 				m.Get("", org.ListPublicMembers)
 				m.Combo("/{username}").Get(org.IsPublicMember).
@@ -1734,6 +1852,9 @@ func Routes() *web.Router {
 				})
 				m.Get("/runs", admin.ListWorkflowRuns)
 				m.Get("/jobs", admin.ListWorkflowJobs)
+				m.Get("/queue", admin.ListQueuedJobs)
+				m.Get("/caches", admin.ListActionCaches)
+				m.Delete("/caches/{cache_id}", admin.DeleteActionCache)
 			})
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryAdmin), reqToken(), reqSiteAdmin())
 
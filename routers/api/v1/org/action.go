@@ -184,6 +184,11 @@ func (Action) CreateRegistrationToken(ctx *context.APIContext) {
 	//   description: name of the organization
 	//   type: string
 	//   required: true
+	// - name: ephemeral
+	//   in: query
+	//   description: create a single-use token which expires after [actions] EPHEMERAL_TOKEN_EXPIRY, the runner registered with it will be deleted after it has run one task
+	//   type: boolean
+	//   required: false
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/RegistrationToken"
@@ -682,6 +687,77 @@ func (Action) ListWorkflowRuns(ctx *context.APIContext) {
 	shared.ListRuns(ctx, ctx.Org.Organization.ID, 0)
 }
 
+// ListQueuedJobs list the jobs of an organization waiting for runners
+func (Action) ListQueuedJobs(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/queue organization getOrgActionsQueuedJobs
+	// ---
+	// summary: List the jobs of an organization waiting for runners, grouped by their runs-on labels
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueuedJobsResponse"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListQueuedJobs(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// GetQueueLimit get the max queue time of the jobs of an organization
+func (Action) GetQueueLimit(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/queue-limit organization getOrgActionsQueueLimit
+	// ---
+	// summary: Get the max time the jobs of an organization could wait for a runner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueueLimit"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.GetQueueLimit(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// UpdateQueueLimit update the max queue time of the jobs of an organization
+func (Action) UpdateQueueLimit(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/actions/queue-limit organization updateOrgActionsQueueLimit
+	// ---
+	// summary: Update the max time the jobs of an organization could wait for a runner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionQueueLimitOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueueLimit"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.UpdateQueueLimit(ctx, ctx.Org.Organization.ID, 0)
+}
+
 var _ actions_service.API = new(Action)
 
 // Action implements actions_service.API
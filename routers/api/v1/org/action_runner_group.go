@@ -0,0 +1,452 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func handleRunnerGroupError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIErrorNotFound(err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err)
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// getRunnerGroup gets the runner group of the organization by the "group_id" path parameter
+func getRunnerGroup(ctx *context.APIContext) *actions_model.ActionRunnerGroup {
+	group, err := actions_model.GetRunnerGroupByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("group_id"))
+	if err != nil {
+		handleRunnerGroupError(ctx, err)
+		return nil
+	}
+	return group
+}
+
+// getRunnerGroupRepoIDs converts the names of the repositories of the organization to their IDs
+func getRunnerGroupRepoIDs(ctx *context.APIContext, names []string) ([]int64, error) {
+	repoIDs := make([]int64, 0, len(names))
+	for _, name := range names {
+		repo, err := repo_model.GetRepositoryByName(ctx, ctx.Org.Organization.ID, name)
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil, util.NewInvalidArgumentErrorf("repository %q does not exist", name)
+		} else if err != nil {
+			return nil, err
+		}
+		repoIDs = append(repoIDs, repo.ID)
+	}
+	return repoIDs, nil
+}
+
+func respondRunnerGroup(ctx *context.APIContext, status int, group *actions_model.ActionRunnerGroup) {
+	apiGroup, err := convert.ToActionRunnerGroup(ctx, group)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(status, apiGroup)
+}
+
+// ListRunnerGroups list the runner groups of an organization
+func ListRunnerGroups(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runner-groups organization listOrgRunnerGroups
+	// ---
+	// summary: List the runner groups of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroupList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	groups, count, err := db.FindAndCount[actions_model.ActionRunnerGroup](ctx, actions_model.FindRunnerGroupOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OwnerID:     ctx.Org.Organization.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiGroups := make([]*api.ActionRunnerGroup, 0, len(groups))
+	for _, group := range groups {
+		apiGroup, err := convert.ToActionRunnerGroup(ctx, group)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiGroups = append(apiGroups, apiGroup)
+	}
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiGroups)
+}
+
+// CreateRunnerGroup create a runner group for an organization
+func CreateRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/actions/runner-groups organization createOrgRunnerGroup
+	// ---
+	// summary: Create a runner group for an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateActionRunnerGroupOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: a runner group with the same name already exists
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	form := web.GetForm(ctx).(*api.CreateActionRunnerGroupOption)
+	repoIDs, err := getRunnerGroupRepoIDs(ctx, form.Repositories)
+	if err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+
+	group := &actions_model.ActionRunnerGroup{
+		OwnerID:     ctx.Org.Organization.ID,
+		Name:        form.Name,
+		Description: form.Description,
+		RepoIDs:     repoIDs,
+		Workflows:   form.Workflows,
+	}
+	if err := actions_model.CreateRunnerGroup(ctx, group); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	respondRunnerGroup(ctx, http.StatusCreated, group)
+}
+
+// GetRunnerGroup get a runner group of an organization
+func GetRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runner-groups/{group_id} organization getOrgRunnerGroup
+	// ---
+	// summary: Get a runner group of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	group := getRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+	respondRunnerGroup(ctx, http.StatusOK, group)
+}
+
+// EditRunnerGroup edit a runner group of an organization
+func EditRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/actions/runner-groups/{group_id} organization editOrgRunnerGroup
+	// ---
+	// summary: Edit a runner group of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionRunnerGroupOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: a runner group with the same name already exists
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	group := getRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.EditActionRunnerGroupOption)
+	if form.Name != nil {
+		group.Name = *form.Name
+	}
+	if form.Description != nil {
+		group.Description = *form.Description
+	}
+	if form.Repositories != nil {
+		repoIDs, err := getRunnerGroupRepoIDs(ctx, *form.Repositories)
+		if err != nil {
+			handleRunnerGroupError(ctx, err)
+			return
+		}
+		group.RepoIDs = repoIDs
+	}
+	if form.Workflows != nil {
+		group.Workflows = *form.Workflows
+	}
+	if err := actions_model.UpdateRunnerGroup(ctx, group); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	respondRunnerGroup(ctx, http.StatusOK, group)
+}
+
+// DeleteRunnerGroup delete a runner group of an organization
+func DeleteRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/actions/runner-groups/{group_id} organization deleteOrgRunnerGroup
+	// ---
+	// summary: Delete a runner group of an organization, its runners are moved to the default group
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	group := getRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_model.DeleteRunnerGroup(ctx, group); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListRunnerGroupRunners list the runners of a runner group of an organization
+func ListRunnerGroupRunners(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runner-groups/{group_id}/runners organization listOrgRunnerGroupRunners
+	// ---
+	// summary: List the runners of a runner group of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/definitions/ActionRunnersResponse"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	group := getRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	runners, total, err := db.FindAndCount[actions_model.ActionRunner](ctx, actions_model.FindRunnerOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OwnerID:     ctx.Org.Organization.ID,
+		GroupID:     group.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := &api.ActionRunnersResponse{
+		TotalCount: total,
+		Entries:    make([]*api.ActionRunner, len(runners)),
+	}
+	for i, runner := range runners {
+		res.Entries[i] = convert.ToActionRunner(ctx, runner)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// getOrgRunner gets the runner of the organization by the "runner_id" path parameter
+func getOrgRunner(ctx *context.APIContext) *actions_model.ActionRunner {
+	runner, err := actions_model.GetRunnerByID(ctx, ctx.PathParamInt64("runner_id"))
+	if err != nil {
+		handleRunnerGroupError(ctx, err)
+		return nil
+	}
+	if runner.OwnerID != ctx.Org.Organization.ID || runner.RepoID != 0 {
+		ctx.APIErrorNotFound("Runner not found")
+		return nil
+	}
+	return runner
+}
+
+// AddRunnerGroupRunner move a runner of an organization to a runner group
+func AddRunnerGroupRunner(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/actions/runner-groups/{group_id}/runners/{runner_id} organization addOrgRunnerGroupRunner
+	// ---
+	// summary: Move a runner of an organization to a runner group
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	group := getRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+	runner := getOrgRunner(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_model.SetRunnerGroup(ctx, runner, group.ID); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveRunnerGroupRunner move a runner of a runner group back to the default group
+func RemoveRunnerGroupRunner(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/actions/runner-groups/{group_id}/runners/{runner_id} organization removeOrgRunnerGroupRunner
+	// ---
+	// summary: Move a runner of a runner group back to the default group
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	group := getRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+	runner := getOrgRunner(ctx)
+	if ctx.Written() {
+		return
+	}
+	if runner.GroupID != group.ID {
+		ctx.APIErrorNotFound("Runner not found in the group")
+		return
+	}
+	if err := actions_model.SetRunnerGroup(ctx, runner, 0); err != nil {
+		handleRunnerGroupError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
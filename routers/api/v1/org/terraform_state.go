@@ -0,0 +1,316 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListTerraformStates lists the Terraform states of an organization
+func ListTerraformStates(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/terraform/state organization orgListTerraformStates
+	// ---
+	// summary: List an organization's Terraform states
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListTerraformStates(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// GetTerraformState serves the latest version of a Terraform state
+func GetTerraformState(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/terraform/state/{name} organization orgGetTerraformState
+	// ---
+	// summary: Get the latest version of a Terraform state
+	// description: This endpoint is the address of the Terraform http backend.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the state document
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetTerraformState(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// UpdateTerraformState stores a new version of a Terraform state
+func UpdateTerraformState(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/terraform/state/{name} organization orgUpdateTerraformState
+	// ---
+	// summary: Store a new version of a Terraform state
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: ID
+	//   in: query
+	//   description: id of the lock held by the Terraform run, required if the state is locked
+	//   type: string
+	// - name: body
+	//   in: body
+	//   description: the state document
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateVersion"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "423":
+	//     description: the state is locked by another Terraform run
+
+	shared.UpdateTerraformState(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// DeleteTerraformState deletes a Terraform state with all its versions
+func DeleteTerraformState(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/terraform/state/{name} organization orgDeleteTerraformState
+	// ---
+	// summary: Delete a Terraform state with all its versions
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     description: the state is locked by a Terraform run
+
+	shared.DeleteTerraformState(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// GetTerraformStateLock gets a Terraform state with the info of its lock
+func GetTerraformStateLock(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/terraform/state/{name}/lock organization orgGetTerraformStateLock
+	// ---
+	// summary: Get a Terraform state with the info of its lock
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformState"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetTerraformStateLock(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// LockTerraformState locks a Terraform state, it handles the LOCK method of the Terraform http backend
+func LockTerraformState(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/terraform/state/{name}/lock organization orgLockTerraformState
+	// ---
+	// summary: Lock a Terraform state
+	// description: The LOCK method on the address of the state is handled in the same way.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: the lock info sent by Terraform
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformState"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     description: the state is locked by another Terraform run, the body contains the lock info of the lock holder
+
+	shared.LockTerraformState(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// UnlockTerraformState unlocks a Terraform state, it handles the UNLOCK method of the Terraform http backend
+func UnlockTerraformState(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/terraform/state/{name}/lock organization orgUnlockTerraformState
+	// ---
+	// summary: Unlock a Terraform state
+	// description: The UNLOCK method on the address of the state is handled in the same way.
+	//   The state is unlocked regardless of the lock holder if the body is empty.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: the lock info of the lock to release
+	//   schema:
+	//     type: object
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: the state is locked by another Terraform run, the body contains the lock info of the lock holder
+
+	shared.UnlockTerraformState(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// ListTerraformStateVersions lists the versions of a Terraform state
+func ListTerraformStateVersions(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/terraform/state/{name}/versions organization orgListTerraformStateVersions
+	// ---
+	// summary: List the versions of a Terraform state
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateVersionList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListTerraformStateVersions(ctx, ctx.Org.Organization.ID, 0)
+}
+
+// GetTerraformStateVersion serves a version of a Terraform state
+func GetTerraformStateVersion(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/terraform/state/{name}/versions/{version} organization orgGetTerraformStateVersion
+	// ---
+	// summary: Get a version of a Terraform state
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: number of the version
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the state document
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetTerraformStateVersion(ctx, ctx.Org.Organization.ID, 0)
+}
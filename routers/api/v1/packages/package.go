@@ -10,12 +10,14 @@ import (
 	"code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/optional"
+	sbom_module "code.gitea.io/gitea/modules/packages/sbom"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 	packages_service "code.gitea.io/gitea/services/packages"
+	sbom_service "code.gitea.io/gitea/services/packages/sbom"
 )
 
 // ListPackages gets all packages of an owner
@@ -43,7 +45,7 @@ func ListPackages(ctx *context.APIContext) {
 	//   in: query
 	//   description: package type filter
 	//   type: string
-	//   enum: [alpine, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, vagrant]
+	//   enum: [alpine, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, hex, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, terraform, vagrant]
 	// - name: q
 	//   in: query
 	//   description: name filter
@@ -56,13 +58,19 @@ func ListPackages(ctx *context.APIContext) {
 
 	listOptions := utils.GetListOptions(ctx)
 
-	apiPackages, count, err := searchPackages(ctx, &packages.PackageSearchOptions{
+	opts := &packages.PackageSearchOptions{
 		OwnerID:    ctx.Package.Owner.ID,
 		Type:       packages.Type(ctx.FormTrim("type")),
 		Name:       packages.SearchValue{Value: ctx.FormTrim("q")},
 		IsInternal: optional.Some(false),
 		Paginator:  &listOptions,
-	})
+	}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.Package.Owner, ctx.Doer, opts); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiPackages, count, err := searchPackages(ctx, opts)
 	if err != nil {
 		ctx.APIErrorInternal(err)
 		return
@@ -198,6 +206,122 @@ func ListPackageFiles(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, apiPackageFiles)
 }
 
+// GetPackageSBOM gets the software bill of materials of a package
+func GetPackageSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/sbom package getPackageSBOM
+	// ---
+	// summary: Gets the software bill of materials of a package
+	// description: The document is created from the dependencies declared in the metadata of Cargo, Composer, Maven, npm and PyPI packages.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: format
+	//   in: query
+	//   description: format of the document, defaults to cyclonedx
+	//   type: string
+	//   enum: [cyclonedx, spdx]
+	// responses:
+	//   "200":
+	//     description: "SBOM document"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pd := ctx.Package.Descriptor
+	if !sbom_service.IsSupportedType(pd.Package.Type) {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	format := ctx.FormString("format")
+	if format == "" {
+		format = sbom_module.FormatCycloneDX
+	}
+
+	content, err := sbom_service.EncodeDocument(ctx, pd, format)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", sbom_module.ContentType(format))
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(content)
+}
+
+// ListPackageVulnerabilities gets the known vulnerabilities in the dependencies of a package
+func ListPackageVulnerabilities(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/vulnerabilities package listPackageVulnerabilities
+	// ---
+	// summary: Gets the known vulnerabilities in the dependencies of a package
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageVulnerabilityList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pvs, err := packages.GetVulnerabilitiesByVersionID(ctx, ctx.Package.Descriptor.Version.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiVulnerabilities := make([]*api.PackageVulnerability, 0, len(pvs))
+	for _, pv := range pvs {
+		apiVulnerabilities = append(apiVulnerabilities, convert.ToPackageVulnerability(pv))
+	}
+
+	ctx.JSON(http.StatusOK, apiVulnerabilities)
+}
+
 // ListPackageVersions gets all versions of a package
 func ListPackageVersions(ctx *context.APIContext) {
 	// swagger:operation GET /packages/{owner}/{type}/{name} package listPackageVersions
@@ -529,6 +529,11 @@ func (Action) CreateRegistrationToken(ctx *context.APIContext) {
 	//   description: name of the repo
 	//   type: string
 	//   required: true
+	// - name: ephemeral
+	//   in: query
+	//   description: create a single-use token which expires after [actions] EPHEMERAL_TOKEN_EXPIRY, the runner registered with it will be deleted after it has run one task
+	//   type: boolean
+	//   required: false
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/RegistrationToken"
@@ -784,6 +789,92 @@ func (Action) ListWorkflowRuns(ctx *context.APIContext) {
 	shared.ListRuns(ctx, 0, repoID)
 }
 
+// ListQueuedJobs list the jobs of a repository waiting for runners
+func (Action) ListQueuedJobs(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/queue repository getRepoActionsQueuedJobs
+	// ---
+	// summary: List the jobs of a repository waiting for runners, grouped by their runs-on labels
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueuedJobsResponse"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.ListQueuedJobs(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// GetQueueLimit get the max queue time of the jobs of a repository
+func (Action) GetQueueLimit(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/queue-limit repository getRepoActionsQueueLimit
+	// ---
+	// summary: Get the max time the jobs of a repository could wait for a runner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueueLimit"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	shared.GetQueueLimit(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// UpdateQueueLimit update the max queue time of the jobs of a repository
+func (Action) UpdateQueueLimit(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/queue-limit repository updateRepoActionsQueueLimit
+	// ---
+	// summary: Update the max time the jobs of a repository could wait for a runner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionQueueLimitOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueueLimit"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	shared.UpdateQueueLimit(ctx, 0, ctx.Repo.Repository.ID)
+}
+
 var _ actions_service.API = new(Action)
 
 // Action implements actions_service.API
@@ -0,0 +1,149 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListActionRunApprovals lists the approval gates of a workflow run, including the reviewed ones
+func ListActionRunApprovals(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runs/{run}/approvals repository listActionRunApprovals
+	// ---
+	// summary: List the approval gates of a workflow run and who reviewed them
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionApprovalList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getCurrentRepoActionRunByID(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	approvals, count, err := db.FindAndCount[actions_model.ActionApproval](ctx, actions_model.FindApprovalsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+		RunID:       run.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiApprovals := make([]*api.ActionApproval, len(approvals))
+	for i, approval := range approvals {
+		apiApprovals[i], err = convert.ToActionApproval(ctx, approval, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiApprovals)
+}
+
+// ReviewActionJobApproval approves or rejects the approval gate of a workflow job
+func ReviewActionJobApproval(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/jobs/{job_id}/approval repository reviewActionJobApproval
+	// ---
+	// summary: Approve or reject the approval gate of a workflow job which is waiting for a review
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// - name: job_id
+	//   in: path
+	//   description: id of the job
+	//   type: integer
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReviewActionApprovalOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionApproval"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getCurrentRepoActionRunByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	job, err := actions_model.GetRunJobByRunAndID(ctx, run.ID, ctx.PathParamInt64("job_id"))
+	if err != nil {
+		handleActionEnvironmentError(ctx, err)
+		return
+	}
+
+	opt := web.GetForm(ctx).(*api.ReviewActionApprovalOption)
+	approval, err := actions_service.ReviewApprovalGate(ctx, job, ctx.Doer, opt.State == "approved", opt.Comment)
+	if err != nil {
+		handleActionEnvironmentError(ctx, err)
+		return
+	}
+	apiApproval, err := convert.ToActionApproval(ctx, approval, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiApproval)
+}
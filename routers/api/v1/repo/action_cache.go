@@ -0,0 +1,223 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/optional"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListActionCaches lists the caches of actions/cache of a repository
+func ListActionCaches(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/caches repository repoListActionCaches
+	// ---
+	// summary: List the caches of actions/cache of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: key
+	//   in: query
+	//   description: the key of the caches
+	//   type: string
+	// - name: ref
+	//   in: query
+	//   description: the full git reference of the caches, e.g. refs/heads/main
+	//   type: string
+	// - name: sort
+	//   in: query
+	//   description: the property to sort the caches by
+	//   type: string
+	//   enum: [created_at, last_accessed_at, size_in_bytes]
+	//   default: last_accessed_at
+	// - name: direction
+	//   in: query
+	//   description: the direction to sort the caches
+	//   type: string
+	//   enum: [asc, desc]
+	//   default: desc
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionCachesList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListCaches(ctx, ctx.Repo.Repository.ID)
+}
+
+// GetActionCacheUsage gets the cache usage of actions/cache of a repository
+func GetActionCacheUsage(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/cache/usage repository repoGetActionCacheUsage
+	// ---
+	// summary: Get the cache usage of actions/cache of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionCacheUsage"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	size, err := actions_model.GetCacheSizeOfRepo(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	count, err := db.Count[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		RepoID:   ctx.Repo.Repository.ID,
+		Complete: optional.Some(true),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.ActionCacheUsage{
+		FullName:                ctx.Repo.Repository.FullName(),
+		ActiveCachesSizeInBytes: size,
+		ActiveCachesCount:       count,
+	})
+}
+
+// DeleteActionCachesByKey deletes the caches of actions/cache of a repository by key
+func DeleteActionCachesByKey(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/caches repository repoDeleteActionCachesByKey
+	// ---
+	// summary: Delete the caches of actions/cache of a repository by key
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: key
+	//   in: query
+	//   description: the key of the caches
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: the full git reference of the caches, e.g. refs/heads/main
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionCachesList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	key := ctx.FormString("key")
+	if key == "" {
+		ctx.APIError(http.StatusBadRequest, "the key of the caches is required")
+		return
+	}
+	opts := actions_model.FindCachesOptions{
+		RepoID:   ctx.Repo.Repository.ID,
+		CacheKey: key,
+		Complete: optional.Some(true),
+	}
+	if ref := ctx.FormString("ref"); ref != "" {
+		opts.Refs = []string{ref}
+	}
+	caches, err := db.Find[actions_model.ActionCache](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if len(caches) == 0 {
+		ctx.APIErrorNotFound("no cache matches the key")
+		return
+	}
+
+	res := &api.ActionCachesResponse{
+		Entries:    make([]*api.ActionCache, len(caches)),
+		TotalCount: int64(len(caches)),
+	}
+	for i, cache := range caches {
+		if err := actions_service.DeleteCache(ctx, cache); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		res.Entries[i] = convert.ToActionCache(cache)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteActionCache deletes a cache of actions/cache of a repository
+func DeleteActionCache(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/caches/{cache_id} repository repoDeleteActionCache
+	// ---
+	// summary: Delete a cache of actions/cache of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: cache_id
+	//   in: path
+	//   description: id of the cache
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: cache has been deleted
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.DeleteCache(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("cache_id"))
+}
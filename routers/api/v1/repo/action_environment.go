@@ -0,0 +1,828 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	secret_model "code.gitea.io/gitea/models/secret"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	secret_service "code.gitea.io/gitea/services/secrets"
+)
+
+func getCurrentRepoActionEnvironment(ctx *context.APIContext) *actions_model.ActionEnvironment {
+	env, err := actions_model.GetEnvironmentByRepoAndName(ctx, ctx.Repo.Repository.ID, ctx.PathParam("environment_name"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err)
+		return nil
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	return env
+}
+
+func handleActionEnvironmentError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusBadRequest, err)
+	case errors.Is(err, util.ErrPermissionDenied):
+		ctx.APIError(http.StatusForbidden, err)
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIError(http.StatusNotFound, err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// ListActionEnvironments lists the deployment environments of a repository
+func ListActionEnvironments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments repository repoListActionEnvironments
+	// ---
+	// summary: List a repository's deployment environments
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironmentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	envs, count, err := db.FindAndCount[actions_model.ActionEnvironment](ctx, actions_model.FindEnvironmentsOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiEnvs := make([]*api.ActionEnvironment, len(envs))
+	for i, env := range envs {
+		apiEnvs[i], err = convert.ToActionEnvironment(ctx, env, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiEnvs)
+}
+
+// GetActionEnvironment gets a deployment environment of a repository
+func GetActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name} repository repoGetActionEnvironment
+	// ---
+	// summary: Get a repository's deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	apiEnv, err := convert.ToActionEnvironment(ctx, env, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiEnv)
+}
+
+// CreateOrUpdateActionEnvironment creates or updates a deployment environment of a repository
+func CreateOrUpdateActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/environments/{environment_name} repository repoCreateOrUpdateActionEnvironment
+	// ---
+	// summary: Create or update a repository's deployment environment
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateActionEnvironmentOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "201":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	opt := web.GetForm(ctx).(*api.CreateOrUpdateActionEnvironmentOption)
+
+	reviewerIDs, err := user_model.GetUserIDsByNames(ctx, opt.RequiredReviewers, false)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	env, err := actions_model.GetEnvironmentByRepoAndName(ctx, ctx.Repo.Repository.ID, ctx.PathParam("environment_name"))
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	created := env == nil
+	if created {
+		env = &actions_model.ActionEnvironment{
+			RepoID: ctx.Repo.Repository.ID,
+			Name:   ctx.PathParam("environment_name"),
+		}
+	}
+	env.BranchFilters = opt.BranchFilters
+	env.TagFilters = opt.TagFilters
+	env.RequiredReviewerIDs = reviewerIDs
+	env.PreventSelfReview = opt.PreventSelfReview
+
+	if created {
+		err = actions_service.CreateEnvironment(ctx, env)
+	} else {
+		err = actions_service.UpdateEnvironment(ctx, env)
+	}
+	if err != nil {
+		handleActionEnvironmentError(ctx, err)
+		return
+	}
+
+	apiEnv, err := convert.ToActionEnvironment(ctx, env, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(util.Iif(created, http.StatusCreated, http.StatusOK), apiEnv)
+}
+
+// DeleteActionEnvironment deletes a deployment environment of a repository
+func DeleteActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/environments/{environment_name} repository repoDeleteActionEnvironment
+	// ---
+	// summary: Delete a repository's deployment environment with its secrets, variables and deployment history
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := actions_service.DeleteEnvironment(ctx, env); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListActionEnvironmentDeployments lists the deployment history of an environment
+func ListActionEnvironmentDeployments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name}/deployments repository repoListActionEnvironmentDeployments
+	// ---
+	// summary: List the deployment history of a repository's deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionDeploymentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	deployments, count, err := db.FindAndCount[actions_model.ActionDeployment](ctx, actions_model.FindDeploymentsOptions{
+		ListOptions:    listOptions,
+		RepoID:         ctx.Repo.Repository.ID,
+		EnvironmentIDs: []int64{env.ID},
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiDeployments := make([]*api.ActionDeployment, len(deployments))
+	for i, deployment := range deployments {
+		apiDeployments[i], err = convert.ToActionDeployment(ctx, deployment, env, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiDeployments)
+}
+
+// ListActionEnvironmentSecrets lists the secrets of a deployment environment
+func ListActionEnvironmentSecrets(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name}/secrets repository repoListActionEnvironmentSecrets
+	// ---
+	// summary: List the secrets of a repository's deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecretList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	secrets, count, err := db.FindAndCount[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		ListOptions:   listOptions,
+		RepoID:        ctx.Repo.Repository.ID,
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiSecrets := make([]*api.Secret, len(secrets))
+	for k, v := range secrets {
+		apiSecrets[k] = &api.Secret{
+			Name:        v.Name,
+			Description: v.Description,
+			Created:     v.CreatedUnix.AsTime(),
+		}
+	}
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiSecrets)
+}
+
+// CreateOrUpdateActionEnvironmentSecret creates or updates a secret of a deployment environment
+func CreateOrUpdateActionEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/environments/{environment_name}/secrets/{secretname} repository updateRepoEnvironmentSecret
+	// ---
+	// summary: Create or Update a secret value in a repository's deployment environment
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateSecretOption"
+	// responses:
+	//   "201":
+	//     description: response when creating a secret
+	//   "204":
+	//     description: response when updating a secret
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	opt := web.GetForm(ctx).(*api.CreateOrUpdateSecretOption)
+	_, created, err := secret_service.CreateOrUpdateEnvironmentSecret(ctx, 0, ctx.Repo.Repository.ID, env.ID, ctx.PathParam("secretname"), opt.Data, opt.Description)
+	if err != nil {
+		handleActionEnvironmentError(ctx, err)
+		return
+	}
+
+	if created {
+		ctx.Status(http.StatusCreated)
+	} else {
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteActionEnvironmentSecret deletes a secret of a deployment environment
+func DeleteActionEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/environments/{environment_name}/secrets/{secretname} repository deleteRepoEnvironmentSecret
+	// ---
+	// summary: Delete a secret in a repository's deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: delete one secret of the environment
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := secret_service.DeleteEnvironmentSecretByName(ctx, 0, ctx.Repo.Repository.ID, env.ID, ctx.PathParam("secretname")); err != nil {
+		handleActionEnvironmentError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListActionEnvironmentVariables lists the variables of a deployment environment
+func ListActionEnvironmentVariables(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name}/variables repository getRepoEnvironmentVariablesList
+	// ---
+	// summary: Get the variables list of a repository's deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/VariableList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	vars, count, err := db.FindAndCount[actions_model.ActionVariable](ctx, &actions_model.FindVariablesOpts{
+		ListOptions:   listOptions,
+		RepoID:        ctx.Repo.Repository.ID,
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	variables := make([]*api.ActionVariable, len(vars))
+	for i, v := range vars {
+		variables[i] = &api.ActionVariable{
+			OwnerID:     v.OwnerID,
+			RepoID:      v.RepoID,
+			Name:        v.Name,
+			Data:        v.Data,
+			Description: v.Description,
+		}
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, variables)
+}
+
+// CreateOrUpdateActionEnvironmentVariable creates or updates a variable of a deployment environment
+func CreateOrUpdateActionEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/environments/{environment_name}/variables/{variablename} repository updateRepoEnvironmentVariable
+	// ---
+	// summary: Create or update a variable of a repository's deployment environment
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateVariableOption"
+	// responses:
+	//   "201":
+	//     description: response when creating a variable
+	//   "204":
+	//     description: response when updating a variable
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	opt := web.GetForm(ctx).(*api.CreateVariableOption)
+	variableName := ctx.PathParam("variablename")
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        ctx.Repo.Repository.ID,
+		EnvironmentID: env.ID,
+		Name:          variableName,
+	})
+	if errors.Is(err, util.ErrNotExist) {
+		if _, err := actions_service.CreateEnvironmentVariable(ctx, ctx.Repo.Repository.ID, env.ID, variableName, opt.Value, opt.Description); err != nil {
+			handleActionEnvironmentError(ctx, err)
+			return
+		}
+		ctx.Status(http.StatusCreated)
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	v.Data = opt.Value
+	v.Description = opt.Description
+	if _, err := actions_service.UpdateVariableNameData(ctx, v); err != nil {
+		handleActionEnvironmentError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteActionEnvironmentVariable deletes a variable of a deployment environment
+func DeleteActionEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/environments/{environment_name}/variables/{variablename} repository deleteRepoEnvironmentVariable
+	// ---
+	// summary: Delete a variable of a repository's deployment environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: response when deleting a variable
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        ctx.Repo.Repository.ID,
+		EnvironmentID: env.ID,
+		Name:          ctx.PathParam("variablename"),
+	})
+	if err != nil {
+		handleActionEnvironmentError(ctx, err)
+		return
+	}
+	if err := actions_service.DeleteVariableByID(ctx, v.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetPendingDeployments lists the deployments of a workflow run waiting for a required reviewer
+func GetPendingDeployments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runs/{run}/pending_deployments repository getPendingDeployments
+	// ---
+	// summary: Get the deployments of a workflow run waiting for a required reviewer
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionDeploymentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getCurrentRepoActionRunByID(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	deployments, err := actions_service.GetPendingDeployments(ctx, run)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiDeployments := make([]*api.ActionDeployment, len(deployments))
+	for i, deployment := range deployments {
+		env, err := actions_model.GetEnvironmentByRepoAndID(ctx, ctx.Repo.Repository.ID, deployment.EnvironmentID)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiDeployments[i], err = convert.ToActionDeployment(ctx, deployment, env, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+	ctx.JSON(http.StatusOK, apiDeployments)
+}
+
+// ReviewPendingDeployments approves or rejects the pending deployments of a workflow run
+func ReviewPendingDeployments(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/pending_deployments repository reviewPendingDeployments
+	// ---
+	// summary: Approve or reject the pending deployments of a workflow run, the doer must be a required reviewer of the environments
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReviewActionDeploymentsOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getCurrentRepoActionRunByID(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	opt := web.GetForm(ctx).(*api.ReviewActionDeploymentsOption)
+	if err := actions_service.ReviewPendingDeployments(ctx, ctx.Repo.Repository, run, ctx.Doer, opt.EnvironmentIDs, opt.State == "approved", opt.Comment); err != nil {
+		handleActionEnvironmentError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -5,10 +5,13 @@ package repo
 
 import (
 	"errors"
+	"net/http"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/common"
+	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
 )
 
@@ -66,3 +69,61 @@ func DownloadActionsRunJobLogs(ctx *context.APIContext) {
 		}
 	}
 }
+
+func ListActionsRunJobSummaries(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/jobs/{job_id}/summaries repository listActionsRunJobSummaries
+	// ---
+	// summary: Lists the summaries written by the steps of the latest attempt of a job
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: job_id
+	//   in: path
+	//   description: id of the job
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionJobSummaryList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	jobID := ctx.PathParamInt64("job_id")
+	curJob, err := actions_model.GetRunJobByRepoAndID(ctx, ctx.Repo.Repository.ID, jobID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	summaries, err := actions_service.GetJobSummaries(ctx, curJob, false)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ret := make([]*api.ActionJobSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		ret = append(ret, &api.ActionJobSummary{
+			StepNumber: summary.StepIndex,
+			StepName:   summary.StepName,
+			Content:    summary.Content,
+			UpdatedAt:  summary.Updated.AsTime(),
+		})
+	}
+	ctx.JSON(http.StatusOK, ret)
+}
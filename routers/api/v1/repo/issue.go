@@ -20,6 +20,7 @@ import (
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	issue_query "code.gitea.io/gitea/modules/indexer/issues/query"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
@@ -111,7 +112,7 @@ func SearchIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: Search string
+	//   description: Search string, which can contain filters like `is:open label:bug -label:wontfix assignee:@me milestone:"v2" updated:>2026-01-01 sort:updated-desc`
 	//   type: string
 	// - name: type
 	//   in: query
@@ -288,6 +289,17 @@ func SearchIssues(ctx *context.APIContext) {
 		}
 	}
 
+	if searchQuery := issue_query.Parse(keyword); searchQuery.HasFilters() {
+		if err := issue_indexer.ApplyQuery(ctx, ctx.Doer, searchQuery, searchOpt); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.APIErrorInternal(err)
@@ -333,7 +345,7 @@ func ListIssues(ctx *context.APIContext) {
 	//   type: string
 	// - name: q
 	//   in: query
-	//   description: search string
+	//   description: search string, which can contain filters like `is:open label:bug -label:wontfix assignee:@me milestone:"v2" updated:>2026-01-01 sort:updated-desc`
 	//   type: string
 	// - name: type
 	//   in: query
@@ -381,6 +393,8 @@ func ListIssues(ctx *context.APIContext) {
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.APIError(http.StatusUnprocessableEntity, err)
@@ -516,6 +530,21 @@ func ListIssues(ctx *context.APIContext) {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
 
+	if searchQuery := issue_query.Parse(keyword); searchQuery.HasFilters() {
+		if err := issue_indexer.ApplyQuery(ctx, ctx.Doer, searchQuery, searchOpt); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		if searchOpt.IsPull.Has() && !ctx.Repo.CanReadIssuesOrPulls(searchOpt.IsPull.Value()) {
+			ctx.APIErrorNotFound()
+			return
+		}
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.APIErrorInternal(err)
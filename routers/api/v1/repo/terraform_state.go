@@ -0,0 +1,361 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListTerraformStates lists the Terraform states of a repository
+func ListTerraformStates(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state repository repoListTerraformStates
+	// ---
+	// summary: List a repository's Terraform states
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListTerraformStates(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// GetTerraformState serves the latest version of a Terraform state
+func GetTerraformState(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state/{name} repository repoGetTerraformState
+	// ---
+	// summary: Get the latest version of a Terraform state
+	// description: This endpoint is the address of the Terraform http backend.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the state document
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetTerraformState(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// UpdateTerraformState stores a new version of a Terraform state
+func UpdateTerraformState(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/terraform/state/{name} repository repoUpdateTerraformState
+	// ---
+	// summary: Store a new version of a Terraform state
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: ID
+	//   in: query
+	//   description: id of the lock held by the Terraform run, required if the state is locked
+	//   type: string
+	// - name: body
+	//   in: body
+	//   description: the state document
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateVersion"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "423":
+	//     description: the state is locked by another Terraform run
+
+	shared.UpdateTerraformState(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// DeleteTerraformState deletes a Terraform state with all its versions
+func DeleteTerraformState(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/terraform/state/{name} repository repoDeleteTerraformState
+	// ---
+	// summary: Delete a Terraform state with all its versions
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     description: the state is locked by a Terraform run
+
+	shared.DeleteTerraformState(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// GetTerraformStateLock gets a Terraform state with the info of its lock
+func GetTerraformStateLock(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state/{name}/lock repository repoGetTerraformStateLock
+	// ---
+	// summary: Get a Terraform state with the info of its lock
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformState"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetTerraformStateLock(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// LockTerraformState locks a Terraform state, it handles the LOCK method of the Terraform http backend
+func LockTerraformState(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/terraform/state/{name}/lock repository repoLockTerraformState
+	// ---
+	// summary: Lock a Terraform state
+	// description: The LOCK method on the address of the state is handled in the same way.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: the lock info sent by Terraform
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformState"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     description: the state is locked by another Terraform run, the body contains the lock info of the lock holder
+
+	shared.LockTerraformState(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// UnlockTerraformState unlocks a Terraform state, it handles the UNLOCK method of the Terraform http backend
+func UnlockTerraformState(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/terraform/state/{name}/lock repository repoUnlockTerraformState
+	// ---
+	// summary: Unlock a Terraform state
+	// description: The UNLOCK method on the address of the state is handled in the same way.
+	//   The state is unlocked regardless of the lock holder if the body is empty.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: the lock info of the lock to release
+	//   schema:
+	//     type: object
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: the state is locked by another Terraform run, the body contains the lock info of the lock holder
+
+	shared.UnlockTerraformState(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// ListTerraformStateVersions lists the versions of a Terraform state
+func ListTerraformStateVersions(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state/{name}/versions repository repoListTerraformStateVersions
+	// ---
+	// summary: List the versions of a Terraform state
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateVersionList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListTerraformStateVersions(ctx, 0, ctx.Repo.Repository.ID)
+}
+
+// GetTerraformStateVersion serves a version of a Terraform state
+func GetTerraformStateVersion(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/state/{name}/versions/{version} repository repoGetTerraformStateVersion
+	// ---
+	// summary: Get a version of a Terraform state
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: number of the version
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the state document
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetTerraformStateVersion(ctx, 0, ctx.Repo.Repository.ID)
+}
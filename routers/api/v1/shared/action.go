@@ -4,6 +4,7 @@
 package shared
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -12,10 +13,13 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/webhook"
 	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 )
@@ -189,3 +193,71 @@ func ListRuns(ctx *context.APIContext, ownerID, repoID int64) {
 	ctx.SetTotalCountHeader(total)
 	ctx.JSON(http.StatusOK, &res)
 }
+
+var cacheSortOrders = map[string]string{
+	"created_at":       "created",
+	"last_accessed_at": "last_used",
+	"size_in_bytes":    "size",
+}
+
+// ListCaches lists the committed caches of actions/cache for api route validated repoID
+// repoID == 0 means all caches
+// Access rights are checked at the API route level
+func ListCaches(ctx *context.APIContext, repoID int64) {
+	opts := actions_model.FindCachesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      repoID,
+		CacheKey:    ctx.FormString("key"),
+		Complete:    optional.Some(true),
+	}
+	if ref := ctx.FormString("ref"); ref != "" {
+		opts.Refs = []string{ref}
+	}
+	sort := ctx.FormString("sort", "last_accessed_at")
+	column, ok := cacheSortOrders[sort]
+	if !ok {
+		ctx.APIError(http.StatusBadRequest, fmt.Errorf("Invalid sort %s", sort))
+		return
+	}
+	opts.OrderBy = column + util.Iif(ctx.FormString("direction") == "asc", " ASC", " DESC") + ", `id` DESC"
+
+	caches, total, err := db.FindAndCount[actions_model.ActionCache](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := &api.ActionCachesResponse{
+		Entries:    make([]*api.ActionCache, len(caches)),
+		TotalCount: total,
+	}
+	for i := range caches {
+		res.Entries[i] = convert.ToActionCache(caches[i])
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteCache deletes a cache of actions/cache for api route validated repoID
+// repoID == 0 means the cache could belong to any repository
+// Access rights are checked at the API route level
+func DeleteCache(ctx *context.APIContext, repoID, cacheID int64) {
+	var cache *actions_model.ActionCache
+	var err error
+	if repoID == 0 {
+		cache, err = actions_model.GetCacheByID(ctx, cacheID)
+	} else {
+		cache, err = actions_model.GetCacheByRepoAndID(ctx, repoID, cacheID)
+	}
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err)
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := actions_service.DeleteCache(ctx, cache); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
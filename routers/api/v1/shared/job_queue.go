@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListQueuedJobs lists the jobs waiting for runners grouped by owners and labels, for api route validated ownerID and repoID
+// ownerID == 0 and repoID == 0 means all queued jobs
+// ownerID != 0 and repoID == 0 means the queued jobs of the given user/org
+// ownerID == 0 and repoID != 0 means the queued jobs of the given repo
+// Access rights are checked at the API route level
+func ListQueuedJobs(ctx *context.APIContext, ownerID, repoID int64) {
+	groups, err := actions_model.GetQueuedJobGroups(ctx, ownerID, repoID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := &api.ActionQueuedJobsResponse{
+		Entries:    make([]*api.ActionQueuedJobs, 0, len(groups)),
+		TotalCount: int64(len(groups)),
+	}
+	for _, group := range groups {
+		res.Entries = append(res.Entries, convert.ToActionQueuedJobs(ctx, group))
+	}
+	ctx.JSON(http.StatusOK, res)
+}
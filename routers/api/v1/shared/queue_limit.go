@@ -0,0 +1,75 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetQueueLimit returns the max queue time of the jobs for api route validated ownerID and repoID
+// ownerID != 0 and repoID == 0 means the limit of the given user/org
+// ownerID == 0 and repoID != 0 means the limit of the current repo
+// Access rights are checked at the API route level
+func GetQueueLimit(ctx *context.APIContext, ownerID, repoID int64) {
+	var (
+		minutes   int64
+		effective time.Duration
+		err       error
+	)
+	if repoID != 0 {
+		minutes, err = actions_service.GetRepoMaxQueueMinutes(ctx, ctx.Repo.Repository)
+		if err == nil {
+			effective, err = actions_service.GetMaxQueueTime(ctx, ctx.Repo.Repository)
+		}
+	} else {
+		minutes, err = actions_service.GetOwnerMaxQueueMinutes(ctx, ownerID)
+		effective = util.Iif(minutes > 0, time.Duration(minutes)*time.Minute, setting.Actions.MaxQueueTime)
+	}
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &api.ActionQueueLimit{
+		MaxQueueMinutes:          minutes,
+		EffectiveMaxQueueMinutes: int64(effective / time.Minute),
+	})
+}
+
+// UpdateQueueLimit updates the max queue time of the jobs for api route validated ownerID and repoID
+// ownerID != 0 and repoID == 0 means the limit of the given user/org
+// ownerID == 0 and repoID != 0 means the limit of the current repo
+// Access rights are checked at the API route level
+func UpdateQueueLimit(ctx *context.APIContext, ownerID, repoID int64) {
+	form := web.GetForm(ctx).(*api.EditActionQueueLimitOption)
+	if form.MaxQueueMinutes == nil {
+		ctx.APIError(http.StatusUnprocessableEntity, "[MaxQueueMinutes]: Required")
+		return
+	}
+
+	var err error
+	if repoID != 0 {
+		err = actions_service.SetRepoMaxQueueMinutes(ctx, ctx.Repo.Repository, *form.MaxQueueMinutes)
+	} else {
+		err = actions_service.SetOwnerMaxQueueMinutes(ctx, ownerID, *form.MaxQueueMinutes)
+	}
+	if errors.Is(err, util.ErrInvalidArgument) {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	GetQueueLimit(ctx, ownerID, repoID)
+}
@@ -25,6 +25,16 @@ type RegistrationToken struct {
 }
 
 func GetRegistrationToken(ctx *context.APIContext, ownerID, repoID int64) {
+	if ctx.FormBool("ephemeral") {
+		token, err := actions_model.NewEphemeralRunnerToken(ctx, ownerID, repoID, setting.Actions.EphemeralTokenExpiry)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, RegistrationToken{Token: token.Token})
+		return
+	}
+
 	token, err := actions_model.GetLatestRunnerToken(ctx, ownerID, repoID)
 	if errors.Is(err, util.ErrNotExist) || (token != nil && !token.IsActive) {
 		token, err = actions_model.NewRunnerToken(ctx, ownerID, repoID)
@@ -0,0 +1,243 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	terraform_service "code.gitea.io/gitea/services/terraform"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// The lock methods of the Terraform http backend
+const (
+	MethodLock   = "LOCK"
+	MethodUnlock = "UNLOCK"
+)
+
+func init() {
+	chi.RegisterMethod(MethodLock)
+	chi.RegisterMethod(MethodUnlock)
+}
+
+// The handlers of the Terraform http backend are shared by the states of the repositories and the owners.
+// ownerID != 0 and repoID == 0 means the states of the given user/org
+// ownerID == 0 and repoID != 0 means the states of the given repo
+// Access rights are checked at the API route level
+
+func handleTerraformStateError(ctx *context.APIContext, err error, lockedStatus int) {
+	var errLocked terraform_service.ErrStateLocked
+	switch {
+	case errors.As(err, &errLocked):
+		// Terraform shows the lock info of the current holder to the user
+		ctx.Resp.Header().Set("Content-Type", "application/json")
+		ctx.Resp.WriteHeader(lockedStatus)
+		if _, err := ctx.Resp.Write([]byte(errLocked.LockInfo)); err != nil {
+			log.Error("Error writing lock info: %v", err)
+		}
+	case errors.Is(err, util.ErrContentTooLarge):
+		ctx.APIError(http.StatusRequestEntityTooLarge, err)
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusBadRequest, err)
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIErrorNotFound(err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+func getCurrentTerraformState(ctx *context.APIContext, ownerID, repoID int64) *terraform_model.TerraformState {
+	s, err := terraform_model.GetStateByName(ctx, ownerID, repoID, ctx.PathParam("name"))
+	if err != nil {
+		handleTerraformStateError(ctx, err, http.StatusLocked)
+		return nil
+	}
+	return s
+}
+
+// ListTerraformStates lists the Terraform states of the owner or the repository
+func ListTerraformStates(ctx *context.APIContext, ownerID, repoID int64) {
+	listOptions := utils.GetListOptions(ctx)
+	states, count, err := db.FindAndCount[terraform_model.TerraformState](ctx, terraform_model.FindStatesOptions{
+		ListOptions: listOptions,
+		OwnerID:     ownerID,
+		RepoID:      repoID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiStates := make([]*api.TerraformState, len(states))
+	for i, s := range states {
+		apiStates[i], err = convert.ToTerraformState(ctx, s, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiStates)
+}
+
+// GetTerraformState serves the latest version of a Terraform state
+func GetTerraformState(ctx *context.APIContext, ownerID, repoID int64) {
+	_, v, err := terraform_service.GetLatestVersion(ctx, ownerID, repoID, ctx.PathParam("name"))
+	if err != nil {
+		handleTerraformStateError(ctx, err, http.StatusLocked)
+		return
+	}
+	serveTerraformStateVersion(ctx, v)
+}
+
+func serveTerraformStateVersion(ctx *context.APIContext, v *terraform_model.TerraformStateVersion) {
+	f, err := terraform_service.OpenVersion(v)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	defer f.Close()
+
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(ctx.Resp, f); err != nil {
+		log.Error("Error writing terraform state %s: %v", v.StoragePath(), err)
+	}
+}
+
+// UpdateTerraformState stores a new version of a Terraform state
+func UpdateTerraformState(ctx *context.APIContext, ownerID, repoID int64) {
+	v, err := terraform_service.UpdateState(ctx, ownerID, repoID, ctx.Doer, ctx.PathParam("name"), ctx.FormString("ID"), ctx.Req.Body)
+	if err != nil {
+		handleTerraformStateError(ctx, err, http.StatusLocked)
+		return
+	}
+
+	apiVersion, err := convert.ToTerraformStateVersion(ctx, v, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiVersion)
+}
+
+// DeleteTerraformState deletes a Terraform state with all its versions
+func DeleteTerraformState(ctx *context.APIContext, ownerID, repoID int64) {
+	if err := terraform_service.DeleteState(ctx, ownerID, repoID, ctx.PathParam("name")); err != nil {
+		handleTerraformStateError(ctx, err, http.StatusLocked)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetTerraformStateLock serves a Terraform state with the info of its lock
+func GetTerraformStateLock(ctx *context.APIContext, ownerID, repoID int64) {
+	s := getCurrentTerraformState(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	apiState, err := convert.ToTerraformState(ctx, s, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiState)
+}
+
+// LockTerraformState locks a Terraform state, it handles the LOCK method of the Terraform http backend
+func LockTerraformState(ctx *context.APIContext, ownerID, repoID int64) {
+	info, err := io.ReadAll(ctx.Req.Body)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	s, err := terraform_service.LockState(ctx, ownerID, repoID, ctx.Doer, ctx.PathParam("name"), info)
+	if err != nil {
+		handleTerraformStateError(ctx, err, http.StatusLocked)
+		return
+	}
+
+	apiState, err := convert.ToTerraformState(ctx, s, ctx.Doer)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiState)
+}
+
+// UnlockTerraformState unlocks a Terraform state, it handles the UNLOCK method of the Terraform http backend
+func UnlockTerraformState(ctx *context.APIContext, ownerID, repoID int64) {
+	info, err := io.ReadAll(ctx.Req.Body)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if err := terraform_service.UnlockState(ctx, ownerID, repoID, ctx.PathParam("name"), info); err != nil {
+		handleTerraformStateError(ctx, err, http.StatusConflict)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+// ListTerraformStateVersions lists the versions of a Terraform state
+func ListTerraformStateVersions(ctx *context.APIContext, ownerID, repoID int64) {
+	s := getCurrentTerraformState(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	versions, count, err := db.FindAndCount[terraform_model.TerraformStateVersion](ctx, terraform_model.FindStateVersionsOptions{
+		ListOptions: listOptions,
+		StateID:     s.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiVersions := make([]*api.TerraformStateVersion, len(versions))
+	for i, v := range versions {
+		apiVersions[i], err = convert.ToTerraformStateVersion(ctx, v, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiVersions)
+}
+
+// GetTerraformStateVersion serves a version of a Terraform state
+func GetTerraformStateVersion(ctx *context.APIContext, ownerID, repoID int64) {
+	s := getCurrentTerraformState(ctx, ownerID, repoID)
+	if ctx.Written() {
+		return
+	}
+
+	v, err := terraform_model.GetStateVersion(ctx, s.ID, ctx.PathParamInt64("version"))
+	if err != nil {
+		handleTerraformStateError(ctx, err, http.StatusLocked)
+		return
+	}
+	serveTerraformStateVersion(ctx, v)
+}
@@ -53,3 +53,73 @@ type swaggerResponseRunDetails struct {
 	// in:body
 	Body api.RunDetails `json:"body"`
 }
+
+// ActionEnvironment
+// swagger:response ActionEnvironment
+type swaggerResponseActionEnvironment struct {
+	// in:body
+	Body api.ActionEnvironment `json:"body"`
+}
+
+// ActionEnvironmentList
+// swagger:response ActionEnvironmentList
+type swaggerResponseActionEnvironmentList struct {
+	// in:body
+	Body []api.ActionEnvironment `json:"body"`
+}
+
+// ActionDeploymentList
+// swagger:response ActionDeploymentList
+type swaggerResponseActionDeploymentList struct {
+	// in:body
+	Body []api.ActionDeployment `json:"body"`
+}
+
+// ActionApproval
+// swagger:response ActionApproval
+type swaggerResponseActionApproval struct {
+	// in:body
+	Body api.ActionApproval `json:"body"`
+}
+
+// ActionApprovalList
+// swagger:response ActionApprovalList
+type swaggerResponseActionApprovalList struct {
+	// in:body
+	Body []api.ActionApproval `json:"body"`
+}
+
+// ActionQueueLimit
+// swagger:response ActionQueueLimit
+type swaggerResponseActionQueueLimit struct {
+	// in:body
+	Body api.ActionQueueLimit `json:"body"`
+}
+
+// ActionRunnerGroup
+// swagger:response ActionRunnerGroup
+type swaggerResponseActionRunnerGroup struct {
+	// in:body
+	Body api.ActionRunnerGroup `json:"body"`
+}
+
+// ActionRunnerGroupList
+// swagger:response ActionRunnerGroupList
+type swaggerResponseActionRunnerGroupList struct {
+	// in:body
+	Body []api.ActionRunnerGroup `json:"body"`
+}
+
+// ActionQueuedJobsResponse
+// swagger:response ActionQueuedJobsResponse
+type swaggerResponseActionQueuedJobsResponse struct {
+	// in:body
+	Body api.ActionQueuedJobsResponse `json:"body"`
+}
+
+// ActionJobSummaryList
+// swagger:response ActionJobSummaryList
+type swaggerResponseActionJobSummaryList struct {
+	// in:body
+	Body []api.ActionJobSummary `json:"body"`
+}
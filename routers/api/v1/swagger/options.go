@@ -228,6 +228,24 @@ type swaggerParameterBodies struct {
 	// in:body
 	EditActionRunnerOption api.EditActionRunnerOption
 
+	// in:body
+	EditActionQueueLimitOption api.EditActionQueueLimitOption
+
+	// in:body
+	CreateActionRunnerGroupOption api.CreateActionRunnerGroupOption
+
+	// in:body
+	EditActionRunnerGroupOption api.EditActionRunnerGroupOption
+
 	// in:body
 	LockIssueOption api.LockIssueOption
+
+	// in:body
+	CreateOrUpdateActionEnvironmentOption api.CreateOrUpdateActionEnvironmentOption
+
+	// in:body
+	ReviewActionDeploymentsOption api.ReviewActionDeploymentsOption
+
+	// in:body
+	ReviewActionApprovalOption api.ReviewActionApprovalOption
 }
@@ -27,3 +27,10 @@ type swaggerResponsePackageFileList struct {
 	// in:body
 	Body []api.PackageFile `json:"body"`
 }
+
+// PackageVulnerabilityList
+// swagger:response PackageVulnerabilityList
+type swaggerResponsePackageVulnerabilityList struct {
+	// in:body
+	Body []api.PackageVulnerability `json:"body"`
+}
@@ -484,6 +484,20 @@ type swaggerRepoArtifactsList struct {
 	Body api.ActionArtifactsResponse `json:"body"`
 }
 
+// ActionCachesList
+// swagger:response ActionCachesList
+type swaggerActionCachesList struct {
+	// in:body
+	Body api.ActionCachesResponse `json:"body"`
+}
+
+// ActionCacheUsage
+// swagger:response ActionCacheUsage
+type swaggerActionCacheUsage struct {
+	// in:body
+	Body api.ActionCacheUsage `json:"body"`
+}
+
 // Artifact
 // swagger:response Artifact
 type swaggerRepoArtifact struct {
@@ -522,3 +536,31 @@ type swaggerMergeUpstreamResponse struct {
 	// in:body
 	Body api.MergeUpstreamResponse `json:"body"`
 }
+
+// TerraformState
+// swagger:response TerraformState
+type swaggerTerraformState struct {
+	// in:body
+	Body api.TerraformState `json:"body"`
+}
+
+// TerraformStateList
+// swagger:response TerraformStateList
+type swaggerTerraformStateList struct {
+	// in:body
+	Body []api.TerraformState `json:"body"`
+}
+
+// TerraformStateVersion
+// swagger:response TerraformStateVersion
+type swaggerTerraformStateVersion struct {
+	// in:body
+	Body api.TerraformStateVersion `json:"body"`
+}
+
+// TerraformStateVersionList
+// swagger:response TerraformStateVersionList
+type swaggerTerraformStateVersionList struct {
+	// in:body
+	Body []api.TerraformStateVersion `json:"body"`
+}
@@ -441,3 +441,16 @@ func ListWorkflowJobs(ctx *context.APIContext) {
 
 	shared.ListJobs(ctx, ctx.Doer.ID, 0, 0)
 }
+
+// ListQueuedJobs list the jobs of the authenticated user waiting for runners
+func ListQueuedJobs(ctx *context.APIContext) {
+	// swagger:operation GET /user/actions/queue user getUserActionsQueuedJobs
+	// ---
+	// summary: List the jobs of the authenticated user waiting for runners, grouped by their runs-on labels
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionQueuedJobsResponse"
+	shared.ListQueuedJobs(ctx, ctx.Doer.ID, 0)
+}
@@ -18,6 +18,11 @@ func CreateRegistrationToken(ctx *context.APIContext) {
 	// produces:
 	// - application/json
 	// parameters:
+	// - name: ephemeral
+	//   in: query
+	//   description: create a single-use token which expires after [actions] EPHEMERAL_TOKEN_EXPIRY, the runner registered with it will be deleted after it has run one task
+	//   type: boolean
+	//   required: false
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/RegistrationToken"
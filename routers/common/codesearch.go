@@ -4,6 +4,10 @@
 package common
 
 import (
+	"slices"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/indexer"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/setting"
@@ -11,17 +15,21 @@ import (
 )
 
 func PrepareCodeSearch(ctx *context.Context) (ret struct {
-	Keyword    string
-	Language   string
+	code_indexer.Query
 	SearchMode indexer.SearchModeType
 },
 ) {
-	ret.Language = ctx.FormTrim("l")
-	ret.Keyword = ctx.FormTrim("q")
+	keyword := ctx.FormTrim("q")
+	ret.Query = *code_indexer.ParseQuery(keyword)
+	// the language selected from the results overrides the "lang:" qualifier
+	language := ctx.FormTrim("l")
+	if language != "" {
+		ret.Language = language
+	}
 	ret.SearchMode = indexer.SearchModeType(ctx.FormTrim("search_mode"))
 
-	ctx.Data["Keyword"] = ret.Keyword
-	ctx.Data["Language"] = ret.Language
+	ctx.Data["Keyword"] = keyword
+	ctx.Data["Language"] = language
 	ctx.Data["SelectedSearchMode"] = string(ret.SearchMode)
 	if setting.Indexer.RepoIndexerEnabled {
 		ctx.Data["SearchModes"] = code_indexer.SupportedSearchModes()
@@ -31,3 +39,30 @@ func PrepareCodeSearch(ctx *context.Context) (ret struct {
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
 	return ret
 }
+
+// FilterCodeSearchRepoIDs returns the IDs of the repositories given by the "repo:" qualifiers of a code search query.
+// A name without owner refers to a repository of the owner, if it is given.
+// Repositories which don't exist or aren't in accessibleRepoIDs are skipped, unless all repositories are accessible.
+func FilterCodeSearchRepoIDs(ctx *context.Context, repos []string, owner string, accessibleRepoIDs []int64, allAccessible bool) ([]int64, error) {
+	repoIDs := make([]int64, 0, len(repos))
+	for _, name := range repos {
+		ownerName, repoName, found := strings.Cut(name, "/")
+		if !found {
+			ownerName, repoName = owner, name
+		}
+		if ownerName == "" || repoName == "" {
+			continue
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if (allAccessible || slices.Contains(accessibleRepoIDs, repo.ID)) && !slices.Contains(repoIDs, repo.ID) {
+			repoIDs = append(repoIDs, repo.ID)
+		}
+	}
+	return repoIDs, nil
+}
@@ -209,6 +209,10 @@ func NormalRoutes() *web.Router {
 		r.Mount(prefix, actions_router.ArtifactsRoutes(prefix))
 		prefix = actions_router.ArtifactV4RouteBase
 		r.Mount(prefix, actions_router.ArtifactsV4Routes(prefix))
+		prefix = actions_service.CacheRoutePrefix
+		r.Mount(prefix, actions_router.CacheRoutes(prefix))
+		prefix = actions_router.CacheV2RouteBase
+		r.Mount(prefix, actions_router.CacheV2Routes(prefix))
 	}
 
 	r.NotFound(func(w http.ResponseWriter, req *http.Request) {
@@ -4,6 +4,7 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"time"
@@ -13,9 +14,11 @@ import (
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	sbom_service "code.gitea.io/gitea/services/packages/sbom"
 )
 
 const (
@@ -62,6 +65,12 @@ func Packages(ctx *context.Context) {
 		return
 	}
 
+	advisoryStats, err := packages_model.GetAdvisoryStats(ctx)
+	if err != nil {
+		ctx.ServerError("GetAdvisoryStats", err)
+		return
+	}
+
 	ctx.Data["Title"] = ctx.Tr("packages.title")
 	ctx.Data["PageIsAdminPackages"] = true
 	ctx.Data["Query"] = query
@@ -72,6 +81,7 @@ func Packages(ctx *context.Context) {
 	ctx.Data["TotalCount"] = total
 	ctx.Data["TotalBlobSize"] = totalBlobSize - totalUnreferencedBlobSize
 	ctx.Data["TotalUnreferencedBlobSize"] = totalUnreferencedBlobSize
+	ctx.Data["AdvisoryStats"] = advisoryStats
 
 	pager := context.NewPagination(total, setting.UI.PackagesPagingNum, page, 5)
 	pager.AddParamFromRequest(ctx.Req)
@@ -106,3 +116,28 @@ func CleanupExpiredData(ctx *context.Context) {
 	ctx.Flash.Success(ctx.Tr("admin.packages.cleanup.success"))
 	ctx.Redirect(setting.AppSubURL + "/-/admin/packages")
 }
+
+// ImportVulnerabilityDatabase imports the advisories of an uploaded OSV database file
+func ImportVulnerabilityDatabase(ctx *context.Context) {
+	file, header, err := ctx.Req.FormFile("file")
+	if err != nil {
+		ctx.Flash.Error(ctx.Tr("admin.packages.vulnerabilities.import.error", err))
+		ctx.Redirect(setting.AppSubURL + "/-/admin/packages")
+		return
+	}
+	defer file.Close()
+
+	count, err := sbom_service.ImportDatabase(ctx, file, header.Size)
+	if err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("ImportDatabase", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("admin.packages.vulnerabilities.import.error", err))
+		ctx.Redirect(setting.AppSubURL + "/-/admin/packages")
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("admin.packages.vulnerabilities.import.success", count))
+	ctx.Redirect(setting.AppSubURL + "/-/admin/packages")
+}
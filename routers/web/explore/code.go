@@ -4,6 +4,7 @@
 package explore
 
 import (
+	"errors"
 	"net/http"
 	"slices"
 
@@ -12,6 +13,7 @@ import (
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/context"
 )
@@ -37,7 +39,7 @@ func Code(ctx *context.Context) {
 	ctx.Data["PageIsViewCode"] = true
 
 	prepareSearch := common.PrepareCodeSearch(ctx)
-	if prepareSearch.Keyword == "" {
+	if prepareSearch.IsEmpty() {
 		ctx.HTML(http.StatusOK, tplExploreCode)
 		return
 	}
@@ -65,16 +67,29 @@ func Code(ctx *context.Context) {
 		}
 	}
 
+	// admins search all repositories unless the repositories are given by the query
+	searchAllRepos := isAdmin && len(prepareSearch.Repos) == 0
+	if len(prepareSearch.Repos) > 0 {
+		repoIDs, err = common.FilterCodeSearchRepoIDs(ctx, prepareSearch.Repos, "", repoIDs, isAdmin)
+		if err != nil {
+			ctx.ServerError("FilterCodeSearchRepoIDs", err)
+			return
+		}
+	}
+
 	var (
 		total                 int64
 		searchResults         []*code_indexer.Result
 		searchResultLanguages []*code_indexer.SearchResultLanguages
 	)
 
-	if (len(repoIDs) > 0) || isAdmin {
+	if (len(repoIDs) > 0) || searchAllRepos {
 		total, searchResults, searchResultLanguages, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
 			RepoIDs:    repoIDs,
 			Keyword:    prepareSearch.Keyword,
+			Symbol:     prepareSearch.Symbol,
+			Path:       prepareSearch.Path,
+			Refs:       prepareSearch.Refs,
 			SearchMode: prepareSearch.SearchMode,
 			Language:   prepareSearch.Language,
 			Paginator: &db.ListOptions{
@@ -82,7 +97,9 @@ func Code(ctx *context.Context) {
 				PageSize: setting.UI.RepoSearchPagingNum,
 			},
 		})
-		if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("search.code_search_invalid_query", err.Error()), true)
+		} else if err != nil {
 			if code_indexer.IsAvailable(ctx) {
 				ctx.ServerError("SearchResults", err)
 				return
@@ -302,6 +302,10 @@ func prepareWorkflowList(ctx *context.Context, workflows []WorkflowInfo) {
 		ctx.ServerError("FindRunners", err)
 		return
 	}
+	if err := actions_model.RunnerList(runners).LoadGroups(ctx); err != nil {
+		ctx.ServerError("LoadGroups", err)
+		return
+	}
 	for _, run := range runs {
 		if !run.Status.In(actions_model.StatusWaiting, actions_model.StatusRunning) {
 			continue
@@ -317,7 +321,7 @@ func prepareWorkflowList(ctx *context.Context, workflows []WorkflowInfo) {
 			}
 			hasOnlineRunner := false
 			for _, runner := range runners {
-				if !runner.IsDisabled && runner.CanMatchLabels(job.RunsOn) {
+				if !runner.IsDisabled && runner.CanRun(run) && runner.CanMatchGroup(job.RunsOnGroup) && runner.CanMatchLabels(job.RunsOn) {
 					hasOnlineRunner = true
 					break
 				}
@@ -131,9 +131,12 @@ type ViewResponse struct {
 			Commit            ViewCommit    `json:"commit"`
 		} `json:"run"`
 		CurrentJob struct {
-			Title  string         `json:"title"`
-			Detail string         `json:"detail"`
-			Steps  []*ViewJobStep `json:"steps"`
+			Title             string            `json:"title"`
+			Detail            string            `json:"detail"`
+			CanReviewApproval bool              `json:"canReviewApproval"` // the job is an approval gate waiting for a review and the doer has permission to review it
+			Steps             []*ViewJobStep    `json:"steps"`
+			Summaries         []*ViewSummary    `json:"summaries"`
+			Annotations       []*ViewAnnotation `json:"annotations"`
 		} `json:"currentJob"`
 	} `json:"state"`
 	Logs struct {
@@ -175,6 +178,20 @@ type ViewJobStep struct {
 	Status   string `json:"status"`
 }
 
+type ViewSummary struct {
+	StepName string        `json:"stepName"`
+	HTML     template.HTML `json:"html"`
+}
+
+type ViewAnnotation struct {
+	Level    string `json:"level"`
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	Location string `json:"location"` // the file and the line, like "app.js#L10"
+	FileLink string `json:"fileLink"`
+	LogLink  string `json:"logLink"` // the anchor of the log line which creates the annotation
+}
+
 type ViewStepLog struct {
 	Step    int                `json:"step"`
 	Cursor  int64              `json:"cursor"`
@@ -300,9 +317,25 @@ func ViewPost(ctx *context_module.Context) {
 	resp.State.CurrentJob.Detail = current.Status.LocaleString(ctx.Locale)
 	if run.NeedApproval {
 		resp.State.CurrentJob.Detail = ctx.Locale.TrString("actions.need_approval_desc")
+	} else if current.IsApprovalGate && current.Started > 0 {
+		approval, err := actions_model.GetApprovalByJobAttempt(ctx, current.ID, current.Attempt)
+		if err != nil {
+			ctx.ServerError("GetApprovalByJobAttempt", err)
+			return
+		}
+		if err := approval.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("approval.LoadAttributes", err)
+			return
+		}
+		resp.State.CurrentJob.Detail = approvalGateDetail(ctx, approval)
+		resp.State.CurrentJob.CanReviewApproval = approval.State == actions_model.ApprovalStateWaiting && current.Status == actions_model.StatusBlocked && ctx.Repo.CanWrite(unit.TypeActions)
+	} else if current.StopReason != actions_model.JobStopReasonNone {
+		resp.State.CurrentJob.Detail = current.StopReason.LocaleString(ctx.Locale)
 	}
 	resp.State.CurrentJob.Steps = make([]*ViewJobStep, 0) // marshal to '[]' instead fo 'null' in json
 	resp.Logs.StepsLog = make([]*ViewStepLog, 0)          // marshal to '[]' instead fo 'null' in json
+	resp.State.CurrentJob.Summaries = make([]*ViewSummary, 0)
+	resp.State.CurrentJob.Annotations = make([]*ViewAnnotation, 0)
 	if task != nil {
 		steps, logs, err := convertToViewModel(ctx, ctx.Locale, req.LogCursors, task)
 		if err != nil {
@@ -311,11 +344,71 @@ func ViewPost(ctx *context_module.Context) {
 		}
 		resp.State.CurrentJob.Steps = append(resp.State.CurrentJob.Steps, steps...)
 		resp.Logs.StepsLog = append(resp.Logs.StepsLog, logs...)
+
+		summaries, err := renderJobSummaries(ctx, current)
+		if err != nil {
+			ctx.ServerError("renderJobSummaries", err)
+			return
+		}
+		resp.State.CurrentJob.Summaries = append(resp.State.CurrentJob.Summaries, summaries...)
+
+		annotations, err := convertAnnotationsToViewModel(ctx, task)
+		if err != nil {
+			ctx.ServerError("convertAnnotationsToViewModel", err)
+			return
+		}
+		resp.State.CurrentJob.Annotations = append(resp.State.CurrentJob.Annotations, annotations...)
 	}
 
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// renderJobSummaries returns the summaries written by the steps of the job, they have been rendered when they were saved
+func renderJobSummaries(ctx *context_module.Context, job *actions_model.ActionRunJob) ([]*ViewSummary, error) {
+	summaries, err := actions_service.GetJobSummaries(ctx, job, true)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*ViewSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		ret = append(ret, &ViewSummary{StepName: summary.StepName, HTML: template.HTML(summary.Content)})
+	}
+	return ret, nil
+}
+
+// convertAnnotationsToViewModel converts the annotations of the task, they are linked to the log lines in the full steps
+func convertAnnotationsToViewModel(ctx *context_module.Context, task *actions_model.ActionTask) ([]*ViewAnnotation, error) {
+	annotations, err := actions_model.GetTaskAnnotations(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	steps := actions.FullSteps(task)
+	ret := make([]*ViewAnnotation, 0, len(annotations))
+	for _, annotation := range annotations {
+		view := &ViewAnnotation{
+			Level:   annotation.Level.String(),
+			Title:   annotation.Title,
+			Message: annotation.Message,
+		}
+		if annotation.Path != "" {
+			view.Location = annotation.Path
+			view.FileLink = fmt.Sprintf("%s/src/commit/%s/%s", ctx.Repo.RepoLink, util.PathEscapeSegments(task.CommitSHA), util.PathEscapeSegments(annotation.Path))
+			if annotation.Line > 0 {
+				view.Location += fmt.Sprintf("#L%d", annotation.Line)
+				view.FileLink += fmt.Sprintf("#L%d", annotation.Line)
+			}
+		}
+		for i, step := range steps {
+			if annotation.LogIndex >= step.LogIndex && annotation.LogIndex < step.LogIndex+step.LogLength {
+				view.LogLink = fmt.Sprintf("#jobstep-%d-%d", i, annotation.LogIndex-step.LogIndex+1)
+				break
+			}
+		}
+		ret = append(ret, view)
+	}
+	return ret, nil
+}
+
 func convertToViewModel(ctx context.Context, locale translation.Locale, cursors []LogCursor, task *actions_model.ActionTask) ([]*ViewJobStep, []*ViewStepLog, error) {
 	var viewJobs []*ViewJobStep
 	var logs []*ViewStepLog
@@ -435,6 +528,42 @@ func Rerun(ctx *context_module.Context) {
 	ctx.JSONOK()
 }
 
+func approvalGateDetail(ctx *context_module.Context, approval *actions_model.ActionApproval) string {
+	switch approval.State {
+	case actions_model.ApprovalStateApproved:
+		return ctx.Locale.TrString("actions.approval_gate.approved_by", approval.Reviewer.GetDisplayName())
+	case actions_model.ApprovalStateRejected:
+		return ctx.Locale.TrString("actions.approval_gate.rejected_by", approval.Reviewer.GetDisplayName())
+	case actions_model.ApprovalStateTimedOut:
+		return ctx.Locale.TrString("actions.approval_gate.timed_out")
+	}
+	if approval.Message != "" {
+		return approval.Message
+	}
+	return ctx.Locale.TrString("actions.approval_gate.waiting_desc")
+}
+
+// ReviewApprovalGate approves or rejects the approval gate of the current job
+func ReviewApprovalGate(ctx *context_module.Context) {
+	runID := getRunID(ctx)
+
+	_, _, currentJob := getRunJobsAndCurrentJob(ctx, runID)
+	if ctx.Written() {
+		return
+	}
+
+	if _, err := actions_service.ReviewApprovalGate(ctx, currentJob, ctx.Doer, ctx.FormString("state") == "approved", ctx.FormString("comment")); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			ctx.JSONError(ctx.Locale.Tr("actions.approval_gate.not_waiting"))
+			return
+		}
+		ctx.ServerError("ReviewApprovalGate", err)
+		return
+	}
+
+	ctx.JSONOK()
+}
+
 func Logs(ctx *context_module.Context) {
 	runID := getRunID(ctx)
 	jobID := ctx.PathParamInt64("job")
@@ -528,6 +657,10 @@ func approveRuns(ctx *context_module.Context, runIDs []int64) {
 			}
 			runJobs[run.ID] = jobs
 			for _, job := range jobs {
+				if job.IsStartedByJobEmitter() {
+					// they will be started by the job emitter after the run is approved
+					continue
+				}
 				job.Status, err = actions_service.PrepareToStartJobWithConcurrency(ctx, job)
 				if err != nil {
 					return err
@@ -554,6 +687,9 @@ func approveRuns(ctx *context_module.Context, runIDs []int64) {
 
 	for runID, run := range runMap {
 		actions_service.CreateCommitStatusForRunJobs(ctx, run, runJobs[runID]...)
+		if err := actions_service.EmitJobsIfReadyByRun(runID); err != nil {
+			log.Error("EmitJobsIfReadyByRun: %v", err)
+		}
 	}
 
 	if len(updatedJobs) > 0 {
@@ -5,6 +5,7 @@ package repo
 
 import (
 	"bytes"
+	"errors"
 	"maps"
 	"net/http"
 	"slices"
@@ -21,6 +22,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	db_indexer "code.gitea.io/gitea/modules/indexer/issues/db"
+	issue_query "code.gitea.io/gitea/modules/indexer/issues/query"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
@@ -530,7 +532,20 @@ func prepareIssueFilterAndList(ctx *context.Context, milestoneID, projectID int6
 		IssueIDs:          nil,
 	}
 	if keyword != "" {
-		keywordMatchedIssueIDs, _, err = issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, statsOpts))
+		searchQuery := issue_query.Parse(keyword)
+		searchOpt := issue_indexer.ToSearchOptions(keyword, statsOpts)
+		if searchQuery.HasFilters() {
+			err = issue_indexer.ApplyQuery(ctx, ctx.Doer, searchQuery, searchOpt)
+			searchOpt.IsPull = isPullOption // the page decides between issues and pull requests
+			sortType = util.IfZero(issue_indexer.QuerySortType(searchQuery), sortType)
+		}
+		if err == nil {
+			keywordMatchedIssueIDs, _, err = issue_indexer.SearchIssues(ctx, searchOpt)
+		} else if errors.Is(err, util.ErrInvalidArgument) {
+			// an invalid query matches nothing
+			ctx.Data["IssueSearchQueryError"] = err.Error()
+			err = nil
+		}
 		if err != nil {
 			if issue_indexer.IsAvailable(ctx) {
 				ctx.ServerError("issueIDsFromSearch", err)
@@ -13,6 +13,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
 )
 
 const (
@@ -25,7 +26,7 @@ func Packages(ctx *context.Context) {
 	query := ctx.FormTrim("q")
 	packageType := ctx.FormTrim("type")
 
-	pvs, total, err := packages.SearchLatestVersions(ctx, &packages.PackageSearchOptions{
+	opts := &packages.PackageSearchOptions{
 		Paginator: &db.ListOptions{
 			PageSize: setting.UI.PackagesPagingNum,
 			Page:     page,
@@ -35,7 +36,19 @@ func Packages(ctx *context.Context) {
 		Type:       packages.Type(packageType),
 		Name:       packages.SearchValue{Value: query},
 		IsInternal: optional.Some(false),
-	})
+	}
+
+	accessMode, err := context.PackageAccessMode(ctx.Base, ctx.ContextUser, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("PackageAccessMode", err)
+		return
+	}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, accessMode, ctx.ContextUser, ctx.Doer, opts); err != nil {
+		ctx.ServerError("ApplyPrivatePackageFilter", err)
+		return
+	}
+
+	pvs, total, err := packages.SearchLatestVersions(ctx, opts)
 	if err != nil {
 		ctx.ServerError("SearchLatestVersions", err)
 		return
@@ -843,6 +843,13 @@ func viewPullFiles(ctx *context.Context, beforeCommitID, afterCommitID string) {
 		return
 	}
 
+	if setting.Actions.Enabled && ctx.Repo.CanRead(unit.TypeActions) {
+		if err = diff.LoadActionsAnnotations(ctx, ctx.Repo.Repository.ID, afterCommitID); err != nil {
+			ctx.ServerError("LoadActionsAnnotations", err)
+			return
+		}
+	}
+
 	allComments := issues_model.CommentList{}
 	for _, file := range diff.Files {
 		for _, section := range file.Sections {
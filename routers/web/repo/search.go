@@ -4,6 +4,7 @@
 package repo
 
 import (
+	"errors"
 	"net/http"
 
 	"code.gitea.io/gitea/models/db"
@@ -12,6 +13,7 @@ import (
 	"code.gitea.io/gitea/modules/indexer/code/gitgrep"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/context"
 )
@@ -22,7 +24,7 @@ const tplSearch templates.TplName = "repo/search"
 func Search(ctx *context.Context) {
 	ctx.Data["PageIsViewCode"] = true
 	prepareSearch := common.PrepareCodeSearch(ctx)
-	if prepareSearch.Keyword == "" {
+	if prepareSearch.IsEmpty() {
 		ctx.HTML(http.StatusOK, tplSearch)
 		return
 	}
@@ -40,6 +42,9 @@ func Search(ctx *context.Context) {
 		total, searchResults, searchResultLanguages, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
 			RepoIDs:    []int64{ctx.Repo.Repository.ID},
 			Keyword:    prepareSearch.Keyword,
+			Symbol:     prepareSearch.Symbol,
+			Path:       prepareSearch.Path,
+			Refs:       prepareSearch.Refs,
 			SearchMode: prepareSearch.SearchMode,
 			Language:   prepareSearch.Language,
 			Paginator: &db.ListOptions{
@@ -47,7 +52,9 @@ func Search(ctx *context.Context) {
 				PageSize: setting.UI.RepoSearchPagingNum,
 			},
 		})
-		if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("search.code_search_invalid_query", err.Error()), true)
+		} else if err != nil {
 			if code_indexer.IsAvailable(ctx) {
 				ctx.ServerError("SearchResults", err)
 				return
@@ -60,8 +67,10 @@ func Search(ctx *context.Context) {
 		var err error
 		// ref should be default branch or the first existing branch
 		searchRef := git.RefNameFromBranch(ctx.Repo.Repository.DefaultBranch)
-		searchResults, total, err = gitgrep.PerformSearch(ctx, page, ctx.Repo.Repository.ID, ctx.Repo.GitRepo, searchRef, prepareSearch.Keyword, prepareSearch.SearchMode)
-		if err != nil {
+		searchResults, total, err = gitgrep.PerformSearch(ctx, page, ctx.Repo.Repository.ID, ctx.Repo.GitRepo, searchRef, &prepareSearch.Query, prepareSearch.SearchMode)
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("search.code_search_invalid_query", err.Error()), true)
+		} else if err != nil {
 			ctx.ServerError("gitgrep.PerformSearch", err)
 			return
 		}
@@ -7,6 +7,7 @@ package setting
 import (
 	"errors"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -66,6 +67,7 @@ func SettingsCtxData(ctx *context.Context) {
 	ctx.Data["SigningKeyAvailable"] = signing != nil
 	ctx.Data["SigningSettings"] = setting.Repository.Signing
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	ctx.Data["MaxIndexerRefs"] = setting.Indexer.MaxIndexerRefs
 
 	if ctx.Doer.IsAdmin {
 		if setting.Indexer.RepoIndexerEnabled {
@@ -134,6 +136,8 @@ func SettingsPost(ctx *context.Context) {
 		handleSettingsPostAdvanced(ctx)
 	case "signing":
 		handleSettingsPostSigning(ctx)
+	case "code_search":
+		handleSettingsPostCodeSearch(ctx)
 	case "admin":
 		handleSettingsPostAdmin(ctx)
 	case "admin_index":
@@ -673,6 +677,37 @@ func handleSettingsPostSigning(ctx *context.Context) {
 	ctx.Redirect(ctx.Repo.RepoLink + "/settings")
 }
 
+func handleSettingsPostCodeSearch(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.RepoSettingForm)
+	repo := ctx.Repo.Repository
+	patterns := make([]string, 0, 5)
+	for line := range strings.SplitSeq(form.CodeIndexerRefPatterns, "\n") {
+		if pattern := strings.TrimSpace(line); pattern != "" && !slices.Contains(patterns, pattern) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	if err := code.ValidateRefPatterns(patterns); err != nil {
+		ctx.Flash.Error(ctx.Tr("repo.settings.code_search_ref_patterns_invalid", err.Error()))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+		return
+	}
+
+	if !slices.Equal(patterns, repo.CodeIndexerRefPatterns) {
+		repo.CodeIndexerRefPatterns = patterns
+		if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "code_indexer_ref_patterns"); err != nil {
+			ctx.ServerError("UpdateRepositoryColsNoAutoTime", err)
+			return
+		}
+		if setting.Indexer.RepoIndexerEnabled {
+			code.UpdateRepoIndexer(repo)
+		}
+		log.Trace("Repository code search settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+}
+
 func handleSettingsPostAdmin(ctx *context.Context) {
 	if !ctx.Doer.IsAdmin {
 		ctx.HTTPError(http.StatusForbidden)
@@ -187,6 +187,7 @@ func ParseHookEvent(form forms.WebhookForm) *webhook_module.HookEvent {
 			webhook_module.HookEventStatus:                   form.Status,
 			webhook_module.HookEventWorkflowRun:              form.WorkflowRun,
 			webhook_module.HookEventWorkflowJob:              form.WorkflowJob,
+			webhook_module.HookEventWorkflowJobQueue:         form.WorkflowJobQueue,
 		},
 		BranchFilter: form.BranchFilter,
 	}
@@ -199,6 +199,15 @@ func RunnersEdit(ctx *context.Context) {
 
 	ctx.Data["Runner"] = runner
 
+	if runner.OwnerID != 0 && runner.RepoID == 0 {
+		groups, err := db.Find[actions_model.ActionRunnerGroup](ctx, actions_model.FindRunnerGroupOptions{OwnerID: runner.OwnerID})
+		if err != nil {
+			ctx.ServerError("FindRunnerGroups", err)
+			return
+		}
+		ctx.Data["RunnerGroups"] = groups
+	}
+
 	opts := actions_model.FindTaskOptions{
 		ListOptions: db.ListOptions{
 			Page:     page,
@@ -253,6 +262,9 @@ func RunnersEditPost(ctx *context.Context) {
 	runner.Description = form.Description
 
 	err = actions_model.UpdateRunner(ctx, runner, "description")
+	if err == nil && runner.OwnerID != 0 && runner.RepoID == 0 {
+		err = actions_model.SetRunnerGroup(ctx, runner, form.GroupID)
+	}
 	if err != nil {
 		log.Warn("RunnerDetailsEditPost.UpdateRunner failed: %v, url: %s", err, ctx.Req.URL)
 		ctx.Flash.Warning(ctx.Tr("actions.runners.update_runner_failed"))
@@ -4,8 +4,11 @@
 package packages
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	packages_model "code.gitea.io/gitea/models/packages"
@@ -13,11 +16,13 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	cargo_service "code.gitea.io/gitea/services/packages/cargo"
 	container_service "code.gitea.io/gitea/services/packages/container"
+	signing_service "code.gitea.io/gitea/services/packages/signing"
 )
 
 func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
@@ -28,6 +33,30 @@ func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
 	}
 
 	ctx.Data["CleanupRules"] = pcrs
+
+	prs, err := packages_model.GetRemotesByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetRemotesByOwner", err)
+		return
+	}
+
+	ctx.Data["Remotes"] = prs
+
+	pvs, err := packages_model.GetVirtualsByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetVirtualsByOwner", err)
+		return
+	}
+
+	ctx.Data["Virtuals"] = pvs
+
+	keyInfos, err := signing_service.GetKeyInfos(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetKeyInfos", err)
+		return
+	}
+
+	ctx.Data["SigningKeys"] = keyInfos
 }
 
 func SetRuleAddContext(ctx *context.Context) {
@@ -94,6 +123,7 @@ func performRuleEditPost(ctx *context.Context, owner *user_model.User, pcr *pack
 	pcr.RemoveDays = form.RemoveDays
 	pcr.RemovePattern = form.RemovePattern
 	pcr.MatchFullName = form.MatchFullName
+	pcr.RemoveUntaggedDays = form.RemoveUntaggedDays
 
 	ctx.Data["IsEditRule"] = isEditRule
 	ctx.Data["CleanupRule"] = pcr
@@ -199,10 +229,36 @@ func SetRulePreviewContext(ctx *context.Context, owner *user_model.User) {
 			}
 			versionsToRemove = append(versionsToRemove, pd)
 		}
+
+		if pcr.Type == packages_model.TypeContainer && pcr.RemoveUntaggedDays > 0 {
+			pvs, err := container_service.FindUnreachableUntaggedManifests(ctx, p, time.Now().AddDate(0, 0, -pcr.RemoveUntaggedDays))
+			if err != nil {
+				ctx.ServerError("FindUnreachableUntaggedManifests", err)
+				return
+			}
+			for _, pv := range pvs {
+				if slices.ContainsFunc(versionsToRemove, func(pd *packages_model.PackageDescriptor) bool { return pd.Version.ID == pv.ID }) {
+					continue
+				}
+
+				pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+				if err != nil {
+					ctx.ServerError("GetPackageDescriptor", err)
+					return
+				}
+				versionsToRemove = append(versionsToRemove, pd)
+			}
+		}
+	}
+
+	var sizeToRemove int64
+	for _, pd := range versionsToRemove {
+		sizeToRemove += pd.CalculateBlobSize()
 	}
 
 	ctx.Data["CleanupRule"] = pcr
 	ctx.Data["VersionsToRemove"] = versionsToRemove
+	ctx.Data["SizeToRemove"] = sizeToRemove
 }
 
 func getCleanupRuleByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageCleanupRule {
@@ -230,6 +286,327 @@ func getCleanupRuleByContext(ctx *context.Context, owner *user_model.User) *pack
 	return nil
 }
 
+func SetRemoteAddContext(ctx *context.Context) {
+	setRemoteEditContext(ctx, nil)
+}
+
+func SetRemoteEditContext(ctx *context.Context, owner *user_model.User) {
+	pr := getRemoteByContext(ctx, owner)
+	if pr == nil {
+		return
+	}
+
+	setRemoteEditContext(ctx, pr)
+}
+
+func setRemoteEditContext(ctx *context.Context, pr *packages_model.PackageRemote) {
+	ctx.Data["IsEditRemote"] = pr != nil
+
+	if pr == nil {
+		pr = &packages_model.PackageRemote{}
+	}
+	ctx.Data["Remote"] = pr
+	ctx.Data["AvailableTypes"] = packages_model.RemoteTypes
+}
+
+func PerformRemoteAddPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	performRemoteEditPost(ctx, owner, nil, redirectURL, template)
+}
+
+func PerformRemoteEditPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	pr := getRemoteByContext(ctx, owner)
+	if pr == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageRemoteForm)
+
+	if form.Action == "remove" {
+		if err := packages_model.DeleteRemoteByID(ctx, pr.ID); err != nil {
+			ctx.ServerError("DeleteRemoteByID", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.remotes.success.delete"))
+		ctx.Redirect(redirectURL)
+	} else {
+		performRemoteEditPost(ctx, owner, pr, redirectURL, template)
+	}
+}
+
+func performRemoteEditPost(ctx *context.Context, owner *user_model.User, pr *packages_model.PackageRemote, redirectURL string, template templates.TplName) {
+	isEditRemote := pr != nil
+
+	if pr == nil {
+		pr = &packages_model.PackageRemote{}
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageRemoteForm)
+
+	pr.Enabled = form.Enabled
+	pr.OwnerID = owner.ID
+	pr.URL = strings.TrimSuffix(form.URL, "/")
+	pr.Username = form.Username
+	pr.MetadataTTL = form.MetadataTTL
+	if !isEditRemote {
+		pr.Type = packages_model.Type(form.Type)
+	}
+
+	ctx.Data["IsEditRemote"] = isEditRemote
+	ctx.Data["Remote"] = pr
+	ctx.Data["AvailableTypes"] = packages_model.RemoteTypes
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+
+	// an empty password keeps the existing one, unless the username is removed
+	if form.Password != "" || form.Username == "" {
+		if err := pr.SetPassword(form.Password); err != nil {
+			ctx.ServerError("SetPassword", err)
+			return
+		}
+	}
+
+	if isEditRemote {
+		if err := packages_model.UpdateRemote(ctx, pr); err != nil {
+			ctx.ServerError("UpdateRemote", err)
+			return
+		}
+	} else {
+		if has, err := packages_model.HasOwnerRemoteForPackageType(ctx, owner.ID, pr.Type); err != nil {
+			ctx.ServerError("HasOwnerRemoteForPackageType", err)
+			return
+		} else if has {
+			ctx.Data["Err_Type"] = true
+			ctx.Flash.Error(ctx.Tr("packages.owner.settings.remotes.type.exists"), true)
+			ctx.HTML(http.StatusOK, template)
+			return
+		}
+
+		var err error
+		if pr, err = packages_model.InsertRemote(ctx, pr); err != nil {
+			ctx.ServerError("InsertRemote", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.remotes.success.update"))
+	ctx.Redirect(fmt.Sprintf("%s/remotes/%d", redirectURL, pr.ID))
+}
+
+func getRemoteByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageRemote {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.PathParamInt64("id")
+	}
+
+	pr, err := packages_model.GetRemoteByID(ctx, id)
+	if err != nil {
+		if err == packages_model.ErrPackageRemoteNotExist {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetRemoteByID", err)
+		}
+		return nil
+	}
+
+	if pr.OwnerID == owner.ID {
+		return pr
+	}
+
+	ctx.NotFound(fmt.Errorf("PackageRemote[%v] not associated to owner %v", id, owner))
+
+	return nil
+}
+
+func SetVirtualAddContext(ctx *context.Context) {
+	setVirtualEditContext(ctx, nil, "")
+}
+
+func SetVirtualEditContext(ctx *context.Context, owner *user_model.User) {
+	pv := getVirtualByContext(ctx, owner)
+	if pv == nil {
+		return
+	}
+
+	sources, err := formatVirtualSources(ctx, pv.Sources)
+	if err != nil {
+		ctx.ServerError("formatVirtualSources", err)
+		return
+	}
+
+	setVirtualEditContext(ctx, pv, sources)
+}
+
+func setVirtualEditContext(ctx *context.Context, pv *packages_model.PackageVirtual, sources string) {
+	ctx.Data["IsEditVirtual"] = pv != nil
+
+	if pv == nil {
+		pv = &packages_model.PackageVirtual{}
+	}
+	ctx.Data["Virtual"] = pv
+	ctx.Data["VirtualSources"] = sources
+	ctx.Data["AvailableTypes"] = packages_model.VirtualTypes
+}
+
+func PerformVirtualAddPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	performVirtualEditPost(ctx, owner, nil, redirectURL, template)
+}
+
+func PerformVirtualEditPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	pv := getVirtualByContext(ctx, owner)
+	if pv == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageVirtualForm)
+
+	if form.Action == "remove" {
+		if err := packages_model.DeleteVirtualByID(ctx, pv.ID); err != nil {
+			ctx.ServerError("DeleteVirtualByID", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.virtuals.success.delete"))
+		ctx.Redirect(redirectURL)
+	} else {
+		performVirtualEditPost(ctx, owner, pv, redirectURL, template)
+	}
+}
+
+func performVirtualEditPost(ctx *context.Context, owner *user_model.User, pv *packages_model.PackageVirtual, redirectURL string, template templates.TplName) {
+	isEditVirtual := pv != nil
+
+	if pv == nil {
+		pv = &packages_model.PackageVirtual{}
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageVirtualForm)
+
+	pv.Enabled = form.Enabled
+	pv.OwnerID = owner.ID
+	pv.InternalPattern = form.InternalPattern
+	if !isEditVirtual {
+		pv.Type = packages_model.Type(form.Type)
+	}
+
+	ctx.Data["IsEditVirtual"] = isEditVirtual
+	ctx.Data["Virtual"] = pv
+	ctx.Data["VirtualSources"] = form.Sources
+	ctx.Data["AvailableTypes"] = packages_model.VirtualTypes
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+
+	sources, invalidName, err := parseVirtualSources(ctx, owner, form.Sources)
+	if err != nil {
+		ctx.ServerError("parseVirtualSources", err)
+		return
+	} else if invalidName != "" {
+		ctx.Data["Err_Sources"] = true
+		ctx.Flash.Error(ctx.Tr("packages.owner.settings.virtuals.sources.invalid", invalidName), true)
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+	pv.Sources = sources
+
+	if isEditVirtual {
+		if err := packages_model.UpdateVirtual(ctx, pv); err != nil {
+			ctx.ServerError("UpdateVirtual", err)
+			return
+		}
+	} else {
+		if has, err := packages_model.HasOwnerVirtualForPackageType(ctx, owner.ID, pv.Type); err != nil {
+			ctx.ServerError("HasOwnerVirtualForPackageType", err)
+			return
+		} else if has {
+			ctx.Data["Err_Type"] = true
+			ctx.Flash.Error(ctx.Tr("packages.owner.settings.virtuals.type.exists"), true)
+			ctx.HTML(http.StatusOK, template)
+			return
+		}
+
+		if pv, err = packages_model.InsertVirtual(ctx, pv); err != nil {
+			ctx.ServerError("InsertVirtual", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.virtuals.success.update"))
+	ctx.Redirect(fmt.Sprintf("%s/virtuals/%d", redirectURL, pv.ID))
+}
+
+// parseVirtualSources parses the sources of a virtual registry, one owner name per line.
+// The remote of the owner is used instead of the local packages if the name has the ":remote" suffix.
+// The name of the first owner which doesn't exist is returned if there is one.
+func parseVirtualSources(ctx *context.Context, owner *user_model.User, s string) ([]*packages_model.VirtualSource, string, error) {
+	sources := make([]*packages_model.VirtualSource, 0, 5)
+	for line := range strings.SplitSeq(s, "\n") {
+		name, isRemote := strings.CutSuffix(strings.TrimSpace(line), ":remote")
+		if name == "" {
+			continue
+		}
+
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return nil, name, nil
+			}
+			return nil, "", err
+		}
+		// the local packages of the owner are always the first source
+		if u.ID == owner.ID && !isRemote {
+			continue
+		}
+		sources = append(sources, &packages_model.VirtualSource{OwnerID: u.ID, Remote: isRemote})
+	}
+	return sources, "", nil
+}
+
+func formatVirtualSources(ctx *context.Context, sources []*packages_model.VirtualSource) (string, error) {
+	lines := make([]string, 0, len(sources))
+	for _, vs := range sources {
+		u, err := user_model.GetUserByID(ctx, vs.OwnerID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return "", err
+		}
+		lines = append(lines, u.Name+util.Iif(vs.Remote, ":remote", ""))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func getVirtualByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageVirtual {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.PathParamInt64("id")
+	}
+
+	pv, err := packages_model.GetVirtualByID(ctx, id)
+	if err != nil {
+		if err == packages_model.ErrPackageVirtualNotExist {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetVirtualByID", err)
+		}
+		return nil
+	}
+
+	if pv.OwnerID == owner.ID {
+		return pv
+	}
+
+	ctx.NotFound(fmt.Errorf("PackageVirtual[%v] not associated to owner %v", id, owner))
+
+	return nil
+}
+
 func InitializeCargoIndex(ctx *context.Context, owner *user_model.User) {
 	err := cargo_service.InitializeIndexRepository(ctx, owner, owner)
 	if err != nil {
@@ -240,6 +617,34 @@ func InitializeCargoIndex(ctx *context.Context, owner *user_model.User) {
 	}
 }
 
+func UpdateSigningKey(ctx *context.Context, owner *user_model.User) {
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageSigningKeyForm)
+
+	packageType := packages_model.Type(form.Type)
+	overlap := time.Duration(form.OverlapDays) * 24 * time.Hour
+
+	var err error
+	if form.Action == "import" {
+		err = signing_service.ImportKey(ctx, owner.ID, packageType, form.PrivateKey, overlap)
+	} else {
+		err = signing_service.RotateKey(ctx, owner.ID, packageType, overlap)
+	}
+	if err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			log.Error("UpdateSigningKey failed: %v", err)
+		}
+		ctx.Flash.Error(ctx.Tr("packages.owner.settings.signing.error", err))
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.signing.success", packageType.Name()))
+}
+
 func RebuildCargoIndex(ctx *context.Context, owner *user_model.User) {
 	err := cargo_service.RebuildIndex(ctx, owner, owner)
 	if err != nil {
@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package web
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/context"
+)
+
+type terraformServiceDiscoveryType struct {
+	ModulesV1   string `json:"modules.v1"`
+	ProvidersV1 string `json:"providers.v1"`
+}
+
+// terraformServiceDiscovery returns the base urls of the Terraform registry protocols
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+func terraformServiceDiscovery(ctx *context.Context) {
+	baseURL := setting.AppURL + "api/packages/-/terraform/"
+	ctx.JSON(http.StatusOK, terraformServiceDiscoveryType{
+		ModulesV1:   baseURL + "modules/v1/",
+		ProvidersV1: baseURL + "providers/v1/",
+	})
+}
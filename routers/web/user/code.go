@@ -4,6 +4,7 @@
 package user
 
 import (
+	"errors"
 	"net/http"
 	"slices"
 
@@ -12,6 +13,7 @@ import (
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/common"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
 	"code.gitea.io/gitea/services/context"
@@ -37,7 +39,7 @@ func CodeSearch(ctx *context.Context) {
 	ctx.Data["IsCodePage"] = true
 
 	prepareSearch := common.PrepareCodeSearch(ctx)
-	if prepareSearch.Keyword == "" {
+	if prepareSearch.IsEmpty() {
 		ctx.HTML(http.StatusOK, tplUserCode)
 		return
 	}
@@ -57,6 +59,13 @@ func CodeSearch(ctx *context.Context) {
 		ctx.ServerError("FindUserCodeAccessibleOwnerRepoIDs", err)
 		return
 	}
+	if len(prepareSearch.Repos) > 0 {
+		repoIDs, err = common.FilterCodeSearchRepoIDs(ctx, prepareSearch.Repos, ctx.ContextUser.Name, repoIDs, false)
+		if err != nil {
+			ctx.ServerError("FilterCodeSearchRepoIDs", err)
+			return
+		}
+	}
 
 	var (
 		total                 int64
@@ -68,6 +77,9 @@ func CodeSearch(ctx *context.Context) {
 		total, searchResults, searchResultLanguages, err = code_indexer.PerformSearch(ctx, &code_indexer.SearchOptions{
 			RepoIDs:    repoIDs,
 			Keyword:    prepareSearch.Keyword,
+			Symbol:     prepareSearch.Symbol,
+			Path:       prepareSearch.Path,
+			Refs:       prepareSearch.Refs,
 			SearchMode: prepareSearch.SearchMode,
 			Language:   prepareSearch.Language,
 			Paginator: &db.ListOptions{
@@ -75,7 +87,9 @@ func CodeSearch(ctx *context.Context) {
 				PageSize: setting.UI.RepoSearchPagingNum,
 			},
 		})
-		if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("search.code_search_invalid_query", err.Error()), true)
+		} else if err != nil {
 			if code_indexer.IsAvailable(ctx) {
 				ctx.ServerError("SearchResults", err)
 				return
@@ -6,8 +6,10 @@ package user
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
 	"slices"
 	"sort"
@@ -28,6 +30,7 @@ import (
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/indexer"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	issue_query "code.gitea.io/gitea/modules/indexer/issues/query"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/markup/markdown"
 	"code.gitea.io/gitea/modules/optional"
@@ -41,6 +44,7 @@ import (
 	feed_service "code.gitea.io/gitea/services/feed"
 	issue_service "code.gitea.io/gitea/services/issue"
 	pull_service "code.gitea.io/gitea/services/pull"
+	savedsearch_service "code.gitea.io/gitea/services/savedsearch"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/ProtonMail/go-crypto/openpgp/armor"
@@ -535,13 +539,29 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 
 	// Slice of Issues that will be displayed on the overview page
 	// USING FINAL STATE OF opts FOR A QUERY.
+	searchOpts := issue_indexer.ToSearchOptions(keyword, opts).Copy(
+		func(o *issue_indexer.SearchOptions) {
+			o.SearchMode = indexer.SearchModeType(searchMode)
+		},
+	)
+	if searchQuery := issue_query.Parse(keyword); searchQuery.HasFilters() {
+		if err := issue_indexer.ApplyQuery(ctx, ctx.Doer, searchQuery, searchOpts); err != nil {
+			if !errors.Is(err, util.ErrInvalidArgument) {
+				ctx.ServerError("ApplyQuery", err)
+				return
+			}
+			// an invalid query matches nothing
+			ctx.Data["IssueSearchQueryError"] = err.Error()
+			searchOpts = nil
+		} else {
+			searchOpts.IsPull = opts.IsPull // the page decides between issues and pull requests
+		}
+		sortType = util.IfZero(issue_indexer.QuerySortType(searchQuery), sortType)
+	}
+
 	var issues issues_model.IssueList
-	{
-		issueIDs, _, err := issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(keyword, opts).Copy(
-			func(o *issue_indexer.SearchOptions) {
-				o.SearchMode = indexer.SearchModeType(searchMode)
-			},
-		))
+	if searchOpts != nil {
+		issueIDs, _, err := issue_indexer.SearchIssues(ctx, searchOpts)
 		if err != nil {
 			ctx.ServerError("issueIDsFromSearch", err)
 			return
@@ -567,14 +587,13 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	// -------------------------------
 	// Fill stats to post to ctx.Data.
 	// -------------------------------
-	issueStats, err := getUserIssueStats(ctx, ctxUser, filterMode, issue_indexer.ToSearchOptions(keyword, opts).Copy(
-		func(o *issue_indexer.SearchOptions) {
-			o.SearchMode = indexer.SearchModeType(searchMode)
-		},
-	))
-	if err != nil {
-		ctx.ServerError("getUserIssueStats", err)
-		return
+	issueStats := &issues_model.IssueStats{}
+	if searchOpts != nil {
+		issueStats, err = getUserIssueStats(ctx, ctxUser, filterMode, searchOpts)
+		if err != nil {
+			ctx.ServerError("getUserIssueStats", err)
+			return
+		}
 	}
 
 	// Will be posted to ctx.Data.
@@ -638,9 +657,47 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	pager.AddParamFromRequest(ctx.Req)
 	ctx.Data["Page"] = pager
 
+	loadPinnedSavedSearches(ctx, isPullList)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["SaveSearchLink"] = fmt.Sprintf("%s/user/settings/saved_searches?type=%s&owner=%s&q=%s",
+		setting.AppSubURL, util.Iif(isPullList, "pulls", "issues"), url.QueryEscape(ctxUser.Name), url.QueryEscape(keyword))
+
 	ctx.HTML(http.StatusOK, tplIssues)
 }
 
+// loadPinnedSavedSearches loads the saved searches pinned by the doer and the number of open items matching them
+func loadPinnedSavedSearches(ctx *context.Context, isPull bool) {
+	searches, err := db.Find[issues_model.SavedSearch](ctx, issues_model.FindSavedSearchOptions{
+		VisibleToUserID: ctx.Doer.ID,
+		PinnedByUserID:  ctx.Doer.ID,
+		IsPull:          optional.Some(isPull),
+	})
+	if err != nil {
+		ctx.ServerError("FindSavedSearches", err)
+		return
+	}
+	if err := issues_model.SavedSearchList(searches).LoadOwners(ctx); err != nil {
+		ctx.ServerError("LoadOwners", err)
+		return
+	}
+
+	counts := make(map[int64]int64, len(searches))
+	for _, s := range searches {
+		count, err := savedsearch_service.Count(ctx, ctx.Doer, s)
+		if err != nil && !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("CountSavedSearch", err)
+			return
+		}
+		// an invalid query matches nothing
+		counts[s.ID] = count
+	}
+
+	ctx.Data["PinnedSavedSearches"] = searches
+	ctx.Data["PinnedSavedSearchCounts"] = counts
+}
+
 // ShowSSHKeys output all the ssh keys of user by uid
 func ShowSSHKeys(ctx *context.Context) {
 	keys, err := db.Find[asymkey_model.PublicKey](ctx, asymkey_model.FindPublicKeyOptions{
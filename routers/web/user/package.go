@@ -4,6 +4,7 @@
 package user
 
 import (
+	"bytes"
 	gocontext "context"
 	"errors"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	container_module "code.gitea.io/gitea/modules/packages/container"
 	debian_module "code.gitea.io/gitea/modules/packages/debian"
 	rpm_module "code.gitea.io/gitea/modules/packages/rpm"
+	sbom_module "code.gitea.io/gitea/modules/packages/sbom"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/util"
@@ -34,7 +36,10 @@ import (
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	packages_service "code.gitea.io/gitea/services/packages"
+	cargo_service "code.gitea.io/gitea/services/packages/cargo"
 	container_service "code.gitea.io/gitea/services/packages/container"
+	sbom_service "code.gitea.io/gitea/services/packages/sbom"
+	signing_service "code.gitea.io/gitea/services/packages/signing"
 )
 
 const (
@@ -54,7 +59,7 @@ func ListPackages(ctx *context.Context) {
 	query := ctx.FormTrim("q")
 	packageType := ctx.FormTrim("type")
 
-	pvs, total, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+	opts := &packages_model.PackageSearchOptions{
 		Paginator: &db.ListOptions{
 			PageSize: setting.UI.PackagesPagingNum,
 			Page:     page,
@@ -63,7 +68,13 @@ func ListPackages(ctx *context.Context) {
 		Type:       packages_model.Type(packageType),
 		Name:       packages_model.SearchValue{Value: query},
 		IsInternal: optional.Some(false),
-	})
+	}
+	if err := packages_service.ApplyPrivatePackageFilter(ctx, ctx.Package.AccessMode, ctx.ContextUser, ctx.Doer, opts); err != nil {
+		ctx.ServerError("ApplyPrivatePackageFilter", err)
+		return
+	}
+
+	pvs, total, err := packages_model.SearchLatestVersions(ctx, opts)
 	if err != nil {
 		ctx.ServerError("SearchLatestVersions", err)
 		return
@@ -184,6 +195,42 @@ func viewPackageContainerImage(ctx gocontext.Context, pd *packages_model.Package
 	return metadata, err
 }
 
+// prepareContainerReferrers loads the artifacts attached to the displayed manifest and the manifest an artifact is attached to
+func prepareContainerReferrers(ctx *context.Context, pd *packages_model.PackageDescriptor, digest string) error {
+	if digest == "" {
+		for _, pfd := range pd.Files {
+			if pfd.File.IsLead && pfd.File.LowerName == container_module.ManifestFilename {
+				digest = pfd.Properties.GetByName(container_module.PropertyDigest)
+			}
+		}
+	}
+	if digest != "" {
+		referrers, err := container_service.GetReferrers(ctx, pd.Owner.ID, pd.Package.LowerName, digest, "")
+		if err != nil {
+			return err
+		}
+		ctx.Data["ContainerReferrers"] = referrers
+	}
+
+	metadata, ok := pd.Metadata.(*container_module.Metadata)
+	if !ok || metadata.Subject == "" {
+		return nil
+	}
+	pvs, err := container_model.GetManifestVersions(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    pd.Owner.ID,
+		Image:      pd.Package.LowerName,
+		Digest:     metadata.Subject,
+		IsManifest: true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(pvs) > 0 {
+		ctx.Data["ContainerSubjectVersion"] = pvs[0]
+	}
+	return nil
+}
+
 // ViewPackageVersion displays a single package version
 func ViewPackageVersion(ctx *context.Context) {
 	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
@@ -293,7 +340,23 @@ func ViewPackageVersion(ctx *context.Context) {
 			}
 		}
 		ctx.Data["ContainerImageMetadata"] = imageMetadata
+
+		if err := prepareContainerReferrers(ctx, pd, versionSub); err != nil {
+			ctx.ServerError("prepareContainerReferrers", err)
+			return
+		}
+	}
+
+	if sbom_service.IsSupportedType(pd.Package.Type) {
+		vulnerabilities, err := packages_model.GetVulnerabilitiesByVersionID(ctx, pd.Version.ID)
+		if err != nil {
+			ctx.ServerError("GetVulnerabilitiesByVersionID", err)
+			return
+		}
+		ctx.Data["SupportsSBOM"] = true
+		ctx.Data["Vulnerabilities"] = vulnerabilities
 	}
+
 	var pvs []*packages_model.PackageVersion
 	var pvsTotal int64
 	if pd.Package.Type == packages_model.TypeContainer {
@@ -442,9 +505,46 @@ func PackageSettings(ctx *context.Context) {
 		ctx.Data["LinkedRepoName"] = repo.Name
 	}
 
+	ctx.Data["CanManagePackageAccess"] = canManagePackageAccess(ctx)
+	if pd.Owner.IsOrganization() {
+		teams, err := org_model.FindOrgTeams(ctx, pd.Owner.ID)
+		if err != nil {
+			ctx.ServerError("FindOrgTeams", err)
+			return
+		}
+		pts, err := packages_model.GetPackageTeams(ctx, pd.Package.ID)
+		if err != nil {
+			ctx.ServerError("GetPackageTeams", err)
+			return
+		}
+
+		packageTeams := make([]*packageTeamPermission, 0, len(pts))
+		for _, pt := range pts {
+			for _, t := range teams {
+				if t.ID == pt.TeamID {
+					packageTeams = append(packageTeams, &packageTeamPermission{Team: t, AccessMode: pt.AccessMode})
+					break
+				}
+			}
+		}
+
+		ctx.Data["Teams"] = teams
+		ctx.Data["PackageTeams"] = packageTeams
+	}
+
 	ctx.HTML(http.StatusOK, tplPackagesSettings)
 }
 
+type packageTeamPermission struct {
+	Team       *org_model.Team
+	AccessMode perm.AccessMode
+}
+
+// canManagePackageAccess returns true if the doer can change the visibility and the permissions of the package
+func canManagePackageAccess(ctx *context.Context) bool {
+	return ctx.Package.AccessMode >= perm.AccessModeAdmin || ctx.IsUserSiteAdmin()
+}
+
 // PackageSettingsPost updates the package settings
 func PackageSettingsPost(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.PackageSettingForm)
@@ -453,6 +553,12 @@ func PackageSettingsPost(ctx *context.Context) {
 		packageSettingsPostActionLink(ctx, form)
 	case "delete":
 		packageSettingsPostActionDelete(ctx)
+	case "access":
+		packageSettingsPostActionAccess(ctx, form)
+	case "add_team":
+		packageSettingsPostActionAddTeam(ctx, form)
+	case "remove_team":
+		packageSettingsPostActionRemoveTeam(ctx, form)
 	default:
 		ctx.NotFound(nil)
 	}
@@ -490,6 +596,80 @@ func packageSettingsPostActionLink(ctx *context.Context, form *forms.PackageSett
 	ctx.JSONRedirect("")
 }
 
+func packageSettingsPostActionAccess(ctx *context.Context, form *forms.PackageSettingForm) {
+	if !canManagePackageAccess(ctx) {
+		ctx.NotFound(nil)
+		return
+	}
+
+	p := ctx.Package.Descriptor.Package
+	visibilityChanged := p.IsPrivate != form.IsPrivate
+	p.IsPrivate = form.IsPrivate
+	p.InheritRepoPermissions = form.InheritRepoPermissions
+	if err := packages_model.UpdatePackageAccessSettings(ctx, p); err != nil {
+		ctx.ServerError("UpdatePackageAccessSettings", err)
+		return
+	}
+
+	if visibilityChanged {
+		if p.Type == packages_model.TypeCargo {
+			if err := cargo_service.UpdatePackageIndexIfExists(ctx, ctx.Doer, ctx.Package.Owner, p.ID); err != nil {
+				ctx.ServerError("UpdatePackageIndexIfExists", err)
+				return
+			}
+		} else if err := signing_service.BuildRepositoryFiles(ctx, p.OwnerID, p.Type); err != nil {
+			ctx.ServerError("BuildRepositoryFiles", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.settings.access.success"))
+	ctx.JSONRedirect("")
+}
+
+func packageSettingsPostActionAddTeam(ctx *context.Context, form *forms.PackageSettingForm) {
+	if !canManagePackageAccess(ctx) {
+		ctx.NotFound(nil)
+		return
+	}
+
+	pd := ctx.Package.Descriptor
+
+	mode := perm.ParseAccessMode(form.AccessMode, perm.AccessModeRead, perm.AccessModeWrite, perm.AccessModeAdmin)
+	team, err := org_model.GetTeamByID(ctx, form.TeamID)
+	if err != nil && !org_model.IsErrTeamNotExist(err) {
+		ctx.ServerError("GetTeamByID", err)
+		return
+	}
+	if team == nil || team.OrgID != pd.Owner.ID || mode == perm.AccessModeNone {
+		ctx.JSONError(ctx.Tr("packages.settings.teams.invalid"))
+		return
+	}
+
+	if err := packages_model.SetPackageTeam(ctx, pd.Package.ID, team.ID, mode); err != nil {
+		ctx.ServerError("SetPackageTeam", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.settings.teams.add_success"))
+	ctx.JSONRedirect("")
+}
+
+func packageSettingsPostActionRemoveTeam(ctx *context.Context, form *forms.PackageSettingForm) {
+	if !canManagePackageAccess(ctx) {
+		ctx.NotFound(nil)
+		return
+	}
+
+	if err := packages_model.RemovePackageTeam(ctx, ctx.Package.Descriptor.Package.ID, form.TeamID); err != nil {
+		ctx.ServerError("RemovePackageTeam", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.settings.teams.remove_success"))
+	ctx.JSONRedirect("")
+}
+
 func packageSettingsPostActionDelete(ctx *context.Context) {
 	err := packages_service.RemovePackageVersion(ctx, ctx.Doer, ctx.Package.Descriptor.Version)
 	if err != nil {
@@ -528,3 +708,24 @@ func DownloadPackageFile(ctx *context.Context) {
 
 	packages_helper.ServePackageFile(ctx, s, u, pf)
 }
+
+// DownloadPackageSBOM serves the software bill of materials of a package version
+func DownloadPackageSBOM(ctx *context.Context) {
+	pd := ctx.Package.Descriptor
+	format := ctx.PathParam("format")
+
+	content, err := sbom_service.EncodeDocument(ctx, pd, format)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("EncodeDocument", err)
+		}
+		return
+	}
+
+	ctx.ServeContent(bytes.NewReader(content), &context.ServeHeaderOptions{
+		ContentType: sbom_module.ContentType(format),
+		Filename:    sbom_service.Filename(pd, format),
+	})
+}
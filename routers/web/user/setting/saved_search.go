@@ -0,0 +1,234 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	savedsearch_service "code.gitea.io/gitea/services/savedsearch"
+)
+
+const (
+	tplSettingsSavedSearches templates.TplName = "user/settings/saved_searches"
+)
+
+// SavedSearches render the saved issue searches visible to the user
+func SavedSearches(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.saved_searches")
+	ctx.Data["PageIsSettingsSavedSearches"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	searches, err := db.Find[issues_model.SavedSearch](ctx, issues_model.FindSavedSearchOptions{VisibleToUserID: ctx.Doer.ID})
+	if err != nil {
+		ctx.ServerError("FindSavedSearches", err)
+		return
+	}
+	if err := issues_model.SavedSearchList(searches).LoadOwners(ctx); err != nil {
+		ctx.ServerError("LoadOwners", err)
+		return
+	}
+
+	ids := make([]int64, 0, len(searches))
+	canEdit := make(map[int64]bool, len(searches))
+	for _, s := range searches {
+		ids = append(ids, s.ID)
+		if canEdit[s.ID], err = savedsearch_service.CanEdit(ctx, ctx.Doer, s); err != nil {
+			ctx.ServerError("CanEdit", err)
+			return
+		}
+	}
+	searchUsers, err := issues_model.GetSavedSearchUsers(ctx, ctx.Doer.ID, ids)
+	if err != nil {
+		ctx.ServerError("GetSavedSearchUsers", err)
+		return
+	}
+
+	// the organizations and their teams the user can create saved searches for
+	orgs, err := organization.GetUserOrgsList(ctx, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserOrgsList", err)
+		return
+	}
+	ownedOrgs := make([]*organization.Organization, 0, len(orgs))
+	var teams []*organization.Team
+	teamLabels := make(map[int64]string)
+	for _, org := range orgs {
+		if isOwner, err := org.IsOwnedBy(ctx, ctx.Doer.ID); err != nil {
+			ctx.ServerError("IsOwnedBy", err)
+			return
+		} else if !isOwner {
+			continue
+		}
+		ownedOrgs = append(ownedOrgs, org)
+		orgTeams, err := org.LoadTeams(ctx)
+		if err != nil {
+			ctx.ServerError("LoadTeams", err)
+			return
+		}
+		for _, team := range orgTeams {
+			teams = append(teams, team)
+			teamLabels[team.ID] = org.DisplayName() + " / " + team.Name
+		}
+	}
+	teamNames := make(map[int64]string)
+	for _, s := range searches {
+		if s.TeamID == 0 || teamNames[s.TeamID] != "" {
+			continue
+		}
+		team, err := organization.GetTeamByID(ctx, s.TeamID)
+		if err != nil {
+			ctx.ServerError("GetTeamByID", err)
+			return
+		}
+		teamNames[team.ID] = team.Name
+	}
+
+	ctx.Data["SavedSearches"] = searches
+	ctx.Data["SavedSearchUsers"] = searchUsers
+	ctx.Data["CanEditSavedSearch"] = canEdit
+	ctx.Data["OwnedOrgs"] = ownedOrgs
+	ctx.Data["Teams"] = teams
+	ctx.Data["TeamLabels"] = teamLabels
+	ctx.Data["TeamNames"] = teamNames
+
+	// prefill the form when saving a search from an issue overview page
+	ctx.Data["NewSearchQuery"] = ctx.FormString("q")
+	ctx.Data["NewSearchType"] = ctx.FormString("type")
+	ctx.Data["NewSearchOwner"] = ctx.FormString("owner")
+
+	ctx.HTML(http.StatusOK, tplSettingsSavedSearches)
+}
+
+// SavedSearchesPost creates, changes, deletes, pins and subscribes to saved issue searches
+func SavedSearchesPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SavedSearchForm)
+	redirectTo := setting.AppSubURL + "/user/settings/saved_searches"
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(redirectTo)
+		return
+	}
+
+	if form.Action == "create" {
+		owner := ctx.Doer
+		if form.Owner != "" && form.Owner != ctx.Doer.Name {
+			var err error
+			if owner, err = user_model.GetUserByName(ctx, form.Owner); err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					ctx.Flash.Error(ctx.Tr("settings.saved_search_failure", err.Error()))
+					ctx.Redirect(redirectTo)
+				} else {
+					ctx.ServerError("GetUserByName", err)
+				}
+				return
+			}
+		}
+		if canManage, err := savedsearch_service.CanManage(ctx, ctx.Doer, owner.ID); err != nil {
+			ctx.ServerError("CanManage", err)
+			return
+		} else if !canManage {
+			ctx.NotFound(nil)
+			return
+		}
+
+		search := &issues_model.SavedSearch{
+			OwnerID: owner.ID,
+			Owner:   owner,
+			Name:    form.Name,
+			IsPull:  form.Type == "pulls",
+			Query:   form.Query,
+			TeamID:  util.Iif(owner.IsOrganization(), form.TeamID, 0),
+		}
+		if err := savedsearch_service.CreateSavedSearch(ctx, ctx.Doer, search); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Flash.Error(ctx.Tr("settings.saved_search_failure", err.Error()))
+			} else {
+				ctx.ServerError("CreateSavedSearch", err)
+				return
+			}
+		} else {
+			ctx.Flash.Success(ctx.Tr("settings.saved_search_created", search.Name))
+		}
+		ctx.Redirect(redirectTo)
+		return
+	}
+
+	search, err := issues_model.GetSavedSearchByID(ctx, form.ID)
+	if err != nil {
+		if issues_model.IsErrSavedSearchNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetSavedSearchByID", err)
+		}
+		return
+	}
+	if canView, err := savedsearch_service.CanView(ctx, ctx.Doer, search); err != nil {
+		ctx.ServerError("CanView", err)
+		return
+	} else if !canView {
+		ctx.NotFound(nil)
+		return
+	}
+
+	switch form.Action {
+	case "edit", "delete":
+		if canEdit, err := savedsearch_service.CanEdit(ctx, ctx.Doer, search); err != nil {
+			ctx.ServerError("CanEdit", err)
+			return
+		} else if !canEdit {
+			ctx.NotFound(nil)
+			return
+		}
+		if form.Action == "delete" {
+			if err := issues_model.DeleteSavedSearchByID(ctx, search.ID); err != nil {
+				ctx.ServerError("DeleteSavedSearchByID", err)
+				return
+			}
+			ctx.Flash.Success(ctx.Tr("settings.saved_search_deleted", search.Name))
+			break
+		}
+
+		if err := search.LoadOwner(ctx); err != nil {
+			ctx.ServerError("LoadOwner", err)
+			return
+		}
+		search.Name = form.Name
+		search.IsPull = form.Type == "pulls"
+		search.Query = form.Query
+		search.TeamID = util.Iif(search.Owner.IsOrganization(), form.TeamID, 0)
+		if err := savedsearch_service.UpdateSavedSearch(ctx, ctx.Doer, search); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Flash.Error(ctx.Tr("settings.saved_search_failure", err.Error()))
+			} else {
+				ctx.ServerError("UpdateSavedSearch", err)
+				return
+			}
+		} else {
+			ctx.Flash.Success(ctx.Tr("settings.saved_search_updated", search.Name))
+		}
+	case "pin", "unpin":
+		if err := issues_model.SetSavedSearchPinned(ctx, search.ID, ctx.Doer.ID, form.Action == "pin"); err != nil {
+			ctx.ServerError("SetSavedSearchPinned", err)
+			return
+		}
+	case "subscribe", "unsubscribe":
+		if err := issues_model.SetSavedSearchSubscribed(ctx, search.ID, ctx.Doer.ID, form.Action == "subscribe"); err != nil {
+			ctx.ServerError("SetSavedSearchSubscribed", err)
+			return
+		}
+	}
+
+	ctx.Redirect(redirectTo)
+}
@@ -514,6 +514,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		})
 		m.Get("/passkey-endpoints", passkeyEndpoints)
+		m.Get("/terraform.json", packagesEnabled, terraformServiceDiscovery)
 		m.Methods("GET, HEAD", "/*", public.FileHandlerFunc())
 	}, optionsCorsHandler())
 
@@ -685,10 +686,31 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 					m.Get("/preview", user_setting.PackagesRulePreview)
 				})
 			})
+			m.Group("/remotes", func() {
+				m.Group("/add", func() {
+					m.Get("", user_setting.PackagesRemoteAdd)
+					m.Post("", web.Bind(forms.PackageRemoteForm{}), user_setting.PackagesRemoteAddPost)
+				})
+				m.Group("/{id}", func() {
+					m.Get("", user_setting.PackagesRemoteEdit)
+					m.Post("", web.Bind(forms.PackageRemoteForm{}), user_setting.PackagesRemoteEditPost)
+				})
+			})
+			m.Group("/virtuals", func() {
+				m.Group("/add", func() {
+					m.Get("", user_setting.PackagesVirtualAdd)
+					m.Post("", web.Bind(forms.PackageVirtualForm{}), user_setting.PackagesVirtualAddPost)
+				})
+				m.Group("/{id}", func() {
+					m.Get("", user_setting.PackagesVirtualEdit)
+					m.Post("", web.Bind(forms.PackageVirtualForm{}), user_setting.PackagesVirtualEditPost)
+				})
+			})
 			m.Group("/cargo", func() {
 				m.Post("/initialize", user_setting.InitializeCargoIndex)
 				m.Post("/rebuild", user_setting.RebuildCargoIndex)
 			})
+			m.Post("/signing", web.Bind(forms.PackageSigningKeyForm{}), user_setting.UpdateSigningKey)
 			m.Post("/chef/regenerate_keypair", user_setting.RegenerateChefKeyPair)
 		}, packagesEnabled)
 
@@ -718,6 +740,9 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 			m.Get("", user_setting.BlockedUsers)
 			m.Post("", web.Bind(forms.BlockUserForm{}), user_setting.BlockedUsersPost)
 		})
+
+		m.Combo("/saved_searches").Get(user_setting.SavedSearches).
+			Post(web.Bind(forms.SavedSearchForm{}), user_setting.SavedSearchesPost)
 	}, reqSignIn, ctxDataSet("PageIsUserSettings", true, "EnablePackages", setting.Packages.Enabled, "EnableNotifyMail", setting.Service.EnableNotifyMail))
 
 	m.Group("/user", func() {
@@ -805,6 +830,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 			m.Get("", admin.Packages)
 			m.Post("/delete", admin.DeletePackageVersion)
 			m.Post("/cleanup", admin.CleanupExpiredData)
+			m.Post("/vulnerabilities/import", admin.ImportVulnerabilityDatabase)
 		}, packagesEnabled)
 
 		m.Group("/hooks", func() {
@@ -1021,10 +1047,31 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 							m.Get("/preview", org.PackagesRulePreview)
 						})
 					})
+					m.Group("/remotes", func() {
+						m.Group("/add", func() {
+							m.Get("", org.PackagesRemoteAdd)
+							m.Post("", web.Bind(forms.PackageRemoteForm{}), org.PackagesRemoteAddPost)
+						})
+						m.Group("/{id}", func() {
+							m.Get("", org.PackagesRemoteEdit)
+							m.Post("", web.Bind(forms.PackageRemoteForm{}), org.PackagesRemoteEditPost)
+						})
+					})
+					m.Group("/virtuals", func() {
+						m.Group("/add", func() {
+							m.Get("", org.PackagesVirtualAdd)
+							m.Post("", web.Bind(forms.PackageVirtualForm{}), org.PackagesVirtualAddPost)
+						})
+						m.Group("/{id}", func() {
+							m.Get("", org.PackagesVirtualEdit)
+							m.Post("", web.Bind(forms.PackageVirtualForm{}), org.PackagesVirtualEditPost)
+						})
+					})
 					m.Group("/cargo", func() {
 						m.Post("/initialize", org.InitializeCargoIndex)
 						m.Post("/rebuild", org.RebuildCargoIndex)
 					})
+					m.Post("/signing", web.Bind(forms.PackageSigningKeyForm{}), org.UpdateSigningKey)
 				}, packagesEnabled)
 
 				m.Group("/blocked_users", func() {
@@ -1056,6 +1103,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 						m.Get("", user.ViewPackageVersion)
 						m.Get("/{version_sub}", user.ViewPackageVersion)
 						m.Get("/files/{fileid}", user.DownloadPackageFile)
+						m.Get("/sbom/{format}", user.DownloadPackageSBOM)
 						m.Group("/settings", func() {
 							m.Get("", user.PackageSettings)
 							m.Post("", web.Bind(forms.PackageSettingForm{}), user.PackageSettingsPost)
@@ -1520,6 +1568,7 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 					Get(actions.View).
 					Post(web.Bind(actions.ViewRequest{}), actions.ViewPost)
 				m.Post("/rerun", reqRepoActionsWriter, actions.Rerun)
+				m.Post("/approval", reqRepoActionsWriter, actions.ReviewApprovalGate)
 				m.Get("/logs", actions.Logs)
 			})
 			m.Get("/workflow", actions.ViewWorkflowFile)
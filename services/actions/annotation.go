@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	actions_module "code.gitea.io/gitea/modules/actions"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+)
+
+// ExtractAnnotations stores the annotations written by the workflow commands in the log rows of a task,
+// index is the index of the first row in the logs of the task.
+func ExtractAnnotations(ctx context.Context, task *actions_model.ActionTask, index int64, rows []*runnerv1.LogRow) error {
+	var annotations []*actions_model.ActionTaskAnnotation
+	for i, row := range rows {
+		annotation, ok := actions_module.ParseAnnotation(row.Content)
+		if !ok {
+			continue
+		}
+		annotation.TaskID = task.ID
+		annotation.JobID = task.JobID
+		annotation.RepoID = task.RepoID
+		annotation.CommitSHA = task.CommitSHA
+		annotation.LogIndex = index + int64(i)
+		annotations = append(annotations, annotation)
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	if err := task.LoadJob(ctx); err != nil {
+		return err
+	}
+	for _, annotation := range annotations {
+		annotation.RunID = task.Job.RunID
+	}
+	return actions_model.InsertTaskAnnotations(ctx, task.ID, annotations)
+}
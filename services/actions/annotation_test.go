@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"fmt"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/unittest"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractAnnotations(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+	rows := []*runnerv1.LogRow{
+		{Content: "Run golangci-lint run"},
+		{Content: "::error file=main.go,line=3,col=2,title=unused::x declared and not used"},
+		{Content: "::warning::deprecated option"},
+		{Content: "::debug::not an annotation"},
+	}
+	require.NoError(t, ExtractAnnotations(t.Context(), task, 10, rows))
+
+	annotations, err := actions_model.GetTaskAnnotations(t.Context(), task.ID)
+	require.NoError(t, err)
+	require.Len(t, annotations, 2)
+	assert.Equal(t, actions_model.AnnotationLevelError, annotations[0].Level)
+	assert.EqualValues(t, 11, annotations[0].LogIndex)
+	assert.Equal(t, "main.go", annotations[0].Path)
+	assert.EqualValues(t, 3, annotations[0].Line)
+	assert.Equal(t, "unused", annotations[0].Title)
+	assert.Equal(t, task.JobID, annotations[0].JobID)
+	assert.EqualValues(t, 791, annotations[0].RunID)
+	assert.Equal(t, task.CommitSHA, annotations[0].CommitSHA)
+	assert.Equal(t, actions_model.AnnotationLevelWarning, annotations[1].Level)
+	assert.EqualValues(t, 12, annotations[1].LogIndex)
+
+	// only the annotations with files are shown on the diff of the commit
+	fileAnnotations, err := actions_model.GetCommitFileAnnotations(t.Context(), task.RepoID, task.CommitSHA)
+	require.NoError(t, err)
+	require.Len(t, fileAnnotations, 1)
+	assert.Equal(t, annotations[0].ID, fileAnnotations[0].ID)
+
+	// the annotations exceeding the limit are ignored
+	rows = rows[:0]
+	for i := range actions_model.MaxAnnotationsPerTask {
+		rows = append(rows, &runnerv1.LogRow{Content: fmt.Sprintf("::notice::notice %d", i)})
+	}
+	require.NoError(t, ExtractAnnotations(t.Context(), task, 20, rows))
+	annotations, err = actions_model.GetTaskAnnotations(t.Context(), task.ID)
+	require.NoError(t, err)
+	assert.Len(t, annotations, actions_model.MaxAnnotationsPerTask)
+}
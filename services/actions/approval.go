@@ -0,0 +1,181 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// approvalGateTimeout returns how long an approval gate waits for a review,
+// the `timeout-minutes` of the job overrides the default timeout.
+func approvalGateTimeout(job *actions_model.ActionRunJob) time.Duration {
+	if workflowJob, err := job.ParseJob(); err == nil {
+		if timeout := workflowJob.Timeout(); timeout > 0 {
+			return timeout
+		}
+	}
+	return setting.Actions.ApprovalGateTimeout
+}
+
+// prepareApprovalGate opens the approval gate of the current attempt of the job if it hasn't been opened,
+// and returns the status the job should be changed to:
+//   - StatusBlocked if the gate is waiting for a review
+//   - StatusSuccess if the gate has been approved, or it isn't required since the run is trusted
+//   - StatusFailure if the gate has been rejected
+//   - StatusCancelled if nobody reviewed the gate before the deadline
+//
+// opened is true if the gate has been opened just now.
+func prepareApprovalGate(ctx context.Context, job *actions_model.ActionRunJob) (_ actions_model.Status, opened bool, _ error) {
+	// an approval gate isn't picked up by runners, the "started" timestamp marks that the gate of the current attempt has been opened
+	if job.Started > 0 {
+		approval, err := actions_model.GetApprovalByJobAttempt(ctx, job.ID, job.Attempt)
+		if err != nil {
+			return actions_model.StatusUnknown, false, err
+		}
+		return approval.State.JobStatus(), false, nil
+	}
+
+	workflowJob, err := job.ParseJob()
+	if err != nil {
+		return actions_model.StatusUnknown, false, err
+	}
+	gate := workflowJob.Approval()
+	if gate == nil {
+		return actions_model.StatusUnknown, false, fmt.Errorf("job %d is not an approval gate", job.ID)
+	}
+
+	if err := job.LoadAttributes(ctx); err != nil {
+		return actions_model.StatusUnknown, false, err
+	}
+	if gate.UntrustedOnly {
+		if err := job.Run.LoadAttributes(ctx); err != nil {
+			return actions_model.StatusUnknown, false, err
+		}
+		needApproval, err := ifNeedApproval(ctx, job.Run, job.Run.Repo, job.Run.TriggerUser)
+		if err != nil {
+			return actions_model.StatusUnknown, false, err
+		}
+		if !needApproval {
+			return actions_model.StatusSuccess, false, nil
+		}
+	}
+
+	now := timeutil.TimeStampNow()
+	job.Attempt++
+	job.Started = now
+	if _, err := actions_model.UpdateRunJob(ctx, job, nil, "attempt", "started"); err != nil {
+		return actions_model.StatusUnknown, false, err
+	}
+	approval := &actions_model.ActionApproval{
+		RepoID:   job.RepoID,
+		RunID:    job.RunID,
+		RunJobID: job.ID,
+		Attempt:  job.Attempt,
+		Message:  gate.Message,
+		State:    actions_model.ApprovalStateWaiting,
+		Deadline: now.AddDuration(approvalGateTimeout(job)),
+	}
+	if err := db.Insert(ctx, approval); err != nil {
+		return actions_model.StatusUnknown, false, err
+	}
+	return actions_model.StatusBlocked, true, nil
+}
+
+// GetPendingApprovals returns the approval gates of a run which are waiting for a review
+func GetPendingApprovals(ctx context.Context, run *actions_model.ActionRun) ([]*actions_model.ActionApproval, error) {
+	approvals, err := db.Find[actions_model.ActionApproval](ctx, actions_model.FindApprovalsOptions{
+		RepoID: run.RepoID,
+		RunID:  run.ID,
+		State:  optional.Some(actions_model.ApprovalStateWaiting),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the gates of the jobs which have been cancelled are not pending anymore
+	pending := make([]*actions_model.ActionApproval, 0, len(approvals))
+	for _, approval := range approvals {
+		if err := approval.LoadAttributes(ctx); err != nil {
+			return nil, err
+		}
+		if approval.Status() == actions_model.StatusBlocked && approval.Attempt == approval.RunJob.Attempt {
+			pending = append(pending, approval)
+		}
+	}
+	return pending, nil
+}
+
+// ReviewApprovalGate approves or rejects the pending approval gate of a job,
+// the permission of the doer to write actions of the repository should be checked by the caller.
+// The token of an Actions task can't review a gate, otherwise a workflow could approve its own jobs.
+func ReviewApprovalGate(ctx context.Context, job *actions_model.ActionRunJob, doer *user_model.User, approve bool, comment string) (*actions_model.ActionApproval, error) {
+	if doer.IsGiteaActions() {
+		return nil, util.NewPermissionDeniedErrorf("an Actions task can't review approval gates")
+	}
+	if !job.IsApprovalGate {
+		return nil, util.NewInvalidArgumentErrorf("job %d is not an approval gate", job.ID)
+	}
+	if job.Status != actions_model.StatusBlocked || job.Started == 0 {
+		return nil, util.NewNotExistErrorf("job %d is not waiting for a review", job.ID)
+	}
+	approval, err := actions_model.GetApprovalByJobAttempt(ctx, job.ID, job.Attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	approval.State = util.Iif(approve, actions_model.ApprovalStateApproved, actions_model.ApprovalStateRejected)
+	approval.ReviewerID = doer.ID
+	approval.Reviewer = doer
+	approval.ReviewComment = comment
+	approval.Reviewed = timeutil.TimeStampNow()
+	if updated, err := actions_model.UpdateApprovalIfWaiting(ctx, approval, "state", "reviewer_id", "review_comment", "reviewed"); err != nil {
+		return nil, err
+	} else if !updated {
+		return nil, util.NewNotExistErrorf("job %d is not waiting for a review", job.ID)
+	}
+	approval.RunJob = job
+
+	return approval, EmitJobsIfReadyByRun(job.RunID)
+}
+
+// ExpireApprovalGates times out the approval gates which haven't been reviewed before their deadlines
+func ExpireApprovalGates(ctx context.Context) error {
+	approvals, err := db.Find[actions_model.ActionApproval](ctx, actions_model.FindApprovalsOptions{
+		State:          optional.Some(actions_model.ApprovalStateWaiting),
+		DeadlineBefore: timeutil.TimeStampNow(),
+	})
+	if err != nil {
+		return fmt.Errorf("find expired approval gates: %w", err)
+	}
+
+	runIDs := make(container.Set[int64])
+	for _, approval := range approvals {
+		approval.State = actions_model.ApprovalStateTimedOut
+		if updated, err := actions_model.UpdateApprovalIfWaiting(ctx, approval, "state"); err != nil {
+			log.Error("Expire approval gate %d: %v", approval.ID, err)
+			continue
+		} else if updated {
+			runIDs.Add(approval.RunID)
+		}
+	}
+	var errs []error
+	for runID := range runIDs {
+		if err := EmitJobsIfReadyByRun(runID); err != nil {
+			errs = append(errs, fmt.Errorf("check jobs of run %d: %w", runID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
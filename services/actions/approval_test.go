@@ -0,0 +1,173 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestApprovalGateRun(t *testing.T, approval string) (run *actions_model.ActionRun, gate, deploy *actions_model.ActionRunJob) {
+	index, err := db.GetNextResourceIndex(t.Context(), "action_run_index", 4)
+	require.NoError(t, err)
+	run = &actions_model.ActionRun{
+		Title:         "approval gate",
+		Index:         index,
+		RepoID:        4,
+		OwnerID:       1,
+		WorkflowID:    "deploy.yaml",
+		TriggerUserID: 1,
+		Ref:           "refs/heads/master",
+		CommitSHA:     "c2d72f548424103f01ee1dc02889c1e2bff816b0",
+		Event:         webhook_module.HookEventPush,
+		TriggerEvent:  "push",
+		Status:        actions_model.StatusRunning,
+	}
+	require.NoError(t, db.Insert(t.Context(), run))
+
+	newJob := func(jobID string, status actions_model.Status, needs []string, payload string) *actions_model.ActionRunJob {
+		job := &actions_model.ActionRunJob{
+			RunID:           run.ID,
+			RepoID:          run.RepoID,
+			OwnerID:         run.OwnerID,
+			CommitSHA:       run.CommitSHA,
+			Name:            jobID,
+			JobID:           jobID,
+			Needs:           needs,
+			Status:          status,
+			WorkflowPayload: []byte("name: deploy\non: push\njobs:\n  " + jobID + ":\n" + payload),
+		}
+		require.NoError(t, db.Insert(t.Context(), job))
+		return job
+	}
+	newJob("build", actions_model.StatusSuccess, nil, "    runs-on: ubuntu-latest\n    steps:\n      - run: make\n")
+	gate = newJob("gate", actions_model.StatusBlocked, []string{"build"}, approval)
+	gate.IsApprovalGate = true
+	_, err = actions_model.UpdateRunJob(t.Context(), gate, nil, "is_approval_gate")
+	require.NoError(t, err)
+	deploy = newJob("deploy", actions_model.StatusBlocked, []string{"gate"}, "    runs-on: ubuntu-latest\n    steps:\n      - run: make deploy\n")
+	return run, gate, deploy
+}
+
+func checkTestApprovalGateRun(t *testing.T, run *actions_model.ActionRun) (updatedJobs []*actions_model.ActionRunJob) {
+	_, updatedJobs, err := checkJobsOfRun(t.Context(), run)
+	require.NoError(t, err)
+	return updatedJobs
+}
+
+func TestApprovalGate(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	// the jobs are checked by the tests, the job emitter doesn't need to handle them
+	defer test.MockVariableValue(&jobEmitterQueue, queue.CreateUniqueQueue(t.Context(), "test_actions_ready_job", func(items ...*jobUpdate) []*jobUpdate { return nil }))()
+
+	reviewer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	t.Run("Approve", func(t *testing.T) {
+		run, gate, deploy := createTestApprovalGateRun(t, "    approval:\n      message: Deploy to production?\n")
+
+		// the gate is opened after the needed jobs are done
+		updatedJobs := checkTestApprovalGateRun(t, run)
+		require.Len(t, updatedJobs, 1)
+		assert.Equal(t, gate.ID, updatedJobs[0].ID)
+		assert.Equal(t, actions_model.StatusBlocked, updatedJobs[0].Status)
+
+		gate = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: gate.ID})
+		assert.EqualValues(t, 1, gate.Attempt)
+		assert.NotZero(t, gate.Started)
+		approval, err := actions_model.GetApprovalByJobAttempt(t.Context(), gate.ID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.ApprovalStateWaiting, approval.State)
+		assert.Equal(t, "Deploy to production?", approval.Message)
+		assert.Greater(t, approval.Deadline, timeutil.TimeStampNow())
+
+		pending, err := GetPendingApprovals(t.Context(), run)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, approval.ID, pending[0].ID)
+
+		// the gate isn't opened again while it's waiting
+		assert.Empty(t, checkTestApprovalGateRun(t, run))
+
+		// the token of an Actions task can't review the gate
+		_, err = ReviewApprovalGate(t.Context(), gate, user_model.NewActionsUser(), true, "")
+		assert.ErrorIs(t, err, util.ErrPermissionDenied)
+
+		approval, err = ReviewApprovalGate(t.Context(), gate, reviewer, true, "LGTM")
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.ApprovalStateApproved, approval.State)
+		_, err = ReviewApprovalGate(t.Context(), gate, reviewer, false, "")
+		assert.ErrorIs(t, err, util.ErrNotExist)
+
+		checkTestApprovalGateRun(t, run)
+		gate = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: gate.ID})
+		assert.Equal(t, actions_model.StatusSuccess, gate.Status)
+		assert.NotZero(t, gate.Stopped)
+		deploy = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: deploy.ID})
+		assert.Equal(t, actions_model.StatusWaiting, deploy.Status)
+
+		approval = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionApproval{ID: approval.ID})
+		assert.Equal(t, reviewer.ID, approval.ReviewerID)
+		assert.Equal(t, "LGTM", approval.ReviewComment)
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		run, gate, deploy := createTestApprovalGateRun(t, "    approval: true\n")
+		checkTestApprovalGateRun(t, run)
+		gate = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: gate.ID})
+
+		_, err := ReviewApprovalGate(t.Context(), gate, reviewer, false, "not now")
+		require.NoError(t, err)
+
+		checkTestApprovalGateRun(t, run)
+		gate = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: gate.ID})
+		assert.Equal(t, actions_model.StatusFailure, gate.Status)
+		deploy = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: deploy.ID})
+		assert.Equal(t, actions_model.StatusSkipped, deploy.Status)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		run, gate, deploy := createTestApprovalGateRun(t, "    timeout-minutes: 10\n    approval: true\n")
+		checkTestApprovalGateRun(t, run)
+		gate = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: gate.ID})
+		approval, err := actions_model.GetApprovalByJobAttempt(t.Context(), gate.ID, gate.Attempt)
+		require.NoError(t, err)
+		assert.Equal(t, gate.Started.AddDuration(10*time.Minute), approval.Deadline)
+
+		// expire the gate
+		approval.Deadline = timeutil.TimeStampNow().AddDuration(-time.Minute)
+		_, err = db.GetEngine(t.Context()).ID(approval.ID).Cols("deadline").Update(approval)
+		require.NoError(t, err)
+		require.NoError(t, ExpireApprovalGates(t.Context()))
+
+		checkTestApprovalGateRun(t, run)
+		gate = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: gate.ID})
+		assert.Equal(t, actions_model.StatusCancelled, gate.Status)
+		deploy = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: deploy.ID})
+		assert.Equal(t, actions_model.StatusSkipped, deploy.Status)
+		approval = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionApproval{ID: approval.ID})
+		assert.Equal(t, actions_model.ApprovalStateTimedOut, approval.State)
+	})
+
+	t.Run("UntrustedOnly", func(t *testing.T) {
+		// the run isn't triggered by a fork pull request, so the gate isn't required
+		run, gate, _ := createTestApprovalGateRun(t, "    approval:\n      untrusted-only: true\n")
+		checkTestApprovalGateRun(t, run)
+		gate = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: gate.ID})
+		assert.Equal(t, actions_model.StatusSuccess, gate.Status)
+		unittest.AssertNotExistsBean(t, &actions_model.ActionApproval{RunJobID: gate.ID})
+	})
+}
@@ -0,0 +1,483 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// CacheKeyMaxLength is the max length of a cache key, the same as GitHub's
+const CacheKeyMaxLength = 512
+
+// incompleteCacheTimeout is how long a reserved cache could be uploaded before it is removed
+const incompleteCacheTimeout = 24 * time.Hour
+
+// maxCacheParts is the max number of the chunks or blocks uploaded for a cache
+var maxCacheParts = 10000
+
+// CacheRoutePrefix is the route prefix of the v1 cache API, relative to the app URL
+const CacheRoutePrefix = "/api/actions_cache"
+
+// CacheURL returns the url of the v1 cache API used by actions/cache as ACTIONS_CACHE_URL
+func CacheURL() string {
+	return strings.TrimSuffix(setting.AppURL, "/") + CacheRoutePrefix + "/"
+}
+
+func makeCacheStoragePath(cache *actions_model.ActionCache) string {
+	return fmt.Sprintf("%d/%d", cache.RepoID, cache.ID)
+}
+
+func makeCacheTmpPath(cacheID int64) string {
+	return fmt.Sprintf("tmp-upload/%d", cacheID)
+}
+
+// GetCacheScopesOfRun returns the refs whose caches could be restored by the run in order, like GitHub:
+// the ref of the run, the base branch of the pull request and the default branch of the repository.
+// Only the caches of the first one could be created by the run.
+func GetCacheScopesOfRun(ctx context.Context, run *actions_model.ActionRun) ([]string, error) {
+	if err := run.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	scopes := []string{run.Ref}
+	if pullPayload, err := run.GetPullRequestEventPayload(); err == nil && pullPayload.PullRequest != nil && pullPayload.PullRequest.Base != nil {
+		scopes = append(scopes, git.RefNameFromBranch(pullPayload.PullRequest.Base.Ref).String())
+	}
+	scopes = append(scopes, git.RefNameFromBranch(run.Repo.DefaultBranch).String())
+	return slices.Compact(scopes), nil
+}
+
+// ReserveCache reserves a cache of the run's ref to be uploaded, size is the size of the cache declared by the client, 0 if it is unknown
+func ReserveCache(ctx context.Context, run *actions_model.ActionRun, key, version string, size int64) (*actions_model.ActionCache, error) {
+	if key == "" || len(key) > CacheKeyMaxLength || strings.Contains(key, ",") {
+		return nil, util.NewInvalidArgumentErrorf("invalid cache key %q", key)
+	}
+	if version == "" || len(version) > 255 {
+		return nil, util.NewInvalidArgumentErrorf("invalid cache version %q", version)
+	}
+	if size < 0 || setting.Actions.CacheRepoSizeLimit >= 0 && size > setting.Actions.CacheRepoSizeLimit {
+		return nil, util.NewInvalidArgumentErrorf("cache size %d exceeds the limit %d", size, setting.Actions.CacheRepoSizeLimit)
+	}
+
+	// the reservations of the same cache are serialized, so only one of the concurrent jobs could reserve it.
+	// A unique index isn't used since the key, the ref and the version exceed the max index length of MySQL.
+	releaser, err := globallock.Lock(ctx, fmt.Sprintf("actions_cache_%d_%s_%s_%s", run.RepoID, run.Ref, key, version))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		RepoID:   run.RepoID,
+		Refs:     []string{run.Ref},
+		CacheKey: key,
+		Version:  version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, cache := range caches {
+		// an incomplete cache is being uploaded by another job unless it has timed out
+		if cache.Complete || cache.Created.AddDuration(incompleteCacheTimeout) > timeutil.TimeStampNow() {
+			return nil, util.NewAlreadyExistErrorf("cache %q of ref %q already exists", key, run.Ref)
+		}
+		if err := DeleteCache(ctx, cache); err != nil {
+			return nil, err
+		}
+	}
+
+	cache := &actions_model.ActionCache{
+		RepoID:       run.RepoID,
+		Ref:          run.Ref,
+		CacheKey:     key,
+		Version:      version,
+		RunID:        run.ID,
+		ReservedSize: size,
+	}
+	if err := db.Insert(ctx, cache); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// GetReservedCache returns the cache reserved by the run's ref which hasn't been committed
+func GetReservedCache(ctx context.Context, run *actions_model.ActionRun, key, version string) (*actions_model.ActionCache, error) {
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		RepoID:   run.RepoID,
+		Refs:     []string{run.Ref},
+		CacheKey: key,
+		Version:  version,
+		Complete: optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(caches) == 0 {
+		return nil, util.NewNotExistErrorf("no reserved cache %q of ref %q", key, run.Ref)
+	}
+	return caches[0], nil
+}
+
+// cacheSizeLimit returns the max size of a cache, which is the size declared when it was reserved,
+// or the size limit of the caches of a repository if the size is unknown. -1 means no limit.
+func cacheSizeLimit(cache *actions_model.ActionCache) int64 {
+	if cache.ReservedSize > 0 {
+		return cache.ReservedSize
+	}
+	return setting.Actions.CacheRepoSizeLimit
+}
+
+// saveCachePart saves a chunk or a block of a cache, the uploaded parts can't exceed the size limit of the cache,
+// and the number of the parts is limited. end is the end offset of a chunk, or 0 for a block whose offset is unknown.
+// size is -1 if the size of the part is unknown.
+func saveCachePart(cache *actions_model.ActionCache, partPath string, end int64, r io.Reader, size int64) error {
+	if cache.Complete {
+		return util.NewInvalidArgumentErrorf("cache %d has been committed", cache.ID)
+	}
+	limit := cacheSizeLimit(cache)
+	if limit >= 0 && end > limit {
+		return util.NewInvalidArgumentErrorf("cache %d exceeds its size limit %d", cache.ID, limit)
+	}
+
+	var parts int
+	var uploaded int64
+	if err := storage.ActionsCaches.IterateObjects(makeCacheTmpPath(cache.ID), func(fullPath string, obj storage.Object) error {
+		defer obj.Close()
+		if fullPath == partPath || path.Base(fullPath) == "blocklist" {
+			return nil // the part which is uploaded again will be replaced
+		}
+		fi, err := obj.Stat()
+		if err != nil {
+			return err
+		}
+		parts++
+		uploaded += fi.Size()
+		return nil
+	}); err != nil {
+		return err
+	}
+	if parts >= maxCacheParts {
+		return util.NewInvalidArgumentErrorf("cache %d can't be uploaded in more than %d parts", cache.ID, maxCacheParts)
+	}
+
+	switch {
+	case limit >= 0 && size > limit-uploaded:
+		return util.NewInvalidArgumentErrorf("cache %d exceeds its size limit %d", cache.ID, limit)
+	case size >= 0:
+		r = io.LimitReader(r, size)
+	case limit >= 0:
+		r = io.LimitReader(r, limit-uploaded+1)
+	}
+	written, err := storage.ActionsCaches.Save(partPath, r, size)
+	if err != nil {
+		return err
+	}
+	if limit >= 0 && uploaded+written > limit {
+		_ = storage.ActionsCaches.Delete(partPath)
+		return util.NewInvalidArgumentErrorf("cache %d exceeds its size limit %d", cache.ID, limit)
+	}
+	return nil
+}
+
+// UploadCacheChunk saves a chunk of a cache uploaded by the v1 protocol, the chunks are identified by their offsets
+func UploadCacheChunk(cache *actions_model.ActionCache, start int64, r io.Reader, size int64) error {
+	if start < 0 {
+		return util.NewInvalidArgumentErrorf("invalid chunk offset %d", start)
+	}
+	return saveCachePart(cache, fmt.Sprintf("%s/chunk-%d", makeCacheTmpPath(cache.ID), start), start+max(size, 0), r, size)
+}
+
+// UploadCacheBlock saves a block of a cache uploaded by the v2 protocol, the blocks are ordered by the block list
+func UploadCacheBlock(cache *actions_model.ActionCache, blockID string, r io.Reader, size int64) error {
+	return saveCachePart(cache, fmt.Sprintf("%s/block-%s", makeCacheTmpPath(cache.ID), base64.RawURLEncoding.EncodeToString([]byte(blockID))), 0, r, size)
+}
+
+// UploadCacheBlockList saves the block list of a cache uploaded by the v2 protocol
+func UploadCacheBlockList(cache *actions_model.ActionCache, r io.Reader) error {
+	if cache.Complete {
+		return util.NewInvalidArgumentErrorf("cache %d has been committed", cache.ID)
+	}
+	_, err := storage.ActionsCaches.Save(makeCacheTmpPath(cache.ID)+"/blocklist", r, -1)
+	return err
+}
+
+type cacheBlockList struct {
+	Latest []string `xml:"Latest"`
+}
+
+// listCacheParts returns the uploaded parts of a cache in order
+func listCacheParts(cache *actions_model.ActionCache) ([]string, error) {
+	tmpPath := makeCacheTmpPath(cache.ID)
+	if obj, err := storage.ActionsCaches.Open(tmpPath + "/blocklist"); err == nil {
+		blockList := &cacheBlockList{}
+		err = xml.NewDecoder(obj).Decode(blockList)
+		_ = obj.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode block list: %w", err)
+		}
+		if len(blockList.Latest) > maxCacheParts {
+			return nil, util.NewInvalidArgumentErrorf("cache %d can't have more than %d blocks", cache.ID, maxCacheParts)
+		}
+		parts := make([]string, 0, len(blockList.Latest))
+		for _, blockID := range blockList.Latest {
+			parts = append(parts, fmt.Sprintf("%s/block-%s", tmpPath, base64.RawURLEncoding.EncodeToString([]byte(blockID))))
+		}
+		return parts, nil
+	}
+
+	type chunk struct {
+		path        string
+		start, size int64
+	}
+	var chunks []chunk
+	if err := storage.ActionsCaches.IterateObjects(tmpPath, func(fullPath string, obj storage.Object) error {
+		defer obj.Close()
+		var start int64
+		if _, err := fmt.Sscanf(path.Base(fullPath), "chunk-%d", &start); err != nil {
+			return nil //nolint:nilerr // skip the files which are not chunks
+		}
+		fi, err := obj.Stat()
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, chunk{path: fullPath, start: start, size: fi.Size()})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(chunks, func(a, b chunk) int { return cmp.Compare(a.start, b.start) })
+
+	parts := make([]string, 0, len(chunks))
+	var offset int64
+	for _, c := range chunks {
+		if c.start != offset {
+			return nil, util.NewInvalidArgumentErrorf("cache %d has a gap or an overlap at offset %d", cache.ID, offset)
+		}
+		offset += c.size
+		parts = append(parts, c.path)
+	}
+	return parts, nil
+}
+
+func removeCacheTmpFiles(cacheID int64) {
+	if err := storage.ActionsCaches.IterateObjects(makeCacheTmpPath(cacheID), func(fullPath string, obj storage.Object) error {
+		_ = obj.Close()
+		return storage.ActionsCaches.Delete(fullPath)
+	}); err != nil {
+		log.Warn("Failed to remove the uploaded parts of cache %d: %v", cacheID, err)
+	}
+}
+
+// CommitCache merges the uploaded parts of a cache and makes it restorable,
+// the least recently used caches of the repository are evicted if its caches exceed the size limit.
+func CommitCache(ctx context.Context, cache *actions_model.ActionCache, size int64) error {
+	if cache.Complete {
+		return util.NewInvalidArgumentErrorf("cache %d has been committed", cache.ID)
+	}
+	limit := cacheSizeLimit(cache)
+	if limit >= 0 && size > limit {
+		return util.NewInvalidArgumentErrorf("cache size %d exceeds the limit %d", size, limit)
+	}
+
+	parts, err := listCacheParts(cache)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 0 {
+		return util.NewInvalidArgumentErrorf("cache %d has no uploaded content", cache.ID)
+	}
+
+	readers := make([]io.Reader, 0, len(parts))
+	for _, p := range parts {
+		obj, err := storage.ActionsCaches.Open(p)
+		if err != nil {
+			return fmt.Errorf("open part %s: %w", p, err)
+		}
+		defer obj.Close()
+		readers = append(readers, obj)
+	}
+
+	storagePath := makeCacheStoragePath(cache)
+	written, err := storage.ActionsCaches.Save(storagePath, io.MultiReader(readers...), -1)
+	if err != nil {
+		return fmt.Errorf("save cache %d: %w", cache.ID, err)
+	}
+	if size > 0 && written != size {
+		_ = storage.ActionsCaches.Delete(storagePath)
+		return util.NewInvalidArgumentErrorf("cache size mismatch, expected %d, got %d", size, written)
+	}
+	if limit >= 0 && written > limit {
+		_ = storage.ActionsCaches.Delete(storagePath)
+		return util.NewInvalidArgumentErrorf("cache size %d exceeds the limit %d", written, limit)
+	}
+	removeCacheTmpFiles(cache.ID)
+
+	cache.StoragePath = storagePath
+	cache.Size = written
+	cache.Complete = true
+	cache.LastUsed = timeutil.TimeStampNow()
+	if err := actions_model.UpdateCache(ctx, cache, "storage_path", "size", "complete", "last_used"); err != nil {
+		return err
+	}
+
+	return evictCachesOfRepo(ctx, cache.RepoID, setting.Actions.CacheRepoSizeLimit)
+}
+
+// FindCacheToRestore finds the cache which could be restored by the run with the keys, like GitHub:
+// the scopes are searched in order, and in each scope the first key is matched exactly before the keys are matched as prefixes,
+// the newest cache is used if there are multiple matched caches.
+func FindCacheToRestore(ctx context.Context, run *actions_model.ActionRun, keys []string, version string) (*actions_model.ActionCache, error) {
+	if len(keys) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("no cache key")
+	}
+	scopes, err := GetCacheScopesOfRun(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range scopes {
+		caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+			RepoID:   run.RepoID,
+			Refs:     []string{scope},
+			Version:  version,
+			Complete: optional.Some(true),
+		})
+		if err != nil {
+			return nil, err
+		}
+		var matched *actions_model.ActionCache
+		if idx := slices.IndexFunc(caches, func(c *actions_model.ActionCache) bool { return c.CacheKey == keys[0] }); idx >= 0 {
+			matched = caches[idx]
+		}
+		for i := 0; matched == nil && i < len(keys); i++ {
+			if idx := slices.IndexFunc(caches, func(c *actions_model.ActionCache) bool { return strings.HasPrefix(c.CacheKey, keys[i]) }); idx >= 0 {
+				matched = caches[idx]
+			}
+		}
+		if matched != nil {
+			if err := actions_model.UpdateCacheLastUsed(ctx, matched); err != nil {
+				return nil, err
+			}
+			return matched, nil
+		}
+	}
+	return nil, util.NewNotExistErrorf("no cache matches the keys")
+}
+
+// OpenCache opens the content of a committed cache
+func OpenCache(cache *actions_model.ActionCache) (storage.Object, error) {
+	if !cache.Complete {
+		return nil, util.NewNotExistErrorf("cache %d has not been committed", cache.ID)
+	}
+	return storage.ActionsCaches.Open(cache.StoragePath)
+}
+
+// DeleteCache deletes a cache with its content
+func DeleteCache(ctx context.Context, cache *actions_model.ActionCache) error {
+	if cache.StoragePath != "" {
+		if err := storage.ActionsCaches.Delete(cache.StoragePath); err != nil {
+			log.Warn("Failed to delete the content of cache %d: %v", cache.ID, err)
+		}
+	}
+	removeCacheTmpFiles(cache.ID)
+	return actions_model.DeleteCacheByID(ctx, cache.ID)
+}
+
+// evictCachesOfRepo deletes the least recently used caches of a repository until the total size doesn't exceed the limit
+func evictCachesOfRepo(ctx context.Context, repoID, limit int64) error {
+	if limit < 0 {
+		return nil
+	}
+	total, err := actions_model.GetCacheSizeOfRepo(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if total <= limit {
+		return nil
+	}
+
+	caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		RepoID:   repoID,
+		Complete: optional.Some(true),
+		OrderBy:  "last_used ASC, `id` ASC",
+	})
+	if err != nil {
+		return err
+	}
+	for _, cache := range caches {
+		if total <= limit {
+			break
+		}
+		if err := DeleteCache(ctx, cache); err != nil {
+			return err
+		}
+		total -= cache.Size
+		log.Debug("Cache %d of repo %d is evicted since the caches exceed the size limit", cache.ID, repoID)
+	}
+	return nil
+}
+
+// CleanupCaches removes the caches which haven't been used for the retention days and the incomplete caches which have timed out,
+// and evicts the least recently used caches of the repositories whose caches exceed the size limit.
+func CleanupCaches(ctx context.Context) error {
+	expired, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		Complete:       optional.Some(true),
+		LastUsedBefore: timeutil.TimeStampNow().AddDuration(-time.Duration(setting.Actions.CacheRetentionDays) * 24 * time.Hour),
+	})
+	if err != nil {
+		return err
+	}
+	incomplete, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+		Complete:      optional.Some(false),
+		CreatedBefore: timeutil.TimeStampNow().AddDuration(-incompleteCacheTimeout),
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Found %d expired caches and %d incomplete caches", len(expired), len(incomplete))
+	for _, cache := range slices.Concat(expired, incomplete) {
+		if err := DeleteCache(ctx, cache); err != nil {
+			log.Error("Cannot delete cache %d: %v", cache.ID, err)
+		}
+	}
+
+	if setting.Actions.CacheRepoSizeLimit < 0 {
+		return nil
+	}
+	usages, err := actions_model.GetCacheUsagesExceedingSize(ctx, setting.Actions.CacheRepoSizeLimit)
+	if err != nil {
+		return err
+	}
+	for _, usage := range usages {
+		if err := evictCachesOfRepo(ctx, usage.RepoID, setting.Actions.CacheRepoSizeLimit); err != nil {
+			log.Error("Cannot evict caches of repo %d: %v", usage.RepoID, err)
+		}
+	}
+	return nil
+}
+
+// IsCacheErrorClient returns whether the error is caused by the client
+func IsCacheErrorClient(err error) bool {
+	return errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrAlreadyExist)
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestCache(t *testing.T, run *actions_model.ActionRun, key, content string) *actions_model.ActionCache {
+	cache, err := ReserveCache(t.Context(), run, key, "v1", int64(len(content)))
+	require.NoError(t, err)
+	// upload the content in two chunks
+	half := len(content) / 2
+	require.NoError(t, UploadCacheChunk(cache, int64(half), strings.NewReader(content[half:]), int64(len(content)-half)))
+	require.NoError(t, UploadCacheChunk(cache, 0, strings.NewReader(content[:half]), int64(half)))
+	require.NoError(t, CommitCache(t.Context(), cache, int64(len(content))))
+	return cache
+}
+
+func readTestCache(t *testing.T, cache *actions_model.ActionCache) string {
+	obj, err := OpenCache(cache)
+	require.NoError(t, err)
+	defer obj.Close()
+	content, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestCache(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	masterRun := &actions_model.ActionRun{ID: 1, RepoID: 4, Ref: "refs/heads/master", Event: webhook_module.HookEventPush}
+	developRun := &actions_model.ActionRun{ID: 2, RepoID: 4, Ref: "refs/heads/develop", Event: webhook_module.HookEventPush}
+	featureRun := &actions_model.ActionRun{ID: 3, RepoID: 4, Ref: "refs/heads/feature", Event: webhook_module.HookEventPush}
+	pullRun := &actions_model.ActionRun{
+		ID: 4, RepoID: 4, Ref: "refs/pull/1/head", Event: webhook_module.HookEventPullRequest,
+		EventPayload: `{"pull_request":{"base":{"ref":"develop"}}}`,
+	}
+
+	masterCache := createTestCache(t, masterRun, "deps-master", "master content")
+	developCache := createTestCache(t, developRun, "deps-develop", "develop content")
+	assert.Equal(t, "master content", readTestCache(t, masterCache))
+	assert.EqualValues(t, len("master content"), masterCache.Size)
+
+	t.Run("Scopes", func(t *testing.T) {
+		scopes, err := GetCacheScopesOfRun(t.Context(), pullRun)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"refs/pull/1/head", "refs/heads/develop", "refs/heads/master"}, scopes)
+
+		scopes, err = GetCacheScopesOfRun(t.Context(), masterRun)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"refs/heads/master"}, scopes)
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		// the pull request restores the cache of the base branch before the default branch
+		cache, err := FindCacheToRestore(t.Context(), pullRun, []string{"deps-x", "deps-"}, "v1")
+		require.NoError(t, err)
+		assert.Equal(t, developCache.ID, cache.ID)
+
+		// the exact match of the primary key is preferred in the same scope
+		cache, err = FindCacheToRestore(t.Context(), pullRun, []string{"deps-master"}, "v1")
+		require.NoError(t, err)
+		assert.Equal(t, masterCache.ID, cache.ID)
+
+		// a branch restores the caches of the default branch
+		cache, err = FindCacheToRestore(t.Context(), featureRun, []string{"deps-"}, "v1")
+		require.NoError(t, err)
+		assert.Equal(t, masterCache.ID, cache.ID)
+
+		// the default branch can't restore the caches of other branches
+		_, err = FindCacheToRestore(t.Context(), masterRun, []string{"deps-develop"}, "v1")
+		assert.ErrorIs(t, err, util.ErrNotExist)
+
+		// the version must match
+		_, err = FindCacheToRestore(t.Context(), masterRun, []string{"deps-master"}, "v2")
+		assert.ErrorIs(t, err, util.ErrNotExist)
+	})
+
+	t.Run("Reserve", func(t *testing.T) {
+		_, err := ReserveCache(t.Context(), masterRun, "deps-master", "v1", 0)
+		assert.ErrorIs(t, err, util.ErrAlreadyExist)
+
+		_, err = ReserveCache(t.Context(), masterRun, "a,b", "v1", 0)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+		// the incomplete caches can't be restored
+		cache, err := ReserveCache(t.Context(), featureRun, "incomplete", "v1", 0)
+		require.NoError(t, err)
+		_, err = FindCacheToRestore(t.Context(), featureRun, []string{"incomplete"}, "v1")
+		assert.ErrorIs(t, err, util.ErrNotExist)
+		reserved, err := GetReservedCache(t.Context(), featureRun, "incomplete", "v1")
+		require.NoError(t, err)
+		assert.Equal(t, cache.ID, reserved.ID)
+
+		// only one of the concurrent reservations of the same cache succeeds
+		var wg sync.WaitGroup
+		errs := make([]error, 5)
+		for i := range errs {
+			wg.Go(func() {
+				_, errs[i] = ReserveCache(t.Context(), featureRun, "concurrent", "v1", 0)
+			})
+		}
+		wg.Wait()
+		reservedCount := 0
+		for _, err := range errs {
+			if err == nil {
+				reservedCount++
+			} else {
+				assert.ErrorIs(t, err, util.ErrAlreadyExist)
+			}
+		}
+		assert.Equal(t, 1, reservedCount)
+	})
+
+	t.Run("UploadLimits", func(t *testing.T) {
+		// the chunks can't go past the reserved size
+		cache, err := ReserveCache(t.Context(), featureRun, "chunks", "v1", 10)
+		require.NoError(t, err)
+		err = UploadCacheChunk(cache, 5, strings.NewReader("world!"), 6)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		require.NoError(t, UploadCacheChunk(cache, 0, strings.NewReader("hello"), 5))
+		require.NoError(t, UploadCacheChunk(cache, 5, strings.NewReader("world"), 5))
+		// a chunk uploaded again replaces the old one
+		require.NoError(t, UploadCacheChunk(cache, 0, strings.NewReader("HELLO"), 5))
+		require.NoError(t, CommitCache(t.Context(), cache, 10))
+		assert.Equal(t, "HELLOworld", readTestCache(t, cache))
+
+		// the blocks of unknown size are limited by the size limit of the caches of a repository
+		defer test.MockVariableValue(&setting.Actions.CacheRepoSizeLimit, int64(8))()
+		defer test.MockVariableValue(&maxCacheParts, 2)()
+		cache, err = ReserveCache(t.Context(), featureRun, "blocks", "v1", 0)
+		require.NoError(t, err)
+		require.NoError(t, UploadCacheBlock(cache, "a", strings.NewReader("1234"), 4))
+		require.NoError(t, UploadCacheBlock(cache, "b", strings.NewReader("5678"), -1))
+		err = UploadCacheBlock(cache, "c", strings.NewReader("9"), 1)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		err = UploadCacheBlock(cache, "a", strings.NewReader("12345"), -1)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		require.NoError(t, DeleteCache(t.Context(), cache))
+	})
+
+	t.Run("Evict", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Actions.CacheRepoSizeLimit, int64(30))()
+
+		// mark the cache of master as the most recently used one
+		masterCache.LastUsed = timeutil.TimeStampNow().AddDuration(time.Minute)
+		require.NoError(t, actions_model.UpdateCache(t.Context(), masterCache, "last_used"))
+
+		// the least recently used cache is evicted when the caches exceed the limit
+		createTestCache(t, featureRun, "deps-feature", "feature content")
+		_, err := actions_model.GetCacheByID(t.Context(), developCache.ID)
+		assert.ErrorIs(t, err, util.ErrNotExist)
+		_, err = actions_model.GetCacheByID(t.Context(), masterCache.ID)
+		assert.NoError(t, err)
+
+		// a cache larger than the limit is rejected
+		_, err = ReserveCache(t.Context(), featureRun, "too-large", "v1", 100)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+		cache, err := ReserveCache(t.Context(), featureRun, "too-large", "v1", 0)
+		require.NoError(t, err)
+		err = CommitCache(t.Context(), cache, 100)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		masterCache.LastUsed = timeutil.TimeStampNow().AddDuration(-time.Duration(setting.Actions.CacheRetentionDays+1) * 24 * time.Hour)
+		require.NoError(t, actions_model.UpdateCache(t.Context(), masterCache, "last_used"))
+
+		require.NoError(t, CleanupCaches(t.Context()))
+		_, err := actions_model.GetCacheByID(t.Context(), masterCache.ID)
+		assert.ErrorIs(t, err, util.ErrNotExist)
+		_, err = OpenCache(masterCache)
+		assert.Error(t, err)
+	})
+}
@@ -21,7 +21,7 @@ import (
 	"xorm.io/builder"
 )
 
-// Cleanup removes expired actions logs, data, artifacts and used ephemeral runners
+// Cleanup removes expired actions logs, data, artifacts, used ephemeral runners and their stale registration tokens
 func Cleanup(ctx context.Context) error {
 	// clean up expired artifacts
 	if err := CleanupArtifacts(ctx); err != nil {
@@ -38,6 +38,11 @@ func Cleanup(ctx context.Context) error {
 		return fmt.Errorf("cleanup old ephemeral runners: %w", err)
 	}
 
+	// clean up used and expired ephemeral runner tokens
+	if err := CleanupEphemeralRunnerTokens(ctx); err != nil {
+		return fmt.Errorf("cleanup ephemeral runner tokens: %w", err)
+	}
+
 	return nil
 }
 
@@ -105,6 +110,8 @@ func removeTaskLog(ctx context.Context, task *actions_model.ActionTask) {
 		log.Error("Failed to remove log %s (in storage %v) of task %v: %v", task.LogFilename, task.LogInStorage, task.ID, err)
 		// do not return error here, go on
 	}
+	// the summaries are stored alongside the logs, so they are removed together
+	removeTaskSummaries(ctx, task.ID)
 }
 
 // CleanupExpiredLogs removes logs which are older than the configured retention time
@@ -155,6 +162,16 @@ func CleanupEphemeralRunners(ctx context.Context) error {
 	return nil
 }
 
+// CleanupEphemeralRunnerTokens removes the ephemeral runner tokens which have been used or have expired
+func CleanupEphemeralRunnerTokens(ctx context.Context) error {
+	affected, err := actions_model.DeleteStaleEphemeralRunnerTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("delete runner tokens: %w", err)
+	}
+	log.Info("Removed %d ephemeral runner tokens", affected)
+	return nil
+}
+
 // CleanupEphemeralRunnersByPickedTaskOfRepo removes all ephemeral runners that have active/finished tasks on the given repository
 func CleanupEphemeralRunnersByPickedTaskOfRepo(ctx context.Context, repoID int64) error {
 	subQuery := builder.Select("`action_runner`.id").
@@ -220,6 +237,10 @@ func DeleteRun(ctx context.Context, run *actions_model.ActionRun) error {
 			RepoID: repoID,
 			TaskID: tas.ID,
 		})
+		recordsToDelete = append(recordsToDelete, &actions_model.ActionTaskAnnotation{
+			RepoID: repoID,
+			TaskID: tas.ID,
+		})
 		recordsToDelete = append(recordsToDelete, &actions_model.ActionTaskOutput{
 			TaskID: tas.ID,
 		})
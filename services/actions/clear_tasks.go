@@ -18,6 +18,8 @@ import (
 	"code.gitea.io/gitea/modules/util"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 	notify_service "code.gitea.io/gitea/services/notify"
+
+	"xorm.io/builder"
 )
 
 // StopZombieTasks stops the task which have running status, but haven't been updated for a long time
@@ -25,7 +27,7 @@ func StopZombieTasks(ctx context.Context) error {
 	return stopTasks(ctx, actions_model.FindTaskOptions{
 		Status:        actions_model.StatusRunning,
 		UpdatedBefore: timeutil.TimeStamp(time.Now().Add(-setting.Actions.ZombieTaskTimeout).Unix()),
-	})
+	}, actions_model.JobStopReasonNone)
 }
 
 // StopEndlessTasks stops the tasks which have running status and continuous updates, but don't end for a long time
@@ -33,7 +35,15 @@ func StopEndlessTasks(ctx context.Context) error {
 	return stopTasks(ctx, actions_model.FindTaskOptions{
 		Status:        actions_model.StatusRunning,
 		StartedBefore: timeutil.TimeStamp(time.Now().Add(-setting.Actions.EndlessTaskTimeout).Unix()),
-	})
+	}, actions_model.JobStopReasonNone)
+}
+
+// StopTimedOutTasks stops the tasks which have running status, but have exceeded the timeout-minutes of their jobs
+func StopTimedOutTasks(ctx context.Context) error {
+	return stopTasks(ctx, actions_model.FindTaskOptions{
+		Status:         actions_model.StatusRunning,
+		DeadlineBefore: timeutil.TimeStampNow(),
+	}, actions_model.JobStopReasonTimeout)
 }
 
 func notifyWorkflowJobStatusUpdate(ctx context.Context, jobs []*actions_model.ActionRunJob) {
@@ -135,7 +145,7 @@ func PrepareToStartRunWithConcurrency(ctx context.Context, run *actions_model.Ac
 	return util.Iif(shouldBlock, actions_model.StatusBlocked, actions_model.StatusWaiting), nil
 }
 
-func stopTasks(ctx context.Context, opts actions_model.FindTaskOptions) error {
+func stopTasks(ctx context.Context, opts actions_model.FindTaskOptions, reason actions_model.JobStopReason) error {
 	tasks, err := db.Find[actions_model.ActionTask](ctx, opts)
 	if err != nil {
 		return fmt.Errorf("find tasks: %w", err)
@@ -150,6 +160,12 @@ func stopTasks(ctx context.Context, opts actions_model.FindTaskOptions) error {
 			if err := task.LoadJob(ctx); err != nil {
 				return err
 			}
+			if reason != actions_model.JobStopReasonNone {
+				task.Job.StopReason = reason
+				if _, err := actions_model.UpdateRunJob(ctx, task.Job, nil, "stop_reason"); err != nil {
+					return err
+				}
+			}
 			jobs = append(jobs, task.Job)
 			return nil
 		}); err != nil {
@@ -187,6 +203,12 @@ func CancelAbandonedJobs(ctx context.Context) error {
 		return err
 	}
 
+	cancelJobs(ctx, jobs, actions_model.JobStopReasonNone)
+	return nil
+}
+
+// cancelJobs cancels the jobs which haven't been picked by any runner, and records the reason why they are cancelled
+func cancelJobs(ctx context.Context, jobs []*actions_model.ActionRunJob, reason actions_model.JobStopReason) {
 	now := timeutil.TimeStampNow()
 
 	// Collect one job per run to send workflow run status update
@@ -196,9 +218,10 @@ func CancelAbandonedJobs(ctx context.Context) error {
 	for _, job := range jobs {
 		job.Status = actions_model.StatusCancelled
 		job.Stopped = now
+		job.StopReason = reason
 		updated := false
 		if err := db.WithTx(ctx, func(ctx context.Context) error {
-			n, err := actions_model.UpdateRunJob(ctx, job, nil, "status", "stopped")
+			n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"task_id": 0}, "status", "stopped", "stop_reason")
 			if err != nil {
 				return err
 			}
@@ -211,7 +234,7 @@ func CancelAbandonedJobs(ctx context.Context) error {
 			}
 			return nil
 		}); err != nil {
-			log.Warn("cancel abandoned job %v: %v", job.ID, err)
+			log.Warn("cancel job %v: %v", job.ID, err)
 			// go on
 		}
 		if job.Run == nil || job.Run.Repo == nil {
@@ -228,6 +251,4 @@ func CancelAbandonedJobs(ctx context.Context) error {
 		notify_service.WorkflowRunStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job.Run)
 	}
 	EmitJobsIfReadyByJobs(updatedJobs)
-
-	return nil
 }
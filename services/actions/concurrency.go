@@ -90,7 +90,7 @@ func EvaluateJobConcurrencyFillModel(ctx context.Context, run *actions_model.Act
 
 	if inputs == nil {
 		var err error
-		inputs, err = getInputsFromRun(run)
+		inputs, err = getInputsOfJob(ctx, run, actionRunJob)
 		if err != nil {
 			return fmt.Errorf("get inputs: %w", err)
 		}
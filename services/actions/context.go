@@ -114,8 +114,12 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 	}
 
 	jobIDJobs := make(map[string][]*actions_model.ActionRunJob)
-	for _, job := range jobs {
-		jobIDJobs[job.JobID] = append(jobIDJobs[job.JobID], job)
+	for _, j := range jobs {
+		// a job can only need the jobs in the same workflow
+		if j.ParentJobID != job.ParentJobID {
+			continue
+		}
+		jobIDJobs[j.JobID] = append(jobIDJobs[j.JobID], j)
 	}
 
 	ret := make(map[string]*TaskNeed, len(needs))
@@ -123,8 +127,34 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 		if !needs.Contains(jobID) {
 			continue
 		}
-		var jobOutputs map[string]string
-		for _, job := range jobsWithSameID {
+		jobOutputs, err := collectJobOutputs(ctx, jobsWithSameID, jobs)
+		if err != nil {
+			return nil, err
+		}
+		ret[jobID] = &TaskNeed{
+			Outputs: jobOutputs,
+			Result:  actions_model.AggregateJobStatus(jobsWithSameID),
+		}
+	}
+	return ret, nil
+}
+
+// collectJobOutputs collects the outputs of the jobs with the same job id (e.g. the jobs of a matrix),
+// the outputs of a job calling a reusable workflow are evaluated from the jobs of the called workflow.
+func collectJobOutputs(ctx context.Context, jobsWithSameID, allJobs []*actions_model.ActionRunJob) (map[string]string, error) {
+	var jobOutputs map[string]string
+	for _, job := range jobsWithSameID {
+		var outputs map[string]string
+		if job.IsWorkflowCall {
+			if !job.Status.IsDone() {
+				continue
+			}
+			got, err := getWorkflowCallOutputs(ctx, job, allJobs)
+			if err != nil {
+				return nil, fmt.Errorf("getWorkflowCallOutputs: %w", err)
+			}
+			outputs = got
+		} else {
 			if job.TaskID == 0 || !job.Status.IsDone() {
 				// it shouldn't happen, or the job has been rerun
 				continue
@@ -133,22 +163,18 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 			if err != nil {
 				return nil, fmt.Errorf("FindTaskOutputByTaskID: %w", err)
 			}
-			outputs := make(map[string]string, len(got))
+			outputs = make(map[string]string, len(got))
 			for _, v := range got {
 				outputs[v.OutputKey] = v.OutputValue
 			}
-			if len(jobOutputs) == 0 {
-				jobOutputs = outputs
-			} else {
-				jobOutputs = mergeTwoOutputs(outputs, jobOutputs)
-			}
 		}
-		ret[jobID] = &TaskNeed{
-			Outputs: jobOutputs,
-			Result:  actions_model.AggregateJobStatus(jobsWithSameID),
+		if len(jobOutputs) == 0 {
+			jobOutputs = outputs
+		} else {
+			jobOutputs = mergeTwoOutputs(outputs, jobOutputs)
 		}
 	}
-	return ret, nil
+	return jobOutputs, nil
 }
 
 // mergeTwoOutputs merges two outputs from two different ActionRunJobs
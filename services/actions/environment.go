@@ -0,0 +1,218 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	secret_service "code.gitea.io/gitea/services/secrets"
+
+	"xorm.io/builder"
+)
+
+// ValidateEnvironment checks the name, the ref filters and the required reviewers of an environment
+func ValidateEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) error {
+	env.Name = strings.TrimSpace(env.Name)
+	if env.Name == "" || len(env.Name) > actions_model.EnvironmentNameMaxLength || strings.ContainsAny(env.Name, "/\\") {
+		return util.NewInvalidArgumentErrorf("invalid environment name %q", env.Name)
+	}
+	for _, pattern := range slices.Concat(env.BranchFilters, env.TagFilters) {
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid ref filter %q: %v", pattern, err)
+		}
+	}
+	if len(env.RequiredReviewerIDs) > 0 {
+		reviewers, err := user_model.GetUsersByIDs(ctx, env.RequiredReviewerIDs)
+		if err != nil {
+			return err
+		}
+		if len(reviewers) != len(env.RequiredReviewerIDs) {
+			return util.NewInvalidArgumentErrorf("some required reviewers don't exist")
+		}
+	}
+	return nil
+}
+
+// CreateEnvironment creates a deployment environment for a repository
+func CreateEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) error {
+	if err := ValidateEnvironment(ctx, env); err != nil {
+		return err
+	}
+	if _, err := actions_model.GetEnvironmentByRepoAndName(ctx, env.RepoID, env.Name); err == nil {
+		return util.NewAlreadyExistErrorf("environment %q already exists", env.Name)
+	} else if !errors.Is(err, util.ErrNotExist) {
+		return err
+	}
+	return actions_model.InsertEnvironment(ctx, env)
+}
+
+// UpdateEnvironment updates the protection rules of a deployment environment
+func UpdateEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) error {
+	if err := ValidateEnvironment(ctx, env); err != nil {
+		return err
+	}
+	return actions_model.UpdateEnvironment(ctx, env, "branch_filters", "tag_filters", "required_reviewer_ids", "prevent_self_review")
+}
+
+// DeleteEnvironment deletes a deployment environment with its secrets, variables and deployment history
+func DeleteEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := secret_service.DeleteEnvironmentSecrets(ctx, env.RepoID, env.ID); err != nil {
+			return err
+		}
+		return actions_model.DeleteEnvironment(ctx, env)
+	})
+}
+
+// prepareJobDeployment checks the protection rules of the environment which the job deploys to,
+// and returns the status the job should be changed to:
+//   - StatusWaiting if the job could be started
+//   - StatusBlocked if the deployment is waiting for a required reviewer
+//   - StatusFailure if the ref is not allowed to be deployed to the environment, or the deployment has been rejected
+//
+// An environment referenced by a job is created automatically if it doesn't exist, like GitHub does.
+func prepareJobDeployment(ctx context.Context, job *actions_model.ActionRunJob) (actions_model.Status, error) {
+	if err := job.LoadRun(ctx); err != nil {
+		return actions_model.StatusUnknown, err
+	}
+
+	env, err := actions_model.GetEnvironmentByRepoAndName(ctx, job.RepoID, job.Environment)
+	if errors.Is(err, util.ErrNotExist) {
+		env = &actions_model.ActionEnvironment{RepoID: job.RepoID, Name: job.Environment}
+		if err := CreateEnvironment(ctx, env); err != nil {
+			return actions_model.StatusUnknown, fmt.Errorf("create environment %q: %w", job.Environment, err)
+		}
+	} else if err != nil {
+		return actions_model.StatusUnknown, err
+	}
+
+	if !env.IsRefAllowed(job.Run.Ref) {
+		log.Debug("ref %q of run %d is not allowed to be deployed to environment %q", job.Run.Ref, job.RunID, env.Name)
+		return actions_model.StatusFailure, nil
+	}
+
+	// the attempt of a job is increased when a new task is created for it
+	attempt := job.Attempt + 1
+	deployment, err := actions_model.GetDeploymentByJobAttempt(ctx, job.ID, attempt)
+	if errors.Is(err, util.ErrNotExist) {
+		deployment = &actions_model.ActionDeployment{
+			RepoID:        job.RepoID,
+			EnvironmentID: env.ID,
+			RunID:         job.RunID,
+			RunJobID:      job.ID,
+			Attempt:       attempt,
+			Ref:           job.Run.Ref,
+			CommitSHA:     job.CommitSHA,
+			CreatorID:     job.Run.TriggerUserID,
+			ReviewState:   util.Iif(env.NeedReview(), actions_model.DeploymentReviewStateWaiting, actions_model.DeploymentReviewStateNone),
+		}
+		if workflowJob, err := job.ParseJob(); err == nil {
+			if jobEnv := workflowJob.DeploymentEnvironment(); jobEnv != nil {
+				deployment.URL = jobEnv.URL
+			}
+		}
+		if err := db.Insert(ctx, deployment); err != nil {
+			return actions_model.StatusUnknown, err
+		}
+	} else if err != nil {
+		return actions_model.StatusUnknown, err
+	}
+
+	switch deployment.ReviewState {
+	case actions_model.DeploymentReviewStateWaiting:
+		return actions_model.StatusBlocked, nil
+	case actions_model.DeploymentReviewStateRejected:
+		return actions_model.StatusFailure, nil
+	}
+	return actions_model.StatusWaiting, nil
+}
+
+// GetPendingDeployments returns the deployments of a run which are waiting for a required reviewer
+func GetPendingDeployments(ctx context.Context, run *actions_model.ActionRun) ([]*actions_model.ActionDeployment, error) {
+	deployments, err := db.Find[actions_model.ActionDeployment](ctx, actions_model.FindDeploymentsOptions{
+		RepoID:      run.RepoID,
+		RunID:       run.ID,
+		ReviewState: actions_model.DeploymentReviewStateWaiting,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the deployments of the jobs which have been cancelled are not pending anymore
+	pending := make([]*actions_model.ActionDeployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		if err := deployment.LoadAttributes(ctx); err != nil {
+			return nil, err
+		}
+		if deployment.Status() == actions_model.StatusBlocked {
+			pending = append(pending, deployment)
+		}
+	}
+	return pending, nil
+}
+
+// ReviewPendingDeployments approves or rejects the pending deployments of a run to the environments,
+// the doer must be a required reviewer of all the environments.
+func ReviewPendingDeployments(ctx context.Context, repo *repo_model.Repository, run *actions_model.ActionRun, doer *user_model.User, environmentIDs []int64, approve bool, comment string) error {
+	if len(environmentIDs) == 0 {
+		return util.NewInvalidArgumentErrorf("no environment to review")
+	}
+
+	deployments, err := db.Find[actions_model.ActionDeployment](ctx, actions_model.FindDeploymentsOptions{
+		RepoID:         repo.ID,
+		RunID:          run.ID,
+		EnvironmentIDs: environmentIDs,
+		ReviewState:    actions_model.DeploymentReviewStateWaiting,
+	})
+	if err != nil {
+		return err
+	}
+	if len(deployments) == 0 {
+		return util.NewNotExistErrorf("no pending deployment of the run to the environments")
+	}
+
+	for _, id := range environmentIDs {
+		env, err := actions_model.GetEnvironmentByRepoAndID(ctx, repo.ID, id)
+		if err != nil {
+			return err
+		}
+		if !env.IsRequiredReviewer(doer.ID) {
+			return util.NewPermissionDeniedErrorf("user is not a required reviewer of environment %q", env.Name)
+		}
+		if env.PreventSelfReview && run.TriggerUserID == doer.ID {
+			return util.NewPermissionDeniedErrorf("user triggered the run is not allowed to review the deployments to environment %q", env.Name)
+		}
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		for _, deployment := range deployments {
+			deployment.ReviewState = util.Iif(approve, actions_model.DeploymentReviewStateApproved, actions_model.DeploymentReviewStateRejected)
+			deployment.ReviewerID = doer.ID
+			deployment.ReviewComment = comment
+			deployment.Reviewed = timeutil.TimeStampNow()
+			n, err := actions_model.UpdateDeployment(ctx, deployment, builder.Eq{"review_state": actions_model.DeploymentReviewStateWaiting}, "review_state", "reviewer_id", "review_comment", "reviewed")
+			if err != nil {
+				return err
+			} else if n != 1 {
+				return fmt.Errorf("deployment %d has been reviewed", deployment.ID)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return EmitJobsIfReadyByRun(run.ID)
+}
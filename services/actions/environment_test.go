@@ -0,0 +1,184 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	secret_model "code.gitea.io/gitea/models/secret"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+	secret_service "code.gitea.io/gitea/services/secrets"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestDeploymentJob(t *testing.T, environment string) (*actions_model.ActionRun, *actions_model.ActionRunJob) {
+	index, err := db.GetNextResourceIndex(t.Context(), "action_run_index", 4)
+	require.NoError(t, err)
+	run := &actions_model.ActionRun{
+		Title:         "deploy",
+		Index:         index,
+		RepoID:        4,
+		OwnerID:       5,
+		WorkflowID:    "deploy.yaml",
+		TriggerUserID: 1,
+		Ref:           "refs/heads/master",
+		CommitSHA:     "c2d72f548424103f01ee1dc02889c1e2bff816b0",
+		Event:         webhook_module.HookEventPush,
+		TriggerEvent:  "push",
+		Status:        actions_model.StatusRunning,
+	}
+	require.NoError(t, db.Insert(t.Context(), run))
+
+	job := &actions_model.ActionRunJob{
+		RunID:           run.ID,
+		RepoID:          run.RepoID,
+		OwnerID:         run.OwnerID,
+		CommitSHA:       run.CommitSHA,
+		Name:            "deploy",
+		JobID:           "deploy",
+		Environment:     environment,
+		Status:          actions_model.StatusBlocked,
+		WorkflowPayload: []byte("name: deploy\non: push\njobs:\n  deploy:\n    runs-on: ubuntu-latest\n    environment:\n      name: " + environment + "\n      url: https://example.com\n    steps:\n      - run: make deploy\n"),
+	}
+	require.NoError(t, db.Insert(t.Context(), job))
+	return run, job
+}
+
+func TestPrepareJobDeployment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&jobEmitterQueue, queue.CreateUniqueQueue(t.Context(), "test_actions_deployment_job", func(items ...*jobUpdate) []*jobUpdate { return nil }))()
+
+	t.Run("CreateUnknownEnvironment", func(t *testing.T) {
+		_, job := createTestDeploymentJob(t, "staging")
+		status, err := prepareJobDeployment(t.Context(), job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusWaiting, status)
+
+		env, err := actions_model.GetEnvironmentByRepoAndName(t.Context(), job.RepoID, "staging")
+		require.NoError(t, err)
+		assert.False(t, env.NeedReview())
+		deployment, err := actions_model.GetDeploymentByJobAttempt(t.Context(), job.ID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, env.ID, deployment.EnvironmentID)
+		assert.Equal(t, "https://example.com", deployment.URL)
+		assert.Equal(t, actions_model.DeploymentReviewStateNone, deployment.ReviewState)
+	})
+
+	t.Run("RefNotAllowed", func(t *testing.T) {
+		require.NoError(t, CreateEnvironment(t.Context(), &actions_model.ActionEnvironment{RepoID: 4, Name: "release", BranchFilters: []string{"release/*"}}))
+		_, job := createTestDeploymentJob(t, "release")
+		status, err := prepareJobDeployment(t.Context(), job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusFailure, status)
+		unittest.AssertNotExistsBean(t, &actions_model.ActionDeployment{RunJobID: job.ID})
+	})
+
+	t.Run("Review", func(t *testing.T) {
+		env := &actions_model.ActionEnvironment{RepoID: 4, Name: "production", RequiredReviewerIDs: []int64{1, 2}, PreventSelfReview: true}
+		require.NoError(t, CreateEnvironment(t.Context(), env))
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+		triggerUser := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+		reviewer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		other := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+		run, job := createTestDeploymentJob(t, "production")
+		status, err := prepareJobDeployment(t.Context(), job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusBlocked, status)
+
+		// the deployment is reused while it's waiting
+		status, err = prepareJobDeployment(t.Context(), job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusBlocked, status)
+		pending, err := GetPendingDeployments(t.Context(), run)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, env.ID, pending[0].EnvironmentID)
+
+		err = ReviewPendingDeployments(t.Context(), repo, run, other, []int64{env.ID}, true, "")
+		assert.ErrorIs(t, err, util.ErrPermissionDenied)
+		err = ReviewPendingDeployments(t.Context(), repo, run, triggerUser, []int64{env.ID}, true, "")
+		assert.ErrorIs(t, err, util.ErrPermissionDenied)
+		err = ReviewPendingDeployments(t.Context(), repo, run, reviewer, nil, true, "")
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+		require.NoError(t, ReviewPendingDeployments(t.Context(), repo, run, reviewer, []int64{env.ID}, true, "LGTM"))
+		err = ReviewPendingDeployments(t.Context(), repo, run, reviewer, []int64{env.ID}, false, "")
+		assert.ErrorIs(t, err, util.ErrNotExist)
+		deployment := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionDeployment{ID: pending[0].ID})
+		assert.Equal(t, actions_model.DeploymentReviewStateApproved, deployment.ReviewState)
+		assert.Equal(t, reviewer.ID, deployment.ReviewerID)
+		assert.Equal(t, "LGTM", deployment.ReviewComment)
+
+		status, err = prepareJobDeployment(t.Context(), job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusWaiting, status)
+
+		run, job = createTestDeploymentJob(t, "production")
+		_, err = prepareJobDeployment(t.Context(), job)
+		require.NoError(t, err)
+		require.NoError(t, ReviewPendingDeployments(t.Context(), repo, run, reviewer, []int64{env.ID}, false, "not now"))
+		status, err = prepareJobDeployment(t.Context(), job)
+		require.NoError(t, err)
+		assert.Equal(t, actions_model.StatusFailure, status)
+	})
+}
+
+func TestEnvironmentSecretsAndVariables(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	env := &actions_model.ActionEnvironment{RepoID: 4, Name: "scoped"}
+	require.NoError(t, CreateEnvironment(t.Context(), env))
+
+	_, err := CreateVariable(t.Context(), 0, 4, "TARGET", "repo", "")
+	require.NoError(t, err)
+	_, err = CreateEnvironmentVariable(t.Context(), 4, env.ID, "TARGET", "scoped", "")
+	require.NoError(t, err)
+	_, err = CreateEnvironmentVariable(t.Context(), 4, env.ID, "ONLY_ENV", "value", "")
+	require.NoError(t, err)
+	_, _, err = secret_service.CreateOrUpdateSecret(t.Context(), 0, 4, "TOKEN", "repo", "")
+	require.NoError(t, err)
+	_, _, err = secret_service.CreateOrUpdateEnvironmentSecret(t.Context(), 0, 4, env.ID, "TOKEN", "scoped", "")
+	require.NoError(t, err)
+
+	check := func(t *testing.T, environment, expected string, hasEnvValues bool) {
+		_, job := createTestDeploymentJob(t, environment)
+		require.NoError(t, job.LoadAttributes(t.Context()))
+
+		vars, err := actions_model.GetVariablesOfJob(t.Context(), job)
+		require.NoError(t, err)
+		assert.Equal(t, expected, vars["TARGET"])
+		_, ok := vars["ONLY_ENV"]
+		assert.Equal(t, hasEnvValues, ok)
+
+		secrets, err := secret_model.GetSecretsOfTask(t.Context(), &actions_model.ActionTask{Job: job})
+		require.NoError(t, err)
+		assert.Equal(t, expected, secrets["TOKEN"])
+	}
+
+	t.Run("Environment", func(t *testing.T) {
+		check(t, "scoped", "scoped", true)
+	})
+	t.Run("OtherEnvironment", func(t *testing.T) {
+		check(t, "unknown", "repo", false)
+	})
+	t.Run("NoEnvironment", func(t *testing.T) {
+		check(t, "", "repo", false)
+	})
+
+	// the secrets and variables of an environment are deleted with it
+	require.NoError(t, DeleteEnvironment(t.Context(), env))
+	unittest.AssertNotExistsBean(t, &secret_model.Secret{RepoID: 4, EnvironmentID: env.ID})
+	unittest.AssertNotExistsBean(t, &actions_model.ActionVariable{RepoID: 4, EnvironmentID: env.ID})
+}
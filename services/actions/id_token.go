@@ -0,0 +1,172 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/actions/jobparser"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/oauth2_provider"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// IDTokenRoutePrefix is the route prefix of the OIDC issuer of Actions, relative to the app URL
+const IDTokenRoutePrefix = "/api/actions/oidc"
+
+// IDTokenClaims are the claims of an OIDC ID token requested by an Actions job,
+// the custom claims are compatible with GitHub's, see
+// https://docs.github.com/en/actions/security-for-github-actions/security-hardening-your-deployments/about-security-hardening-with-openid-connect#understanding-the-oidc-token
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+
+	Ref                  string `json:"ref"`
+	RefType              string `json:"ref_type"`
+	SHA                  string `json:"sha"`
+	Repository           string `json:"repository"`
+	RepositoryID         string `json:"repository_id"`
+	RepositoryOwner      string `json:"repository_owner"`
+	RepositoryOwnerID    string `json:"repository_owner_id"`
+	RepositoryVisibility string `json:"repository_visibility"`
+	RunID                string `json:"run_id"`
+	RunNumber            string `json:"run_number"`
+	RunAttempt           string `json:"run_attempt"`
+	Actor                string `json:"actor"`
+	ActorID              string `json:"actor_id"`
+	Workflow             string `json:"workflow"`
+	WorkflowRef          string `json:"workflow_ref"`
+	EventName            string `json:"event_name"`
+	HeadRef              string `json:"head_ref"`
+	BaseRef              string `json:"base_ref"`
+	Environment          string `json:"environment,omitempty"`
+}
+
+// IDTokenIssuer returns the issuer of the ID tokens, the OpenID configuration is served at "{issuer}/.well-known/openid-configuration"
+func IDTokenIssuer() string {
+	return strings.TrimSuffix(setting.AppURL, "/") + IDTokenRoutePrefix
+}
+
+// IDTokenSigningKey returns the key to sign the ID tokens, which is the asymmetric signing key of the OAuth2 provider,
+// the relying parties verify the ID tokens by the public keys published by the JWKS endpoint.
+func IDTokenSigningKey() (oauth2_provider.JWTSigningKey, error) {
+	key := oauth2_provider.DefaultSigningKey
+	if key == nil || key.IsSymmetric() {
+		return nil, errors.New("ID tokens require an asymmetric [oauth2] JWT_SIGNING_ALGORITHM")
+	}
+	return key, nil
+}
+
+// CanTaskRequestIDToken returns whether the job of the task is granted the "id-token: write" permission,
+// the jobs triggered by pull requests from forks can never request ID tokens since they run untrusted code.
+func CanTaskRequestIDToken(task *actions_model.ActionTask) (bool, error) {
+	if task.Job == nil {
+		return false, errors.New("the job of the task is not loaded")
+	}
+	if task.Job.IsForkPullRequest {
+		return false, nil
+	}
+	workflows, err := jobparser.Parse(task.Job.WorkflowPayload)
+	if err != nil {
+		return false, fmt.Errorf("parse workflow payload of job %d: %w", task.JobID, err)
+	} else if len(workflows) != 1 {
+		return false, fmt.Errorf("job %d single workflow: not single workflow", task.JobID)
+	}
+	return workflows[0].Permission(jobparser.PermissionScopeIDToken) == jobparser.PermissionLevelWrite, nil
+}
+
+// CreateIDToken creates a signed OIDC ID token for a running task, the audience defaults to the URL of the repository owner like GitHub does.
+func CreateIDToken(ctx context.Context, task *actions_model.ActionTask, audience string) (string, error) {
+	if task.Status != actions_model.StatusRunning {
+		return "", util.NewPermissionDeniedErrorf("task %d is not running", task.ID)
+	}
+	if err := task.LoadAttributes(ctx); err != nil {
+		return "", err
+	}
+	if task.Job.Run.IsForkPullRequest {
+		return "", util.NewPermissionDeniedErrorf("the jobs triggered by pull requests from forks can't request ID tokens")
+	}
+	if ok, err := CanTaskRequestIDToken(task); err != nil {
+		return "", err
+	} else if !ok {
+		return "", util.NewPermissionDeniedErrorf("the job is not granted the %q permission", jobparser.PermissionScopeIDToken+": "+jobparser.PermissionLevelWrite)
+	}
+
+	signingKey, err := IDTokenSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	job := task.Job
+	run := job.Run
+	if err := run.LoadAttributes(ctx); err != nil {
+		return "", err
+	}
+
+	gitCtx := GenerateGiteaContext(run, job)
+	repository := run.Repo.FullName()
+	ref, _ := gitCtx["ref"].(string)
+	refType, _ := gitCtx["ref_type"].(string)
+	sha, _ := gitCtx["sha"].(string)
+	headRef, _ := gitCtx["head_ref"].(string)
+	baseRef, _ := gitCtx["base_ref"].(string)
+	if audience == "" {
+		audience = run.Repo.Owner.HTMLURL(ctx)
+	}
+
+	// the subject is what the relying parties usually match, it is scoped by the environment or the ref
+	var subject string
+	switch {
+	case job.Environment != "":
+		subject = fmt.Sprintf("repo:%s:environment:%s", repository, job.Environment)
+	case strings.HasPrefix(run.TriggerEvent, "pull_request"):
+		subject = fmt.Sprintf("repo:%s:pull_request", repository)
+	default:
+		subject = fmt.Sprintf("repo:%s:ref:%s", repository, ref)
+	}
+
+	now := time.Now()
+	claims := &IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    IDTokenIssuer(),
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(setting.Actions.IDTokenExpiration)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+		Ref:                  ref,
+		RefType:              refType,
+		SHA:                  sha,
+		Repository:           repository,
+		RepositoryID:         strconv.FormatInt(run.RepoID, 10),
+		RepositoryOwner:      run.Repo.OwnerName,
+		RepositoryOwnerID:    strconv.FormatInt(run.Repo.OwnerID, 10),
+		RepositoryVisibility: util.Iif(run.Repo.IsPrivate, "private", "public"),
+		RunID:                strconv.FormatInt(run.ID, 10),
+		RunNumber:            strconv.FormatInt(run.Index, 10),
+		RunAttempt:           strconv.FormatInt(task.Attempt, 10),
+		Actor:                run.TriggerUser.Name,
+		ActorID:              strconv.FormatInt(run.TriggerUserID, 10),
+		Workflow:             run.WorkflowID,
+		WorkflowRef:          fmt.Sprintf("%s/%s@%s", repository, run.WorkflowID, ref),
+		EventName:            run.TriggerEvent,
+		HeadRef:              headRef,
+		BaseRef:              baseRef,
+		Environment:          job.Environment,
+	}
+
+	token := jwt.NewWithClaims(signingKey.SigningMethod(), claims)
+	signingKey.PreProcessToken(token)
+	return token.SignedString(signingKey.SignKey())
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/oauth2_provider"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateIDToken(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signingKey, err := oauth2_provider.CreateJWTSigningKey("ES256", privateKey)
+	require.NoError(t, err)
+	defer test.MockVariableValue(&oauth2_provider.DefaultSigningKey, signingKey)()
+	defer test.MockVariableValue(&setting.AppURL, "https://gitea.example.com/")()
+
+	loadTask := func(t *testing.T, payload string) *actions_model.ActionTask {
+		task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+		require.NoError(t, task.LoadAttributes(t.Context()))
+		task.Job.WorkflowPayload = []byte(payload)
+		return task
+	}
+
+	t.Run("NoPermission", func(t *testing.T) {
+		task := loadTask(t, `
+name: test
+on: push
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+`)
+		_, err := CreateIDToken(t.Context(), task, "")
+		assert.ErrorIs(t, err, util.ErrPermissionDenied)
+	})
+
+	t.Run("JobPermission", func(t *testing.T) {
+		task := loadTask(t, `
+name: test
+on: push
+permissions: read-all
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+    permissions:
+      id-token: write
+    steps:
+      - run: echo
+`)
+		token, err := CreateIDToken(t.Context(), task, "https://vault.example.com")
+		require.NoError(t, err)
+
+		claims := &IDTokenClaims{}
+		parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+			return signingKey.VerifyKey(), nil
+		})
+		require.NoError(t, err)
+		assert.True(t, parsed.Valid)
+		assert.Equal(t, "https://gitea.example.com/api/actions/oidc", claims.Issuer)
+		assert.Equal(t, jwt.ClaimStrings{"https://vault.example.com"}, claims.Audience)
+		assert.Equal(t, task.Job.Run.Repo.FullName(), claims.Repository)
+		assert.Equal(t, "repo:"+task.Job.Run.Repo.FullName()+":ref:"+task.Job.Run.Ref, claims.Subject)
+		assert.Equal(t, task.Job.Run.TriggerUser.Name, claims.Actor)
+	})
+
+	t.Run("ForkPullRequest", func(t *testing.T) {
+		task := loadTask(t, `
+name: test
+on: pull_request
+permissions: write-all
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+`)
+		task.Job.IsForkPullRequest = true
+		task.Job.Run.IsForkPullRequest = true
+
+		ok, err := CanTaskRequestIDToken(task)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		_, err = CreateIDToken(t.Context(), task, "")
+		assert.ErrorIs(t, err, util.ErrPermissionDenied)
+	})
+
+	t.Run("SymmetricKey", func(t *testing.T) {
+		hmacKey, err := oauth2_provider.CreateJWTSigningKey("HS256", []byte("secret"))
+		require.NoError(t, err)
+		defer test.MockVariableValue(&oauth2_provider.DefaultSigningKey, hmacKey)()
+
+		task := loadTask(t, `
+name: test
+on: push
+permissions: write-all
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+`)
+		_, err = CreateIDToken(t.Context(), task, "")
+		assert.ErrorContains(t, err, "asymmetric")
+	})
+}
@@ -37,4 +37,10 @@ type API interface {
 	ListWorkflowJobs(*context.APIContext)
 	// ListWorkflowRuns list runs
 	ListWorkflowRuns(*context.APIContext)
+	// ListQueuedJobs list the jobs waiting for runners grouped by labels
+	ListQueuedJobs(*context.APIContext)
+	// GetQueueLimit get the max queue time of jobs
+	GetQueueLimit(*context.APIContext)
+	// UpdateQueueLimit update the max queue time of jobs
+	UpdateQueueLimit(*context.APIContext)
 }
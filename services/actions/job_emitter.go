@@ -15,6 +15,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	notify_service "code.gitea.io/gitea/services/notify"
 
@@ -207,23 +208,48 @@ func checkJobsOfRun(ctx context.Context, run *actions_model.ActionRun) (jobs, up
 		return nil, nil, err
 	}
 
+	if run.NeedApproval {
+		// the jobs of a run which needs approval will be started after it is approved
+		return jobs, nil, nil
+	}
+
 	if err = db.WithTx(ctx, func(ctx context.Context) error {
 		for _, job := range jobs {
 			job.Run = run
 		}
 
-		updates := newJobStatusResolver(jobs, vars).Resolve(ctx)
+		resolver := newJobStatusResolver(jobs, vars)
+		updates := resolver.Resolve(ctx)
 		for _, job := range jobs {
 			if status, ok := updates[job.ID]; ok {
+				oldStatus := job.Status
 				job.Status = status
-				if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, "status"); err != nil {
+				cols := []string{"status"}
+				if job.IsWorkflowCall || job.IsApprovalGate {
+					// a job calling a reusable workflow or an approval gate is never picked up by runners, so its timestamps are maintained here
+					if job.Started == 0 && (status.IsRunning() || status.IsDone()) {
+						job.Started = timeutil.TimeStampNow()
+						cols = append(cols, "started")
+					}
+					if status.IsDone() {
+						job.Stopped = timeutil.TimeStampNow()
+						cols = append(cols, "stopped")
+					}
+				}
+				if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": oldStatus}, cols...); err != nil {
 					return err
 				} else if n != 1 {
-					return fmt.Errorf("no affected for updating blocked job %v", job.ID)
+					return fmt.Errorf("no affected for updating %s job %v", oldStatus, job.ID)
 				}
 				updatedJobs = append(updatedJobs, job)
 			}
 		}
+		// the opened approval gates stay blocked, but they are updated to wait for a review
+		for _, job := range resolver.openedGates {
+			if _, ok := updates[job.ID]; !ok {
+				updatedJobs = append(updatedJobs, job)
+			}
+		}
 		return nil
 	}); err != nil {
 		return nil, nil, err
@@ -242,18 +268,30 @@ func NotifyWorkflowRunStatusUpdateWithReload(ctx context.Context, job *actions_m
 }
 
 type jobStatusResolver struct {
-	statuses map[int64]actions_model.Status
-	needs    map[int64][]int64
-	jobMap   map[int64]*actions_model.ActionRunJob
-	vars     map[string]string
+	statuses   map[int64]actions_model.Status
+	needs      map[int64][]int64
+	calledJobs map[int64][]int64
+	jobMap     map[int64]*actions_model.ActionRunJob
+	vars       map[string]string
+
+	// openedGates are the approval gates which have been opened to wait for a review
+	openedGates []*actions_model.ActionRunJob
 }
 
 func newJobStatusResolver(jobs actions_model.ActionJobList, vars map[string]string) *jobStatusResolver {
-	idToJobs := make(map[string][]*actions_model.ActionRunJob, len(jobs))
+	// job ids are only unique in a workflow, so group the jobs by the job calling the reusable workflow which they belong to
+	idToJobs := make(map[int64]map[string][]*actions_model.ActionRunJob)
 	jobMap := make(map[int64]*actions_model.ActionRunJob)
+	calledJobs := make(map[int64][]int64)
 	for _, job := range jobs {
-		idToJobs[job.JobID] = append(idToJobs[job.JobID], job)
+		if idToJobs[job.ParentJobID] == nil {
+			idToJobs[job.ParentJobID] = make(map[string][]*actions_model.ActionRunJob)
+		}
+		idToJobs[job.ParentJobID][job.JobID] = append(idToJobs[job.ParentJobID][job.JobID], job)
 		jobMap[job.ID] = job
+		if job.ParentJobID > 0 {
+			calledJobs[job.ParentJobID] = append(calledJobs[job.ParentJobID], job.ID)
+		}
 	}
 
 	statuses := make(map[int64]actions_model.Status, len(jobs))
@@ -261,16 +299,17 @@ func newJobStatusResolver(jobs actions_model.ActionJobList, vars map[string]stri
 	for _, job := range jobs {
 		statuses[job.ID] = job.Status
 		for _, need := range job.Needs {
-			for _, v := range idToJobs[need] {
+			for _, v := range idToJobs[job.ParentJobID][need] {
 				needs[job.ID] = append(needs[job.ID], v.ID)
 			}
 		}
 	}
 	return &jobStatusResolver{
-		statuses: statuses,
-		needs:    needs,
-		jobMap:   jobMap,
-		vars:     vars,
+		statuses:   statuses,
+		needs:      needs,
+		calledJobs: calledJobs,
+		jobMap:     jobMap,
+		vars:       vars,
 	}
 }
 
@@ -311,18 +350,93 @@ func (r *jobStatusResolver) resolveJobHasIfCondition(actionRunJob *actions_model
 	return hasIf
 }
 
+// resolveWorkflowCallResult aggregates the result of a job calling a reusable workflow when all jobs of the called workflow are done
+func (r *jobStatusResolver) resolveWorkflowCallResult(id int64) (actions_model.Status, bool) {
+	calledJobs := make([]*actions_model.ActionRunJob, 0, len(r.calledJobs[id]))
+	for _, calledID := range r.calledJobs[id] {
+		status := r.statuses[calledID]
+		if !status.IsDone() {
+			return actions_model.StatusUnknown, false
+		}
+		calledJobs = append(calledJobs, &actions_model.ActionRunJob{Status: status})
+	}
+	return actions_model.AggregateJobStatus(calledJobs), true
+}
+
+// resolveWorkflowCallStart decides whether a job calling a reusable workflow should start the called workflow or be skipped
+func (r *jobStatusResolver) resolveWorkflowCallStart(ctx context.Context, actionRunJob *actions_model.ActionRunJob, allSucceed bool) actions_model.Status {
+	if !r.resolveJobHasIfCondition(actionRunJob) {
+		return util.Iif(allSucceed, actions_model.StatusRunning, actions_model.StatusSkipped)
+	}
+	shouldStart, err := evaluateWorkflowCallIf(ctx, actionRunJob, r.vars)
+	if err != nil {
+		// TODO: show the error to end users
+		log.Error("evaluateWorkflowCallIf failed, the job will fail: job: %d, err: %v", actionRunJob.ID, err)
+		return actions_model.StatusFailure
+	}
+	return util.Iif(shouldStart, actions_model.StatusRunning, actions_model.StatusSkipped)
+}
+
+// resolveApprovalGate decides whether an approval gate should be skipped, or opened to wait for a review,
+// the status of an opened gate is decided by its review.
+func (r *jobStatusResolver) resolveApprovalGate(ctx context.Context, actionRunJob *actions_model.ActionRunJob, allSucceed bool) actions_model.Status {
+	if actionRunJob.Started == 0 {
+		// like a job calling a reusable workflow, the "if" of a gate is evaluated on the server side
+		if status := r.resolveWorkflowCallStart(ctx, actionRunJob, allSucceed); status != actions_model.StatusRunning {
+			return status
+		}
+	}
+	status, opened, err := prepareApprovalGate(ctx, actionRunJob)
+	if err != nil {
+		log.Error("prepareApprovalGate failed, this job will stay blocked: job: %d, err: %v", actionRunJob.ID, err)
+		return actions_model.StatusBlocked
+	}
+	if opened {
+		r.openedGates = append(r.openedGates, actionRunJob)
+	}
+	return status
+}
+
 func (r *jobStatusResolver) resolve(ctx context.Context) map[int64]actions_model.Status {
 	ret := map[int64]actions_model.Status{}
 	for id, status := range r.statuses {
 		actionRunJob := r.jobMap[id]
+		if actionRunJob.IsWorkflowCall && status == actions_model.StatusRunning {
+			if newStatus, ok := r.resolveWorkflowCallResult(id); ok {
+				ret[id] = newStatus
+			}
+			continue
+		}
 		if status != actions_model.StatusBlocked {
 			continue
 		}
+		if actionRunJob.ParentJobID > 0 {
+			// the jobs of a reusable workflow can only start after the caller job starts the called workflow
+			parentStatus := r.statuses[actionRunJob.ParentJobID]
+			if parentStatus.IsDone() {
+				ret[id] = actions_model.StatusSkipped
+				continue
+			} else if parentStatus != actions_model.StatusRunning {
+				continue
+			}
+		}
 		allDone, allSucceed := r.resolveCheckNeeds(id)
 		if !allDone {
 			continue
 		}
 
+		if actionRunJob.IsWorkflowCall {
+			ret[id] = r.resolveWorkflowCallStart(ctx, actionRunJob, allSucceed)
+			continue
+		}
+
+		if actionRunJob.IsApprovalGate {
+			if newStatus := r.resolveApprovalGate(ctx, actionRunJob, allSucceed); newStatus != actions_model.StatusBlocked {
+				ret[id] = newStatus
+			}
+			continue
+		}
+
 		// update concurrency and check whether the job can run now
 		err := updateConcurrencyEvaluationForJobWithNeeds(ctx, actionRunJob, r.vars)
 		if err != nil {
@@ -343,6 +457,13 @@ func (r *jobStatusResolver) resolve(ctx context.Context) map[int64]actions_model
 		}
 
 		newStatus := util.Iif(shouldStartJob, actions_model.StatusWaiting, actions_model.StatusSkipped)
+		if newStatus == actions_model.StatusWaiting && actionRunJob.Environment != "" {
+			newStatus, err = resolveJobDeployment(ctx, actionRunJob)
+			if err != nil {
+				log.Error("resolveJobDeployment failed, this job will stay blocked: job: %d, err: %v", id, err)
+				continue
+			}
+		}
 		if newStatus == actions_model.StatusWaiting {
 			newStatus, err = PrepareToStartJobWithConcurrency(ctx, actionRunJob)
 			if err != nil {
@@ -357,6 +478,13 @@ func (r *jobStatusResolver) resolve(ctx context.Context) map[int64]actions_model
 	return ret
 }
 
+func resolveJobDeployment(ctx context.Context, actionRunJob *actions_model.ActionRunJob) (actions_model.Status, error) {
+	if setting.IsInTesting && actionRunJob.RepoID == 0 {
+		return actions_model.StatusWaiting, nil // for testing purpose only, no repo, no environment
+	}
+	return prepareJobDeployment(ctx, actionRunJob)
+}
+
 func updateConcurrencyEvaluationForJobWithNeeds(ctx context.Context, actionRunJob *actions_model.ActionRunJob, vars map[string]string) error {
 	if setting.IsInTesting && actionRunJob.RepoID == 0 {
 		return nil // for testing purpose only, no repo, no evaluation
@@ -126,6 +126,46 @@ jobs:
 			},
 			want: map[int64]actions_model.Status{2: actions_model.StatusSkipped},
 		},
+		{
+			name: "start the called workflow",
+			jobs: actions_model.ActionJobList{
+				{ID: 1, JobID: "job1", Status: actions_model.StatusSuccess, Needs: []string{}},
+				{ID: 2, JobID: "call", Status: actions_model.StatusBlocked, Needs: []string{"job1"}, IsWorkflowCall: true},
+				{ID: 3, JobID: "job1", Status: actions_model.StatusBlocked, Needs: []string{}, ParentJobID: 2},
+				{ID: 4, JobID: "job2", Status: actions_model.StatusBlocked, Needs: []string{"job1"}, ParentJobID: 2},
+			},
+			want: map[int64]actions_model.Status{
+				2: actions_model.StatusRunning,
+				3: actions_model.StatusWaiting,
+			},
+		},
+		{
+			name: "skip the called workflow",
+			jobs: actions_model.ActionJobList{
+				{ID: 1, JobID: "job1", Status: actions_model.StatusFailure, Needs: []string{}},
+				{ID: 2, JobID: "call", Status: actions_model.StatusBlocked, Needs: []string{"job1"}, IsWorkflowCall: true},
+				{ID: 3, JobID: "job1", Status: actions_model.StatusBlocked, Needs: []string{}, ParentJobID: 2},
+				{ID: 4, JobID: "job2", Status: actions_model.StatusBlocked, Needs: []string{"job1"}, ParentJobID: 2},
+			},
+			want: map[int64]actions_model.Status{
+				2: actions_model.StatusSkipped,
+				3: actions_model.StatusSkipped,
+				4: actions_model.StatusSkipped,
+			},
+		},
+		{
+			name: "the called workflow is done",
+			jobs: actions_model.ActionJobList{
+				{ID: 1, JobID: "call", Status: actions_model.StatusRunning, Needs: []string{}, IsWorkflowCall: true},
+				{ID: 2, JobID: "job1", Status: actions_model.StatusSuccess, Needs: []string{}, ParentJobID: 1},
+				{ID: 3, JobID: "job2", Status: actions_model.StatusFailure, Needs: []string{"job1"}, ParentJobID: 1},
+				{ID: 4, JobID: "job3", Status: actions_model.StatusBlocked, Needs: []string{"call"}},
+			},
+			want: map[int64]actions_model.Status{
+				1: actions_model.StatusFailure,
+				4: actions_model.StatusSkipped,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
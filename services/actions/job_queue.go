@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// NotifyQueuedJobs notifies the jobs waiting for runners of every owner,
+// so the runners could be scaled with the `workflow_job_queue` webhooks.
+func NotifyQueuedJobs(ctx context.Context) error {
+	groups, err := actions_model.GetQueuedJobGroups(ctx, 0, 0)
+	if err != nil {
+		return fmt.Errorf("find queued jobs: %w", err)
+	}
+
+	// the groups are sorted by owners
+	for start := 0; start < len(groups); {
+		end := start + 1
+		for end < len(groups) && groups[end].OwnerID == groups[start].OwnerID {
+			end++
+		}
+		// the owner could have been deleted
+		if owner := groups[start].Owner; owner != nil {
+			notify_service.WorkflowJobQueueStatus(ctx, owner, groups[start:end])
+		}
+		start = end
+	}
+	return nil
+}
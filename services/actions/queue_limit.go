@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// GetOwnerMaxQueueMinutes returns the max queue time in minutes configured by a user or an organization, 0 means no limit is configured
+func GetOwnerMaxQueueMinutes(ctx context.Context, ownerID int64) (int64, error) {
+	val, err := user_model.GetUserSetting(ctx, ownerID, user_model.SettingsKeyActionsMaxQueueMinutes)
+	if err != nil || val == "" {
+		return 0, err
+	}
+	return strconv.ParseInt(val, 10, 64)
+}
+
+// SetOwnerMaxQueueMinutes configures the max queue time in minutes of the jobs of a user or an organization, 0 means to remove the limit
+func SetOwnerMaxQueueMinutes(ctx context.Context, ownerID, minutes int64) error {
+	if minutes < 0 {
+		return util.NewInvalidArgumentErrorf("max queue minutes must not be negative")
+	}
+	if minutes == 0 {
+		return user_model.DeleteUserSetting(ctx, ownerID, user_model.SettingsKeyActionsMaxQueueMinutes)
+	}
+	return user_model.SetUserSetting(ctx, ownerID, user_model.SettingsKeyActionsMaxQueueMinutes, strconv.FormatInt(minutes, 10))
+}
+
+// GetRepoMaxQueueMinutes returns the max queue time in minutes configured by a repository, 0 means no limit is configured
+func GetRepoMaxQueueMinutes(ctx context.Context, repo *repo_model.Repository) (int64, error) {
+	actionsUnit, err := repo.GetUnit(ctx, unit.TypeActions)
+	if repo_model.IsErrUnitTypeNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return actionsUnit.ActionsConfig().MaxQueueMinutes, nil
+}
+
+// SetRepoMaxQueueMinutes configures the max queue time in minutes of the jobs of a repository, 0 means to inherit the limit of the owner
+func SetRepoMaxQueueMinutes(ctx context.Context, repo *repo_model.Repository, minutes int64) error {
+	if minutes < 0 {
+		return util.NewInvalidArgumentErrorf("max queue minutes must not be negative")
+	}
+	actionsUnit, err := repo.GetUnit(ctx, unit.TypeActions)
+	if err != nil {
+		return err
+	}
+	actionsUnit.ActionsConfig().MaxQueueMinutes = minutes
+	return repo_model.UpdateRepoUnit(ctx, actionsUnit)
+}
+
+// GetMaxQueueTime returns how long a job of the repository could wait for a runner before it is cancelled,
+// the limit of the repository overrides the limit of the owner, which overrides the global limit. 0 means no limit.
+func GetMaxQueueTime(ctx context.Context, repo *repo_model.Repository) (time.Duration, error) {
+	minutes, err := GetRepoMaxQueueMinutes(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+	if minutes == 0 {
+		if minutes, err = GetOwnerMaxQueueMinutes(ctx, repo.OwnerID); err != nil {
+			return 0, err
+		}
+	}
+	if minutes > 0 {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+	return setting.Actions.MaxQueueTime, nil
+}
+
+// CancelQueueTimedOutJobs cancels the jobs which have been waiting for a runner longer than the max queue time
+func CancelQueueTimedOutJobs(ctx context.Context) error {
+	now := timeutil.TimeStampNow()
+	// a limit is at least one minute, so the jobs queued in the last minute couldn't time out
+	jobs, err := db.Find[actions_model.ActionRunJob](ctx, actions_model.FindRunJobOptions{
+		Statuses:     []actions_model.Status{actions_model.StatusWaiting},
+		QueuedBefore: now.AddDuration(-time.Minute),
+	})
+	if err != nil {
+		return fmt.Errorf("find waiting jobs: %w", err)
+	}
+
+	limits := make(map[int64]time.Duration)
+	timedOutJobs := make([]*actions_model.ActionRunJob, 0, len(jobs))
+	for _, job := range jobs {
+		limit, ok := limits[job.RepoID]
+		if !ok {
+			if err := job.LoadRepo(ctx); err != nil {
+				log.Error("Load repo of job %d: %v", job.ID, err)
+				continue
+			}
+			if limit, err = GetMaxQueueTime(ctx, job.Repo); err != nil {
+				log.Error("Get max queue time of repo %d: %v", job.RepoID, err)
+				continue
+			}
+			limits[job.RepoID] = limit
+		}
+		if limit > 0 && job.Queued.AddDuration(limit) < now {
+			timedOutJobs = append(timedOutJobs, job)
+		}
+	}
+
+	cancelJobs(ctx, timedOutJobs, actions_model.JobStopReasonQueueTimeout)
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestTimeoutRunJob(t *testing.T, repo *repo_model.Repository, status actions_model.Status) *actions_model.ActionRunJob {
+	index, err := db.GetNextResourceIndex(t.Context(), "action_run_index", repo.ID)
+	require.NoError(t, err)
+	run := &actions_model.ActionRun{
+		Title:         "timeout",
+		Index:         index,
+		RepoID:        repo.ID,
+		OwnerID:       repo.OwnerID,
+		WorkflowID:    "test.yaml",
+		TriggerUserID: repo.OwnerID,
+		Ref:           "refs/heads/master",
+		CommitSHA:     "c2d72f548424103f01ee1dc02889c1e2bff816b0",
+		Event:         webhook_module.HookEventPush,
+		TriggerEvent:  "push",
+		Status:        status,
+	}
+	require.NoError(t, db.Insert(t.Context(), run))
+	job := &actions_model.ActionRunJob{
+		RunID:           run.ID,
+		RepoID:          run.RepoID,
+		OwnerID:         run.OwnerID,
+		CommitSHA:       run.CommitSHA,
+		Name:            "test",
+		JobID:           "test",
+		Status:          status,
+		Queued:          timeutil.TimeStampNow().AddDuration(-20 * time.Minute),
+		WorkflowPayload: []byte("name: test\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make\n"),
+	}
+	require.NoError(t, db.Insert(t.Context(), job))
+	return job
+}
+
+func TestGetMaxQueueTime(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Actions.MaxQueueTime, 2*time.Hour)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	limit, err := GetMaxQueueTime(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, limit)
+
+	require.NoError(t, SetOwnerMaxQueueMinutes(t.Context(), repo.OwnerID, 30))
+	limit, err = GetMaxQueueTime(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, limit)
+
+	require.NoError(t, SetRepoMaxQueueMinutes(t.Context(), repo, 10))
+	limit, err = GetMaxQueueTime(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, limit)
+
+	// reset the limits to inherit the upper levels
+	require.NoError(t, SetRepoMaxQueueMinutes(t.Context(), repo, 0))
+	require.NoError(t, SetOwnerMaxQueueMinutes(t.Context(), repo.OwnerID, 0))
+	limit, err = GetMaxQueueTime(t.Context(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, limit)
+
+	assert.Error(t, SetRepoMaxQueueMinutes(t.Context(), repo, -1))
+}
+
+func TestCancelQueueTimedOutJobs(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&jobEmitterQueue, queue.CreateUniqueQueue(t.Context(), "test_actions_ready_job", func(items ...*jobUpdate) []*jobUpdate { return nil }))()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	job := createTestTimeoutRunJob(t, repo, actions_model.StatusWaiting)
+
+	// no limit by default
+	require.NoError(t, CancelQueueTimedOutJobs(t.Context()))
+	job = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID})
+	assert.Equal(t, actions_model.StatusWaiting, job.Status)
+
+	// the job has been waiting longer than the limit of the repo
+	require.NoError(t, SetRepoMaxQueueMinutes(t.Context(), repo, 10))
+	require.NoError(t, CancelQueueTimedOutJobs(t.Context()))
+	job = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: job.ID})
+	assert.Equal(t, actions_model.StatusCancelled, job.Status)
+	assert.Equal(t, actions_model.JobStopReasonQueueTimeout, job.StopReason)
+	assert.NotZero(t, job.Stopped)
+}
+
+func TestStopTimedOutTasks(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&jobEmitterQueue, queue.CreateUniqueQueue(t.Context(), "test_actions_ready_job", func(items ...*jobUpdate) []*jobUpdate { return nil }))()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	newTask := func(deadline timeutil.TimeStamp) (*actions_model.ActionRunJob, *actions_model.ActionTask) {
+		job := createTestTimeoutRunJob(t, repo, actions_model.StatusRunning)
+		task := &actions_model.ActionTask{
+			JobID:     job.ID,
+			Attempt:   1,
+			RepoID:    job.RepoID,
+			OwnerID:   job.OwnerID,
+			CommitSHA: job.CommitSHA,
+			Status:    actions_model.StatusRunning,
+			Started:   timeutil.TimeStampNow(),
+			Deadline:  deadline,
+		}
+		require.NoError(t, task.GenerateToken())
+		require.NoError(t, db.Insert(t.Context(), task))
+		job.TaskID = task.ID
+		_, err := actions_model.UpdateRunJob(t.Context(), job, nil, "task_id")
+		require.NoError(t, err)
+		return job, task
+	}
+	timedOutJob, timedOutTask := newTask(timeutil.TimeStampNow().AddDuration(-time.Minute))
+	runningJob, runningTask := newTask(timeutil.TimeStampNow().AddDuration(time.Hour))
+
+	require.NoError(t, StopTimedOutTasks(t.Context()))
+
+	timedOutTask = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: timedOutTask.ID})
+	assert.Equal(t, actions_model.StatusFailure, timedOutTask.Status)
+	timedOutJob = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: timedOutJob.ID})
+	assert.Equal(t, actions_model.StatusFailure, timedOutJob.Status)
+	assert.Equal(t, actions_model.JobStopReasonTimeout, timedOutJob.StopReason)
+
+	runningTask = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: runningTask.ID})
+	assert.Equal(t, actions_model.StatusRunning, runningTask.Status)
+	runningJob = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: runningJob.ID})
+	assert.Equal(t, actions_model.JobStopReasonNone, runningJob.StopReason)
+}
@@ -22,6 +22,15 @@ import (
 
 // GetAllRerunJobs get all jobs that need to be rerun when job should be rerun
 func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.ActionRunJob) []*actions_model.ActionRunJob {
+	// a job of a reusable workflow can't be rerun alone, the whole called workflow is rerun by its caller job
+	for job.ParentJobID > 0 {
+		parent := findJobByID(allJobs, job.ParentJobID)
+		if parent == nil {
+			break
+		}
+		job = parent
+	}
+
 	rerunJobs := []*actions_model.ActionRunJob{job}
 	rerunJobsIDSet := make(container.Set[string])
 	rerunJobsIDSet.Add(job.JobID)
@@ -29,7 +38,7 @@ func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.A
 	for {
 		found := false
 		for _, j := range allJobs {
-			if rerunJobsIDSet.Contains(j.JobID) {
+			if j.ParentJobID != job.ParentJobID || rerunJobsIDSet.Contains(j.JobID) {
 				continue
 			}
 			for _, need := range j.Needs {
@@ -46,9 +55,30 @@ func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.A
 		}
 	}
 
+	// the jobs of the reusable workflows called by the rerun jobs should be rerun too
+	for i := 0; i < len(rerunJobs); i++ {
+		if !rerunJobs[i].IsWorkflowCall {
+			continue
+		}
+		for _, j := range allJobs {
+			if j.ParentJobID == rerunJobs[i].ID {
+				rerunJobs = append(rerunJobs, j)
+			}
+		}
+	}
+
 	return rerunJobs
 }
 
+func findJobByID(jobs []*actions_model.ActionRunJob, id int64) *actions_model.ActionRunJob {
+	for _, job := range jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
 // RerunWorkflowRunJobs reruns all done jobs of a workflow run,
 // or reruns a selected job and all of its downstream jobs when targetJob is specified.
 func RerunWorkflowRunJobs(ctx context.Context, repo *repo_model.Repository, run *actions_model.ActionRun, jobs []*actions_model.ActionRunJob, targetJob *actions_model.ActionRunJob) error {
@@ -108,26 +138,35 @@ func RerunWorkflowRunJobs(ctx context.Context, repo *repo_model.Repository, run
 
 	isRunBlocked := run.Status == actions_model.StatusBlocked
 
-	if targetJob == nil {
-		for _, job := range jobs {
-			// If the job has needs, it should be blocked to wait for its dependencies.
-			shouldBlockJob := len(job.Needs) > 0 || isRunBlocked
-			if err := rerunWorkflowJob(ctx, job, shouldBlockJob); err != nil {
-				return err
-			}
-		}
-		return nil
+	rerunJobs := jobs
+	if targetJob != nil {
+		rerunJobs = GetAllRerunJobs(targetJob, jobs)
+		// the selected job may be replaced by the job calling its reusable workflow
+		targetJob = rerunJobs[0]
 	}
 
-	rerunJobs := GetAllRerunJobs(targetJob, jobs)
+	hasJobsStartedByEmitter := false
 	for _, job := range rerunJobs {
-		// Jobs other than the selected one should wait for dependencies.
-		shouldBlockJob := job.JobID != targetJob.JobID || isRunBlocked
+		var shouldBlockJob bool
+		if targetJob == nil {
+			// If the job has needs, it should be blocked to wait for its dependencies.
+			shouldBlockJob = len(job.Needs) > 0 || isRunBlocked
+		} else {
+			// Jobs other than the selected one should wait for dependencies.
+			shouldBlockJob = job.ID != targetJob.ID || isRunBlocked
+		}
+		if job.IsStartedByJobEmitter() {
+			shouldBlockJob = true
+			hasJobsStartedByEmitter = true
+		}
 		if err := rerunWorkflowJob(ctx, job, shouldBlockJob); err != nil {
 			return err
 		}
 	}
 
+	if hasJobsStartedByEmitter && !isRunBlocked {
+		return EmitJobsIfReadyByRun(run.ID)
+	}
 	return nil
 }
 
@@ -141,6 +180,7 @@ func rerunWorkflowJob(ctx context.Context, job *actions_model.ActionRunJob, shou
 	job.Status = util.Iif(shouldBlock, actions_model.StatusBlocked, actions_model.StatusWaiting)
 	job.Started = 0
 	job.Stopped = 0
+	job.StopReason = actions_model.JobStopReasonNone
 	job.ConcurrencyGroup = ""
 	job.ConcurrencyCancel = false
 	job.IsConcurrencyEvaluated = false
@@ -169,7 +209,7 @@ func rerunWorkflowJob(ctx context.Context, job *actions_model.ActionRunJob, shou
 	}
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		updateCols := []string{"task_id", "status", "started", "stopped", "concurrency_group", "concurrency_cancel", "is_concurrency_evaluated"}
+		updateCols := []string{"task_id", "status", "started", "stopped", "stop_reason", "concurrency_group", "concurrency_cancel", "is_concurrency_evaluated"}
 		_, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": status}, updateCols...)
 		return err
 	}); err != nil {
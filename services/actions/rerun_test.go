@@ -46,3 +46,40 @@ func TestGetAllRerunJobs(t *testing.T) {
 		assert.ElementsMatch(t, tc.rerunJobs, rerunJobs)
 	}
 }
+
+func TestGetAllRerunJobsWithWorkflowCall(t *testing.T) {
+	job1 := &actions_model.ActionRunJob{ID: 1, JobID: "job1"}
+	caller := &actions_model.ActionRunJob{ID: 2, JobID: "call", Needs: []string{"job1"}, IsWorkflowCall: true}
+	called1 := &actions_model.ActionRunJob{ID: 3, JobID: "job1", ParentJobID: 2}
+	called2 := &actions_model.ActionRunJob{ID: 4, JobID: "job2", ParentJobID: 2, Needs: []string{"job1"}}
+	job3 := &actions_model.ActionRunJob{ID: 5, JobID: "job3", Needs: []string{"call"}}
+
+	jobs := []*actions_model.ActionRunJob{job1, caller, called1, called2, job3}
+
+	testCases := []struct {
+		job       *actions_model.ActionRunJob
+		rerunJobs []*actions_model.ActionRunJob
+	}{
+		{
+			job1,
+			[]*actions_model.ActionRunJob{job1, caller, called1, called2, job3},
+		},
+		{
+			caller,
+			[]*actions_model.ActionRunJob{caller, called1, called2, job3},
+		},
+		{
+			called2,
+			[]*actions_model.ActionRunJob{caller, called1, called2, job3},
+		},
+		{
+			job3,
+			[]*actions_model.ActionRunJob{job3},
+		},
+	}
+
+	for _, tc := range testCases {
+		rerunJobs := GetAllRerunJobs(tc.job, jobs)
+		assert.ElementsMatch(t, tc.rerunJobs, rerunJobs)
+	}
+}
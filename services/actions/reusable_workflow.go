@@ -0,0 +1,342 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/actions/jobparser"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	// maxReusableWorkflowDepth is the max nesting level of reusable workflows, including the top-level caller workflow
+	maxReusableWorkflowDepth = 10
+	// maxReusableWorkflowCalls is the max number of reusable workflows called by a run
+	maxReusableWorkflowCalls = 50
+)
+
+// WorkflowJob is a job parsed from a workflow to be inserted into a run.
+// If the job calls a reusable workflow, CalledJobs are the jobs parsed from the called workflow.
+type WorkflowJob struct {
+	Workflow   *jobparser.SingleWorkflow
+	CalledJobs []*WorkflowJob
+}
+
+// reusableWorkflowSource is the repository and commit where a workflow file is loaded from,
+// the local reusable workflows ("./path/to/workflow.yml") called by the workflow are loaded from the same commit.
+type reusableWorkflowSource struct {
+	Repo      *repo_model.Repository
+	CommitSHA string
+}
+
+type reusableWorkflowExpander struct {
+	run   *actions_model.ActionRun
+	vars  map[string]string
+	calls int
+}
+
+// ExpandReusableWorkflows loads the reusable workflows called by the jobs recursively,
+// the jobs of the called workflows will be inserted into the run as well.
+func ExpandReusableWorkflows(ctx context.Context, run *actions_model.ActionRun, jobs []*jobparser.SingleWorkflow, vars map[string]string, inputs map[string]any) ([]*WorkflowJob, error) {
+	if err := run.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	e := &reusableWorkflowExpander{run: run, vars: vars}
+	return e.expand(ctx, &reusableWorkflowSource{Repo: run.Repo, CommitSHA: run.CommitSHA}, jobs, inputs, 1)
+}
+
+func (e *reusableWorkflowExpander) expand(ctx context.Context, source *reusableWorkflowSource, jobs []*jobparser.SingleWorkflow, inputs map[string]any, depth int) ([]*WorkflowJob, error) {
+	ret := make([]*WorkflowJob, 0, len(jobs))
+	for _, swf := range jobs {
+		wj := &WorkflowJob{Workflow: swf}
+		ret = append(ret, wj)
+
+		id, job := swf.Job()
+		if job == nil || job.Uses == "" {
+			continue
+		}
+
+		if depth >= maxReusableWorkflowDepth {
+			return nil, util.NewInvalidArgumentErrorf("job %q: reusable workflows can only be nested up to %d levels", id, maxReusableWorkflowDepth)
+		}
+		e.calls++
+		if e.calls > maxReusableWorkflowCalls {
+			return nil, util.NewInvalidArgumentErrorf("a workflow run can only call up to %d reusable workflows", maxReusableWorkflowCalls)
+		}
+
+		ref, err := jobparser.ParseReusableWorkflowRef(job.Uses)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("job %q: %v", id, err)
+		}
+		calledSource, content, err := loadReusableWorkflow(ctx, e.run.Repo, source, ref)
+		if err != nil {
+			return nil, fmt.Errorf("job %q: load reusable workflow %q: %w", id, job.Uses, err)
+		}
+
+		call, err := actions_module.GetWorkflowCallFromContent(content)
+		if err != nil {
+			return nil, fmt.Errorf("job %q: parse reusable workflow %q: %w", id, job.Uses, err)
+		} else if call == nil {
+			return nil, util.NewInvalidArgumentErrorf("job %q: workflow %q doesn't have a workflow_call trigger", id, job.Uses)
+		}
+		if err := jobparser.CheckWorkflowCallSecrets(call, job); err != nil {
+			return nil, util.NewInvalidArgumentErrorf("job %q: %v", id, err)
+		}
+
+		// The called workflow is parsed with the inputs when the run is created, the needed jobs haven't run yet.
+		if err := jobparser.CheckWorkflowCallInputs(job); err != nil {
+			return nil, util.NewInvalidArgumentErrorf("job %q: %v", id, err)
+		}
+		giteaCtx := GenerateGiteaContext(e.run, nil)
+		results := map[string]*jobparser.JobResult{id: {Needs: job.Needs()}}
+		calledInputs, err := jobparser.EvaluateWorkflowCallInputs(call, id, job, giteaCtx, results, e.vars, inputs)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("job %q: %v", id, err)
+		}
+
+		calledJobs, err := jobparser.Parse(content, jobparser.WithVars(e.vars), jobparser.WithGitContext(giteaCtx.ToGitHubContext()), jobparser.WithInputs(calledInputs))
+		if err != nil {
+			return nil, fmt.Errorf("job %q: parse reusable workflow %q: %w", id, job.Uses, err)
+		}
+		if len(calledJobs) == 0 {
+			return nil, util.NewInvalidArgumentErrorf("job %q: reusable workflow %q has no jobs", id, job.Uses)
+		}
+
+		if wj.CalledJobs, err = e.expand(ctx, calledSource, calledJobs, calledInputs, depth+1); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// loadReusableWorkflow loads the content of a reusable workflow referenced by a job of the workflow from the source
+func loadReusableWorkflow(ctx context.Context, runRepo *repo_model.Repository, source *reusableWorkflowSource, ref *jobparser.ReusableWorkflowRef) (*reusableWorkflowSource, []byte, error) {
+	if !actions_module.IsWorkflow(ref.Path) {
+		return nil, nil, util.NewInvalidArgumentErrorf("%q is not in the workflow directories", ref.Path)
+	}
+
+	calledSource := &reusableWorkflowSource{Repo: source.Repo, CommitSHA: source.CommitSHA}
+	if !ref.IsLocal() {
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ref.Owner, ref.Repo)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil, nil, util.NewNotExistErrorf("repository %s/%s does not exist", ref.Owner, ref.Repo)
+			}
+			return nil, nil, err
+		}
+		if ok, err := canCallReusableWorkflowsOfRepo(ctx, runRepo, repo); err != nil {
+			return nil, nil, err
+		} else if !ok {
+			// don't leak the existence of the repository
+			return nil, nil, util.NewNotExistErrorf("repository %s/%s does not exist", ref.Owner, ref.Repo)
+		}
+		calledSource = &reusableWorkflowSource{Repo: repo}
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, calledSource.Repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gitRepo.Close()
+
+	commitID := util.IfZero(calledSource.CommitSHA, ref.Ref)
+	commit, err := gitRepo.GetCommit(commitID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get commit %q of %s: %w", commitID, calledSource.Repo.FullName(), err)
+	}
+	calledSource.CommitSHA = commit.ID.String()
+
+	content, err := commit.GetFileContent(ref.Path, 1024*1024)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get workflow file %q: %w", ref.Path, err)
+	}
+	return calledSource, []byte(content), nil
+}
+
+// canCallReusableWorkflowsOfRepo checks whether the workflows of the run's repository can read the reusable workflows of the target repository.
+// It follows the same rules as the actions token, see access_model.GetActionsUserRepoPermission.
+func canCallReusableWorkflowsOfRepo(ctx context.Context, runRepo, targetRepo *repo_model.Repository) (bool, error) {
+	if runRepo.ID == targetRepo.ID {
+		return true, nil
+	}
+	if runRepo.IsPrivate && targetRepo.MustGetUnit(ctx, unit.TypeActions).ActionsConfig().IsCollaborativeOwner(runRepo.OwnerID) {
+		return true, nil
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, targetRepo, user_model.NewActionsUser())
+	if err != nil {
+		return false, err
+	}
+	return perm.CanRead(unit.TypeCode), nil
+}
+
+// getParentJob returns the job calling the reusable workflow which the job belongs to
+func getParentJob(ctx context.Context, job *actions_model.ActionRunJob) (*actions_model.ActionRunJob, error) {
+	parent, err := actions_model.GetRunJobByRunAndID(ctx, job.RunID, job.ParentJobID)
+	if err != nil {
+		return nil, err
+	}
+	parent.Run = job.Run
+	return parent, nil
+}
+
+// getInputsOfJob returns the "inputs" context of a job, which are the workflow_dispatch inputs of the run for the top-level jobs,
+// or the inputs passed by the caller job for the jobs of a reusable workflow.
+func getInputsOfJob(ctx context.Context, run *actions_model.ActionRun, job *actions_model.ActionRunJob) (map[string]any, error) {
+	if job.ParentJobID == 0 {
+		return getInputsFromRun(run)
+	}
+
+	parent, err := getParentJob(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("get parent job: %w", err)
+	}
+	parentInputs, err := getInputsOfJob(ctx, run, parent)
+	if err != nil {
+		return nil, err
+	}
+	parentJob, err := parent.ParseJob()
+	if err != nil {
+		return nil, err
+	}
+	// the payload of a called job contains the triggers of the called workflow
+	call, err := actions_module.GetWorkflowCallFromContent(job.WorkflowPayload)
+	if err != nil {
+		return nil, err
+	} else if call == nil {
+		return nil, errors.New("the called workflow doesn't have a workflow_call trigger")
+	}
+	vars, err := actions_model.GetVariablesOfRun(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+	results, err := findJobNeedsAndFillJobResults(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	return jobparser.EvaluateWorkflowCallInputs(call, parent.JobID, parentJob, GenerateGiteaContext(run, parent), results, vars, parentInputs)
+}
+
+// getSecretsOfJob filters the secrets of the run for a job, the jobs of a reusable workflow can only access the secrets passed by the caller jobs
+func getSecretsOfJob(ctx context.Context, job *actions_model.ActionRunJob, secrets map[string]string) (map[string]string, error) {
+	if job.ParentJobID == 0 {
+		return secrets, nil
+	}
+
+	var callers []*jobparser.Job
+	for current := job; current.ParentJobID > 0; {
+		parent, err := getParentJob(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("get parent job: %w", err)
+		}
+		parentJob, err := parent.ParseJob()
+		if err != nil {
+			return nil, err
+		}
+		callers = append(callers, parentJob)
+		current = parent
+	}
+
+	// pass the secrets from the outermost caller to the innermost one
+	for i := len(callers) - 1; i >= 0; i-- {
+		secrets = jobparser.EvaluateWorkflowCallSecrets(callers[i], secrets)
+	}
+	return secrets, nil
+}
+
+// getWorkflowCallOutputs evaluates the outputs of the reusable workflow called by the job
+func getWorkflowCallOutputs(ctx context.Context, caller *actions_model.ActionRunJob, allJobs []*actions_model.ActionRunJob) (map[string]string, error) {
+	var calledJobs []*actions_model.ActionRunJob
+	for _, job := range allJobs {
+		if job.ParentJobID == caller.ID {
+			calledJobs = append(calledJobs, job)
+		}
+	}
+	if len(calledJobs) == 0 {
+		return nil, nil //nolint:nilnil // the called workflow has no jobs, so there are no outputs
+	}
+
+	call, err := actions_module.GetWorkflowCallFromContent(calledJobs[0].WorkflowPayload)
+	if err != nil {
+		return nil, err
+	} else if call == nil || len(call.Outputs) == 0 {
+		return nil, nil //nolint:nilnil // the called workflow has no outputs
+	}
+
+	jobIDJobs := make(map[string][]*actions_model.ActionRunJob)
+	for _, job := range calledJobs {
+		jobIDJobs[job.JobID] = append(jobIDJobs[job.JobID], job)
+	}
+	jobOutputs := make(map[string]map[string]string, len(jobIDJobs))
+	for jobID, jobsWithSameID := range jobIDJobs {
+		if jobOutputs[jobID], err = collectJobOutputs(ctx, jobsWithSameID, allJobs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := caller.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	vars, err := actions_model.GetVariablesOfRun(ctx, caller.Run)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := getInputsOfJob(ctx, caller.Run, calledJobs[0])
+	if err != nil {
+		return nil, err
+	}
+	return jobparser.EvaluateWorkflowCallOutputs(call, jobOutputs, GenerateGiteaContext(caller.Run, caller), vars, inputs), nil
+}
+
+// evaluateWorkflowCallIf evaluates the "if" of a job calling a reusable workflow,
+// such a job isn't executed by runners, so its "if" has to be evaluated on the server side.
+func evaluateWorkflowCallIf(ctx context.Context, job *actions_model.ActionRunJob, vars map[string]string) (bool, error) {
+	if err := job.LoadAttributes(ctx); err != nil {
+		return false, err
+	}
+	workflowJob, err := job.ParseJob()
+	if err != nil {
+		return false, err
+	}
+	results, err := findJobNeedsAndFillJobResults(ctx, job)
+	if err != nil {
+		return false, err
+	}
+	inputs, err := getInputsOfJob(ctx, job.Run, job)
+	if err != nil {
+		return false, err
+	}
+	return jobparser.EvaluateJobIf(job.JobID, workflowJob, GenerateGiteaContext(job.Run, job), results, vars, inputs)
+}
+
+// insertWorkflowJobs inserts the jobs of a workflow, and the jobs of the reusable workflows called by them recursively
+func insertWorkflowJobs(ctx context.Context, run *actions_model.ActionRun, parent *actions_model.ActionRunJob, jobs []*WorkflowJob, vars map[string]string, inputs map[string]any) (runJobs []*actions_model.ActionRunJob, hasWaitingJobs bool, err error) {
+	for _, wj := range jobs {
+		runJob, err := insertRunJob(ctx, run, parent, wj, vars, inputs)
+		if err != nil {
+			return nil, false, err
+		}
+		hasWaitingJobs = hasWaitingJobs || runJob.Status == actions_model.StatusWaiting
+		runJobs = append(runJobs, runJob)
+
+		if len(wj.CalledJobs) > 0 {
+			calledRunJobs, calledHasWaitingJobs, err := insertWorkflowJobs(ctx, run, runJob, wj.CalledJobs, vars, nil)
+			if err != nil {
+				return nil, false, err
+			}
+			hasWaitingJobs = hasWaitingJobs || calledHasWaitingJobs
+			runJobs = append(runJobs, calledRunJobs...)
+		}
+	}
+	return runJobs, hasWaitingJobs, nil
+}
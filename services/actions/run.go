@@ -6,10 +6,12 @@ package actions
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/modules/actions/jobparser"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	notify_service "code.gitea.io/gitea/services/notify"
 
@@ -52,7 +54,12 @@ func PrepareRunAndInsert(ctx context.Context, content []byte, run *actions_model
 		run.Title = jobs[0].RunName
 	}
 
-	if err = InsertRun(ctx, run, jobs, vars, inputsWithDefaults); err != nil {
+	workflowJobs, err := ExpandReusableWorkflows(ctx, run, jobs, vars, inputsWithDefaults)
+	if err != nil {
+		return fmt.Errorf("ExpandReusableWorkflows: %w", err)
+	}
+
+	if err = InsertRun(ctx, run, workflowJobs, vars, inputsWithDefaults); err != nil {
 		return fmt.Errorf("InsertRun: %w", err)
 	}
 
@@ -74,8 +81,9 @@ func PrepareRunAndInsert(ctx context.Context, content []byte, run *actions_model
 
 // InsertRun inserts a run
 // The title will be cut off at 255 characters if it's longer than 255 characters.
-func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobparser.SingleWorkflow, vars map[string]string, inputs map[string]any) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*WorkflowJob, vars map[string]string, inputs map[string]any) error {
+	var isRunBlocked, hasJobsStartedByEmitter bool
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		index, err := db.GetNextResourceIndex(ctx, "action_run_index", run.RepoID)
 		if err != nil {
 			return err
@@ -88,6 +96,7 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 		if err != nil {
 			return err
 		}
+		isRunBlocked = run.Status == actions_model.StatusBlocked
 
 		if err := db.Insert(ctx, run); err != nil {
 			return err
@@ -101,66 +110,13 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 			return err
 		}
 
-		runJobs := make([]*actions_model.ActionRunJob, 0, len(jobs))
-		var hasWaitingJobs bool
-		for _, v := range jobs {
-			id, job := v.Job()
-			needs := job.Needs()
-			if err := v.SetJob(id, job.EraseNeeds()); err != nil {
-				return err
-			}
-			payload, _ := v.Marshal()
-
-			shouldBlockJob := len(needs) > 0 || run.NeedApproval || run.Status == actions_model.StatusBlocked
-
-			job.Name = util.EllipsisDisplayString(job.Name, 255)
-			runJob := &actions_model.ActionRunJob{
-				RunID:             run.ID,
-				RepoID:            run.RepoID,
-				OwnerID:           run.OwnerID,
-				CommitSHA:         run.CommitSHA,
-				IsForkPullRequest: run.IsForkPullRequest,
-				Name:              job.Name,
-				WorkflowPayload:   payload,
-				JobID:             id,
-				Needs:             needs,
-				RunsOn:            job.RunsOn(),
-				Status:            util.Iif(shouldBlockJob, actions_model.StatusBlocked, actions_model.StatusWaiting),
-			}
-			// check job concurrency
-			if job.RawConcurrency != nil {
-				rawConcurrency, err := yaml.Marshal(job.RawConcurrency)
-				if err != nil {
-					return fmt.Errorf("marshal raw concurrency: %w", err)
-				}
-				runJob.RawConcurrency = string(rawConcurrency)
-
-				// do not evaluate job concurrency when it requires `needs`, the jobs with `needs` will be evaluated later by job emitter
-				if len(needs) == 0 {
-					err = EvaluateJobConcurrencyFillModel(ctx, run, runJob, vars, inputs)
-					if err != nil {
-						return fmt.Errorf("evaluate job concurrency: %w", err)
-					}
-				}
-
-				// If a job needs other jobs ("needs" is not empty), its status is set to StatusBlocked at the entry of the loop
-				// No need to check job concurrency for a blocked job (it will be checked by job emitter later)
-				if runJob.Status == actions_model.StatusWaiting {
-					runJob.Status, err = PrepareToStartJobWithConcurrency(ctx, runJob)
-					if err != nil {
-						return fmt.Errorf("prepare to start job with concurrency: %w", err)
-					}
-				}
-			}
-
-			hasWaitingJobs = hasWaitingJobs || runJob.Status == actions_model.StatusWaiting
-			if err := db.Insert(ctx, runJob); err != nil {
-				return err
-			}
-
-			runJobs = append(runJobs, runJob)
+		runJobs, hasWaitingJobs, err := insertWorkflowJobs(ctx, run, nil, jobs, vars, inputs)
+		if err != nil {
+			return err
 		}
 
+		hasJobsStartedByEmitter = slices.ContainsFunc(runJobs, (*actions_model.ActionRunJob).IsStartedByJobEmitter)
+
 		run.Status = actions_model.AggregateJobStatus(runJobs)
 		if err := actions_model.UpdateRun(ctx, run, "status"); err != nil {
 			return err
@@ -174,5 +130,92 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 		}
 
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+
+	if hasJobsStartedByEmitter && !run.NeedApproval && !isRunBlocked {
+		return EmitJobsIfReadyByRun(run.ID)
+	}
+	return nil
+}
+
+// insertRunJob inserts a job of the run, parent is the job calling the reusable workflow which the job belongs to
+func insertRunJob(ctx context.Context, run *actions_model.ActionRun, parent *actions_model.ActionRunJob, wj *WorkflowJob, vars map[string]string, inputs map[string]any) (*actions_model.ActionRunJob, error) {
+	v := wj.Workflow
+	id, job := v.Job()
+	needs := job.Needs()
+	if err := v.SetJob(id, job.EraseNeeds()); err != nil {
+		return nil, err
+	}
+	payload, _ := v.Marshal()
+
+	isWorkflowCall := len(wj.CalledJobs) > 0
+	isApprovalGate := job.Approval() != nil
+	var environment string
+	if env := job.DeploymentEnvironment(); env != nil {
+		environment = util.EllipsisDisplayString(env.Name, 255)
+	}
+	// The jobs calling reusable workflows, the jobs of the called workflows, the jobs deploying to environments and the approval gates are always blocked,
+	// the job emitter will start them after checking them on the server side.
+	shouldBlockJob := len(needs) > 0 || run.NeedApproval || run.Status == actions_model.StatusBlocked || isWorkflowCall || parent != nil || environment != "" || isApprovalGate
+
+	jobName := util.EllipsisDisplayString(job.Name, 255)
+	if parent != nil {
+		jobName = util.EllipsisDisplayString(parent.Name+" / "+job.Name, 255)
+	}
+	runJob := &actions_model.ActionRunJob{
+		RunID:             run.ID,
+		RepoID:            run.RepoID,
+		OwnerID:           run.OwnerID,
+		CommitSHA:         run.CommitSHA,
+		IsForkPullRequest: run.IsForkPullRequest,
+		Name:              jobName,
+		WorkflowPayload:   payload,
+		JobID:             id,
+		Needs:             needs,
+		RunsOn:            util.Iif(isWorkflowCall || isApprovalGate, nil, job.RunsOn()),
+		RunsOnGroup:       util.Iif(isWorkflowCall || isApprovalGate, "", job.RunsOnGroup()),
+		Status:            util.Iif(shouldBlockJob, actions_model.StatusBlocked, actions_model.StatusWaiting),
+		IsWorkflowCall:    isWorkflowCall,
+		IsApprovalGate:    isApprovalGate,
+		Environment:       environment,
+	}
+	if parent != nil {
+		runJob.ParentJobID = parent.ID
+	}
+	// check job concurrency
+	if job.RawConcurrency != nil {
+		rawConcurrency, err := yaml.Marshal(job.RawConcurrency)
+		if err != nil {
+			return nil, fmt.Errorf("marshal raw concurrency: %w", err)
+		}
+		runJob.RawConcurrency = string(rawConcurrency)
+
+		// do not evaluate job concurrency when it requires `needs`, the jobs with `needs` will be evaluated later by job emitter
+		// the jobs of reusable workflows will be evaluated later too, since their inputs may depend on the `needs` of the caller jobs
+		if len(needs) == 0 && parent == nil {
+			err = EvaluateJobConcurrencyFillModel(ctx, run, runJob, vars, inputs)
+			if err != nil {
+				return nil, fmt.Errorf("evaluate job concurrency: %w", err)
+			}
+		}
+
+		// If a job needs other jobs ("needs" is not empty), its status is set to StatusBlocked at the entry of the loop
+		// No need to check job concurrency for a blocked job (it will be checked by job emitter later)
+		if runJob.Status == actions_model.StatusWaiting {
+			runJob.Status, err = PrepareToStartJobWithConcurrency(ctx, runJob)
+			if err != nil {
+				return nil, fmt.Errorf("prepare to start job with concurrency: %w", err)
+			}
+		}
+	}
+
+	if runJob.Status == actions_model.StatusWaiting {
+		runJob.Queued = timeutil.TimeStampNow()
+	}
+	if err := db.Insert(ctx, runJob); err != nil {
+		return nil, err
+	}
+	return runJob, nil
 }
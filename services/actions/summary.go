@@ -0,0 +1,131 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/renderhelper"
+	repo_model "code.gitea.io/gitea/models/repo"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// StepSummary is the Markdown summary written by a step of a job
+type StepSummary struct {
+	StepIndex int64
+	StepName  string
+	Content   string // the Markdown, or the rendered HTML if it's requested
+	Updated   timeutil.TimeStamp
+}
+
+// StepSummaryURL returns the url which the runners upload the summaries of the steps to
+func StepSummaryURL() string {
+	return strings.TrimSuffix(setting.AppURL, "/") + "/api/actions/summaries"
+}
+
+// SaveStepSummary stores the summary written by a step of a running task and its rendered HTML, it replaces the summary saved before.
+// The Markdown is rendered only once here, since the run page polls the summaries while the job is running.
+func SaveStepSummary(ctx context.Context, task *actions_model.ActionTask, stepIndex int64, content string) error {
+	if task.Status.IsDone() {
+		return util.NewInvalidArgumentErrorf("task %d has been done", task.ID)
+	}
+	if len(content) > actions_module.MaxSummarySize {
+		return util.NewInvalidArgumentErrorf("the size of the summary must not exceed %d bytes", actions_module.MaxSummarySize)
+	}
+	steps, err := actions_model.GetTaskStepsByTaskID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if stepIndex < 0 || stepIndex >= int64(len(steps)) {
+		return util.NewInvalidArgumentErrorf("task %d has no step %d", task.ID, stepIndex)
+	}
+
+	repo, err := repo_model.GetRepositoryByID(ctx, task.RepoID)
+	if err != nil {
+		return err
+	}
+	// the sanitizer of markup strips the unsafe content
+	rctx := renderhelper.NewRenderContextRepoComment(ctx, repo, renderhelper.RepoCommentOptions{
+		CurrentRefPath:    "commit/" + util.PathEscapeSegments(task.CommitSHA),
+		FootnoteContextID: strconv.FormatInt(stepIndex, 10),
+	})
+	rendered, err := markdown.RenderString(rctx, content)
+	if err != nil {
+		return fmt.Errorf("render summary of step %d of task %d: %w", stepIndex, task.ID, err)
+	}
+
+	summary, err := actions_model.GetTaskSummary(ctx, task, stepIndex)
+	if err != nil {
+		return err
+	}
+	if err := actions_module.WriteSummary(summary.Filename, content, string(rendered)); err != nil {
+		return err
+	}
+	summary.Size = int64(len(content))
+	return actions_model.SaveTaskSummary(ctx, summary)
+}
+
+// GetJobSummaries returns the summaries written by the steps of the latest attempt of a job,
+// their content is the rendered HTML instead of the Markdown if rendered is true.
+func GetJobSummaries(ctx context.Context, job *actions_model.ActionRunJob, rendered bool) ([]*StepSummary, error) {
+	if job.TaskID == 0 {
+		return nil, nil
+	}
+	summaries, err := actions_model.GetTaskSummaries(ctx, job.TaskID)
+	if err != nil || len(summaries) == 0 {
+		return nil, err
+	}
+	steps, err := actions_model.GetTaskStepsByTaskID(ctx, job.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*StepSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		content, err := actions_module.ReadSummary(summary.Filename, rendered)
+		if err != nil {
+			return nil, fmt.Errorf("read summary of step %d of task %d: %w", summary.StepIndex, summary.TaskID, err)
+		}
+		stepSummary := &StepSummary{
+			StepIndex: summary.StepIndex,
+			Content:   content,
+			Updated:   summary.Updated,
+		}
+		if summary.StepIndex < int64(len(steps)) {
+			stepSummary.StepName = steps[summary.StepIndex].Name
+		}
+		ret = append(ret, stepSummary)
+	}
+	return ret, nil
+}
+
+// removeTaskSummaries removes the summaries of a task from the storage and the database
+func removeTaskSummaries(ctx context.Context, taskID int64) {
+	summaries, err := actions_model.GetTaskSummaries(ctx, taskID)
+	if err != nil {
+		log.Error("Failed to find summaries of task %v: %v", taskID, err)
+		return
+	}
+	if len(summaries) == 0 {
+		return
+	}
+	for _, summary := range summaries {
+		if err := actions_module.RemoveSummary(summary.Filename); err != nil {
+			log.Error("Failed to remove summary %s of task %v: %v", summary.Filename, taskID, err)
+			// do not return error here, go on
+		}
+	}
+	if err := actions_model.DeleteTaskSummaries(ctx, taskID); err != nil {
+		log.Error("Failed to delete summaries of task %v: %v", taskID, err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"strings"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveStepSummary(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+	job := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: task.JobID})
+	for i, name := range []string{"Set up job", "Run tests"} {
+		require.NoError(t, db.Insert(t.Context(), &actions_model.ActionTaskStep{
+			Name:   name,
+			TaskID: task.ID,
+			Index:  int64(i),
+			RepoID: task.RepoID,
+			Status: actions_model.StatusRunning,
+		}))
+	}
+
+	assert.ErrorIs(t, SaveStepSummary(t.Context(), task, 2, "# Out of range"), util.ErrInvalidArgument)
+	assert.ErrorIs(t, SaveStepSummary(t.Context(), task, 1, strings.Repeat("a", actions_module.MaxSummarySize+1)), util.ErrInvalidArgument)
+
+	require.NoError(t, SaveStepSummary(t.Context(), task, 1, "# Tests\n\nall passed"))
+	require.NoError(t, SaveStepSummary(t.Context(), task, 0, "first"))
+	// the summary saved again replaces the old one
+	require.NoError(t, SaveStepSummary(t.Context(), task, 0, "## Setup<script>alert(1)</script>"))
+
+	summaries, err := GetJobSummaries(t.Context(), job, false)
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.EqualValues(t, 0, summaries[0].StepIndex)
+	assert.Equal(t, "Set up job", summaries[0].StepName)
+	assert.Equal(t, "## Setup<script>alert(1)</script>", summaries[0].Content)
+	assert.EqualValues(t, 1, summaries[1].StepIndex)
+	assert.Equal(t, "Run tests", summaries[1].StepName)
+	assert.Equal(t, "# Tests\n\nall passed", summaries[1].Content)
+
+	// the summaries are rendered when they are saved, and the unsafe content is stripped
+	summaries, err = GetJobSummaries(t.Context(), job, true)
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.Contains(t, summaries[0].Content, "<h2>Setup")
+	assert.NotContains(t, summaries[0].Content, "<script>")
+	assert.Contains(t, summaries[1].Content, "<h1>Tests</h1>")
+
+	// the summaries can't be uploaded after the task is done
+	task.Status = actions_model.StatusSuccess
+	assert.ErrorIs(t, SaveStepSummary(t.Context(), task, 0, "late"), util.ErrInvalidArgument)
+
+	removeTaskSummaries(t.Context(), task.ID)
+	summaries, err = GetJobSummaries(t.Context(), job, false)
+	require.NoError(t, err)
+	assert.Empty(t, summaries)
+	unittest.AssertNotExistsBean(t, &actions_model.ActionTaskSummary{TaskID: task.ID})
+}
@@ -11,6 +11,7 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	secret_model "code.gitea.io/gitea/models/secret"
+	actions_module "code.gitea.io/gitea/modules/actions"
 	notify_service "code.gitea.io/gitea/services/notify"
 
 	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
@@ -67,10 +68,14 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 		if err != nil {
 			return fmt.Errorf("GetSecretsOfTask: %w", err)
 		}
+		secrets, err = getSecretsOfJob(ctx, t.Job, secrets)
+		if err != nil {
+			return fmt.Errorf("getSecretsOfJob: %w", err)
+		}
 
-		vars, err := actions_model.GetVariablesOfRun(ctx, t.Job.Run)
+		vars, err := actions_model.GetVariablesOfJob(ctx, t.Job)
 		if err != nil {
-			return fmt.Errorf("GetVariablesOfRun: %w", err)
+			return fmt.Errorf("GetVariablesOfJob: %w", err)
 		}
 
 		needs, err := findTaskNeeds(ctx, job)
@@ -78,7 +83,7 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 			return fmt.Errorf("findTaskNeeds: %w", err)
 		}
 
-		taskContext, err := generateTaskContext(t)
+		taskContext, err := generateTaskContext(ctx, t)
 		if err != nil {
 			return fmt.Errorf("generateTaskContext: %w", err)
 		}
@@ -107,7 +112,7 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 	return task, true, nil
 }
 
-func generateTaskContext(t *actions_model.ActionTask) (*structpb.Struct, error) {
+func generateTaskContext(ctx context.Context, t *actions_model.ActionTask) (*structpb.Struct, error) {
 	giteaRuntimeToken, err := CreateAuthorizationToken(t.ID, t.Job.RunID, t.JobID)
 	if err != nil {
 		return nil, err
@@ -117,6 +122,33 @@ func generateTaskContext(t *actions_model.ActionTask) (*structpb.Struct, error)
 	gitCtx["token"] = t.Token
 	gitCtx["gitea_runtime_token"] = giteaRuntimeToken
 
+	if ok, err := CanTaskRequestIDToken(t); err != nil {
+		return nil, err
+	} else if ok {
+		// the runner requests ID tokens with gitea_runtime_token, like GitHub's ACTIONS_ID_TOKEN_REQUEST_URL and ACTIONS_ID_TOKEN_REQUEST_TOKEN
+		gitCtx["gitea_id_token_request_url"] = IDTokenIssuer() + "/token"
+	}
+	// the runner uses it as ACTIONS_CACHE_URL instead of the url of its own cache server
+	gitCtx["gitea_cache_url"] = CacheURL()
+	// the runner uploads the summary of a step to "{gitea_step_summary_url}/{step_index}" with gitea_runtime_token
+	gitCtx["gitea_step_summary_url"] = StepSummaryURL()
+
+	if t.Job.ParentJobID > 0 {
+		// The runner reads the inputs of a called workflow from the event payload only when the event is "workflow_call",
+		// it is also the event name used by the runner when it runs a reusable workflow by itself.
+		inputs, err := getInputsOfJob(ctx, t.Job.Run, t.Job)
+		if err != nil {
+			return nil, fmt.Errorf("getInputsOfJob: %w", err)
+		}
+		event, _ := gitCtx["event"].(map[string]any)
+		if event == nil {
+			event = map[string]any{}
+		}
+		event["inputs"] = inputs
+		gitCtx["event"] = event
+		gitCtx["event_name"] = actions_module.GithubEventWorkflowCall
+	}
+
 	return structpb.NewStruct(gitCtx)
 }
 
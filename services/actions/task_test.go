@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickTaskWithRunnerGroup(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4})
+	newJob := func(workflowID, runsOnGroup string, runsOn []string) *actions_model.ActionRunJob {
+		index, err := db.GetNextResourceIndex(t.Context(), "action_run_index", repo.ID)
+		require.NoError(t, err)
+		run := &actions_model.ActionRun{
+			Title:         "runner group",
+			Index:         index,
+			RepoID:        repo.ID,
+			OwnerID:       repo.OwnerID,
+			WorkflowID:    workflowID,
+			TriggerUserID: repo.OwnerID,
+			Ref:           "refs/heads/master",
+			CommitSHA:     "c2d72f548424103f01ee1dc02889c1e2bff816b0",
+			Event:         webhook_module.HookEventPush,
+			TriggerEvent:  "push",
+			Status:        actions_model.StatusWaiting,
+		}
+		require.NoError(t, db.Insert(t.Context(), run))
+		job := &actions_model.ActionRunJob{
+			RunID:           run.ID,
+			RepoID:          run.RepoID,
+			OwnerID:         run.OwnerID,
+			CommitSHA:       run.CommitSHA,
+			Name:            "test",
+			JobID:           "test",
+			RunsOn:          runsOn,
+			RunsOnGroup:     runsOnGroup,
+			Status:          actions_model.StatusWaiting,
+			WorkflowPayload: []byte("name: test\non: push\njobs:\n  test:\n    runs-on:\n      group: " + runsOnGroup + "\n      labels: [linux]\n    steps:\n      - run: make\n"),
+		}
+		require.NoError(t, db.Insert(t.Context(), job))
+		return job
+	}
+
+	runner := &actions_model.ActionRunner{
+		UUID:        "0d5b7f8c-8a44-4c1e-b3ea-5a2b9bcf7f31",
+		Name:        "gpu-runner",
+		OwnerID:     repo.OwnerID,
+		AgentLabels: []string{"linux"},
+	}
+	require.NoError(t, runner.GenerateToken())
+	require.NoError(t, actions_model.CreateRunner(t.Context(), runner))
+
+	group := &actions_model.ActionRunnerGroup{
+		OwnerID:   repo.OwnerID,
+		Name:      "gpu",
+		Workflows: []string{"train.yaml"},
+	}
+	require.NoError(t, actions_model.CreateRunnerGroup(t.Context(), group))
+	assert.ErrorIs(t, actions_model.CreateRunnerGroup(t.Context(), &actions_model.ActionRunnerGroup{OwnerID: repo.OwnerID, Name: "gpu"}), util.ErrAlreadyExist)
+
+	// the job targets the group, but the runner isn't in the group yet
+	deployJob := newJob("deploy.yaml", "gpu", []string{"linux"})
+	trainJob := newJob("train.yaml", "gpu", []string{"linux"})
+	task, ok, err := PickTask(t.Context(), runner)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, task)
+
+	require.NoError(t, actions_model.SetRunnerGroup(t.Context(), runner, group.ID))
+	runner = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunner{ID: runner.ID})
+	assert.Equal(t, group.ID, runner.GroupID)
+
+	// the name of the group isn't one of the labels of its runners
+	labelJob := newJob("train.yaml", "", []string{"gpu"})
+	require.NoError(t, runner.LoadGroup(t.Context()))
+	assert.True(t, runner.CanMatchGroup("gpu"))
+	assert.False(t, runner.CanMatchGroup("cpu"))
+	assert.False(t, runner.CanMatchLabels(labelJob.RunsOn))
+
+	// the runner only picks the job of the workflow allowed by the group
+	task, ok, err = PickTask(t.Context(), runner)
+	require.NoError(t, err)
+	require.True(t, ok)
+	actionTask := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: task.Id})
+	assert.Equal(t, trainJob.ID, actionTask.JobID)
+	_, ok, err = PickTask(t.Context(), runner)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	deployJob = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: deployJob.ID})
+	assert.Equal(t, actions_model.StatusWaiting, deployJob.Status)
+	labelJob = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: labelJob.ID})
+	assert.Equal(t, actions_model.StatusWaiting, labelJob.Status)
+
+	// the runners are moved to the default group after the group is deleted
+	require.NoError(t, actions_model.DeleteRunnerGroup(t.Context(), group))
+	runner = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunner{ID: runner.ID})
+	assert.Zero(t, runner.GroupID)
+
+	// the runners of repositories can't be moved to groups
+	repoRunner := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunner{ID: 34348})
+	group = &actions_model.ActionRunnerGroup{OwnerID: repo.OwnerID, Name: "prod-deploy", RepoIDs: []int64{repo.ID}}
+	require.NoError(t, actions_model.CreateRunnerGroup(t.Context(), group))
+	assert.ErrorIs(t, actions_model.SetRunnerGroup(t.Context(), repoRunner, group.ID), util.ErrInvalidArgument)
+	assert.True(t, group.CanRun(&actions_model.ActionRun{RepoID: repo.ID, WorkflowID: "deploy.yaml"}))
+	assert.False(t, group.CanRun(&actions_model.ActionRun{RepoID: 1, WorkflowID: "deploy.yaml"}))
+}
@@ -15,6 +15,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/templates"
+	packages_service "code.gitea.io/gitea/services/packages"
 )
 
 // Package contains owner, access mode and optional the package descriptor
@@ -71,7 +72,12 @@ func packageAssignment(ctx *packageAssignmentCtx, errCb func(int, any)) *Package
 	packageType := ctx.PathParam("type")
 	name := ctx.PathParam("name")
 	version := ctx.PathParam("version")
-	if packageType != "" && name != "" && version != "" {
+	if packageType == "" || name == "" {
+		return pkg
+	}
+
+	var p *packages_model.Package
+	if version != "" {
 		pv, err := packages_model.GetVersionByNameAndVersion(ctx, pkg.Owner.ID, packages_model.Type(packageType), name, version)
 		if err != nil {
 			if err == packages_model.ErrPackageNotExist {
@@ -87,11 +93,35 @@ func packageAssignment(ctx *packageAssignmentCtx, errCb func(int, any)) *Package
 			errCb(http.StatusInternalServerError, fmt.Errorf("GetPackageDescriptor: %w", err))
 			return pkg
 		}
+		p = pkg.Descriptor.Package
+	} else {
+		p, err = packages_model.GetPackageByName(ctx, pkg.Owner.ID, packages_model.Type(packageType), name)
+		if err != nil {
+			if err != packages_model.ErrPackageNotExist {
+				errCb(http.StatusInternalServerError, fmt.Errorf("GetPackageByName: %w", err))
+			}
+			return pkg
+		}
+	}
+
+	pkg.AccessMode, err = packages_service.GetPackageAccessMode(ctx, pkg.AccessMode, pkg.Owner, p, ctx.Doer)
+	if err != nil {
+		errCb(http.StatusInternalServerError, fmt.Errorf("GetPackageAccessMode: %w", err))
+		return pkg
+	}
+	if pkg.AccessMode == perm.AccessModeNone && p.IsPrivate && (ctx.Doer == nil || !ctx.Doer.IsAdmin) {
+		// private packages are hidden like private repositories
+		errCb(http.StatusNotFound, packages_model.ErrPackageNotExist)
 	}
 
 	return pkg
 }
 
+// PackageAccessMode returns the access mode of the doer to the packages of the owner
+func PackageAccessMode(ctx *Base, owner, doer *user_model.User) (perm.AccessMode, error) {
+	return determineAccessMode(ctx, &Package{Owner: owner}, doer)
+}
+
 func determineAccessMode(ctx *Base, pkg *Package, doer *user_model.User) (perm.AccessMode, error) {
 	if setting.Service.RequireSignInViewStrict && (doer == nil || doer.IsGhost()) {
 		return perm.AccessModeNone, nil
@@ -0,0 +1,40 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToActionApproval converts an ActionApproval to API format
+func ToActionApproval(ctx context.Context, approval *actions_model.ActionApproval, doer *user_model.User) (*api.ActionApproval, error) {
+	if err := approval.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	result := &api.ActionApproval{
+		ID:            approval.ID,
+		RunID:         approval.RunID,
+		JobID:         approval.RunJobID,
+		JobName:       approval.RunJob.Name,
+		Attempt:       approval.Attempt,
+		Message:       approval.Message,
+		Status:        approval.Status().String(),
+		State:         approval.State.String(),
+		ReviewComment: approval.ReviewComment,
+		Deadline:      approval.Deadline.AsTime(),
+		Created:       approval.Created.AsTime(),
+	}
+	if approval.Reviewer != nil {
+		result.Reviewer = ToUser(ctx, approval.Reviewer, doer)
+	}
+	if approval.Reviewed > 0 {
+		reviewed := approval.Reviewed.AsTime()
+		result.Reviewed = &reviewed
+	}
+	return result, nil
+}
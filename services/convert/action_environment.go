@@ -0,0 +1,56 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToActionEnvironment converts an ActionEnvironment to API format
+func ToActionEnvironment(ctx context.Context, env *actions_model.ActionEnvironment, doer *user_model.User) (*api.ActionEnvironment, error) {
+	reviewers, err := user_model.GetUsersByIDs(ctx, env.RequiredReviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ActionEnvironment{
+		ID:                env.ID,
+		Name:              env.Name,
+		BranchFilters:     env.BranchFilters,
+		TagFilters:        env.TagFilters,
+		RequiredReviewers: ToUsers(ctx, doer, reviewers),
+		PreventSelfReview: env.PreventSelfReview,
+		Created:           env.Created.AsTime(),
+		Updated:           env.Updated.AsTime(),
+	}, nil
+}
+
+// ToActionDeployment converts an ActionDeployment to API format
+func ToActionDeployment(ctx context.Context, deployment *actions_model.ActionDeployment, env *actions_model.ActionEnvironment, doer *user_model.User) (*api.ActionDeployment, error) {
+	if err := deployment.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	result := &api.ActionDeployment{
+		ID:            deployment.ID,
+		Environment:   env.Name,
+		RunID:         deployment.RunID,
+		JobID:         deployment.RunJobID,
+		Attempt:       deployment.Attempt,
+		Ref:           deployment.Ref,
+		CommitSHA:     deployment.CommitSHA,
+		URL:           deployment.URL,
+		Status:        deployment.Status().String(),
+		ReviewState:   deployment.ReviewState.String(),
+		ReviewComment: deployment.ReviewComment,
+		Created:       deployment.Created.AsTime(),
+		Updated:       deployment.Updated.AsTime(),
+	}
+	if deployment.Reviewer != nil {
+		result.Reviewer = ToUser(ctx, deployment.Reviewer, doer)
+	}
+	return result, nil
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToActionQueuedJobs converts a QueuedJobGroup to API format
+func ToActionQueuedJobs(ctx context.Context, group *actions_model.QueuedJobGroup) *api.ActionQueuedJobs {
+	owner := group.Owner
+	if owner == nil {
+		owner = user_model.NewGhostUser()
+	}
+	return &api.ActionQueuedJobs{
+		Owner:          ToUser(ctx, owner, nil),
+		Labels:         group.Labels,
+		Count:          group.Count,
+		OldestQueuedAt: group.OldestQueued.AsTime(),
+		WaitSeconds:    group.WaitSeconds(),
+	}
+}
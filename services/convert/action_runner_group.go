@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ToActionRunnerGroup converts an ActionRunnerGroup to API format
+func ToActionRunnerGroup(ctx context.Context, group *actions_model.ActionRunnerGroup) (*api.ActionRunnerGroup, error) {
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, group.RepoIDs)
+	if err != nil {
+		return nil, err
+	}
+	repoNames := make([]string, 0, len(group.RepoIDs))
+	for _, repoID := range group.RepoIDs {
+		if repo, ok := repos[repoID]; ok {
+			repoNames = append(repoNames, repo.Name)
+		}
+	}
+	return &api.ActionRunnerGroup{
+		ID:           group.ID,
+		Name:         group.Name,
+		Description:  group.Description,
+		Repositories: repoNames,
+		Workflows:    util.SliceNilAsEmpty(group.Workflows),
+		Created:      group.Created.AsTime(),
+		Updated:      group.Updated.AsTime(),
+	}, nil
+}
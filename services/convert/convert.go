@@ -378,6 +378,7 @@ func ToActionWorkflowJob(ctx context.Context, repo *repo_model.Repository, task
 		HeadBranch:  git.RefName(job.Run.Ref).BranchName(),
 		Status:      status,
 		Conclusion:  conclusion,
+		StopReason:  job.StopReason.String(),
 		RunnerID:    runnerID,
 		RunnerName:  runnerName,
 		Steps:       util.SliceNilAsEmpty(steps),
@@ -502,6 +503,20 @@ func ToActionArtifact(repo *repo_model.Repository, art *actions_model.ActionArti
 	}, nil
 }
 
+// ToActionCache convert a actions_model.ActionCache to an api.ActionCache
+func ToActionCache(cache *actions_model.ActionCache) *api.ActionCache {
+	return &api.ActionCache{
+		ID:             cache.ID,
+		RepositoryID:   cache.RepoID,
+		Ref:            cache.Ref,
+		Key:            cache.CacheKey,
+		Version:        cache.Version,
+		SizeInBytes:    cache.Size,
+		LastAccessedAt: cache.LastUsed.AsLocalTime(),
+		CreatedAt:      cache.Created.AsLocalTime(),
+	}
+}
+
 func ToActionRunner(ctx context.Context, runner *actions_model.ActionRunner) *api.ActionRunner {
 	status := runner.Status()
 	apiStatus := "offline"
@@ -524,6 +539,8 @@ func ToActionRunner(ctx context.Context, runner *actions_model.ActionRunner) *ap
 		Disabled:  runner.IsDisabled,
 		Ephemeral: runner.Ephemeral,
 		Labels:    labels,
+
+		RunnerGroupID: runner.GroupID,
 	}
 }
 
@@ -51,3 +51,16 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageVulnerability converts packages.PackageVulnerability to api.PackageVulnerability
+func ToPackageVulnerability(pv *packages.PackageVulnerability) *api.PackageVulnerability {
+	return &api.PackageVulnerability{
+		AdvisoryID:        pv.AdvisoryID,
+		DependencyName:    pv.DependencyName,
+		DependencyVersion: pv.DependencyVersion,
+		FixedVersion:      pv.FixedVersion,
+		Summary:           pv.Summary,
+		Severity:          pv.Severity,
+		FoundAt:           pv.CreatedUnix.AsTime(),
+	}
+}
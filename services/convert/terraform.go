@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToTerraformState converts a TerraformState to API format
+func ToTerraformState(ctx context.Context, s *terraform_model.TerraformState, doer *user_model.User) (*api.TerraformState, error) {
+	result := &api.TerraformState{
+		Name:          s.Name,
+		LatestVersion: s.LatestVersion,
+		Locked:        s.IsLocked(),
+		LockInfo:      s.LockInfo,
+		Created:       s.CreatedUnix.AsTime(),
+		Updated:       s.UpdatedUnix.AsTime(),
+	}
+	if s.IsLocked() {
+		lockedAt := s.LockedUnix.AsTime()
+		result.LockedAt = &lockedAt
+
+		locker, err := user_model.GetPossibleUserByID(ctx, s.LockerID)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			return nil, err
+		}
+		if locker != nil {
+			result.Locker = ToUser(ctx, locker, doer)
+		}
+	}
+	return result, nil
+}
+
+// ToTerraformStateVersion converts a TerraformStateVersion to API format
+func ToTerraformStateVersion(ctx context.Context, v *terraform_model.TerraformStateVersion, doer *user_model.User) (*api.TerraformStateVersion, error) {
+	result := &api.TerraformStateVersion{
+		Version: v.Version,
+		Serial:  v.Serial,
+		Lineage: v.Lineage,
+		Size:    v.Size,
+		Created: v.CreatedUnix.AsTime(),
+	}
+
+	creator, err := user_model.GetPossibleUserByID(ctx, v.CreatorID)
+	if err != nil && !user_model.IsErrUserNotExist(err) {
+		return nil, err
+	}
+	if creator != nil {
+		result.Creator = ToUser(ctx, creator, doer)
+	}
+	return result, nil
+}
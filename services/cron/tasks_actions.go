@@ -20,6 +20,11 @@ func initActionsTasks() {
 	registerCancelAbandonedJobs()
 	registerScheduleTasks()
 	registerActionsCleanup()
+	registerActionsCachesCleanup()
+	registerExpireApprovalGates()
+	registerStopTimedOutTasks()
+	registerCancelQueueTimedOutJobs()
+	registerNotifyQueuedJobs()
 }
 
 func registerStopZombieTasks() {
@@ -74,3 +79,53 @@ func registerActionsCleanup() {
 		return actions_service.Cleanup(ctx)
 	})
 }
+
+func registerActionsCachesCleanup() {
+	RegisterTaskFatal("cleanup_actions_caches", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 6h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.CleanupCaches(ctx)
+	})
+}
+
+func registerExpireApprovalGates() {
+	RegisterTaskFatal("expire_actions_approval_gates", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 5m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.ExpireApprovalGates(ctx)
+	})
+}
+
+func registerStopTimedOutTasks() {
+	RegisterTaskFatal("stop_timed_out_tasks", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.StopTimedOutTasks(ctx)
+	})
+}
+
+func registerCancelQueueTimedOutJobs() {
+	RegisterTaskFatal("cancel_queue_timed_out_jobs", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 5m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.CancelQueueTimedOutJobs(ctx)
+	})
+}
+
+func registerNotifyQueuedJobs() {
+	RegisterTaskFatal("notify_queued_actions_jobs", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.NotifyQueuedJobs(ctx)
+	})
+}
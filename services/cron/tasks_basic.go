@@ -17,8 +17,10 @@ import (
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	sbom_service "code.gitea.io/gitea/services/packages/sbom"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
+	savedsearch_service "code.gitea.io/gitea/services/savedsearch"
 )
 
 func registerUpdateMirrorTask() {
@@ -156,6 +158,26 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerScanPackageVulnerabilities() {
+	RegisterTaskFatal("scan_package_vulnerabilities", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return sbom_service.ScanPackages(ctx)
+	})
+}
+
+func registerSendSavedSearchDigests() {
+	RegisterTaskFatal("send_saved_search_digests", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return savedsearch_service.SendDigests(ctx)
+	})
+}
+
 func registerSyncRepoLicenses() {
 	RegisterTaskFatal("sync_repo_licenses", &BaseConfig{
 		Enabled:    false,
@@ -181,6 +203,8 @@ func initBasicTasks() {
 	registerCleanupHookTaskTable()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
+		registerScanPackageVulnerabilities()
 	}
+	registerSendSavedSearchDigests()
 	registerSyncRepoLicenses()
 }
@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+)
+
+func init() {
+	Register(&Check{
+		Title:                      "Garbage collect package blobs",
+		Name:                       "gc-packages",
+		IsDefault:                  false,
+		Run:                        garbageCollectPackagesCheck,
+		AbortIfFailed:              false,
+		SkipDatabaseInitialization: false,
+		Priority:                   1,
+	})
+}
+
+func garbageCollectPackagesCheck(ctx context.Context, logger log.Logger, autofix bool) error {
+	if !setting.Packages.Enabled {
+		return errors.New("package registry is disabled")
+	}
+
+	if err := packages_cleanup_service.GarbageCollectBlobs(ctx, packages_cleanup_service.GarbageCollectBlobsOptions{
+		LogDetail: logger.Info,
+		AutoFix:   autofix,
+		// Blobs are inserted before the package files referencing them, so only collect blobs which can't be part of a running upload
+		OlderThan: 24 * time.Hour,
+	}); err != nil {
+		return err
+	}
+
+	return checkStorage(&checkStorageOptions{Packages: true})(ctx, logger, autofix)
+}
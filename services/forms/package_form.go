@@ -13,18 +13,61 @@ import (
 )
 
 type PackageCleanupRuleForm struct {
-	ID            int64
-	Enabled       bool
-	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,vagrant)"`
-	KeepCount     int    `binding:"In(0,1,5,10,25,50,100)"`
-	KeepPattern   string `binding:"RegexPattern"`
-	RemoveDays    int    `binding:"In(0,7,14,30,60,90,180)"`
-	RemovePattern string `binding:"RegexPattern"`
-	MatchFullName bool
-	Action        string `binding:"Required;In(save,remove)"`
+	ID                 int64
+	Enabled            bool
+	Type               string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,hex,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,terraform,vagrant)"`
+	KeepCount          int    `binding:"In(0,1,5,10,25,50,100)"`
+	KeepPattern        string `binding:"RegexPattern"`
+	RemoveDays         int    `binding:"In(0,7,14,30,60,90,180)"`
+	RemovePattern      string `binding:"RegexPattern"`
+	MatchFullName      bool
+	RemoveUntaggedDays int    `binding:"In(0,1,7,14,30,60,90,180)"`
+	Action             string `binding:"Required;In(save,remove)"`
 }
 
 func (f *PackageCleanupRuleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+type PackageSigningKeyForm struct {
+	Type        string `binding:"Required;In(alpine,debian,rpm)"`
+	PrivateKey  string
+	OverlapDays int    `binding:"In(0,7,14,30,60,90)"`
+	Action      string `binding:"Required;In(import,rotate)"`
+}
+
+func (f *PackageSigningKeyForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+type PackageRemoteForm struct {
+	ID          int64
+	Enabled     bool
+	Type        string `binding:"Required;In(container,maven,npm,pypi)"`
+	URL         string `binding:"Required;ValidUrl;MaxSize(2048)"`
+	Username    string `binding:"MaxSize(255)"`
+	Password    string
+	MetadataTTL int64  `binding:"In(0,60,300,1800,3600,21600,86400)"`
+	Action      string `binding:"Required;In(save,remove)"`
+}
+
+func (f *PackageRemoteForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+type PackageVirtualForm struct {
+	ID              int64
+	Enabled         bool
+	Type            string `binding:"Required;In(container,maven,npm,pypi)"`
+	Sources         string
+	InternalPattern string `binding:"RegexPattern"`
+	Action          string `binding:"Required;In(save,remove)"`
+}
+
+func (f *PackageVirtualForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -151,6 +151,9 @@ type RepoSettingForm struct {
 	// Signing Settings
 	TrustModel string
 
+	// Code Search Settings
+	CodeIndexerRefPatterns string
+
 	// Admin settings
 	EnableHealthCheck  bool
 	RequestReindexType string
@@ -231,6 +234,7 @@ type WebhookForm struct {
 	Status                   bool
 	WorkflowRun              bool
 	WorkflowJob              bool
+	WorkflowJobQueue         bool
 	Active                   bool
 	BranchFilter             string `binding:"GlobPattern"`
 	AuthorizationHeader      string
@@ -15,6 +15,7 @@ import (
 // EditRunnerForm form for admin to create runner
 type EditRunnerForm struct {
 	Description string
+	GroupID     int64
 }
 
 // Validate validates form fields
@@ -413,8 +413,12 @@ func (f *WebauthnDeleteForm) Validate(req *http.Request, errs binding.Errors) bi
 
 // PackageSettingForm form for package settings
 type PackageSettingForm struct {
-	Action   string
-	RepoName string `form:"repo_name"`
+	Action                 string
+	RepoName               string `form:"repo_name"`
+	IsPrivate              bool   `form:"is_private"`
+	InheritRepoPermissions bool   `form:"inherit_repo_permissions"`
+	TeamID                 int64  `form:"team_id"`
+	AccessMode             string `form:"access_mode"`
 }
 
 // Validate validates the fields
@@ -433,3 +437,20 @@ func (f *BlockUserForm) Validate(req *http.Request, errs binding.Errors) binding
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// SavedSearchForm form for managing saved issue searches
+type SavedSearchForm struct {
+	Action string `binding:"Required;In(create,edit,delete,pin,unpin,subscribe,unsubscribe)"`
+	ID     int64
+	Owner  string
+	Name   string `binding:"MaxSize(255)"`
+	Type   string
+	Query  string
+	TeamID int64
+}
+
+// Validate validates the fields
+func (f *SavedSearchForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
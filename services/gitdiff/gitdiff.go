@@ -18,6 +18,7 @@ import (
 	"strings"
 	"time"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -77,7 +78,8 @@ type DiffLine struct {
 	Match       int // the diff matched index. -1: no match. 0: plain and no need to match. >0: for add/del, "Lines" slice index of the other side
 	Type        DiffLineType
 	Content     string
-	Comments    issues_model.CommentList // related PR code comments
+	Comments    issues_model.CommentList              // related PR code comments
+	Annotations []*actions_model.ActionTaskAnnotation // annotations of Actions jobs on the new side of the line
 	SectionInfo *DiffLineSectionInfo
 }
 
@@ -625,6 +627,41 @@ func (diff *Diff) LoadComments(ctx context.Context, issue *issues_model.Issue, c
 	return nil
 }
 
+// LoadActionsAnnotations loads the annotations of the Actions jobs running for the commit into the lines of the new side
+func (diff *Diff) LoadActionsAnnotations(ctx context.Context, repoID int64, commitSHA string) error {
+	annotations, err := actions_model.GetCommitFileAnnotations(ctx, repoID, commitSHA)
+	if err != nil {
+		return err
+	}
+	fileAnnotations := make(map[string]map[int][]*actions_model.ActionTaskAnnotation)
+	for _, annotation := range annotations {
+		if annotation.Line <= 0 {
+			continue
+		}
+		if fileAnnotations[annotation.Path] == nil {
+			fileAnnotations[annotation.Path] = make(map[int][]*actions_model.ActionTaskAnnotation)
+		}
+		fileAnnotations[annotation.Path][int(annotation.Line)] = append(fileAnnotations[annotation.Path][int(annotation.Line)], annotation)
+	}
+	if len(fileAnnotations) == 0 {
+		return nil
+	}
+	for _, file := range diff.Files {
+		lineAnnotations, ok := fileAnnotations[file.Name]
+		if !ok {
+			continue
+		}
+		for _, section := range file.Sections {
+			for _, line := range section.Lines {
+				if line.RightIdx > 0 && line.Type != DiffLineSection {
+					line.Annotations = lineAnnotations[line.RightIdx]
+				}
+			}
+		}
+	}
+	return nil
+}
+
 const cmdDiffHead = "diff --git "
 
 // ParsePatch builds a Diff object from a io.Reader and some parameters.
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	issues_model "code.gitea.io/gitea/models/issues"
 	pull_model "code.gitea.io/gitea/models/pull"
 	"code.gitea.io/gitea/models/unittest"
@@ -1237,3 +1238,43 @@ D test10.txt`
 	assert.Equal(t, thirdReviewUpdatedFiles, thirdReview.UpdatedFiles)
 	assert.Equal(t, 1, thirdReview.GetViewedFileCount())
 }
+
+func TestDiff_LoadActionsAnnotations(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	job := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: 192})
+	newAnnotation := func(taskID int64, path string, line int64) *actions_model.ActionTaskAnnotation {
+		return &actions_model.ActionTaskAnnotation{
+			TaskID:    taskID,
+			JobID:     job.ID,
+			RunID:     job.RunID,
+			RepoID:    job.RepoID,
+			CommitSHA: job.CommitSHA,
+			Level:     actions_model.AnnotationLevelError,
+			Path:      path,
+			Line:      line,
+			Message:   "failed",
+		}
+	}
+	assert.NoError(t, actions_model.InsertTaskAnnotations(t.Context(), job.TaskID, []*actions_model.ActionTaskAnnotation{
+		newAnnotation(job.TaskID, "README.md", 4),
+		newAnnotation(job.TaskID, "README.md", 5),
+		newAnnotation(job.TaskID, "LICENSE", 4),
+	}))
+	// the annotations of the previous attempts of the job are not shown
+	assert.NoError(t, actions_model.InsertTaskAnnotations(t.Context(), 46, []*actions_model.ActionTaskAnnotation{
+		newAnnotation(46, "README.md", 4),
+	}))
+
+	diff := setupDefaultDiff()
+	assert.NoError(t, diff.LoadActionsAnnotations(t.Context(), job.RepoID, job.CommitSHA))
+	annotations := diff.Files[0].Sections[0].Lines[0].Annotations
+	if assert.Len(t, annotations, 1) {
+		assert.Equal(t, job.TaskID, annotations[0].TaskID)
+		assert.EqualValues(t, 4, annotations[0].Line)
+	}
+
+	diff = setupDefaultDiff()
+	assert.NoError(t, diff.LoadActionsAnnotations(t.Context(), job.RepoID, "0000000000000000000000000000000000000000"))
+	assert.Empty(t, diff.Files[0].Sections[0].Lines[0].Annotations)
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/translation"
+	sender_service "code.gitea.io/gitea/services/mailer/sender"
+)
+
+const tplSavedSearchDigestMail templates.TplName = "user/saved_search_digest"
+
+// MailSavedSearchDigest sends the new issues or pull requests matching a saved search to a subscribed user
+func MailSavedSearchDigest(ctx context.Context, u *user_model.User, search *issues_model.SavedSearch, issues issues_model.IssueList) error {
+	if setting.MailService == nil || len(issues) == 0 {
+		return nil
+	}
+	if u.EmailNotificationsPreference == user_model.EmailNotificationsDisabled {
+		return nil
+	}
+
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return err
+	}
+
+	locale := translation.NewLocale(u.Language)
+
+	subject := locale.TrString("mail.saved_search.digest.subject", search.Name, len(issues))
+	mailMeta := map[string]any{
+		"locale":      locale,
+		"Subject":     subject,
+		"SavedSearch": search,
+		"Issues":      issues,
+		"Link":        search.HTMLURL(),
+		"Language":    locale.Language(),
+	}
+
+	var mailBody bytes.Buffer
+	if err := LoadedTemplates().BodyTemplates.ExecuteTemplate(&mailBody, string(tplSavedSearchDigestMail), mailMeta); err != nil {
+		log.Error("ExecuteTemplate [%s]: %v", string(tplSavedSearchDigestMail)+"/body", err)
+		return err
+	}
+
+	msg := sender_service.NewMessage(u.EmailTo(), subject, mailBody.String())
+	msg.Info = subject
+
+	SendAsync(msg)
+
+	return nil
+}
@@ -82,4 +82,6 @@ type Notifier interface {
 	WorkflowRunStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, run *actions_model.ActionRun)
 
 	WorkflowJobStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, job *actions_model.ActionRunJob, task *actions_model.ActionTask)
+
+	WorkflowJobQueueStatus(ctx context.Context, owner *user_model.User, queuedJobs []*actions_model.QueuedJobGroup)
 }
@@ -410,3 +410,10 @@ func WorkflowJobStatusUpdate(ctx context.Context, repo *repo_model.Repository, s
 		notifier.WorkflowJobStatusUpdate(ctx, repo, sender, job, task)
 	}
 }
+
+// WorkflowJobQueueStatus notifies the jobs of an owner which are waiting for runners
+func WorkflowJobQueueStatus(ctx context.Context, owner *user_model.User, queuedJobs []*actions_model.QueuedJobGroup) {
+	for _, notifier := range notifiers {
+		notifier.WorkflowJobQueueStatus(ctx, owner, queuedJobs)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
 	org_model "code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
 	access_model "code.gitea.io/gitea/models/perm/access"
@@ -21,6 +22,7 @@ import (
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	repo_service "code.gitea.io/gitea/services/repository"
+	terraform_service "code.gitea.io/gitea/services/terraform"
 )
 
 // deleteOrganization deletes models associated to an organization.
@@ -38,11 +40,20 @@ func deleteOrganization(ctx context.Context, org *org_model.Organization) error
 		&secret_model.Secret{OwnerID: org.ID},
 		&user_model.Blocking{BlockerID: org.ID},
 		&actions_model.ActionRunner{OwnerID: org.ID},
+		&actions_model.ActionRunnerGroup{OwnerID: org.ID},
 		&actions_model.ActionRunnerToken{OwnerID: org.ID},
 	); err != nil {
 		return fmt.Errorf("DeleteBeans: %w", err)
 	}
 
+	if err := issues_model.DeleteSavedSearchesByOwnerID(ctx, org.ID); err != nil {
+		return err
+	}
+
+	if err := terraform_service.DeleteOwnerStates(ctx, org.ID); err != nil {
+		return err
+	}
+
 	if _, err := db.GetEngine(ctx).ID(org.ID).Delete(new(user_model.User)); err != nil {
 		return fmt.Errorf("Delete: %w", err)
 	}
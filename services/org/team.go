@@ -12,6 +12,7 @@ import (
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
+	packages_model "code.gitea.io/gitea/models/packages"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
@@ -190,11 +191,16 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 			&organization.TeamUser{OrgID: t.OrgID, TeamID: t.ID},
 			&organization.TeamUnit{TeamID: t.ID},
 			&organization.TeamInvite{TeamID: t.ID},
+			&packages_model.PackageTeam{TeamID: t.ID},
 			&issues_model.Review{Type: issues_model.ReviewTypeRequest, ReviewerTeamID: t.ID}, // batch delete the binding relationship between team and PR (request review from team)
 		); err != nil {
 			return err
 		}
 
+		if err := issues_model.DeleteSavedSearchesByTeamID(ctx, t.ID); err != nil {
+			return err
+		}
+
 		for _, tm := range t.Members {
 			if err := removeInvalidOrgUser(ctx, t.OrgID, tm); err != nil {
 				return err
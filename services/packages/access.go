@@ -0,0 +1,136 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"slices"
+
+	"code.gitea.io/gitea/models/organization"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+// CanSeePrivatePackages returns true if the doer can see the private packages of the owner without an explicit permission.
+// These are site admins, the owner itself and the members of the owner organization.
+func CanSeePrivatePackages(ctx context.Context, owner, doer *user_model.User) (bool, error) {
+	if doer == nil || doer.IsGhost() || doer.ID <= 0 {
+		return false, nil
+	}
+	if doer.IsAdmin || doer.ID == owner.ID {
+		return true, nil
+	}
+	if owner.IsOrganization() {
+		return organization.IsOrganizationMember(ctx, owner.ID, doer.ID)
+	}
+	return false, nil
+}
+
+// GetPackageAccessMode returns the access mode of the doer to the package.
+// ownerAccessMode is the access mode of the doer to the packages of the owner which gets restricted if the package is private
+// and raised by the team permissions of the package and the permissions of the linked repository.
+func GetPackageAccessMode(ctx context.Context, ownerAccessMode perm.AccessMode, owner *user_model.User, p *packages_model.Package, doer *user_model.User) (perm.AccessMode, error) {
+	accessMode := ownerAccessMode
+	if p.IsPrivate {
+		canSee, err := CanSeePrivatePackages(ctx, owner, doer)
+		if err != nil {
+			return perm.AccessModeNone, err
+		}
+		if !canSee {
+			accessMode = perm.AccessModeNone
+		}
+	}
+
+	if doer == nil || doer.IsGhost() {
+		return accessMode, nil
+	}
+
+	if owner.IsOrganization() && doer.ID > 0 {
+		teamMode, err := packages_model.GetUserPackageTeamAccessMode(ctx, p.ID, doer.ID)
+		if err != nil {
+			return perm.AccessModeNone, err
+		}
+		accessMode = max(accessMode, teamMode)
+	}
+
+	if p.InheritRepoPermissions && p.RepoID > 0 {
+		repoMode, err := getLinkedRepositoryAccessMode(ctx, p.RepoID, doer)
+		if err != nil {
+			return perm.AccessModeNone, err
+		}
+		accessMode = max(accessMode, repoMode)
+	}
+
+	return accessMode, nil
+}
+
+func getLinkedRepositoryAccessMode(ctx context.Context, repoID int64, doer *user_model.User) (perm.AccessMode, error) {
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return perm.AccessModeNone, nil
+		}
+		return perm.AccessModeNone, err
+	}
+
+	var permission access_model.Permission
+	if taskID, ok := user_model.GetActionsUserTaskID(doer); ok {
+		permission, err = access_model.GetActionsUserRepoPermission(ctx, repo, doer, taskID)
+	} else {
+		permission, err = access_model.GetUserRepoPermission(ctx, repo, doer)
+	}
+	if err != nil {
+		return perm.AccessModeNone, err
+	}
+
+	mode := permission.UnitAccessMode(unit.TypePackages)
+	// everyone can read a public repository, that must not make private packages visible
+	if mode == perm.AccessModeRead && !repo.IsPrivate {
+		return perm.AccessModeNone, nil
+	}
+	return mode, nil
+}
+
+// ApplyPrivatePackageFilter hides the private packages of the owner the doer has no access to from the search results
+func ApplyPrivatePackageFilter(ctx context.Context, ownerAccessMode perm.AccessMode, owner, doer *user_model.User, opts *packages_model.PackageSearchOptions) error {
+	canSee, err := CanSeePrivatePackages(ctx, owner, doer)
+	if err != nil || canSee {
+		return err
+	}
+
+	ps, err := packages_model.GetPrivatePackagesByOwner(ctx, owner.ID)
+	if err != nil {
+		return err
+	}
+
+	opts.HidePrivate = true
+	for _, p := range ps {
+		accessMode, err := GetPackageAccessMode(ctx, ownerAccessMode, owner, p, doer)
+		if err != nil {
+			return err
+		}
+		if accessMode >= perm.AccessModeRead {
+			opts.PrivateIDs = append(opts.PrivateIDs, p.ID)
+		}
+	}
+	return nil
+}
+
+// FilterPrivatePackages removes the private packages of the owner the doer has no access to from the list
+func FilterPrivatePackages(ctx context.Context, ownerAccessMode perm.AccessMode, owner, doer *user_model.User, ps []*packages_model.Package) ([]*packages_model.Package, error) {
+	opts := &packages_model.PackageSearchOptions{}
+	if err := ApplyPrivatePackageFilter(ctx, ownerAccessMode, owner, doer, opts); err != nil {
+		return nil, err
+	}
+	if !opts.HidePrivate {
+		return ps, nil
+	}
+	return slices.DeleteFunc(ps, func(p *packages_model.Package) bool {
+		return p.IsPrivate && !slices.Contains(opts.PrivateIDs, p.ID)
+	}), nil
+}
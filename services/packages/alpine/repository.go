@@ -42,34 +42,25 @@ func GetOrCreateRepositoryVersion(ctx context.Context, ownerID int64) (*packages
 	return packages_service.GetOrCreateInternalPackageVersion(ctx, ownerID, packages_model.TypeAlpine, alpine_module.RepositoryPackage, alpine_module.RepositoryVersion)
 }
 
+// SigningKeySettings are the settings which store the RSA keys used to sign repository files
+var SigningKeySettings = &packages_service.SigningKeySettings{
+	Private:         alpine_module.SettingKeyPrivate,
+	Public:          alpine_module.SettingKeyPublic,
+	PreviousPrivate: alpine_module.SettingPreviousKeyPrivate,
+	PreviousPublic:  alpine_module.SettingPreviousKeyPublic,
+	PreviousExpires: alpine_module.SettingPreviousKeyExpires,
+	Generate: func() (string, string, error) {
+		return util.GenerateKeyPair(4096)
+	},
+}
+
 // GetOrCreateKeyPair gets or creates the RSA keys used to sign repository files
 func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
-	priv, err := user_model.GetSetting(ctx, ownerID, alpine_module.SettingKeyPrivate)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
-		return "", "", err
-	}
-
-	pub, err := user_model.GetSetting(ctx, ownerID, alpine_module.SettingKeyPublic)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
+	kp, err := packages_service.GetOrCreateSigningKeyPair(ctx, ownerID, SigningKeySettings)
+	if err != nil {
 		return "", "", err
 	}
-
-	if priv == "" || pub == "" {
-		priv, pub, err = util.GenerateKeyPair(4096)
-		if err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, alpine_module.SettingKeyPrivate, priv); err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, alpine_module.SettingKeyPublic, pub); err != nil {
-			return "", "", err
-		}
-	}
-
-	return priv, pub, nil
+	return kp.Private, kp.Public, nil
 }
 
 // BuildAllRepositoryFiles (re)builds all repository files for every available branches, repositories and architectures
@@ -162,6 +153,7 @@ func searchPackageFiles(ctx context.Context, ownerID int64, branch, repository,
 			alpine_module.PropertyRepository:   repository,
 			alpine_module.PropertyArchitecture: architecture,
 		},
+		HidePrivate: true, // private packages are left out of the signed index
 	})
 	if err != nil {
 		return nil, err
@@ -279,49 +271,54 @@ func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *package
 
 	h := sha1.New()
 
-	if err := writeGzipStream(io.MultiWriter(unsignedIndexContent, h), IndexFilename, buf.Bytes(), true); err != nil {
+	if err := writeGzipStream(io.MultiWriter(unsignedIndexContent, h), true, &tarFile{Name: IndexFilename, Content: buf.Bytes()}); err != nil {
 		return err
 	}
 
-	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	owner, err := user_model.GetUserByID(ctx, ownerID)
 	if err != nil {
 		return err
 	}
 
-	privPem, _ := pem.Decode([]byte(priv))
-	if privPem == nil {
-		return errors.New("failed to decode private key pem")
-	}
-
-	privKey, err := x509.ParsePKCS1PrivateKey(privPem.Bytes)
+	keys, err := packages_service.GetOrCreateSigningKeys(ctx, ownerID, SigningKeySettings)
 	if err != nil {
 		return err
 	}
 
-	sign, err := rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA1, h.Sum(nil))
-	if err != nil {
-		return err
-	}
+	// During a key rotation the index is signed with the current and the previous key.
+	// apk uses the first signature it has a public key for.
+	signatures := make([]*tarFile, 0, 2)
+	for _, kp := range keys.All() {
+		privPem, _ := pem.Decode([]byte(kp.Private))
+		if privPem == nil {
+			return errors.New("failed to decode private key pem")
+		}
 
-	owner, err := user_model.GetUserByID(ctx, ownerID)
-	if err != nil {
-		return err
-	}
+		privKey, err := x509.ParsePKCS1PrivateKey(privPem.Bytes)
+		if err != nil {
+			return err
+		}
 
-	fingerprint, err := util.CreatePublicKeyFingerprint(&privKey.PublicKey)
-	if err != nil {
-		return err
+		sign, err := rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA1, h.Sum(nil))
+		if err != nil {
+			return err
+		}
+
+		fingerprint, err := util.CreatePublicKeyFingerprint(&privKey.PublicKey)
+		if err != nil {
+			return err
+		}
+
+		signatures = append(signatures, &tarFile{
+			Name:    fmt.Sprintf(".SIGN.RSA.%s@%s.rsa.pub", owner.LowerName, hex.EncodeToString(fingerprint)),
+			Content: sign,
+		})
 	}
 
 	signedIndexContent, _ := packages_module.NewHashedBuffer()
 	defer signedIndexContent.Close()
 
-	if err := writeGzipStream(
-		signedIndexContent,
-		fmt.Sprintf(".SIGN.RSA.%s@%s.rsa.pub", owner.LowerName, hex.EncodeToString(fingerprint)),
-		sign,
-		false,
-	); err != nil {
+	if err := writeGzipStream(signedIndexContent, false, signatures...); err != nil {
 		return err
 	}
 
@@ -351,7 +348,12 @@ func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *package
 	return err
 }
 
-func writeGzipStream(w io.Writer, filename string, content []byte, addTarEnd bool) error {
+type tarFile struct {
+	Name    string
+	Content []byte
+}
+
+func writeGzipStream(w io.Writer, addTarEnd bool, files ...*tarFile) error {
 	zw := gzip.NewWriter(w)
 	defer zw.Close()
 
@@ -359,16 +361,19 @@ func writeGzipStream(w io.Writer, filename string, content []byte, addTarEnd boo
 	if addTarEnd {
 		defer tw.Close()
 	}
-	hdr := &tar.Header{
-		Name: filename,
-		Mode: 0o600,
-		Size: int64(len(content)),
-	}
-	if err := tw.WriteHeader(hdr); err != nil {
-		return err
-	}
-	if _, err := tw.Write(content); err != nil {
-		return err
+	for _, file := range files {
+		hdr := &tar.Header{
+			Name: file.Name,
+			Mode: 0o600,
+			Size: int64(len(file.Content)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.Content); err != nil {
+			return err
+		}
 	}
-	return nil
+	// write the block padding of the last file, the end of the archive is only written if requested
+	return tw.Flush()
 }
@@ -203,6 +203,7 @@ func searchPackageFiles(ctx context.Context, ownerID int64, repository, architec
 			arch_module.PropertyRepository:   repository,
 			arch_module.PropertyArchitecture: architecture,
 		},
+		HidePrivate: true, // private packages are left out of the signed index
 	})
 	if err != nil {
 		return nil, err
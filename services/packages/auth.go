@@ -22,6 +22,10 @@ type packageClaims struct {
 	jwt.RegisteredClaims
 	PackageMeta
 }
+
+// PackageMeta is the identity carried by a package token.
+// The token does not contain any permissions, they are resolved for every request,
+// so changes of the visibility and the permissions of a package apply to issued tokens too.
 type PackageMeta struct {
 	UserID            int64
 	Scope             auth_model.AccessTokenScope
@@ -196,6 +196,11 @@ func BuildPackageIndex(ctx context.Context, p *packages_model.Package) (*bytes.B
 }
 
 func addOrUpdatePackageIndex(ctx context.Context, t *files_service.TemporaryUploadRepository, p *packages_model.Package) error {
+	// the index repository is readable for everyone who can see the owner, private packages are only served by the HTTP index
+	if p.IsPrivate {
+		return t.RemoveFilesFromIndex(ctx, BuildPackagePath(p.LowerName))
+	}
+
 	b, err := BuildPackageIndex(ctx, p)
 	if err != nil {
 		return err
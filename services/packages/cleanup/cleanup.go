@@ -11,6 +11,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	packages_module "code.gitea.io/gitea/modules/packages"
@@ -21,6 +22,7 @@ import (
 	container_service "code.gitea.io/gitea/services/packages/container"
 	debian_service "code.gitea.io/gitea/services/packages/debian"
 	rpm_service "code.gitea.io/gitea/services/packages/rpm"
+	signing_service "code.gitea.io/gitea/services/packages/signing"
 )
 
 // CleanupTask executes cleanup rules and cleanup expired package data
@@ -29,6 +31,10 @@ func CleanupTask(ctx context.Context, olderThan time.Duration) error {
 		return err
 	}
 
+	if err := signing_service.RemoveExpiredKeys(ctx); err != nil {
+		return err
+	}
+
 	return CleanupExpiredData(ctx, olderThan)
 }
 
@@ -81,6 +87,21 @@ func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.Packa
 		}
 		versionDeleted = true
 	}
+
+	if pcr.Type == packages_model.TypeContainer && pcr.RemoveUntaggedDays > 0 {
+		pvs, err := container_service.FindUnreachableUntaggedManifests(ctx, p, time.Now().AddDate(0, 0, -pcr.RemoveUntaggedDays))
+		if err != nil {
+			return false, fmt.Errorf("CleanupRule [%d]: container.FindUnreachableUntaggedManifests failed: %w", pcr.ID, err)
+		}
+		for _, pv := range pvs {
+			log.Debug("Rule[%d]: remove untagged '%s/%s'", pcr.ID, p.Name, pv.Version)
+			if err := packages_service.DeletePackageVersionAndReferences(ctx, pv); err != nil {
+				log.Error("CleanupRule [%d]: DeletePackageVersionAndReferences failed: %v", pcr.ID, err)
+				continue
+			}
+			versionDeleted = true
+		}
+	}
 	return versionDeleted, nil
 }
 
@@ -165,7 +186,6 @@ func ExecuteCleanupRules(ctx context.Context) error {
 }
 
 func CleanupExpiredData(ctx context.Context, olderThan time.Duration) error {
-	pbs := make([]*packages_model.PackageBlob, 0, 100)
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		if err := container_service.Cleanup(ctx, olderThan); err != nil {
 			return err
@@ -184,8 +204,34 @@ func CleanupExpiredData(ctx context.Context, olderThan time.Duration) error {
 			}
 		}
 
-		pbs, err = packages_model.FindExpiredUnreferencedBlobs(ctx, olderThan)
-		if err != nil {
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return GarbageCollectBlobs(ctx, GarbageCollectBlobsOptions{
+		AutoFix:   true,
+		OlderThan: olderThan,
+	})
+}
+
+type GarbageCollectBlobsOptions struct {
+	LogDetail func(format string, v ...any)
+	AutoFix   bool
+	OlderThan time.Duration
+}
+
+// GarbageCollectBlobs sweeps the package blobs older than the specific duration which are not referenced by a package file.
+// If AutoFix is false, the blobs are only reported.
+func GarbageCollectBlobs(ctx context.Context, opts GarbageCollectBlobsOptions) error {
+	if opts.LogDetail == nil {
+		opts.LogDetail = log.Debug
+	}
+
+	var pbs []*packages_model.PackageBlob
+	if err := db.WithTx(ctx, func(ctx context.Context) (err error) {
+		pbs, err = packages_model.FindExpiredUnreferencedBlobs(ctx, opts.OlderThan)
+		if err != nil || !opts.AutoFix {
 			return err
 		}
 
@@ -199,11 +245,26 @@ func CleanupExpiredData(ctx context.Context, olderThan time.Duration) error {
 		return err
 	}
 
+	var size int64
+	for _, pb := range pbs {
+		size += pb.Size
+	}
+	if !opts.AutoFix {
+		opts.LogDetail("Found %d unreferenced package blobs (%s)", len(pbs), base.FileSize(size))
+		return nil
+	}
+
+	deleted := 0
 	contentStore := packages_module.NewContentStore()
 	for _, pb := range pbs {
 		if err := contentStore.Delete(packages_module.BlobHash256Key(pb.HashSHA256)); err != nil {
 			log.Error("Error deleting package blob [%v]: %v", pb.ID, err)
+			continue
 		}
+		deleted++
+	}
+	if len(pbs) > 0 {
+		opts.LogDetail("Collected %d unreferenced package blobs (%s). %d removed from storage.", len(pbs), base.FileSize(size), deleted)
 	}
 
 	return nil
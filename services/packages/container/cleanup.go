@@ -9,6 +9,7 @@ import (
 
 	packages_model "code.gitea.io/gitea/models/packages"
 	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/optional"
 	container_module "code.gitea.io/gitea/modules/packages/container"
 	packages_service "code.gitea.io/gitea/services/packages"
@@ -106,3 +107,91 @@ func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule
 
 	return false, nil
 }
+
+// FindUnreachableUntaggedManifests marks all manifests of the image which are reachable from a kept manifest
+// and returns the other untagged manifests created before olderThan.
+// Tagged manifests and manifests created after olderThan are kept. Manifests referenced by a kept multi-arch index
+// and artifacts (signatures, SBOMs, ...) attached to a kept manifest are kept too.
+func FindUnreachableUntaggedManifests(ctx context.Context, p *packages_model.Package, olderThan time.Time) ([]*packages_model.PackageVersion, error) {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		PackageID:  p.ID,
+		IsInternal: optional.Some(false),
+		Sort:       packages_model.SortCreatedDesc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pfds, err := container_model.GetContainerBlobs(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    p.OwnerID,
+		Image:      p.LowerName,
+		IsManifest: true,
+		OnlyLead:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	versionDigests := make(map[int64]string, len(pfds))
+	for _, pfd := range pfds {
+		versionDigests[pfd.File.VersionID] = pfd.Properties.GetByName(container_module.PropertyDigest)
+	}
+
+	type manifestNode struct {
+		Version    *packages_model.PackageVersion
+		Digest     string
+		IsRoot     bool
+		References []string
+		Subject    string
+		IsMarked   bool
+	}
+
+	nodes := make([]*manifestNode, 0, len(pvs))
+	for _, pv := range pvs {
+		pps, err := packages_model.GetProperties(ctx, packages_model.PropertyTypeVersion, pv.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		n := &manifestNode{
+			Version: pv,
+			Digest:  versionDigests[pv.ID],
+			IsRoot:  pv.CreatedUnix.AsLocalTime().After(olderThan),
+		}
+		for _, pp := range pps {
+			switch pp.Name {
+			case container_module.PropertyManifestTagged:
+				n.IsRoot = true
+			case container_module.PropertyManifestReference:
+				n.References = append(n.References, pp.Value)
+			case container_module.PropertyManifestSubject:
+				n.Subject = pp.Value
+			}
+		}
+		nodes = append(nodes, n)
+	}
+
+	// Mark until no more manifests become reachable because indexes and artifacts may be chained
+	reachable := make(container.Set[string])
+	for changed := true; changed; {
+		changed = false
+		for _, n := range nodes {
+			if n.IsMarked {
+				continue
+			}
+			if n.IsRoot || (n.Digest != "" && reachable.Contains(n.Digest)) || (n.Subject != "" && reachable.Contains(n.Subject)) {
+				n.IsMarked = true
+				reachable.Add(n.Digest)
+				reachable.AddMultiple(n.References...)
+				changed = true
+			}
+		}
+	}
+
+	unreachable := make([]*packages_model.PackageVersion, 0, 10)
+	for _, n := range nodes {
+		if !n.IsMarked {
+			unreachable = append(unreachable, n.Version)
+		}
+	}
+	return unreachable, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/json"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Referrer is a manifest (signature, SBOM, attestation, ...) which refers to a subject manifest
+type Referrer struct {
+	Version    *packages_model.PackageVersion
+	Descriptor oci.Descriptor
+}
+
+// GetReferrers gets the manifests of the image which refer to the subject digest.
+// If artifactType is not empty, only the manifests of this artifact type are returned.
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func GetReferrers(ctx context.Context, ownerID int64, image, subject, artifactType string) ([]*Referrer, error) {
+	pfds, err := container_model.GetContainerBlobs(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    ownerID,
+		Image:      image,
+		Subject:    subject,
+		IsManifest: true,
+		OnlyLead:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	referrers := make([]*Referrer, 0, len(pfds))
+	for _, pfd := range pfds {
+		pv, err := packages_model.GetVersionByID(ctx, pfd.File.VersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		var metadata container_module.Metadata
+		if err := json.Unmarshal([]byte(pv.MetadataJSON), &metadata); err != nil {
+			return nil, err
+		}
+
+		if artifactType != "" && metadata.ArtifactType != artifactType {
+			continue
+		}
+
+		referrers = append(referrers, &Referrer{
+			Version: pv,
+			Descriptor: oci.Descriptor{
+				MediaType:    pfd.Properties.GetByName(container_module.PropertyMediaType),
+				Digest:       digest.Digest(pfd.Properties.GetByName(container_module.PropertyDigest)),
+				Size:         pfd.Blob.Size,
+				ArtifactType: metadata.ArtifactType,
+				Annotations:  metadata.Annotations,
+			},
+		})
+	}
+	return referrers, nil
+}
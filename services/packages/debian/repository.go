@@ -37,38 +37,27 @@ func GetOrCreateRepositoryVersion(ctx context.Context, ownerID int64) (*packages
 	return packages_service.GetOrCreateInternalPackageVersion(ctx, ownerID, packages_model.TypeDebian, debian_module.RepositoryPackage, debian_module.RepositoryVersion)
 }
 
+// SigningKeySettings are the settings which store the PGP keys used to sign repository files
+var SigningKeySettings = &packages_service.SigningKeySettings{
+	Private:         debian_module.SettingKeyPrivate,
+	Public:          debian_module.SettingKeyPublic,
+	PreviousPrivate: debian_module.SettingPreviousKeyPrivate,
+	PreviousPublic:  debian_module.SettingPreviousKeyPublic,
+	PreviousExpires: debian_module.SettingPreviousKeyExpires,
+	Generate:        generateKeypair,
+}
+
 // GetOrCreateKeyPair gets or creates the PGP keys used to sign repository files
 func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
-	priv, err := user_model.GetSetting(ctx, ownerID, debian_module.SettingKeyPrivate)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
-		return "", "", err
-	}
-
-	pub, err := user_model.GetSetting(ctx, ownerID, debian_module.SettingKeyPublic)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
+	kp, err := packages_service.GetOrCreateSigningKeyPair(ctx, ownerID, SigningKeySettings)
+	if err != nil {
 		return "", "", err
 	}
-
-	if priv == "" || pub == "" {
-		priv, pub, err = generateKeypair()
-		if err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, debian_module.SettingKeyPrivate, priv); err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, debian_module.SettingKeyPublic, pub); err != nil {
-			return "", "", err
-		}
-	}
-
-	return priv, pub, nil
+	return kp.Private, kp.Public, nil
 }
 
 func generateKeypair() (string, string, error) {
-	// Repository signing keys are long-lived and there is currently no rotation mechanism, choose stronger algorithms
+	// Repository signing keys are long-lived and are only replaced by an explicit rotation, choose stronger algorithms
 	cfg := &packet.Config{
 		RSABits:       4096,
 		DefaultHash:   crypto.SHA256,
@@ -175,6 +164,7 @@ func buildPackagesIndices(ctx context.Context, ownerID int64, repoVersion *packa
 		Distribution: distribution,
 		Component:    component,
 		Architecture: architecture,
+		HidePrivate:  true, // private packages are left out of the signed indices
 	}
 
 	// Delete the package indices if there are no packages
@@ -317,25 +307,30 @@ func buildReleaseFiles(ctx context.Context, ownerID int64, repoVersion *packages
 
 	sort.Strings(architectures)
 
-	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	keys, err := packages_service.GetOrCreateSigningKeys(ctx, ownerID, SigningKeySettings)
 	if err != nil {
 		return err
 	}
 
-	block, err := armor.Decode(strings.NewReader(priv))
-	if err != nil {
-		return err
+	// During a key rotation the files are signed with the current and the previous key
+	entities := make([]*openpgp.Entity, 0, 2)
+	for _, kp := range keys.All() {
+		e, err := packages_service.ReadPGPEntity(kp.Private)
+		if err != nil {
+			return err
+		}
+		entities = append(entities, e)
 	}
 
-	e, err := openpgp.ReadEntity(packet.NewReader(block.Body))
-	if err != nil {
-		return err
+	privateKeys := make([]*packet.PrivateKey, 0, len(entities))
+	for _, e := range entities {
+		privateKeys = append(privateKeys, e.PrivateKey)
 	}
 
 	inReleaseContent, _ := packages_module.NewHashedBuffer()
 	defer inReleaseContent.Close()
 
-	sw, err := clearsign.Encode(inReleaseContent, e.PrivateKey, nil)
+	sw, err := clearsign.EncodeMulti(inReleaseContent, privateKeys, nil)
 	if err != nil {
 		return err
 	}
@@ -381,7 +376,7 @@ func buildReleaseFiles(ctx context.Context, ownerID int64, repoVersion *packages
 	releaseGpgContent, _ := packages_module.NewHashedBuffer()
 	defer releaseGpgContent.Close()
 
-	if err := openpgp.ArmoredDetachSign(releaseGpgContent, e, bytes.NewReader(buf.Bytes()), nil); err != nil {
+	if err := packages_service.ArmoredDetachSignMulti(releaseGpgContent, entities, buf.Bytes()); err != nil {
 		return err
 	}
 
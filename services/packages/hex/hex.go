@@ -0,0 +1,188 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+
+	"github.com/hashicorp/go-version"
+)
+
+// SigningKeySettings are the settings which store the RSA keys used to sign the registry resources
+var SigningKeySettings = &packages_service.SigningKeySettings{
+	Private: hex_module.SettingKeyPrivate,
+	Public:  hex_module.SettingKeyPublic,
+	Generate: func() (string, string, error) {
+		return util.GenerateKeyPair(4096)
+	},
+}
+
+// GetOrCreateKeyPair gets or creates the RSA keys used to sign the registry resources
+func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
+	kp, err := packages_service.GetOrCreateSigningKeyPair(ctx, ownerID, SigningKeySettings)
+	if err != nil {
+		return "", "", err
+	}
+	return kp.Private, kp.Public, nil
+}
+
+// RepositoryName is the name of the repository which is embedded in the registry resources.
+// Hex verifies it against the name the repository was added with, so it must be added with the owner name.
+func RepositoryName(owner *user_model.User) string {
+	return owner.Name
+}
+
+func sortVersions(pvs []*packages_model.PackageVersion) {
+	sort.SliceStable(pvs, func(i, j int) bool {
+		vi, erri := version.NewSemver(pvs[i].Version)
+		vj, errj := version.NewSemver(pvs[j].Version)
+		if erri != nil || errj != nil {
+			return pvs[i].Version < pvs[j].Version
+		}
+		return vi.LessThan(vj)
+	})
+}
+
+func signResource(ctx context.Context, owner *user_model.User, payload []byte) ([]byte, error) {
+	priv, _, err := GetOrCreateKeyPair(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+	return hex_module.SignResource(payload, priv)
+}
+
+// getPackagesWithVersions returns the packages of the owner the doer can see with their versions sorted by name and version
+func getPackagesWithVersions(ctx context.Context, ownerAccessMode perm.AccessMode, owner, doer *user_model.User) ([]*packages_model.Package, map[int64][]*packages_model.PackageVersion, error) {
+	ps, err := packages_model.GetPackagesByType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ps, err = packages_service.FilterPrivatePackages(ctx, ownerAccessMode, owner, doer, ps)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	versions := make(map[int64][]*packages_model.PackageVersion, len(ps))
+	for _, pv := range pvs {
+		versions[pv.PackageID] = append(versions[pv.PackageID], pv)
+	}
+	for _, pvs := range versions {
+		sortVersions(pvs)
+	}
+
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].LowerName < ps[j].LowerName
+	})
+
+	return ps, versions, nil
+}
+
+// BuildNames creates the signed /names resource, it lists the packages the doer can see
+func BuildNames(ctx context.Context, ownerAccessMode perm.AccessMode, owner, doer *user_model.User) ([]byte, error) {
+	ps, versions, err := getPackagesWithVersions(ctx, ownerAccessMode, owner, doer)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]*hex_module.NamesPackage, 0, len(ps))
+	for _, p := range ps {
+		pvs := versions[p.ID]
+		if len(pvs) == 0 {
+			continue
+		}
+
+		np := &hex_module.NamesPackage{Name: p.Name}
+		for _, pv := range pvs {
+			if t := pv.CreatedUnix.AsTime(); t.After(np.UpdatedAt) {
+				np.UpdatedAt = t
+			}
+		}
+		names = append(names, np)
+	}
+
+	return signResource(ctx, owner, hex_module.EncodeNames(RepositoryName(owner), names))
+}
+
+// BuildVersions creates the signed /versions resource, it lists the packages the doer can see
+func BuildVersions(ctx context.Context, ownerAccessMode perm.AccessMode, owner, doer *user_model.User) ([]byte, error) {
+	ps, versions, err := getPackagesWithVersions(ctx, ownerAccessMode, owner, doer)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]*hex_module.VersionsPackage, 0, len(ps))
+	for _, p := range ps {
+		pvs := versions[p.ID]
+		if len(pvs) == 0 {
+			continue
+		}
+
+		vp := &hex_module.VersionsPackage{Name: p.Name, Versions: make([]string, 0, len(pvs))}
+		for _, pv := range pvs {
+			vp.Versions = append(vp.Versions, pv.Version)
+		}
+		packages = append(packages, vp)
+	}
+
+	return signResource(ctx, owner, hex_module.EncodeVersions(RepositoryName(owner), packages))
+}
+
+// BuildPackage creates the signed /packages/<name> resource
+func BuildPackage(ctx context.Context, owner *user_model.User, name string) ([]byte, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, owner.ID, packages_model.TypeHex, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	sortVersions(pvs)
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*hex_module.Release, 0, len(pds))
+	for _, pd := range pds {
+		metadata := pd.Metadata.(*hex_module.Metadata)
+
+		innerChecksum, err := hex.DecodeString(metadata.InnerChecksum)
+		if err != nil {
+			return nil, err
+		}
+
+		release := &hex_module.Release{
+			Version:       pd.Version.Version,
+			InnerChecksum: innerChecksum,
+			Dependencies:  metadata.Dependencies,
+		}
+		for _, pf := range pd.Files {
+			if pf.File.IsLead {
+				if release.OuterChecksum, err = hex.DecodeString(pf.Blob.HashSHA256); err != nil {
+					return nil, err
+				}
+			}
+		}
+		releases = append(releases, release)
+	}
+
+	return signResource(ctx, owner, hex_module.EncodePackage(RepositoryName(owner), pds[0].Package.Name, releases))
+}
@@ -199,6 +199,15 @@ func AddFileToExistingPackage(ctx context.Context, pvi *PackageInfo, pfci *Packa
 	})
 }
 
+// GetOrCreatePackageVersion gets the package version or creates it (and the package) if it doesn't exist.
+// The files of the version must be added by the caller.
+func GetOrCreatePackageVersion(ctx context.Context, pvci *PackageCreationInfo) (*packages_model.PackageVersion, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (*packages_model.PackageVersion, error) {
+		pv, _, err := createPackageAndVersion(ctx, pvci, true)
+		return pv, err
+	})
+}
+
 // AddFileToPackageVersionInternal adds a file to the package
 // This method skips quota checks and should only be used for system-managed packages.
 func AddFileToPackageVersionInternal(ctx context.Context, pv *packages_model.PackageVersion, pfci *PackageFileCreationInfo) (*packages_model.PackageFile, error) {
@@ -343,13 +352,8 @@ func CheckCountQuotaExceeded(ctx context.Context, doer, owner *user_model.User)
 	return nil
 }
 
-// CheckSizeQuotaExceeded checks if the upload size is bigger than the allowed size
-// The check is skipped if the doer is an admin.
-func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, packageType packages_model.Type, uploadSize int64) error {
-	if doer.IsAdmin {
-		return nil
-	}
-
+// typeSizeLimit returns the max size of a file of the package type, -1 means no limit
+func typeSizeLimit(packageType packages_model.Type) int64 {
 	var typeSpecificSize int64
 	switch packageType {
 	case packages_model.TypeAlpine:
@@ -378,6 +382,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
 		typeSpecificSize = setting.Packages.LimitSizeHelm
+	case packages_model.TypeHex:
+		typeSpecificSize = setting.Packages.LimitSizeHex
 	case packages_model.TypeMaven:
 		typeSpecificSize = setting.Packages.LimitSizeMaven
 	case packages_model.TypeNpm:
@@ -394,10 +400,22 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeRubyGems
 	case packages_model.TypeSwift:
 		typeSpecificSize = setting.Packages.LimitSizeSwift
+	case packages_model.TypeTerraform:
+		typeSpecificSize = setting.Packages.LimitSizeTerraform
 	case packages_model.TypeVagrant:
 		typeSpecificSize = setting.Packages.LimitSizeVagrant
 	}
-	if typeSpecificSize > -1 && typeSpecificSize < uploadSize {
+	return typeSpecificSize
+}
+
+// CheckSizeQuotaExceeded checks if the upload size is bigger than the allowed size
+// The check is skipped if the doer is an admin.
+func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, packageType packages_model.Type, uploadSize int64) error {
+	if doer.IsAdmin {
+		return nil
+	}
+
+	if typeSpecificSize := typeSizeLimit(packageType); typeSpecificSize > -1 && typeSpecificSize < uploadSize {
 		return ErrQuotaTypeSize
 	}
 
@@ -417,6 +435,29 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 	return nil
 }
 
+// GetSizeQuotaLimit returns the max size of a file which could be uploaded without exceeding the size quota, -1 means no limit.
+// It's used to stop reading the content early, the size must still be checked by CheckSizeQuotaExceeded.
+func GetSizeQuotaLimit(ctx context.Context, doer, owner *user_model.User, packageType packages_model.Type) (int64, error) {
+	if doer.IsAdmin {
+		return -1, nil
+	}
+
+	limit := typeSizeLimit(packageType)
+	if setting.Packages.LimitTotalOwnerSize > -1 {
+		totalSize, err := packages_model.CalculateFileSize(ctx, &packages_model.PackageFileSearchOptions{
+			OwnerID: owner.ID,
+		})
+		if err != nil {
+			return 0, err
+		}
+		remaining := max(setting.Packages.LimitTotalOwnerSize-totalSize, 0)
+		if limit == -1 || remaining < limit {
+			limit = remaining
+		}
+	}
+	return limit, nil
+}
+
 // GetOrCreateInternalPackageVersion gets or creates an internal package
 // Some package types need such internal packages for housekeeping.
 func GetOrCreateInternalPackageVersion(ctx context.Context, ownerID int64, packageType packages_model.Type, name, version string) (*packages_model.PackageVersion, error) {
@@ -533,6 +574,10 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 		return err
 	}
 
+	if err := packages_model.DeleteVulnerabilitiesByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
 	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
 	if err != nil {
 		return err
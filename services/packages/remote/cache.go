@@ -0,0 +1,229 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+const (
+	// the metadata of the upstream is stored in an internal package which name is invalid for all supported types
+	metadataPackageName = "_remote"
+	metadataVersion     = "_metadata"
+
+	propertyFetchedUnix = "remote.fetched"
+
+	// MaxMetadataSize is the max size of a metadata file of the upstream
+	MaxMetadataSize = 64 * 1024 * 1024
+)
+
+// GetMetadata returns the metadata file at the path of the upstream.
+// The content is cached for the TTL of the remote, if the upstream is not available the stale content is returned.
+func (r *Remote) GetMetadata(ctx context.Context, p string, accept ...string) ([]byte, error) {
+	pv, err := packages_service.GetOrCreateInternalPackageVersion(ctx, r.OwnerID, r.Type, metadataPackageName, metadataVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256([]byte(p))
+	filename := hex.EncodeToString(hash[:])
+
+	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return nil, err
+	}
+
+	if pf != nil {
+		fetched, err := metadataFetchedTime(ctx, pf)
+		if err != nil {
+			return nil, err
+		}
+		if time.Since(fetched) < time.Duration(r.MetadataTTL)*time.Second {
+			return readFile(ctx, pf)
+		}
+	}
+
+	content, err := r.fetchMetadata(ctx, p, accept)
+	if err != nil {
+		if pf != nil && IsUpstreamError(err) {
+			logUpstreamError(r, p, err)
+			return readFile(ctx, pf)
+		}
+		return nil, err
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	pf, err = packages_service.AddFileToPackageVersionInternal(ctx, pv, &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Creator:           r.Owner,
+		Data:              buf,
+		OverwriteExisting: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the file is not replaced if the content didn't change, so the time of the fetch is stored separately
+	if err := packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeFile, pf.ID, propertyFetchedUnix, strconv.FormatInt(int64(timeutil.TimeStampNow()), 10)); err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func (r *Remote) fetchMetadata(ctx context.Context, p string, accept []string) ([]byte, error) {
+	resp, err := r.Get(ctx, p, accept...)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, MaxMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > MaxMetadataSize {
+		return nil, util.NewInvalidArgumentErrorf("metadata of the upstream exceeds the maximum size")
+	}
+	return content, nil
+}
+
+func metadataFetchedTime(ctx context.Context, pf *packages_model.PackageFile) (time.Time, error) {
+	pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeFile, pf.ID, propertyFetchedUnix)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(pps) == 0 {
+		return pf.CreatedUnix.AsTime(), nil
+	}
+	unix, _ := strconv.ParseInt(pps[0].Value, 10, 64)
+	return time.Unix(unix, 0), nil
+}
+
+func readFile(ctx context.Context, pf *packages_model.PackageFile) ([]byte, error) {
+	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	return io.ReadAll(s)
+}
+
+// CacheFile stores a file fetched from the upstream in the package version which is created if it doesn't exist.
+// The package is marked as cached from the remote and the files count for the quota of the owner.
+func (r *Remote) CacheFile(ctx context.Context, pvci *packages_service.PackageCreationInfo, pfci *packages_service.PackageFileCreationInfo) (*packages_model.PackageVersion, *packages_model.PackageFile, error) {
+	pvci.Owner = r.Owner
+	pvci.Creator = r.Owner
+	pvci.PackageType = r.Type
+	if pvci.PackageProperties == nil {
+		pvci.PackageProperties = make(map[string]string)
+	}
+	pvci.PackageProperties[packages_model.PropertyCachedFromRemote] = r.URL
+	pfci.Creator = r.Owner
+
+	if err := packages_service.CheckSizeQuotaExceeded(ctx, r.Owner, r.Owner, r.Type, pfci.Data.Size()); err != nil {
+		return nil, nil, err
+	}
+
+	pv, err := packages_service.GetOrCreatePackageVersion(ctx, pvci)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pf, err := packages_service.AddFileToPackageVersionInternal(ctx, pv, pfci)
+	if errors.Is(err, packages_model.ErrDuplicatePackageFile) {
+		// the file has been cached by a concurrent request
+		pf, err = packages_model.GetFileForVersionByName(ctx, pv.ID, pfci.Filename, pfci.CompositeKey)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return pv, pf, nil
+}
+
+// DownloadFile downloads the file at the path of the upstream into a buffer, the caller must close the buffer.
+// The file is rejected if it exceeds the size limit of the package type or the remaining quota of the owner.
+func (r *Remote) DownloadFile(ctx context.Context, p string, accept ...string) (*packages_module.HashedBuffer, error) {
+	limit, err := packages_service.GetSizeQuotaLimit(ctx, r.Owner, r.Owner, r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.Get(ctx, p, accept...)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.ContentLength > 0 {
+		if err := packages_service.CheckSizeQuotaExceeded(ctx, r.Owner, r.Owner, r.Type, resp.ContentLength); err != nil {
+			return nil, err
+		}
+	}
+
+	var body io.Reader = resp.Body
+	if limit > -1 {
+		// read one more byte to find out whether the content exceeds the limit
+		body = io.LimitReader(resp.Body, limit+1)
+	}
+	buf, err := packages_module.CreateHashedBufferFromReader(body)
+	if err != nil {
+		return nil, err
+	}
+	if limit > -1 && buf.Size() > limit {
+		buf.Close()
+		if err := packages_service.CheckSizeQuotaExceeded(ctx, r.Owner, r.Owner, r.Type, buf.Size()); err != nil {
+			return nil, err
+		}
+		return nil, packages_service.ErrQuotaTotalSize
+	}
+	return buf, nil
+}
+
+// IsCachedPackage returns true if the package has been fetched from an upstream
+func IsCachedPackage(ctx context.Context, p *packages_model.Package) (bool, error) {
+	pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypePackage, p.ID, packages_model.PropertyCachedFromRemote)
+	if err != nil {
+		return false, err
+	}
+	return len(pps) > 0, nil
+}
+
+// IsLocalPackage returns true if the package has been published to the owner and is not a cached copy of an upstream.
+// The upstream is never asked for the files of local packages.
+func IsLocalPackage(ctx context.Context, ownerID int64, packageType packages_model.Type, packageName string) (bool, error) {
+	p, err := packages_model.GetPackageByName(ctx, ownerID, packageType, packageName)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	cached, err := IsCachedPackage(ctx, p)
+	return !cached, err
+}
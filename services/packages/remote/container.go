@@ -0,0 +1,73 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	packages_module "code.gitea.io/gitea/modules/packages"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var containerManifestMediaTypes = []string{
+	oci.MediaTypeImageIndex,
+	oci.MediaTypeImageManifest,
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// ContainerImageName returns the name of the image in the upstream, the official images of Docker Hub are in the "library" namespace
+func (r *Remote) ContainerImageName(image string) string {
+	if !strings.Contains(image, "/") {
+		switch r.baseURL.Host {
+		case "docker.io", "index.docker.io", "registry-1.docker.io":
+			return "library/" + image
+		}
+	}
+	return image
+}
+
+// ContainerManifest returns the manifest of the image.
+// The manifests referenced by tags are cached for the TTL of the remote, the ones referenced by digest are verified.
+func (r *Remote) ContainerManifest(ctx context.Context, image, reference string) ([]byte, error) {
+	p := fmt.Sprintf("/v2/%s/manifests/%s", r.ContainerImageName(image), reference)
+
+	d := digest.Digest(reference)
+	if d.Validate() != nil {
+		return r.GetMetadata(ctx, p, containerManifestMediaTypes...)
+	}
+
+	content, err := r.fetchMetadata(ctx, p, containerManifestMediaTypes)
+	if err != nil {
+		return nil, err
+	}
+	if d.Algorithm() != digest.SHA256 || digest.FromBytes(content) != d {
+		return nil, ErrHashMismatch
+	}
+	return content, nil
+}
+
+// ContainerDownloadBlob downloads the blob of the image and verifies its digest, the caller must close the buffer
+func (r *Remote) ContainerDownloadBlob(ctx context.Context, image string, d digest.Digest) (*packages_module.HashedBuffer, error) {
+	if d.Validate() != nil || d.Algorithm() != digest.SHA256 {
+		return nil, ErrUpstreamNotExist
+	}
+
+	buf, err := r.DownloadFile(ctx, fmt.Sprintf("/v2/%s/blobs/%s", r.ContainerImageName(image), d))
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, hashSHA256, _ := buf.Sums()
+	if d.Encoded() != hex.EncodeToString(hashSHA256) {
+		buf.Close()
+		return nil, ErrHashMismatch
+	}
+	return buf, nil
+}
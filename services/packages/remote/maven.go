@@ -0,0 +1,108 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	maven_module "code.gitea.io/gitea/modules/packages/maven"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// MavenCachePackageFile fetches the file at the path of the upstream and caches it as a file of the package version
+func (r *Remote) MavenCachePackageFile(ctx context.Context, p, packageName, packageVersion string) (*packages_model.PackageFile, error) {
+	buf, err := r.DownloadFile(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	if err := r.mavenVerifyChecksum(ctx, p, buf); err != nil {
+		return nil, err
+	}
+
+	filename := path.Base(p)
+
+	pvci := &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Name:    packageName,
+			Version: packageVersion,
+		},
+	}
+	pfci := &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Data: buf,
+	}
+
+	var metadata *maven_module.Metadata
+	if strings.ToLower(path.Ext(filename)) == ".pom" {
+		pfci.IsLead = true
+
+		if metadata, err = maven_module.ParsePackageMetaData(buf); err != nil {
+			return nil, err
+		}
+		pvci.Metadata = metadata
+
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	pv, pf, err := r.CacheFile(ctx, pvci, pfci)
+	if err != nil {
+		return nil, err
+	}
+
+	// the version could have been created by another file before the pom
+	if metadata != nil && pv.MetadataJSON == "null" {
+		raw, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, err
+		}
+		pv.MetadataJSON = string(raw)
+		if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+			return nil, err
+		}
+	}
+
+	return pf, nil
+}
+
+// mavenVerifyChecksum compares the hash of the file with the sha1 checksum file of the upstream if there is one
+func (r *Remote) mavenVerifyChecksum(ctx context.Context, p string, hs packages_module.HashSummer) error {
+	resp, err := r.Get(ctx, p+".sha1")
+	if err != nil {
+		if errors.Is(err, ErrUpstreamNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return err
+	}
+	// some checksum files contain the file name after the hash
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return nil
+	}
+
+	_, hashSHA1, _, _ := hs.Sums()
+	if !strings.EqualFold(fields[0], hex.EncodeToString(hashSHA1)) {
+		return ErrHashMismatch
+	}
+	return nil
+}
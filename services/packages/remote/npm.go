@@ -0,0 +1,162 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	packages_service "code.gitea.io/gitea/services/packages"
+
+	"github.com/hashicorp/go-version"
+)
+
+func npmPackagePath(packageName string) string {
+	return "/" + url.PathEscape(packageName)
+}
+
+func npmTarballFilename(tarball string) string {
+	u, err := url.Parse(tarball)
+	if err != nil {
+		return ""
+	}
+	return path.Base(u.Path)
+}
+
+// NpmPackageMetadata returns the package document of the upstream.
+// The tarballs of the versions are rewritten to be downloaded through the registry url.
+func (r *Remote) NpmPackageMetadata(ctx context.Context, packageName, registryURL string) (map[string]any, error) {
+	content, err := r.GetMetadata(ctx, npmPackagePath(packageName), "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+
+	versions, _ := doc["versions"].(map[string]any)
+	for packageVersion, v := range versions {
+		meta, _ := v.(map[string]any)
+		dist, _ := meta["dist"].(map[string]any)
+		if dist == nil {
+			continue
+		}
+		tarball, _ := dist["tarball"].(string)
+		dist["tarball"] = fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(packageName), url.PathEscape(packageVersion), url.PathEscape(npmTarballFilename(tarball)))
+	}
+	return doc, nil
+}
+
+func (r *Remote) npmPackageVersions(ctx context.Context, packageName string) (map[string]*npm_module.PackageMetadataVersion, error) {
+	content, err := r.GetMetadata(ctx, npmPackagePath(packageName), "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Versions map[string]*npm_module.PackageMetadataVersion `json:"versions"`
+	}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Versions, nil
+}
+
+// NpmFindVersionByFilename returns the version of the package whose tarball has the filename
+func (r *Remote) NpmFindVersionByFilename(ctx context.Context, packageName, filename string) (string, error) {
+	versions, err := r.npmPackageVersions(ctx, packageName)
+	if err != nil {
+		return "", err
+	}
+	for packageVersion, meta := range versions {
+		if strings.EqualFold(npmTarballFilename(meta.Dist.Tarball), filename) {
+			return packageVersion, nil
+		}
+	}
+	return "", ErrUpstreamNotExist
+}
+
+// NpmCachePackageFile fetches the tarball of the package version from the upstream and caches it
+func (r *Remote) NpmCachePackageFile(ctx context.Context, packageName, packageVersion, filename string) (*packages_model.PackageFile, error) {
+	versions, err := r.npmPackageVersions(ctx, packageName)
+	if err != nil {
+		return nil, err
+	}
+	meta := versions[packageVersion]
+	if meta == nil || !strings.EqualFold(npmTarballFilename(meta.Dist.Tarball), filename) {
+		return nil, ErrUpstreamNotExist
+	}
+	if meta.Name != packageName {
+		return nil, npm_module.ErrInvalidPackageName
+	}
+
+	v, err := version.NewSemver(meta.Version)
+	if err != nil {
+		return nil, npm_module.ErrInvalidPackageVersion
+	}
+
+	buf, err := r.DownloadFile(ctx, meta.Dist.Tarball)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	if !npmVerifyIntegrity(buf, &meta.Dist) {
+		return nil, npm_module.ErrInvalidIntegrity
+	}
+
+	_, pf, err := r.CacheFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Name:    packageName,
+				Version: v.String(),
+			},
+			SemverCompatible: true,
+			Metadata:         npm_module.NewMetadata(meta),
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: strings.ToLower(filename),
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	return pf, err
+}
+
+// npmVerifyIntegrity compares the hashes of the data with the integrity (or the legacy shasum) of the distribution
+func npmVerifyIntegrity(hs packages_module.HashSummer, dist *npm_module.PackageDistribution) bool {
+	_, hashSHA1, _, hashSHA512 := hs.Sums()
+
+	if algorithm, value, ok := strings.Cut(dist.Integrity, "-"); ok {
+		expected, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return false
+		}
+		switch algorithm {
+		case "sha512":
+			return subtle.ConstantTimeCompare(expected, hashSHA512) == 1
+		case "sha1":
+			return subtle.ConstantTimeCompare(expected, hashSHA1) == 1
+		}
+	}
+	if dist.Shasum != "" {
+		return strings.EqualFold(dist.Shasum, hex.EncodeToString(hashSHA1))
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net/url"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
+	packages_service "code.gitea.io/gitea/services/packages"
+
+	"golang.org/x/net/html"
+)
+
+// PyPIFile is a file of a package listed by the simple repository api of the upstream
+type PyPIFile struct {
+	Filename       string
+	Version        string
+	URL            string
+	SHA256         string
+	RequiresPython string
+}
+
+func pypiSimplePath(packageName string) string {
+	return "/simple/" + url.PathEscape(packageName) + "/"
+}
+
+// PyPIPackageFiles returns the files of the package listed by the simple repository api of the upstream
+// https://peps.python.org/pep-0503/
+func (r *Remote) PyPIPackageFiles(ctx context.Context, packageName string) ([]*PyPIFile, error) {
+	p := pypiSimplePath(packageName)
+
+	content, err := r.GetMetadata(ctx, p, "text/html")
+	if err != nil {
+		return nil, err
+	}
+
+	pageURL, err := r.ResolveURL(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePyPISimplePage(pageURL, content)
+}
+
+func parsePyPISimplePage(pageURL *url.URL, content []byte) ([]*PyPIFile, error) {
+	doc, err := html.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*PyPIFile
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			if f := newPyPIFile(pageURL, n); f != nil {
+				files = append(files, f)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return files, nil
+}
+
+func newPyPIFile(pageURL *url.URL, n *html.Node) *PyPIFile {
+	f := &PyPIFile{}
+	for _, attr := range n.Attr {
+		switch attr.Key {
+		case "href":
+			u, err := pageURL.Parse(attr.Val)
+			if err != nil {
+				return nil
+			}
+			if hash, ok := strings.CutPrefix(u.Fragment, "sha256="); ok {
+				f.SHA256 = strings.ToLower(hash)
+			}
+			u.Fragment = ""
+			f.URL = u.String()
+			f.Filename = path.Base(u.Path)
+		case "data-requires-python":
+			f.RequiresPython = attr.Val
+		}
+	}
+
+	f.Version = pypiVersionFromFilename(f.Filename)
+	if f.URL == "" || f.Version == "" {
+		return nil
+	}
+	return f
+}
+
+// pypiVersionFromFilename extracts the version from the file name of a wheel or a source distribution
+// https://packaging.python.org/en/latest/specifications/binary-distribution-format/#file-name-convention
+// https://packaging.python.org/en/latest/specifications/source-distribution-format/#source-distribution-file-name
+func pypiVersionFromFilename(filename string) string {
+	if name, ok := strings.CutSuffix(filename, ".whl"); ok {
+		parts := strings.Split(name, "-")
+		if len(parts) < 5 {
+			return ""
+		}
+		return parts[1]
+	}
+
+	for _, ext := range []string{".tar.gz", ".tar.bz2", ".tgz", ".zip"} {
+		if name, ok := strings.CutSuffix(filename, ext); ok {
+			// the version of a source distribution can't contain "-", the name of old ones could
+			pos := strings.LastIndex(name, "-")
+			if pos <= 0 {
+				return ""
+			}
+			return name[pos+1:]
+		}
+	}
+	return ""
+}
+
+// PyPICachePackageFile fetches the file of the package version from the upstream and caches it
+func (r *Remote) PyPICachePackageFile(ctx context.Context, packageName, packageVersion, filename string) (*packages_model.PackageFile, error) {
+	files, err := r.PyPIPackageFiles(ctx, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var file *PyPIFile
+	for _, f := range files {
+		if f.Filename == filename && strings.EqualFold(f.Version, packageVersion) {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return nil, ErrUpstreamNotExist
+	}
+
+	buf, err := r.DownloadFile(ctx, file.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	if file.SHA256 != "" {
+		_, _, hashSHA256, _ := buf.Sums()
+		if file.SHA256 != hex.EncodeToString(hashSHA256) {
+			return nil, ErrHashMismatch
+		}
+	}
+
+	_, pf, err := r.CacheFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Name:    packageName,
+				Version: file.Version,
+			},
+			Metadata: &pypi_module.Metadata{
+				RequiresPython: file.RequiresPython,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: file.Filename,
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	return pf, err
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/hostmatcher"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ErrUpstreamNotExist is returned if the requested file doesn't exist in the upstream
+var ErrUpstreamNotExist = util.NewNotExistErrorf("file does not exist in the upstream")
+
+// ErrHashMismatch is returned if the file downloaded from the upstream doesn't match the hash listed by the upstream
+var ErrHashMismatch = util.NewInvalidArgumentErrorf("file of the upstream does not match the expected hash")
+
+var (
+	httpClient     *http.Client
+	httpClientOnce sync.Once
+)
+
+func getHTTPClient() *http.Client {
+	httpClientOnce.Do(func() {
+		allowedHostListValue := setting.Packages.RemoteAllowedHostList
+		if allowedHostListValue == "" {
+			allowedHostListValue = hostmatcher.MatchBuiltinExternal
+		}
+		allowedHostMatcher := hostmatcher.ParseHostMatchList("packages.REMOTE_ALLOWED_HOST_LIST", allowedHostListValue)
+
+		httpClient = &http.Client{
+			Timeout: time.Duration(setting.Packages.RemoteTimeout) * time.Second,
+			Transport: &http.Transport{
+				Proxy:       proxy.Proxy(),
+				DialContext: hostmatcher.NewDialContext("packages remote", allowedHostMatcher, nil, setting.Proxy.ProxyURLFixed),
+			},
+		}
+	})
+	return httpClient
+}
+
+// Remote is a configured upstream registry of an owner and package type
+type Remote struct {
+	*packages_model.PackageRemote
+	Owner *user_model.User
+
+	baseURL  *url.URL
+	password string
+	token    string // the bearer token returned by the token endpoint of the upstream
+}
+
+// GetRemote returns the enabled remote of the owner for the package type
+func GetRemote(ctx context.Context, owner *user_model.User, packageType packages_model.Type) (*Remote, error) {
+	if !packages_model.IsRemoteSupported(packageType) {
+		return nil, packages_model.ErrPackageRemoteNotExist
+	}
+
+	pr, err := packages_model.GetEnabledRemote(ctx, owner.ID, packageType)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemote(pr, owner)
+}
+
+// NewRemote creates a Remote for the configuration of the owner
+func NewRemote(pr *packages_model.PackageRemote, owner *user_model.User) (*Remote, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(pr.URL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote url: %w", err)
+	}
+	password, err := pr.Password()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt remote password: %w", err)
+	}
+	return &Remote{
+		PackageRemote: pr,
+		Owner:         owner,
+		baseURL:       baseURL,
+		password:      password,
+	}, nil
+}
+
+// ResolveURL returns the absolute url of the path or url of the upstream
+func (r *Remote) ResolveURL(p string) (*url.URL, error) {
+	u, err := url.Parse(p)
+	if err != nil {
+		return nil, err
+	}
+	if u.IsAbs() {
+		return u, nil
+	}
+	resolved := *r.baseURL
+	resolved.Path = r.baseURL.Path + "/" + strings.TrimPrefix(u.Path, "/")
+	resolved.RawPath = ""
+	resolved.RawQuery = u.RawQuery
+	return &resolved, nil
+}
+
+// Get requests the path (or absolute url) from the upstream. The caller must close the body of the response.
+// ErrUpstreamNotExist is returned if the upstream responds with 404.
+func (r *Remote) Get(ctx context.Context, p string, accept ...string) (*http.Response, error) {
+	return r.Do(ctx, http.MethodGet, p, accept...)
+}
+
+// Do sends a request without body to the upstream, the credentials of the remote are used to authenticate
+func (r *Remote) Do(ctx context.Context, method, p string, accept ...string) (*http.Response, error) {
+	u, err := r.ResolveURL(p)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.send(ctx, method, u, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && r.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		if err := r.requestToken(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = r.send(ctx, method, u, accept); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrUpstreamNotExist
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("upstream responded with status %d for %s", resp.StatusCode, u.Redacted())
+	}
+	return resp, nil
+}
+
+func (r *Remote) send(ctx context.Context, method string, u *url.URL, accept []string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range accept {
+		req.Header.Add("Accept", a)
+	}
+	req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+
+	// only send the credentials to the host of the upstream, files could be served by other hosts
+	if u.Host == r.baseURL.Host {
+		if r.token != "" {
+			req.Header.Set("Authorization", "Bearer "+r.token)
+		} else if r.Username != "" || r.password != "" {
+			req.SetBasicAuth(r.Username, r.password)
+		}
+	}
+
+	return getHTTPClient().Do(req)
+}
+
+// requestToken gets a bearer token from the endpoint of the challenge like the container registries do
+// https://distribution.github.io/distribution/spec/auth/token/
+func (r *Remote) requestToken(ctx context.Context, challenge string) error {
+	scheme, params, ok := strings.Cut(challenge, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return util.NewPermissionDeniedErrorf("upstream requires unsupported authentication")
+	}
+
+	values := parseChallengeParams(params)
+	realm := values["realm"]
+	if realm == "" {
+		return util.NewPermissionDeniedErrorf("upstream requires authentication without realm")
+	}
+
+	u, err := url.Parse(realm)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	for _, key := range []string{"service", "scope"} {
+		if values[key] != "" {
+			q.Set(key, values[key])
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	if r.Username != "" || r.password != "" {
+		req.SetBasicAuth(r.Username, r.password)
+	}
+
+	resp, err := getHTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return util.NewPermissionDeniedErrorf("upstream token endpoint responded with status %d", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return err
+	}
+
+	r.token = util.IfZero(token.Token, token.AccessToken)
+	if r.token == "" {
+		return util.NewPermissionDeniedErrorf("upstream token endpoint returned no token")
+	}
+	return nil
+}
+
+// parseChallengeParams parses the comma separated key="value" pairs of a WWW-Authenticate header
+func parseChallengeParams(s string) map[string]string {
+	values := make(map[string]string)
+	for s != "" {
+		s = strings.TrimLeft(s, ", ")
+		key, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			break
+		}
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				break
+			}
+			value, s = rest[1:end+1], rest[end+2:]
+		} else {
+			value, s, _ = strings.Cut(rest, ",")
+		}
+		values[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return values
+}
+
+// IsUpstreamError returns true if the error is caused by the upstream and the cached content could be used instead
+func IsUpstreamError(err error) bool {
+	return err != nil && !errors.Is(err, ErrUpstreamNotExist) && !errors.Is(err, context.Canceled)
+}
+
+func logUpstreamError(r *Remote, p string, err error) {
+	log.Warn("Unable to fetch %s from the upstream %s of package remote %d: %v", p, r.URL, r.ID, err)
+}
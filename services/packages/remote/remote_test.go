@@ -0,0 +1,256 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	packages_service "code.gitea.io/gitea/services/packages"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRemote(t *testing.T, packageType packages_model.Type, upstreamURL string, ttl int64) *Remote {
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	r, err := NewRemote(&packages_model.PackageRemote{
+		Enabled:     true,
+		OwnerID:     owner.ID,
+		Type:        packageType,
+		URL:         upstreamURL,
+		MetadataTTL: ttl,
+	}, owner)
+	require.NoError(t, err)
+	return r
+}
+
+func TestGetMetadata(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	var requests atomic.Int32
+	var fail atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		switch req.URL.Path {
+		case "/base/metadata":
+			fmt.Fprintf(w, "content %d", requests.Load())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	t.Run("TTL", func(t *testing.T) {
+		r := newTestRemote(t, packages_model.TypeNpm, upstream.URL+"/base/", 3600)
+
+		content, err := r.GetMetadata(t.Context(), "/metadata")
+		require.NoError(t, err)
+		assert.Equal(t, "content 1", string(content))
+
+		content, err = r.GetMetadata(t.Context(), "/metadata")
+		require.NoError(t, err)
+		assert.Equal(t, "content 1", string(content))
+		assert.EqualValues(t, 1, requests.Load())
+
+		r.MetadataTTL = 0
+
+		content, err = r.GetMetadata(t.Context(), "/metadata")
+		require.NoError(t, err)
+		assert.Equal(t, "content 2", string(content))
+		assert.EqualValues(t, 2, requests.Load())
+	})
+
+	t.Run("NotExist", func(t *testing.T) {
+		r := newTestRemote(t, packages_model.TypeNpm, upstream.URL+"/base", 0)
+
+		_, err := r.GetMetadata(t.Context(), "/missing")
+		assert.ErrorIs(t, err, ErrUpstreamNotExist)
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		fail.Store(true)
+		defer fail.Store(false)
+
+		r := newTestRemote(t, packages_model.TypeNpm, upstream.URL+"/base", 0)
+
+		content, err := r.GetMetadata(t.Context(), "/metadata")
+		require.NoError(t, err)
+		assert.Equal(t, "content 2", string(content))
+
+		_, err = r.GetMetadata(t.Context(), "/other")
+		assert.Error(t, err)
+	})
+}
+
+func TestBearerToken(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	var upstreamURL string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/token":
+			user, pass, _ := req.BasicAuth()
+			if user != "user" || pass != "secret" || req.URL.Query().Get("scope") != "repository:test:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"token":"abc"}`)
+		case "/v2/test/manifests/latest", "/v2/test/manifests/other":
+			if req.Header.Get("Authorization") != "Bearer abc" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:test:pull"`, upstreamURL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+	upstreamURL = upstream.URL
+
+	r := newTestRemote(t, packages_model.TypeContainer, upstream.URL, 0)
+	r.Username = "user"
+	r.password = "secret"
+
+	content, err := r.ContainerManifest(t.Context(), "test", "latest")
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(content))
+	assert.Equal(t, "abc", r.token)
+
+	r = newTestRemote(t, packages_model.TypeContainer, upstream.URL, 0)
+
+	_, err = r.ContainerManifest(t.Context(), "test", "other")
+	assert.Error(t, err)
+}
+
+func TestNpmCachePackageFile(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	packageName := "@scope/test-package"
+	tarball := []byte("tarball content")
+	hash := sha512.Sum512(tarball)
+	integrity := "sha512-" + base64.StdEncoding.EncodeToString(hash[:])
+
+	var upstreamURL string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/@scope/test-package":
+			fmt.Fprintf(w, `{"name":"%[1]s","versions":{"1.0.0":{"name":"%[1]s","version":"1.0.0","dist":{"integrity":"%[2]s","tarball":"%[3]s/files/test-package-1.0.0.tgz"}},"1.0.1":{"name":"%[1]s","version":"1.0.1","dist":{"integrity":"sha512-invalid","tarball":"%[3]s/files/test-package-1.0.1.tgz"}}}}`, packageName, integrity, upstreamURL)
+		case "/files/test-package-1.0.0.tgz", "/files/test-package-1.0.1.tgz":
+			_, _ = w.Write(tarball)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+	upstreamURL = upstream.URL
+
+	r := newTestRemote(t, packages_model.TypeNpm, upstream.URL, 3600)
+
+	doc, err := r.NpmPackageMetadata(t.Context(), packageName, "https://gitea.example/api/packages/user2/npm")
+	require.NoError(t, err)
+	dist := doc["versions"].(map[string]any)["1.0.0"].(map[string]any)["dist"].(map[string]any)
+	assert.Equal(t, "https://gitea.example/api/packages/user2/npm/%40scope%2Ftest-package/-/1.0.0/test-package-1.0.0.tgz", dist["tarball"])
+
+	packageVersion, err := r.NpmFindVersionByFilename(t.Context(), packageName, "test-package-1.0.0.tgz")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", packageVersion)
+
+	local, err := IsLocalPackage(t.Context(), r.OwnerID, packages_model.TypeNpm, packageName)
+	require.NoError(t, err)
+	assert.False(t, local)
+
+	pf, err := r.NpmCachePackageFile(t.Context(), packageName, "1.0.0", "test-package-1.0.0.tgz")
+	require.NoError(t, err)
+	assert.Equal(t, "test-package-1.0.0.tgz", pf.Name)
+	assert.True(t, pf.IsLead)
+
+	p, err := packages_model.GetPackageByName(t.Context(), r.OwnerID, packages_model.TypeNpm, packageName)
+	require.NoError(t, err)
+	cached, err := IsCachedPackage(t.Context(), p)
+	require.NoError(t, err)
+	assert.True(t, cached)
+
+	local, err = IsLocalPackage(t.Context(), r.OwnerID, packages_model.TypeNpm, packageName)
+	require.NoError(t, err)
+	assert.False(t, local)
+
+	_, err = r.NpmCachePackageFile(t.Context(), packageName, "1.0.1", "test-package-1.0.1.tgz")
+	assert.Error(t, err)
+
+	_, err = r.NpmCachePackageFile(t.Context(), packageName, "2.0.0", "test-package-2.0.0.tgz")
+	assert.ErrorIs(t, err, ErrUpstreamNotExist)
+}
+
+func TestDownloadFileSizeLimit(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	content := []byte("0123456789")
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/chunked" {
+			// the length of a chunked response is unknown until it has been read
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write(content)
+	}))
+	defer upstream.Close()
+
+	r := newTestRemote(t, packages_model.TypeMaven, upstream.URL, 3600)
+
+	buf, err := r.DownloadFile(t.Context(), "/file")
+	require.NoError(t, err)
+	assert.EqualValues(t, len(content), buf.Size())
+	buf.Close()
+
+	defer test.MockVariableValue(&setting.Packages.LimitSizeMaven, int64(len(content)-1))()
+	for _, p := range []string{"/file", "/chunked"} {
+		_, err = r.DownloadFile(t.Context(), p)
+		assert.ErrorIs(t, err, packages_service.ErrQuotaTypeSize, p)
+	}
+
+	defer test.MockVariableValue(&setting.Packages.LimitSizeMaven, int64(-1))()
+	defer test.MockVariableValue(&setting.Packages.LimitTotalOwnerSize, int64(len(content)-1))()
+	_, err = r.DownloadFile(t.Context(), "/chunked")
+	assert.ErrorIs(t, err, packages_service.ErrQuotaTotalSize)
+}
+
+func TestPyPIVersionFromFilename(t *testing.T) {
+	cases := map[string]string{
+		"test_package-1.0.0-py3-none-any.whl":     "1.0.0",
+		"test-package-1.0.0.tar.gz":               "1.0.0",
+		"test_package-2.0rc1-cp312-cp312-win.whl": "2.0rc1",
+		"test.zip":         "",
+		"test-package.whl": "",
+		"README.md":        "",
+	}
+	for filename, expected := range cases {
+		assert.Equal(t, expected, pypiVersionFromFilename(filename), filename)
+	}
+}
+
+func TestParseChallengeParams(t *testing.T) {
+	values := parseChallengeParams(`realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/alpine:pull"`)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/alpine:pull",
+	}, values)
+}
@@ -10,7 +10,6 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/xml"
-	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -22,12 +21,10 @@ import (
 	"code.gitea.io/gitea/modules/json"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	rpm_module "code.gitea.io/gitea/modules/packages/rpm"
-	"code.gitea.io/gitea/modules/util"
 	packages_service "code.gitea.io/gitea/services/packages"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/ProtonMail/go-crypto/openpgp/armor"
-	"github.com/ProtonMail/go-crypto/openpgp/packet"
 )
 
 // GetOrCreateRepositoryVersion gets or creates the internal repository package
@@ -36,34 +33,23 @@ func GetOrCreateRepositoryVersion(ctx context.Context, ownerID int64) (*packages
 	return packages_service.GetOrCreateInternalPackageVersion(ctx, ownerID, packages_model.TypeRpm, rpm_module.RepositoryPackage, rpm_module.RepositoryVersion)
 }
 
+// SigningKeySettings are the settings which store the PGP keys used to sign repository metadata files
+var SigningKeySettings = &packages_service.SigningKeySettings{
+	Private:         rpm_module.SettingKeyPrivate,
+	Public:          rpm_module.SettingKeyPublic,
+	PreviousPrivate: rpm_module.SettingPreviousKeyPrivate,
+	PreviousPublic:  rpm_module.SettingPreviousKeyPublic,
+	PreviousExpires: rpm_module.SettingPreviousKeyExpires,
+	Generate:        generateKeypair,
+}
+
 // GetOrCreateKeyPair gets or creates the PGP keys used to sign repository metadata files
 func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
-	priv, err := user_model.GetSetting(ctx, ownerID, rpm_module.SettingKeyPrivate)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
-		return "", "", err
-	}
-
-	pub, err := user_model.GetSetting(ctx, ownerID, rpm_module.SettingKeyPublic)
-	if err != nil && !errors.Is(err, util.ErrNotExist) {
+	kp, err := packages_service.GetOrCreateSigningKeyPair(ctx, ownerID, SigningKeySettings)
+	if err != nil {
 		return "", "", err
 	}
-
-	if priv == "" || pub == "" {
-		priv, pub, err = generateKeypair()
-		if err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, rpm_module.SettingKeyPrivate, priv); err != nil {
-			return "", "", err
-		}
-
-		if err := user_model.SetUserSetting(ctx, ownerID, rpm_module.SettingKeyPublic, pub); err != nil {
-			return "", "", err
-		}
-	}
-
-	return priv, pub, nil
+	return kp.Private, kp.Public, nil
 }
 
 func generateKeypair() (string, string, error) {
@@ -170,6 +156,7 @@ func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, group stri
 		PackageType:  packages_model.TypeRpm,
 		Query:        "%.rpm",
 		CompositeKey: group,
+		HidePrivate:  true, // private packages are left out of the signed repository files
 	})
 	if err != nil {
 		return err
@@ -273,25 +260,25 @@ func buildRepomd(ctx context.Context, pv *packages_model.PackageVersion, ownerID
 		return err
 	}
 
-	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
-	if err != nil {
-		return err
-	}
-
-	block, err := armor.Decode(strings.NewReader(priv))
+	keys, err := packages_service.GetOrCreateSigningKeys(ctx, ownerID, SigningKeySettings)
 	if err != nil {
 		return err
 	}
 
-	e, err := openpgp.ReadEntity(packet.NewReader(block.Body))
-	if err != nil {
-		return err
+	// During a key rotation the metadata is signed with the current and the previous key
+	entities := make([]*openpgp.Entity, 0, 2)
+	for _, kp := range keys.All() {
+		e, err := packages_service.ReadPGPEntity(kp.Private)
+		if err != nil {
+			return err
+		}
+		entities = append(entities, e)
 	}
 
 	repomdAscContent, _ := packages_module.NewHashedBuffer()
 	defer repomdAscContent.Close()
 
-	if err := openpgp.ArmoredDetachSign(repomdAscContent, e, bytes.NewReader(buf.Bytes()), nil); err != nil {
+	if err := packages_service.ArmoredDetachSignMulti(repomdAscContent, entities, buf.Bytes()); err != nil {
 		return err
 	}
 
@@ -0,0 +1,233 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/packages/cargo"
+	"code.gitea.io/gitea/modules/packages/composer"
+	"code.gitea.io/gitea/modules/packages/maven"
+	"code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/packages/osv"
+	"code.gitea.io/gitea/modules/packages/pypi"
+	sbom_module "code.gitea.io/gitea/modules/packages/sbom"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnsupportedType = util.NewInvalidArgumentErrorf("software bills of materials are not supported for this package type")
+
+// SupportedTypes are the package types whose dependencies can be extracted
+var SupportedTypes = []packages_model.Type{
+	packages_model.TypeCargo,
+	packages_model.TypeComposer,
+	packages_model.TypeMaven,
+	packages_model.TypeNpm,
+	packages_model.TypePyPI,
+}
+
+// IsSupportedType checks if the dependencies of the package type can be extracted
+func IsSupportedType(t packages_model.Type) bool {
+	for _, st := range SupportedTypes {
+		if st == t {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildDocument creates the software bill of materials of the package version from its stored metadata
+func BuildDocument(ctx context.Context, pd *packages_model.PackageDescriptor) (*sbom_module.Document, error) {
+	var root *sbom_module.Component
+	var deps []*sbom_module.Component
+
+	switch m := pd.Metadata.(type) {
+	case *cargo.Metadata:
+		root = newComponent(osv.EcosystemCargo, "cargo", "", pd.Package.Name, pd.Version.Version, m.License)
+		deps = extractCargo(m)
+	case *composer.Metadata:
+		vendor, name, _ := strings.Cut(pd.Package.Name, "/")
+		root = newComponent(osv.EcosystemComposer, "composer", vendor, name, pd.Version.Version, strings.Join(m.License, " OR "))
+		root.Name = pd.Package.Name
+		deps = extractComposer(m)
+	case *maven.Metadata:
+		root = newComponent(osv.EcosystemMaven, "maven", m.GroupID, m.ArtifactID, pd.Version.Version, strings.Join(m.Licenses, " OR "))
+		root.Name = pd.Package.Name
+		deps = extractMaven(m)
+	case *npm.Metadata:
+		root = newNpmComponent(pd.Package.Name, pd.Version.Version, "")
+		root.License = string(m.License)
+		deps = extractNpm(m)
+	case *pypi.Metadata:
+		root = newComponent(osv.EcosystemPyPI, "pypi", "", osv.NormalizeName(osv.EcosystemPyPI, pd.Package.Name), pd.Version.Version, m.License)
+		root.Name = pd.Package.Name
+		deps = extractPyPI(m)
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	return &sbom_module.Document{
+		Namespace:    pd.VersionHTMLURL(ctx) + "/sbom/" + uuid.NewString(),
+		ToolName:     "Gitea",
+		ToolVersion:  setting.AppVer,
+		Created:      time.Now(),
+		Root:         root,
+		Dependencies: deps,
+	}, nil
+}
+
+func newComponent(ecosystem, purlType, namespace, name, version, license string) *sbom_module.Component {
+	fullName := name
+	if namespace != "" {
+		fullName = namespace + ":" + name
+	}
+	return &sbom_module.Component{
+		Ecosystem: ecosystem,
+		Name:      fullName,
+		Version:   version,
+		PURL:      sbom_module.PackageURL(purlType, namespace, name, version),
+		License:   license,
+		Scope:     sbom_module.ScopeRequired,
+	}
+}
+
+func newDependency(ecosystem, purlType, namespace, name, requirement, scope string) *sbom_module.Component {
+	c := newComponent(ecosystem, purlType, namespace, name, sbom_module.MinimumVersion(requirement), "")
+	c.Requirement = requirement
+	c.Scope = scope
+	return c
+}
+
+func newNpmComponent(name, version, requirement string) *sbom_module.Component {
+	scope, unscoped, found := strings.Cut(name, "/")
+	if !found {
+		scope, unscoped = "", name
+	}
+	c := newComponent(osv.EcosystemNpm, "npm", scope, unscoped, version, "")
+	c.Name = name
+	c.Requirement = requirement
+	return c
+}
+
+// sortAndDeduplicate sorts the dependencies by name and keeps the first occurrence of a name
+func sortAndDeduplicate(deps []*sbom_module.Component) []*sbom_module.Component {
+	seen := make(map[string]bool, len(deps))
+	result := make([]*sbom_module.Component, 0, len(deps))
+	for _, d := range deps {
+		if seen[d.Name] {
+			continue
+		}
+		seen[d.Name] = true
+		result = append(result, d)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func extractCargo(m *cargo.Metadata) []*sbom_module.Component {
+	deps := make([]*sbom_module.Component, 0, len(m.Dependencies))
+	for _, d := range m.Dependencies {
+		if d.Kind == "dev" {
+			continue
+		}
+		name := d.Name
+		if d.Package != nil && *d.Package != "" {
+			// the dependency is renamed
+			name = *d.Package
+		}
+		scope := sbom_module.ScopeRequired
+		if d.Optional {
+			scope = sbom_module.ScopeOptional
+		}
+		deps = append(deps, newDependency(osv.EcosystemCargo, "cargo", "", name, d.Req, scope))
+	}
+	return sortAndDeduplicate(deps)
+}
+
+func extractComposer(m *composer.Metadata) []*sbom_module.Component {
+	deps := make([]*sbom_module.Component, 0, len(m.Require))
+	for name, requirement := range m.Require {
+		vendor, project, found := strings.Cut(name, "/")
+		if !found {
+			// platform packages like php or ext-json
+			continue
+		}
+		d := newDependency(osv.EcosystemComposer, "composer", vendor, project, requirement, sbom_module.ScopeRequired)
+		d.Name = name
+		deps = append(deps, d)
+	}
+	return sortAndDeduplicate(deps)
+}
+
+func extractMaven(m *maven.Metadata) []*sbom_module.Component {
+	deps := make([]*sbom_module.Component, 0, len(m.Dependencies))
+	for _, d := range m.Dependencies {
+		if d.GroupID == "" || d.ArtifactID == "" {
+			continue
+		}
+		deps = append(deps, newDependency(osv.EcosystemMaven, "maven", d.GroupID, d.ArtifactID, d.Version, sbom_module.ScopeRequired))
+	}
+	return sortAndDeduplicate(deps)
+}
+
+func extractNpm(m *npm.Metadata) []*sbom_module.Component {
+	deps := make([]*sbom_module.Component, 0, len(m.Dependencies)+len(m.PeerDependencies)+len(m.OptionalDependencies))
+	add := func(dependencies map[string]string, scope string) {
+		for name, requirement := range dependencies {
+			d := newNpmComponent(name, sbom_module.MinimumVersion(requirement), requirement)
+			d.Scope = scope
+			deps = append(deps, d)
+		}
+	}
+	add(m.Dependencies, sbom_module.ScopeRequired)
+	add(m.PeerDependencies, sbom_module.ScopeRequired)
+	add(m.OptionalDependencies, sbom_module.ScopeOptional)
+	return sortAndDeduplicate(deps)
+}
+
+// https://packaging.python.org/en/latest/specifications/dependency-specifiers/
+var pypiRequirementPattern = regexp.MustCompile(`\A\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*\(?([^;()]*)\)?\s*(?:;(.*))?\z`)
+
+func extractPyPI(m *pypi.Metadata) []*sbom_module.Component {
+	deps := make([]*sbom_module.Component, 0, len(m.RequiresDist))
+	for _, requirement := range m.RequiresDist {
+		match := pypiRequirementPattern.FindStringSubmatch(requirement)
+		if match == nil {
+			continue
+		}
+		scope := sbom_module.ScopeRequired
+		if strings.Contains(match[3], "extra") {
+			scope = sbom_module.ScopeOptional
+		}
+		d := newDependency(osv.EcosystemPyPI, "pypi", "", osv.NormalizeName(osv.EcosystemPyPI, match[1]), strings.TrimSpace(match[2]), scope)
+		d.Name = match[1]
+		deps = append(deps, d)
+	}
+	return sortAndDeduplicate(deps)
+}
+
+// EncodeDocument creates the software bill of materials of the package version in the format
+func EncodeDocument(ctx context.Context, pd *packages_model.PackageDescriptor, format string) ([]byte, error) {
+	doc, err := BuildDocument(ctx, pd)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Encode(format)
+}
+
+// Filename returns the file name of the software bill of materials of the package version
+func Filename(pd *packages_model.PackageDescriptor, format string) string {
+	name := strings.NewReplacer("/", "-", ":", "-", "@", "").Replace(pd.Package.Name)
+	return name + "-" + pd.Version.Version + sbom_module.FileExtension(format)
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/packages/osv"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ImportDatabase imports the advisories of an OSV database file.
+// Only advisories of the supported ecosystems are stored, withdrawn advisories are removed.
+// It returns the number of imported advisories.
+func ImportDatabase(ctx context.Context, r io.ReaderAt, size int64) (int, error) {
+	count := 0
+	err := osv.ReadDatabase(r, size, func(v *osv.Vulnerability) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		pas, err := toAdvisories(v)
+		if err != nil {
+			return err
+		}
+		if err := packages_model.ReplaceAdvisory(ctx, v.ID, pas); err != nil {
+			return err
+		}
+		if len(pas) > 0 {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+var supportedEcosystems = map[string]bool{
+	osv.EcosystemCargo:    true,
+	osv.EcosystemComposer: true,
+	osv.EcosystemMaven:    true,
+	osv.EcosystemNpm:      true,
+	osv.EcosystemPyPI:     true,
+}
+
+func toAdvisories(v *osv.Vulnerability) ([]*packages_model.PackageAdvisory, error) {
+	if v.Withdrawn != nil {
+		return nil, nil
+	}
+
+	// an advisory can contain several entries for a package
+	affectedByPackage := make(map[[2]string][]*osv.Affected)
+	keys := make([][2]string, 0, len(v.Affected))
+	for _, a := range v.Affected {
+		if !supportedEcosystems[a.Package.Ecosystem] {
+			continue
+		}
+		key := [2]string{a.Package.Ecosystem, osv.NormalizeName(a.Package.Ecosystem, a.Package.Name)}
+		if _, has := affectedByPackage[key]; !has {
+			keys = append(keys, key)
+		}
+		affectedByPackage[key] = append(affectedByPackage[key], a)
+	}
+
+	pas := make([]*packages_model.PackageAdvisory, 0, len(keys))
+	for _, key := range keys {
+		affected, err := json.Marshal(affectedByPackage[key])
+		if err != nil {
+			return nil, err
+		}
+
+		summary := v.Summary
+		if summary == "" && len(v.Aliases) > 0 {
+			summary = v.Aliases[0]
+		}
+
+		pas = append(pas, &packages_model.PackageAdvisory{
+			AdvisoryID:   v.ID,
+			Ecosystem:    key[0],
+			PackageName:  key[1],
+			Summary:      summary,
+			Severity:     v.Severity(),
+			Affected:     string(affected),
+			ModifiedUnix: timeutil.TimeStamp(v.Modified.Unix()),
+		})
+	}
+	return pas, nil
+}
+
+// ScanPackageVersion matches the dependencies of the package version against the imported advisories and stores the findings
+func ScanPackageVersion(ctx context.Context, pd *packages_model.PackageDescriptor) error {
+	doc, err := BuildDocument(ctx, pd)
+	if err != nil {
+		return err
+	}
+
+	var findings []*packages_model.PackageVulnerability
+	for _, dep := range doc.Dependencies {
+		if dep.Version == "" {
+			continue
+		}
+
+		pas, err := packages_model.GetAdvisoriesByPackage(ctx, dep.Ecosystem, osv.NormalizeName(dep.Ecosystem, dep.Name))
+		if err != nil {
+			return err
+		}
+
+		for _, pa := range pas {
+			var affected []*osv.Affected
+			if err := json.Unmarshal([]byte(pa.Affected), &affected); err != nil {
+				log.Error("Invalid affected versions of advisory %s: %v", pa.AdvisoryID, err)
+				continue
+			}
+
+			for _, a := range affected {
+				if !a.IsAffected(dep.Version) {
+					continue
+				}
+				findings = append(findings, &packages_model.PackageVulnerability{
+					AdvisoryID:        pa.AdvisoryID,
+					DependencyName:    dep.Name,
+					DependencyVersion: dep.Version,
+					FixedVersion:      a.FixedVersion(dep.Version),
+					Summary:           pa.Summary,
+					Severity:          pa.Severity,
+				})
+				break
+			}
+		}
+	}
+
+	return packages_model.ReplaceVulnerabilities(ctx, pd.Version.ID, findings)
+}
+
+// ScanPackages scans all package versions of the supported types
+func ScanPackages(ctx context.Context) error {
+	for _, packageType := range SupportedTypes {
+		for page := 1; ; page++ {
+			pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+				Type:       packageType,
+				IsInternal: optional.Some(false),
+				Sort:       packages_model.SortCreatedDesc,
+				Paginator:  &db.ListOptions{Page: page, PageSize: 200},
+			})
+			if err != nil {
+				return fmt.Errorf("SearchVersions failed: %w", err)
+			}
+			if len(pvs) == 0 {
+				break
+			}
+
+			for _, pv := range pvs {
+				select {
+				case <-ctx.Done():
+					return db.ErrCancelledf("while scanning packages")
+				default:
+				}
+
+				pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+				if err != nil {
+					return fmt.Errorf("GetPackageDescriptor[%d] failed: %w", pv.ID, err)
+				}
+				if err := ScanPackageVersion(ctx, pd); err != nil {
+					return fmt.Errorf("ScanPackageVersion[%d] failed: %w", pv.ID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package signing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	alpine_module "code.gitea.io/gitea/modules/packages/alpine"
+	debian_module "code.gitea.io/gitea/modules/packages/debian"
+	rpm_module "code.gitea.io/gitea/modules/packages/rpm"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+	alpine_service "code.gitea.io/gitea/services/packages/alpine"
+	arch_service "code.gitea.io/gitea/services/packages/arch"
+	debian_service "code.gitea.io/gitea/services/packages/debian"
+	rpm_service "code.gitea.io/gitea/services/packages/rpm"
+)
+
+// registry describes a package registry which signs its repository files
+type registry struct {
+	Settings          *packages_service.SigningKeySettings
+	RepositoryPackage string
+	// ParsePrivate validates an imported private key and returns the private and the public key in the stored format
+	ParsePrivate func(string) (string, string, error)
+	Fingerprint  func(string) (string, error)
+	// BuildAllRepositoryFiles signs the repository files again
+	BuildAllRepositoryFiles func(context.Context, int64) error
+}
+
+var registries = map[packages_model.Type]*registry{
+	packages_model.TypeAlpine: {
+		Settings:                alpine_service.SigningKeySettings,
+		RepositoryPackage:       alpine_module.RepositoryPackage,
+		ParsePrivate:            packages_service.ParseRSAPrivateKey,
+		Fingerprint:             packages_service.RSAKeyFingerprint,
+		BuildAllRepositoryFiles: alpine_service.BuildAllRepositoryFiles,
+	},
+	packages_model.TypeDebian: {
+		Settings:                debian_service.SigningKeySettings,
+		RepositoryPackage:       debian_module.RepositoryPackage,
+		ParsePrivate:            packages_service.ParsePGPPrivateKey,
+		Fingerprint:             packages_service.PGPKeyFingerprint,
+		BuildAllRepositoryFiles: debian_service.BuildAllRepositoryFiles,
+	},
+	packages_model.TypeRpm: {
+		Settings:                rpm_service.SigningKeySettings,
+		RepositoryPackage:       rpm_module.RepositoryPackage,
+		ParsePrivate:            packages_service.ParsePGPPrivateKey,
+		Fingerprint:             packages_service.PGPKeyFingerprint,
+		BuildAllRepositoryFiles: rpm_service.BuildAllRepositoryFiles,
+	},
+}
+
+// Types are the package types with signed repository files
+var Types = []packages_model.Type{packages_model.TypeAlpine, packages_model.TypeDebian, packages_model.TypeRpm}
+
+func getRegistry(packageType packages_model.Type) (*registry, error) {
+	r, ok := registries[packageType]
+	if !ok {
+		return nil, util.NewInvalidArgumentErrorf("package type %q doesn't sign its repository files", packageType)
+	}
+	return r, nil
+}
+
+// BuildRepositoryFiles builds the repository files of the owner again if the package type has signed repository files.
+// The indices only list public packages, they must be rebuilt if the visibility of a package changes.
+func BuildRepositoryFiles(ctx context.Context, ownerID int64, packageType packages_model.Type) error {
+	// the arch registry has its own keys which aren't managed here
+	if packageType == packages_model.TypeArch {
+		release, err := arch_service.AcquireRegistryLock(ctx, ownerID)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return arch_service.BuildAllRepositoryFiles(ctx, ownerID)
+	}
+
+	r, ok := registries[packageType]
+	if !ok {
+		return nil
+	}
+	return r.BuildAllRepositoryFiles(ctx, ownerID)
+}
+
+// KeyInfo describes the signing keys of a registry
+type KeyInfo struct {
+	Type                packages_model.Type
+	Fingerprint         string // empty if the key is created with the first package
+	PreviousFingerprint string // empty if there is no rotation in progress
+	PreviousExpires     timeutil.TimeStamp
+}
+
+// GetKeyInfos returns the signing keys of the owner for all registries with signed repository files
+func GetKeyInfos(ctx context.Context, ownerID int64) ([]*KeyInfo, error) {
+	infos := make([]*KeyInfo, 0, len(Types))
+	for _, packageType := range Types {
+		r := registries[packageType]
+
+		keys, err := packages_service.GetSigningKeys(ctx, ownerID, r.Settings)
+		if err != nil {
+			return nil, err
+		}
+
+		info := &KeyInfo{Type: packageType}
+		if keys.Current != nil {
+			if info.Fingerprint, err = r.Fingerprint(keys.Current.Public); err != nil {
+				return nil, err
+			}
+		}
+		if keys.Previous != nil {
+			if info.PreviousFingerprint, err = r.Fingerprint(keys.Previous.Public); err != nil {
+				return nil, err
+			}
+			info.PreviousExpires = keys.PreviousExpires
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// ImportKey replaces the signing key of the registry with the private key and signs the repository files again.
+// The replaced key keeps signing the files until the overlap period ends.
+func ImportKey(ctx context.Context, ownerID int64, packageType packages_model.Type, privateKey string, overlap time.Duration) error {
+	r, err := getRegistry(packageType)
+	if err != nil {
+		return err
+	}
+
+	priv, pub, err := r.ParsePrivate(privateKey)
+	if err != nil {
+		return err
+	}
+
+	return replaceKey(ctx, ownerID, packageType, r, &packages_service.SigningKeyPair{Private: priv, Public: pub}, overlap)
+}
+
+// RotateKey replaces the signing key of the registry with a new generated key and signs the repository files again.
+// The replaced key keeps signing the files until the overlap period ends.
+func RotateKey(ctx context.Context, ownerID int64, packageType packages_model.Type, overlap time.Duration) error {
+	r, err := getRegistry(packageType)
+	if err != nil {
+		return err
+	}
+
+	priv, pub, err := r.Settings.Generate()
+	if err != nil {
+		return err
+	}
+
+	return replaceKey(ctx, ownerID, packageType, r, &packages_service.SigningKeyPair{Private: priv, Public: pub}, overlap)
+}
+
+func replaceKey(ctx context.Context, ownerID int64, packageType packages_model.Type, r *registry, kp *packages_service.SigningKeyPair, overlap time.Duration) error {
+	if err := packages_service.ReplaceSigningKeyPair(ctx, ownerID, r.Settings, kp, overlap); err != nil {
+		return err
+	}
+
+	// Don't create the repository files if the owner has no packages yet
+	ownerIDs, err := getRepositoryOwnerIDs(ctx, ownerID, packageType)
+	if err != nil || len(ownerIDs) == 0 {
+		return err
+	}
+	return r.BuildAllRepositoryFiles(ctx, ownerID)
+}
+
+// getRepositoryOwnerIDs gets the owners with repository files, all owners if ownerID is 0
+func getRepositoryOwnerIDs(ctx context.Context, ownerID int64, packageType packages_model.Type) ([]int64, error) {
+	r := registries[packageType]
+
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID: ownerID,
+		Type:    packageType,
+		Name: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      r.RepositoryPackage,
+		},
+		IsInternal: optional.Some(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ownerIDs := make([]int64, 0, len(pvs))
+	for _, pv := range pvs {
+		p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+		if err != nil {
+			return nil, err
+		}
+		ownerIDs = append(ownerIDs, p.OwnerID)
+	}
+	return ownerIDs, nil
+}
+
+// ResignOptions are the options of ResignRepositoryFiles
+type ResignOptions struct {
+	OwnerID   int64                 // all owners if 0
+	Types     []packages_model.Type // all types with signed repository files if empty
+	LogDetail func(format string, v ...any)
+}
+
+// ResignRepositoryFiles rebuilds and signs the repository files with the current keys
+func ResignRepositoryFiles(ctx context.Context, opts ResignOptions) error {
+	if opts.LogDetail == nil {
+		opts.LogDetail = log.Debug
+	}
+
+	types := opts.Types
+	if len(types) == 0 {
+		types = Types
+	}
+
+	for _, packageType := range types {
+		r, err := getRegistry(packageType)
+		if err != nil {
+			return err
+		}
+
+		ownerIDs, err := getRepositoryOwnerIDs(ctx, opts.OwnerID, packageType)
+		if err != nil {
+			return err
+		}
+		for _, ownerID := range ownerIDs {
+			if _, err := packages_service.RemoveExpiredSigningKeyPair(ctx, ownerID, r.Settings); err != nil {
+				return err
+			}
+			if err := r.BuildAllRepositoryFiles(ctx, ownerID); err != nil {
+				return fmt.Errorf("failed to sign %s repository files of owner %d: %w", packageType.Name(), ownerID, err)
+			}
+			opts.LogDetail("Signed %s repository files of owner %d", packageType.Name(), ownerID)
+		}
+	}
+	return nil
+}
+
+// RemoveExpiredKeys removes the previous keys whose overlap period has ended and signs the repository files again
+func RemoveExpiredKeys(ctx context.Context) error {
+	for _, packageType := range Types {
+		r := registries[packageType]
+
+		ownerIDs, err := getRepositoryOwnerIDs(ctx, 0, packageType)
+		if err != nil {
+			return err
+		}
+		for _, ownerID := range ownerIDs {
+			removed, err := packages_service.RemoveExpiredSigningKeyPair(ctx, ownerID, r.Settings)
+			if err != nil {
+				return err
+			}
+			if !removed {
+				continue
+			}
+			if err := r.BuildAllRepositoryFiles(ctx, ownerID); err != nil {
+				return fmt.Errorf("failed to sign %s repository files of owner %d: %w", packageType.Name(), ownerID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// SigningKeySettings are the names of the user settings which store the keys used to sign repository files.
+// After a rotation the previous key pair is kept until the overlap period ends, both keys sign the files in the meantime.
+type SigningKeySettings struct {
+	Private         string
+	Public          string
+	PreviousPrivate string
+	PreviousPublic  string
+	PreviousExpires string
+	// Generate creates a new key pair if the owner has none yet
+	Generate func() (string, string, error)
+}
+
+// SigningKeyPair is a private and public key used to sign repository files
+type SigningKeyPair struct {
+	Private string
+	Public  string
+}
+
+// SigningKeys are the current and the previous key pair of an owner
+type SigningKeys struct {
+	Current         *SigningKeyPair
+	Previous        *SigningKeyPair // nil if there is no previous key pair or the overlap period has ended
+	PreviousExpires timeutil.TimeStamp
+}
+
+// All returns the key pairs which must be used to sign the repository files
+func (keys *SigningKeys) All() []*SigningKeyPair {
+	if keys.Previous == nil {
+		return []*SigningKeyPair{keys.Current}
+	}
+	return []*SigningKeyPair{keys.Current, keys.Previous}
+}
+
+func getSetting(ctx context.Context, ownerID int64, key string) (string, error) {
+	value, err := user_model.GetSetting(ctx, ownerID, key)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", err
+	}
+	return value, nil
+}
+
+func getSigningKeyPair(ctx context.Context, ownerID int64, s *SigningKeySettings) (*SigningKeyPair, error) {
+	priv, err := getSetting(ctx, ownerID, s.Private)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := getSetting(ctx, ownerID, s.Public)
+	if err != nil {
+		return nil, err
+	}
+
+	if priv == "" || pub == "" {
+		return nil, nil
+	}
+	return &SigningKeyPair{Private: priv, Public: pub}, nil
+}
+
+// GetOrCreateSigningKeyPair gets or creates the current key pair.
+// The creation is locked per owner, so concurrent requests don't generate different keys and sign files with a key which gets replaced.
+func GetOrCreateSigningKeyPair(ctx context.Context, ownerID int64, s *SigningKeySettings) (*SigningKeyPair, error) {
+	kp, err := getSigningKeyPair(ctx, ownerID, s)
+	if err != nil || kp != nil {
+		return kp, err
+	}
+
+	releaser, err := globallock.Lock(ctx, fmt.Sprintf("packages_signing_key_%d_%s", ownerID, s.Private))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	// the key pair may have been created while waiting for the lock
+	kp, err = getSigningKeyPair(ctx, ownerID, s)
+	if err != nil || kp != nil {
+		return kp, err
+	}
+
+	priv, pub, err := s.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user_model.SetUserSetting(ctx, ownerID, s.Private, priv); err != nil {
+		return nil, err
+	}
+
+	if err := user_model.SetUserSetting(ctx, ownerID, s.Public, pub); err != nil {
+		return nil, err
+	}
+
+	return &SigningKeyPair{Private: priv, Public: pub}, nil
+}
+
+// GetOrCreateSigningKeys gets the current and the previous key pair.
+// The current key pair is created if it doesn't exist.
+func GetOrCreateSigningKeys(ctx context.Context, ownerID int64, s *SigningKeySettings) (*SigningKeys, error) {
+	current, err := GetOrCreateSigningKeyPair(ctx, ownerID, s)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &SigningKeys{Current: current}
+	return keys, loadPreviousSigningKeyPair(ctx, ownerID, s, keys)
+}
+
+// GetSigningKeys gets the current and the previous key pair without creating them, Current is nil if the owner has no keys yet
+func GetSigningKeys(ctx context.Context, ownerID int64, s *SigningKeySettings) (*SigningKeys, error) {
+	priv, err := getSetting(ctx, ownerID, s.Private)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := getSetting(ctx, ownerID, s.Public)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &SigningKeys{}
+	if priv != "" && pub != "" {
+		keys.Current = &SigningKeyPair{Private: priv, Public: pub}
+	}
+	return keys, loadPreviousSigningKeyPair(ctx, ownerID, s, keys)
+}
+
+func loadPreviousSigningKeyPair(ctx context.Context, ownerID int64, s *SigningKeySettings, keys *SigningKeys) error {
+	expires, err := getSetting(ctx, ownerID, s.PreviousExpires)
+	if err != nil || expires == "" {
+		return err
+	}
+	expiresUnix, _ := strconv.ParseInt(expires, 10, 64)
+	if timeutil.TimeStamp(expiresUnix) <= timeutil.TimeStampNow() {
+		return nil
+	}
+
+	priv, err := getSetting(ctx, ownerID, s.PreviousPrivate)
+	if err != nil {
+		return err
+	}
+	pub, err := getSetting(ctx, ownerID, s.PreviousPublic)
+	if err != nil {
+		return err
+	}
+	if priv != "" && pub != "" {
+		keys.Previous = &SigningKeyPair{Private: priv, Public: pub}
+		keys.PreviousExpires = timeutil.TimeStamp(expiresUnix)
+	}
+	return nil
+}
+
+// ReplaceSigningKeyPair replaces the current key pair.
+// If overlap is greater than zero, the replaced key pair is used to sign the repository files until the overlap period ends.
+func ReplaceSigningKeyPair(ctx context.Context, ownerID int64, s *SigningKeySettings, kp *SigningKeyPair, overlap time.Duration) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := deletePreviousSigningKeyPair(ctx, ownerID, s); err != nil {
+			return err
+		}
+
+		if overlap > 0 {
+			priv, err := getSetting(ctx, ownerID, s.Private)
+			if err != nil {
+				return err
+			}
+			pub, err := getSetting(ctx, ownerID, s.Public)
+			if err != nil {
+				return err
+			}
+
+			if priv != "" && pub != "" {
+				for key, value := range map[string]string{
+					s.PreviousPrivate: priv,
+					s.PreviousPublic:  pub,
+					s.PreviousExpires: strconv.FormatInt(time.Now().Add(overlap).Unix(), 10),
+				} {
+					if err := user_model.SetUserSetting(ctx, ownerID, key, value); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, s.Private, kp.Private); err != nil {
+			return err
+		}
+		return user_model.SetUserSetting(ctx, ownerID, s.Public, kp.Public)
+	})
+}
+
+// RemoveExpiredSigningKeyPair removes the previous key pair if its overlap period has ended.
+// It returns true if a key pair got removed and the repository files need to be signed again.
+func RemoveExpiredSigningKeyPair(ctx context.Context, ownerID int64, s *SigningKeySettings) (bool, error) {
+	expires, err := getSetting(ctx, ownerID, s.PreviousExpires)
+	if err != nil || expires == "" {
+		return false, err
+	}
+	if expiresUnix, _ := strconv.ParseInt(expires, 10, 64); timeutil.TimeStamp(expiresUnix) > timeutil.TimeStampNow() {
+		return false, nil
+	}
+	return true, deletePreviousSigningKeyPair(ctx, ownerID, s)
+}
+
+func deletePreviousSigningKeyPair(ctx context.Context, ownerID int64, s *SigningKeySettings) error {
+	for _, key := range []string{s.PreviousPrivate, s.PreviousPublic, s.PreviousExpires} {
+		if err := user_model.DeleteUserSetting(ctx, ownerID, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadPGPEntity reads an armored PGP key, either the private or the public key
+func ReadPGPEntity(armored string) (*openpgp.Entity, error) {
+	block, err := armor.Decode(strings.NewReader(armored))
+	if err != nil {
+		return nil, err
+	}
+	return openpgp.ReadEntity(packet.NewReader(block.Body))
+}
+
+// ArmoredDetachSignMulti writes a single armored signature block which contains a signature of every entity
+func ArmoredDetachSignMulti(w io.Writer, entities []*openpgp.Entity, data []byte) error {
+	aw, err := armor.Encode(w, openpgp.SignatureType, nil)
+	if err != nil {
+		return err
+	}
+	for _, e := range entities {
+		if err := openpgp.DetachSign(aw, e, bytes.NewReader(data), nil); err != nil {
+			return err
+		}
+	}
+	return aw.Close()
+}
+
+// ParsePGPPrivateKey validates an armored private PGP key and returns it together with the armored public key
+func ParsePGPPrivateKey(priv string) (string, string, error) {
+	e, err := ReadPGPEntity(priv)
+	if err != nil {
+		return "", "", util.NewInvalidArgumentErrorf("invalid PGP private key: %v", err)
+	}
+	if e.PrivateKey == nil {
+		return "", "", util.NewInvalidArgumentErrorf("the PGP key is not a private key")
+	}
+	if e.PrivateKey.Encrypted {
+		return "", "", util.NewInvalidArgumentErrorf("the PGP private key must not be protected by a passphrase")
+	}
+
+	var pub strings.Builder
+	w, err := armor.Encode(&pub, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err := e.Serialize(w); err != nil {
+		return "", "", err
+	}
+	w.Close()
+
+	return strings.TrimSpace(priv) + "\n", pub.String(), nil
+}
+
+// PGPKeyFingerprint returns the fingerprint of the armored public PGP key
+func PGPKeyFingerprint(pub string) (string, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(pub))
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(keyring[0].PrimaryKey.Fingerprint)), nil
+}
+
+// ParseRSAPrivateKey validates a PEM encoded PKCS#1 or PKCS#8 RSA private key and returns it as PKCS#1 together with the PKIX public key
+func ParseRSAPrivateKey(priv string) (string, string, error) {
+	block, _ := pem.Decode([]byte(priv))
+	if block == nil {
+		return "", "", util.NewInvalidArgumentErrorf("invalid RSA private key: no PEM data found")
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return "", "", util.NewInvalidArgumentErrorf("invalid RSA private key: %v", err)
+		}
+		key = k
+	case "PRIVATE KEY":
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return "", "", util.NewInvalidArgumentErrorf("invalid RSA private key: %v", err)
+		}
+		rsaKey, ok := k.(*rsa.PrivateKey)
+		if !ok {
+			return "", "", util.NewInvalidArgumentErrorf("the private key is not a RSA key")
+		}
+		key = rsaKey
+	default:
+		return "", "", util.NewInvalidArgumentErrorf("unsupported PEM block type %q", block.Type)
+	}
+
+	pubASN1, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	privPem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	pubPem := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubASN1})
+	return string(privPem), string(pubPem), nil
+}
+
+// RSAKeyFingerprint returns the fingerprint of the PEM encoded public RSA key
+func RSAKeyFingerprint(pub string) (string, error) {
+	block, _ := pem.Decode([]byte(pub))
+	if block == nil {
+		return "", errors.New("failed to decode public key pem")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	fingerprint, err := util.CreatePublicKeyFingerprint(key)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(fingerprint), nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	packages_service "code.gitea.io/gitea/services/packages"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// SigningKeySettings are the settings which store the PGP keys used to sign the checksums of providers
+var SigningKeySettings = &packages_service.SigningKeySettings{
+	Private:  terraform_module.SettingKeyPrivate,
+	Public:   terraform_module.SettingKeyPublic,
+	Generate: generateKeypair,
+}
+
+// GetOrCreateKeyPair gets or creates the PGP keys used to sign the checksums of providers
+func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
+	kp, err := packages_service.GetOrCreateSigningKeyPair(ctx, ownerID, SigningKeySettings)
+	if err != nil {
+		return "", "", err
+	}
+	return kp.Private, kp.Public, nil
+}
+
+func generateKeypair() (string, string, error) {
+	cfg := &packet.Config{
+		RSABits:       4096,
+		DefaultHash:   crypto.SHA256,
+		DefaultCipher: packet.CipherAES256,
+	}
+
+	e, err := openpgp.NewEntity("", "Automatically generated Terraform Registry Key; created "+time.Now().UTC().Format(time.RFC3339), "", cfg)
+	if err != nil {
+		return "", "", err
+	}
+
+	var priv strings.Builder
+	var pub strings.Builder
+
+	w, err := armor.Encode(&priv, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err := e.SerializePrivate(w, nil); err != nil {
+		return "", "", err
+	}
+	w.Close()
+
+	w, err = armor.Encode(&pub, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", "", err
+	}
+	if err := e.Serialize(w); err != nil {
+		return "", "", err
+	}
+	w.Close()
+
+	return priv.String(), pub.String(), nil
+}
+
+// SigningKey is the public key which verifies the checksums of the providers of an owner
+type SigningKey struct {
+	KeyID      string
+	ASCIIArmor string
+}
+
+// GetSigningKey gets or creates the key pair of the owner and returns the public key
+func GetSigningKey(ctx context.Context, ownerID int64) (*SigningKey, error) {
+	_, pub, err := GetOrCreateKeyPair(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := packages_service.ReadPGPEntity(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SigningKey{
+		KeyID:      e.PrimaryKey.KeyIdString(),
+		ASCIIArmor: pub,
+	}, nil
+}
+
+// BuildProviderShasums builds the content of the SHA256SUMS file of the archives of a provider version
+func BuildProviderShasums(pfds []*packages_model.PackageFileDescriptor) []byte {
+	archives := make([]*packages_model.PackageFileDescriptor, 0, len(pfds))
+	for _, pfd := range pfds {
+		if pfd.Properties.GetByName(terraform_module.PropertyOS) != "" {
+			archives = append(archives, pfd)
+		}
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].File.Name < archives[j].File.Name
+	})
+
+	var buf bytes.Buffer
+	for _, pfd := range archives {
+		fmt.Fprintf(&buf, "%s  %s\n", pfd.Blob.HashSHA256, pfd.File.Name)
+	}
+	return buf.Bytes()
+}
+
+// SignProviderShasums creates the binary detached signature of the SHA256SUMS content with the key of the owner
+func SignProviderShasums(ctx context.Context, ownerID int64, shasums []byte) ([]byte, error) {
+	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := packages_service.ReadPGPEntity(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := openpgp.DetachSign(&buf, e, bytes.NewReader(shasums), nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package virtual
+
+import (
+	"context"
+	"errors"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+)
+
+// Source is a source of a virtual registry with its loaded owner
+type Source struct {
+	Owner  *user_model.User
+	Remote bool
+}
+
+// GetSources returns the ordered sources of the virtual registry.
+// The local packages of the owner of the virtual registry are always the first source, sources of deleted owners are skipped.
+func GetSources(ctx context.Context, owner *user_model.User, pv *packages_model.PackageVirtual) ([]*Source, error) {
+	sources := make([]*Source, 0, len(pv.Sources)+1)
+	sources = append(sources, &Source{Owner: owner})
+
+	for _, vs := range pv.Sources {
+		if vs.OwnerID == owner.ID && !vs.Remote {
+			continue
+		}
+
+		u, err := user_model.GetUserByID(ctx, vs.OwnerID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		sources = append(sources, &Source{Owner: u, Remote: vs.Remote})
+	}
+	return sources, nil
+}
+
+// Resolve returns the first source of the virtual registry of the owner which has the package.
+// The package names are alternative names of the same package, for example the legacy names of Maven packages.
+// Remote sources are never asked for internal packages: packages which exist in any local source or match the internal pattern.
+// canRead filters the sources the requester has no access to.
+// nil is returned if the owner has no enabled virtual registry for the package type,
+// packages_model.ErrPackageNotExist if no source has the package.
+func Resolve(ctx context.Context, owner *user_model.User, packageType packages_model.Type, canRead func(*user_model.User) (bool, error), packageNames ...string) (*Source, error) {
+	if !packages_model.IsVirtualSupported(packageType) {
+		return nil, nil
+	}
+
+	pv, err := packages_model.GetEnabledVirtual(ctx, owner.ID, packageType)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := pv.CompiledPattern(); err != nil {
+		return nil, err
+	}
+
+	sources, err := GetSources(ctx, owner, pv)
+	if err != nil {
+		return nil, err
+	}
+
+	isInternal := false
+	if pv.InternalPatternMatcher != nil {
+		for _, name := range packageNames {
+			if pv.InternalPatternMatcher.MatchString(name) {
+				isInternal = true
+				break
+			}
+		}
+	}
+
+	// all local sources are checked before the first remote source to prevent dependency confusion,
+	// the access of the requester doesn't matter for this
+	hasLocal := make(map[int64]bool)
+	for _, s := range sources {
+		if s.Remote {
+			continue
+		}
+		has, err := hasLocalPackage(ctx, s.Owner.ID, packageType, packageNames)
+		if err != nil {
+			return nil, err
+		}
+		hasLocal[s.Owner.ID] = has
+		isInternal = isInternal || has
+	}
+
+	for _, s := range sources {
+		var has bool
+		if s.Remote {
+			if isInternal {
+				continue
+			}
+			if has, err = hasRemote(ctx, s.Owner, packageType, packageNames); err != nil {
+				return nil, err
+			}
+		} else {
+			has = hasLocal[s.Owner.ID]
+		}
+		if !has {
+			continue
+		}
+
+		if s.Owner.ID != owner.ID {
+			if ok, err := canRead(s.Owner); err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
+		}
+		return s, nil
+	}
+
+	return nil, packages_model.ErrPackageNotExist
+}
+
+func hasLocalPackage(ctx context.Context, ownerID int64, packageType packages_model.Type, packageNames []string) (bool, error) {
+	for _, name := range packageNames {
+		if local, err := remote_service.IsLocalPackage(ctx, ownerID, packageType, name); err != nil || local {
+			return local, err
+		}
+	}
+	return false, nil
+}
+
+// hasRemote returns true if the owner has an enabled remote or has cached the package already.
+// The upstream itself isn't asked, so a remote source matches all packages which are not internal.
+func hasRemote(ctx context.Context, owner *user_model.User, packageType packages_model.Type, packageNames []string) (bool, error) {
+	if _, err := packages_model.GetEnabledRemote(ctx, owner.ID, packageType); err == nil {
+		return true, nil
+	} else if !errors.Is(err, util.ErrNotExist) {
+		return false, err
+	}
+
+	for _, name := range packageNames {
+		p, err := packages_model.GetPackageByName(ctx, owner.ID, packageType, name)
+		if err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				continue
+			}
+			return false, err
+		}
+		if cached, err := remote_service.IsCachedPackage(ctx, p); err != nil || cached {
+			return cached, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package virtual
+
+import (
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	proxy := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	insertPackage := func(t *testing.T, ownerID int64, name string, cached bool) {
+		p, err := packages_model.TryInsertPackage(t.Context(), &packages_model.Package{
+			OwnerID:   ownerID,
+			Type:      packages_model.TypeNpm,
+			Name:      name,
+			LowerName: name,
+		})
+		require.NoError(t, err)
+		if cached {
+			_, err = packages_model.InsertProperty(t.Context(), packages_model.PropertyTypePackage, p.ID, packages_model.PropertyCachedFromRemote, "https://registry.example")
+			require.NoError(t, err)
+		}
+	}
+
+	insertPackage(t, owner.ID, "own-package", false)
+	insertPackage(t, org.ID, "own-package", false)
+	insertPackage(t, org.ID, "org-package", false)
+	insertPackage(t, owner.ID, "cached-package", true)
+	insertPackage(t, proxy.ID, "cached-package", true)
+
+	canReadAll := func(*user_model.User) (bool, error) { return true, nil }
+
+	resolve := func(t *testing.T, canRead func(*user_model.User) (bool, error), name string) *Source {
+		s, err := Resolve(t.Context(), owner, packages_model.TypeNpm, canRead, name)
+		if err != nil {
+			assert.ErrorIs(t, err, packages_model.ErrPackageNotExist)
+			return nil
+		}
+		require.NotNil(t, s)
+		return s
+	}
+
+	t.Run("NoVirtual", func(t *testing.T) {
+		s, err := Resolve(t.Context(), owner, packages_model.TypeNpm, canReadAll, "org-package")
+		require.NoError(t, err)
+		assert.Nil(t, s)
+	})
+
+	pv, err := packages_model.InsertVirtual(t.Context(), &packages_model.PackageVirtual{
+		Enabled: true,
+		OwnerID: owner.ID,
+		Type:    packages_model.TypeNpm,
+		Sources: []*packages_model.VirtualSource{
+			{OwnerID: proxy.ID, Remote: true},
+			{OwnerID: org.ID},
+			{OwnerID: 999},
+		},
+		InternalPattern: "@internal/.*",
+	})
+	require.NoError(t, err)
+
+	t.Run("FirstMatch", func(t *testing.T) {
+		s := resolve(t, canReadAll, "own-package")
+		assert.Equal(t, owner.ID, s.Owner.ID)
+		assert.False(t, s.Remote)
+
+		s = resolve(t, canReadAll, "org-package")
+		assert.Equal(t, org.ID, s.Owner.ID)
+		assert.False(t, s.Remote)
+	})
+
+	t.Run("Remote", func(t *testing.T) {
+		// the proxy has no enabled remote but has cached the package already
+		s := resolve(t, canReadAll, "cached-package")
+		assert.Equal(t, proxy.ID, s.Owner.ID)
+		assert.True(t, s.Remote)
+
+		assert.Nil(t, resolve(t, canReadAll, "public-package"))
+
+		_, err := packages_model.InsertRemote(t.Context(), &packages_model.PackageRemote{
+			Enabled: true,
+			OwnerID: proxy.ID,
+			Type:    packages_model.TypeNpm,
+			URL:     "https://registry.example",
+		})
+		require.NoError(t, err)
+
+		s = resolve(t, canReadAll, "public-package")
+		assert.Equal(t, proxy.ID, s.Owner.ID)
+		assert.True(t, s.Remote)
+	})
+
+	t.Run("DependencyConfusion", func(t *testing.T) {
+		// the local package of the org is never resolved by the remote source listed before
+		s := resolve(t, canReadAll, "org-package")
+		assert.Equal(t, org.ID, s.Owner.ID)
+
+		// not even if the requester can't read the org
+		canReadNotOrg := func(u *user_model.User) (bool, error) { return u.ID != org.ID, nil }
+		assert.Nil(t, resolve(t, canReadNotOrg, "org-package"))
+
+		assert.Nil(t, resolve(t, canReadAll, "@internal/package"))
+		assert.Nil(t, resolve(t, canReadAll, "@INTERNAL/package"))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		pv.Enabled = false
+		require.NoError(t, packages_model.UpdateVirtual(t.Context(), pv))
+
+		s, err := Resolve(t.Context(), owner, packages_model.TypeNpm, canReadAll, "org-package")
+		require.NoError(t, err)
+		assert.Nil(t, s)
+	})
+}
@@ -20,6 +20,7 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	secret_model "code.gitea.io/gitea/models/secret"
 	system_model "code.gitea.io/gitea/models/system"
+	terraform_model "code.gitea.io/gitea/models/terraform"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/models/webhook"
 	actions_module "code.gitea.io/gitea/modules/actions"
@@ -76,12 +77,24 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		return fmt.Errorf("find actions tasks of repo %v: %w", repoID, err)
 	}
 
+	// Query the summaries of the action tasks of this repo, they will be needed after they have been deleted to remove the files in ObjectStorage
+	summaries, err := actions_model.GetTaskSummariesByRepoID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("find actions task summaries of repo %v: %w", repoID, err)
+	}
+
 	// Query the artifacts of this repo, they will be needed after they have been deleted to remove artifacts files in ObjectStorage
 	artifacts, err := db.Find[actions_model.ActionArtifact](ctx, actions_model.FindArtifactsOptions{RepoID: repoID})
 	if err != nil {
 		return fmt.Errorf("list actions artifacts of repo %v: %w", repoID, err)
 	}
 
+	// Query the terraform state versions of this repo, they will be needed after they have been deleted to remove state files in ObjectStorage
+	stateVersions, err := db.Find[terraform_model.TerraformStateVersion](ctx, terraform_model.FindStateVersionsOptions{RepoID: repoID})
+	if err != nil {
+		return fmt.Errorf("list terraform state versions of repo %v: %w", repoID, err)
+	}
+
 	// In case owner is a organization, we have to change repo specific teams
 	// if ignoreOrgTeams is not true
 	var org *user_model.User
@@ -170,6 +183,8 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&webhook.Webhook{RepoID: repoID},
 		&secret_model.Secret{RepoID: repoID},
 		&actions_model.ActionTaskStep{RepoID: repoID},
+		&actions_model.ActionTaskSummary{RepoID: repoID},
+		&actions_model.ActionTaskAnnotation{RepoID: repoID},
 		&actions_model.ActionTask{RepoID: repoID},
 		&actions_model.ActionRunJob{RepoID: repoID},
 		&actions_model.ActionRun{RepoID: repoID},
@@ -179,6 +194,8 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		&actions_model.ActionArtifact{RepoID: repoID},
 		&actions_model.ActionRunnerToken{RepoID: repoID},
 		&issues_model.IssuePin{RepoID: repoID},
+		&terraform_model.TerraformStateVersion{RepoID: repoID},
+		&terraform_model.TerraformState{RepoID: repoID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
 	}
@@ -365,6 +382,13 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		}
 	}
 
+	for _, summary := range summaries {
+		if err := actions_module.RemoveSummary(summary.Filename); err != nil {
+			log.Error("remove summary file %q: %v", summary.Filename, err)
+			// go on
+		}
+	}
+
 	// delete actions artifacts in ObjectStorage after the repo have already been deleted
 	for _, art := range artifacts {
 		if err := storage.ActionsArtifacts.Delete(art.StoragePath); err != nil {
@@ -373,6 +397,14 @@ func DeleteRepositoryDirectly(ctx context.Context, repoID int64, ignoreOrgTeams
 		}
 	}
 
+	// delete terraform state files in ObjectStorage after the repo have already been deleted
+	for _, v := range stateVersions {
+		if err := storage.TerraformStates.Delete(v.StoragePath()); err != nil {
+			log.Error("remove terraform state file %q: %v", v.StoragePath(), err)
+			// go on
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package savedsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/mailer"
+)
+
+// maxDigestItems is the maximum number of items included in a single digest
+const maxDigestItems = 50
+
+// SendDigests notifies the subscribers of saved searches about the issues and pull requests
+// which have been created since their last digest and match the search.
+func SendDigests(ctx context.Context) error {
+	for page := 1; ; page++ {
+		subscriptions, err := issues_model.FindSubscribedSavedSearchUsers(ctx, db.ListOptions{Page: page, PageSize: 100})
+		if err != nil {
+			return fmt.Errorf("FindSubscribedSavedSearchUsers failed: %w", err)
+		}
+		if len(subscriptions) == 0 {
+			return nil
+		}
+
+		for _, su := range subscriptions {
+			select {
+			case <-ctx.Done():
+				return db.ErrCancelledf("while sending saved search digests")
+			default:
+			}
+
+			// a failing subscription must not block the digests of the other subscribers
+			if err := sendDigest(ctx, su); err != nil {
+				log.Error("sendDigest[%d] failed: %v", su.ID, err)
+			}
+		}
+	}
+}
+
+func sendDigest(ctx context.Context, su *issues_model.SavedSearchUser) error {
+	search, err := issues_model.GetSavedSearchByID(ctx, su.SavedSearchID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if err := search.LoadOwner(ctx); err != nil {
+		return err
+	}
+
+	u, err := user_model.GetUserByID(ctx, su.UserID)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !u.IsActive || u.ProhibitLogin {
+		return nil
+	}
+	if canView, err := CanView(ctx, u, search); err != nil || !canView {
+		return err
+	}
+
+	now := timeutil.TimeStampNow()
+
+	opts, err := SearchOptions(ctx, u, search)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			// the query became invalid, for example because a label has been deleted
+			log.Debug("Saved search %d is invalid for user %d: %v", search.ID, u.ID, err)
+			return nil
+		}
+		return err
+	}
+	opts.SortBy = issue_indexer.SortByCreatedDesc
+	opts.Paginator = &db.ListOptions{PageSize: maxDigestItems}
+
+	issueIDs, _, err := issue_indexer.SearchIssues(ctx, opts)
+	if err != nil {
+		return err
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, issueIDs, true)
+	if err != nil {
+		return err
+	}
+
+	newIssues := make(issues_model.IssueList, 0, len(issues))
+	for _, issue := range issues {
+		if issue.CreatedUnix > su.LastNotifiedUnix && issue.CreatedUnix <= now && issue.PosterID != u.ID {
+			newIssues = append(newIssues, issue)
+		}
+	}
+
+	for _, issue := range newIssues {
+		if err := activities_model.CreateOrUpdateIssueNotifications(ctx, issue.ID, 0, issue.PosterID, u.ID); err != nil {
+			return err
+		}
+	}
+	if err := mailer.MailSavedSearchDigest(ctx, u, search, newIssues); err != nil {
+		return err
+	}
+
+	su.LastNotifiedUnix = now
+	return issues_model.UpdateSavedSearchUserNotified(ctx, su)
+}
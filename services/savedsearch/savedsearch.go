@@ -0,0 +1,136 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package savedsearch
+
+import (
+	"context"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	issue_query "code.gitea.io/gitea/modules/indexer/issues/query"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// CanView checks if the user can see the saved search.
+// Searches of an organization are visible to its owners and members, or only to the owners and the members of the team they are shared with.
+func CanView(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch) (bool, error) {
+	if doer == nil {
+		return false, nil
+	}
+	if search.OwnerID == doer.ID {
+		return true, nil
+	}
+	if search.TeamID > 0 {
+		if isOwner, err := organization.IsOrganizationOwner(ctx, search.OwnerID, doer.ID); err != nil || isOwner {
+			return isOwner, err
+		}
+		return organization.IsTeamMember(ctx, search.OwnerID, search.TeamID, doer.ID)
+	}
+	return organization.IsOrganizationMember(ctx, search.OwnerID, doer.ID)
+}
+
+// CanEdit checks if the user can change or delete the saved search
+func CanEdit(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch) (bool, error) {
+	return CanManage(ctx, doer, search.OwnerID)
+}
+
+// CanManage checks if the user can create, change and delete the saved searches of the owner
+func CanManage(ctx context.Context, doer *user_model.User, ownerID int64) (bool, error) {
+	if doer == nil {
+		return false, nil
+	}
+	if doer.ID == ownerID || doer.IsAdmin {
+		return true, nil
+	}
+	return organization.IsOrganizationOwner(ctx, ownerID, doer.ID)
+}
+
+// SearchOptions returns the issue indexer options of the saved search for the user.
+// The search is limited to the non-archived repositories of the owner which the user can access.
+// An invalid query results in an error which satisfies util.ErrInvalidArgument.
+func SearchOptions(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch) (*issue_indexer.SearchOptions, error) {
+	repoIDs, _, err := repo_model.SearchRepositoryIDs(ctx, repo_model.SearchRepoOptions{
+		Actor:    doer,
+		OwnerID:  search.OwnerID,
+		Private:  true,
+		UnitType: util.Iif(search.IsPull, unit.TypePullRequests, unit.TypeIssues),
+		Archived: optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(repoIDs) == 0 {
+		// no repos found, don't let the indexer return all repos
+		repoIDs = []int64{0}
+	}
+
+	opts := &issue_indexer.SearchOptions{
+		RepoIDs:    repoIDs,
+		IsClosed:   optional.Some(false),
+		IsArchived: optional.Some(false),
+		SortBy:     issue_indexer.SortByCreatedDesc,
+	}
+	if err := issue_indexer.ApplyQuery(ctx, doer, issue_query.Parse(search.Query), opts); err != nil {
+		return nil, err
+	}
+	// the type of the saved search decides between issues and pull requests
+	opts.IsPull = optional.Some(search.IsPull)
+	return opts, nil
+}
+
+// Count returns the number of issues or pull requests matching the saved search for the user
+func Count(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch) (int64, error) {
+	opts, err := SearchOptions(ctx, doer, search)
+	if err != nil {
+		return 0, err
+	}
+	return issue_indexer.CountIssues(ctx, opts)
+}
+
+func validate(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch) error {
+	search.Name = strings.TrimSpace(search.Name)
+	search.Query = strings.TrimSpace(search.Query)
+	if search.Name == "" {
+		return util.NewInvalidArgumentErrorf("the name of a saved search can't be empty")
+	}
+
+	if search.TeamID > 0 {
+		team, err := organization.GetTeamByID(ctx, search.TeamID)
+		if err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				return util.NewInvalidArgumentErrorf("team does not exist")
+			}
+			return err
+		}
+		if team.OrgID != search.OwnerID {
+			return util.NewInvalidArgumentErrorf("team %q does not belong to the owner of the saved search", team.Name)
+		}
+	}
+
+	_, err := SearchOptions(ctx, doer, search)
+	return err
+}
+
+// CreateSavedSearch validates and creates a new saved search
+func CreateSavedSearch(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch) error {
+	if err := validate(ctx, doer, search); err != nil {
+		return err
+	}
+	search.CreatorID = doer.ID
+	return issues_model.CreateSavedSearch(ctx, search)
+}
+
+// UpdateSavedSearch validates and updates the saved search
+func UpdateSavedSearch(ctx context.Context, doer *user_model.User, search *issues_model.SavedSearch) error {
+	if err := validate(ctx, doer, search); err != nil {
+		return err
+	}
+	return issues_model.UpdateSavedSearch(ctx, search)
+}
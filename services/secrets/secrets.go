@@ -11,21 +11,27 @@ import (
 )
 
 func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data, description string) (*secret_model.Secret, bool, error) {
+	return CreateOrUpdateEnvironmentSecret(ctx, ownerID, repoID, 0, name, data, description)
+}
+
+// CreateOrUpdateEnvironmentSecret is like CreateOrUpdateSecret, but the secret belongs to a deployment environment of the repository
+func CreateOrUpdateEnvironmentSecret(ctx context.Context, ownerID, repoID, environmentID int64, name, data, description string) (*secret_model.Secret, bool, error) {
 	if err := ValidateName(name); err != nil {
 		return nil, false, err
 	}
 
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
-		OwnerID: ownerID,
-		RepoID:  repoID,
-		Name:    name,
+		OwnerID:       ownerID,
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          name,
 	})
 	if err != nil {
 		return nil, false, err
 	}
 
 	if len(s) == 0 {
-		s, err := secret_model.InsertEncryptedSecret(ctx, ownerID, repoID, name, data, description)
+		s, err := secret_model.InsertEncryptedEnvironmentSecret(ctx, ownerID, repoID, environmentID, name, data, description)
 		if err != nil {
 			return nil, false, err
 		}
@@ -56,10 +62,16 @@ func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) erro
 }
 
 func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string) error {
+	return DeleteEnvironmentSecretByName(ctx, ownerID, repoID, 0, name)
+}
+
+// DeleteEnvironmentSecretByName is like DeleteSecretByName, but the secret belongs to a deployment environment of the repository
+func DeleteEnvironmentSecretByName(ctx context.Context, ownerID, repoID, environmentID int64, name string) error {
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
-		OwnerID: ownerID,
-		RepoID:  repoID,
-		Name:    name,
+		OwnerID:       ownerID,
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          name,
 	})
 	if err != nil {
 		return err
@@ -77,3 +89,12 @@ func deleteSecret(ctx context.Context, s *secret_model.Secret) error {
 	}
 	return nil
 }
+
+// DeleteEnvironmentSecrets deletes all the secrets of a deployment environment
+func DeleteEnvironmentSecrets(ctx context.Context, repoID, environmentID int64) error {
+	if environmentID == 0 {
+		return nil
+	}
+	_, err := db.DeleteByBean(ctx, &secret_model.Secret{RepoID: repoID, EnvironmentID: environmentID})
+	return err
+}
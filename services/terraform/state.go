@@ -0,0 +1,329 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	"code.gitea.io/gitea/models/db"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	// ErrInvalidName indicates an invalid state name
+	ErrInvalidName = util.NewInvalidArgumentErrorf("terraform state name is invalid")
+	// ErrInvalidState indicates a state document which is not valid JSON
+	ErrInvalidState = util.NewInvalidArgumentErrorf("terraform state is invalid")
+	// ErrInvalidLockInfo indicates a lock request without a valid lock info
+	ErrInvalidLockInfo = util.NewInvalidArgumentErrorf("terraform lock info is invalid")
+	// ErrStateTooLarge indicates a state document which exceeds the configured size limit
+	ErrStateTooLarge = util.ErrorWrap(util.ErrContentTooLarge, "terraform state exceeds the size limit")
+)
+
+var namePattern = regexp.MustCompile(`\A[a-zA-Z0-9][a-zA-Z0-9._-]*\z`)
+
+// IsValidName checks if the name can be used as the name of a state
+func IsValidName(name string) bool {
+	return len(name) <= 255 && namePattern.MatchString(name)
+}
+
+// ErrStateLocked is returned if the state is locked by another Terraform run
+type ErrStateLocked struct {
+	// LockInfo is the lock info of the current lock holder, Terraform shows it to the user
+	LockInfo string
+}
+
+func (err ErrStateLocked) Error() string {
+	return "terraform state is locked"
+}
+
+// IsErrStateLocked checks if an error is a ErrStateLocked
+func IsErrStateLocked(err error) bool {
+	return errors.As(err, &ErrStateLocked{})
+}
+
+// lockInfo contains the fields of the lock info sent by Terraform which are used by Gitea
+type lockInfo struct {
+	ID string `json:"ID"`
+}
+
+func parseLockID(info []byte) (string, error) {
+	var li lockInfo
+	if err := json.Unmarshal(info, &li); err != nil || li.ID == "" {
+		return "", ErrInvalidLockInfo
+	}
+	return li.ID, nil
+}
+
+func lockState(ctx context.Context, ownerID, repoID int64, name string) (globallock.ReleaseFunc, error) {
+	return globallock.Lock(ctx, fmt.Sprintf("terraform_state_%d_%d_%s", ownerID, repoID, name))
+}
+
+// LockState locks the state of the owner or the repository for a Terraform run, the state is created if it doesn't exist
+func LockState(ctx context.Context, ownerID, repoID int64, doer *user_model.User, name string, info []byte) (*terraform_model.TerraformState, error) {
+	if !IsValidName(name) {
+		return nil, ErrInvalidName
+	}
+	lockID, err := parseLockID(info)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := lockState(ctx, ownerID, repoID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	s, err := terraform_model.GetOrInsertState(ctx, ownerID, repoID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.IsLocked() {
+		if s.LockID == lockID {
+			return s, nil
+		}
+		return nil, ErrStateLocked{LockInfo: s.LockInfo}
+	}
+
+	s.LockID = lockID
+	s.LockInfo = string(info)
+	s.LockerID = doer.ID
+	s.LockedUnix = timeutil.TimeStampNow()
+	if err := terraform_model.UpdateStateCols(ctx, s, "lock_id", "lock_info", "locker_id", "locked_unix"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// UnlockState releases the lock of the state. The lock is removed regardless of its holder if info is empty.
+func UnlockState(ctx context.Context, ownerID, repoID int64, name string, info []byte) error {
+	lockID := ""
+	if len(bytes.TrimSpace(info)) > 0 {
+		var err error
+		if lockID, err = parseLockID(info); err != nil {
+			return err
+		}
+	}
+
+	release, err := lockState(ctx, ownerID, repoID, name)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	s, err := terraform_model.GetStateByName(ctx, ownerID, repoID, name)
+	if err != nil {
+		return err
+	}
+
+	if !s.IsLocked() {
+		return nil
+	}
+	if lockID != "" && s.LockID != lockID {
+		return ErrStateLocked{LockInfo: s.LockInfo}
+	}
+
+	s.LockID = ""
+	s.LockInfo = ""
+	s.LockerID = 0
+	s.LockedUnix = 0
+	return terraform_model.UpdateStateCols(ctx, s, "lock_id", "lock_info", "locker_id", "locked_unix")
+}
+
+// GetLatestVersion gets the latest version of the state
+func GetLatestVersion(ctx context.Context, ownerID, repoID int64, name string) (*terraform_model.TerraformState, *terraform_model.TerraformStateVersion, error) {
+	s, err := terraform_model.GetStateByName(ctx, ownerID, repoID, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.LatestVersion == 0 {
+		return s, nil, terraform_model.ErrStateNotExist
+	}
+	v, err := terraform_model.GetStateVersion(ctx, s.ID, s.LatestVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, v, nil
+}
+
+// OpenVersion opens the content of the version
+func OpenVersion(v *terraform_model.TerraformStateVersion) (storage.Object, error) {
+	return storage.TerraformStates.Open(v.StoragePath())
+}
+
+// stateHeader contains the fields of the state document which are stored with a version
+type stateHeader struct {
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+// UpdateState stores a new version of the state. If the state is locked, lockID must match the id of the lock.
+func UpdateState(ctx context.Context, ownerID, repoID int64, doer *user_model.User, name, lockID string, r io.Reader) (*terraform_model.TerraformStateVersion, error) {
+	if !IsValidName(name) {
+		return nil, ErrInvalidName
+	}
+
+	if setting.TerraformState.MaxStateSize >= 0 {
+		r = io.LimitReader(r, setting.TerraformState.MaxStateSize+1)
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if setting.TerraformState.MaxStateSize >= 0 && int64(len(content)) > setting.TerraformState.MaxStateSize {
+		return nil, ErrStateTooLarge
+	}
+	var header stateHeader
+	if err := json.Unmarshal(content, &header); err != nil {
+		return nil, ErrInvalidState
+	}
+
+	release, err := lockState(ctx, ownerID, repoID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	s, err := terraform_model.GetOrInsertState(ctx, ownerID, repoID, name)
+	if err != nil {
+		return nil, err
+	}
+	if s.IsLocked() && s.LockID != lockID {
+		return nil, ErrStateLocked{LockInfo: s.LockInfo}
+	}
+
+	v := &terraform_model.TerraformStateVersion{
+		OwnerID:   ownerID,
+		RepoID:    repoID,
+		StateID:   s.ID,
+		Version:   s.LatestVersion + 1,
+		Serial:    header.Serial,
+		Lineage:   header.Lineage,
+		Size:      int64(len(content)),
+		CreatorID: doer.ID,
+	}
+	if _, err := storage.TerraformStates.Save(v.StoragePath(), bytes.NewReader(content), v.Size); err != nil {
+		return nil, err
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := terraform_model.InsertStateVersion(ctx, v); err != nil {
+			return err
+		}
+		s.LatestVersion = v.Version
+		return terraform_model.UpdateStateCols(ctx, s, "latest_version")
+	}); err != nil {
+		if err := storage.TerraformStates.Delete(v.StoragePath()); err != nil {
+			log.Error("Error deleting terraform state file %s: %v", v.StoragePath(), err)
+		}
+		return nil, err
+	}
+
+	if err := pruneVersions(ctx, s); err != nil {
+		log.Error("Error pruning versions of terraform state %d: %v", s.ID, err)
+	}
+
+	return v, nil
+}
+
+// pruneVersions removes the oldest versions of the state which exceed the configured limit
+func pruneVersions(ctx context.Context, s *terraform_model.TerraformState) error {
+	if setting.TerraformState.MaxVersions <= 0 || s.LatestVersion <= int64(setting.TerraformState.MaxVersions) {
+		return nil
+	}
+
+	versions, err := db.Find[terraform_model.TerraformStateVersion](ctx, terraform_model.FindStateVersionsOptions{
+		StateID:       s.ID,
+		VersionBefore: s.LatestVersion - int64(setting.TerraformState.MaxVersions) + 1,
+	})
+	if err != nil {
+		return err
+	}
+	return deleteVersions(ctx, versions)
+}
+
+func deleteVersions(ctx context.Context, versions []*terraform_model.TerraformStateVersion) error {
+	ids := make([]int64, 0, len(versions))
+	for _, v := range versions {
+		ids = append(ids, v.ID)
+	}
+	if err := terraform_model.DeleteStateVersions(ctx, ids); err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		if err := storage.TerraformStates.Delete(v.StoragePath()); err != nil {
+			log.Error("Error deleting terraform state file %s: %v", v.StoragePath(), err)
+		}
+	}
+	return nil
+}
+
+// DeleteState deletes the state with all its versions. A locked state can't be deleted.
+func DeleteState(ctx context.Context, ownerID, repoID int64, name string) error {
+	release, err := lockState(ctx, ownerID, repoID, name)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	s, err := terraform_model.GetStateByName(ctx, ownerID, repoID, name)
+	if err != nil {
+		return err
+	}
+	if s.IsLocked() {
+		return ErrStateLocked{LockInfo: s.LockInfo}
+	}
+
+	versions, err := db.Find[terraform_model.TerraformStateVersion](ctx, terraform_model.FindStateVersionsOptions{StateID: s.ID})
+	if err != nil {
+		return err
+	}
+	if err := terraform_model.DeleteState(ctx, s); err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		if err := storage.TerraformStates.Delete(v.StoragePath()); err != nil {
+			log.Error("Error deleting terraform state file %s: %v", v.StoragePath(), err)
+		}
+	}
+	return nil
+}
+
+// DeleteOwnerStates deletes the states of the owner with all their versions, the states of its repositories are not affected
+func DeleteOwnerStates(ctx context.Context, ownerID int64) error {
+	versions, err := db.Find[terraform_model.TerraformStateVersion](ctx, terraform_model.FindStateVersionsOptions{OwnerID: ownerID})
+	if err != nil {
+		return err
+	}
+	if err := db.DeleteBeans(ctx,
+		&terraform_model.TerraformStateVersion{OwnerID: ownerID},
+		&terraform_model.TerraformState{OwnerID: ownerID},
+	); err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		if err := storage.TerraformStates.Delete(v.StoragePath()); err != nil {
+			log.Error("Error deleting terraform state file %s: %v", v.StoragePath(), err)
+		}
+	}
+	return nil
+}
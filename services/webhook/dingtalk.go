@@ -188,6 +188,12 @@ func (dingtalkConvertor) WorkflowJob(p *api.WorkflowJobPayload) (DingtalkPayload
 	return createDingtalkPayload(text, text, "Workflow Job", p.WorkflowJob.HTMLURL), nil
 }
 
+func (dingtalkConvertor) WorkflowJobQueue(p *api.WorkflowJobQueuePayload) (DingtalkPayload, error) {
+	text, _ := getWorkflowJobQueuePayloadInfo(p, noneLinkFormatter)
+
+	return createDingtalkPayload(text, text, "Workflow Job Queue", p.Owner.HTMLURL), nil
+}
+
 func createDingtalkPayload(title, text, singleTitle, singleURL string) DingtalkPayload {
 	return DingtalkPayload{
 		MsgType: "actionCard",
@@ -290,6 +290,12 @@ func (d discordConvertor) WorkflowJob(p *api.WorkflowJobPayload) (DiscordPayload
 	return d.createPayload(p.Sender, text, "", p.WorkflowJob.HTMLURL, color), nil
 }
 
+func (d discordConvertor) WorkflowJobQueue(p *api.WorkflowJobQueuePayload) (DiscordPayload, error) {
+	text, color := getWorkflowJobQueuePayloadInfo(p, noneLinkFormatter)
+
+	return d.createPayload(p.Owner, text, "", p.Owner.HTMLURL, color), nil
+}
+
 func newDiscordRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
 	meta := &DiscordMeta{}
 	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
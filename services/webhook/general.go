@@ -8,6 +8,7 @@ import (
 	"html"
 	"net/url"
 	"strings"
+	"time"
 
 	user_model "code.gitea.io/gitea/models/user"
 	webhook_model "code.gitea.io/gitea/models/webhook"
@@ -389,6 +390,17 @@ func getWorkflowJobPayloadInfo(p *api.WorkflowJobPayload, linkFormatter linkForm
 	return text, color
 }
 
+func getWorkflowJobQueuePayloadInfo(p *api.WorkflowJobQueuePayload, linkFormatter linkFormatter) (text string, color int) {
+	var count, waitSeconds int64
+	for _, jobs := range p.QueuedJobs {
+		count += jobs.Count
+		waitSeconds = max(waitSeconds, jobs.WaitSeconds)
+	}
+	ownerLink := linkFormatter(p.Owner.HTMLURL, p.Owner.UserName)
+	text = fmt.Sprintf("[%s] %d workflow jobs are waiting for runners, the oldest one has been waiting for %s", ownerLink, count, time.Duration(waitSeconds)*time.Second)
+	return text, orangeColorLight
+}
+
 // ToHook convert models.Webhook to api.Hook
 // This function is not part of the convert package to prevent an import cycle
 func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
@@ -610,6 +610,20 @@ func TestGetReleasePayloadInfo(t *testing.T) {
 	}
 }
 
+func TestGetWorkflowJobQueuePayloadInfo(t *testing.T) {
+	p := &api.WorkflowJobQueuePayload{
+		Owner: &api.User{UserName: "user1", HTMLURL: "http://localhost:3000/user1"},
+		QueuedJobs: []*api.ActionQueuedJobs{
+			{Labels: []string{"gpu", "linux"}, Count: 2, WaitSeconds: 90},
+			{Labels: []string{"ubuntu-latest"}, Count: 3, WaitSeconds: 30},
+		},
+	}
+
+	text, color := getWorkflowJobQueuePayloadInfo(p, noneLinkFormatter)
+	assert.Equal(t, "[user1] 5 workflow jobs are waiting for runners, the oldest one has been waiting for 1m30s", text)
+	assert.Equal(t, orangeColorLight, color)
+}
+
 func TestGetIssueCommentPayloadInfo(t *testing.T) {
 	p := pullRequestCommentTestPayload()
 
@@ -265,6 +265,12 @@ func (m matrixConvertor) WorkflowJob(p *api.WorkflowJobPayload) (MatrixPayload,
 	return m.newPayload(text)
 }
 
+func (m matrixConvertor) WorkflowJobQueue(p *api.WorkflowJobQueuePayload) (MatrixPayload, error) {
+	text, _ := getWorkflowJobQueuePayloadInfo(p, htmlLinkFormatter)
+
+	return m.newPayload(text)
+}
+
 var urlRegex = regexp.MustCompile(`<a [^>]*?href="([^">]*?)">(.*?)</a>`)
 
 func getMessageBody(htmlText string) string {
@@ -346,6 +346,20 @@ func (msteamsConvertor) WorkflowJob(p *api.WorkflowJobPayload) (MSTeamsPayload,
 	), nil
 }
 
+func (msteamsConvertor) WorkflowJobQueue(p *api.WorkflowJobQueuePayload) (MSTeamsPayload, error) {
+	title, color := getWorkflowJobQueuePayloadInfo(p, noneLinkFormatter)
+
+	return createMSTeamsPayload(
+		nil,
+		p.Owner,
+		title,
+		"",
+		p.Owner.HTMLURL,
+		color,
+		nil,
+	), nil
+}
+
 func createMSTeamsPayload(r *api.Repository, s *api.User, title, text, actionTarget string, color int, fact *MSTeamsFact) MSTeamsPayload {
 	facts := make([]MSTeamsFact, 0, 2)
 	if r != nil {
@@ -1012,6 +1012,26 @@ func (*webhookNotifier) WorkflowJobStatusUpdate(ctx context.Context, repo *repo_
 	}
 }
 
+func (*webhookNotifier) WorkflowJobQueueStatus(ctx context.Context, owner *user_model.User, queuedJobs []*actions_model.QueuedJobGroup) {
+	var org *api.Organization
+	if owner.IsOrganization() {
+		org = convert.ToOrganization(ctx, organization.OrgFromUser(owner))
+	}
+
+	apiQueuedJobs := make([]*api.ActionQueuedJobs, 0, len(queuedJobs))
+	for _, jobs := range queuedJobs {
+		apiQueuedJobs = append(apiQueuedJobs, convert.ToActionQueuedJobs(ctx, jobs))
+	}
+
+	if err := PrepareWebhooks(ctx, EventSource{Owner: owner}, webhook_module.HookEventWorkflowJobQueue, &api.WorkflowJobQueuePayload{
+		Owner:        convert.ToUser(ctx, owner, nil),
+		Organization: org,
+		QueuedJobs:   apiQueuedJobs,
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
 func (*webhookNotifier) WorkflowRunStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, run *actions_model.ActionRun) {
 	source := EventSource{
 		Repository: repo,
@@ -31,6 +31,7 @@ type payloadConvertor[T any] interface {
 	Status(*api.CommitStatusPayload) (T, error)
 	WorkflowRun(*api.WorkflowRunPayload) (T, error)
 	WorkflowJob(*api.WorkflowJobPayload) (T, error)
+	WorkflowJobQueue(*api.WorkflowJobQueuePayload) (T, error)
 }
 
 func convertUnmarshalledJSON[T, P any](convert func(P) (T, error), data []byte) (t T, err error) {
@@ -86,6 +87,8 @@ func newPayload[T any](rc payloadConvertor[T], data []byte, event webhook_module
 		return convertUnmarshalledJSON(rc.WorkflowRun, data)
 	case webhook_module.HookEventWorkflowJob:
 		return convertUnmarshalledJSON(rc.WorkflowJob, data)
+	case webhook_module.HookEventWorkflowJobQueue:
+		return convertUnmarshalledJSON(rc.WorkflowJobQueue, data)
 	}
 	return t, fmt.Errorf("newPayload unsupported event: %s", event)
 }
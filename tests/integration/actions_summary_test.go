@@ -0,0 +1,85 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionsStepSummary(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		user2Session := loginUser(t, user2.Name)
+		user2Token := getTokenForLoggedInUser(t, user2Session, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteUser)
+
+		apiRepo := createActionsTestRepo(t, user2Token, "actions-step-summary", false)
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: apiRepo.ID})
+		runner := newMockRunner()
+		runner.registerAsRepoRunner(t, repo.OwnerName, repo.Name, "mock-runner", []string{"ubuntu-latest"}, false)
+
+		wfTreePath := ".gitea/workflows/summary.yml"
+		wfFileContent := `name: Summary
+on: push
+jobs:
+  job1:
+    runs-on: ubuntu-latest
+    outputs:
+      result: ${{ steps.test.outputs.result }}
+    steps:
+      - id: test
+        run: echo '# Report' >> $GITHUB_STEP_SUMMARY
+`
+		opts := getWorkflowCreateFileOptions(user2, repo.DefaultBranch, "create "+wfTreePath, wfFileContent)
+		createWorkflowFile(t, user2Token, repo.OwnerName, repo.Name, wfTreePath, opts)
+
+		// the runner uploads the summary of the step to the url in the task context with the runtime token
+		task := runner.fetchTask(t)
+		summaryURL, err := url.Parse(task.Context.GetFields()["gitea_step_summary_url"].GetStringValue())
+		require.NoError(t, err)
+		runtimeToken := task.Context.GetFields()["gitea_runtime_token"].GetStringValue()
+		require.NotEmpty(t, runtimeToken)
+
+		req := NewRequestWithBody(t, "PUT", summaryURL.Path+"/0", strings.NewReader("# Report\n\nall passed"))
+		MakeRequest(t, req, http.StatusUnauthorized)
+		req = NewRequestWithBody(t, "PUT", summaryURL.Path+"/1", strings.NewReader("# Report")).AddTokenAuth(runtimeToken)
+		MakeRequest(t, req, http.StatusBadRequest)
+		req = NewRequestWithBody(t, "PUT", summaryURL.Path+"/0", strings.NewReader("# Report\n\nall passed")).AddTokenAuth(runtimeToken)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		runner.execTask(t, task, &mockTaskOutcome{
+			result:  runnerv1.Result_RESULT_SUCCESS,
+			outputs: map[string]string{"result": "passed"},
+		})
+		actionTask := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: task.Id})
+
+		// the summaries can't be uploaded after the task is done
+		req = NewRequestWithBody(t, "PUT", summaryURL.Path+"/0", strings.NewReader("# Late")).AddTokenAuth(runtimeToken)
+		resp := MakeRequest(t, req, NoExpectedStatus)
+		assert.NotEqual(t, http.StatusNoContent, resp.Code)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/%s/actions/jobs/%d/summaries", repo.OwnerName, repo.Name, actionTask.JobID)).
+			AddTokenAuth(user2Token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var summaries []*api.ActionJobSummary
+		DecodeJSON(t, resp, &summaries)
+		require.Len(t, summaries, 1)
+		assert.EqualValues(t, 0, summaries[0].StepNumber)
+		assert.Equal(t, "# Report\n\nall passed", summaries[0].Content)
+	})
+}
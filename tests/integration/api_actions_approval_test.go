@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIActionApprovalGate(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		session := loginUser(t, user2.Name)
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteUser)
+
+		apiRepo := createActionsTestRepo(t, token, "actions-approval-gate", false)
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: apiRepo.ID})
+		runner := newMockRunner()
+		runner.registerAsRepoRunner(t, user2.Name, repo.Name, "mock-runner", []string{"ubuntu-latest"}, false)
+
+		wfTreePath := ".gitea/workflows/approval-gate.yml"
+		wfFileContent := `name: approval-gate
+on: push
+jobs:
+  gate:
+    approval: true
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo build
+`
+		opts := getWorkflowCreateFileOptions(user2, repo.DefaultBranch, "create "+wfTreePath, wfFileContent)
+		createWorkflowFile(t, token, user2.Name, repo.Name, wfTreePath, opts)
+
+		task := runner.fetchTask(t)
+		taskToken := task.Secrets["GITEA_TOKEN"]
+		require.NotEmpty(t, taskToken)
+
+		gate := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{RepoID: repo.ID, JobID: "gate"})
+		assert.Eventually(t, func() bool {
+			gate = unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: gate.ID})
+			return gate.Started > 0
+		}, 5*time.Second, 100*time.Millisecond)
+		approvalURL := fmt.Sprintf("/api/v1/repos/%s/%s/actions/runs/%d/jobs/%d/approval", user2.Name, repo.Name, gate.RunID, gate.ID)
+
+		// the token of a task of the same run can't approve the gate
+		req := NewRequestWithJSON(t, "POST", approvalURL, &api.ReviewActionApprovalOption{State: "approved"}).AddTokenAuth(taskToken)
+		MakeRequest(t, req, http.StatusForbidden)
+		approval := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionApproval{RunJobID: gate.ID})
+		assert.Equal(t, actions_model.ApprovalStateWaiting, approval.State)
+
+		req = NewRequestWithJSON(t, "POST", approvalURL, &api.ReviewActionApprovalOption{State: "approved", Comment: "LGTM"}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var apiApproval api.ActionApproval
+		DecodeJSON(t, resp, &apiApproval)
+		assert.Equal(t, "approved", apiApproval.State)
+		require.NotNil(t, apiApproval.Reviewer)
+		assert.Equal(t, user2.Name, apiApproval.Reviewer.UserName)
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/routers/api/actions"
+	actions_service "code.gitea.io/gitea/services/actions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// the token of the running task 47 of run 791, whose ref is refs/heads/master
+const testActionsCacheTaskToken = "8061e833a55f6fc0157c98b883e91fcfeeb1a71a"
+
+// signedURLPath returns the path and query of a signed url returned by the cache API
+func signedURLPath(t *testing.T, signedURL string) string {
+	u, err := url.Parse(signedURL)
+	require.NoError(t, err)
+	return u.RequestURI()
+}
+
+func TestActionsCacheV1(t *testing.T) {
+	defer prepareTestEnvActionsArtifacts(t)()
+	require.NoError(t, storage.Clean(storage.ActionsCaches))
+	defer test.MockVariableValue(&setting.Actions.CacheRepoSizeLimit, int64(1024))()
+
+	baseURL := actions_service.CacheRoutePrefix + actions.CacheRouteBase
+
+	req := NewRequestWithJSON(t, "POST", baseURL+"/caches", map[string]any{"key": "deps-v1", "version": "v1", "cacheSize": 2048}).
+		AddTokenAuth(testActionsCacheTaskToken)
+	MakeRequest(t, req, http.StatusBadRequest)
+
+	req = NewRequestWithJSON(t, "POST", baseURL+"/caches", map[string]any{"key": "deps-v1", "version": "v1", "cacheSize": 10}).
+		AddTokenAuth(testActionsCacheTaskToken)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var reserved struct {
+		CacheID int64 `json:"cacheId"`
+	}
+	DecodeJSON(t, resp, &reserved)
+	cacheURL := baseURL + "/caches/" + strconv.FormatInt(reserved.CacheID, 10)
+
+	// the cache being uploaded can't be reserved again
+	req = NewRequestWithJSON(t, "POST", baseURL+"/caches", map[string]any{"key": "deps-v1", "version": "v1", "cacheSize": 10}).
+		AddTokenAuth(testActionsCacheTaskToken)
+	MakeRequest(t, req, http.StatusConflict)
+
+	uploadChunk := func(contentRange, content string, expectedStatus int) {
+		req := NewRequestWithBody(t, "PATCH", cacheURL, strings.NewReader(content)).
+			AddTokenAuth(testActionsCacheTaskToken).
+			SetHeader("Content-Range", contentRange)
+		MakeRequest(t, req, expectedStatus)
+	}
+	uploadChunk("bytes 5-10/*", "world!", http.StatusBadRequest)
+	uploadChunk("invalid", "world", http.StatusBadRequest)
+	uploadChunk("bytes 5-9/*", "world", http.StatusNoContent)
+	uploadChunk("bytes 0-4/*", "hello", http.StatusNoContent)
+
+	req = NewRequestWithJSON(t, "POST", cacheURL, map[string]any{"size": 10}).AddTokenAuth(testActionsCacheTaskToken)
+	MakeRequest(t, req, http.StatusNoContent)
+	uploadChunk("bytes 0-4/*", "hello", http.StatusBadRequest)
+
+	req = NewRequest(t, "GET", baseURL+"/cache?keys=deps-x,deps-&version=v1").AddTokenAuth(testActionsCacheTaskToken)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var entry struct {
+		CacheKey        string `json:"cacheKey"`
+		Scope           string `json:"scope"`
+		ArchiveLocation string `json:"archiveLocation"`
+	}
+	DecodeJSON(t, resp, &entry)
+	assert.Equal(t, "deps-v1", entry.CacheKey)
+	assert.Equal(t, "refs/heads/master", entry.Scope)
+
+	req = NewRequest(t, "GET", signedURLPath(t, entry.ArchiveLocation))
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.Equal(t, "helloworld", resp.Body.String())
+
+	req = NewRequest(t, "GET", baseURL+"/cache?keys=other&version=v1").AddTokenAuth(testActionsCacheTaskToken)
+	MakeRequest(t, req, http.StatusNoContent)
+	req = NewRequest(t, "GET", baseURL+"/cache?keys=deps-v1&version=v1")
+	MakeRequest(t, req, http.StatusUnauthorized)
+}
+
+func TestActionsCacheV2(t *testing.T) {
+	defer prepareTestEnvActionsArtifacts(t)()
+	require.NoError(t, storage.Clean(storage.ActionsCaches))
+	defer test.MockVariableValue(&setting.Actions.CacheRepoSizeLimit, int64(10))()
+
+	req := NewRequestWithBody(t, "POST", actions.CacheV2RouteBase+"/CreateCacheEntry", toProtoJSON(&actions.CreateCacheEntryRequest{
+		Key:     "deps-v2",
+		Version: "v1",
+	})).AddTokenAuth(testActionsCacheTaskToken)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var createResp actions.CreateCacheEntryResponse
+	require.NoError(t, protojson.Unmarshal(resp.Body.Bytes(), &createResp))
+	require.True(t, createResp.Ok)
+	uploadURL := signedURLPath(t, createResp.SignedUploadUrl)
+
+	uploadBlock := func(blockID, content string, expectedStatus int) {
+		req := NewRequestWithBody(t, "PUT", uploadURL+"&comp=block&blockid="+url.QueryEscape(blockID), strings.NewReader(content))
+		MakeRequest(t, req, expectedStatus)
+	}
+	uploadBlock("block-1", "hello", http.StatusCreated)
+	// the blocks can't exceed the size limit
+	uploadBlock("block-2", "world!", http.StatusBadRequest)
+	uploadBlock("block-2", "world", http.StatusCreated)
+
+	req = NewRequestWithBody(t, "PUT", uploadURL+"&comp=blocklist", strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?><BlockList><Latest>block-1</Latest><Latest>block-2</Latest></BlockList>`))
+	MakeRequest(t, req, http.StatusCreated)
+
+	req = NewRequestWithBody(t, "POST", actions.CacheV2RouteBase+"/FinalizeCacheEntryUpload", toProtoJSON(&actions.FinalizeCacheEntryUploadRequest{
+		Key:       "deps-v2",
+		Version:   "v1",
+		SizeBytes: 10,
+	})).AddTokenAuth(testActionsCacheTaskToken)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var finalizeResp actions.FinalizeCacheEntryUploadResponse
+	require.NoError(t, protojson.Unmarshal(resp.Body.Bytes(), &finalizeResp))
+	require.True(t, finalizeResp.Ok, finalizeResp.Message)
+
+	// the committed cache can't be uploaded again
+	uploadBlock("block-3", "!", http.StatusBadRequest)
+
+	req = NewRequestWithBody(t, "POST", actions.CacheV2RouteBase+"/GetCacheEntryDownloadURL", toProtoJSON(&actions.GetCacheEntryDownloadURLRequest{
+		Key:         "deps-x",
+		RestoreKeys: []string{"deps-"},
+		Version:     "v1",
+	})).AddTokenAuth(testActionsCacheTaskToken)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var downloadResp actions.GetCacheEntryDownloadURLResponse
+	require.NoError(t, protojson.Unmarshal(resp.Body.Bytes(), &downloadResp))
+	require.True(t, downloadResp.Ok)
+	assert.Equal(t, "deps-v2", downloadResp.MatchedKey)
+
+	req = NewRequest(t, "GET", signedURLPath(t, downloadResp.SignedDownloadUrl))
+	resp = MakeRequest(t, req, http.StatusOK)
+	assert.Equal(t, "helloworld", resp.Body.String())
+
+	// the signed url can't be used for another cache
+	req = NewRequest(t, "GET", signedURLPath(t, downloadResp.SignedDownloadUrl)+"0")
+	MakeRequest(t, req, http.StatusUnauthorized)
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	auth_model "code.gitea.io/gitea/models/auth"
+	secret_model "code.gitea.io/gitea/models/secret"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIActionEnvironment(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+	otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+	envURL := "/api/v1/repos/user2/repo1/actions/environments/production"
+
+	t.Run("CreateAndUpdate", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PUT", envURL, &api.CreateOrUpdateActionEnvironmentOption{
+			BranchFilters:     []string{"main", "release/*"},
+			RequiredReviewers: []string{"user2"},
+			PreventSelfReview: true,
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var env api.ActionEnvironment
+		DecodeJSON(t, resp, &env)
+		assert.Equal(t, "production", env.Name)
+		assert.Equal(t, []string{"main", "release/*"}, env.BranchFilters)
+		require.Len(t, env.RequiredReviewers, 1)
+		assert.Equal(t, "user2", env.RequiredReviewers[0].UserName)
+		assert.True(t, env.PreventSelfReview)
+
+		req = NewRequestWithJSON(t, "PUT", envURL, &api.CreateOrUpdateActionEnvironmentOption{
+			TagFilters: []string{"v*"},
+		}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		env = api.ActionEnvironment{}
+		DecodeJSON(t, resp, &env)
+		assert.Empty(t, env.BranchFilters)
+		assert.Equal(t, []string{"v*"}, env.TagFilters)
+		assert.Empty(t, env.RequiredReviewers)
+
+		req = NewRequestWithJSON(t, "PUT", envURL, &api.CreateOrUpdateActionEnvironmentOption{
+			RequiredReviewers: []string{"user-not-exist"},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+		req = NewRequestWithJSON(t, "PUT", envURL, &api.CreateOrUpdateActionEnvironmentOption{
+			BranchFilters: []string{"[invalid"},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		// only the repository admins could manage the environments
+		req = NewRequestWithJSON(t, "PUT", envURL, &api.CreateOrUpdateActionEnvironmentOption{}).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/actions/environments").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var envs []*api.ActionEnvironment
+		DecodeJSON(t, resp, &envs)
+		require.Len(t, envs, 1)
+		assert.Equal(t, "production", envs[0].Name)
+
+		req = NewRequest(t, "GET", envURL).AddTokenAuth(otherToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var env api.ActionEnvironment
+		DecodeJSON(t, resp, &env)
+		assert.Equal(t, []string{"v*"}, env.TagFilters)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/actions/environments/staging").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", envURL+"/deployments").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var deployments []*api.ActionDeployment
+		DecodeJSON(t, resp, &deployments)
+		assert.Empty(t, deployments)
+	})
+
+	t.Run("SecretsAndVariables", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PUT", envURL+"/secrets/DEPLOY_KEY", &api.CreateOrUpdateSecretOption{Data: "secret"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+		req = NewRequestWithJSON(t, "PUT", envURL+"/secrets/DEPLOY_KEY", &api.CreateOrUpdateSecretOption{Data: "changed"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequestWithJSON(t, "PUT", envURL+"/secrets/DEPLOY_KEY", &api.CreateOrUpdateSecretOption{Data: "secret"}).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", envURL+"/secrets").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var secrets []*api.Secret
+		DecodeJSON(t, resp, &secrets)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, "DEPLOY_KEY", secrets[0].Name)
+
+		req = NewRequestWithJSON(t, "PUT", envURL+"/variables/TARGET", &api.CreateVariableOption{Value: "prod"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+		req = NewRequestWithJSON(t, "PUT", envURL+"/variables/TARGET", &api.CreateVariableOption{Value: "production"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "GET", envURL+"/variables").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var variables []*api.ActionVariable
+		DecodeJSON(t, resp, &variables)
+		require.Len(t, variables, 1)
+		assert.Equal(t, "TARGET", variables[0].Name)
+		assert.Equal(t, "production", variables[0].Data)
+
+		// the secrets and variables of the environment aren't visible at the repository level
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/actions/variables").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		variables = nil
+		DecodeJSON(t, resp, &variables)
+		for _, v := range variables {
+			assert.NotEqual(t, "TARGET", v.Name)
+		}
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/actions/secrets").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		secrets = nil
+		DecodeJSON(t, resp, &secrets)
+		for _, s := range secrets {
+			assert.NotEqual(t, "DEPLOY_KEY", s.Name)
+		}
+
+		req = NewRequest(t, "DELETE", envURL+"/variables/TARGET").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "DELETE", envURL+"/variables/TARGET").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		env, err := actions_model.GetEnvironmentByRepoAndName(t.Context(), 1, "production")
+		require.NoError(t, err)
+
+		req := NewRequest(t, "DELETE", envURL).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+		req = NewRequest(t, "DELETE", envURL).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		req = NewRequest(t, "GET", envURL).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+		unittest.AssertNotExistsBean(t, &secret_model.Secret{RepoID: 1, EnvironmentID: env.ID})
+	})
+}
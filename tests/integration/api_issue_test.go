@@ -75,6 +75,58 @@ func TestAPIListIssues(t *testing.T) {
 	}
 }
 
+func TestAPIListIssuesWithQuery(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeReadIssue)
+	link := fmt.Sprintf("/api/v1/repos/%s/%s/issues", owner.Name, repo.Name)
+
+	cases := map[string][]int64{
+		"is:issue label:label1":                {1},
+		"is:pr -label:label1 sort:created-asc": {3, 11},
+		"is:closed author:user2":               {5},
+		`milestone:"MILESTONE1"`:               {2},
+		"no:milestone is:open is:pr":           {11},
+	}
+	for q, expected := range cases {
+		req := NewRequest(t, "GET", link+"?"+url.Values{"state": {"all"}, "q": {q}}.Encode()).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var apiIssues []*api.Issue
+		DecodeJSON(t, resp, &apiIssues)
+
+		ids := make([]int64, 0, len(apiIssues))
+		for _, apiIssue := range apiIssues {
+			ids = append(ids, apiIssue.ID)
+		}
+		assert.Equal(t, expected, ids, q)
+	}
+
+	req := NewRequest(t, "GET", link+"?"+url.Values{"q": {"label:unknown"}}.Encode()).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequest(t, "GET", "/api/v1/repos/issues/search?"+url.Values{"state": {"all"}, "q": {"is:issue assignee:user1 label:label1"}}.Encode()).
+		AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var apiIssues []*api.Issue
+	DecodeJSON(t, resp, &apiIssues)
+	if assert.Len(t, apiIssues, 1) {
+		assert.EqualValues(t, 1, apiIssues[0].ID)
+	}
+
+	// the labels of private repositories are only resolved for users who can see them
+	req = NewRequest(t, "GET", "/api/v1/repos/issues/search?"+url.Values{"q": {"label:repo3label1"}}.Encode())
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequest(t, "GET", "/api/v1/repos/issues/search?"+url.Values{"q": {"label:repo3label1"}}.Encode()).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusOK)
+}
+
 func TestAPIListIssuesPublicOnly(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIOrgActionsRunnerGroup(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteOrganization)
+	memberToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteOrganization)
+	groupsURL := "/api/v1/orgs/org3/actions/runner-groups"
+
+	// only the owners of the organization could manage the runner groups
+	req := NewRequest(t, "GET", groupsURL).AddTokenAuth(memberToken)
+	MakeRequest(t, req, http.StatusForbidden)
+
+	req = NewRequestWithJSON(t, "POST", groupsURL, &api.CreateActionRunnerGroupOption{
+		Name:         "gpu",
+		Description:  "GPU runners",
+		Repositories: []string{"repo3"},
+		Workflows:    []string{"train.yaml"},
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var group api.ActionRunnerGroup
+	DecodeJSON(t, resp, &group)
+	assert.Equal(t, "gpu", group.Name)
+	assert.Equal(t, "GPU runners", group.Description)
+	assert.Equal(t, []string{"repo3"}, group.Repositories)
+	assert.Equal(t, []string{"train.yaml"}, group.Workflows)
+	groupURL := fmt.Sprintf("%s/%d", groupsURL, group.ID)
+
+	req = NewRequestWithJSON(t, "POST", groupsURL, &api.CreateActionRunnerGroupOption{Name: "gpu"}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusConflict)
+	req = NewRequestWithJSON(t, "POST", groupsURL, &api.CreateActionRunnerGroupOption{Name: "other", Repositories: []string{"repo-not-exist"}}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequest(t, "GET", groupsURL).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var groups []*api.ActionRunnerGroup
+	DecodeJSON(t, resp, &groups)
+	require.Len(t, groups, 1)
+	assert.Equal(t, group.ID, groups[0].ID)
+
+	name, repos := "gpu-runners", []string{}
+	req = NewRequestWithJSON(t, "PATCH", groupURL, &api.EditActionRunnerGroupOption{Name: &name, Repositories: &repos}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	group = api.ActionRunnerGroup{}
+	DecodeJSON(t, resp, &group)
+	assert.Equal(t, "gpu-runners", group.Name)
+	assert.Empty(t, group.Repositories)
+	assert.Equal(t, []string{"train.yaml"}, group.Workflows)
+
+	// the runners of the organization could be moved between the groups
+	runnerURL := groupURL + "/runners/34347"
+	req = NewRequest(t, "PUT", runnerURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	assert.Equal(t, group.ID, unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunner{ID: 34347}).GroupID)
+	// the runners of other owners can't be moved to the group
+	req = NewRequest(t, "PUT", groupURL+"/runners/34346").AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+
+	req = NewRequest(t, "GET", groupURL+"/runners").AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var runners api.ActionRunnersResponse
+	DecodeJSON(t, resp, &runners)
+	require.EqualValues(t, 1, runners.TotalCount)
+	assert.EqualValues(t, 34347, runners.Entries[0].ID)
+
+	req = NewRequest(t, "DELETE", runnerURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	req = NewRequest(t, "DELETE", runnerURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+	assert.Zero(t, unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunner{ID: 34347}).GroupID)
+
+	// the runners are moved to the default group after the group is deleted
+	req = NewRequest(t, "PUT", runnerURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	req = NewRequest(t, "DELETE", groupURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNoContent)
+	req = NewRequest(t, "GET", groupURL).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusNotFound)
+	assert.Zero(t, unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunner{ID: 34347}).GroupID)
+}
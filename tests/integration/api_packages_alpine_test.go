@@ -240,6 +240,26 @@ AACAX/AKARNTyAAoAAA=`
 					MakeRequest(t, req, http.StatusOK)
 				})
 
+				t.Run("Private", func(t *testing.T) {
+					defer tests.PrintCurrentTest(t)()
+
+					url := fmt.Sprintf("%s/%s/%s/x86_64/APKINDEX.tar.gz", rootURL, branch, repository)
+
+					// the index is rebuilt without the private package
+					setPackagePrivate(t, user, packages.TypeAlpine, packageName, packageVersion, true)
+
+					req := NewRequest(t, "GET", url)
+					MakeRequest(t, req, http.StatusNotFound)
+
+					setPackagePrivate(t, user, packages.TypeAlpine, packageName, packageVersion, false)
+
+					req = NewRequest(t, "GET", url)
+					resp := MakeRequest(t, req, http.StatusOK)
+					content, err := readIndexContent(resp.Body)
+					assert.NoError(t, err)
+					assert.Contains(t, content, "P:"+packageName+"\n")
+				})
+
 				t.Run("NoArch", func(t *testing.T) {
 					defer tests.PrintCurrentTest(t)()
 
@@ -219,6 +219,27 @@ license = MIT`)
 					MakeRequest(t, req, http.StatusOK)
 				})
 
+				t.Run("Private", func(t *testing.T) {
+					defer tests.PrintCurrentTest(t)()
+
+					url := fmt.Sprintf("%s/%s/aarch64/%s", rootURL, repository, arch_service.IndexArchiveFilename)
+
+					// the index is rebuilt without the private package
+					setPackagePrivate(t, user, packages.TypeArch, packageName, packageVersion, true)
+
+					req := NewRequest(t, "GET", url)
+					MakeRequest(t, req, http.StatusNotFound)
+
+					setPackagePrivate(t, user, packages.TypeArch, packageName, packageVersion, false)
+
+					req = NewRequest(t, "GET", url)
+					resp := MakeRequest(t, req, http.StatusOK)
+
+					content, err := test.ReadAllTarGzContent(resp.Body)
+					assert.NoError(t, err)
+					assert.Contains(t, content, fmt.Sprintf("%s-%s/desc", packageName, packageVersion))
+				})
+
 				t.Run("Any", func(t *testing.T) {
 					defer tests.PrintCurrentTest(t)()
 
@@ -328,6 +328,44 @@ func testPackageCargo(t *testing.T, _ *neturl.URL) {
 		}
 	})
 
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeCargo, packageName, packageVersion, true)
+
+		hasGitEntry := func(t *testing.T) bool {
+			gitRepo, err := gitrepo.OpenRepository(t.Context(), repo)
+			assert.NoError(t, err)
+			defer gitRepo.Close()
+
+			commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+			assert.NoError(t, err)
+
+			_, err = commit.GetBlobByPath(cargo_service.BuildPackagePath(packageName))
+			return err == nil
+		}
+
+		assert.False(t, hasGitEntry(t))
+
+		req := NewRequest(t, "GET", url)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result cargo_router.SearchResult
+		DecodeJSON(t, resp, &result)
+		assert.EqualValues(t, 0, result.Meta.Total)
+
+		req = NewRequest(t, "GET", root+"/"+cargo_service.BuildPackagePath(packageName))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", root+"/"+cargo_service.BuildPackagePath(packageName)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+
+		setPackagePrivate(t, user, packages.TypeCargo, packageName, packageVersion, false)
+
+		assert.True(t, hasGitEntry(t))
+	})
+
 	t.Run("Yank", func(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
@@ -523,6 +523,31 @@ nwIDAQAB
 		assert.Equal(t, fmt.Sprintf("%s/versions/%s/download", packageURL, packageVersion), result.File)
 	})
 
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeChef, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeChef, packageName, packageVersion, false)
+
+		req := NewRequest(t, "GET", root+"/universe")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), packageName)
+
+		for _, endpoint := range []string{"/search", "/cookbooks"} {
+			req = NewRequest(t, "GET", root+endpoint)
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Contains(t, resp.Body.String(), `"total":0`, "endpoint %s", endpoint)
+		}
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/cookbooks/%s", root, packageName))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", root+"/search").
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `"total":1`)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		uploadPackage(t, "1.0.2", http.StatusCreated)
 		uploadPackage(t, "1.0.3", http.StatusCreated)
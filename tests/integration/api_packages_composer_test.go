@@ -252,4 +252,38 @@ func TestPackageComposer(t *testing.T) {
 		assert.Equal(t, "git", pkgs[0].Source.Type)
 		assert.Equal(t, packageVersion, pkgs[0].Source.Reference)
 	})
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeComposer, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeComposer, packageName, packageVersion, false)
+
+		req := NewRequest(t, "GET", url+"/search.json")
+		resp := MakeRequest(t, req, http.StatusOK)
+		var searchResult composer.SearchResultResponse
+		DecodeJSON(t, resp, &searchResult)
+		assert.Zero(t, searchResult.Total)
+		assert.Empty(t, searchResult.Results)
+
+		req = NewRequest(t, "GET", url+"/list.json")
+		resp = MakeRequest(t, req, http.StatusOK)
+		var listResult map[string][]string
+		DecodeJSON(t, resp, &listResult)
+		assert.Empty(t, listResult["packageNames"])
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/p2/%s/%s.json", url, vendorName, projectName))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", url+"/search.json").
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		searchResult = composer.SearchResultResponse{}
+		DecodeJSON(t, resp, &searchResult)
+		assert.EqualValues(t, 1, searchResult.Total)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/p2/%s/%s.json", url, vendorName, projectName)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+	})
 }
@@ -478,6 +478,34 @@ func TestPackageConan(t *testing.T) {
 				info := result[conanPackageReference]
 				assert.NotEmpty(t, info.Settings)
 			})
+
+			t.Run("Private", func(t *testing.T) {
+				defer tests.PrintCurrentTest(t)()
+
+				setPackagePrivate(t, user, packages.TypeConan, name, version1, true)
+				defer setPackagePrivate(t, user, packages.TypeConan, name, version1, false)
+
+				req := NewRequest(t, "GET", fmt.Sprintf("%s/v1/conans/search?q=%s", url, name))
+				resp := MakeRequest(t, req, http.StatusOK)
+
+				var result *conan_router.SearchResult
+				DecodeJSON(t, resp, &result)
+				assert.Empty(t, result.Results)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/v1/conans/search?q=%s", url, name)).
+					AddTokenAuth(token)
+				resp = MakeRequest(t, req, http.StatusOK)
+
+				DecodeJSON(t, resp, &result)
+				assert.Len(t, result.Results, 5)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/v1/conans/%s/%s/%s/%s", url, name, version1, user1, channel1))
+				MakeRequest(t, req, http.StatusUnauthorized)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/v1/conans/%s/%s/%s/%s", url, name, version1, user1, channel1)).
+					AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusOK)
+			})
 		})
 
 		t.Run("Delete", func(t *testing.T) {
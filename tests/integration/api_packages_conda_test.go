@@ -274,4 +274,31 @@ func TestPackageConda(t *testing.T) {
 			assert.Empty(t, packageInfo.Dependencies)
 		})
 	})
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeConda, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeConda, packageName, packageVersion, false)
+
+		type RepoData struct {
+			Packages map[string]any `json:"packages"`
+		}
+
+		filename := fmt.Sprintf("%s-%s-xxx.tar.bz2", packageName, packageVersion)
+
+		req := NewRequest(t, "GET", root+"/noarch/repodata.json")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", root+"/noarch/"+filename)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", root+"/noarch/repodata.json").
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result RepoData
+		DecodeJSON(t, resp, &result)
+		assert.Contains(t, result.Packages, filename)
+	})
 }
@@ -10,12 +10,16 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
@@ -23,7 +27,9 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
 	package_service "code.gitea.io/gitea/services/packages"
+	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
 	"code.gitea.io/gitea/tests"
 
 	oci "github.com/opencontainers/image-spec/specs-go/v1"
@@ -841,4 +847,317 @@ func TestPackageContainer(t *testing.T) {
 		})
 		session.MakeRequest(t, req, http.StatusSeeOther)
 	})
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages_model.TypeContainer, images[0], tags[0], true)
+		defer setPackagePrivate(t, user, packages_model.TypeContainer, images[0], tags[0], false)
+
+		type RepositoryList struct {
+			Repositories []string `json:"repositories"`
+		}
+
+		req := NewRequest(t, "GET", setting.AppURL+"v2/_catalog").
+			AddTokenAuth(anonymousToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		repoList := &RepositoryList{}
+		DecodeJSON(t, resp, &repoList)
+		assert.NotContains(t, repoList.Repositories, user.LowerName+"/"+images[0])
+		assert.Contains(t, repoList.Repositories, user.LowerName+"/"+images[1])
+
+		req = NewRequest(t, "GET", setting.AppURL+"v2/_catalog").
+			AddTokenAuth(userToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		repoList = &RepositoryList{}
+		DecodeJSON(t, resp, &repoList)
+		assert.Contains(t, repoList.Repositories, user.LowerName+"/"+images[0])
+	})
+}
+
+func TestPackageContainerReferrers(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	req := NewRequest(t, "GET", setting.AppURL+"v2/token").
+		AddBasicAuth(user.Name)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var tokenResponse struct {
+		Token string `json:"token"`
+	}
+	DecodeJSON(t, resp, &tokenResponse)
+	userToken := "Bearer " + tokenResponse.Token
+
+	image := "referrers"
+	imageURL := fmt.Sprintf("%sv2/%s/%s", setting.AppURL, user.Name, image)
+
+	sha256Digest := func(content string) string {
+		h := sha256.Sum256([]byte(content))
+		return "sha256:" + hex.EncodeToString(h[:])
+	}
+	uploadBlob := func(t *testing.T, content string) {
+		req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", imageURL, sha256Digest(content)), strings.NewReader(content)).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+	uploadManifest := func(t *testing.T, reference, content string) *http.Response {
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", imageURL, reference), strings.NewReader(content)).
+			AddTokenAuth(userToken).
+			SetHeader("Content-Type", oci.MediaTypeImageManifest)
+		return MakeRequest(t, req, http.StatusCreated).Result()
+	}
+
+	configContent := `{"architecture":"amd64","os":"linux","config":{}}`
+	emptyContent := `{}`
+	layerContent := "layer"
+	uploadBlob(t, configContent)
+	uploadBlob(t, emptyContent)
+	uploadBlob(t, layerContent)
+
+	imageManifest := fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","config":{"mediaType":"%s","digest":"%s","size":%d},"layers":[{"mediaType":"%s","digest":"%s","size":%d}]}`,
+		oci.MediaTypeImageManifest, oci.MediaTypeImageConfig, sha256Digest(configContent), len(configContent), oci.MediaTypeImageLayer, sha256Digest(layerContent), len(layerContent))
+	imageDigest := sha256Digest(imageManifest)
+
+	artifactManifest := func(artifactType string) string {
+		return fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","artifactType":"%s","config":{"mediaType":"%s","digest":"%s","size":%d},"layers":[{"mediaType":"%s","digest":"%s","size":%d}],"subject":{"mediaType":"%s","digest":"%s","size":%d},"annotations":{"org.opencontainers.image.created":"2026-01-01T00:00:00Z"}}`,
+			oci.MediaTypeImageManifest, artifactType, oci.MediaTypeEmptyJSON, sha256Digest(emptyContent), len(emptyContent), "application/octet-stream", sha256Digest(layerContent), len(layerContent), oci.MediaTypeImageManifest, imageDigest, len(imageManifest))
+	}
+	signatureType := "application/vnd.dev.cosign.artifact.sig.v1+json"
+	signatureManifest := artifactManifest(signatureType)
+	sbomType := "application/spdx+json"
+	sbomManifest := artifactManifest(sbomType)
+
+	getReferrers := func(t *testing.T, referrersURL string) (*oci.Index, *httptest.ResponseRecorder) {
+		req := NewRequest(t, "GET", referrersURL).AddTokenAuth(userToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, oci.MediaTypeImageIndex, resp.Header().Get("Content-Type"))
+
+		var index oci.Index
+		DecodeJSON(t, resp, &index)
+		assert.Equal(t, 2, index.SchemaVersion)
+		assert.Equal(t, oci.MediaTypeImageIndex, index.MediaType)
+		return &index, resp
+	}
+
+	t.Run("UploadArtifacts", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := uploadManifest(t, "latest", imageManifest)
+		assert.Empty(t, resp.Header.Get("OCI-Subject"))
+
+		// artifacts may be pushed before their subject, so the order doesn't matter
+		resp = uploadManifest(t, sha256Digest(signatureManifest), signatureManifest)
+		assert.Equal(t, imageDigest, resp.Header.Get("OCI-Subject"))
+		resp = uploadManifest(t, sha256Digest(sbomManifest), sbomManifest)
+		assert.Equal(t, imageDigest, resp.Header.Get("OCI-Subject"))
+
+		pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeContainer, image, sha256Digest(signatureManifest))
+		assert.NoError(t, err)
+		pd, err := packages_model.GetPackageDescriptor(t.Context(), pv)
+		assert.NoError(t, err)
+		assert.Equal(t, imageDigest, pd.VersionProperties.GetByName(container_module.PropertyManifestSubject))
+		metadata := pd.Metadata.(*container_module.Metadata)
+		assert.Equal(t, imageDigest, metadata.Subject)
+		assert.Equal(t, signatureType, metadata.ArtifactType)
+	})
+
+	t.Run("GetReferrers", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		index, resp := getReferrers(t, fmt.Sprintf("%s/referrers/%s", imageURL, imageDigest))
+		assert.Empty(t, resp.Header().Get("OCI-Filters-Applied"))
+		assert.Len(t, index.Manifests, 2)
+		artifacts := map[string]oci.Descriptor{}
+		for _, m := range index.Manifests {
+			artifacts[m.ArtifactType] = m
+		}
+		assert.EqualValues(t, sha256Digest(signatureManifest), artifacts[signatureType].Digest)
+		assert.EqualValues(t, len(signatureManifest), artifacts[signatureType].Size)
+		assert.Equal(t, oci.MediaTypeImageManifest, artifacts[signatureType].MediaType)
+		assert.Equal(t, "2026-01-01T00:00:00Z", artifacts[signatureType].Annotations["org.opencontainers.image.created"])
+		assert.EqualValues(t, sha256Digest(sbomManifest), artifacts[sbomType].Digest)
+
+		index, resp = getReferrers(t, fmt.Sprintf("%s/referrers/%s?artifactType=%s", imageURL, imageDigest, url.QueryEscape(sbomType)))
+		assert.Equal(t, "artifactType", resp.Header().Get("OCI-Filters-Applied"))
+		assert.Len(t, index.Manifests, 1)
+		assert.EqualValues(t, sha256Digest(sbomManifest), index.Manifests[0].Digest)
+
+		index, _ = getReferrers(t, fmt.Sprintf("%s/referrers/%s", imageURL, sha256Digest(signatureManifest)))
+		assert.Empty(t, index.Manifests)
+
+		index, _ = getReferrers(t, fmt.Sprintf("%sv2/%s/unknown/referrers/%s", setting.AppURL, user.Name, imageDigest))
+		assert.Empty(t, index.Manifests)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/referrers/invalid", imageURL)).AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, user.Name)
+
+		resp := session.MakeRequest(t, NewRequestf(t, "GET", "/%s/-/packages/container/%s/latest", user.Name, image), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 2, htmlDoc.Find(fmt.Sprintf(`a.tw-font-mono[href^="/%s/-/packages/container/%s/sha256"]`, user.Name, image)).Length())
+
+		resp = session.MakeRequest(t, NewRequestf(t, "GET", "/%s/-/packages/container/%s/%s", user.Name, image, sha256Digest(sbomManifest)), http.StatusOK)
+		htmlDoc = NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, htmlDoc.Find(fmt.Sprintf(`a.tw-font-mono[href="/%s/-/packages/container/%s/latest"]`, user.Name, image)).Length())
+	})
+}
+
+func TestPackageContainerCleanupUntagged(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	session := loginUser(t, user.Name)
+
+	image := "cleanup-untagged"
+	imageURL := fmt.Sprintf("%sv2/%s/%s", setting.AppURL, user.Name, image)
+
+	sha256Digest := func(content string) string {
+		h := sha256.Sum256([]byte(content))
+		return "sha256:" + hex.EncodeToString(h[:])
+	}
+	uploadBlob := func(t *testing.T, content string) {
+		req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", imageURL, sha256Digest(content)), strings.NewReader(content)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+	uploadManifest := func(t *testing.T, reference, mediaType, content string) {
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", imageURL, reference), strings.NewReader(content)).
+			AddBasicAuth(user.Name).
+			SetHeader("Content-Type", mediaType)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	layerContent := "layer"
+	uploadBlob(t, layerContent)
+
+	// imageManifest creates an image manifest with an unique config blob
+	imageManifest := func(t *testing.T, name, subject string) string {
+		configContent := fmt.Sprintf(`{"architecture":"amd64","os":"linux","config":{"Labels":{"name":"%s"}}}`, name)
+		uploadBlob(t, configContent)
+
+		var subjectField string
+		if subject != "" {
+			subjectField = fmt.Sprintf(`,"subject":{"mediaType":"%s","digest":"%s","size":1}`, oci.MediaTypeImageManifest, subject)
+		}
+		return fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","config":{"mediaType":"%s","digest":"%s","size":%d},"layers":[{"mediaType":"%s","digest":"%s","size":%d}]%s}`,
+			oci.MediaTypeImageManifest, oci.MediaTypeImageConfig, sha256Digest(configContent), len(configContent), oci.MediaTypeImageLayer, sha256Digest(layerContent), len(layerContent), subjectField)
+	}
+	indexManifest := func(manifest string) string {
+		return fmt.Sprintf(`{"schemaVersion":2,"mediaType":"%s","manifests":[{"mediaType":"%s","digest":"%s","size":%d,"platform":{"os":"linux","architecture":"amd64"}}]}`,
+			oci.MediaTypeImageIndex, oci.MediaTypeImageManifest, sha256Digest(manifest), len(manifest))
+	}
+
+	type manifest struct {
+		Name        string
+		Reference   string
+		MediaType   string
+		Content     string
+		Recent      bool
+		ShouldExist bool
+	}
+
+	tagged := imageManifest(t, "tagged", "")
+	referenced := imageManifest(t, "referenced", "")
+	dangling := imageManifest(t, "dangling", "")
+	danglingChild := imageManifest(t, "dangling-child", "")
+
+	manifests := []*manifest{
+		{Name: "tagged", Reference: "v1", Content: tagged, ShouldExist: true},
+		{Name: "signature of tagged", Content: imageManifest(t, "signature", sha256Digest(tagged)), ShouldExist: true},
+		{Name: "referenced by tagged index", Content: referenced, ShouldExist: true},
+		{Name: "tagged index", Reference: "multi", MediaType: oci.MediaTypeImageIndex, Content: indexManifest(referenced), ShouldExist: true},
+		{Name: "recent", Content: imageManifest(t, "recent", ""), Recent: true, ShouldExist: true},
+		{Name: "dangling", Content: dangling, ShouldExist: false},
+		{Name: "signature of dangling", Content: imageManifest(t, "dangling-signature", sha256Digest(dangling)), ShouldExist: false},
+		{Name: "referenced by untagged index", Content: danglingChild, ShouldExist: false},
+		{Name: "untagged index", MediaType: oci.MediaTypeImageIndex, Content: indexManifest(danglingChild), ShouldExist: false},
+	}
+
+	for _, m := range manifests {
+		m.Reference = util.IfZero(m.Reference, sha256Digest(m.Content))
+		uploadManifest(t, m.Reference, util.IfZero(m.MediaType, oci.MediaTypeImageManifest), m.Content)
+
+		if !m.Recent {
+			pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeContainer, image, m.Reference)
+			require.NoError(t, err)
+			_, err = db.GetEngine(t.Context()).Exec("UPDATE package_version SET created_unix = ? WHERE id = ?", time.Now().AddDate(0, 0, -30).Unix(), pv.ID)
+			require.NoError(t, err)
+		}
+	}
+
+	pcr, err := packages_model.InsertCleanupRule(t.Context(), &packages_model.PackageCleanupRule{
+		Enabled:            true,
+		OwnerID:            user.ID,
+		Type:               packages_model.TypeContainer,
+		KeepPattern:        ".*", // keep all versions by the tag rules, only the untagged manifests are removed
+		RemoveUntaggedDays: 7,
+	})
+	require.NoError(t, err)
+
+	t.Run("Preview", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/user/settings/packages/rules/%d/preview", pcr.ID)), http.StatusOK)
+		body := resp.Body.String()
+		for _, m := range manifests {
+			if m.Reference == sha256Digest(m.Content) {
+				assert.Equal(t, !m.ShouldExist, strings.Contains(body, m.Reference), m.Name)
+			}
+		}
+	})
+
+	t.Run("Cleanup", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		danglingConfig := fmt.Sprintf(`{"architecture":"amd64","os":"linux","config":{"Labels":{"name":"%s"}}}`, "dangling")
+		danglingConfigHash := strings.TrimPrefix(sha256Digest(danglingConfig), "sha256:")
+
+		require.NoError(t, packages_cleanup_service.ExecuteCleanupRules(t.Context()))
+
+		for _, m := range manifests {
+			_, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeContainer, image, m.Reference)
+			if m.ShouldExist {
+				assert.NoError(t, err, m.Name)
+			} else {
+				assert.ErrorIs(t, err, packages_model.ErrPackageNotExist, m.Name)
+			}
+		}
+
+		// the blobs are only reported in dry-run mode
+		var report []string
+		err := packages_cleanup_service.GarbageCollectBlobs(t.Context(), packages_cleanup_service.GarbageCollectBlobsOptions{
+			LogDetail: func(format string, v ...any) {
+				report = append(report, fmt.Sprintf(format, v...))
+			},
+			OlderThan: -time.Hour,
+		})
+		require.NoError(t, err)
+		require.Len(t, report, 1)
+		assert.True(t, strings.HasPrefix(report[0], "Found "), report[0])
+		exists, err := packages_model.ExistPackageBlobWithSHA(t.Context(), danglingConfigHash)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		err = packages_cleanup_service.GarbageCollectBlobs(t.Context(), packages_cleanup_service.GarbageCollectBlobsOptions{
+			AutoFix:   true,
+			OlderThan: -time.Hour,
+		})
+		require.NoError(t, err)
+		exists, err = packages_model.ExistPackageBlobWithSHA(t.Context(), danglingConfigHash)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		// the shared layer is still referenced by the kept manifests
+		exists, err = packages_model.ExistPackageBlobWithSHA(t.Context(), strings.TrimPrefix(sha256Digest(layerContent), "sha256:"))
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
 }
@@ -141,6 +141,21 @@ func TestPackageCran(t *testing.T) {
 
 			assert.Contains(t, resp.Header().Get("Content-Type"), "application/x-gzip")
 		})
+
+		t.Run("Private", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			setPackagePrivate(t, user, packages.TypeCran, packageName, packageVersion, true)
+			defer setPackagePrivate(t, user, packages.TypeCran, packageName, packageVersion, false)
+
+			req := NewRequest(t, "GET", url+"/src/contrib/PACKAGES")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", url+"/src/contrib/PACKAGES").
+				AddBasicAuth(user.Name)
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "Package: "+packageName)
+		})
 	})
 
 	t.Run("Binary", func(t *testing.T) {
@@ -7,21 +7,28 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/base"
 	debian_module "code.gitea.io/gitea/modules/packages/debian"
+	"code.gitea.io/gitea/modules/util"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	signing_service "code.gitea.io/gitea/services/packages/signing"
 	"code.gitea.io/gitea/tests"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
 	"github.com/blakesmith/ar"
 	"github.com/stretchr/testify/assert"
 )
@@ -234,6 +241,125 @@ func TestPackageDebian(t *testing.T) {
 		})
 	}
 
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := fmt.Sprintf("%s/dists/%s/%s/binary-%s/Packages", rootURL, distributions[0], components[0], architectures[0])
+
+		// the indices are rebuilt without the private package
+		setPackagePrivate(t, user, packages.TypeDebian, packageName, packageVersion, true)
+
+		req := NewRequest(t, "GET", url)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/pool/%s/%s/%s_%s_%s.deb", rootURL, distributions[0], components[0], packageName, packageVersion, architectures[0]))
+		MakeRequest(t, req, http.StatusNotFound)
+
+		setPackagePrivate(t, user, packages.TypeDebian, packageName, packageVersion, false)
+
+		req = NewRequest(t, "GET", url)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "Package: "+packageName+"\n")
+	})
+
+	t.Run("SigningKey", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		distribution := distributions[0]
+
+		getKeyring := func(t *testing.T) openpgp.EntityList {
+			req := NewRequest(t, "GET", rootURL+"/repository.key")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			keyring, err := openpgp.ReadArmoredKeyRing(resp.Body)
+			assert.NoError(t, err)
+			return keyring
+		}
+
+		checkSignatures := func(t *testing.T, keyring openpgp.EntityList, valid bool) {
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/dists/%s/Release", rootURL, distribution))
+			release := MakeRequest(t, req, http.StatusOK).Body.Bytes()
+
+			req = NewRequest(t, "GET", fmt.Sprintf("%s/dists/%s/Release.gpg", rootURL, distribution))
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			_, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(release), resp.Body, nil)
+			assert.Equal(t, valid, err == nil)
+
+			req = NewRequest(t, "GET", fmt.Sprintf("%s/dists/%s/InRelease", rootURL, distribution))
+			resp = MakeRequest(t, req, http.StatusOK)
+
+			block, _ := clearsign.Decode(resp.Body.Bytes())
+			assert.NotNil(t, block)
+			_, err = block.VerifySignature(keyring, nil)
+			assert.Equal(t, valid, err == nil)
+		}
+
+		oldKeyring := getKeyring(t)
+		checkSignatures(t, oldKeyring, true)
+
+		t.Run("Rotate", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			assert.NoError(t, signing_service.RotateKey(t.Context(), user.ID, packages.TypeDebian, 24*time.Hour))
+
+			newKeyring := getKeyring(t)
+			assert.NotEqual(t, oldKeyring[0].PrimaryKey.Fingerprint, newKeyring[0].PrimaryKey.Fingerprint)
+
+			// both keys sign the repository files during the overlap period
+			checkSignatures(t, oldKeyring, true)
+			checkSignatures(t, newKeyring, true)
+
+			infos, err := signing_service.GetKeyInfos(t.Context(), user.ID)
+			assert.NoError(t, err)
+			for _, info := range infos {
+				if info.Type == packages.TypeDebian {
+					assert.Equal(t, strings.ToUpper(hex.EncodeToString(newKeyring[0].PrimaryKey.Fingerprint)), info.Fingerprint)
+					assert.Equal(t, strings.ToUpper(hex.EncodeToString(oldKeyring[0].PrimaryKey.Fingerprint)), info.PreviousFingerprint)
+					assert.NotZero(t, info.PreviousExpires)
+				}
+			}
+		})
+
+		t.Run("Import", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			rotatedKeyring := getKeyring(t)
+
+			err := signing_service.ImportKey(t.Context(), user.ID, packages.TypeDebian, "invalid", 0)
+			assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+			e, err := openpgp.NewEntity("Imported", "", "imported@example.com", nil)
+			assert.NoError(t, err)
+
+			var priv strings.Builder
+			w, _ := armor.Encode(&priv, openpgp.PrivateKeyType, nil)
+			assert.NoError(t, e.SerializePrivate(w, nil))
+			w.Close()
+
+			assert.NoError(t, signing_service.ImportKey(t.Context(), user.ID, packages.TypeDebian, priv.String(), 0))
+
+			importedKeyring := getKeyring(t)
+			assert.Equal(t, e.PrimaryKey.Fingerprint, importedKeyring[0].PrimaryKey.Fingerprint)
+
+			// without overlap only the imported key signs the repository files
+			checkSignatures(t, importedKeyring, true)
+			checkSignatures(t, rotatedKeyring, false)
+			checkSignatures(t, oldKeyring, false)
+		})
+
+		t.Run("Resign", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			assert.NoError(t, signing_service.ResignRepositoryFiles(t.Context(), signing_service.ResignOptions{
+				OwnerID: user.ID,
+				Types:   []packages.Type{packages.TypeDebian},
+			}))
+
+			checkSignatures(t, getKeyring(t), true)
+		})
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
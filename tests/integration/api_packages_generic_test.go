@@ -175,6 +175,21 @@ func TestPackageGeneric(t *testing.T) {
 		})
 	})
 
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeGeneric, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeGeneric, packageName, packageVersion, false)
+
+		req := NewRequest(t, "GET", url+"/"+filename)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", url+"/"+filename).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
@@ -163,4 +163,20 @@ dependencies:
 
 		assert.Equal(t, url, result.ServerInfo.ContextPath)
 	})
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeHelm, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeHelm, packageName, packageVersion, false)
+
+		req := NewRequest(t, "GET", url+"/index.yaml")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), packageName)
+
+		req = NewRequest(t, "GET", url+"/index.yaml").
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), packageName)
+	})
 }
@@ -0,0 +1,340 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestPackageHex(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	// the Hex client sends the token without an authentication scheme
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "gitea_test"
+	packageVersion := "1.0.1"
+	packageDescription := "Test Description"
+
+	createPackage := func(name, version string) []byte {
+		metadata := fmt.Sprintf(`{<<"app">>,<<"%[1]s">>}.
+{<<"description">>,<<"%[3]s">>}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"name">>,<<"%[1]s">>}.
+{<<"requirements">>,[{<<"jason">>,[{<<"app">>,<<"jason">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.4">>},{<<"repository">>,<<"hexpm">>}]}]}.
+{<<"version">>,<<"%[2]s">>}.
+`, name, version, packageDescription)
+
+		var cbuf bytes.Buffer
+		zw := gzip.NewWriter(&cbuf)
+		tw := tar.NewWriter(zw)
+		tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0o600, Size: 8})
+		tw.Write([]byte("# Readme"))
+		tw.Close()
+		zw.Close()
+
+		h := sha256.New()
+		h.Write([]byte(hex_module.TarballVersion))
+		h.Write([]byte(metadata))
+		h.Write(cbuf.Bytes())
+
+		var buf bytes.Buffer
+		tw = tar.NewWriter(&buf)
+		for _, f := range []struct {
+			Name    string
+			Content []byte
+		}{
+			{"VERSION", []byte(hex_module.TarballVersion)},
+			{"CHECKSUM", []byte(strings.ToUpper(hex.EncodeToString(h.Sum(nil))))},
+			{"metadata.config", []byte(metadata)},
+			{"contents.tar.gz", cbuf.Bytes()},
+		} {
+			tw.WriteHeader(&tar.Header{Name: f.Name, Mode: 0o600, Size: int64(len(f.Content))})
+			tw.Write(f.Content)
+		}
+		tw.Close()
+		return buf.Bytes()
+	}
+
+	content := createPackage(packageName, packageVersion)
+	contentChecksum := sha256.Sum256(content)
+
+	root := fmt.Sprintf("/api/packages/%s/hex", user.Name)
+
+	var publicKey *rsa.PublicKey
+
+	// decodeResource verifies the signature and returns the payload of a registry resource
+	decodeResource := func(t *testing.T, body []byte) []byte {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		signed, err := io.ReadAll(zr)
+		require.NoError(t, err)
+
+		fields := decodeProtobufFields(t, signed)
+		require.Len(t, fields[1], 1)
+		require.Len(t, fields[2], 1)
+
+		payload, signature := fields[1][0], fields[2][0]
+		h := sha512.Sum512(payload)
+		assert.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA512, h[:], signature))
+
+		return payload
+	}
+
+	t.Run("PublicKey", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/public_key")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		block, _ := pem.Decode(resp.Body.Bytes())
+		require.NotNil(t, block)
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		require.NoError(t, err)
+		publicKey = key.(*rsa.PublicKey)
+	})
+
+	t.Run("Publish", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		publishURL := root + "/api/publish"
+
+		req := NewRequestWithBody(t, "POST", publishURL, bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", publishURL, bytes.NewReader([]byte{1, 2, 3})).
+			SetHeader("Authorization", token)
+		resp := MakeRequest(t, req, http.StatusUnprocessableEntity)
+		assert.Equal(t, "application/vnd.hex+erlang", resp.Header().Get("Content-Type"))
+
+		req = NewRequestWithBody(t, "POST", publishURL, bytes.NewReader(content)).
+			SetHeader("Authorization", token)
+		resp = MakeRequest(t, req, http.StatusCreated)
+		assert.Equal(t, "application/vnd.hex+erlang", resp.Header().Get("Content-Type"))
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHex)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		assert.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &hex_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+
+		metadata := pd.Metadata.(*hex_module.Metadata)
+		assert.Equal(t, packageDescription, metadata.Description)
+		assert.Equal(t, "# Readme", metadata.Readme)
+		assert.Equal(t, []string{"MIT"}, metadata.Licenses)
+		assert.Len(t, metadata.Dependencies, 1)
+
+		pfs, err := packages.GetFilesByVersionID(t.Context(), pvs[0].ID)
+		assert.NoError(t, err)
+		assert.Len(t, pfs, 1)
+		assert.Equal(t, hex_module.TarballFilename(packageName, packageVersion), pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		req = NewRequestWithBody(t, "POST", publishURL, bytes.NewReader(content)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequestWithBody(t, "POST", publishURL+"?replace=true", bytes.NewReader(content)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pvs, err = packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHex)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-%s.tar", root, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-0.0.0.tar", root, packageName))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Names", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/names")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		fields := decodeProtobufFields(t, decodeResource(t, resp.Body.Bytes()))
+		assert.Equal(t, [][]byte{[]byte(user.Name)}, fields[2])
+		require.Len(t, fields[1], 1)
+
+		pkg := decodeProtobufFields(t, fields[1][0])
+		assert.Equal(t, [][]byte{[]byte(packageName)}, pkg[1])
+		assert.Len(t, pkg[2], 1) // updated_at
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "POST", root+"/api/publish", bytes.NewReader(createPackage(packageName, "1.0.0-rc.1"))).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", root+"/versions")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		fields := decodeProtobufFields(t, decodeResource(t, resp.Body.Bytes()))
+		assert.Equal(t, [][]byte{[]byte(user.Name)}, fields[2])
+		require.Len(t, fields[1], 1)
+
+		pkg := decodeProtobufFields(t, fields[1][0])
+		assert.Equal(t, [][]byte{[]byte(packageName)}, pkg[1])
+		assert.Equal(t, [][]byte{[]byte("1.0.0-rc.1"), []byte(packageVersion)}, pkg[2])
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/packages/unknown")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", root+"/packages/"+packageName)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		fields := decodeProtobufFields(t, decodeResource(t, resp.Body.Bytes()))
+		assert.Equal(t, [][]byte{[]byte(packageName)}, fields[2])
+		assert.Equal(t, [][]byte{[]byte(user.Name)}, fields[3])
+		require.Len(t, fields[1], 2)
+
+		release := decodeProtobufFields(t, fields[1][1])
+		assert.Equal(t, [][]byte{[]byte(packageVersion)}, release[1])
+		assert.Len(t, release[2], 1)
+		assert.Len(t, release[2][0], sha256.Size)
+		assert.Equal(t, [][]byte{contentChecksum[:]}, release[5])
+		require.Len(t, release[3], 1)
+
+		dependency := decodeProtobufFields(t, release[3][0])
+		assert.Equal(t, [][]byte{[]byte("jason")}, dependency[1])
+		assert.Equal(t, [][]byte{[]byte("~> 1.4")}, dependency[2])
+		assert.Equal(t, [][]byte{[]byte("hexpm")}, dependency[5])
+	})
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeHex, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeHex, packageName, packageVersion, false)
+
+		for _, resource := range []string{"/names", "/versions"} {
+			req := NewRequest(t, "GET", root+resource)
+			resp := MakeRequest(t, req, http.StatusOK)
+			fields := decodeProtobufFields(t, decodeResource(t, resp.Body.Bytes()))
+			assert.Empty(t, fields[1], resource)
+
+			req = NewRequest(t, "GET", root+resource).
+				SetHeader("Authorization", token)
+			resp = MakeRequest(t, req, http.StatusOK)
+			fields = decodeProtobufFields(t, decodeResource(t, resp.Body.Bytes()))
+			assert.Len(t, fields[1], 1, resource)
+		}
+
+		req := NewRequest(t, "GET", root+"/packages/"+packageName)
+		MakeRequest(t, req, http.StatusUnauthorized)
+	})
+
+	t.Run("Docs", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		docs := []byte("docs")
+		docsURL := fmt.Sprintf("%s/api/packages/%s/releases/%s/docs", root, packageName, packageVersion)
+
+		req := NewRequestWithBody(t, "POST", docsURL, bytes.NewReader(docs))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", fmt.Sprintf("%s/api/packages/%s/releases/0.0.0/docs", root, packageName), bytes.NewReader(docs)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithBody(t, "POST", docsURL, bytes.NewReader(docs)).
+			SetHeader("Authorization", token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/docs/%s-%s.tar.gz", root, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, docs, resp.Body.Bytes())
+	})
+
+	t.Run("Revert", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		for _, version := range []string{packageVersion, "1.0.0-rc.1"} {
+			releaseURL := fmt.Sprintf("%s/api/packages/%s/releases/%s", root, packageName, version)
+
+			req := NewRequest(t, "DELETE", releaseURL)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "DELETE", releaseURL).
+				SetHeader("Authorization", token)
+			MakeRequest(t, req, http.StatusNoContent)
+
+			req = NewRequest(t, "DELETE", releaseURL).
+				SetHeader("Authorization", token)
+			MakeRequest(t, req, http.StatusNotFound)
+		}
+
+		req := NewRequest(t, "GET", root+"/packages/"+packageName)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
+
+// decodeProtobufFields returns the length-delimited fields of a protobuf message
+func decodeProtobufFields(t *testing.T, b []byte) map[protowire.Number][][]byte {
+	fields := make(map[protowire.Number][][]byte)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+
+		if typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			require.GreaterOrEqual(t, n, 0)
+			fields[num] = append(fields[num], v)
+			b = b[n:]
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+	}
+	return fields
+}
@@ -280,6 +280,21 @@ func TestPackageMaven(t *testing.T) {
 		}
 	})
 
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeMaven, groupID+":"+artifactID, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeMaven, groupID+":"+artifactID, packageVersion, false)
+
+		for _, path := range []string{"/maven-metadata.xml", "/maven-metadata.xml.sha1", fmt.Sprintf("/%s/%s", packageVersion, filename), fmt.Sprintf("/%s/%s.sha1", packageVersion, filename)} {
+			req := NewRequest(t, "GET", root+path)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "GET", root+path).AddBasicAuth(user.Name)
+			MakeRequest(t, req, http.StatusOK)
+		}
+	})
+
 	t.Run("UploadSnapshot", func(t *testing.T) {
 		snapshotVersion := packageVersion + "-SNAPSHOT"
 
@@ -286,6 +286,30 @@ func TestPackageNpm(t *testing.T) {
 		}
 	})
 
+	t.Run("SearchPrivate", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeNpm, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeNpm, packageName, packageVersion, false)
+
+		url := fmt.Sprintf("/api/packages/%s/npm/-/v1/search?text=test", user.Name)
+
+		req := NewRequest(t, "GET", url)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var result npm.PackageSearch
+		DecodeJSON(t, resp, &result)
+		assert.EqualValues(t, 0, result.Total)
+		assert.Empty(t, result.Objects)
+
+		req = NewRequest(t, "GET", url).
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		result = npm.PackageSearch{}
+		DecodeJSON(t, resp, &result)
+		assert.EqualValues(t, 1, result.Total)
+		assert.Len(t, result.Objects, 1)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
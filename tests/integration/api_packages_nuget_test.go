@@ -899,6 +899,41 @@ AAAjQmxvYgAAAGm7ENm9SGxMtAFVvPUsPJTF6PbtAAAAAFcVogEJAAAAAQAAAA==`)
 		})
 	})
 
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeNuGet, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeNuGet, packageName, packageVersion, false)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/query?q=%s", url, packageName))
+		resp := MakeRequest(t, req, http.StatusOK)
+		var result nuget.SearchResultResponse
+		DecodeJSON(t, resp, &result)
+		for _, sr := range result.Data {
+			assert.NotEqual(t, packageName, sr.ID)
+		}
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/Search()?searchTerm='%s'", url, packageName))
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), fmt.Sprintf("Id='%s'", packageName))
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/FindPackagesById()/$count?id='%s'", url, packageName))
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "0", resp.Body.String())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/Packages(Id='%s',Version='%s')", url, packageName, packageVersion))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/FindPackagesById()/$count?id='%s'", url, packageName)).
+			AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.NotEqual(t, "0", resp.Body.String())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/Packages(Id='%s',Version='%s')", url, packageName, packageVersion)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
@@ -177,4 +177,18 @@ description: ` + packageDescription
 		assert.Equal(t, packageVersion, result.Latest.Version)
 		assert.NotNil(t, result.Latest.Pubspec)
 	})
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypePub, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypePub, packageName, packageVersion, false)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/api/packages/%s", root, packageName))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/api/packages/%s", root, packageName)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+	})
 }
@@ -51,6 +51,8 @@ func TestPackagePyPI(t *testing.T) {
 		writer.WriteField("description", packageDescription)
 		writer.WriteField("sha256_digest", hashSHA256)
 		writer.WriteField("requires_python", "3.6")
+		writer.WriteField("requires_dist", "requests (>=2.0)")
+		writer.WriteField("requires_dist", "pytest; extra == 'test'")
 
 		return body, writer, writer.Close
 	}
@@ -88,6 +90,7 @@ func TestPackagePyPI(t *testing.T) {
 		assert.Nil(t, pd.SemVer)
 		assert.IsType(t, &pypi.Metadata{}, pd.Metadata)
 		assert.Equal(t, projectURL, pd.Metadata.(*pypi.Metadata).ProjectURL)
+		assert.Equal(t, []string{"requests (>=2.0)", "pytest; extra == 'test'"}, pd.Metadata.(*pypi.Metadata).RequiresDist)
 		assert.Equal(t, packageName, pd.Package.Name)
 		assert.Equal(t, packageVersion, pd.Version.Version)
 
@@ -235,4 +238,24 @@ func TestPackagePyPI(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypePyPI, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypePyPI, packageName, packageVersion, false)
+
+		// the name is normalized before the permissions of the package are checked
+		for _, name := range []string{packageName, "test_package"} {
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/simple/%s", root, name))
+			MakeRequest(t, req, http.StatusUnauthorized)
+		}
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/files/%s/%s/test.whl", root, packageName, packageVersion))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/simple/%s", root, packageName)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+	})
 }
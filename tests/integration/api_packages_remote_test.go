@@ -0,0 +1,123 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageRemote(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	content := []byte("remote package content")
+	hashSHA256 := sha256.Sum256(content)
+	hashSHA512 := sha512.Sum512(content)
+
+	var upstreamURL string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/npm/remote-package":
+			fmt.Fprintf(w, `{"name":"remote-package","dist-tags":{"latest":"1.0.0"},"versions":{"1.0.0":{"name":"remote-package","version":"1.0.0","dist":{"integrity":"sha512-%s","tarball":"%s/npm/remote-package/-/remote-package-1.0.0.tgz"}}}}`, base64.StdEncoding.EncodeToString(hashSHA512[:]), upstreamURL)
+		case "/pypi/simple/remote-package/":
+			fmt.Fprintf(w, `<html><body><a href="../../files/remote_package-1.0.0-py3-none-any.whl#sha256=%s" data-requires-python="&gt;=3.8">remote_package-1.0.0-py3-none-any.whl</a></body></html>`, hex.EncodeToString(hashSHA256[:]))
+		case "/npm/remote-package/-/remote-package-1.0.0.tgz", "/pypi/files/remote_package-1.0.0-py3-none-any.whl":
+			_, _ = w.Write(content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+	upstreamURL = upstream.URL
+
+	for _, packageType := range []packages_model.Type{packages_model.TypeNpm, packages_model.TypePyPI} {
+		_, err := packages_model.InsertRemote(t.Context(), &packages_model.PackageRemote{
+			Enabled:     true,
+			OwnerID:     user.ID,
+			Type:        packageType,
+			URL:         upstream.URL + "/" + string(packageType),
+			MetadataTTL: 3600,
+		})
+		require.NoError(t, err)
+	}
+
+	assertCachedPackage := func(t *testing.T, packageType packages_model.Type, name string) {
+		p, err := packages_model.GetPackageByName(t.Context(), user.ID, packageType, name)
+		require.NoError(t, err)
+		pps, err := packages_model.GetPropertiesByName(t.Context(), packages_model.PropertyTypePackage, p.ID, packages_model.PropertyCachedFromRemote)
+		require.NoError(t, err)
+		assert.Len(t, pps, 1)
+	}
+
+	t.Run("Npm", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		root := fmt.Sprintf("/api/packages/%s/npm", user.Name)
+
+		req := NewRequest(t, "GET", root+"/remote-package")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var doc struct {
+			Versions map[string]struct {
+				Dist struct {
+					Tarball string `json:"tarball"`
+				} `json:"dist"`
+			} `json:"versions"`
+		}
+		DecodeJSON(t, resp, &doc)
+		require.Contains(t, doc.Versions, "1.0.0")
+		assert.Equal(t, "http://localhost:3003"+root+"/remote-package/-/1.0.0/remote-package-1.0.0.tgz", doc.Versions["1.0.0"].Dist.Tarball)
+
+		req = NewRequest(t, "GET", root+"/remote-package/-/1.0.0/remote-package-1.0.0.tgz")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		assertCachedPackage(t, packages_model.TypeNpm, "remote-package")
+
+		req = NewRequest(t, "GET", root+"/missing-package")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("PyPI", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		root := fmt.Sprintf("/api/packages/%s/pypi", user.Name)
+
+		req := NewRequest(t, "GET", root+"/simple/remote-package")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		links := htmlDoc.Find("a")
+		assert.Equal(t, 1, links.Length())
+		href, _ := links.Attr("href")
+		assert.Equal(t, "http://localhost:3003"+root+"/files/remote-package/1.0.0/remote_package-1.0.0-py3-none-any.whl#sha256="+hex.EncodeToString(hashSHA256[:]), href)
+		requiresPython, _ := links.Attr("data-requires-python")
+		assert.Equal(t, ">=3.8", requiresPython)
+
+		req = NewRequest(t, "GET", root+"/files/remote-package/1.0.0/remote_package-1.0.0-py3-none-any.whl")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		assertCachedPackage(t, packages_model.TypePyPI, "remote-package")
+
+		req = NewRequest(t, "GET", root+"/files/remote-package/2.0.0/remote_package-2.0.0-py3-none-any.whl")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
@@ -420,6 +420,23 @@ gpgkey=%sapi/packages/%s/rpm/repository.key`,
 				})
 			})
 
+			t.Run("Private", func(t *testing.T) {
+				defer tests.PrintCurrentTest(t)()
+
+				url := groupURL + "/repodata/primary.xml.gz"
+
+				// the repository files are rebuilt without the private package
+				setPackagePrivate(t, user, packages.TypeRpm, packageName, packageVersion, true)
+
+				req := NewRequest(t, "GET", url)
+				MakeRequest(t, req, http.StatusNotFound)
+
+				setPackagePrivate(t, user, packages.TypeRpm, packageName, packageVersion, false)
+
+				req = NewRequest(t, "GET", url)
+				MakeRequest(t, req, http.StatusOK)
+			})
+
 			t.Run("Delete", func(t *testing.T) {
 				defer tests.PrintCurrentTest(t)()
 
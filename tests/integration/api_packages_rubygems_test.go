@@ -11,6 +11,7 @@ import (
 	"crypto/sha512"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"testing"
@@ -300,6 +301,42 @@ gitea-another 0.99 8b639e4048d282941485368ec42609be
 `, resp.Body.String())
 	})
 
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeRubyGems, testGemName, testGemVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeRubyGems, testGemName, testGemVersion, false)
+
+		for _, endpoint := range []string{"specs.4.8.gz", "latest_specs.4.8.gz"} {
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/%s", root, endpoint))
+			resp := MakeRequest(t, req, http.StatusOK)
+			zr, err := gzip.NewReader(resp.Body)
+			assert.NoError(t, err)
+			content, err := io.ReadAll(zr)
+			assert.NoError(t, err)
+			assert.NotContains(t, string(content), testGemVersion, "endpoint %s", endpoint)
+			assert.Contains(t, string(content), testAnotherGemVersion, "endpoint %s", endpoint)
+		}
+
+		req := NewRequest(t, "GET", root+"/versions")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), testGemName+" "+testGemVersion)
+		assert.Contains(t, resp.Body.String(), testAnotherGemName+" "+testAnotherGemVersion)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/info/%s", root, testGemName))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/quick/Marshal.4.8/%s-%s.gemspec.rz", root, testGemName, testGemVersion))
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", root+"/versions").AddBasicAuth(user.Name)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), testGemName+" "+testGemVersion)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/info/%s", root, testGemName)).AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusOK)
+	})
+
 	deleteGemPackage := func(t *testing.T, packageName, packageVersion string) {
 		body := bytes.Buffer{}
 		writer := multipart.NewWriter(&body)
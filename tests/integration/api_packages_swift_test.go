@@ -431,4 +431,24 @@ func TestPackageSwift(t *testing.T) {
 		assert.Len(t, result.Identifiers, 1)
 		assert.Equal(t, packageID, result.Identifiers[0])
 	})
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeSwift, packageID, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeSwift, packageID, packageVersion, false)
+
+		req := NewRequest(t, "GET", url+"/identifiers?url="+packageRepositoryURL).
+			SetHeader("Accept", swift_router.AcceptJSON)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%s/%s", url, packageScope, packageName)).
+			SetHeader("Accept", swift_router.AcceptJSON)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", url+"/identifiers?url="+packageRepositoryURL).
+			AddBasicAuth(user.Name).
+			SetHeader("Accept", swift_router.AcceptJSON)
+		MakeRequest(t, req, http.StatusOK)
+	})
 }
@@ -0,0 +1,385 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageTerraform(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the owner is private to verify the download urls contain a token
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 31})
+	assert.Equal(t, structs.VisibleTypePrivate, user.Visibility)
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	root := fmt.Sprintf("/api/packages/%s/terraform", user.Name)
+	protocolRoot := "/api/packages/-/terraform"
+
+	// trimAppURL converts the absolute urls returned by the registry to request paths
+	trimAppURL := func(u string) string {
+		return "/" + strings.TrimPrefix(u, setting.AppURL)
+	}
+
+	t.Run("ServiceDiscovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/.well-known/terraform.json")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+
+		assert.Equal(t, setting.AppURL+"api/packages/-/terraform/modules/v1/", result["modules.v1"])
+		assert.Equal(t, setting.AppURL+"api/packages/-/terraform/providers/v1/", result["providers.v1"])
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		moduleName := "network"
+		moduleSystem := "aws"
+		moduleVersion := "1.2.0"
+		readme := "# Network module"
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		archive := tar.NewWriter(zw)
+		for name, content := range map[string]string{
+			"main.tf":   `variable "cidr" {}`,
+			"README.md": readme,
+		} {
+			archive.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			archive.Write([]byte(content))
+		}
+		archive.Close()
+		zw.Close()
+		content := buf.Bytes()
+
+		uploadURL := fmt.Sprintf("%s/modules/%s/%s/%s", root, moduleName, moduleSystem, moduleVersion)
+		versionsURL := fmt.Sprintf("%s/modules/v1/%s/%s/%s/versions", protocolRoot, user.Name, moduleName, moduleSystem)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/modules/-invalid/%s/%s", root, moduleSystem, moduleVersion), bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/modules/%s/%s/latest", root, moduleName, moduleSystem), bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, strings.NewReader("invalid")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeTerraform, moduleName+"/"+moduleSystem, moduleVersion)
+			require.NoError(t, err)
+
+			pd, err := packages.GetPackageDescriptor(t.Context(), pv)
+			require.NoError(t, err)
+			assert.NotNil(t, pd.SemVer)
+			assert.IsType(t, &terraform_module.Metadata{}, pd.Metadata)
+			assert.Equal(t, terraform_module.KindModule, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Equal(t, readme, pd.Metadata.(*terraform_module.Metadata).Readme)
+			require.Len(t, pd.Files, 1)
+			assert.Equal(t, "network-aws-1.2.0.tar.gz", pd.Files[0].File.Name)
+			assert.True(t, pd.Files[0].File.IsLead)
+			assert.Equal(t, int64(len(content)), pd.Files[0].Blob.Size)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+		})
+
+		t.Run("Versions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", versionsURL)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "GET", fmt.Sprintf("%s/modules/v1/%s/%s/gcp/versions", protocolRoot, user.Name, moduleName)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", versionsURL).
+				AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Modules []struct {
+					Versions []struct {
+						Version string `json:"version"`
+					} `json:"versions"`
+				} `json:"modules"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			require.Len(t, result.Modules, 1)
+			require.Len(t, result.Modules[0].Versions, 1)
+			assert.Equal(t, moduleVersion, result.Modules[0].Versions[0].Version)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			downloadURL := fmt.Sprintf("%s/modules/v1/%s/%s/%s/%s/download", protocolRoot, user.Name, moduleName, moduleSystem, moduleVersion)
+
+			req := NewRequest(t, "GET", downloadURL)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "GET", fmt.Sprintf("%s/modules/v1/%s/%s/%s/9.9.9/download", protocolRoot, user.Name, moduleName, moduleSystem)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", downloadURL).
+				AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusNoContent)
+
+			archiveURL := resp.Header().Get("X-Terraform-Get")
+			assert.True(t, strings.HasPrefix(archiveURL, setting.AppURL+"api/packages/user31/terraform/modules/network/aws/1.2.0/network-aws-1.2.0.tar.gz?download_token="))
+
+			// Terraform doesn't send credentials when it downloads the archive
+			req = NewRequest(t, "GET", trimAppURL(archiveURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+
+			req = NewRequest(t, "GET", trimAppURL(archiveURL[:strings.Index(archiveURL, "?")]))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			// the download token can't be used to modify packages
+			req = NewRequestWithBody(t, "PUT", trimAppURL(strings.Replace(archiveURL, "1.2.0/network-aws-1.2.0.tar.gz", "1.3.0", 1)), bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+		})
+
+		t.Run("Private", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			// the owner must be public to see that the private module is hidden anyway
+			user.Visibility = structs.VisibleTypePublic
+			require.NoError(t, user_model.UpdateUserCols(t.Context(), user, "visibility"))
+			defer func() {
+				user.Visibility = structs.VisibleTypePrivate
+				require.NoError(t, user_model.UpdateUserCols(t.Context(), user, "visibility"))
+			}()
+
+			downloadURL := fmt.Sprintf("%s/modules/v1/%s/%s/%s/%s/download", protocolRoot, user.Name, moduleName, moduleSystem, moduleVersion)
+
+			req := NewRequest(t, "GET", versionsURL)
+			MakeRequest(t, req, http.StatusOK)
+
+			setPackagePrivate(t, user, packages.TypeTerraform, moduleName+"/"+moduleSystem, moduleVersion, true)
+			defer setPackagePrivate(t, user, packages.TypeTerraform, moduleName+"/"+moduleSystem, moduleVersion, false)
+
+			req = NewRequest(t, "GET", versionsURL)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "GET", downloadURL)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "GET", versionsURL).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusOK)
+		})
+	})
+
+	t.Run("Provider", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		providerType := "example"
+		providerVersion := "0.1.0"
+
+		createArchive := func(platform string) []byte {
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			w, _ := zw.Create("terraform-provider-example_v0.1.0")
+			w.Write([]byte(platform))
+			zw.Close()
+			return buf.Bytes()
+		}
+
+		archives := map[string][]byte{
+			"linux_amd64":  createArchive("linux_amd64"),
+			"darwin_arm64": createArchive("darwin_arm64"),
+		}
+
+		versionURL := fmt.Sprintf("%s/providers/%s/%s", root, providerType, providerVersion)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			for _, filename := range []string{
+				"terraform-provider-example_0.1.0_linux.zip",
+				"terraform-provider-other_0.1.0_linux_amd64.zip",
+				"terraform-provider-example_0.2.0_linux_amd64.zip",
+			} {
+				req := NewRequestWithBody(t, "PUT", versionURL+"/"+filename, bytes.NewReader(archives["linux_amd64"])).
+					AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusBadRequest)
+			}
+
+			for platform, content := range archives {
+				uploadURL := fmt.Sprintf("%s/terraform-provider-%s_%s_%s.zip", versionURL, providerType, providerVersion, platform)
+
+				req := NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content))
+				MakeRequest(t, req, http.StatusUnauthorized)
+
+				req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+					AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusCreated)
+
+				req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+					AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusConflict)
+			}
+
+			manifestURL := fmt.Sprintf("%s/terraform-provider-%s_%s_manifest.json", versionURL, providerType, providerVersion)
+
+			req := NewRequestWithBody(t, "PUT", manifestURL, strings.NewReader("{}")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", manifestURL, strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeTerraform, providerType, providerVersion)
+			require.NoError(t, err)
+
+			pd, err := packages.GetPackageDescriptor(t.Context(), pv)
+			require.NoError(t, err)
+			metadata := pd.Metadata.(*terraform_module.Metadata)
+			assert.Equal(t, terraform_module.KindProvider, metadata.Kind)
+			assert.Equal(t, []string{"6.0"}, metadata.Protocols)
+			assert.Len(t, pd.Files, 3)
+		})
+
+		t.Run("Versions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			versionsURL := fmt.Sprintf("%s/providers/v1/%s/%s/versions", protocolRoot, user.Name, providerType)
+
+			req := NewRequest(t, "GET", versionsURL)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "GET", versionsURL).
+				AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			type platform struct {
+				OS   string `json:"os"`
+				Arch string `json:"arch"`
+			}
+
+			var result struct {
+				Versions []struct {
+					Version   string      `json:"version"`
+					Protocols []string    `json:"protocols"`
+					Platforms []*platform `json:"platforms"`
+				} `json:"versions"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			require.Len(t, result.Versions, 1)
+			assert.Equal(t, providerVersion, result.Versions[0].Version)
+			assert.Equal(t, []string{"6.0"}, result.Versions[0].Protocols)
+			assert.Equal(t, []*platform{{"darwin", "arm64"}, {"linux", "amd64"}}, result.Versions[0].Platforms)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			downloadURL := fmt.Sprintf("%s/providers/v1/%s/%s/%s/download/linux/amd64", protocolRoot, user.Name, providerType, providerVersion)
+
+			req := NewRequest(t, "GET", downloadURL)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "GET", fmt.Sprintf("%s/providers/v1/%s/%s/%s/download/windows/amd64", protocolRoot, user.Name, providerType, providerVersion)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", downloadURL).
+				AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Protocols           []string `json:"protocols"`
+				OS                  string   `json:"os"`
+				Arch                string   `json:"arch"`
+				Filename            string   `json:"filename"`
+				DownloadURL         string   `json:"download_url"`
+				ShasumsURL          string   `json:"shasums_url"`
+				ShasumsSignatureURL string   `json:"shasums_signature_url"`
+				Shasum              string   `json:"shasum"`
+				SigningKeys         struct {
+					GPGPublicKeys []struct {
+						KeyID      string `json:"key_id"`
+						ASCIIArmor string `json:"ascii_armor"`
+					} `json:"gpg_public_keys"`
+				} `json:"signing_keys"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			assert.Equal(t, []string{"6.0"}, result.Protocols)
+			assert.Equal(t, "linux", result.OS)
+			assert.Equal(t, "amd64", result.Arch)
+			assert.Equal(t, "terraform-provider-example_0.1.0_linux_amd64.zip", result.Filename)
+			require.Len(t, result.SigningKeys.GPGPublicKeys, 1)
+
+			req = NewRequest(t, "GET", trimAppURL(result.DownloadURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, archives["linux_amd64"], resp.Body.Bytes())
+
+			req = NewRequest(t, "GET", trimAppURL(result.ShasumsURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			shasums := resp.Body.Bytes()
+			assert.Contains(t, string(shasums), result.Shasum+"  terraform-provider-example_0.1.0_linux_amd64.zip\n")
+			assert.Len(t, strings.Split(strings.TrimSpace(string(shasums)), "\n"), 2)
+
+			req = NewRequest(t, "GET", trimAppURL(result.ShasumsSignatureURL))
+			resp = MakeRequest(t, req, http.StatusOK)
+			signature := resp.Body.Bytes()
+
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(result.SigningKeys.GPGPublicKeys[0].ASCIIArmor))
+			require.NoError(t, err)
+			signer, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(shasums), bytes.NewReader(signature), nil)
+			require.NoError(t, err)
+			assert.Equal(t, result.SigningKeys.GPGPublicKeys[0].KeyID, signer.PrimaryKey.KeyIdString())
+		})
+	})
+}
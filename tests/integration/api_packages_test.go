@@ -8,19 +8,25 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	container_module "code.gitea.io/gitea/modules/packages/container"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
+	org_service "code.gitea.io/gitea/services/org"
 	packages_service "code.gitea.io/gitea/services/packages"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -446,6 +452,150 @@ func TestPackageAccess(t *testing.T) {
 	})
 }
 
+func TestPackagePermissions(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 25}) // public org
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 10, OrgID: org.ID})
+	member := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	nonMember := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	// the team has read access to the org packages
+	assert.NoError(t, org_service.AddTeamMember(t.Context(), team, member))
+
+	uploadPackage := func(doer, owner *user_model.User, packageName, filename string, expectedStatus int) {
+		url := fmt.Sprintf("/api/packages/%s/generic/%s/1.0/%s", owner.Name, packageName, filename)
+		req := NewRequestWithBody(t, "PUT", url, bytes.NewReader([]byte{1}))
+		if doer != nil {
+			req.AddBasicAuth(doer.Name)
+		}
+		MakeRequest(t, req, expectedStatus)
+	}
+
+	downloadPackage := func(doer, owner *user_model.User, packageName string, expectedStatus int) {
+		url := fmt.Sprintf("/api/packages/%s/generic/%s/1.0/file.bin", owner.Name, packageName)
+		req := NewRequest(t, "GET", url)
+		if doer != nil {
+			req.AddBasicAuth(doer.Name)
+		}
+		MakeRequest(t, req, expectedStatus)
+	}
+
+	updateSettings := func(owner *user_model.User, packageName string, values map[string]string) {
+		req := NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/-/packages/generic/%s/1.0/settings", owner.Name, packageName), values)
+		loginUser(t, admin.Name).MakeRequest(t, req, http.StatusOK)
+	}
+
+	uploadPackage(admin, org, "private-package", "file.bin", http.StatusCreated)
+	uploadPackage(admin, org, "public-package", "file.bin", http.StatusCreated)
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		updateSettings(org, "private-package", map[string]string{"action": "access", "is_private": "on"})
+
+		p, err := packages_model.GetPackageByName(t.Context(), org.ID, packages_model.TypeGeneric, "private-package")
+		assert.NoError(t, err)
+		assert.True(t, p.IsPrivate)
+
+		downloadPackage(nil, org, "private-package", http.StatusUnauthorized)
+		downloadPackage(nonMember, org, "private-package", http.StatusUnauthorized)
+		downloadPackage(member, org, "private-package", http.StatusOK)
+		downloadPackage(admin, org, "private-package", http.StatusOK)
+		downloadPackage(nil, org, "public-package", http.StatusOK)
+		downloadPackage(nonMember, org, "public-package", http.StatusOK)
+
+		webURL := fmt.Sprintf("/%s/-/packages/generic/private-package/1.0", org.Name)
+		MakeRequest(t, NewRequest(t, "GET", webURL), http.StatusNotFound)
+		loginUser(t, nonMember.Name).MakeRequest(t, NewRequest(t, "GET", webURL), http.StatusNotFound)
+		loginUser(t, member.Name).MakeRequest(t, NewRequest(t, "GET", webURL), http.StatusOK)
+
+		listPackageNames := func(doer *user_model.User) []string {
+			session := loginUser(t, doer.Name)
+			token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeReadPackage)
+
+			req := NewRequest(t, "GET", "/api/v1/packages/"+org.Name).
+				AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var apiPackages []*api.Package
+			DecodeJSON(t, resp, &apiPackages)
+
+			names := make([]string, 0, len(apiPackages))
+			for _, apiPackage := range apiPackages {
+				names = append(names, apiPackage.Name)
+			}
+			return names
+		}
+
+		assert.ElementsMatch(t, []string{"public-package"}, listPackageNames(nonMember))
+		assert.ElementsMatch(t, []string{"private-package", "public-package"}, listPackageNames(member))
+	})
+
+	t.Run("TeamPermission", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadPackage(member, org, "private-package", "member.bin", http.StatusUnauthorized)
+
+		updateSettings(org, "private-package", map[string]string{"action": "add_team", "team_id": strconv.FormatInt(team.ID, 10), "access_mode": "write"})
+		unittest.AssertExistsAndLoadBean(t, &packages_model.PackageTeam{TeamID: team.ID, AccessMode: perm.AccessModeWrite})
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/generic/private-package/1.0/settings", org.Name))
+		resp := loginUser(t, admin.Name).MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), team.Name)
+
+		uploadPackage(member, org, "private-package", "member.bin", http.StatusCreated)
+		uploadPackage(member, org, "public-package", "member.bin", http.StatusUnauthorized)
+		uploadPackage(member, org, "new-package", "member.bin", http.StatusUnauthorized)
+
+		updateSettings(org, "private-package", map[string]string{"action": "remove_team", "team_id": strconv.FormatInt(team.ID, 10)})
+		unittest.AssertNotExistsBean(t, &packages_model.PackageTeam{TeamID: team.ID})
+
+		uploadPackage(member, org, "private-package", "member2.bin", http.StatusUnauthorized)
+	})
+
+	t.Run("RepositoryPermission", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}) // public repository with the packages unit
+		collaborator := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+
+		uploadPackage(owner, owner, "linked-package", "file.bin", http.StatusCreated)
+
+		p, err := packages_model.GetPackageByName(t.Context(), owner.ID, packages_model.TypeGeneric, "linked-package")
+		assert.NoError(t, err)
+		assert.NoError(t, packages_model.SetRepositoryLink(t.Context(), p.ID, repo.ID))
+
+		updateSettings(owner, "linked-package", map[string]string{"action": "access", "is_private": "on"})
+
+		downloadPackage(collaborator, owner, "linked-package", http.StatusUnauthorized)
+
+		assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), repo, collaborator, perm.AccessModeWrite))
+
+		downloadPackage(collaborator, owner, "linked-package", http.StatusUnauthorized)
+		uploadPackage(collaborator, owner, "linked-package", "collaborator.bin", http.StatusUnauthorized)
+
+		updateSettings(owner, "linked-package", map[string]string{"action": "access", "is_private": "on", "inherit_repo_permissions": "on"})
+
+		downloadPackage(collaborator, owner, "linked-package", http.StatusOK)
+		uploadPackage(collaborator, owner, "linked-package", "collaborator.bin", http.StatusCreated)
+		// everyone can read the public repository which must not grant access to the private package
+		downloadPackage(nonMember, owner, "linked-package", http.StatusUnauthorized)
+	})
+}
+
+// setPackagePrivate changes the visibility of the package as the site admin
+func setPackagePrivate(t *testing.T, owner *user_model.User, packageType packages_model.Type, packageName, packageVersion string, isPrivate bool) {
+	values := map[string]string{"action": "access"}
+	if isPrivate {
+		values["is_private"] = "on"
+	}
+	req := NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/-/packages/%s/%s/%s/settings", owner.Name, packageType, url.PathEscape(packageName), url.PathEscape(packageVersion)), values)
+	loginUser(t, "user1").MakeRequest(t, req, http.StatusOK)
+}
+
 func TestPackageQuota(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
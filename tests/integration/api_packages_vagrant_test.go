@@ -167,4 +167,18 @@ func TestPackageVagrant(t *testing.T) {
 		assert.Equal(t, "sha512", provider.ChecksumType)
 		assert.Equal(t, "259bebd6160acad695016d22a45812e26f187aaf78e71a4c23ee3201528346293f991af3468a8c6c5d2a21d7d9e1bdc1bf79b87110b2fddfcc5a0d45963c7c30", provider.Checksum)
 	})
+
+	t.Run("Private", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		setPackagePrivate(t, user, packages.TypeVagrant, packageName, packageVersion, true)
+		defer setPackagePrivate(t, user, packages.TypeVagrant, packageName, packageVersion, false)
+
+		req := NewRequest(t, "GET", boxURL)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "GET", boxURL).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+	})
 }
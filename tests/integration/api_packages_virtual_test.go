@@ -0,0 +1,138 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
+	packages_service "code.gitea.io/gitea/services/packages"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageVirtual(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+
+	localContent := []byte("local package content")
+	remoteContent := []byte("remote package content")
+
+	var internalRequests atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/simple/internal-package/":
+			internalRequests.Add(1)
+			fmt.Fprint(w, `<html><body><a href="/files/internal_package-9.9.9-py3-none-any.whl">internal_package-9.9.9-py3-none-any.whl</a></body></html>`)
+		case "/simple/public-package/":
+			fmt.Fprint(w, `<html><body><a href="/files/public_package-1.0.0-py3-none-any.whl">public_package-1.0.0-py3-none-any.whl</a></body></html>`)
+		case "/files/public_package-1.0.0-py3-none-any.whl":
+			_, _ = w.Write(remoteContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(localContent))
+	require.NoError(t, err)
+	defer buf.Close()
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		t.Context(),
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       org,
+				PackageType: packages_model.TypePyPI,
+				Name:        "internal-package",
+				Version:     "1.0.0",
+			},
+			Creator:  user,
+			Metadata: &pypi_module.Metadata{},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: "internal_package-1.0.0-py3-none-any.whl",
+			},
+			Creator: user,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	require.NoError(t, err)
+
+	_, err = packages_model.InsertRemote(t.Context(), &packages_model.PackageRemote{
+		Enabled: true,
+		OwnerID: user.ID,
+		Type:    packages_model.TypePyPI,
+		URL:     upstream.URL,
+	})
+	require.NoError(t, err)
+
+	_, err = packages_model.InsertVirtual(t.Context(), &packages_model.PackageVirtual{
+		Enabled: true,
+		OwnerID: user.ID,
+		Type:    packages_model.TypePyPI,
+		Sources: []*packages_model.VirtualSource{
+			{OwnerID: user.ID, Remote: true},
+			{OwnerID: org.ID},
+		},
+	})
+	require.NoError(t, err)
+
+	root := fmt.Sprintf("/api/packages/%s/pypi", user.Name)
+
+	t.Run("Local", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/simple/internal-package")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		links := NewHTMLParser(t, resp.Body).Find("a")
+		assert.Equal(t, 1, links.Length())
+		href, _ := links.Attr("href")
+		assert.Contains(t, href, root+"/files/internal-package/1.0.0/internal_package-1.0.0-py3-none-any.whl")
+
+		req = NewRequest(t, "GET", root+"/files/internal-package/1.0.0/internal_package-1.0.0-py3-none-any.whl")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, localContent, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/files/internal-package/9.9.9/internal_package-9.9.9-py3-none-any.whl")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		assert.EqualValues(t, 0, internalRequests.Load())
+	})
+
+	t.Run("Remote", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/simple/public-package")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, 1, NewHTMLParser(t, resp.Body).Find("a").Length())
+
+		req = NewRequest(t, "GET", root+"/files/public-package/1.0.0/public_package-1.0.0-py3-none-any.whl")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, remoteContent, resp.Body.Bytes())
+	})
+
+	t.Run("NotExist", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/simple/missing-package")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	sbom_service "code.gitea.io/gitea/services/packages/sbom"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageVulnerabilities(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "vulnerable-package"
+	packageVersion := "1.0.0"
+
+	upload := `{
+		"_id": "` + packageName + `",
+		"name": "` + packageName + `",
+		"dist-tags": {
+			"latest": "` + packageVersion + `"
+		},
+		"versions": {
+			"` + packageVersion + `": {
+				"name": "` + packageName + `",
+				"version": "` + packageVersion + `",
+				"license": "MIT",
+				"dist": {
+					"integrity": "sha512-yA4FJsVhetynGfOC1jFf79BuS+jrHbm0fhh+aHzCQkOaOBXKf9oBnC4a6DnLLnEsHQDRLYd00cwj8sCXpC+wIg==",
+					"shasum": "aaa7eaf852a948b0aa05afeda35b1badca155d90"
+				},
+				"dependencies": {
+					"lodash": "^4.17.10",
+					"left-pad": "1.3.0"
+				},
+				"devDependencies": {
+					"mocha": "1.0.0"
+				}
+			}
+		},
+		"_attachments": {
+			"` + packageName + `-` + packageVersion + `.tgz": {
+				"data": "H4sIAAAAAAAA/ytITM5OTE/VL4DQelnF+XkMVAYGBgZmJiYK2MRBwNDcSIHB2NTMwNDQzMwAqA7IMDUxA9LUdgg2UFpcklgEdAql5kD8ogCnhwio5lJQUMpLzE1VslJQcihOzi9I1S9JLS7RhSYIJR2QgrLUouLM/DyQGkM9Az1D3YIiqExKanFyUWZBCVQ2BKhVwQVJDKwosbQkI78IJO/tZ+LsbRykxFXLNdA+HwWjYBSMgpENACgAbtAACAAA"
+			}
+		}
+	}`
+
+	req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/npm/%s", user.Name, packageName), strings.NewReader(upload)).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusCreated)
+
+	apiURL := fmt.Sprintf("/api/v1/packages/%s/npm/%s/%s", user.Name, packageName, packageVersion)
+	webURL := fmt.Sprintf("/%s/-/packages/npm/%s/%s", user.Name, packageName, packageVersion)
+
+	t.Run("SBOM", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", apiURL+"/sbom").
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "application/vnd.cyclonedx+json", resp.Header().Get("Content-Type"))
+
+		var cyclonedx struct {
+			BOMFormat string `json:"bomFormat"`
+			Metadata  struct {
+				Component struct {
+					Name string `json:"name"`
+					PURL string `json:"purl"`
+				} `json:"component"`
+			} `json:"metadata"`
+			Components []struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+				PURL    string `json:"purl"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &cyclonedx))
+		assert.Equal(t, "CycloneDX", cyclonedx.BOMFormat)
+		assert.Equal(t, "pkg:npm/vulnerable-package@1.0.0", cyclonedx.Metadata.Component.PURL)
+		require.Len(t, cyclonedx.Components, 2)
+		assert.Equal(t, "left-pad", cyclonedx.Components[0].Name)
+		assert.Equal(t, "lodash", cyclonedx.Components[1].Name)
+		assert.Equal(t, "4.17.10", cyclonedx.Components[1].Version)
+		assert.Equal(t, "pkg:npm/lodash@4.17.10", cyclonedx.Components[1].PURL)
+
+		req = NewRequest(t, "GET", apiURL+"/sbom?format=spdx").
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var spdx struct {
+			SPDXVersion string `json:"spdxVersion"`
+			Packages    []struct {
+				Name string `json:"name"`
+			} `json:"packages"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &spdx))
+		assert.Equal(t, "SPDX-2.3", spdx.SPDXVersion)
+		assert.Len(t, spdx.Packages, 3)
+
+		req = NewRequest(t, "GET", apiURL+"/sbom?format=unknown").
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		session := loginUser(t, user.Name)
+		resp = session.MakeRequest(t, NewRequest(t, "GET", webURL+"/sbom/spdx"), http.StatusOK)
+		assert.Equal(t, "application/spdx+json", resp.Header().Get("Content-Type"))
+		assert.Contains(t, resp.Header().Get("Content-Disposition"), "vulnerable-package-1.0.0.spdx.json")
+	})
+
+	t.Run("Scan", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		database := `[
+			{
+				"id": "GHSA-test-lodash",
+				"modified": "2024-01-02T03:04:05Z",
+				"summary": "Prototype pollution in lodash",
+				"affected": [
+					{
+						"package": {"ecosystem": "npm", "name": "lodash"},
+						"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.12"}]}]
+					}
+				],
+				"database_specific": {"severity": "HIGH"}
+			},
+			{
+				"id": "GHSA-test-left-pad",
+				"modified": "2024-01-02T03:04:05Z",
+				"summary": "Not affected",
+				"affected": [
+					{
+						"package": {"ecosystem": "npm", "name": "left-pad"},
+						"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.1.0"}]}]
+					}
+				]
+			},
+			{
+				"id": "PYSEC-test",
+				"modified": "2024-01-02T03:04:05Z",
+				"affected": [
+					{
+						"package": {"ecosystem": "PyPI", "name": "lodash"},
+						"versions": ["4.17.10"]
+					}
+				]
+			}
+		]`
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "database.json")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(database))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		session := loginUser(t, "user1")
+		req := NewRequestWithBody(t, "POST", "/-/admin/packages/vulnerabilities/import", body)
+		req.Header.Add("Content-Type", writer.FormDataContentType())
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		stats, err := packages_model.GetAdvisoryStats(t.Context())
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, stats.Count)
+
+		require.NoError(t, sbom_service.ScanPackages(t.Context()))
+
+		req = NewRequest(t, "GET", apiURL+"/vulnerabilities").
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var vulnerabilities []*api.PackageVulnerability
+		DecodeJSON(t, resp, &vulnerabilities)
+		require.Len(t, vulnerabilities, 1)
+		assert.Equal(t, "GHSA-test-lodash", vulnerabilities[0].AdvisoryID)
+		assert.Equal(t, "lodash", vulnerabilities[0].DependencyName)
+		assert.Equal(t, "4.17.10", vulnerabilities[0].DependencyVersion)
+		assert.Equal(t, "4.17.12", vulnerabilities[0].FixedVersion)
+		assert.Equal(t, "HIGH", vulnerabilities[0].Severity)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", webURL), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "GHSA-test-lodash")
+
+		// a withdrawn advisory is removed and the finding disappears with the next scan
+		withdrawn := `{"id": "GHSA-test-lodash", "modified": "2024-02-02T03:04:05Z", "withdrawn": "2024-02-02T03:04:05Z"}`
+		_, err = sbom_service.ImportDatabase(t.Context(), strings.NewReader(withdrawn), int64(len(withdrawn)))
+		require.NoError(t, err)
+		require.NoError(t, sbom_service.ScanPackages(t.Context()))
+
+		req = NewRequest(t, "GET", apiURL+"/vulnerabilities").
+			AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &vulnerabilities)
+		assert.Empty(t, vulnerabilities)
+	})
+}
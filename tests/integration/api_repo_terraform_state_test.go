@@ -0,0 +1,289 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoTerraformState(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+	readToken := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+
+	rootURL := "/api/v1/repos/user2/repo1/terraform/state"
+	stateURL := rootURL + "/production"
+
+	lockInfo := func(id string) string {
+		return fmt.Sprintf(`{"ID":"%s","Operation":"OperationTypeApply","Info":"","Who":"user@host","Version":"1.9.0","Created":"2026-01-01T00:00:00Z","Path":""}`, id)
+	}
+	state := func(serial int) string {
+		return fmt.Sprintf(`{"version":4,"terraform_version":"1.9.0","serial":%d,"lineage":"5d2a3c1e","outputs":{},"resources":[]}`, serial)
+	}
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", stateURL), http.StatusUnauthorized)
+		MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("a"))), http.StatusUnauthorized)
+		MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("a"))).AddTokenAuth(readToken), http.StatusForbidden)
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(1))).AddTokenAuth(readToken), http.StatusForbidden)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(token), http.StatusNotFound)
+
+		req := NewRequestWithBody(t, "POST", stateURL, strings.NewReader("not json")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(1))).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var version api.TerraformStateVersion
+		DecodeJSON(t, resp, &version)
+		assert.EqualValues(t, 1, version.Version)
+		assert.EqualValues(t, 1, version.Serial)
+		assert.Equal(t, "5d2a3c1e", version.Lineage)
+		assert.Equal(t, "user2", version.Creator.UserName)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(token), http.StatusOK)
+		assert.Equal(t, state(1), resp.Body.String())
+
+		// Terraform sends the credentials with basic auth
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL).AddBasicAuth("user2", token), http.StatusOK)
+		assert.Equal(t, state(1), resp.Body.String())
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.TerraformState.MaxStateSize, int64(len(state(1))-1))()
+
+		req := NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(1))).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusRequestEntityTooLarge)
+	})
+
+	t.Run("ReadAccess", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// user4 can read the public repository but can't write to it
+		otherToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteRepository)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(otherToken), http.StatusOK)
+		assert.Equal(t, state(1), resp.Body.String())
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL+"/lock").AddTokenAuth(otherToken), http.StatusOK)
+		var apiState api.TerraformState
+		DecodeJSON(t, resp, &apiState)
+		assert.False(t, apiState.Locked)
+		MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions").AddTokenAuth(readToken), http.StatusOK)
+
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(2))).AddTokenAuth(otherToken), http.StatusForbidden)
+		MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("a"))).AddTokenAuth(otherToken), http.StatusForbidden)
+		MakeRequest(t, NewRequestWithBody(t, "UNLOCK", stateURL, strings.NewReader("")).AddTokenAuth(otherToken), http.StatusForbidden)
+		MakeRequest(t, NewRequest(t, "DELETE", stateURL).AddTokenAuth(otherToken), http.StatusForbidden)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader("{}")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("a"))).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var apiState api.TerraformState
+		DecodeJSON(t, resp, &apiState)
+		assert.True(t, apiState.Locked)
+		assert.Equal(t, "user2", apiState.Locker.UserName)
+
+		// locking again with the same id succeeds
+		req = NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("a"))).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("b"))).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusLocked)
+		assert.Equal(t, lockInfo("a"), resp.Body.String())
+
+		req = NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(2))).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusLocked)
+		req = NewRequestWithBody(t, "POST", stateURL+"?ID=b", strings.NewReader(state(2))).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusLocked)
+		req = NewRequestWithBody(t, "POST", stateURL+"?ID=a", strings.NewReader(state(2))).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "DELETE", stateURL).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusLocked)
+
+		req = NewRequestWithBody(t, "UNLOCK", stateURL, strings.NewReader(lockInfo("b"))).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusConflict)
+		assert.Equal(t, lockInfo("a"), resp.Body.String())
+
+		req = NewRequestWithBody(t, "UNLOCK", stateURL, strings.NewReader(lockInfo("a"))).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("b"))).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL+"/lock").AddTokenAuth(readToken), http.StatusOK)
+		apiState = api.TerraformState{}
+		DecodeJSON(t, resp, &apiState)
+		assert.True(t, apiState.Locked)
+		assert.Equal(t, lockInfo("b"), apiState.LockInfo)
+	})
+
+	t.Run("ForceUnlock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "POST", stateURL+"/lock", strings.NewReader(lockInfo("c"))).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusLocked)
+
+		req = NewRequestWithBody(t, "DELETE", stateURL+"/lock", strings.NewReader("")).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", rootURL).AddTokenAuth(token), http.StatusOK)
+		var states []*api.TerraformState
+		DecodeJSON(t, resp, &states)
+		assert.Len(t, states, 1)
+		assert.Equal(t, "production", states[0].Name)
+		assert.EqualValues(t, 2, states[0].LatestVersion)
+		assert.False(t, states[0].Locked)
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions").AddTokenAuth(token), http.StatusOK)
+		var versions []*api.TerraformStateVersion
+		DecodeJSON(t, resp, &versions)
+		assert.Len(t, versions, 2)
+		assert.EqualValues(t, 2, versions[0].Version)
+		assert.EqualValues(t, 1, versions[1].Version)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions/1").AddTokenAuth(token), http.StatusOK)
+		assert.Equal(t, state(1), resp.Body.String())
+
+		MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions/3").AddTokenAuth(token), http.StatusNotFound)
+
+		defer test.MockVariableValue(&setting.TerraformState.MaxVersions, 2)()
+
+		for serial := 3; serial <= 5; serial++ {
+			req := NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state(serial))).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusOK)
+		}
+
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions").AddTokenAuth(token), http.StatusOK)
+		DecodeJSON(t, resp, &versions)
+		assert.Len(t, versions, 2)
+		assert.EqualValues(t, 5, versions[0].Version)
+		assert.EqualValues(t, 4, versions[1].Version)
+
+		MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions/3").AddTokenAuth(token), http.StatusNotFound)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(token), http.StatusOK)
+		assert.Equal(t, state(5), resp.Body.String())
+	})
+
+	t.Run("ActionsToken", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := "/api/v1/repos/user5/repo4/terraform/state/default"
+
+		req := NewRequestWithBody(t, "LOCK", url, strings.NewReader(lockInfo("run"))).AddBasicAuth("gitea-actions", "8061e833a55f6fc0157c98b883e91fcfeeb1a71a")
+		MakeRequest(t, req, http.StatusOK)
+		req = NewRequestWithBody(t, "POST", url+"?ID=run", strings.NewReader(state(1))).AddBasicAuth("gitea-actions", "8061e833a55f6fc0157c98b883e91fcfeeb1a71a")
+		resp := MakeRequest(t, req, http.StatusOK)
+		var version api.TerraformStateVersion
+		DecodeJSON(t, resp, &version)
+		assert.Equal(t, "gitea-actions", version.Creator.UserName)
+		req = NewRequestWithBody(t, "UNLOCK", url, strings.NewReader(lockInfo("run"))).AddBasicAuth("gitea-actions", "8061e833a55f6fc0157c98b883e91fcfeeb1a71a")
+		MakeRequest(t, req, http.StatusOK)
+
+		// the token of the task can't write the states of other repositories
+		req = NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("run"))).AddTokenAuth("8061e833a55f6fc0157c98b883e91fcfeeb1a71a")
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, NewRequest(t, "DELETE", stateURL).AddTokenAuth(token), http.StatusNoContent)
+		MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(token), http.StatusNotFound)
+		MakeRequest(t, NewRequest(t, "GET", stateURL+"/versions").AddTokenAuth(token), http.StatusNotFound)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.TerraformState.Enabled, false)()
+
+		MakeRequest(t, NewRequest(t, "GET", rootURL).AddTokenAuth(token), http.StatusNotFound)
+	})
+}
+
+func TestAPIOwnerTerraformState(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	state := `{"version":4,"serial":1,"lineage":"5d2a3c1e"}`
+
+	t.Run("Organization", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		ownerToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteOrganization)
+		memberToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteOrganization)
+		otherToken := getUserToken(t, "user5", auth_model.AccessTokenScopeWriteOrganization)
+		stateURL := "/api/v1/orgs/org3/terraform/state/network"
+
+		// only the owners can write the states of the organization
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state)).AddTokenAuth(memberToken), http.StatusForbidden)
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL, strings.NewReader(state)).AddTokenAuth(ownerToken), http.StatusOK)
+
+		// the members can read them
+		resp := MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(memberToken), http.StatusOK)
+		assert.Equal(t, state, resp.Body.String())
+		MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(otherToken), http.StatusForbidden)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org3/terraform/state").AddTokenAuth(memberToken), http.StatusOK)
+		var states []*api.TerraformState
+		DecodeJSON(t, resp, &states)
+		assert.Len(t, states, 1)
+		assert.Equal(t, "network", states[0].Name)
+
+		// the states of the organization are separated from the states of its repositories
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/org3/repo3/terraform/state/network").AddTokenAuth(getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)), http.StatusNotFound)
+
+		MakeRequest(t, NewRequest(t, "DELETE", stateURL).AddTokenAuth(ownerToken), http.StatusNoContent)
+		MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(ownerToken), http.StatusNotFound)
+	})
+
+	t.Run("User", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteUser)
+		stateURL := "/api/v1/user/terraform/state/personal"
+
+		MakeRequest(t, NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(`{"ID":"a"}`)).AddTokenAuth(token), http.StatusOK)
+		MakeRequest(t, NewRequestWithBody(t, "POST", stateURL+"?ID=a", strings.NewReader(state)).AddTokenAuth(token), http.StatusOK)
+		MakeRequest(t, NewRequestWithBody(t, "UNLOCK", stateURL, strings.NewReader(`{"ID":"a"}`)).AddTokenAuth(token), http.StatusOK)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(token), http.StatusOK)
+		assert.Equal(t, state, resp.Body.String())
+
+		// the states are only visible to the user
+		MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth(getUserToken(t, "user4", auth_model.AccessTokenScopeReadUser)), http.StatusNotFound)
+
+		// the token of an Actions task has no states of its own
+		MakeRequest(t, NewRequest(t, "GET", stateURL).AddTokenAuth("8061e833a55f6fc0157c98b883e91fcfeeb1a71a"), http.StatusForbidden)
+	})
+}
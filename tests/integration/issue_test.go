@@ -115,6 +115,29 @@ func TestViewIssuesKeyword(t *testing.T) {
 	})
 }
 
+func TestViewIssuesQuery(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	session := loginUser(t, "user2")
+
+	req := NewRequest(t, "GET", repo.Link()+"/issues?"+url.Values{"q": {"label:label1 -label:orglabel4 author:user1"}}.Encode())
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	issuesSelection := getIssuesSelection(t, htmlDoc)
+	assert.Equal(t, 1, issuesSelection.Length())
+	issuesSelection.Each(func(_ int, selection *goquery.Selection) {
+		issue := getIssue(t, repo.ID, selection)
+		assert.EqualValues(t, 1, issue.ID)
+	})
+
+	req = NewRequest(t, "GET", repo.Link()+"/issues?"+url.Values{"q": {"label:unknown"}}.Encode())
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Equal(t, 0, getIssuesSelection(t, htmlDoc).Length())
+	assert.Contains(t, htmlDoc.doc.Find(".ui.error.message").Text(), `label "unknown" does not exist`)
+}
+
 func TestNoLoginViewIssue(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
@@ -34,6 +34,25 @@ func TestSearchRepo(t *testing.T) {
 	code_indexer.UpdateRepoIndexer(repo)
 
 	testSearch(t, "/user2/repo1/search?q=Description&page=1", []string{"README.md"})
+	testSearch(t, "/user2/repo1/search?q=descr.*&search_mode=regexp&page=1", []string{"README.md"})
+	testSearch(t, "/user2/repo1/search?q=second&page=1", []string{})
+
+	req := NewRequest(t, "GET", "/user2/repo1/search?q=description&page=1")
+	resp := MakeRequest(t, req, http.StatusOK)
+	assert.Equal(t, "Description", NewHTMLParser(t, resp.Body).Find(".repo-search-result mark").First().Text())
+
+	session := loginUser(t, "user2")
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/settings", map[string]string{
+		"action":                    "code_search",
+		"code_indexer_ref_patterns": "branch2\n",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	repo, err = repo_model.GetRepositoryByOwnerAndName(t.Context(), "user2", "repo1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"branch2"}, repo.CodeIndexerRefPatterns)
+
+	testSearch(t, "/user2/repo1/search?q=second+ref:branch2&page=1", []string{"README.md"})
+	testSearch(t, "/user2/repo1/search?q=second&page=1", []string{})
 
 	setting.Indexer.IncludePatterns = setting.IndexerGlobFromString("**.txt")
 	setting.Indexer.ExcludePatterns = setting.IndexerGlobFromString("**/y/**")
@@ -49,6 +68,7 @@ func TestSearchRepo(t *testing.T) {
 	testSearch(t, "/user2/glob/search?q=file3&page=1&t=match", []string{"x/b.txt", "a.txt"})
 	testSearch(t, "/user2/glob/search?q=file4&page=1&t=match", []string{"x/b.txt", "a.txt"})
 	testSearch(t, "/user2/glob/search?q=file5&page=1&t=match", []string{"x/b.txt", "a.txt"})
+	testSearch(t, "/user2/glob/search?q=file3+path:X/&page=1", []string{"x/b.txt"})
 }
 
 func testSearch(t *testing.T, url string, expected []string) {
@@ -0,0 +1,123 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+	savedsearch_service "code.gitea.io/gitea/services/savedsearch"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedSearches(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+
+	req := NewRequestWithValues(t, "POST", "/user/settings/saved_searches", map[string]string{
+		"action": "create",
+		"name":   "Label 1",
+		"type":   "issues",
+		"query":  "label:label1 author:user1",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	search := unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearch{OwnerID: 2, Name: "Label 1"})
+	assert.False(t, search.IsPull)
+	assert.EqualValues(t, 2, search.CreatorID)
+
+	t.Run("Invalid", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", "/user/settings/saved_searches", map[string]string{
+			"action": "create",
+			"name":   "Invalid",
+			"query":  "label:unknown",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearch{Name: "Invalid"})
+
+		// user4 can't create searches for org3 which is owned by user2
+		req = NewRequestWithValues(t, "POST", "/user/settings/saved_searches", map[string]string{
+			"action": "create",
+			"owner":  "org3",
+			"name":   "Invalid",
+		})
+		loginUser(t, "user4").MakeRequest(t, req, http.StatusNotFound)
+
+		// and can't change the ones of other users
+		req = NewRequestWithValues(t, "POST", "/user/settings/saved_searches", map[string]string{
+			"action": "delete",
+			"id":     strconv.FormatInt(search.ID, 10),
+		})
+		loginUser(t, "user4").MakeRequest(t, req, http.StatusNotFound)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearch{ID: search.ID})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		req := NewRequest(t, "GET", "/user/settings/saved_searches")
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		doc := NewHTMLParser(t, resp.Body)
+		assertNavbar(t, doc)
+		assert.Equal(t, "Label 1", strings.TrimSpace(doc.Find(".flex-item-title a").Text()))
+	})
+
+	t.Run("Pin", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", "/user/settings/saved_searches", map[string]string{
+			"action": "pin",
+			"id":     strconv.FormatInt(search.ID, 10),
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		req = NewRequest(t, "GET", "/issues")
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		item := NewHTMLParser(t, resp.Body).Find(".saved-searches a.item").First()
+		assert.Equal(t, "Label 1", strings.TrimSpace(item.Find("span").Text()))
+		assert.Equal(t, "1", strings.TrimSpace(item.Find("strong").Text()))
+
+		// the search is only pinned to the issue overview
+		req = NewRequest(t, "GET", "/pulls")
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.NotContains(t, NewHTMLParser(t, resp.Body).Find(".saved-searches").Text(), "Label 1")
+	})
+
+	t.Run("Digest", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", "/user/settings/saved_searches", map[string]string{
+			"action": "subscribe",
+			"id":     strconv.FormatInt(search.ID, 10),
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		su := unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearchUser{SavedSearchID: search.ID, UserID: 2, IsSubscribed: true})
+		assert.NotZero(t, su.LastNotifiedUnix)
+
+		// pretend the issues have been created after subscribing
+		_, err := db.GetEngine(t.Context()).ID(su.ID).Cols("last_notified_unix").Update(&issues_model.SavedSearchUser{LastNotifiedUnix: 1})
+		require.NoError(t, err)
+
+		unittest.AssertNotExistsBean(t, &activities_model.Notification{UserID: 2, IssueID: 1})
+		require.NoError(t, savedsearch_service.SendDigests(t.Context()))
+		unittest.AssertExistsAndLoadBean(t, &activities_model.Notification{UserID: 2, IssueID: 1})
+
+		su = unittest.AssertExistsAndLoadBean(t, &issues_model.SavedSearchUser{ID: su.ID})
+		assert.Greater(t, su.LastNotifiedUnix, timeutil.TimeStamp(1))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		req := NewRequestWithValues(t, "POST", "/user/settings/saved_searches", map[string]string{
+			"action": "delete",
+			"id":     strconv.FormatInt(search.ID, 10),
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearch{ID: search.ID})
+		unittest.AssertNotExistsBean(t, &issues_model.SavedSearchUser{SavedSearchID: search.ID})
+	})
+}
@@ -4,9 +4,12 @@
 package integration
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
 
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/tests"
@@ -425,6 +428,52 @@ func TestUserSettingsPackagesRulesAdd(t *testing.T) {
 	assertNavbar(t, doc)
 }
 
+func TestUserSettingsPackagesRemotesAdd(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	req := NewRequest(t, "GET", "/user/settings/packages/remotes/add")
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	doc := NewHTMLParser(t, resp.Body)
+
+	assertNavbar(t, doc)
+}
+
+func TestUserSettingsPackagesVirtualsAdd(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	req := NewRequest(t, "GET", "/user/settings/packages/virtuals/add")
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	doc := NewHTMLParser(t, resp.Body)
+
+	assertNavbar(t, doc)
+
+	req = NewRequestWithValues(t, "POST", "/user/settings/packages/virtuals/add", map[string]string{
+		"enabled": "on",
+		"type":    "npm",
+		"sources": "org3\nuser2\nnot-exist",
+		"action":  "save",
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertNotExistsBean(t, &packages_model.PackageVirtual{OwnerID: 2})
+
+	req = NewRequestWithValues(t, "POST", "/user/settings/packages/virtuals/add", map[string]string{
+		"enabled": "on",
+		"type":    "npm",
+		"sources": "org3\nuser2\nuser5:remote",
+		"action":  "save",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+
+	pv := unittest.AssertExistsAndLoadBean(t, &packages_model.PackageVirtual{OwnerID: 2})
+	assert.Equal(t, []*packages_model.VirtualSource{{OwnerID: 3}, {OwnerID: 5, Remote: true}}, pv.Sources)
+
+	req = NewRequest(t, "GET", fmt.Sprintf("/user/settings/packages/virtuals/%d", pv.ID))
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	assert.Equal(t, "org3\nuser5:remote", NewHTMLParser(t, resp.Body).Find("textarea[name=sources]").Text())
+}
+
 func TestUserSettingsOrganization(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
@@ -16,6 +16,7 @@ export function initRepositoryActionView() {
     actionsURL: el.getAttribute('data-actions-url'),
     locale: {
       approve: el.getAttribute('data-locale-approve'),
+      reject: el.getAttribute('data-locale-reject'),
       cancel: el.getAttribute('data-locale-cancel'),
       rerun: el.getAttribute('data-locale-rerun'),
       rerun_all: el.getAttribute('data-locale-rerun-all'),
@@ -31,6 +32,12 @@ export function initRepositoryActionView() {
       showLogSeconds: el.getAttribute('data-locale-show-log-seconds'),
       showFullScreen: el.getAttribute('data-locale-show-full-screen'),
       downloadLogs: el.getAttribute('data-locale-download-logs'),
+      summary: el.getAttribute('data-locale-runs-summary'),
+      annotationLevels: {
+        error: el.getAttribute('data-locale-annotation-error'),
+        warning: el.getAttribute('data-locale-annotation-warning'),
+        notice: el.getAttribute('data-locale-annotation-notice'),
+      },
       status: {
         unknown: el.getAttribute('data-locale-status-unknown'),
         waiting: el.getAttribute('data-locale-status-waiting'),